@@ -10,8 +10,11 @@ import (
 	email_brevo "github.com/bncunha/erp-api/src/infrastructure/email/brevo"
 	"github.com/bncunha/erp-api/src/infrastructure/logs"
 	"github.com/bncunha/erp-api/src/infrastructure/observability"
+	"github.com/bncunha/erp-api/src/infrastructure/pdf"
 	"github.com/bncunha/erp-api/src/infrastructure/persistence"
+	"github.com/bncunha/erp-api/src/infrastructure/qrcode"
 	"github.com/bncunha/erp-api/src/infrastructure/repository"
+	"github.com/bncunha/erp-api/src/infrastructure/scheduler"
 	config "github.com/bncunha/erp-api/src/main"
 )
 
@@ -43,7 +46,7 @@ func main() {
 	repository := repository.NewRepository(db)
 	repository.SetupRepositories()
 
-	ports := ports.NewPorts(bcrypt, emailBrevo)
+	ports := ports.NewPorts(bcrypt, emailBrevo, pdf.NewPdf(), qrcode.NewQrCode())
 
 	useCase := usecase.NewApplicationUseCase(repository, config, ports)
 	useCase.SetupUseCases()
@@ -51,6 +54,10 @@ func main() {
 	service := service.NewApplicationService(repository, useCase, ports)
 	service.SetupServices()
 
+	scheduler := scheduler.NewScheduler()
+	scheduler.Daily("atualizar parcelas atrasadas", 0, 5, service.ReceivableService.UpdateDelayedPayments)
+	defer scheduler.Stop()
+
 	controller := controller.NewController(service)
 	controller.SetupControllers()
 
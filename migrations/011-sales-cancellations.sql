@@ -0,0 +1,18 @@
+CREATE TABLE sales_cancellations (
+  id BIGSERIAL PRIMARY KEY,
+  sales_id BIGINT NOT NULL,
+  from_sales_version_id BIGINT NOT NULL,
+  to_sales_version_id BIGINT NOT NULL,
+  cancel_date TIMESTAMP NOT NULL,
+  reason VARCHAR(2000) NOT NULL,
+  created_by_user_id BIGINT NOT NULL,
+  tenant_id BIGINT NOT NULL,
+  created_at TIMESTAMP DEFAULT NOW() NOT NULL,
+  CONSTRAINT SalesCancellations_sales_id_fkey FOREIGN KEY (sales_id) REFERENCES sales(id),
+  CONSTRAINT SalesCancellations_from_sales_version_id_fkey FOREIGN KEY (from_sales_version_id) REFERENCES sales_versions(id),
+  CONSTRAINT SalesCancellations_to_sales_version_id_fkey FOREIGN KEY (to_sales_version_id) REFERENCES sales_versions(id),
+  CONSTRAINT SalesCancellations_created_by_user_id_fkey FOREIGN KEY (created_by_user_id) REFERENCES users(id),
+  CONSTRAINT SalesCancellations_tenant_id_fkey FOREIGN KEY (tenant_id) REFERENCES companies(id),
+  CONSTRAINT SalesCancellations_sales_id_unique UNIQUE (sales_id),
+  CONSTRAINT SalesCancellations_reason_length CHECK (char_length(reason) <= 2000)
+);
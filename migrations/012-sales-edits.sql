@@ -0,0 +1,30 @@
+ALTER TABLE sales_versions
+  ADD COLUMN customer_id BIGINT;
+
+UPDATE sales_versions sv
+SET customer_id = s.customer_id
+FROM sales s
+WHERE s.id = sv.sales_id
+  AND s.tenant_id = sv.tenant_id;
+
+ALTER TABLE sales_versions
+  ALTER COLUMN customer_id SET NOT NULL;
+
+ALTER TABLE sales_versions
+  ADD CONSTRAINT SalesVersions_customer_id_fkey FOREIGN KEY (customer_id) REFERENCES customers(id);
+
+CREATE TABLE sales_edits (
+  id BIGSERIAL PRIMARY KEY,
+  sales_id BIGINT NOT NULL,
+  from_sales_version_id BIGINT NOT NULL,
+  to_sales_version_id BIGINT NOT NULL,
+  edit_date TIMESTAMP NOT NULL,
+  created_by_user_id BIGINT NOT NULL,
+  tenant_id BIGINT NOT NULL,
+  created_at TIMESTAMP DEFAULT NOW() NOT NULL,
+  CONSTRAINT SalesEdits_sales_id_fkey FOREIGN KEY (sales_id) REFERENCES sales(id),
+  CONSTRAINT SalesEdits_from_sales_version_id_fkey FOREIGN KEY (from_sales_version_id) REFERENCES sales_versions(id),
+  CONSTRAINT SalesEdits_to_sales_version_id_fkey FOREIGN KEY (to_sales_version_id) REFERENCES sales_versions(id),
+  CONSTRAINT SalesEdits_created_by_user_id_fkey FOREIGN KEY (created_by_user_id) REFERENCES users(id),
+  CONSTRAINT SalesEdits_tenant_id_fkey FOREIGN KEY (tenant_id) REFERENCES companies(id)
+);
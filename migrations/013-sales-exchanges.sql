@@ -0,0 +1,17 @@
+CREATE TABLE sales_exchanges (
+  id BIGSERIAL PRIMARY KEY,
+  sales_id BIGINT NOT NULL,
+  from_sales_version_id BIGINT NOT NULL,
+  to_sales_version_id BIGINT NOT NULL,
+  sales_return_id BIGINT NOT NULL,
+  exchange_date TIMESTAMP NOT NULL,
+  created_by_user_id BIGINT NOT NULL,
+  tenant_id BIGINT NOT NULL,
+  created_at TIMESTAMP DEFAULT NOW() NOT NULL,
+  CONSTRAINT SalesExchanges_sales_id_fkey FOREIGN KEY (sales_id) REFERENCES sales(id),
+  CONSTRAINT SalesExchanges_from_sales_version_id_fkey FOREIGN KEY (from_sales_version_id) REFERENCES sales_versions(id),
+  CONSTRAINT SalesExchanges_to_sales_version_id_fkey FOREIGN KEY (to_sales_version_id) REFERENCES sales_versions(id),
+  CONSTRAINT SalesExchanges_sales_return_id_fkey FOREIGN KEY (sales_return_id) REFERENCES sales_returns(id),
+  CONSTRAINT SalesExchanges_created_by_user_id_fkey FOREIGN KEY (created_by_user_id) REFERENCES users(id),
+  CONSTRAINT SalesExchanges_tenant_id_fkey FOREIGN KEY (tenant_id) REFERENCES companies(id)
+);
@@ -0,0 +1,23 @@
+CREATE TABLE customer_credits (
+  id BIGSERIAL PRIMARY KEY,
+  customer_id BIGINT NOT NULL,
+  type VARCHAR(20) NOT NULL,
+  origin VARCHAR(20) NOT NULL,
+  value FLOAT NOT NULL,
+  description VARCHAR(500),
+  sales_id BIGINT,
+  sales_version_id BIGINT,
+  created_by_user_id BIGINT NOT NULL,
+  tenant_id BIGINT NOT NULL,
+  created_at TIMESTAMP DEFAULT NOW() NOT NULL,
+  CONSTRAINT CustomerCredits_customer_id_fkey FOREIGN KEY (customer_id) REFERENCES customers(id),
+  CONSTRAINT CustomerCredits_sales_id_fkey FOREIGN KEY (sales_id) REFERENCES sales(id),
+  CONSTRAINT CustomerCredits_sales_version_id_fkey FOREIGN KEY (sales_version_id) REFERENCES sales_versions(id),
+  CONSTRAINT CustomerCredits_created_by_user_id_fkey FOREIGN KEY (created_by_user_id) REFERENCES users(id),
+  CONSTRAINT CustomerCredits_tenant_id_fkey FOREIGN KEY (tenant_id) REFERENCES companies(id),
+  CONSTRAINT CustomerCredits_type_check CHECK (type IN ('CREDIT', 'DEBIT')),
+  CONSTRAINT CustomerCredits_origin_check CHECK (origin IN ('RETURN', 'SALE', 'ADJUSTMENT')),
+  CONSTRAINT CustomerCredits_value_check CHECK (value > 0)
+);
+
+CREATE INDEX customer_credits_customer_id_idx ON customer_credits (tenant_id, customer_id);
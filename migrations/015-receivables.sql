@@ -0,0 +1 @@
+CREATE INDEX payment_dates_status_due_date_idx ON payment_dates (status, due_date);
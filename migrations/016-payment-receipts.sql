@@ -0,0 +1,16 @@
+CREATE TABLE payment_receipts (
+  id BIGSERIAL PRIMARY KEY,
+  payment_date_id BIGINT NOT NULL,
+  value FLOAT NOT NULL,
+  receipt_date DATE NOT NULL,
+  payment_type VARCHAR(50) NOT NULL,
+  received_by_user_id BIGINT NOT NULL,
+  tenant_id BIGINT NOT NULL,
+  created_at TIMESTAMP DEFAULT NOW() NOT NULL,
+  CONSTRAINT PaymentReceipts_payment_date_id_fkey FOREIGN KEY (payment_date_id) REFERENCES payment_dates(id),
+  CONSTRAINT PaymentReceipts_received_by_user_id_fkey FOREIGN KEY (received_by_user_id) REFERENCES users(id),
+  CONSTRAINT PaymentReceipts_tenant_id_fkey FOREIGN KEY (tenant_id) REFERENCES companies(id),
+  CONSTRAINT PaymentReceipts_value_check CHECK (value > 0)
+);
+
+CREATE INDEX payment_receipts_payment_date_id_idx ON payment_receipts (payment_date_id);
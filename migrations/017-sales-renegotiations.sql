@@ -0,0 +1,25 @@
+CREATE TABLE sales_renegotiations (
+  id BIGSERIAL PRIMARY KEY,
+  sales_id BIGINT NOT NULL,
+  from_sales_version_id BIGINT NOT NULL,
+  to_sales_version_id BIGINT NOT NULL,
+  renegotiation_date TIMESTAMP NOT NULL,
+  installments INT NOT NULL,
+  first_due_date DATE NOT NULL,
+  open_value FLOAT NOT NULL,
+  interest_percentage FLOAT NOT NULL DEFAULT 0,
+  discount_percentage FLOAT NOT NULL DEFAULT 0,
+  total_value FLOAT NOT NULL,
+  created_by_user_id BIGINT NOT NULL,
+  tenant_id BIGINT NOT NULL,
+  created_at TIMESTAMP DEFAULT NOW() NOT NULL,
+  CONSTRAINT SalesRenegotiations_sales_id_fkey FOREIGN KEY (sales_id) REFERENCES sales(id),
+  CONSTRAINT SalesRenegotiations_from_sales_version_id_fkey FOREIGN KEY (from_sales_version_id) REFERENCES sales_versions(id),
+  CONSTRAINT SalesRenegotiations_to_sales_version_id_fkey FOREIGN KEY (to_sales_version_id) REFERENCES sales_versions(id),
+  CONSTRAINT SalesRenegotiations_created_by_user_id_fkey FOREIGN KEY (created_by_user_id) REFERENCES users(id),
+  CONSTRAINT SalesRenegotiations_tenant_id_fkey FOREIGN KEY (tenant_id) REFERENCES companies(id),
+  CONSTRAINT SalesRenegotiations_installments_check CHECK (installments > 0),
+  CONSTRAINT SalesRenegotiations_open_value_check CHECK (open_value > 0)
+);
+
+CREATE INDEX sales_renegotiations_sales_id_idx ON sales_renegotiations (tenant_id, sales_id);
@@ -0,0 +1,16 @@
+CREATE TABLE late_fee_rules (
+  id BIGSERIAL PRIMARY KEY,
+  fine_percentage FLOAT NOT NULL DEFAULT 0,
+  daily_interest_percentage FLOAT NOT NULL DEFAULT 0,
+  tenant_id BIGINT NOT NULL,
+  created_at TIMESTAMP DEFAULT NOW() NOT NULL,
+  updated_at TIMESTAMP DEFAULT NOW() NOT NULL,
+  CONSTRAINT LateFeeRules_tenant_id_fkey FOREIGN KEY (tenant_id) REFERENCES companies(id),
+  CONSTRAINT LateFeeRules_tenant_id_unique UNIQUE (tenant_id),
+  CONSTRAINT LateFeeRules_fine_percentage_check CHECK (fine_percentage >= 0 AND fine_percentage <= 100),
+  CONSTRAINT LateFeeRules_daily_interest_percentage_check CHECK (daily_interest_percentage >= 0 AND daily_interest_percentage <= 100)
+);
+
+ALTER TABLE payment_dates
+  ADD COLUMN fine_value FLOAT NOT NULL DEFAULT 0,
+  ADD COLUMN interest_value FLOAT NOT NULL DEFAULT 0;
@@ -0,0 +1,25 @@
+CREATE TABLE discount_rules (
+  id BIGSERIAL PRIMARY KEY,
+  reseller_max_percentage FLOAT NOT NULL DEFAULT 0,
+  tenant_id BIGINT NOT NULL,
+  created_at TIMESTAMP DEFAULT NOW() NOT NULL,
+  updated_at TIMESTAMP DEFAULT NOW() NOT NULL,
+  CONSTRAINT DiscountRules_tenant_id_fkey FOREIGN KEY (tenant_id) REFERENCES companies(id),
+  CONSTRAINT DiscountRules_tenant_id_unique UNIQUE (tenant_id),
+  CONSTRAINT DiscountRules_reseller_max_percentage_check CHECK (reseller_max_percentage >= 0 AND reseller_max_percentage <= 100)
+);
+
+ALTER TABLE sales
+  ADD COLUMN discount_type VARCHAR(20) NULL,
+  ADD COLUMN discount_value FLOAT NOT NULL DEFAULT 0,
+  ADD COLUMN discount_reason TEXT NULL,
+  ADD COLUMN discount_amount FLOAT NOT NULL DEFAULT 0,
+  ADD CONSTRAINT Sales_discount_type_check CHECK (discount_type IN ('PERCENTAGE', 'FIXED'));
+
+ALTER TABLE sales_items
+  ADD COLUMN discount_type VARCHAR(20) NULL,
+  ADD COLUMN discount_value FLOAT NOT NULL DEFAULT 0,
+  ADD COLUMN discount_reason TEXT NULL,
+  ADD COLUMN discount_amount FLOAT NOT NULL DEFAULT 0,
+  ADD COLUMN sale_discount_amount FLOAT NOT NULL DEFAULT 0,
+  ADD CONSTRAINT SalesItems_discount_type_check CHECK (discount_type IN ('PERCENTAGE', 'FIXED'));
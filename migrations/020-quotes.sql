@@ -0,0 +1,61 @@
+CREATE TABLE quotes (
+  id BIGSERIAL PRIMARY KEY,
+  code VARCHAR(50) NOT NULL,
+  date TIMESTAMP NOT NULL,
+  expires_at TIMESTAMP NULL,
+  status VARCHAR(20) NOT NULL DEFAULT 'OPEN',
+  reserve_stock BOOLEAN NOT NULL DEFAULT FALSE,
+  inventory_id BIGINT NOT NULL,
+  user_id BIGINT NOT NULL,
+  customer_id BIGINT NOT NULL,
+  discount_type VARCHAR(20) NULL,
+  discount_value FLOAT NOT NULL DEFAULT 0,
+  discount_reason TEXT NULL,
+  sales_id BIGINT NULL,
+  tenant_id BIGINT NOT NULL,
+  created_at TIMESTAMP DEFAULT NOW() NOT NULL,
+  updated_at TIMESTAMP DEFAULT NOW() NOT NULL,
+  CONSTRAINT Quotes_inventory_id_fkey FOREIGN KEY (inventory_id) REFERENCES inventories(id),
+  CONSTRAINT Quotes_user_id_fkey FOREIGN KEY (user_id) REFERENCES users(id),
+  CONSTRAINT Quotes_customer_id_fkey FOREIGN KEY (customer_id) REFERENCES customers(id),
+  CONSTRAINT Quotes_sales_id_fkey FOREIGN KEY (sales_id) REFERENCES sales(id),
+  CONSTRAINT Quotes_tenant_id_fkey FOREIGN KEY (tenant_id) REFERENCES companies(id),
+  CONSTRAINT Quotes_status_check CHECK (status IN ('OPEN', 'CONVERTED', 'CANCELLED')),
+  CONSTRAINT Quotes_discount_type_check CHECK (discount_type IN ('PERCENTAGE', 'FIXED'))
+);
+
+CREATE INDEX quotes_tenant_status_idx ON quotes (tenant_id, status);
+
+CREATE TABLE quote_items (
+  id BIGSERIAL PRIMARY KEY,
+  quote_id BIGINT NOT NULL,
+  sku_id BIGINT NOT NULL,
+  quantity FLOAT NOT NULL,
+  unit_price FLOAT NOT NULL,
+  discount_type VARCHAR(20) NULL,
+  discount_value FLOAT NOT NULL DEFAULT 0,
+  discount_reason TEXT NULL,
+  discount_amount FLOAT NOT NULL DEFAULT 0,
+  sale_discount_amount FLOAT NOT NULL DEFAULT 0,
+  tenant_id BIGINT NOT NULL,
+  created_at TIMESTAMP DEFAULT NOW() NOT NULL,
+  CONSTRAINT QuoteItems_quote_id_fkey FOREIGN KEY (quote_id) REFERENCES quotes(id),
+  CONSTRAINT QuoteItems_sku_id_fkey FOREIGN KEY (sku_id) REFERENCES skus(id),
+  CONSTRAINT QuoteItems_tenant_id_fkey FOREIGN KEY (tenant_id) REFERENCES companies(id),
+  CONSTRAINT QuoteItems_discount_type_check CHECK (discount_type IN ('PERCENTAGE', 'FIXED'))
+);
+
+CREATE INDEX quote_items_quote_id_idx ON quote_items (quote_id);
+
+CREATE TABLE quote_payments (
+  id BIGSERIAL PRIMARY KEY,
+  quote_id BIGINT NOT NULL,
+  payment_type VARCHAR(50) NOT NULL,
+  value FLOAT NOT NULL,
+  installments_quantity INT NULL,
+  first_installment_date TIMESTAMP NULL,
+  tenant_id BIGINT NOT NULL,
+  created_at TIMESTAMP DEFAULT NOW() NOT NULL,
+  CONSTRAINT QuotePayments_quote_id_fkey FOREIGN KEY (quote_id) REFERENCES quotes(id),
+  CONSTRAINT QuotePayments_tenant_id_fkey FOREIGN KEY (tenant_id) REFERENCES companies(id)
+);
@@ -0,0 +1,51 @@
+CREATE TABLE commission_rules (
+  id BIGSERIAL PRIMARY KEY,
+  scope VARCHAR(20) NOT NULL,
+  reference_id BIGINT NOT NULL,
+  percentage FLOAT NOT NULL,
+  base VARCHAR(20) NOT NULL DEFAULT 'SALE_VALUE',
+  tenant_id BIGINT NOT NULL,
+  created_at TIMESTAMP DEFAULT NOW() NOT NULL,
+  CONSTRAINT CommissionRules_tenant_id_fkey FOREIGN KEY (tenant_id) REFERENCES companies(id),
+  CONSTRAINT CommissionRules_scope_unique UNIQUE (tenant_id, scope, reference_id),
+  CONSTRAINT CommissionRules_scope_check CHECK (scope IN ('PRODUCT', 'CATEGORY', 'RESELLER')),
+  CONSTRAINT CommissionRules_base_check CHECK (base IN ('SALE_VALUE', 'MARGIN')),
+  CONSTRAINT CommissionRules_percentage_check CHECK (percentage >= 0 AND percentage <= 100)
+);
+
+CREATE TABLE commission_statements (
+  id BIGSERIAL PRIMARY KEY,
+  user_id BIGINT NOT NULL,
+  month DATE NOT NULL,
+  status VARCHAR(20) NOT NULL,
+  total_value FLOAT NOT NULL DEFAULT 0,
+  closed_at TIMESTAMP NOT NULL,
+  paid_at TIMESTAMP NULL,
+  tenant_id BIGINT NOT NULL,
+  created_at TIMESTAMP DEFAULT NOW() NOT NULL,
+  CONSTRAINT CommissionStatements_user_id_fkey FOREIGN KEY (user_id) REFERENCES users(id),
+  CONSTRAINT CommissionStatements_tenant_id_fkey FOREIGN KEY (tenant_id) REFERENCES companies(id),
+  CONSTRAINT CommissionStatements_month_unique UNIQUE (tenant_id, user_id, month),
+  CONSTRAINT CommissionStatements_status_check CHECK (status IN ('CLOSED', 'PAID'))
+);
+
+CREATE TABLE commission_statement_entries (
+  id BIGSERIAL PRIMARY KEY,
+  commission_statement_id BIGINT NOT NULL,
+  payment_date_id BIGINT NOT NULL,
+  sales_id BIGINT NOT NULL,
+  payment_type VARCHAR(50) NOT NULL,
+  installment_number INT NOT NULL,
+  paid_date DATE NOT NULL,
+  received_value FLOAT NOT NULL,
+  percentage FLOAT NOT NULL,
+  value FLOAT NOT NULL,
+  tenant_id BIGINT NOT NULL,
+  created_at TIMESTAMP DEFAULT NOW() NOT NULL,
+  CONSTRAINT CommissionStatementEntries_statement_id_fkey FOREIGN KEY (commission_statement_id) REFERENCES commission_statements(id),
+  CONSTRAINT CommissionStatementEntries_payment_date_id_fkey FOREIGN KEY (payment_date_id) REFERENCES payment_dates(id),
+  CONSTRAINT CommissionStatementEntries_sales_id_fkey FOREIGN KEY (sales_id) REFERENCES sales(id),
+  CONSTRAINT CommissionStatementEntries_tenant_id_fkey FOREIGN KEY (tenant_id) REFERENCES companies(id)
+);
+
+CREATE INDEX commission_statement_entries_statement_id_idx ON commission_statement_entries (commission_statement_id);
@@ -0,0 +1,38 @@
+CREATE TABLE consignment_settlements (
+  id BIGSERIAL PRIMARY KEY,
+  code VARCHAR(50) NOT NULL,
+  user_id BIGINT NOT NULL,
+  inventory_id BIGINT NOT NULL,
+  period_start TIMESTAMP NULL,
+  period_end TIMESTAMP NOT NULL,
+  returned_unsold BOOLEAN NOT NULL DEFAULT FALSE,
+  amount_due FLOAT NOT NULL DEFAULT 0,
+  notes VARCHAR(2000) NULL,
+  created_by_user_id BIGINT NOT NULL,
+  tenant_id BIGINT NOT NULL,
+  created_at TIMESTAMP DEFAULT NOW() NOT NULL,
+  CONSTRAINT ConsignmentSettlements_user_id_fkey FOREIGN KEY (user_id) REFERENCES users(id),
+  CONSTRAINT ConsignmentSettlements_inventory_id_fkey FOREIGN KEY (inventory_id) REFERENCES inventories(id),
+  CONSTRAINT ConsignmentSettlements_created_by_user_id_fkey FOREIGN KEY (created_by_user_id) REFERENCES users(id),
+  CONSTRAINT ConsignmentSettlements_tenant_id_fkey FOREIGN KEY (tenant_id) REFERENCES companies(id)
+);
+
+CREATE INDEX consignment_settlements_user_id_idx ON consignment_settlements (tenant_id, user_id, period_end);
+
+CREATE TABLE consignment_settlement_items (
+  id BIGSERIAL PRIMARY KEY,
+  consignment_settlement_id BIGINT NOT NULL,
+  sku_id BIGINT NOT NULL,
+  unit_price FLOAT NOT NULL,
+  sent_quantity FLOAT NOT NULL DEFAULT 0,
+  sold_quantity FLOAT NOT NULL DEFAULT 0,
+  sold_value FLOAT NOT NULL DEFAULT 0,
+  returned_quantity FLOAT NOT NULL DEFAULT 0,
+  on_hand_quantity FLOAT NOT NULL DEFAULT 0,
+  returned_on_closing FLOAT NOT NULL DEFAULT 0,
+  tenant_id BIGINT NOT NULL,
+  created_at TIMESTAMP DEFAULT NOW() NOT NULL,
+  CONSTRAINT ConsignmentSettlementItems_settlement_id_fkey FOREIGN KEY (consignment_settlement_id) REFERENCES consignment_settlements(id),
+  CONSTRAINT ConsignmentSettlementItems_sku_id_fkey FOREIGN KEY (sku_id) REFERENCES skus(id),
+  CONSTRAINT ConsignmentSettlementItems_tenant_id_fkey FOREIGN KEY (tenant_id) REFERENCES companies(id)
+);
@@ -0,0 +1,3 @@
+ALTER TABLE payments
+  ADD COLUMN received_amount FLOAT NOT NULL DEFAULT 0,
+  ADD COLUMN change_amount FLOAT NOT NULL DEFAULT 0;
@@ -0,0 +1,11 @@
+CREATE TABLE document_templates (
+  id BIGSERIAL PRIMARY KEY,
+  type VARCHAR(30) NOT NULL,
+  content TEXT NOT NULL,
+  tenant_id BIGINT NOT NULL,
+  created_at TIMESTAMP DEFAULT NOW() NOT NULL,
+  updated_at TIMESTAMP DEFAULT NOW() NOT NULL,
+  CONSTRAINT DocumentTemplates_tenant_id_fkey FOREIGN KEY (tenant_id) REFERENCES companies(id)
+);
+
+CREATE UNIQUE INDEX document_templates_tenant_id_type_unique ON document_templates (tenant_id, type);
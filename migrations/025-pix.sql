@@ -0,0 +1,11 @@
+CREATE TABLE pix_settings (
+  id BIGSERIAL PRIMARY KEY,
+  pix_key VARCHAR(77) NOT NULL,
+  merchant_name VARCHAR(100) NOT NULL,
+  merchant_city VARCHAR(100) NOT NULL,
+  tenant_id BIGINT NOT NULL,
+  created_at TIMESTAMP DEFAULT NOW() NOT NULL,
+  updated_at TIMESTAMP DEFAULT NOW() NOT NULL,
+  CONSTRAINT PixSettings_tenant_id_fkey FOREIGN KEY (tenant_id) REFERENCES companies(id),
+  CONSTRAINT PixSettings_tenant_id_unique UNIQUE (tenant_id)
+);
@@ -0,0 +1,24 @@
+CREATE TABLE card_fee_rules (
+  id BIGSERIAL PRIMARY KEY,
+  brand VARCHAR(20) NOT NULL,
+  payment_type VARCHAR(20) NOT NULL,
+  installments INT NOT NULL,
+  fee_percentage FLOAT NOT NULL,
+  settlement_days INT NOT NULL,
+  tenant_id BIGINT NOT NULL,
+  created_at TIMESTAMP DEFAULT NOW() NOT NULL,
+  updated_at TIMESTAMP DEFAULT NOW() NOT NULL,
+  CONSTRAINT CardFeeRules_tenant_id_fkey FOREIGN KEY (tenant_id) REFERENCES companies(id),
+  CONSTRAINT CardFeeRules_unique UNIQUE (tenant_id, brand, payment_type, installments),
+  CONSTRAINT CardFeeRules_payment_type_check CHECK (payment_type IN ('CREDIT_CARD', 'DEBIT_CARD')),
+  CONSTRAINT CardFeeRules_fee_percentage_check CHECK (fee_percentage >= 0 AND fee_percentage <= 100)
+);
+
+ALTER TABLE payments
+  ADD COLUMN card_brand VARCHAR(20) NULL;
+
+ALTER TABLE payment_dates
+  ADD COLUMN card_fee_value FLOAT NOT NULL DEFAULT 0,
+  ADD COLUMN settlement_date DATE NULL;
+
+CREATE INDEX payment_dates_settlement_date_idx ON payment_dates (settlement_date) WHERE settlement_date IS NOT NULL;
@@ -0,0 +1,50 @@
+CREATE TABLE cash_register_settings (
+  id BIGSERIAL PRIMARY KEY,
+  require_open_session BOOLEAN NOT NULL DEFAULT FALSE,
+  tenant_id BIGINT NOT NULL,
+  created_at TIMESTAMP DEFAULT NOW() NOT NULL,
+  updated_at TIMESTAMP DEFAULT NOW() NOT NULL,
+  CONSTRAINT CashRegisterSettings_tenant_id_fkey FOREIGN KEY (tenant_id) REFERENCES companies(id),
+  CONSTRAINT CashRegisterSettings_tenant_id_unique UNIQUE (tenant_id)
+);
+
+CREATE TABLE cash_register_sessions (
+  id BIGSERIAL PRIMARY KEY,
+  user_id BIGINT NOT NULL,
+  status VARCHAR(20) NOT NULL,
+  opening_balance FLOAT NOT NULL DEFAULT 0,
+  expected_amount FLOAT NULL,
+  counted_amount FLOAT NULL,
+  difference FLOAT NULL,
+  notes VARCHAR(2000) NULL,
+  opened_at TIMESTAMP NOT NULL,
+  closed_at TIMESTAMP NULL,
+  tenant_id BIGINT NOT NULL,
+  CONSTRAINT CashRegisterSessions_user_id_fkey FOREIGN KEY (user_id) REFERENCES users(id),
+  CONSTRAINT CashRegisterSessions_tenant_id_fkey FOREIGN KEY (tenant_id) REFERENCES companies(id),
+  CONSTRAINT CashRegisterSessions_status_check CHECK (status IN ('OPEN', 'CLOSED'))
+);
+
+CREATE UNIQUE INDEX cash_register_sessions_open_idx ON cash_register_sessions (tenant_id, user_id) WHERE status = 'OPEN';
+
+CREATE TABLE cash_register_entries (
+  id BIGSERIAL PRIMARY KEY,
+  cash_register_session_id BIGINT NOT NULL,
+  type VARCHAR(20) NOT NULL,
+  value FLOAT NOT NULL,
+  description VARCHAR(255) NULL,
+  sales_id BIGINT NULL,
+  payment_date_id BIGINT NULL,
+  created_by_user_id BIGINT NOT NULL,
+  tenant_id BIGINT NOT NULL,
+  created_at TIMESTAMP DEFAULT NOW() NOT NULL,
+  CONSTRAINT CashRegisterEntries_session_id_fkey FOREIGN KEY (cash_register_session_id) REFERENCES cash_register_sessions(id),
+  CONSTRAINT CashRegisterEntries_sales_id_fkey FOREIGN KEY (sales_id) REFERENCES sales(id),
+  CONSTRAINT CashRegisterEntries_payment_date_id_fkey FOREIGN KEY (payment_date_id) REFERENCES payment_dates(id),
+  CONSTRAINT CashRegisterEntries_created_by_user_id_fkey FOREIGN KEY (created_by_user_id) REFERENCES users(id),
+  CONSTRAINT CashRegisterEntries_tenant_id_fkey FOREIGN KEY (tenant_id) REFERENCES companies(id),
+  CONSTRAINT CashRegisterEntries_type_check CHECK (type IN ('SALE', 'RECEIPT', 'WITHDRAWAL', 'DEPOSIT')),
+  CONSTRAINT CashRegisterEntries_value_check CHECK (value > 0)
+);
+
+CREATE INDEX cash_register_entries_session_id_idx ON cash_register_entries (cash_register_session_id);
@@ -0,0 +1,15 @@
+CREATE TABLE idempotency_keys (
+  id BIGSERIAL PRIMARY KEY,
+  key VARCHAR(255) NOT NULL,
+  scope VARCHAR(255) NOT NULL,
+  request_hash VARCHAR(64) NOT NULL,
+  status VARCHAR(20) NOT NULL,
+  response_status INT NULL,
+  response_body TEXT NULL,
+  tenant_id BIGINT NOT NULL,
+  created_at TIMESTAMP DEFAULT NOW() NOT NULL,
+  updated_at TIMESTAMP DEFAULT NOW() NOT NULL,
+  CONSTRAINT IdempotencyKeys_tenant_id_fkey FOREIGN KEY (tenant_id) REFERENCES companies(id),
+  CONSTRAINT IdempotencyKeys_unique UNIQUE (tenant_id, scope, key),
+  CONSTRAINT IdempotencyKeys_status_check CHECK (status IN ('PROCESSING', 'COMPLETED'))
+);
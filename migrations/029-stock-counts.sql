@@ -0,0 +1,31 @@
+CREATE TABLE stock_counts (
+  id BIGSERIAL PRIMARY KEY,
+  code VARCHAR(50) NOT NULL,
+  inventory_id BIGINT NOT NULL,
+  status VARCHAR(20) NOT NULL,
+  notes VARCHAR(2000) NULL,
+  opened_by_user_id BIGINT NOT NULL,
+  opened_at TIMESTAMP NOT NULL,
+  posted_at TIMESTAMP NULL,
+  tenant_id BIGINT NOT NULL,
+  CONSTRAINT StockCounts_inventory_id_fkey FOREIGN KEY (inventory_id) REFERENCES inventories(id),
+  CONSTRAINT StockCounts_opened_by_user_id_fkey FOREIGN KEY (opened_by_user_id) REFERENCES users(id),
+  CONSTRAINT StockCounts_tenant_id_fkey FOREIGN KEY (tenant_id) REFERENCES companies(id),
+  CONSTRAINT StockCounts_status_check CHECK (status IN ('OPEN', 'POSTED', 'CANCELED'))
+);
+
+CREATE UNIQUE INDEX stock_counts_open_idx ON stock_counts (tenant_id, inventory_id) WHERE status = 'OPEN';
+
+CREATE TABLE stock_count_items (
+  id BIGSERIAL PRIMARY KEY,
+  stock_count_id BIGINT NOT NULL,
+  sku_id BIGINT NOT NULL,
+  expected_quantity FLOAT NOT NULL DEFAULT 0,
+  counted_quantity FLOAT NULL,
+  tenant_id BIGINT NOT NULL,
+  updated_at TIMESTAMP DEFAULT NOW() NOT NULL,
+  CONSTRAINT StockCountItems_stock_count_id_fkey FOREIGN KEY (stock_count_id) REFERENCES stock_counts(id),
+  CONSTRAINT StockCountItems_sku_id_fkey FOREIGN KEY (sku_id) REFERENCES skus(id),
+  CONSTRAINT StockCountItems_tenant_id_fkey FOREIGN KEY (tenant_id) REFERENCES companies(id),
+  CONSTRAINT StockCountItems_unique UNIQUE (stock_count_id, sku_id)
+);
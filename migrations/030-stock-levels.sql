@@ -0,0 +1,15 @@
+CREATE TABLE stock_levels (
+  id BIGSERIAL PRIMARY KEY,
+  inventory_id BIGINT NOT NULL,
+  sku_id BIGINT NOT NULL,
+  min_quantity FLOAT NOT NULL DEFAULT 0,
+  max_quantity FLOAT NOT NULL DEFAULT 0,
+  tenant_id BIGINT NOT NULL,
+  created_at TIMESTAMP DEFAULT NOW() NOT NULL,
+  updated_at TIMESTAMP DEFAULT NOW() NOT NULL,
+  CONSTRAINT StockLevels_inventory_id_fkey FOREIGN KEY (inventory_id) REFERENCES inventories(id),
+  CONSTRAINT StockLevels_sku_id_fkey FOREIGN KEY (sku_id) REFERENCES skus(id),
+  CONSTRAINT StockLevels_tenant_id_fkey FOREIGN KEY (tenant_id) REFERENCES companies(id),
+  CONSTRAINT StockLevels_unique UNIQUE (tenant_id, inventory_id, sku_id),
+  CONSTRAINT StockLevels_quantities_check CHECK (min_quantity >= 0 AND max_quantity >= min_quantity)
+);
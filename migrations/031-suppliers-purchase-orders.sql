@@ -0,0 +1,75 @@
+CREATE TABLE suppliers (
+  id BIGSERIAL PRIMARY KEY,
+  name VARCHAR(200) NOT NULL,
+  cnpj VARCHAR(20) NULL,
+  contact_name VARCHAR(200) NULL,
+  phone_number VARCHAR(20) NULL,
+  email VARCHAR(250) NULL,
+  tenant_id BIGINT NOT NULL,
+  created_at TIMESTAMP DEFAULT NOW() NOT NULL,
+  deleted_at TIMESTAMP NULL,
+  CONSTRAINT Suppliers_tenant_id_fkey FOREIGN KEY (tenant_id) REFERENCES companies(id)
+);
+
+CREATE UNIQUE INDEX suppliers_cnpj_idx ON suppliers (tenant_id, cnpj) WHERE cnpj IS NOT NULL AND deleted_at IS NULL;
+
+CREATE TABLE purchase_orders (
+  id BIGSERIAL PRIMARY KEY,
+  code VARCHAR(50) NOT NULL,
+  supplier_id BIGINT NOT NULL,
+  status VARCHAR(30) NOT NULL,
+  expected_date DATE NULL,
+  notes VARCHAR(2000) NULL,
+  created_by_user_id BIGINT NOT NULL,
+  created_at TIMESTAMP DEFAULT NOW() NOT NULL,
+  tenant_id BIGINT NOT NULL,
+  CONSTRAINT PurchaseOrders_supplier_id_fkey FOREIGN KEY (supplier_id) REFERENCES suppliers(id),
+  CONSTRAINT PurchaseOrders_created_by_user_id_fkey FOREIGN KEY (created_by_user_id) REFERENCES users(id),
+  CONSTRAINT PurchaseOrders_tenant_id_fkey FOREIGN KEY (tenant_id) REFERENCES companies(id),
+  CONSTRAINT PurchaseOrders_status_check CHECK (status IN ('OPEN', 'PARTIALLY_RECEIVED', 'RECEIVED', 'CANCELED'))
+);
+
+CREATE TABLE purchase_order_items (
+  id BIGSERIAL PRIMARY KEY,
+  purchase_order_id BIGINT NOT NULL,
+  sku_id BIGINT NOT NULL,
+  quantity FLOAT NOT NULL,
+  received_quantity FLOAT NOT NULL DEFAULT 0,
+  unit_cost FLOAT NOT NULL,
+  tenant_id BIGINT NOT NULL,
+  CONSTRAINT PurchaseOrderItems_purchase_order_id_fkey FOREIGN KEY (purchase_order_id) REFERENCES purchase_orders(id),
+  CONSTRAINT PurchaseOrderItems_sku_id_fkey FOREIGN KEY (sku_id) REFERENCES skus(id),
+  CONSTRAINT PurchaseOrderItems_tenant_id_fkey FOREIGN KEY (tenant_id) REFERENCES companies(id),
+  CONSTRAINT PurchaseOrderItems_unique UNIQUE (purchase_order_id, sku_id),
+  CONSTRAINT PurchaseOrderItems_quantity_check CHECK (quantity > 0 AND received_quantity >= 0),
+  CONSTRAINT PurchaseOrderItems_unit_cost_check CHECK (unit_cost >= 0)
+);
+
+CREATE TABLE purchase_receipts (
+  id BIGSERIAL PRIMARY KEY,
+  purchase_order_id BIGINT NOT NULL,
+  notes VARCHAR(2000) NULL,
+  received_by_user_id BIGINT NOT NULL,
+  received_at TIMESTAMP NOT NULL,
+  tenant_id BIGINT NOT NULL,
+  CONSTRAINT PurchaseReceipts_purchase_order_id_fkey FOREIGN KEY (purchase_order_id) REFERENCES purchase_orders(id),
+  CONSTRAINT PurchaseReceipts_received_by_user_id_fkey FOREIGN KEY (received_by_user_id) REFERENCES users(id),
+  CONSTRAINT PurchaseReceipts_tenant_id_fkey FOREIGN KEY (tenant_id) REFERENCES companies(id)
+);
+
+CREATE TABLE purchase_receipt_items (
+  id BIGSERIAL PRIMARY KEY,
+  purchase_receipt_id BIGINT NOT NULL,
+  purchase_order_item_id BIGINT NOT NULL,
+  sku_id BIGINT NOT NULL,
+  quantity FLOAT NOT NULL,
+  unit_cost FLOAT NOT NULL,
+  tenant_id BIGINT NOT NULL,
+  CONSTRAINT PurchaseReceiptItems_purchase_receipt_id_fkey FOREIGN KEY (purchase_receipt_id) REFERENCES purchase_receipts(id),
+  CONSTRAINT PurchaseReceiptItems_purchase_order_item_id_fkey FOREIGN KEY (purchase_order_item_id) REFERENCES purchase_order_items(id),
+  CONSTRAINT PurchaseReceiptItems_sku_id_fkey FOREIGN KEY (sku_id) REFERENCES skus(id),
+  CONSTRAINT PurchaseReceiptItems_tenant_id_fkey FOREIGN KEY (tenant_id) REFERENCES companies(id),
+  CONSTRAINT PurchaseReceiptItems_quantity_check CHECK (quantity > 0 AND unit_cost >= 0)
+);
+
+CREATE INDEX purchase_receipt_items_sku_idx ON purchase_receipt_items (tenant_id, sku_id);
@@ -0,0 +1,3 @@
+ALTER TABLE inventory_transactions ADD COLUMN unit_cost FLOAT NULL;
+
+ALTER TABLE sales_items ADD COLUMN unit_cost FLOAT NULL;
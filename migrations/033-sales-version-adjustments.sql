@@ -0,0 +1 @@
+ALTER TABLE sales_versions ADD COLUMN adjustment_amount FLOAT NOT NULL DEFAULT 0;
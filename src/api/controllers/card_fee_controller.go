@@ -0,0 +1,50 @@
+package controller
+
+import (
+	_http "net/http"
+
+	"github.com/bncunha/erp-api/src/api/http"
+	request "github.com/bncunha/erp-api/src/api/requests"
+	"github.com/bncunha/erp-api/src/api/viewmodel"
+	helper "github.com/bncunha/erp-api/src/application/helpers"
+	"github.com/bncunha/erp-api/src/application/service"
+	"github.com/labstack/echo/v4"
+)
+
+type CardFeeController struct {
+	cardFeeService service.CardFeeService
+}
+
+func NewCardFeeController(cardFeeService service.CardFeeService) *CardFeeController {
+	return &CardFeeController{cardFeeService}
+}
+
+func (c *CardFeeController) GetRules(context echo.Context) error {
+	rules, err := c.cardFeeService.GetRules(context.Request().Context())
+	if err != nil {
+		return context.JSON(_http.StatusBadRequest, http.HandleError(err))
+	}
+	return context.JSON(_http.StatusOK, viewmodel.ToCardFeeRulesViewModel(rules))
+}
+
+func (c *CardFeeController) SaveRule(context echo.Context) error {
+	var saveCardFeeRuleRequest request.SaveCardFeeRuleRequest
+	if err := context.Bind(&saveCardFeeRuleRequest); err != nil {
+		return context.JSON(_http.StatusBadRequest, http.HandleError(err))
+	}
+
+	id, err := c.cardFeeService.SaveRule(context.Request().Context(), saveCardFeeRuleRequest)
+	if err != nil {
+		return context.JSON(_http.StatusBadRequest, http.HandleError(err))
+	}
+	return context.JSON(_http.StatusOK, id)
+}
+
+func (c *CardFeeController) DeleteRule(context echo.Context) error {
+	id := helper.ParseInt64(context.Param("id"))
+
+	if err := c.cardFeeService.DeleteRule(context.Request().Context(), id); err != nil {
+		return context.JSON(_http.StatusBadRequest, http.HandleError(err))
+	}
+	return context.JSON(_http.StatusOK, nil)
+}
@@ -0,0 +1,105 @@
+package controller
+
+import (
+	_http "net/http"
+
+	"github.com/bncunha/erp-api/src/api/http"
+	request "github.com/bncunha/erp-api/src/api/requests"
+	"github.com/bncunha/erp-api/src/api/viewmodel"
+	helper "github.com/bncunha/erp-api/src/application/helpers"
+	"github.com/bncunha/erp-api/src/application/service"
+	"github.com/labstack/echo/v4"
+)
+
+type CashRegisterController struct {
+	cashRegisterService service.CashRegisterService
+}
+
+func NewCashRegisterController(cashRegisterService service.CashRegisterService) *CashRegisterController {
+	return &CashRegisterController{cashRegisterService}
+}
+
+func (c *CashRegisterController) GetSettings(context echo.Context) error {
+	settings, err := c.cashRegisterService.GetSettings(context.Request().Context())
+	if err != nil {
+		return context.JSON(_http.StatusBadRequest, http.HandleError(err))
+	}
+	return context.JSON(_http.StatusOK, viewmodel.ToCashRegisterSettingsViewModel(settings))
+}
+
+func (c *CashRegisterController) SaveSettings(context echo.Context) error {
+	var saveCashRegisterSettingsRequest request.SaveCashRegisterSettingsRequest
+	if err := context.Bind(&saveCashRegisterSettingsRequest); err != nil {
+		return context.JSON(_http.StatusBadRequest, http.HandleError(err))
+	}
+
+	if err := c.cashRegisterService.SaveSettings(context.Request().Context(), saveCashRegisterSettingsRequest); err != nil {
+		return context.JSON(_http.StatusBadRequest, http.HandleError(err))
+	}
+	return context.JSON(_http.StatusOK, nil)
+}
+
+func (c *CashRegisterController) Open(context echo.Context) error {
+	var openCashRegisterRequest request.OpenCashRegisterRequest
+	if err := context.Bind(&openCashRegisterRequest); err != nil {
+		return context.JSON(_http.StatusBadRequest, http.HandleError(err))
+	}
+
+	id, err := c.cashRegisterService.Open(context.Request().Context(), openCashRegisterRequest)
+	if err != nil {
+		return context.JSON(_http.StatusBadRequest, http.HandleError(err))
+	}
+	return context.JSON(_http.StatusCreated, id)
+}
+
+func (c *CashRegisterController) GetCurrent(context echo.Context) error {
+	session, err := c.cashRegisterService.GetCurrent(context.Request().Context())
+	if err != nil {
+		return context.JSON(_http.StatusBadRequest, http.HandleError(err))
+	}
+	return context.JSON(_http.StatusOK, viewmodel.ToCashRegisterSessionViewModel(session))
+}
+
+func (c *CashRegisterController) GetAll(context echo.Context) error {
+	sessions, err := c.cashRegisterService.GetAll(context.Request().Context())
+	if err != nil {
+		return context.JSON(_http.StatusBadRequest, http.HandleError(err))
+	}
+	return context.JSON(_http.StatusOK, viewmodel.ToCashRegisterSessionsViewModel(sessions))
+}
+
+func (c *CashRegisterController) GetById(context echo.Context) error {
+	id := helper.ParseInt64(context.Param("id"))
+
+	session, err := c.cashRegisterService.GetById(context.Request().Context(), id)
+	if err != nil {
+		return context.JSON(_http.StatusBadRequest, http.HandleError(err))
+	}
+	return context.JSON(_http.StatusOK, viewmodel.ToCashRegisterSessionViewModel(session))
+}
+
+func (c *CashRegisterController) CreateEntry(context echo.Context) error {
+	var createCashRegisterEntryRequest request.CreateCashRegisterEntryRequest
+	if err := context.Bind(&createCashRegisterEntryRequest); err != nil {
+		return context.JSON(_http.StatusBadRequest, http.HandleError(err))
+	}
+
+	id, err := c.cashRegisterService.CreateEntry(context.Request().Context(), createCashRegisterEntryRequest)
+	if err != nil {
+		return context.JSON(_http.StatusBadRequest, http.HandleError(err))
+	}
+	return context.JSON(_http.StatusCreated, id)
+}
+
+func (c *CashRegisterController) Close(context echo.Context) error {
+	var closeCashRegisterRequest request.CloseCashRegisterRequest
+	if err := context.Bind(&closeCashRegisterRequest); err != nil {
+		return context.JSON(_http.StatusBadRequest, http.HandleError(err))
+	}
+
+	session, err := c.cashRegisterService.Close(context.Request().Context(), closeCashRegisterRequest)
+	if err != nil {
+		return context.JSON(_http.StatusBadRequest, http.HandleError(err))
+	}
+	return context.JSON(_http.StatusOK, viewmodel.ToCashRegisterSessionViewModel(session))
+}
@@ -0,0 +1,86 @@
+package controller
+
+import (
+	_http "net/http"
+
+	"github.com/bncunha/erp-api/src/api/http"
+	request "github.com/bncunha/erp-api/src/api/requests"
+	"github.com/bncunha/erp-api/src/api/viewmodel"
+	helper "github.com/bncunha/erp-api/src/application/helpers"
+	"github.com/bncunha/erp-api/src/application/service"
+	"github.com/labstack/echo/v4"
+)
+
+type CommissionController struct {
+	commissionService service.CommissionService
+}
+
+func NewCommissionController(commissionService service.CommissionService) *CommissionController {
+	return &CommissionController{commissionService}
+}
+
+func (c *CommissionController) GetRules(context echo.Context) error {
+	rules, err := c.commissionService.GetRules(context.Request().Context())
+	if err != nil {
+		return context.JSON(_http.StatusBadRequest, http.HandleError(err))
+	}
+	return context.JSON(_http.StatusOK, viewmodel.ToCommissionRulesViewModel(rules))
+}
+
+func (c *CommissionController) CreateRule(context echo.Context) error {
+	var createCommissionRuleRequest request.CreateCommissionRuleRequest
+	if err := context.Bind(&createCommissionRuleRequest); err != nil {
+		return context.JSON(_http.StatusBadRequest, http.HandleError(err))
+	}
+
+	if err := c.commissionService.CreateRule(context.Request().Context(), createCommissionRuleRequest); err != nil {
+		return context.JSON(_http.StatusBadRequest, http.HandleError(err))
+	}
+	return context.JSON(_http.StatusCreated, nil)
+}
+
+func (c *CommissionController) DeleteRule(context echo.Context) error {
+	id := helper.ParseInt64(context.Param("id"))
+
+	if err := c.commissionService.DeleteRule(context.Request().Context(), id); err != nil {
+		return context.JSON(_http.StatusBadRequest, http.HandleError(err))
+	}
+	return context.JSON(_http.StatusOK, nil)
+}
+
+func (c *CommissionController) GetStatement(context echo.Context) error {
+	req := request.CommissionStatementRequest{
+		UserId: helper.ParseInt64(context.QueryParam("user_id")),
+		Month:  context.QueryParam("month"),
+	}
+
+	statement, err := c.commissionService.GetStatement(context.Request().Context(), req)
+	if err != nil {
+		return context.JSON(_http.StatusBadRequest, http.HandleError(err))
+	}
+	return context.JSON(_http.StatusOK, viewmodel.ToCommissionStatementViewModel(statement))
+}
+
+func (c *CommissionController) CloseStatement(context echo.Context) error {
+	var commissionStatementRequest request.CommissionStatementRequest
+	if err := context.Bind(&commissionStatementRequest); err != nil {
+		return context.JSON(_http.StatusBadRequest, http.HandleError(err))
+	}
+
+	if err := c.commissionService.CloseStatement(context.Request().Context(), commissionStatementRequest); err != nil {
+		return context.JSON(_http.StatusBadRequest, http.HandleError(err))
+	}
+	return context.JSON(_http.StatusOK, nil)
+}
+
+func (c *CommissionController) PayStatement(context echo.Context) error {
+	var commissionStatementRequest request.CommissionStatementRequest
+	if err := context.Bind(&commissionStatementRequest); err != nil {
+		return context.JSON(_http.StatusBadRequest, http.HandleError(err))
+	}
+
+	if err := c.commissionService.PayStatement(context.Request().Context(), commissionStatementRequest); err != nil {
+		return context.JSON(_http.StatusBadRequest, http.HandleError(err))
+	}
+	return context.JSON(_http.StatusOK, nil)
+}
@@ -0,0 +1,68 @@
+package controller
+
+import (
+	_http "net/http"
+
+	"github.com/bncunha/erp-api/src/api/http"
+	request "github.com/bncunha/erp-api/src/api/requests"
+	"github.com/bncunha/erp-api/src/api/viewmodel"
+	helper "github.com/bncunha/erp-api/src/application/helpers"
+	"github.com/bncunha/erp-api/src/application/service"
+	"github.com/labstack/echo/v4"
+)
+
+type ConsignmentSettlementController struct {
+	consignmentSettlementService service.ConsignmentSettlementService
+}
+
+func NewConsignmentSettlementController(consignmentSettlementService service.ConsignmentSettlementService) *ConsignmentSettlementController {
+	return &ConsignmentSettlementController{consignmentSettlementService}
+}
+
+func (c *ConsignmentSettlementController) Preview(context echo.Context) error {
+	req := request.ConsignmentSettlementPreviewRequest{
+		UserId: helper.ParseInt64(context.QueryParam("user_id")),
+	}
+
+	settlement, err := c.consignmentSettlementService.Preview(context.Request().Context(), req)
+	if err != nil {
+		return context.JSON(_http.StatusBadRequest, http.HandleError(err))
+	}
+	return context.JSON(_http.StatusOK, viewmodel.ToConsignmentSettlementViewModel(settlement))
+}
+
+func (c *ConsignmentSettlementController) Close(context echo.Context) error {
+	var closeConsignmentSettlementRequest request.CloseConsignmentSettlementRequest
+	if err := context.Bind(&closeConsignmentSettlementRequest); err != nil {
+		return context.JSON(_http.StatusBadRequest, http.HandleError(err))
+	}
+
+	id, err := c.consignmentSettlementService.Close(context.Request().Context(), closeConsignmentSettlementRequest)
+	if err != nil {
+		return context.JSON(_http.StatusBadRequest, http.HandleError(err))
+	}
+	return context.JSON(_http.StatusCreated, id)
+}
+
+func (c *ConsignmentSettlementController) GetAll(context echo.Context) error {
+	var req request.ListConsignmentSettlementsRequest
+	for _, userParam := range context.QueryParams()["user_id"] {
+		req.UserId = append(req.UserId, helper.ParseInt64(userParam))
+	}
+
+	settlements, err := c.consignmentSettlementService.GetAll(context.Request().Context(), req)
+	if err != nil {
+		return context.JSON(_http.StatusBadRequest, http.HandleError(err))
+	}
+	return context.JSON(_http.StatusOK, viewmodel.ToConsignmentSettlementsViewModel(settlements))
+}
+
+func (c *ConsignmentSettlementController) GetById(context echo.Context) error {
+	id := helper.ParseInt64(context.Param("id"))
+
+	settlement, err := c.consignmentSettlementService.GetById(context.Request().Context(), id)
+	if err != nil {
+		return context.JSON(_http.StatusBadRequest, http.HandleError(err))
+	}
+	return context.JSON(_http.StatusOK, viewmodel.ToConsignmentSettlementViewModel(settlement))
+}
@@ -3,19 +3,33 @@ package controller
 import "github.com/bncunha/erp-api/src/application/service"
 
 type Controller struct {
-	services            *service.ApplicationService
-	ProductController   *ProductController
-	SkuController       *SkuController
-	CategoryController  *CategoryController
-	AuthController      *AuthController
-	UserController      *UserController
-	InventoryController *InventoryController
-	SalesController     *SalesController
-	CustomerController  *CustomerController
-	CompanyController   *CompanyController
-	DashboardController *DashboardController
-	BillingController   *BillingController
-	NewsController      *NewsController
+	services                        *service.ApplicationService
+	ProductController               *ProductController
+	SkuController                   *SkuController
+	CategoryController              *CategoryController
+	AuthController                  *AuthController
+	UserController                  *UserController
+	InventoryController             *InventoryController
+	SalesController                 *SalesController
+	CustomerController              *CustomerController
+	CompanyController               *CompanyController
+	DashboardController             *DashboardController
+	BillingController               *BillingController
+	NewsController                  *NewsController
+	ReceivableController            *ReceivableController
+	LateFeeController               *LateFeeController
+	DiscountController              *DiscountController
+	QuoteController                 *QuoteController
+	CommissionController            *CommissionController
+	ConsignmentSettlementController *ConsignmentSettlementController
+	DocumentController              *DocumentController
+	PixController                   *PixController
+	CashRegisterController          *CashRegisterController
+	CardFeeController               *CardFeeController
+	StockCountController            *StockCountController
+	StockLevelController            *StockLevelController
+	SupplierController              *SupplierController
+	PurchaseOrderController         *PurchaseOrderController
 }
 
 func NewController(services *service.ApplicationService) *Controller {
@@ -30,11 +44,25 @@ func (c *Controller) SetupControllers() {
 	c.CategoryController = NewCategoryController(c.services.CategoryService)
 	c.AuthController = NewAuthController(c.services.AuthService)
 	c.UserController = NewUserController(c.services.UserService)
-	c.InventoryController = NewInventoryController(c.services.InventoryService)
-	c.SalesController = NewSalesController(c.services.SalesService)
+	c.InventoryController = NewInventoryController(c.services.InventoryService, c.services.IdempotencyService)
+	c.SalesController = NewSalesController(c.services.SalesService, c.services.IdempotencyService)
 	c.CustomerController = NewCustomerController(c.services.CustomerService)
 	c.CompanyController = NewCompanyController(c.services.CompanyService)
 	c.DashboardController = NewDashboardController(c.services.DashboardService)
 	c.BillingController = NewBillingController(c.services.BillingService)
 	c.NewsController = NewNewsController(c.services.NewsService)
+	c.ReceivableController = NewReceivableController(c.services.ReceivableService)
+	c.LateFeeController = NewLateFeeController(c.services.LateFeeService)
+	c.DiscountController = NewDiscountController(c.services.DiscountService)
+	c.QuoteController = NewQuoteController(c.services.QuoteService)
+	c.CommissionController = NewCommissionController(c.services.CommissionService)
+	c.ConsignmentSettlementController = NewConsignmentSettlementController(c.services.ConsignmentSettlementService)
+	c.DocumentController = NewDocumentController(c.services.DocumentService)
+	c.PixController = NewPixController(c.services.PixService)
+	c.CardFeeController = NewCardFeeController(c.services.CardFeeService)
+	c.CashRegisterController = NewCashRegisterController(c.services.CashRegisterService)
+	c.StockCountController = NewStockCountController(c.services.StockCountService)
+	c.StockLevelController = NewStockLevelController(c.services.StockLevelService)
+	c.SupplierController = NewSupplierController(c.services.SupplierService)
+	c.PurchaseOrderController = NewPurchaseOrderController(c.services.PurchaseOrderService)
 }
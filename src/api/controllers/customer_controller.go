@@ -74,3 +74,27 @@ func (c *CustomerController) Inactivate(context echo.Context) error {
     }
     return context.JSON(_http.StatusOK, nil)
 }
+
+func (c *CustomerController) GetCredit(context echo.Context) error {
+    id := helper.ParseInt64(context.Param("id"))
+
+    credit, err := c.customerService.GetCredit(context.Request().Context(), id)
+    if err != nil {
+        return context.JSON(_http.StatusBadRequest, http.HandleError(err))
+    }
+    return context.JSON(_http.StatusOK, viewmodel.ToCustomerCreditViewModel(credit))
+}
+
+func (c *CustomerController) AdjustCredit(context echo.Context) error {
+    var req request.CreateCustomerCreditAdjustmentRequest
+    if err := context.Bind(&req); err != nil {
+        return context.JSON(_http.StatusBadRequest, http.HandleError(errors.New("parametros invalidos")))
+    }
+
+    id := helper.ParseInt64(context.Param("id"))
+    creditId, err := c.customerService.AdjustCredit(context.Request().Context(), id, req)
+    if err != nil {
+        return context.JSON(_http.StatusBadRequest, http.HandleError(err))
+    }
+    return context.JSON(_http.StatusCreated, creditId)
+}
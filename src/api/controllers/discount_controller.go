@@ -0,0 +1,42 @@
+package controller
+
+import (
+	_http "net/http"
+
+	"github.com/bncunha/erp-api/src/api/http"
+	request "github.com/bncunha/erp-api/src/api/requests"
+	"github.com/bncunha/erp-api/src/api/viewmodel"
+	"github.com/bncunha/erp-api/src/application/service"
+	"github.com/labstack/echo/v4"
+)
+
+type DiscountController struct {
+	discountService service.DiscountService
+}
+
+func NewDiscountController(discountService service.DiscountService) *DiscountController {
+	return &DiscountController{discountService}
+}
+
+func (c *DiscountController) GetRule(context echo.Context) error {
+	rule, err := c.discountService.GetRule(context.Request().Context())
+	if err != nil {
+		return context.JSON(_http.StatusBadRequest, http.HandleError(err))
+	}
+
+	return context.JSON(_http.StatusOK, viewmodel.ToDiscountRuleViewModel(rule))
+}
+
+func (c *DiscountController) UpdateRule(context echo.Context) error {
+	var updateDiscountRuleRequest request.UpdateDiscountRuleRequest
+	if err := context.Bind(&updateDiscountRuleRequest); err != nil {
+		return context.JSON(_http.StatusBadRequest, http.HandleError(err))
+	}
+
+	err := c.discountService.UpdateRule(context.Request().Context(), updateDiscountRuleRequest)
+	if err != nil {
+		return context.JSON(_http.StatusBadRequest, http.HandleError(err))
+	}
+
+	return context.JSON(_http.StatusOK, nil)
+}
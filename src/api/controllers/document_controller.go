@@ -0,0 +1,73 @@
+package controller
+
+import (
+	_http "net/http"
+
+	"github.com/bncunha/erp-api/src/api/http"
+	request "github.com/bncunha/erp-api/src/api/requests"
+	"github.com/bncunha/erp-api/src/api/viewmodel"
+	helper "github.com/bncunha/erp-api/src/application/helpers"
+	"github.com/bncunha/erp-api/src/application/service"
+	"github.com/bncunha/erp-api/src/domain"
+	"github.com/labstack/echo/v4"
+)
+
+const pdfContentType = "application/pdf"
+
+type DocumentController struct {
+	documentService service.DocumentService
+}
+
+func NewDocumentController(documentService service.DocumentService) *DocumentController {
+	return &DocumentController{documentService}
+}
+
+func (c *DocumentController) GetSaleReceipt(context echo.Context) error {
+	id := helper.ParseInt64(context.Param("id"))
+
+	document, err := c.documentService.GetSaleReceipt(context.Request().Context(), id)
+	if err != nil {
+		return context.JSON(_http.StatusBadRequest, http.HandleError(err))
+	}
+	return context.Blob(_http.StatusOK, pdfContentType, document)
+}
+
+func (c *DocumentController) GetSaleCarne(context echo.Context) error {
+	id := helper.ParseInt64(context.Param("id"))
+
+	document, err := c.documentService.GetSaleCarne(context.Request().Context(), id)
+	if err != nil {
+		return context.JSON(_http.StatusBadRequest, http.HandleError(err))
+	}
+	return context.Blob(_http.StatusOK, pdfContentType, document)
+}
+
+func (c *DocumentController) GetTemplates(context echo.Context) error {
+	templates, err := c.documentService.GetTemplates(context.Request().Context())
+	if err != nil {
+		return context.JSON(_http.StatusBadRequest, http.HandleError(err))
+	}
+	return context.JSON(_http.StatusOK, viewmodel.ToDocumentTemplatesViewModel(templates))
+}
+
+func (c *DocumentController) SaveTemplate(context echo.Context) error {
+	var saveDocumentTemplateRequest request.SaveDocumentTemplateRequest
+	if err := context.Bind(&saveDocumentTemplateRequest); err != nil {
+		return context.JSON(_http.StatusBadRequest, http.HandleError(err))
+	}
+	saveDocumentTemplateRequest.Type = domain.DocumentTemplateType(context.Param("type"))
+
+	if err := c.documentService.SaveTemplate(context.Request().Context(), saveDocumentTemplateRequest); err != nil {
+		return context.JSON(_http.StatusBadRequest, http.HandleError(err))
+	}
+	return context.JSON(_http.StatusOK, nil)
+}
+
+func (c *DocumentController) ResetTemplate(context echo.Context) error {
+	templateType := domain.DocumentTemplateType(context.Param("type"))
+
+	if err := c.documentService.ResetTemplate(context.Request().Context(), templateType); err != nil {
+		return context.JSON(_http.StatusBadRequest, http.HandleError(err))
+	}
+	return context.JSON(_http.StatusOK, nil)
+}
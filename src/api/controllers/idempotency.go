@@ -0,0 +1,55 @@
+package controller
+
+import (
+	_http "net/http"
+
+	"github.com/bncunha/erp-api/src/api/http"
+	"github.com/bncunha/erp-api/src/application/service"
+	"github.com/bncunha/erp-api/src/domain"
+	"github.com/bncunha/erp-api/src/infrastructure/logs"
+	"github.com/labstack/echo/v4"
+)
+
+// idempotent executa handle uma única vez por Idempotency-Key. Uma nova
+// tentativa com a mesma chave recebe a resposta original e uma tentativa
+// enquanto a original ainda está em processamento recebe 409. Sem o cabeçalho,
+// handle é executado normalmente.
+func idempotent(context echo.Context, idempotencyService service.IdempotencyService, payload interface{}, handle func() (int, interface{}, error)) error {
+	key := context.Request().Header.Get(domain.IdempotencyKeyHeader)
+	if key == "" {
+		status, body, err := handle()
+		if err != nil {
+			return context.JSON(_http.StatusBadRequest, http.HandleError(err))
+		}
+		return context.JSON(status, body)
+	}
+
+	ctx := context.Request().Context()
+	scope := context.Request().Method + " " + context.Request().URL.Path
+	idempotencyKey, replay, err := idempotencyService.Begin(ctx, key, scope, payload)
+	if err != nil {
+		switch err {
+		case domain.ErrIdempotencyKeyInProgress:
+			return context.JSON(_http.StatusConflict, http.HandleError(err))
+		case domain.ErrIdempotencyKeyReused:
+			return context.JSON(_http.StatusUnprocessableEntity, http.HandleError(err))
+		}
+		return context.JSON(_http.StatusBadRequest, http.HandleError(err))
+	}
+	if replay {
+		context.Response().Header().Set("Idempotent-Replayed", "true")
+		return context.JSONBlob(idempotencyKey.ResponseStatus, idempotencyKey.ResponseBody)
+	}
+
+	status, body, err := handle()
+	if err != nil {
+		if releaseErr := idempotencyService.Release(ctx, idempotencyKey); releaseErr != nil {
+			logs.Logger.Errorf("Erro ao liberar Idempotency-Key %s: %v", key, releaseErr)
+		}
+		return context.JSON(_http.StatusBadRequest, http.HandleError(err))
+	}
+	if err := idempotencyService.Complete(ctx, idempotencyKey, status, body); err != nil {
+		logs.Logger.Errorf("Erro ao gravar resposta da Idempotency-Key %s: %v", key, err)
+	}
+	return context.JSON(status, body)
+}
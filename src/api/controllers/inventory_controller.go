@@ -12,12 +12,14 @@ import (
 )
 
 type InventoryController struct {
-	inventoryService service.InventoryService
+	inventoryService   service.InventoryService
+	idempotencyService service.IdempotencyService
 }
 
-func NewInventoryController(inventoryService service.InventoryService) *InventoryController {
+func NewInventoryController(inventoryService service.InventoryService, idempotencyService service.IdempotencyService) *InventoryController {
 	return &InventoryController{
 		inventoryService,
+		idempotencyService,
 	}
 }
 
@@ -26,7 +28,20 @@ func (c *InventoryController) DoTransaction(context echo.Context) error {
 	if err := context.Bind(&inventoryTransactionRequest); err != nil {
 		return context.JSON(_http.StatusBadRequest, http.HandleError(err))
 	}
-	err := c.inventoryService.DoTransaction(context.Request().Context(), inventoryTransactionRequest)
+	return idempotent(context, c.idempotencyService, inventoryTransactionRequest, func() (int, interface{}, error) {
+		err := c.inventoryService.DoTransaction(context.Request().Context(), inventoryTransactionRequest)
+		return _http.StatusOK, nil, err
+	})
+}
+
+func (c *InventoryController) TransferAll(context echo.Context) error {
+	var transferAllRequest request.TransferAllInventoryRequest
+	if err := context.Bind(&transferAllRequest); err != nil {
+		return context.JSON(_http.StatusBadRequest, http.HandleError(err))
+	}
+	id := helper.ParseInt64(context.Param("id"))
+
+	err := c.inventoryService.TransferAll(context.Request().Context(), id, transferAllRequest)
 	if err != nil {
 		return context.JSON(_http.StatusBadRequest, http.HandleError(err))
 	}
@@ -0,0 +1,42 @@
+package controller
+
+import (
+	_http "net/http"
+
+	"github.com/bncunha/erp-api/src/api/http"
+	request "github.com/bncunha/erp-api/src/api/requests"
+	"github.com/bncunha/erp-api/src/api/viewmodel"
+	"github.com/bncunha/erp-api/src/application/service"
+	"github.com/labstack/echo/v4"
+)
+
+type LateFeeController struct {
+	lateFeeService service.LateFeeService
+}
+
+func NewLateFeeController(lateFeeService service.LateFeeService) *LateFeeController {
+	return &LateFeeController{lateFeeService}
+}
+
+func (c *LateFeeController) GetRule(context echo.Context) error {
+	rule, err := c.lateFeeService.GetRule(context.Request().Context())
+	if err != nil {
+		return context.JSON(_http.StatusBadRequest, http.HandleError(err))
+	}
+
+	return context.JSON(_http.StatusOK, viewmodel.ToLateFeeRuleViewModel(rule))
+}
+
+func (c *LateFeeController) UpdateRule(context echo.Context) error {
+	var updateLateFeeRuleRequest request.UpdateLateFeeRuleRequest
+	if err := context.Bind(&updateLateFeeRuleRequest); err != nil {
+		return context.JSON(_http.StatusBadRequest, http.HandleError(err))
+	}
+
+	err := c.lateFeeService.UpdateRule(context.Request().Context(), updateLateFeeRuleRequest)
+	if err != nil {
+		return context.JSON(_http.StatusBadRequest, http.HandleError(err))
+	}
+
+	return context.JSON(_http.StatusOK, nil)
+}
@@ -0,0 +1,88 @@
+package controller
+
+import (
+	"io"
+	_http "net/http"
+
+	"github.com/bncunha/erp-api/src/api/http"
+	request "github.com/bncunha/erp-api/src/api/requests"
+	"github.com/bncunha/erp-api/src/api/viewmodel"
+	helper "github.com/bncunha/erp-api/src/application/helpers"
+	"github.com/bncunha/erp-api/src/application/service"
+	"github.com/labstack/echo/v4"
+)
+
+const pngContentType = "image/png"
+
+type PixController struct {
+	pixService service.PixService
+}
+
+func NewPixController(pixService service.PixService) *PixController {
+	return &PixController{pixService}
+}
+
+func (c *PixController) GetSettings(context echo.Context) error {
+	settings, err := c.pixService.GetSettings(context.Request().Context())
+	if err != nil {
+		return context.JSON(_http.StatusBadRequest, http.HandleError(err))
+	}
+	return context.JSON(_http.StatusOK, viewmodel.ToPixSettingsViewModel(settings))
+}
+
+func (c *PixController) SaveSettings(context echo.Context) error {
+	var savePixSettingsRequest request.SavePixSettingsRequest
+	if err := context.Bind(&savePixSettingsRequest); err != nil {
+		return context.JSON(_http.StatusBadRequest, http.HandleError(err))
+	}
+
+	if err := c.pixService.SaveSettings(context.Request().Context(), savePixSettingsRequest); err != nil {
+		return context.JSON(_http.StatusBadRequest, http.HandleError(err))
+	}
+	return context.JSON(_http.StatusOK, nil)
+}
+
+func (c *PixController) GetCharge(context echo.Context) error {
+	id := helper.ParseInt64(context.Param("id"))
+	paymentId := helper.ParseInt64(context.Param("payment_id"))
+
+	charge, err := c.pixService.GetCharge(context.Request().Context(), id, paymentId)
+	if err != nil {
+		return context.JSON(_http.StatusBadRequest, http.HandleError(err))
+	}
+	return context.JSON(_http.StatusOK, viewmodel.ToPixChargeViewModel(charge))
+}
+
+func (c *PixController) GetChargeQrCode(context echo.Context) error {
+	id := helper.ParseInt64(context.Param("id"))
+	paymentId := helper.ParseInt64(context.Param("payment_id"))
+
+	image, err := c.pixService.GetChargeQrCode(context.Request().Context(), id, paymentId)
+	if err != nil {
+		return context.JSON(_http.StatusBadRequest, http.HandleError(err))
+	}
+	return context.Blob(_http.StatusOK, pngContentType, image)
+}
+
+func (c *PixController) Reconcile(context echo.Context) error {
+	fileHeader, err := context.FormFile("file")
+	if err != nil {
+		return context.JSON(_http.StatusBadRequest, http.HandleError(err))
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		return context.JSON(_http.StatusBadRequest, http.HandleError(err))
+	}
+	defer file.Close()
+
+	content, err := io.ReadAll(file)
+	if err != nil {
+		return context.JSON(_http.StatusBadRequest, http.HandleError(err))
+	}
+
+	reconciliation, err := c.pixService.Reconcile(context.Request().Context(), request.ReconcilePixRequest{FileName: fileHeader.Filename, Content: content})
+	if err != nil {
+		return context.JSON(_http.StatusBadRequest, http.HandleError(err))
+	}
+	return context.JSON(_http.StatusOK, viewmodel.ToPixReconciliationViewModel(reconciliation))
+}
@@ -0,0 +1,95 @@
+package controller
+
+import (
+	_http "net/http"
+
+	"github.com/bncunha/erp-api/src/api/http"
+	request "github.com/bncunha/erp-api/src/api/requests"
+	"github.com/bncunha/erp-api/src/api/viewmodel"
+	helper "github.com/bncunha/erp-api/src/application/helpers"
+	"github.com/bncunha/erp-api/src/application/service"
+	"github.com/bncunha/erp-api/src/domain"
+	"github.com/labstack/echo/v4"
+)
+
+type PurchaseOrderController struct {
+	purchaseOrderService service.PurchaseOrderService
+}
+
+func NewPurchaseOrderController(purchaseOrderService service.PurchaseOrderService) *PurchaseOrderController {
+	return &PurchaseOrderController{purchaseOrderService}
+}
+
+func (c *PurchaseOrderController) Create(context echo.Context) error {
+	var createPurchaseOrderRequest request.CreatePurchaseOrderRequest
+	if err := context.Bind(&createPurchaseOrderRequest); err != nil {
+		return context.JSON(_http.StatusBadRequest, http.HandleError(err))
+	}
+
+	id, err := c.purchaseOrderService.Create(context.Request().Context(), createPurchaseOrderRequest)
+	if err != nil {
+		return context.JSON(_http.StatusBadRequest, http.HandleError(err))
+	}
+	return context.JSON(_http.StatusCreated, id)
+}
+
+func (c *PurchaseOrderController) GetAll(context echo.Context) error {
+	var req request.ListPurchaseOrdersRequest
+	if context.QueryParam("supplier_id") != "" {
+		supplierId := helper.ParseInt64(context.QueryParam("supplier_id"))
+		req.SupplierId = &supplierId
+	}
+	if context.QueryParam("status") != "" {
+		status := domain.PurchaseOrderStatus(context.QueryParam("status"))
+		req.Status = &status
+	}
+
+	orders, err := c.purchaseOrderService.GetAll(context.Request().Context(), req)
+	if err != nil {
+		return context.JSON(_http.StatusBadRequest, http.HandleError(err))
+	}
+	return context.JSON(_http.StatusOK, viewmodel.ToPurchaseOrdersViewModel(orders))
+}
+
+func (c *PurchaseOrderController) GetById(context echo.Context) error {
+	id := helper.ParseInt64(context.Param("id"))
+
+	order, err := c.purchaseOrderService.GetById(context.Request().Context(), id)
+	if err != nil {
+		return context.JSON(_http.StatusBadRequest, http.HandleError(err))
+	}
+	return context.JSON(_http.StatusOK, viewmodel.ToPurchaseOrderViewModel(order))
+}
+
+func (c *PurchaseOrderController) Receive(context echo.Context) error {
+	id := helper.ParseInt64(context.Param("id"))
+	var receivePurchaseOrderRequest request.ReceivePurchaseOrderRequest
+	if err := context.Bind(&receivePurchaseOrderRequest); err != nil {
+		return context.JSON(_http.StatusBadRequest, http.HandleError(err))
+	}
+
+	receiptId, err := c.purchaseOrderService.Receive(context.Request().Context(), id, receivePurchaseOrderRequest)
+	if err != nil {
+		return context.JSON(_http.StatusBadRequest, http.HandleError(err))
+	}
+	return context.JSON(_http.StatusCreated, receiptId)
+}
+
+func (c *PurchaseOrderController) Cancel(context echo.Context) error {
+	id := helper.ParseInt64(context.Param("id"))
+
+	if err := c.purchaseOrderService.Cancel(context.Request().Context(), id); err != nil {
+		return context.JSON(_http.StatusBadRequest, http.HandleError(err))
+	}
+	return context.JSON(_http.StatusOK, nil)
+}
+
+func (c *PurchaseOrderController) GetSkuPurchases(context echo.Context) error {
+	id := helper.ParseInt64(context.Param("id"))
+
+	purchases, err := c.purchaseOrderService.GetSkuPurchases(context.Request().Context(), id)
+	if err != nil {
+		return context.JSON(_http.StatusBadRequest, http.HandleError(err))
+	}
+	return context.JSON(_http.StatusOK, viewmodel.ToSkuPurchasesViewModel(purchases))
+}
@@ -0,0 +1,84 @@
+package controller
+
+import (
+	_http "net/http"
+
+	"github.com/bncunha/erp-api/src/api/http"
+	request "github.com/bncunha/erp-api/src/api/requests"
+	"github.com/bncunha/erp-api/src/api/viewmodel"
+	helper "github.com/bncunha/erp-api/src/application/helpers"
+	"github.com/bncunha/erp-api/src/application/service"
+	"github.com/bncunha/erp-api/src/domain"
+	"github.com/labstack/echo/v4"
+)
+
+type QuoteController struct {
+	quoteService service.QuoteService
+}
+
+func NewQuoteController(quoteService service.QuoteService) *QuoteController {
+	return &QuoteController{quoteService}
+}
+
+func (c *QuoteController) Create(context echo.Context) error {
+	var createQuoteRequest request.CreateQuoteRequest
+	if err := context.Bind(&createQuoteRequest); err != nil {
+		return context.JSON(_http.StatusBadRequest, http.HandleError(err))
+	}
+
+	err := c.quoteService.Create(context.Request().Context(), createQuoteRequest)
+	if err != nil {
+		return context.JSON(_http.StatusBadRequest, http.HandleError(err))
+	}
+
+	return context.JSON(_http.StatusCreated, nil)
+}
+
+func (c *QuoteController) GetAll(context echo.Context) error {
+	var req request.ListQuotesRequest
+
+	for _, customerParam := range context.QueryParams()["customer_id"] {
+		req.CustomerId = append(req.CustomerId, helper.ParseInt64(customerParam))
+	}
+	for _, userParam := range context.QueryParams()["user_id"] {
+		req.UserId = append(req.UserId, helper.ParseInt64(userParam))
+	}
+	if context.QueryParam("status") != "" {
+		status := domain.QuoteStatus(context.QueryParam("status"))
+		req.Status = &status
+	}
+
+	quotes, err := c.quoteService.GetAll(context.Request().Context(), req)
+	if err != nil {
+		return context.JSON(_http.StatusBadRequest, http.HandleError(err))
+	}
+	return context.JSON(_http.StatusOK, viewmodel.ToQuotesViewModel(quotes))
+}
+
+func (c *QuoteController) GetById(context echo.Context) error {
+	id := helper.ParseInt64(context.Param("id"))
+
+	quote, err := c.quoteService.GetById(context.Request().Context(), id)
+	if err != nil {
+		return context.JSON(_http.StatusBadRequest, http.HandleError(err))
+	}
+	return context.JSON(_http.StatusOK, viewmodel.ToQuoteByIdViewModel(quote))
+}
+
+func (c *QuoteController) Cancel(context echo.Context) error {
+	id := helper.ParseInt64(context.Param("id"))
+
+	if err := c.quoteService.Cancel(context.Request().Context(), id); err != nil {
+		return context.JSON(_http.StatusBadRequest, http.HandleError(err))
+	}
+	return context.JSON(_http.StatusOK, nil)
+}
+
+func (c *QuoteController) Convert(context echo.Context) error {
+	id := helper.ParseInt64(context.Param("id"))
+
+	if err := c.quoteService.Convert(context.Request().Context(), id); err != nil {
+		return context.JSON(_http.StatusBadRequest, http.HandleError(err))
+	}
+	return context.JSON(_http.StatusCreated, nil)
+}
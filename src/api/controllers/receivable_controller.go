@@ -0,0 +1,51 @@
+package controller
+
+import (
+	_http "net/http"
+	"time"
+
+	"github.com/bncunha/erp-api/src/api/http"
+	request "github.com/bncunha/erp-api/src/api/requests"
+	"github.com/bncunha/erp-api/src/api/viewmodel"
+	helper "github.com/bncunha/erp-api/src/application/helpers"
+	"github.com/bncunha/erp-api/src/application/service"
+	"github.com/bncunha/erp-api/src/domain"
+	"github.com/labstack/echo/v4"
+)
+
+type ReceivableController struct {
+	receivableService service.ReceivableService
+}
+
+func NewReceivableController(receivableService service.ReceivableService) *ReceivableController {
+	return &ReceivableController{receivableService}
+}
+
+func (c *ReceivableController) GetAll(context echo.Context) error {
+	var req request.ListReceivablesRequest
+
+	for _, customerParam := range context.QueryParams()["customer_id"] {
+		req.CustomerId = append(req.CustomerId, helper.ParseInt64(customerParam))
+	}
+	for _, userParam := range context.QueryParams()["user_id"] {
+		req.UserId = append(req.UserId, helper.ParseInt64(userParam))
+	}
+	if context.QueryParam("min_due_date") != "" {
+		date, _ := time.Parse(time.DateOnly, context.QueryParam("min_due_date"))
+		req.MinDueDate = &date
+	}
+	if context.QueryParam("max_due_date") != "" {
+		date, _ := time.Parse(time.DateOnly, context.QueryParam("max_due_date"))
+		req.MaxDueDate = &date
+	}
+	if context.QueryParam("payment_status") != "" {
+		status := domain.PaymentStatus(context.QueryParam("payment_status"))
+		req.PaymentStatus = &status
+	}
+
+	receivables, err := c.receivableService.GetAll(context.Request().Context(), req)
+	if err != nil {
+		return context.JSON(_http.StatusBadRequest, http.HandleError(err))
+	}
+	return context.JSON(_http.StatusOK, viewmodel.ToReceivablesViewModel(receivables))
+}
@@ -2,6 +2,7 @@ package controller
 
 import (
 	_http "net/http"
+	"strconv"
 	"time"
 
 	"github.com/bncunha/erp-api/src/api/http"
@@ -14,11 +15,12 @@ import (
 )
 
 type SalesController struct {
-	salesService service.SalesService
+	salesService       service.SalesService
+	idempotencyService service.IdempotencyService
 }
 
-func NewSalesController(salesService service.SalesService) *SalesController {
-	return &SalesController{salesService}
+func NewSalesController(salesService service.SalesService, idempotencyService service.IdempotencyService) *SalesController {
+	return &SalesController{salesService, idempotencyService}
 }
 
 func (c *SalesController) Create(context echo.Context) error {
@@ -26,12 +28,10 @@ func (c *SalesController) Create(context echo.Context) error {
 	if err := context.Bind(&salesRequeste); err != nil {
 		return context.JSON(_http.StatusBadRequest, http.HandleError(err))
 	}
-	err := c.salesService.CreateSales(context.Request().Context(), salesRequeste)
-	if err != nil {
-		return context.JSON(_http.StatusBadRequest, http.HandleError(err))
-	}
-
-	return context.JSON(_http.StatusCreated, nil)
+	return idempotent(context, c.idempotencyService, salesRequeste, func() (int, interface{}, error) {
+		err := c.salesService.CreateSales(context.Request().Context(), salesRequeste)
+		return _http.StatusCreated, nil, err
+	})
 }
 
 func (c *SalesController) GetAll(context echo.Context) error {
@@ -65,16 +65,72 @@ func (c *SalesController) GetAll(context echo.Context) error {
 
 	}
 
+	var productId, skuId, categoryId []int64
+	var paymentType []domain.PaymentType
+	var hasReturns *bool
+	var minValue, maxValue *float64
+	for _, productParam := range context.QueryParams()["product_id"] {
+		productId = append(productId, helper.ParseInt64(productParam))
+	}
+	for _, skuParam := range context.QueryParams()["sku_id"] {
+		skuId = append(skuId, helper.ParseInt64(skuParam))
+	}
+	for _, categoryParam := range context.QueryParams()["category_id"] {
+		categoryId = append(categoryId, helper.ParseInt64(categoryParam))
+	}
+	for _, paymentTypeParam := range context.QueryParams()["payment_type"] {
+		paymentType = append(paymentType, domain.PaymentType(paymentTypeParam))
+	}
+	if context.QueryParam("has_returns") != "" {
+		value := context.QueryParam("has_returns") == "true"
+		hasReturns = &value
+	}
+	if context.QueryParam("min_value") != "" {
+		value, err := helper.ParseFloat(context.QueryParam("min_value"))
+		if err != nil {
+			return context.JSON(_http.StatusBadRequest, http.HandleError(err))
+		}
+		minValue = &value
+	}
+	if context.QueryParam("max_value") != "" {
+		value, err := helper.ParseFloat(context.QueryParam("max_value"))
+		if err != nil {
+			return context.JSON(_http.StatusBadRequest, http.HandleError(err))
+		}
+		maxValue = &value
+	}
+
+	var search *string
+	if context.QueryParam("search") != "" {
+		value := context.QueryParam("search")
+		search = &value
+	}
+
 	sales, err := c.salesService.GetSales(context.Request().Context(), request.ListSalesRequest{
 		CustomerId:    customerId,
 		MinDate:       minDate,
 		MaxDate:       maxDate,
 		UserId:        userId,
 		PaymentStatus: paymentStatus,
+		ProductId:     productId,
+		SkuId:         skuId,
+		CategoryId:    categoryId,
+		PaymentType:   paymentType,
+		HasReturns:    hasReturns,
+		MinValue:      minValue,
+		MaxValue:      maxValue,
+		Search:        search,
+		Sort:          domain.SalesSort(context.QueryParam("sort")),
+		Order:         context.QueryParam("order"),
+		Page:          int(helper.ParseInt64(context.QueryParam("page"))),
+		PageSize:      int(helper.ParseInt64(context.QueryParam("page_size"))),
 	})
 	if err != nil {
 		return context.JSON(_http.StatusBadRequest, http.HandleError(err))
 	}
+	summary := sales.GetSummary()
+	context.Response().Header().Set("X-Total-Count", strconv.FormatInt(sales.Totals.TotalSales, 10))
+	context.Response().Header().Set("X-Total-Value", strconv.FormatFloat(summary.TotalValue, 'f', 2, 64))
 	return context.JSON(_http.StatusOK, viewmodel.ToSalesViewModel(sales))
 }
 
@@ -105,6 +161,34 @@ func (c *SalesController) ChangePaymentStatus(context echo.Context) error {
 	return context.JSON(_http.StatusOK, nil)
 }
 
+func (c *SalesController) CreatePaymentReceipt(context echo.Context) error {
+	id := helper.ParseInt64(context.Param("id"))
+	paymentId := helper.ParseInt64(context.Param("payment_id"))
+	var request request.CreatePaymentReceiptRequest
+	if err := context.Bind(&request); err != nil {
+		return context.JSON(_http.StatusBadRequest, http.HandleError(err))
+	}
+
+	err := c.salesService.CreatePaymentReceipt(context.Request().Context(), id, paymentId, request)
+	if err != nil {
+		return context.JSON(_http.StatusBadRequest, http.HandleError(err))
+	}
+
+	return context.JSON(_http.StatusCreated, nil)
+}
+
+func (c *SalesController) GetPaymentReceipts(context echo.Context) error {
+	id := helper.ParseInt64(context.Param("id"))
+	paymentId := helper.ParseInt64(context.Param("payment_id"))
+
+	receipts, err := c.salesService.GetPaymentReceipts(context.Request().Context(), id, paymentId)
+	if err != nil {
+		return context.JSON(_http.StatusBadRequest, http.HandleError(err))
+	}
+
+	return context.JSON(_http.StatusOK, viewmodel.ToPaymentReceiptsViewModel(receipts))
+}
+
 func (c *SalesController) CreateReturn(context echo.Context) error {
 	id := helper.ParseInt64(context.Param("id"))
 	var salesReturnRequest request.CreateSalesReturnRequest
@@ -112,10 +196,90 @@ func (c *SalesController) CreateReturn(context echo.Context) error {
 		return context.JSON(_http.StatusBadRequest, http.HandleError(err))
 	}
 
-	err := c.salesService.CreateReturn(context.Request().Context(), id, salesReturnRequest)
+	return idempotent(context, c.idempotencyService, salesReturnRequest, func() (int, interface{}, error) {
+		err := c.salesService.CreateReturn(context.Request().Context(), id, salesReturnRequest)
+		return _http.StatusCreated, nil, err
+	})
+}
+
+func (c *SalesController) Cancel(context echo.Context) error {
+	id := helper.ParseInt64(context.Param("id"))
+	var cancelSaleRequest request.CancelSaleRequest
+	if err := context.Bind(&cancelSaleRequest); err != nil {
+		return context.JSON(_http.StatusBadRequest, http.HandleError(err))
+	}
+
+	err := c.salesService.CancelSale(context.Request().Context(), id, cancelSaleRequest)
+	if err != nil {
+		return context.JSON(_http.StatusBadRequest, http.HandleError(err))
+	}
+
+	return context.JSON(_http.StatusCreated, nil)
+}
+
+func (c *SalesController) Edit(context echo.Context) error {
+	id := helper.ParseInt64(context.Param("id"))
+	var editSaleRequest request.EditSaleRequest
+	if err := context.Bind(&editSaleRequest); err != nil {
+		return context.JSON(_http.StatusBadRequest, http.HandleError(err))
+	}
+
+	err := c.salesService.EditSale(context.Request().Context(), id, editSaleRequest)
+	if err != nil {
+		return context.JSON(_http.StatusBadRequest, http.HandleError(err))
+	}
+
+	return context.JSON(_http.StatusOK, nil)
+}
+
+func (c *SalesController) Exchange(context echo.Context) error {
+	id := helper.ParseInt64(context.Param("id"))
+	var exchangeSaleRequest request.ExchangeSaleRequest
+	if err := context.Bind(&exchangeSaleRequest); err != nil {
+		return context.JSON(_http.StatusBadRequest, http.HandleError(err))
+	}
+
+	err := c.salesService.ExchangeSale(context.Request().Context(), id, exchangeSaleRequest)
+	if err != nil {
+		return context.JSON(_http.StatusBadRequest, http.HandleError(err))
+	}
+
+	return context.JSON(_http.StatusCreated, nil)
+}
+
+func (c *SalesController) GetVersions(context echo.Context) error {
+	id := helper.ParseInt64(context.Param("id"))
+
+	versions, err := c.salesService.GetVersions(context.Request().Context(), id)
+	if err != nil {
+		return context.JSON(_http.StatusBadRequest, http.HandleError(err))
+	}
+
+	return context.JSON(_http.StatusOK, viewmodel.ToSaleVersionsViewModel(versions))
+}
+
+func (c *SalesController) Renegotiate(context echo.Context) error {
+	id := helper.ParseInt64(context.Param("id"))
+	var renegotiateSaleRequest request.RenegotiateSaleRequest
+	if err := context.Bind(&renegotiateSaleRequest); err != nil {
+		return context.JSON(_http.StatusBadRequest, http.HandleError(err))
+	}
+
+	err := c.salesService.RenegotiateSale(context.Request().Context(), id, renegotiateSaleRequest)
 	if err != nil {
 		return context.JSON(_http.StatusBadRequest, http.HandleError(err))
 	}
 
 	return context.JSON(_http.StatusCreated, nil)
 }
+
+func (c *SalesController) GetRenegotiations(context echo.Context) error {
+	id := helper.ParseInt64(context.Param("id"))
+
+	renegotiations, err := c.salesService.GetRenegotiations(context.Request().Context(), id)
+	if err != nil {
+		return context.JSON(_http.StatusBadRequest, http.HandleError(err))
+	}
+
+	return context.JSON(_http.StatusOK, viewmodel.ToSaleRenegotiationsViewModel(renegotiations))
+}
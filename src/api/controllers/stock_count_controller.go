@@ -0,0 +1,94 @@
+package controller
+
+import (
+	_http "net/http"
+
+	"github.com/bncunha/erp-api/src/api/http"
+	request "github.com/bncunha/erp-api/src/api/requests"
+	"github.com/bncunha/erp-api/src/api/viewmodel"
+	helper "github.com/bncunha/erp-api/src/application/helpers"
+	"github.com/bncunha/erp-api/src/application/service"
+	"github.com/bncunha/erp-api/src/domain"
+	"github.com/labstack/echo/v4"
+)
+
+type StockCountController struct {
+	stockCountService service.StockCountService
+}
+
+func NewStockCountController(stockCountService service.StockCountService) *StockCountController {
+	return &StockCountController{stockCountService}
+}
+
+func (c *StockCountController) Open(context echo.Context) error {
+	var openStockCountRequest request.OpenStockCountRequest
+	if err := context.Bind(&openStockCountRequest); err != nil {
+		return context.JSON(_http.StatusBadRequest, http.HandleError(err))
+	}
+
+	id, err := c.stockCountService.Open(context.Request().Context(), openStockCountRequest)
+	if err != nil {
+		return context.JSON(_http.StatusBadRequest, http.HandleError(err))
+	}
+	return context.JSON(_http.StatusCreated, id)
+}
+
+func (c *StockCountController) GetAll(context echo.Context) error {
+	var req request.ListStockCountsRequest
+	if context.QueryParam("inventory_id") != "" {
+		inventoryId := helper.ParseInt64(context.QueryParam("inventory_id"))
+		req.InventoryId = &inventoryId
+	}
+	if context.QueryParam("status") != "" {
+		status := domain.StockCountStatus(context.QueryParam("status"))
+		req.Status = &status
+	}
+
+	counts, err := c.stockCountService.GetAll(context.Request().Context(), req)
+	if err != nil {
+		return context.JSON(_http.StatusBadRequest, http.HandleError(err))
+	}
+	return context.JSON(_http.StatusOK, viewmodel.ToStockCountsViewModel(counts))
+}
+
+func (c *StockCountController) GetById(context echo.Context) error {
+	id := helper.ParseInt64(context.Param("id"))
+
+	count, err := c.stockCountService.GetById(context.Request().Context(), id)
+	if err != nil {
+		return context.JSON(_http.StatusBadRequest, http.HandleError(err))
+	}
+	return context.JSON(_http.StatusOK, viewmodel.ToStockCountViewModel(count))
+}
+
+func (c *StockCountController) Count(context echo.Context) error {
+	id := helper.ParseInt64(context.Param("id"))
+	var countStockRequest request.CountStockRequest
+	if err := context.Bind(&countStockRequest); err != nil {
+		return context.JSON(_http.StatusBadRequest, http.HandleError(err))
+	}
+
+	count, err := c.stockCountService.Count(context.Request().Context(), id, countStockRequest)
+	if err != nil {
+		return context.JSON(_http.StatusBadRequest, http.HandleError(err))
+	}
+	return context.JSON(_http.StatusOK, viewmodel.ToStockCountViewModel(count))
+}
+
+func (c *StockCountController) Post(context echo.Context) error {
+	id := helper.ParseInt64(context.Param("id"))
+
+	if err := c.stockCountService.Post(context.Request().Context(), id); err != nil {
+		return context.JSON(_http.StatusBadRequest, http.HandleError(err))
+	}
+	return context.JSON(_http.StatusOK, nil)
+}
+
+func (c *StockCountController) Cancel(context echo.Context) error {
+	id := helper.ParseInt64(context.Param("id"))
+
+	if err := c.stockCountService.Cancel(context.Request().Context(), id); err != nil {
+		return context.JSON(_http.StatusBadRequest, http.HandleError(err))
+	}
+	return context.JSON(_http.StatusOK, nil)
+}
@@ -0,0 +1,78 @@
+package controller
+
+import (
+	_http "net/http"
+	"strconv"
+
+	"github.com/bncunha/erp-api/src/api/http"
+	request "github.com/bncunha/erp-api/src/api/requests"
+	"github.com/bncunha/erp-api/src/api/viewmodel"
+	helper "github.com/bncunha/erp-api/src/application/helpers"
+	"github.com/bncunha/erp-api/src/application/service"
+	"github.com/labstack/echo/v4"
+)
+
+type StockLevelController struct {
+	stockLevelService service.StockLevelService
+}
+
+func NewStockLevelController(stockLevelService service.StockLevelService) *StockLevelController {
+	return &StockLevelController{stockLevelService}
+}
+
+func (c *StockLevelController) GetLevels(context echo.Context) error {
+	var req request.ListStockLevelsRequest
+	if context.QueryParam("inventory_id") != "" {
+		inventoryId := helper.ParseInt64(context.QueryParam("inventory_id"))
+		req.InventoryId = &inventoryId
+	}
+	if context.QueryParam("sku_id") != "" {
+		skuId := helper.ParseInt64(context.QueryParam("sku_id"))
+		req.SkuId = &skuId
+	}
+
+	levels, err := c.stockLevelService.GetLevels(context.Request().Context(), req)
+	if err != nil {
+		return context.JSON(_http.StatusBadRequest, http.HandleError(err))
+	}
+	return context.JSON(_http.StatusOK, viewmodel.ToStockLevelsViewModel(levels))
+}
+
+func (c *StockLevelController) SaveLevel(context echo.Context) error {
+	var saveStockLevelRequest request.SaveStockLevelRequest
+	if err := context.Bind(&saveStockLevelRequest); err != nil {
+		return context.JSON(_http.StatusBadRequest, http.HandleError(err))
+	}
+
+	id, err := c.stockLevelService.SaveLevel(context.Request().Context(), saveStockLevelRequest)
+	if err != nil {
+		return context.JSON(_http.StatusBadRequest, http.HandleError(err))
+	}
+	return context.JSON(_http.StatusOK, id)
+}
+
+func (c *StockLevelController) DeleteLevel(context echo.Context) error {
+	id := helper.ParseInt64(context.Param("id"))
+
+	if err := c.stockLevelService.DeleteLevel(context.Request().Context(), id); err != nil {
+		return context.JSON(_http.StatusBadRequest, http.HandleError(err))
+	}
+	return context.JSON(_http.StatusOK, nil)
+}
+
+func (c *StockLevelController) GetReorderSuggestions(context echo.Context) error {
+	var req request.StockReorderRequest
+	if context.QueryParam("days") != "" {
+		days, err := strconv.Atoi(context.QueryParam("days"))
+		if err != nil {
+			return context.JSON(_http.StatusBadRequest, http.HandleError(err))
+		}
+		req.Days = days
+	}
+
+	suggestions, err := c.stockLevelService.GetReorderSuggestions(context.Request().Context(), req)
+	if err != nil {
+		return context.JSON(_http.StatusBadRequest, http.HandleError(err))
+	}
+	return context.JSON(_http.StatusOK, viewmodel.ToStockReorderSuggestionsViewModel(suggestions))
+}
@@ -0,0 +1,73 @@
+package controller
+
+import (
+	_http "net/http"
+
+	"github.com/bncunha/erp-api/src/api/http"
+	request "github.com/bncunha/erp-api/src/api/requests"
+	"github.com/bncunha/erp-api/src/api/viewmodel"
+	helper "github.com/bncunha/erp-api/src/application/helpers"
+	"github.com/bncunha/erp-api/src/application/service"
+	"github.com/labstack/echo/v4"
+)
+
+type SupplierController struct {
+	supplierService service.SupplierService
+}
+
+func NewSupplierController(supplierService service.SupplierService) *SupplierController {
+	return &SupplierController{supplierService}
+}
+
+func (c *SupplierController) Create(context echo.Context) error {
+	var saveSupplierRequest request.SaveSupplierRequest
+	if err := context.Bind(&saveSupplierRequest); err != nil {
+		return context.JSON(_http.StatusBadRequest, http.HandleError(err))
+	}
+
+	id, err := c.supplierService.Create(context.Request().Context(), saveSupplierRequest)
+	if err != nil {
+		return context.JSON(_http.StatusBadRequest, http.HandleError(err))
+	}
+	return context.JSON(_http.StatusCreated, id)
+}
+
+func (c *SupplierController) GetAll(context echo.Context) error {
+	suppliers, err := c.supplierService.GetAll(context.Request().Context())
+	if err != nil {
+		return context.JSON(_http.StatusBadRequest, http.HandleError(err))
+	}
+	return context.JSON(_http.StatusOK, viewmodel.ToSuppliersViewModel(suppliers))
+}
+
+func (c *SupplierController) GetById(context echo.Context) error {
+	id := helper.ParseInt64(context.Param("id"))
+
+	supplier, err := c.supplierService.GetById(context.Request().Context(), id)
+	if err != nil {
+		return context.JSON(_http.StatusBadRequest, http.HandleError(err))
+	}
+	return context.JSON(_http.StatusOK, viewmodel.ToSupplierViewModel(supplier))
+}
+
+func (c *SupplierController) Edit(context echo.Context) error {
+	id := helper.ParseInt64(context.Param("id"))
+	var saveSupplierRequest request.SaveSupplierRequest
+	if err := context.Bind(&saveSupplierRequest); err != nil {
+		return context.JSON(_http.StatusBadRequest, http.HandleError(err))
+	}
+
+	if err := c.supplierService.Edit(context.Request().Context(), id, saveSupplierRequest); err != nil {
+		return context.JSON(_http.StatusBadRequest, http.HandleError(err))
+	}
+	return context.JSON(_http.StatusOK, nil)
+}
+
+func (c *SupplierController) Inactivate(context echo.Context) error {
+	id := helper.ParseInt64(context.Param("id"))
+
+	if err := c.supplierService.Inactivate(context.Request().Context(), id); err != nil {
+		return context.JSON(_http.StatusBadRequest, http.HandleError(err))
+	}
+	return context.JSON(_http.StatusOK, nil)
+}
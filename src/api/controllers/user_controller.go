@@ -113,7 +113,10 @@ func (c *UserController) AcceptLegalTerms(context echo.Context) error {
 func (c *UserController) Inactivate(context echo.Context) error {
 	id := helper.ParseInt64(context.Param("id"))
 
-	err := c.userService.Inactivate(context.Request().Context(), id)
+	err := c.userService.Inactivate(context.Request().Context(), id, request.InactivateUserRequest{
+		TransferInventory:      context.QueryParam("transfer_inventory") == "true",
+		InventoryDestinationId: helper.ParseInt64(context.QueryParam("inventory_destination_id")),
+	})
 	if err != nil {
 		return context.JSON(_http.StatusBadRequest, http.HandleError(err))
 	}
@@ -0,0 +1,18 @@
+package request
+
+import (
+	"github.com/bncunha/erp-api/src/application/validator"
+	"github.com/bncunha/erp-api/src/domain"
+)
+
+type SaveCardFeeRuleRequest struct {
+	Brand          domain.CardBrand   `json:"brand" validate:"required,oneof=VISA MASTERCARD ELO AMEX HIPERCARD OTHER"`
+	PaymentType    domain.PaymentType `json:"payment_type" validate:"required,oneof=CREDIT_CARD DEBIT_CARD"`
+	Installments   int                `json:"installments" validate:"omitempty,gte=1,lte=24"`
+	FeePercentage  float64            `json:"fee_percentage" validate:"gte=0,lte=100"`
+	SettlementDays *int               `json:"settlement_days" validate:"omitempty,gte=0,lte=365"`
+}
+
+func (r *SaveCardFeeRuleRequest) Validate() error {
+	return validator.Validate(r)
+}
@@ -0,0 +1,41 @@
+package request
+
+import (
+	"github.com/bncunha/erp-api/src/application/validator"
+	"github.com/bncunha/erp-api/src/domain"
+)
+
+type SaveCashRegisterSettingsRequest struct {
+	RequireOpenSession bool `json:"require_open_session"`
+}
+
+func (r *SaveCashRegisterSettingsRequest) Validate() error {
+	return validator.Validate(r)
+}
+
+type OpenCashRegisterRequest struct {
+	OpeningBalance float64 `json:"opening_balance" validate:"gte=0"`
+}
+
+func (r *OpenCashRegisterRequest) Validate() error {
+	return validator.Validate(r)
+}
+
+type CreateCashRegisterEntryRequest struct {
+	Type        domain.CashRegisterEntryType `json:"type" validate:"required,oneof=WITHDRAWAL DEPOSIT"`
+	Value       float64                      `json:"value" validate:"required,gt=0"`
+	Description string                       `json:"description" validate:"max=255"`
+}
+
+func (r *CreateCashRegisterEntryRequest) Validate() error {
+	return validator.Validate(r)
+}
+
+type CloseCashRegisterRequest struct {
+	CountedAmount float64 `json:"counted_amount" validate:"gte=0"`
+	Notes         string  `json:"notes" validate:"max=2000"`
+}
+
+func (r *CloseCashRegisterRequest) Validate() error {
+	return validator.Validate(r)
+}
@@ -0,0 +1,26 @@
+package request
+
+import (
+	"github.com/bncunha/erp-api/src/application/validator"
+	"github.com/bncunha/erp-api/src/domain"
+)
+
+type CreateCommissionRuleRequest struct {
+	Scope       domain.CommissionScope `json:"scope" validate:"required,oneof=PRODUCT CATEGORY RESELLER"`
+	ReferenceId int64                  `json:"reference_id" validate:"required"`
+	Percentage  float64                `json:"percentage" validate:"gte=0,lte=100"`
+	Base        domain.CommissionBase  `json:"base" validate:"omitempty,oneof=SALE_VALUE MARGIN"`
+}
+
+func (r *CreateCommissionRuleRequest) Validate() error {
+	return validator.Validate(r)
+}
+
+type CommissionStatementRequest struct {
+	UserId int64  `json:"user_id" validate:"required"`
+	Month  string `json:"month" validate:"required"`
+}
+
+func (r *CommissionStatementRequest) Validate() error {
+	return validator.Validate(r)
+}
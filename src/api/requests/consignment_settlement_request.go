@@ -0,0 +1,25 @@
+package request
+
+import "github.com/bncunha/erp-api/src/application/validator"
+
+type ConsignmentSettlementPreviewRequest struct {
+	UserId int64 `json:"user_id" validate:"required"`
+}
+
+func (r *ConsignmentSettlementPreviewRequest) Validate() error {
+	return validator.Validate(r)
+}
+
+type CloseConsignmentSettlementRequest struct {
+	UserId       int64  `json:"user_id" validate:"required"`
+	ReturnUnsold bool   `json:"return_unsold"`
+	Notes        string `json:"notes"`
+}
+
+func (r *CloseConsignmentSettlementRequest) Validate() error {
+	return validator.Validate(r)
+}
+
+type ListConsignmentSettlementsRequest struct {
+	UserId []int64 `json:"user_id"`
+}
@@ -1,6 +1,9 @@
 package request
 
-import "github.com/bncunha/erp-api/src/application/validator"
+import (
+    "github.com/bncunha/erp-api/src/application/validator"
+    "github.com/bncunha/erp-api/src/domain"
+)
 
 type CreateCustomerRequest struct {
     Name      string `json:"name" validate:"required,max=200"`
@@ -20,3 +23,13 @@ type EditCustomerRequest struct {
 func (r *EditCustomerRequest) Validate() error {
     return validator.Validate(r)
 }
+
+type CreateCustomerCreditAdjustmentRequest struct {
+    Type        domain.CustomerCreditType `json:"type" validate:"required,oneof=CREDIT DEBIT"`
+    Value       float64                   `json:"value" validate:"required,gt=0"`
+    Description string                    `json:"description" validate:"required,max=500"`
+}
+
+func (r *CreateCustomerCreditAdjustmentRequest) Validate() error {
+    return validator.Validate(r)
+}
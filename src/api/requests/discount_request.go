@@ -0,0 +1,11 @@
+package request
+
+import "github.com/bncunha/erp-api/src/application/validator"
+
+type UpdateDiscountRuleRequest struct {
+	ResellerMaxPercentage float64 `json:"reseller_max_percentage" validate:"gte=0,lte=100"`
+}
+
+func (r *UpdateDiscountRuleRequest) Validate() error {
+	return validator.Validate(r)
+}
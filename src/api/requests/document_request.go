@@ -0,0 +1,15 @@
+package request
+
+import (
+	"github.com/bncunha/erp-api/src/application/validator"
+	"github.com/bncunha/erp-api/src/domain"
+)
+
+type SaveDocumentTemplateRequest struct {
+	Type    domain.DocumentTemplateType `json:"type" validate:"required,oneof=SALE_RECEIPT CARNE"`
+	Content string                      `json:"content" validate:"required"`
+}
+
+func (r *SaveDocumentTemplateRequest) Validate() error {
+	return validator.Validate(r)
+}
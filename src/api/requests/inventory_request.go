@@ -15,8 +15,9 @@ type CreateInventoryTransactionRequest struct {
 }
 
 type CreateInventoryTransactionSkusRequest struct {
-	SkuId    int64   `json:"sku_id" validate:"required"`
-	Quantity float64 `json:"quantity" validate:"required,gt=0"`
+	SkuId    int64    `json:"sku_id" validate:"required"`
+	Quantity float64  `json:"quantity" validate:"required,gt=0"`
+	UnitCost *float64 `json:"unit_cost" validate:"omitempty,gte=0"`
 }
 
 func (r *CreateInventoryTransactionRequest) Validate() error {
@@ -43,3 +44,12 @@ func (r *CreateInventoryTransactionRequest) Validate() error {
 	}
 	return nil
 }
+
+type TransferAllInventoryRequest struct {
+	InventoryDestinationId int64  `json:"inventory_destination_id"`
+	Justification          string `json:"justification" validate:"max=200"`
+}
+
+func (r *TransferAllInventoryRequest) Validate() error {
+	return validator.Validate(r)
+}
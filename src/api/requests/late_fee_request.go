@@ -0,0 +1,12 @@
+package request
+
+import "github.com/bncunha/erp-api/src/application/validator"
+
+type UpdateLateFeeRuleRequest struct {
+	FinePercentage          float64 `json:"fine_percentage" validate:"gte=0,lte=100"`
+	DailyInterestPercentage float64 `json:"daily_interest_percentage" validate:"gte=0,lte=100"`
+}
+
+func (r *UpdateLateFeeRuleRequest) Validate() error {
+	return validator.Validate(r)
+}
@@ -0,0 +1,18 @@
+package request
+
+import "github.com/bncunha/erp-api/src/application/validator"
+
+type SavePixSettingsRequest struct {
+	Key          string `json:"key" validate:"required,max=77"`
+	MerchantName string `json:"merchant_name" validate:"required,max=100"`
+	MerchantCity string `json:"merchant_city" validate:"required,max=100"`
+}
+
+func (r *SavePixSettingsRequest) Validate() error {
+	return validator.Validate(r)
+}
+
+type ReconcilePixRequest struct {
+	FileName string
+	Content  []byte
+}
@@ -0,0 +1,47 @@
+package request
+
+import (
+	"time"
+
+	"github.com/bncunha/erp-api/src/application/validator"
+	"github.com/bncunha/erp-api/src/domain"
+)
+
+type CreatePurchaseOrderRequest struct {
+	SupplierId   int64                            `json:"supplier_id" validate:"required"`
+	ExpectedDate *time.Time                       `json:"expected_date"`
+	Notes        string                           `json:"notes" validate:"max=2000"`
+	Items        []CreatePurchaseOrderItemRequest `json:"items" validate:"required,gt=0,dive"`
+}
+
+type CreatePurchaseOrderItemRequest struct {
+	SkuId    int64   `json:"sku_id" validate:"required"`
+	Quantity float64 `json:"quantity" validate:"gt=0"`
+	UnitCost float64 `json:"unit_cost" validate:"gte=0"`
+}
+
+func (r *CreatePurchaseOrderRequest) Validate() error {
+	return validator.Validate(r)
+}
+
+// ReceivePurchaseOrderRequest informa o que chegou do pedido. Sem custo
+// unitário, vale o custo combinado no pedido.
+type ReceivePurchaseOrderRequest struct {
+	Notes string                            `json:"notes" validate:"max=2000"`
+	Items []ReceivePurchaseOrderItemRequest `json:"items" validate:"required,gt=0,dive"`
+}
+
+type ReceivePurchaseOrderItemRequest struct {
+	SkuId    int64    `json:"sku_id" validate:"required"`
+	Quantity float64  `json:"quantity" validate:"gt=0"`
+	UnitCost *float64 `json:"unit_cost" validate:"omitempty,gte=0"`
+}
+
+func (r *ReceivePurchaseOrderRequest) Validate() error {
+	return validator.Validate(r)
+}
+
+type ListPurchaseOrdersRequest struct {
+	SupplierId *int64                      `json:"supplier_id"`
+	Status     *domain.PurchaseOrderStatus `json:"status"`
+}
@@ -0,0 +1,37 @@
+package request
+
+import (
+	"time"
+
+	"github.com/bncunha/erp-api/src/application/validator"
+	"github.com/bncunha/erp-api/src/domain"
+)
+
+type ListQuotesRequest struct {
+	CustomerId []int64             `json:"customer_id"`
+	UserId     []int64             `json:"user_id"`
+	Status     *domain.QuoteStatus `json:"status" validate:"omitempty,oneof=OPEN CONVERTED CANCELLED EXPIRED"`
+}
+
+func (r *ListQuotesRequest) Validate() error {
+	return validator.Validate(r)
+}
+
+type CreateQuoteRequest struct {
+	CustomerId   int64                       `json:"customer_id" validate:"required"`
+	Items        []CreateSaleRequestItems    `json:"items" validate:"required"`
+	Payments     []CreateSaleRequestPayments `json:"payments" validate:"required"`
+	Discount     *SaleDiscountRequest        `json:"discount" validate:"omitempty"`
+	ExpiresAt    *time.Time                  `json:"expires_at"`
+	ReserveStock bool                        `json:"reserve_stock"`
+}
+
+func (r *CreateQuoteRequest) Validate() error {
+	sale := CreateSaleRequest{
+		CustomerId: r.CustomerId,
+		Items:      r.Items,
+		Payments:   r.Payments,
+		Discount:   r.Discount,
+	}
+	return sale.Validate()
+}
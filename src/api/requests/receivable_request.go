@@ -0,0 +1,20 @@
+package request
+
+import (
+	"time"
+
+	"github.com/bncunha/erp-api/src/application/validator"
+	"github.com/bncunha/erp-api/src/domain"
+)
+
+type ListReceivablesRequest struct {
+	CustomerId    []int64               `json:"customer_id"`
+	UserId        []int64               `json:"user_id"`
+	MinDueDate    *time.Time            `json:"min_due_date"`
+	MaxDueDate    *time.Time            `json:"max_due_date"`
+	PaymentStatus *domain.PaymentStatus `json:"payment_status" validate:"omitempty,oneof=PENDING DELAYED"`
+}
+
+func (r *ListReceivablesRequest) Validate() error {
+	return validator.Validate(r)
+}
@@ -8,18 +8,58 @@ import (
 	"github.com/bncunha/erp-api/src/domain"
 )
 
+const DefaultSalesPageSize = 50
+
 type ListSalesRequest struct {
 	CustomerId    []int64               `json:"customer_id"`
 	MinDate       *time.Time            `json:"min_date"`
 	MaxDate       *time.Time            `json:"max_date"`
 	UserId        []int64               `json:"user_id"`
 	PaymentStatus *domain.PaymentStatus `json:"payment_status"`
+	ProductId     []int64               `json:"product_id"`
+	SkuId         []int64               `json:"sku_id"`
+	CategoryId    []int64               `json:"category_id"`
+	PaymentType   []domain.PaymentType  `json:"payment_type" validate:"omitempty,dive,oneof=CASH CREDIT_CARD DEBIT_CARD PIX CREDIT_STORE PAYMENT_RETURN"`
+	HasReturns    *bool                 `json:"has_returns"`
+	MinValue      *float64              `json:"min_value" validate:"omitempty,gte=0"`
+	MaxValue      *float64              `json:"max_value" validate:"omitempty,gte=0"`
+	Search        *string               `json:"search" validate:"omitempty,max=100"`
+	Sort          domain.SalesSort      `json:"sort" validate:"omitempty,oneof=date total customer"`
+	Order         string                `json:"order" validate:"omitempty,oneof=asc desc"`
+	Page          int                   `json:"page" validate:"gte=0"`
+	PageSize      int                   `json:"page_size" validate:"gte=0,lte=200"`
+}
+
+func (r *ListSalesRequest) Validate() error {
+	if err := validator.Validate(r); err != nil {
+		return err
+	}
+	if r.MinValue != nil && r.MaxValue != nil && *r.MinValue > *r.MaxValue {
+		return errors.New("Valor mínimo deve ser menor ou igual ao valor máximo")
+	}
+	return nil
+}
+
+// GetPage retorna a página pedida, começando em 1.
+func (r *ListSalesRequest) GetPage() int {
+	if r.Page < 1 {
+		return 1
+	}
+	return r.Page
+}
+
+func (r *ListSalesRequest) GetPageSize() int {
+	if r.PageSize < 1 {
+		return DefaultSalesPageSize
+	}
+	return r.PageSize
 }
 
 type CreateSaleRequest struct {
 	CustomerId int64                       `json:"customer_id" validate:"required"`
 	Items      []CreateSaleRequestItems    `json:"items" validate:"required"`
 	Payments   []CreateSaleRequestPayments `json:"payments" validate:"required"`
+	Discount   *SaleDiscountRequest        `json:"discount" validate:"omitempty"`
 }
 
 func (r *CreateSaleRequest) Validate() error {
@@ -27,6 +67,11 @@ func (r *CreateSaleRequest) Validate() error {
 	if err != nil {
 		return err
 	}
+	if r.Discount != nil {
+		if err = r.Discount.Validate(); err != nil {
+			return err
+		}
+	}
 	for _, item := range r.Items {
 		err = item.Validate()
 		if err != nil {
@@ -43,8 +88,9 @@ func (r *CreateSaleRequest) Validate() error {
 }
 
 type CreateSaleRequestItems struct {
-	SkuId    int64   `json:"sku_id" validate:"required"`
-	Quantity float64 `json:"quantity" validate:"required,gt=0"`
+	SkuId    int64                `json:"sku_id" validate:"required"`
+	Quantity float64              `json:"quantity" validate:"required,gt=0"`
+	Discount *SaleDiscountRequest `json:"discount" validate:"omitempty"`
 }
 
 func (i *CreateSaleRequestItems) Validate() error {
@@ -52,14 +98,39 @@ func (i *CreateSaleRequestItems) Validate() error {
 	if err != nil {
 		return err
 	}
+	if i.Discount != nil {
+		return i.Discount.Validate()
+	}
 	return nil
 }
 
+type SaleDiscountRequest struct {
+	Type   domain.DiscountType `json:"type" validate:"required,oneof=PERCENTAGE FIXED"`
+	Value  float64             `json:"value" validate:"required,gt=0"`
+	Reason string              `json:"reason" validate:"required,max=2000"`
+}
+
+func (d *SaleDiscountRequest) Validate() error {
+	if d.Type == domain.DiscountTypePercentage && d.Value > 100 {
+		return domain.ErrDiscountPercentageInvalid
+	}
+	return validator.Validate(d)
+}
+
+func (d *SaleDiscountRequest) ToDomain() domain.SalesDiscount {
+	if d == nil {
+		return domain.SalesDiscount{}
+	}
+	return domain.NewSalesDiscount(d.Type, d.Value, d.Reason)
+}
+
 type CreateSaleRequestPayments struct {
 	PaymentType          domain.PaymentType `json:"payment_type" validate:"required,oneof=CASH CREDIT_CARD DEBIT_CARD PIX CREDIT_STORE PAYMENT_RETURN"`
 	Value                float64            `json:"value" validate:"required,gt=0"`
 	InstallmentsQuantity *int               `json:"installments_quantity" validate:"omitempty,gt=0"`
 	FirstInstallmentDate *time.Time         `json:"first_installment_date"`
+	ReceivedAmount       float64            `json:"received_amount" validate:"gte=0"`
+	CardBrand            domain.CardBrand   `json:"card_brand" validate:"omitempty,oneof=VISA MASTERCARD ELO AMEX HIPERCARD OTHER"`
 }
 
 func (p *CreateSaleRequestPayments) Validate() error {
@@ -70,6 +141,12 @@ func (p *CreateSaleRequestPayments) Validate() error {
 	if p.PaymentType == domain.PaymentTypeCreditStore && p.FirstInstallmentDate == nil {
 		return errors.New("Data de primeira parcela é obrigatória para Notinha")
 	}
+	if p.ReceivedAmount > 0 && p.PaymentType != domain.PaymentTypeCash {
+		return domain.ErrReceivedAmountOnlyCash
+	}
+	if p.CardBrand != "" && !domain.IsCardPayment(p.PaymentType) {
+		return domain.ErrCardBrandOnlyCard
+	}
 	if err != nil {
 		return err
 	}
@@ -100,19 +177,99 @@ func (r *CreateSalesReturnRequest) Validate() error {
 	return nil
 }
 
-type ChangePaymentStatusRequest struct {
-	Status string    `json:"status" validate:"required,oneof=PAID CANCEL PENDING"`
-	Date   time.Time `json:"date"`
+type CancelSaleRequest struct {
+	Reason string `json:"reason" validate:"required,max=2000"`
 }
 
-func (c *ChangePaymentStatusRequest) Validate() error {
-	err := validator.Validate(c)
-	if err != nil {
+func (r *CancelSaleRequest) Validate() error {
+	return validator.Validate(r)
+}
+
+type EditSaleRequest struct {
+	CustomerId int64                       `json:"customer_id" validate:"required"`
+	Items      []CreateSaleRequestItems    `json:"items" validate:"required,gt=0"`
+	Payments   []CreateSaleRequestPayments `json:"payments"`
+}
+
+func (r *EditSaleRequest) Validate() error {
+	if err := validator.Validate(r); err != nil {
 		return err
 	}
+	for _, item := range r.Items {
+		if err := item.Validate(); err != nil {
+			return err
+		}
+		if item.Discount != nil {
+			return domain.ErrDiscountOnlyOnSale
+		}
+	}
+	for _, payment := range r.Payments {
+		if err := payment.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type ExchangeSaleRequest struct {
+	Reason        string                         `json:"reason" validate:"required,max=2000"`
+	ReturnedItems []CreateSalesReturnItemRequest `json:"returned_items" validate:"required,gt=0"`
+	Items         []CreateSaleRequestItems       `json:"items" validate:"required,gt=0"`
+	Payments      []CreateSaleRequestPayments    `json:"payments"`
+}
 
-	if c.Status == string(domain.PaymentStatusPaid) && c.Date.IsZero() {
-		return errors.New("Data de pagamento é obrigatória")
+func (r *ExchangeSaleRequest) Validate() error {
+	if err := validator.Validate(r); err != nil {
+		return err
+	}
+	for _, item := range r.ReturnedItems {
+		if err := validator.Validate(item); err != nil {
+			return err
+		}
+	}
+	for _, item := range r.Items {
+		if err := item.Validate(); err != nil {
+			return err
+		}
+		if item.Discount != nil {
+			return domain.ErrDiscountOnlyOnSale
+		}
+	}
+	for _, payment := range r.Payments {
+		if err := payment.Validate(); err != nil {
+			return err
+		}
 	}
 	return nil
 }
+
+type ChangePaymentStatusRequest struct {
+	Status      string    `json:"status" validate:"required,oneof=PAID CANCEL PENDING"`
+	Date        time.Time `json:"date"`
+	PaymentType string    `json:"payment_type" validate:"omitempty,oneof=CASH CREDIT_CARD DEBIT_CARD PIX"`
+}
+
+func (c *ChangePaymentStatusRequest) Validate() error {
+	return validator.Validate(c)
+}
+
+type CreatePaymentReceiptRequest struct {
+	Value       float64   `json:"value" validate:"required,gt=0"`
+	Date        time.Time `json:"date" validate:"required"`
+	PaymentType string    `json:"payment_type" validate:"required,oneof=CASH CREDIT_CARD DEBIT_CARD PIX"`
+}
+
+func (c *CreatePaymentReceiptRequest) Validate() error {
+	return validator.Validate(c)
+}
+
+type RenegotiateSaleRequest struct {
+	Installments       int       `json:"installments" validate:"required,gt=0,lte=48"`
+	FirstDueDate       time.Time `json:"first_due_date" validate:"required"`
+	InterestPercentage float64   `json:"interest_percentage" validate:"gte=0"`
+	DiscountPercentage float64   `json:"discount_percentage" validate:"gte=0,lt=100"`
+}
+
+func (r *RenegotiateSaleRequest) Validate() error {
+	return validator.Validate(r)
+}
@@ -0,0 +1,49 @@
+package request
+
+import (
+	"strings"
+
+	"github.com/bncunha/erp-api/src/application/errors"
+	"github.com/bncunha/erp-api/src/application/validator"
+	"github.com/bncunha/erp-api/src/domain"
+)
+
+type OpenStockCountRequest struct {
+	InventoryId int64  `json:"inventory_id" validate:"required"`
+	Notes       string `json:"notes" validate:"max=2000"`
+}
+
+func (r *OpenStockCountRequest) Validate() error {
+	return validator.Validate(r)
+}
+
+// CountStockRequest registra quantidades contadas por SKU ou pelo código de
+// barras. Com accumulate, cada quantidade é somada à já contada, como na
+// leitura item a item do leitor.
+type CountStockRequest struct {
+	Items      []CountStockItemRequest `json:"items" validate:"required,gt=0,dive"`
+	Accumulate bool                    `json:"accumulate"`
+}
+
+type CountStockItemRequest struct {
+	SkuId    int64   `json:"sku_id"`
+	Code     string  `json:"code" validate:"max=255"`
+	Quantity float64 `json:"quantity" validate:"gte=0"`
+}
+
+func (r *CountStockRequest) Validate() error {
+	if err := validator.Validate(r); err != nil {
+		return err
+	}
+	for _, item := range r.Items {
+		if item.SkuId == 0 && strings.TrimSpace(item.Code) == "" {
+			return errors.New("Informe o SKU ou o código de barras de cada item contado")
+		}
+	}
+	return nil
+}
+
+type ListStockCountsRequest struct {
+	InventoryId *int64                   `json:"inventory_id"`
+	Status      *domain.StockCountStatus `json:"status"`
+}
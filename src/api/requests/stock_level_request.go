@@ -0,0 +1,23 @@
+package request
+
+import "github.com/bncunha/erp-api/src/application/validator"
+
+type SaveStockLevelRequest struct {
+	InventoryId int64   `json:"inventory_id" validate:"required"`
+	SkuId       int64   `json:"sku_id" validate:"required"`
+	MinQuantity float64 `json:"min_quantity" validate:"gte=0"`
+	MaxQuantity float64 `json:"max_quantity" validate:"gte=0"`
+}
+
+func (r *SaveStockLevelRequest) Validate() error {
+	return validator.Validate(r)
+}
+
+type ListStockLevelsRequest struct {
+	InventoryId *int64 `json:"inventory_id"`
+	SkuId       *int64 `json:"sku_id"`
+}
+
+type StockReorderRequest struct {
+	Days int `json:"days"`
+}
@@ -0,0 +1,28 @@
+package request
+
+import (
+	"github.com/bncunha/erp-api/src/application/errors"
+	helper "github.com/bncunha/erp-api/src/application/helpers"
+	"github.com/bncunha/erp-api/src/application/validator"
+)
+
+type SaveSupplierRequest struct {
+	Name        string `json:"name" validate:"required,max=200"`
+	Cnpj        string `json:"cnpj" validate:"omitempty,max=20"`
+	ContactName string `json:"contact_name" validate:"omitempty,max=200"`
+	PhoneNumber string `json:"phone_number" validate:"omitempty,max=20"`
+	Email       string `json:"email" validate:"omitempty,email,max=250"`
+}
+
+func (r *SaveSupplierRequest) Validate() error {
+	if err := validator.Validate(r); err != nil {
+		return err
+	}
+	if r.Cnpj != "" {
+		if !helper.IsValidCNPJ(r.Cnpj) {
+			return errors.New("CNPJ inválido")
+		}
+		r.Cnpj = helper.SanitizeDocument(r.Cnpj)
+	}
+	return nil
+}
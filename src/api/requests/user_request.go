@@ -37,6 +37,13 @@ func (r *EditUserRequest) Validate() error {
 	return nil
 }
 
+// InactivateUserRequest indica se o estoque do revendedor deve ser transferido
+// na inativação. Sem destino, o saldo volta para o estoque principal.
+type InactivateUserRequest struct {
+	TransferInventory      bool
+	InventoryDestinationId int64
+}
+
 type GetAllUserRequest struct {
 	Role domain.Role `json:"role"`
 }
@@ -33,13 +33,15 @@ func NewRouter(controller *controller.Controller, obs observability.Observabilit
 func (r *router) SetupCors(env string) {
 	if env != "production" {
 		r.echo.Use(_middleware.CORSWithConfig(_middleware.CORSConfig{
-			AllowOrigins: []string{"http://localhost:4200", "https://erp-front-0pem.onrender.com"},
-			AllowMethods: []string{echo.GET, echo.HEAD, echo.PUT, echo.PATCH, echo.POST, echo.DELETE},
+			AllowOrigins:  []string{"http://localhost:4200", "https://erp-front-0pem.onrender.com"},
+			AllowMethods:  []string{echo.GET, echo.HEAD, echo.PUT, echo.PATCH, echo.POST, echo.DELETE},
+			ExposeHeaders: []string{"X-Total-Count", "X-Total-Value"},
 		}))
 	} else {
 		r.echo.Use(_middleware.CORSWithConfig(_middleware.CORSConfig{
-			AllowOrigins: []string{"https://erp-front-production.onrender.com", "https://trinus.app", "https://erp.trinus.app"},
-			AllowMethods: []string{echo.GET, echo.HEAD, echo.PUT, echo.PATCH, echo.POST, echo.DELETE},
+			AllowOrigins:  []string{"https://erp-front-production.onrender.com", "https://trinus.app", "https://erp.trinus.app"},
+			AllowMethods:  []string{echo.GET, echo.HEAD, echo.PUT, echo.PATCH, echo.POST, echo.DELETE},
+			ExposeHeaders: []string{"X-Total-Count", "X-Total-Value"},
 		}))
 	}
 }
@@ -85,6 +87,7 @@ func (r *router) setupPrivateRoutes() {
 	skuGroup.DELETE("/:id", r.controller.SkuController.Inactivate, middleware.RoleMiddleware([]domain.Role{domain.UserRoleAdmin}))
 	skuGroup.GET("/:id/inventory", r.controller.SkuController.GetInventory, middleware.RoleMiddleware([]domain.Role{domain.UserRoleAdmin}))
 	skuGroup.GET("/:id/transactions", r.controller.SkuController.GetTransactions, middleware.RoleMiddleware([]domain.Role{domain.UserRoleAdmin}))
+	skuGroup.GET("/:id/purchases", r.controller.PurchaseOrderController.GetSkuPurchases, middleware.RoleMiddleware([]domain.Role{domain.UserRoleAdmin}))
 
 	categoryGroup := private.Group("/categories", middleware.RoleMiddleware([]domain.Role{domain.UserRoleAdmin}))
 	categoryGroup.POST("", r.controller.CategoryController.Create)
@@ -110,13 +113,58 @@ func (r *router) setupPrivateRoutes() {
 	inventoryGroup.GET("/items", r.controller.InventoryController.GetAllInventoryItems)
 	inventoryGroup.GET("/:id/transaction", r.controller.InventoryController.GetInventoryTransactionsByInventoryId)
 	inventoryGroup.POST("/transaction", r.controller.InventoryController.DoTransaction)
+	inventoryGroup.POST("/:id/transfer-all", r.controller.InventoryController.TransferAll)
+	inventoryGroup.GET("/counts", r.controller.StockCountController.GetAll)
+	inventoryGroup.POST("/counts", r.controller.StockCountController.Open)
+	inventoryGroup.GET("/counts/:id", r.controller.StockCountController.GetById)
+	inventoryGroup.PUT("/counts/:id/items", r.controller.StockCountController.Count)
+	inventoryGroup.POST("/counts/:id/post", r.controller.StockCountController.Post)
+	inventoryGroup.POST("/counts/:id/cancel", r.controller.StockCountController.Cancel)
+	inventoryGroup.GET("/levels", r.controller.StockLevelController.GetLevels)
+	inventoryGroup.PUT("/levels", r.controller.StockLevelController.SaveLevel)
+	inventoryGroup.DELETE("/levels/:id", r.controller.StockLevelController.DeleteLevel)
+	inventoryGroup.GET("/reorder-suggestions", r.controller.StockLevelController.GetReorderSuggestions)
+
+	supplierGroup := private.Group("/suppliers", middleware.RoleMiddleware([]domain.Role{domain.UserRoleAdmin}))
+	supplierGroup.POST("", r.controller.SupplierController.Create)
+	supplierGroup.GET("", r.controller.SupplierController.GetAll)
+	supplierGroup.GET("/:id", r.controller.SupplierController.GetById)
+	supplierGroup.PUT("/:id", r.controller.SupplierController.Edit)
+	supplierGroup.DELETE("/:id", r.controller.SupplierController.Inactivate)
+
+	purchaseOrderGroup := private.Group("/purchase-orders", middleware.RoleMiddleware([]domain.Role{domain.UserRoleAdmin}))
+	purchaseOrderGroup.POST("", r.controller.PurchaseOrderController.Create)
+	purchaseOrderGroup.GET("", r.controller.PurchaseOrderController.GetAll)
+	purchaseOrderGroup.GET("/:id", r.controller.PurchaseOrderController.GetById)
+	purchaseOrderGroup.POST("/:id/receipts", r.controller.PurchaseOrderController.Receive)
+	purchaseOrderGroup.POST("/:id/cancel", r.controller.PurchaseOrderController.Cancel)
 
 	salesGroup := private.Group("/sales")
 	salesGroup.POST("", r.controller.SalesController.Create, middleware.RoleMiddleware([]domain.Role{domain.UserRoleAdmin, domain.UserRoleReseller}))
 	salesGroup.POST("/:id/returns", r.controller.SalesController.CreateReturn, middleware.RoleMiddleware([]domain.Role{domain.UserRoleAdmin, domain.UserRoleReseller}))
+	salesGroup.POST("/:id/exchanges", r.controller.SalesController.Exchange, middleware.RoleMiddleware([]domain.Role{domain.UserRoleAdmin, domain.UserRoleReseller}))
+	salesGroup.POST("/:id/cancel", r.controller.SalesController.Cancel, middleware.RoleMiddleware([]domain.Role{domain.UserRoleAdmin, domain.UserRoleReseller}))
+	salesGroup.POST("/:id/renegotiations", r.controller.SalesController.Renegotiate, middleware.RoleMiddleware([]domain.Role{domain.UserRoleAdmin, domain.UserRoleReseller}))
 	salesGroup.GET("", r.controller.SalesController.GetAll, middleware.RoleMiddleware([]domain.Role{domain.UserRoleAdmin, domain.UserRoleReseller}))
 	salesGroup.GET("/:id", r.controller.SalesController.GetById, middleware.RoleMiddleware([]domain.Role{domain.UserRoleAdmin, domain.UserRoleReseller}))
+	salesGroup.GET("/:id/receipt.pdf", r.controller.DocumentController.GetSaleReceipt, middleware.RoleMiddleware([]domain.Role{domain.UserRoleAdmin, domain.UserRoleReseller}))
+	salesGroup.GET("/:id/carne.pdf", r.controller.DocumentController.GetSaleCarne, middleware.RoleMiddleware([]domain.Role{domain.UserRoleAdmin, domain.UserRoleReseller}))
+	salesGroup.GET("/:id/versions", r.controller.SalesController.GetVersions, middleware.RoleMiddleware([]domain.Role{domain.UserRoleAdmin, domain.UserRoleReseller}))
+	salesGroup.GET("/:id/renegotiations", r.controller.SalesController.GetRenegotiations, middleware.RoleMiddleware([]domain.Role{domain.UserRoleAdmin, domain.UserRoleReseller}))
+	salesGroup.PUT("/:id", r.controller.SalesController.Edit, middleware.RoleMiddleware([]domain.Role{domain.UserRoleAdmin, domain.UserRoleReseller}))
 	salesGroup.PUT("/:id/payments/:payment_id", r.controller.SalesController.ChangePaymentStatus, middleware.RoleMiddleware([]domain.Role{domain.UserRoleAdmin, domain.UserRoleReseller}))
+	salesGroup.POST("/:id/payments/:payment_id/receipts", r.controller.SalesController.CreatePaymentReceipt, middleware.RoleMiddleware([]domain.Role{domain.UserRoleAdmin, domain.UserRoleReseller}))
+	salesGroup.GET("/:id/payments/:payment_id/receipts", r.controller.SalesController.GetPaymentReceipts, middleware.RoleMiddleware([]domain.Role{domain.UserRoleAdmin, domain.UserRoleReseller}))
+	salesGroup.GET("/:id/payments/:payment_id/pix", r.controller.PixController.GetCharge, middleware.RoleMiddleware([]domain.Role{domain.UserRoleAdmin, domain.UserRoleReseller}))
+	salesGroup.GET("/:id/payments/:payment_id/pix.png", r.controller.PixController.GetChargeQrCode, middleware.RoleMiddleware([]domain.Role{domain.UserRoleAdmin, domain.UserRoleReseller}))
+	salesGroup.POST("/pix/reconciliations", r.controller.PixController.Reconcile, middleware.RoleMiddleware([]domain.Role{domain.UserRoleAdmin}))
+
+	quoteGroup := private.Group("/quotes")
+	quoteGroup.POST("", r.controller.QuoteController.Create, middleware.RoleMiddleware([]domain.Role{domain.UserRoleAdmin, domain.UserRoleReseller}))
+	quoteGroup.GET("", r.controller.QuoteController.GetAll, middleware.RoleMiddleware([]domain.Role{domain.UserRoleAdmin, domain.UserRoleReseller}))
+	quoteGroup.GET("/:id", r.controller.QuoteController.GetById, middleware.RoleMiddleware([]domain.Role{domain.UserRoleAdmin, domain.UserRoleReseller}))
+	quoteGroup.POST("/:id/convert", r.controller.QuoteController.Convert, middleware.RoleMiddleware([]domain.Role{domain.UserRoleAdmin, domain.UserRoleReseller}))
+	quoteGroup.POST("/:id/cancel", r.controller.QuoteController.Cancel, middleware.RoleMiddleware([]domain.Role{domain.UserRoleAdmin, domain.UserRoleReseller}))
 
 	customerGroup := private.Group("/customers")
 	customerGroup.POST("", r.controller.CustomerController.Create, middleware.RoleMiddleware([]domain.Role{domain.UserRoleAdmin, domain.UserRoleReseller}))
@@ -124,6 +172,49 @@ func (r *router) setupPrivateRoutes() {
 	customerGroup.GET("/:id", r.controller.CustomerController.GetById, middleware.RoleMiddleware([]domain.Role{domain.UserRoleAdmin, domain.UserRoleReseller}))
 	customerGroup.PUT("/:id", r.controller.CustomerController.Edit, middleware.RoleMiddleware([]domain.Role{domain.UserRoleAdmin, domain.UserRoleReseller}))
 	customerGroup.DELETE("/:id", r.controller.CustomerController.Inactivate, middleware.RoleMiddleware([]domain.Role{domain.UserRoleAdmin, domain.UserRoleReseller}))
+	customerGroup.GET("/:id/credit", r.controller.CustomerController.GetCredit, middleware.RoleMiddleware([]domain.Role{domain.UserRoleAdmin, domain.UserRoleReseller}))
+	customerGroup.POST("/:id/credit/adjustments", r.controller.CustomerController.AdjustCredit, middleware.RoleMiddleware([]domain.Role{domain.UserRoleAdmin}))
+
+	receivableGroup := private.Group("/receivables")
+	receivableGroup.GET("", r.controller.ReceivableController.GetAll, middleware.RoleMiddleware([]domain.Role{domain.UserRoleAdmin, domain.UserRoleReseller}))
+
+	commissionGroup := private.Group("/commissions")
+	commissionGroup.GET("", r.controller.CommissionController.GetStatement, middleware.RoleMiddleware([]domain.Role{domain.UserRoleAdmin, domain.UserRoleReseller}))
+	commissionGroup.POST("/close", r.controller.CommissionController.CloseStatement, middleware.RoleMiddleware([]domain.Role{domain.UserRoleAdmin}))
+	commissionGroup.POST("/pay", r.controller.CommissionController.PayStatement, middleware.RoleMiddleware([]domain.Role{domain.UserRoleAdmin}))
+
+	cashRegisterGroup := private.Group("/cash-registers")
+	cashRegisterGroup.GET("", r.controller.CashRegisterController.GetAll, middleware.RoleMiddleware([]domain.Role{domain.UserRoleAdmin, domain.UserRoleReseller}))
+	cashRegisterGroup.POST("", r.controller.CashRegisterController.Open, middleware.RoleMiddleware([]domain.Role{domain.UserRoleAdmin, domain.UserRoleReseller}))
+	cashRegisterGroup.GET("/current", r.controller.CashRegisterController.GetCurrent, middleware.RoleMiddleware([]domain.Role{domain.UserRoleAdmin, domain.UserRoleReseller}))
+	cashRegisterGroup.POST("/current/entries", r.controller.CashRegisterController.CreateEntry, middleware.RoleMiddleware([]domain.Role{domain.UserRoleAdmin, domain.UserRoleReseller}))
+	cashRegisterGroup.POST("/current/close", r.controller.CashRegisterController.Close, middleware.RoleMiddleware([]domain.Role{domain.UserRoleAdmin, domain.UserRoleReseller}))
+	cashRegisterGroup.GET("/:id", r.controller.CashRegisterController.GetById, middleware.RoleMiddleware([]domain.Role{domain.UserRoleAdmin, domain.UserRoleReseller}))
+
+	settlementGroup := private.Group("/settlements")
+	settlementGroup.GET("/preview", r.controller.ConsignmentSettlementController.Preview, middleware.RoleMiddleware([]domain.Role{domain.UserRoleAdmin, domain.UserRoleReseller}))
+	settlementGroup.POST("", r.controller.ConsignmentSettlementController.Close, middleware.RoleMiddleware([]domain.Role{domain.UserRoleAdmin}))
+	settlementGroup.GET("", r.controller.ConsignmentSettlementController.GetAll, middleware.RoleMiddleware([]domain.Role{domain.UserRoleAdmin, domain.UserRoleReseller}))
+	settlementGroup.GET("/:id", r.controller.ConsignmentSettlementController.GetById, middleware.RoleMiddleware([]domain.Role{domain.UserRoleAdmin, domain.UserRoleReseller}))
+
+	settingsGroup := private.Group("/settings")
+	settingsGroup.GET("/late-fees", r.controller.LateFeeController.GetRule, middleware.RoleMiddleware([]domain.Role{domain.UserRoleAdmin, domain.UserRoleReseller}))
+	settingsGroup.PUT("/late-fees", r.controller.LateFeeController.UpdateRule, middleware.RoleMiddleware([]domain.Role{domain.UserRoleAdmin}))
+	settingsGroup.GET("/discounts", r.controller.DiscountController.GetRule, middleware.RoleMiddleware([]domain.Role{domain.UserRoleAdmin, domain.UserRoleReseller}))
+	settingsGroup.PUT("/discounts", r.controller.DiscountController.UpdateRule, middleware.RoleMiddleware([]domain.Role{domain.UserRoleAdmin}))
+	settingsGroup.GET("/commissions", r.controller.CommissionController.GetRules, middleware.RoleMiddleware([]domain.Role{domain.UserRoleAdmin, domain.UserRoleReseller}))
+	settingsGroup.POST("/commissions", r.controller.CommissionController.CreateRule, middleware.RoleMiddleware([]domain.Role{domain.UserRoleAdmin}))
+	settingsGroup.DELETE("/commissions/:id", r.controller.CommissionController.DeleteRule, middleware.RoleMiddleware([]domain.Role{domain.UserRoleAdmin}))
+	settingsGroup.GET("/documents", r.controller.DocumentController.GetTemplates, middleware.RoleMiddleware([]domain.Role{domain.UserRoleAdmin}))
+	settingsGroup.PUT("/documents/:type", r.controller.DocumentController.SaveTemplate, middleware.RoleMiddleware([]domain.Role{domain.UserRoleAdmin}))
+	settingsGroup.DELETE("/documents/:type", r.controller.DocumentController.ResetTemplate, middleware.RoleMiddleware([]domain.Role{domain.UserRoleAdmin}))
+	settingsGroup.GET("/pix", r.controller.PixController.GetSettings, middleware.RoleMiddleware([]domain.Role{domain.UserRoleAdmin, domain.UserRoleReseller}))
+	settingsGroup.PUT("/pix", r.controller.PixController.SaveSettings, middleware.RoleMiddleware([]domain.Role{domain.UserRoleAdmin}))
+	settingsGroup.GET("/cash-register", r.controller.CashRegisterController.GetSettings, middleware.RoleMiddleware([]domain.Role{domain.UserRoleAdmin, domain.UserRoleReseller}))
+	settingsGroup.PUT("/cash-register", r.controller.CashRegisterController.SaveSettings, middleware.RoleMiddleware([]domain.Role{domain.UserRoleAdmin}))
+	settingsGroup.GET("/card-fees", r.controller.CardFeeController.GetRules, middleware.RoleMiddleware([]domain.Role{domain.UserRoleAdmin, domain.UserRoleReseller}))
+	settingsGroup.PUT("/card-fees", r.controller.CardFeeController.SaveRule, middleware.RoleMiddleware([]domain.Role{domain.UserRoleAdmin}))
+	settingsGroup.DELETE("/card-fees/:id", r.controller.CardFeeController.DeleteRule, middleware.RoleMiddleware([]domain.Role{domain.UserRoleAdmin}))
 
 	dashboardGroup := private.Group("/dashboard")
 	dashboardGroup.GET("/widgets", r.controller.DashboardController.GetWidgets)
@@ -0,0 +1,30 @@
+package viewmodel
+
+import (
+	"github.com/bncunha/erp-api/src/application/service/output"
+	"github.com/bncunha/erp-api/src/domain"
+)
+
+type CardFeeRuleViewModel struct {
+	Id             int64              `json:"id"`
+	Brand          domain.CardBrand   `json:"brand"`
+	PaymentType    domain.PaymentType `json:"payment_type"`
+	Installments   int                `json:"installments"`
+	FeePercentage  float64            `json:"fee_percentage"`
+	SettlementDays int                `json:"settlement_days"`
+}
+
+func ToCardFeeRulesViewModel(rules []output.GetCardFeeRuleOutput) []CardFeeRuleViewModel {
+	viewModels := make([]CardFeeRuleViewModel, len(rules))
+	for i, rule := range rules {
+		viewModels[i] = CardFeeRuleViewModel{
+			Id:             rule.Id,
+			Brand:          rule.Brand,
+			PaymentType:    rule.PaymentType,
+			Installments:   rule.Installments,
+			FeePercentage:  rule.FeePercentage,
+			SettlementDays: rule.SettlementDays,
+		}
+	}
+	return viewModels
+}
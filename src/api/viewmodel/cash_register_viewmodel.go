@@ -0,0 +1,103 @@
+package viewmodel
+
+import (
+	"time"
+
+	"github.com/bncunha/erp-api/src/application/service/output"
+	"github.com/bncunha/erp-api/src/domain"
+)
+
+type CashRegisterSettingsViewModel struct {
+	RequireOpenSession bool `json:"require_open_session"`
+}
+
+func ToCashRegisterSettingsViewModel(settings output.GetCashRegisterSettingsOutput) CashRegisterSettingsViewModel {
+	return CashRegisterSettingsViewModel{RequireOpenSession: settings.RequireOpenSession}
+}
+
+type CashRegisterSessionsViewModel struct {
+	Id             int64                            `json:"id"`
+	UserId         int64                            `json:"user_id"`
+	UserName       string                           `json:"user_name"`
+	Status         domain.CashRegisterSessionStatus `json:"status"`
+	OpeningBalance float64                          `json:"opening_balance"`
+	ExpectedAmount *float64                         `json:"expected_amount"`
+	CountedAmount  *float64                         `json:"counted_amount"`
+	Difference     *float64                         `json:"difference"`
+	OpenedAt       time.Time                        `json:"opened_at"`
+	ClosedAt       *time.Time                       `json:"closed_at"`
+}
+
+func ToCashRegisterSessionsViewModel(sessions []output.GetCashRegisterSessionOutput) []CashRegisterSessionsViewModel {
+	viewModels := make([]CashRegisterSessionsViewModel, len(sessions))
+	for i, session := range sessions {
+		viewModels[i] = toCashRegisterSessionsViewModel(session)
+	}
+	return viewModels
+}
+
+func toCashRegisterSessionsViewModel(session output.GetCashRegisterSessionOutput) CashRegisterSessionsViewModel {
+	var expectedAmount *float64
+	if !session.IsOpen() {
+		expectedAmount = &session.ExpectedAmount
+	}
+	return CashRegisterSessionsViewModel{
+		Id:             session.Id,
+		UserId:         session.User.Id,
+		UserName:       session.User.Name,
+		Status:         session.Status,
+		OpeningBalance: session.OpeningBalance,
+		ExpectedAmount: expectedAmount,
+		CountedAmount:  session.CountedAmount,
+		Difference:     session.Difference,
+		OpenedAt:       session.OpenedAt,
+		ClosedAt:       session.ClosedAt,
+	}
+}
+
+type CashRegisterSessionViewModel struct {
+	CashRegisterSessionsViewModel
+	Balance float64                      `json:"balance"`
+	Notes   string                       `json:"notes"`
+	Entries []CashRegisterEntryViewModel `json:"entries"`
+}
+
+type CashRegisterEntryViewModel struct {
+	Id              int64                        `json:"id"`
+	Type            domain.CashRegisterEntryType `json:"type"`
+	Value           float64                      `json:"value"`
+	Description     string                       `json:"description"`
+	SalesId         *int64                       `json:"sales_id"`
+	PaymentDateId   *int64                       `json:"payment_date_id"`
+	CreatedByUserId int64                        `json:"created_by_user_id"`
+	CreatedAt       time.Time                    `json:"created_at"`
+}
+
+func ToCashRegisterSessionViewModel(session output.GetCashRegisterSessionOutput) CashRegisterSessionViewModel {
+	entries := make([]CashRegisterEntryViewModel, len(session.Entries))
+	for i, entry := range session.Entries {
+		entries[i] = CashRegisterEntryViewModel{
+			Id:              entry.Id,
+			Type:            entry.Type,
+			Value:           entry.Value,
+			Description:     entry.Description,
+			CreatedByUserId: entry.CreatedByUserId,
+			CreatedAt:       entry.CreatedAt,
+		}
+		if entry.SalesId != 0 {
+			salesId := entry.SalesId
+			entries[i].SalesId = &salesId
+		}
+		if entry.PaymentDateId != 0 {
+			paymentDateId := entry.PaymentDateId
+			entries[i].PaymentDateId = &paymentDateId
+		}
+	}
+
+	return CashRegisterSessionViewModel{
+		CashRegisterSessionsViewModel: toCashRegisterSessionsViewModel(session),
+		Balance:                       session.GetBalance(),
+		Notes:                         session.Notes,
+		Entries:                       entries,
+	}
+}
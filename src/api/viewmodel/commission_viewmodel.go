@@ -0,0 +1,82 @@
+package viewmodel
+
+import (
+	"time"
+
+	"github.com/bncunha/erp-api/src/application/service/output"
+	"github.com/bncunha/erp-api/src/domain"
+)
+
+type CommissionRuleViewModel struct {
+	Id          int64                  `json:"id"`
+	Scope       domain.CommissionScope `json:"scope"`
+	ReferenceId int64                  `json:"reference_id"`
+	Percentage  float64                `json:"percentage"`
+	Base        domain.CommissionBase  `json:"base"`
+}
+
+func ToCommissionRulesViewModel(rules []output.GetCommissionRuleOutput) []CommissionRuleViewModel {
+	viewModels := make([]CommissionRuleViewModel, len(rules))
+	for i, rule := range rules {
+		viewModels[i] = CommissionRuleViewModel{
+			Id:          rule.Id,
+			Scope:       rule.Scope,
+			ReferenceId: rule.ReferenceId,
+			Percentage:  rule.Percentage,
+			Base:        rule.Base,
+		}
+	}
+	return viewModels
+}
+
+type CommissionStatementViewModel struct {
+	UserId        int64                               `json:"user_id"`
+	UserName      string                              `json:"user_name"`
+	Month         string                              `json:"month"`
+	Status        domain.CommissionStatementStatus    `json:"status"`
+	ReceivedValue float64                             `json:"received_value"`
+	TotalValue    float64                             `json:"total_value"`
+	ClosedAt      *time.Time                          `json:"closed_at"`
+	PaidAt        *time.Time                          `json:"paid_at"`
+	Entries       []CommissionStatementEntryViewModel `json:"entries"`
+}
+
+type CommissionStatementEntryViewModel struct {
+	SaleId            int64              `json:"sale_id"`
+	SaleCode          string             `json:"sale_code"`
+	CustomerName      string             `json:"customer_name"`
+	PaymentType       domain.PaymentType `json:"payment_type"`
+	InstallmentNumber int                `json:"installment_number"`
+	PaidDate          time.Time          `json:"paid_date"`
+	ReceivedValue     float64            `json:"received_value"`
+	Percentage        float64            `json:"percentage"`
+	Value             float64            `json:"value"`
+}
+
+func ToCommissionStatementViewModel(statement output.GetCommissionStatementOutput) CommissionStatementViewModel {
+	entries := make([]CommissionStatementEntryViewModel, len(statement.Entries))
+	for i, entry := range statement.Entries {
+		entries[i] = CommissionStatementEntryViewModel{
+			SaleId:            entry.SaleId,
+			SaleCode:          entry.SaleCode,
+			CustomerName:      entry.CustomerName,
+			PaymentType:       entry.PaymentType,
+			InstallmentNumber: entry.InstallmentNumber,
+			PaidDate:          entry.PaidDate,
+			ReceivedValue:     entry.ReceivedValue,
+			Percentage:        entry.Percentage,
+			Value:             entry.Value,
+		}
+	}
+	return CommissionStatementViewModel{
+		UserId:        statement.User.Id,
+		UserName:      statement.User.Name,
+		Month:         statement.Month.Format("2006-01"),
+		Status:        statement.Status,
+		ReceivedValue: statement.GetReceivedTotal(),
+		TotalValue:    statement.GetTotal(),
+		ClosedAt:      statement.ClosedAt,
+		PaidAt:        statement.PaidAt,
+		Entries:       entries,
+	}
+}
@@ -0,0 +1,93 @@
+package viewmodel
+
+import (
+	"time"
+
+	"github.com/bncunha/erp-api/src/application/service/output"
+)
+
+type ConsignmentSettlementsViewModel struct {
+	Id             int64      `json:"id"`
+	Code           string     `json:"code"`
+	UserId         int64      `json:"user_id"`
+	UserName       string     `json:"user_name"`
+	PeriodStart    *time.Time `json:"period_start"`
+	PeriodEnd      time.Time  `json:"period_end"`
+	ReturnedUnsold bool       `json:"returned_unsold"`
+	AmountDue      float64    `json:"amount_due"`
+}
+
+func ToConsignmentSettlementsViewModel(settlements []output.GetConsignmentSettlementsOutput) []ConsignmentSettlementsViewModel {
+	viewModels := make([]ConsignmentSettlementsViewModel, len(settlements))
+	for i, settlement := range settlements {
+		viewModels[i] = ConsignmentSettlementsViewModel{
+			Id:             settlement.Id,
+			Code:           settlement.Code,
+			UserId:         settlement.UserId,
+			UserName:       settlement.UserName,
+			PeriodStart:    settlement.PeriodStart,
+			PeriodEnd:      settlement.PeriodEnd,
+			ReturnedUnsold: settlement.ReturnedUnsold,
+			AmountDue:      settlement.AmountDue,
+		}
+	}
+	return viewModels
+}
+
+type ConsignmentSettlementViewModel struct {
+	Id             int64                                `json:"id"`
+	Code           string                               `json:"code"`
+	UserId         int64                                `json:"user_id"`
+	UserName       string                               `json:"user_name"`
+	PeriodStart    *time.Time                           `json:"period_start"`
+	PeriodEnd      time.Time                            `json:"period_end"`
+	ReturnedUnsold bool                                 `json:"returned_unsold"`
+	Notes          string                               `json:"notes"`
+	AmountDue      float64                              `json:"amount_due"`
+	OnHandValue    float64                              `json:"on_hand_value"`
+	Items          []ConsignmentSettlementItemViewModel `json:"items"`
+}
+
+type ConsignmentSettlementItemViewModel struct {
+	SkuId             int64   `json:"sku_id"`
+	SkuCode           string  `json:"sku_code"`
+	ProductName       string  `json:"product_name"`
+	UnitPrice         float64 `json:"unit_price"`
+	SentQuantity      float64 `json:"sent_quantity"`
+	SoldQuantity      float64 `json:"sold_quantity"`
+	SoldValue         float64 `json:"sold_value"`
+	ReturnedQuantity  float64 `json:"returned_quantity"`
+	OnHandQuantity    float64 `json:"on_hand_quantity"`
+	ReturnedOnClosing float64 `json:"returned_on_closing"`
+}
+
+func ToConsignmentSettlementViewModel(settlement output.GetConsignmentSettlementOutput) ConsignmentSettlementViewModel {
+	items := make([]ConsignmentSettlementItemViewModel, len(settlement.Items))
+	for i, item := range settlement.Items {
+		items[i] = ConsignmentSettlementItemViewModel{
+			SkuId:             item.Sku.Id,
+			SkuCode:           item.Sku.Code,
+			ProductName:       item.Sku.GetName(),
+			UnitPrice:         item.Sku.Price,
+			SentQuantity:      item.SentQuantity,
+			SoldQuantity:      item.SoldQuantity,
+			SoldValue:         item.SoldValue,
+			ReturnedQuantity:  item.ReturnedQuantity,
+			OnHandQuantity:    item.OnHandQuantity,
+			ReturnedOnClosing: item.ReturnedOnClosing,
+		}
+	}
+	return ConsignmentSettlementViewModel{
+		Id:             settlement.Id,
+		Code:           settlement.Code,
+		UserId:         settlement.User.Id,
+		UserName:       settlement.User.Name,
+		PeriodStart:    settlement.PeriodStart,
+		PeriodEnd:      settlement.PeriodEnd,
+		ReturnedUnsold: settlement.ReturnedUnsold,
+		Notes:          settlement.Notes,
+		AmountDue:      settlement.GetAmountDue(),
+		OnHandValue:    settlement.GetOnHandValue(),
+		Items:          items,
+	}
+}
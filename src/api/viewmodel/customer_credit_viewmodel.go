@@ -0,0 +1,56 @@
+package viewmodel
+
+import (
+	"time"
+
+	"github.com/bncunha/erp-api/src/application/service/output"
+)
+
+type CustomerCreditViewModel struct {
+	CustomerId int64                             `json:"customer_id"`
+	Balance    float64                           `json:"balance"`
+	Movements  []CustomerCreditMovementViewModel `json:"movements"`
+}
+
+type CustomerCreditMovementViewModel struct {
+	Id             int64   `json:"id"`
+	Type           string  `json:"type"`
+	Origin         string  `json:"origin"`
+	Value          float64 `json:"value"`
+	Description    string  `json:"description"`
+	SalesId        *int64  `json:"sales_id"`
+	SalesVersionId *int64  `json:"sales_version_id"`
+	CreatedBy      string  `json:"created_by"`
+	CreatedAt      string  `json:"created_at"`
+}
+
+func ToCustomerCreditViewModel(credit output.GetCustomerCreditOutput) CustomerCreditViewModel {
+	viewModel := CustomerCreditViewModel{
+		CustomerId: credit.CustomerId,
+		Balance:    credit.Balance,
+		Movements:  make([]CustomerCreditMovementViewModel, 0, len(credit.Movements)),
+	}
+	for _, movement := range credit.Movements {
+		var salesId, salesVersionId *int64
+		if movement.SalesId != 0 {
+			id := movement.SalesId
+			salesId = &id
+		}
+		if movement.SalesVersionId != 0 {
+			id := movement.SalesVersionId
+			salesVersionId = &id
+		}
+		viewModel.Movements = append(viewModel.Movements, CustomerCreditMovementViewModel{
+			Id:             movement.Id,
+			Type:           string(movement.Type),
+			Origin:         string(movement.Origin),
+			Value:          movement.Value,
+			Description:    movement.Description,
+			SalesId:        salesId,
+			SalesVersionId: salesVersionId,
+			CreatedBy:      movement.CreatedBy,
+			CreatedAt:      movement.CreatedAt.Format(time.RFC3339),
+		})
+	}
+	return viewModel
+}
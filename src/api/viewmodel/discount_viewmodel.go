@@ -0,0 +1,13 @@
+package viewmodel
+
+import "github.com/bncunha/erp-api/src/application/service/output"
+
+type DiscountRuleViewModel struct {
+	ResellerMaxPercentage float64 `json:"reseller_max_percentage"`
+}
+
+func ToDiscountRuleViewModel(rule output.GetDiscountRuleOutput) DiscountRuleViewModel {
+	return DiscountRuleViewModel{
+		ResellerMaxPercentage: rule.ResellerMaxPercentage,
+	}
+}
@@ -0,0 +1,28 @@
+package viewmodel
+
+import (
+	"time"
+
+	"github.com/bncunha/erp-api/src/application/service/output"
+	"github.com/bncunha/erp-api/src/domain"
+)
+
+type DocumentTemplateViewModel struct {
+	Type      domain.DocumentTemplateType `json:"type"`
+	Content   string                      `json:"content"`
+	IsDefault bool                        `json:"is_default"`
+	UpdatedAt *time.Time                  `json:"updated_at"`
+}
+
+func ToDocumentTemplatesViewModel(templates []output.GetDocumentTemplateOutput) []DocumentTemplateViewModel {
+	viewModels := make([]DocumentTemplateViewModel, len(templates))
+	for i, template := range templates {
+		viewModels[i] = DocumentTemplateViewModel{
+			Type:      template.Type,
+			Content:   template.Content,
+			IsDefault: template.IsDefault,
+			UpdatedAt: template.UpdatedAt,
+		}
+	}
+	return viewModels
+}
@@ -23,9 +23,10 @@ var inventoryTypeMap = map[domain.InventoryType]string{
 }
 
 var inventoryTransactionTypeMap = map[domain.InventoryTransactionType]string{
-	domain.InventoryTransactionTypeTransfer: "Transferência",
-	domain.InventoryTransactionTypeIn:       "Entrada",
-	domain.InventoryTransactionTypeOut:      "Saída",
+	domain.InventoryTransactionTypeTransfer:   "Transferência",
+	domain.InventoryTransactionTypeIn:         "Entrada",
+	domain.InventoryTransactionTypeOut:        "Saída",
+	domain.InventoryTransactionTypeAdjustment: "Ajuste de contagem",
 }
 
 func ToGetInventoryItemsViewModel(inventoryItem output.GetInventoryItemsOutput) GetInventoryItemsViewModel {
@@ -63,15 +64,16 @@ func ToGetInventoryItemsViewModel(inventoryItem output.GetInventoryItemsOutput)
 }
 
 type GetInventoryTransactionsViewModel struct {
-	Id            int64   `json:"id"`
-	Date          string  `json:"date"`
-	Type          string  `json:"type"`
-	Quantity      float64 `json:"quantity"`
-	SkuCode       string  `json:"sku_code"`
-	ProductName   string  `json:"product_name"`
-	Origin        *string `json:"origin"`
-	Destination   *string `json:"destination"`
-	Justification *string `json:"justification"`
+	Id            int64    `json:"id"`
+	Date          string   `json:"date"`
+	Type          string   `json:"type"`
+	Quantity      float64  `json:"quantity"`
+	SkuCode       string   `json:"sku_code"`
+	ProductName   string   `json:"product_name"`
+	Origin        *string  `json:"origin"`
+	Destination   *string  `json:"destination"`
+	Justification *string  `json:"justification"`
+	UnitCost      *float64 `json:"unit_cost"`
 }
 
 func ToGetInventoryTransactionsViewModel(inventoryTransaction output.GetInventoryTransactionsOutput) GetInventoryTransactionsViewModel {
@@ -127,6 +129,7 @@ func ToGetInventoryTransactionsViewModel(inventoryTransaction output.GetInventor
 		Destination:   destionation,
 		SkuCode:       inventoryTransaction.SkuCode,
 		Justification: inventoryTransaction.Justification,
+		UnitCost:      inventoryTransaction.UnitCost,
 	}
 }
 
@@ -0,0 +1,15 @@
+package viewmodel
+
+import "github.com/bncunha/erp-api/src/application/service/output"
+
+type LateFeeRuleViewModel struct {
+	FinePercentage          float64 `json:"fine_percentage"`
+	DailyInterestPercentage float64 `json:"daily_interest_percentage"`
+}
+
+func ToLateFeeRuleViewModel(rule output.GetLateFeeRuleOutput) LateFeeRuleViewModel {
+	return LateFeeRuleViewModel{
+		FinePercentage:          rule.FinePercentage,
+		DailyInterestPercentage: rule.DailyInterestPercentage,
+	}
+}
@@ -0,0 +1,114 @@
+package viewmodel
+
+import (
+	"time"
+
+	"github.com/bncunha/erp-api/src/application/service/output"
+	"github.com/bncunha/erp-api/src/domain"
+)
+
+type PixSettingsViewModel struct {
+	Key          string     `json:"key"`
+	MerchantName string     `json:"merchant_name"`
+	MerchantCity string     `json:"merchant_city"`
+	IsConfigured bool       `json:"is_configured"`
+	UpdatedAt    *time.Time `json:"updated_at"`
+}
+
+func ToPixSettingsViewModel(settings output.GetPixSettingsOutput) PixSettingsViewModel {
+	return PixSettingsViewModel{
+		Key:          settings.Key,
+		MerchantName: settings.MerchantName,
+		MerchantCity: settings.MerchantCity,
+		IsConfigured: settings.IsConfigured(),
+		UpdatedAt:    settings.UpdatedAt,
+	}
+}
+
+type PixChargeViewModel struct {
+	PaymentDateId int64   `json:"payment_date_id"`
+	TxId          string  `json:"txid"`
+	Amount        float64 `json:"amount"`
+	Payload       string  `json:"payload"`
+}
+
+func ToPixChargeViewModel(charge output.GetPixChargeOutput) PixChargeViewModel {
+	return PixChargeViewModel{
+		PaymentDateId: charge.PaymentDateId,
+		TxId:          charge.TxId,
+		Amount:        charge.Amount,
+		Payload:       charge.Payload,
+	}
+}
+
+type BankStatementTransactionViewModel struct {
+	Id          string  `json:"id"`
+	Date        string  `json:"date"`
+	Amount      float64 `json:"amount"`
+	Description string  `json:"description"`
+}
+
+type PixReconciliationMatchViewModel struct {
+	Transaction       BankStatementTransactionViewModel `json:"transaction"`
+	PaymentDateId     int64                             `json:"payment_date_id"`
+	SaleId            int64                             `json:"sale_id"`
+	SaleCode          string                            `json:"sale_code"`
+	CustomerName      string                            `json:"customer_name"`
+	InstallmentNumber int                               `json:"installment_number"`
+	DueDate           string                            `json:"due_date"`
+	Value             float64                           `json:"value"`
+}
+
+type PixReconciliationFailureViewModel struct {
+	Match PixReconciliationMatchViewModel `json:"match"`
+	Error string                          `json:"error"`
+}
+
+type PixReconciliationViewModel struct {
+	Matches   []PixReconciliationMatchViewModel   `json:"matches"`
+	Unmatched []BankStatementTransactionViewModel `json:"unmatched"`
+	Failed    []PixReconciliationFailureViewModel `json:"failed"`
+}
+
+func ToPixReconciliationViewModel(reconciliation output.PixReconciliationOutput) PixReconciliationViewModel {
+	viewModel := PixReconciliationViewModel{
+		Matches:   make([]PixReconciliationMatchViewModel, len(reconciliation.Matches)),
+		Unmatched: make([]BankStatementTransactionViewModel, len(reconciliation.Unmatched)),
+		Failed:    make([]PixReconciliationFailureViewModel, len(reconciliation.Failed)),
+	}
+	for i, match := range reconciliation.Matches {
+		viewModel.Matches[i] = toPixReconciliationMatchViewModel(match)
+	}
+	for i, transaction := range reconciliation.Unmatched {
+		viewModel.Unmatched[i] = toBankStatementTransactionViewModel(transaction)
+	}
+	for i, failure := range reconciliation.Failed {
+		viewModel.Failed[i] = PixReconciliationFailureViewModel{
+			Match: toPixReconciliationMatchViewModel(failure.Match),
+			Error: failure.Error,
+		}
+	}
+	return viewModel
+}
+
+func toPixReconciliationMatchViewModel(match domain.PixReconciliationMatch) PixReconciliationMatchViewModel {
+	return PixReconciliationMatchViewModel{
+		Transaction:       toBankStatementTransactionViewModel(match.Transaction),
+		PaymentDateId:     match.Payment.PaymentDateId,
+		SaleId:            match.Payment.SaleId,
+		SaleCode:          match.Payment.SaleCode,
+		CustomerName:      match.Payment.CustomerName,
+		InstallmentNumber: match.Payment.InstallmentNumber,
+		DueDate:           match.Payment.DueDate.Format(time.DateOnly),
+		Value:             match.Payment.Value,
+	}
+}
+
+func toBankStatementTransactionViewModel(transaction domain.BankStatementTransaction) BankStatementTransactionViewModel {
+	return BankStatementTransactionViewModel{
+		Id:          transaction.Id,
+		Date:        transaction.Date.Format(time.DateOnly),
+		Amount:      transaction.Amount,
+		Description: transaction.Description,
+	}
+}
@@ -0,0 +1,167 @@
+package viewmodel
+
+import (
+	"math"
+	"time"
+
+	"github.com/bncunha/erp-api/src/application/service/output"
+	"github.com/bncunha/erp-api/src/domain"
+)
+
+var purchaseOrderStatusMap = map[domain.PurchaseOrderStatus]string{
+	domain.PurchaseOrderStatusOpen:              "Em aberto",
+	domain.PurchaseOrderStatusPartiallyReceived: "Recebido parcialmente",
+	domain.PurchaseOrderStatusReceived:          "Recebido",
+	domain.PurchaseOrderStatusCanceled:          "Cancelado",
+}
+
+type PurchaseOrdersViewModel struct {
+	Id               int64      `json:"id"`
+	Code             string     `json:"code"`
+	SupplierId       int64      `json:"supplier_id"`
+	SupplierName     string     `json:"supplier_name"`
+	Status           string     `json:"status"`
+	StatusLabel      string     `json:"status_label"`
+	ExpectedDate     *time.Time `json:"expected_date"`
+	CreatedAt        time.Time  `json:"created_at"`
+	TotalQuantity    float64    `json:"total_quantity"`
+	ReceivedQuantity float64    `json:"received_quantity"`
+	TotalValue       float64    `json:"total_value"`
+}
+
+func ToPurchaseOrdersViewModel(orders []output.GetPurchaseOrdersOutput) []PurchaseOrdersViewModel {
+	viewModels := make([]PurchaseOrdersViewModel, len(orders))
+	for i, order := range orders {
+		viewModels[i] = PurchaseOrdersViewModel{
+			Id:               order.Id,
+			Code:             order.Code,
+			SupplierId:       order.SupplierId,
+			SupplierName:     order.SupplierName,
+			Status:           string(order.Status),
+			StatusLabel:      purchaseOrderStatusMap[order.Status],
+			ExpectedDate:     order.ExpectedDate,
+			CreatedAt:        order.CreatedAt,
+			TotalQuantity:    order.TotalQuantity,
+			ReceivedQuantity: order.ReceivedQuantity,
+			TotalValue:       math.Round(order.TotalValue*100) / 100,
+		}
+	}
+	return viewModels
+}
+
+type PurchaseOrderViewModel struct {
+	Id           int64                        `json:"id"`
+	Code         string                       `json:"code"`
+	SupplierId   int64                        `json:"supplier_id"`
+	SupplierName string                       `json:"supplier_name"`
+	Status       string                       `json:"status"`
+	StatusLabel  string                       `json:"status_label"`
+	ExpectedDate *time.Time                   `json:"expected_date"`
+	Notes        string                       `json:"notes"`
+	CreatedAt    time.Time                    `json:"created_at"`
+	TotalValue   float64                      `json:"total_value"`
+	Items        []PurchaseOrderItemViewModel `json:"items"`
+	Receipts     []PurchaseReceiptViewModel   `json:"receipts"`
+}
+
+type PurchaseOrderItemViewModel struct {
+	SkuId            int64   `json:"sku_id"`
+	SkuCode          string  `json:"sku_code"`
+	ProductName      string  `json:"product_name"`
+	Quantity         float64 `json:"quantity"`
+	ReceivedQuantity float64 `json:"received_quantity"`
+	PendingQuantity  float64 `json:"pending_quantity"`
+	UnitCost         float64 `json:"unit_cost"`
+}
+
+type PurchaseReceiptViewModel struct {
+	Id         int64                          `json:"id"`
+	Notes      string                         `json:"notes"`
+	ReceivedAt time.Time                      `json:"received_at"`
+	Items      []PurchaseReceiptItemViewModel `json:"items"`
+}
+
+type PurchaseReceiptItemViewModel struct {
+	SkuId       int64   `json:"sku_id"`
+	SkuCode     string  `json:"sku_code"`
+	ProductName string  `json:"product_name"`
+	Quantity    float64 `json:"quantity"`
+	UnitCost    float64 `json:"unit_cost"`
+}
+
+func ToPurchaseOrderViewModel(order output.GetPurchaseOrderOutput) PurchaseOrderViewModel {
+	viewModel := PurchaseOrderViewModel{
+		Id:           order.Id,
+		Code:         order.Code,
+		SupplierId:   order.Supplier.Id,
+		SupplierName: order.Supplier.Name,
+		Status:       string(order.Status),
+		StatusLabel:  purchaseOrderStatusMap[order.Status],
+		ExpectedDate: order.ExpectedDate,
+		Notes:        order.Notes,
+		CreatedAt:    order.CreatedAt,
+		TotalValue:   order.GetTotal(),
+		Items:        make([]PurchaseOrderItemViewModel, len(order.Items)),
+		Receipts:     make([]PurchaseReceiptViewModel, len(order.Receipts)),
+	}
+	for i, item := range order.Items {
+		viewModel.Items[i] = PurchaseOrderItemViewModel{
+			SkuId:            item.Sku.Id,
+			SkuCode:          item.Sku.Code,
+			ProductName:      item.Sku.GetName(),
+			Quantity:         item.Quantity,
+			ReceivedQuantity: item.ReceivedQuantity,
+			PendingQuantity:  item.GetPendingQuantity(),
+			UnitCost:         item.UnitCost,
+		}
+	}
+	for i, receipt := range order.Receipts {
+		receiptViewModel := PurchaseReceiptViewModel{
+			Id:         receipt.Id,
+			Notes:      receipt.Notes,
+			ReceivedAt: receipt.ReceivedAt,
+			Items:      make([]PurchaseReceiptItemViewModel, len(receipt.Items)),
+		}
+		for j, item := range receipt.Items {
+			receiptViewModel.Items[j] = PurchaseReceiptItemViewModel{
+				SkuId:       item.Sku.Id,
+				SkuCode:     item.Sku.Code,
+				ProductName: item.Sku.GetName(),
+				Quantity:    item.Quantity,
+				UnitCost:    item.UnitCost,
+			}
+		}
+		viewModel.Receipts[i] = receiptViewModel
+	}
+	return viewModel
+}
+
+type SkuPurchaseViewModel struct {
+	ReceiptId         int64     `json:"receipt_id"`
+	ReceivedAt        time.Time `json:"received_at"`
+	PurchaseOrderId   int64     `json:"purchase_order_id"`
+	PurchaseOrderCode string    `json:"purchase_order_code"`
+	SupplierId        int64     `json:"supplier_id"`
+	SupplierName      string    `json:"supplier_name"`
+	Quantity          float64   `json:"quantity"`
+	UnitCost          float64   `json:"unit_cost"`
+	TotalCost         float64   `json:"total_cost"`
+}
+
+func ToSkuPurchasesViewModel(purchases []output.GetSkuPurchasesOutput) []SkuPurchaseViewModel {
+	viewModels := make([]SkuPurchaseViewModel, len(purchases))
+	for i, purchase := range purchases {
+		viewModels[i] = SkuPurchaseViewModel{
+			ReceiptId:         purchase.ReceiptId,
+			ReceivedAt:        purchase.ReceivedAt,
+			PurchaseOrderId:   purchase.PurchaseOrderId,
+			PurchaseOrderCode: purchase.PurchaseOrderCode,
+			SupplierId:        purchase.SupplierId,
+			SupplierName:      purchase.SupplierName,
+			Quantity:          purchase.Quantity,
+			UnitCost:          purchase.UnitCost,
+			TotalCost:         math.Round(purchase.Quantity*purchase.UnitCost*100) / 100,
+		}
+	}
+	return viewModels
+}
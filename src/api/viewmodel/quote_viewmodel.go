@@ -0,0 +1,112 @@
+package viewmodel
+
+import (
+	"math"
+	"time"
+
+	"github.com/bncunha/erp-api/src/application/service/output"
+	"github.com/bncunha/erp-api/src/domain"
+)
+
+type QuoteViewModel struct {
+	Id           int64              `json:"id"`
+	Code         string             `json:"code"`
+	Date         time.Time          `json:"date"`
+	ExpiresAt    *time.Time         `json:"expires_at"`
+	Status       domain.QuoteStatus `json:"status"`
+	ReserveStock bool               `json:"reserve_stock"`
+	SellerName   string             `json:"seller_name"`
+	CustomerName string             `json:"customer_name"`
+	TotalValue   float64            `json:"total_value"`
+	SalesId      *int64             `json:"sales_id"`
+}
+
+func ToQuotesViewModel(quotes []output.GetQuotesOutput) []QuoteViewModel {
+	viewModels := make([]QuoteViewModel, len(quotes))
+	for i, quote := range quotes {
+		viewModels[i] = QuoteViewModel{
+			Id:           quote.Id,
+			Code:         quote.Code,
+			Date:         quote.Date,
+			ExpiresAt:    quote.ExpiresAt,
+			Status:       quote.Status,
+			ReserveStock: quote.ReserveStock,
+			SellerName:   quote.SellerName,
+			CustomerName: quote.CustomerName,
+			TotalValue:   math.Round(quote.TotalValue*100) / 100,
+			SalesId:      quote.SalesId,
+		}
+	}
+	return viewModels
+}
+
+type QuoteByIdViewModel struct {
+	Id           int64                   `json:"id"`
+	Code         string                  `json:"code"`
+	Date         time.Time               `json:"date"`
+	ExpiresAt    *time.Time              `json:"expires_at"`
+	Status       domain.QuoteStatus      `json:"status"`
+	ReserveStock bool                    `json:"reserve_stock"`
+	SellerName   string                  `json:"seller_name"`
+	CustomerId   int64                   `json:"customer_id"`
+	CustomerName string                  `json:"customer_name"`
+	Discount     *SaleDiscountViewModel  `json:"discount"`
+	TotalValue   float64                 `json:"total_value"`
+	SalesId      *int64                  `json:"sales_id"`
+	Items        []SaleItemsViewModel    `json:"items"`
+	Payments     []QuotePaymentViewModel `json:"payments"`
+}
+
+type QuotePaymentViewModel struct {
+	PaymentType          domain.PaymentType `json:"payment_type"`
+	Value                float64            `json:"value"`
+	InstallmentsQuantity *int               `json:"installments_quantity"`
+	FirstInstallmentDate *time.Time         `json:"first_installment_date"`
+}
+
+func ToQuoteByIdViewModel(quote output.GetQuoteByIdOutput) QuoteByIdViewModel {
+	items := make([]SaleItemsViewModel, len(quote.Items))
+	var total, saleDiscount float64
+	for i, item := range quote.Items {
+		items[i] = SaleItemsViewModel{
+			SkuId:              item.Sku.Id,
+			Code:               item.Sku.Code,
+			Description:        item.Sku.GetName(),
+			Quantity:           item.Quantity,
+			UnitPrice:          item.Sku.Price,
+			GrossValue:         item.GetGrossTotal(),
+			Discount:           toSaleDiscountViewModel(item.Discount, item.DiscountAmount),
+			SaleDiscountAmount: item.SaleDiscountAmount,
+			TotalValue:         item.GetTotal(),
+		}
+		total += item.GetTotal()
+		saleDiscount += item.SaleDiscountAmount
+	}
+
+	payments := make([]QuotePaymentViewModel, len(quote.Payments))
+	for i, payment := range quote.Payments {
+		payments[i] = QuotePaymentViewModel{
+			PaymentType:          payment.PaymentType,
+			Value:                payment.Value,
+			InstallmentsQuantity: payment.InstallmentsQuantity,
+			FirstInstallmentDate: payment.FirstInstallmentDate,
+		}
+	}
+
+	return QuoteByIdViewModel{
+		Id:           quote.Id,
+		Code:         quote.Code,
+		Date:         quote.Date,
+		ExpiresAt:    quote.ExpiresAt,
+		Status:       quote.Status,
+		ReserveStock: quote.ReserveStock,
+		SellerName:   quote.User.Name,
+		CustomerId:   quote.Customer.Id,
+		CustomerName: quote.Customer.Name,
+		Discount:     toSaleDiscountViewModel(quote.Discount, math.Round(saleDiscount*100)/100),
+		TotalValue:   math.Round(total*100) / 100,
+		SalesId:      quote.SalesId,
+		Items:        items,
+		Payments:     payments,
+	}
+}
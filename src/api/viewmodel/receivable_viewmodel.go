@@ -0,0 +1,116 @@
+package viewmodel
+
+import (
+	"time"
+
+	"github.com/bncunha/erp-api/src/application/service/output"
+)
+
+type ReceivablesViewModel struct {
+	Summary     ReceivablesSummaryViewModel `json:"summary"`
+	Receivables []ReceivableViewModel       `json:"receivables"`
+}
+
+type ReceivablesSummaryViewModel struct {
+	TotalInstallments int                            `json:"total_installments"`
+	TotalValue        float64                        `json:"total_value"`
+	PendingValue      float64                        `json:"pending_value"`
+	DelayedValue      float64                        `json:"delayed_value"`
+	LateFeeValue      float64                        `json:"late_fee_value"`
+	Aging             []ReceivablesAgingViewModel    `json:"aging"`
+	Customers         []ReceivablesCustomerViewModel `json:"customers"`
+}
+
+type ReceivablesAgingViewModel struct {
+	Bucket       string  `json:"bucket"`
+	Installments int     `json:"installments"`
+	Value        float64 `json:"value"`
+}
+
+type ReceivablesCustomerViewModel struct {
+	CustomerId    int64   `json:"customer_id"`
+	CustomerName  string  `json:"customer_name"`
+	Installments  int     `json:"installments"`
+	TotalValue    float64 `json:"total_value"`
+	DelayedValue  float64 `json:"delayed_value"`
+	OldestDueDate string  `json:"oldest_due_date"`
+}
+
+type ReceivableViewModel struct {
+	PaymentDateId     int64   `json:"payment_date_id"`
+	SaleId            int64   `json:"sale_id"`
+	SaleCode          string  `json:"sale_code"`
+	SaleDate          string  `json:"sale_date"`
+	SellerId          int64   `json:"seller_id"`
+	SellerName        string  `json:"seller_name"`
+	CustomerId        int64   `json:"customer_id"`
+	CustomerName      string  `json:"customer_name"`
+	InstallmentNumber int     `json:"installment_number"`
+	InstallmentValue  float64 `json:"installment_value"`
+	ReceivedValue     float64 `json:"received_value"`
+	OpenValue         float64 `json:"open_value"`
+	FineValue         float64 `json:"fine_value"`
+	InterestValue     float64 `json:"interest_value"`
+	AmountDue         float64 `json:"amount_due"`
+	DueDate           string  `json:"due_date"`
+	DaysOverdue       int     `json:"days_overdue"`
+	AgingBucket       string  `json:"aging_bucket"`
+	Status            string  `json:"status"`
+}
+
+func ToReceivablesViewModel(receivables output.GetReceivablesOutput) ReceivablesViewModel {
+	summary := receivables.GetSummary()
+	viewModel := ReceivablesViewModel{
+		Summary: ReceivablesSummaryViewModel{
+			TotalInstallments: summary.TotalInstallments,
+			TotalValue:        summary.TotalValue,
+			PendingValue:      summary.PendingValue,
+			DelayedValue:      summary.DelayedValue,
+			LateFeeValue:      summary.LateFeeValue,
+			Aging:             make([]ReceivablesAgingViewModel, 0, len(summary.Aging)),
+			Customers:         make([]ReceivablesCustomerViewModel, 0, len(summary.Customers)),
+		},
+		Receivables: make([]ReceivableViewModel, 0, len(receivables.Receivables)),
+	}
+	for _, aging := range summary.Aging {
+		viewModel.Summary.Aging = append(viewModel.Summary.Aging, ReceivablesAgingViewModel{
+			Bucket:       string(aging.Bucket),
+			Installments: aging.Installments,
+			Value:        aging.Value,
+		})
+	}
+	for _, customer := range summary.Customers {
+		viewModel.Summary.Customers = append(viewModel.Summary.Customers, ReceivablesCustomerViewModel{
+			CustomerId:    customer.CustomerId,
+			CustomerName:  customer.CustomerName,
+			Installments:  customer.Installments,
+			TotalValue:    customer.TotalValue,
+			DelayedValue:  customer.DelayedValue,
+			OldestDueDate: customer.OldestDueDate.Format(time.DateOnly),
+		})
+	}
+	for _, receivable := range receivables.Receivables {
+		viewModel.Receivables = append(viewModel.Receivables, ReceivableViewModel{
+			PaymentDateId:     receivable.PaymentDateId,
+			SaleId:            receivable.SaleId,
+			SaleCode:          receivable.SaleCode,
+			SaleDate:          receivable.SaleDate.Format(time.RFC3339),
+			SellerId:          receivable.SellerId,
+			SellerName:        receivable.SellerName,
+			CustomerId:        receivable.CustomerId,
+			CustomerName:      receivable.CustomerName,
+			InstallmentNumber: receivable.InstallmentNumber,
+			InstallmentValue:  receivable.InstallmentValue,
+			ReceivedValue:     receivable.ReceivedValue,
+			OpenValue:         receivable.GetOpenValue(),
+			FineValue:         receivable.LateFee.FineValue,
+			InterestValue:     receivable.LateFee.InterestValue,
+			AmountDue:         receivable.GetAmountDue(),
+			DueDate:           receivable.DueDate.Format(time.DateOnly),
+			DaysOverdue:       receivable.GetDaysOverdue(receivables.Today),
+			AgingBucket:       string(receivable.GetAgingBucket(receivables.Today)),
+			Status:            string(receivable.Status),
+		})
+	}
+	return viewModel
+}
@@ -8,12 +8,21 @@ import (
 )
 
 type SalesViewModel struct {
-	Summary SalesSummaryViewModel `json:"summary"`
-	Sales   []SalesItemViewModel  `json:"sales"`
+	Summary    SalesSummaryViewModel    `json:"summary"`
+	Pagination SalesPaginationViewModel `json:"pagination"`
+	Sales      []SalesItemViewModel     `json:"sales"`
+}
+
+type SalesPaginationViewModel struct {
+	Page       int   `json:"page"`
+	PageSize   int   `json:"page_size"`
+	TotalCount int64 `json:"total_count"`
+	TotalPages int   `json:"total_pages"`
 }
 
 type SalesSummaryViewModel struct {
 	TotalSales    float64 `json:"total_sales"`
+	TotalValue    float64 `json:"total_value"`
 	TotalItems    float64 `json:"total_items"`
 	ReceivedValue float64 `json:"received_value"`
 	FutureRevenue float64 `json:"future_revenue"`
@@ -22,6 +31,7 @@ type SalesSummaryViewModel struct {
 
 type SalesItemViewModel struct {
 	Id           int     `json:"id"`
+	Code         string  `json:"code"`
 	Date         string  `json:"date"`
 	SellerName   string  `json:"seller_name"`
 	CustomerName string  `json:"customer_name"`
@@ -33,7 +43,13 @@ type SalesItemViewModel struct {
 func ToSalesViewModel(output output.GetSalesOutput) SalesViewModel {
 	salesViewModel := SalesViewModel{
 		Summary: toSalesSummaryViewModel(output.GetSummary()),
-		Sales:   make([]SalesItemViewModel, len(output.Sales)),
+		Pagination: SalesPaginationViewModel{
+			Page:       output.Page,
+			PageSize:   output.PageSize,
+			TotalCount: output.Totals.TotalSales,
+			TotalPages: output.GetTotalPages(),
+		},
+		Sales: make([]SalesItemViewModel, len(output.Sales)),
 	}
 	for i, sale := range output.Sales {
 		salesViewModel.Sales[i] = toSalesItemViewModel(sale)
@@ -44,6 +60,7 @@ func ToSalesViewModel(output output.GetSalesOutput) SalesViewModel {
 func toSalesSummaryViewModel(summary output.GetSalesSummaryOutput) SalesSummaryViewModel {
 	return SalesSummaryViewModel{
 		TotalSales:    summary.TotalSales,
+		TotalValue:    summary.TotalValue,
 		TotalItems:    summary.TotalItems,
 		ReceivedValue: summary.ReceivedValue,
 		FutureRevenue: summary.FutureRevenue,
@@ -54,6 +71,7 @@ func toSalesSummaryViewModel(summary output.GetSalesSummaryOutput) SalesSummaryV
 func toSalesItemViewModel(sale output.GetSalesItemOutput) SalesItemViewModel {
 	return SalesItemViewModel{
 		Id:           sale.Id,
+		Code:         sale.Code,
 		Date:         sale.Date,
 		SellerName:   sale.SellerName,
 		CustomerName: sale.CustomerName,
@@ -64,18 +82,35 @@ func toSalesItemViewModel(sale output.GetSalesItemOutput) SalesItemViewModel {
 }
 
 type SaleByIdViewModel struct {
-	Id            int                     `json:"id"`
-	Code          string                  `json:"code"`
-	Date          time.Time               `json:"date"`
-	TotalValue    float64                 `json:"total_value"`
-	SellerName    string                  `json:"seller_name"`
-	CustomerName  string                  `json:"customer_name"`
-	ReceivedValue float64                 `json:"received_value"`
-	FutureRevenue float64                 `json:"future_revenue"`
-	PaymentStatus domain.PaymentStatus    `json:"payment_status"`
-	Payments      []SalePaymentsViewModel `json:"payments"`
-	Items         []SaleItemsViewModel    `json:"items"`
-	Returns       []SaleReturnViewModel   `json:"returns"`
+	Id            int                        `json:"id"`
+	Code          string                     `json:"code"`
+	Date          time.Time                  `json:"date"`
+	TotalValue    float64                    `json:"total_value"`
+	SellerName    string                     `json:"seller_name"`
+	CustomerName  string                     `json:"customer_name"`
+	ReceivedValue float64                    `json:"received_value"`
+	FutureRevenue float64                    `json:"future_revenue"`
+	PaymentStatus domain.PaymentStatus       `json:"payment_status"`
+	Payments      []SalePaymentsViewModel    `json:"payments"`
+	Items         []SaleItemsViewModel       `json:"items"`
+	Returns       []SaleReturnViewModel      `json:"returns"`
+	Cancellation  *SaleCancellationViewModel `json:"cancellation"`
+	Discount      *SaleDiscountViewModel     `json:"discount"`
+	CashReceived  float64                    `json:"cash_received"`
+	ChangeAmount  float64                    `json:"change_amount"`
+}
+
+type SaleDiscountViewModel struct {
+	Type   domain.DiscountType `json:"type"`
+	Value  float64             `json:"value"`
+	Reason string              `json:"reason"`
+	Amount float64             `json:"amount"`
+}
+
+type SaleCancellationViewModel struct {
+	CancelDate  string `json:"cancel_date"`
+	Reason      string `json:"reason"`
+	CancelledBy string `json:"cancelled_by"`
 }
 
 type SalePaymentsViewModel struct {
@@ -91,22 +126,35 @@ type SalePaymentsItemViewModel struct {
 	PaidDate          *string              `json:"paid_date"`
 	PaymentStatus     domain.PaymentStatus `json:"payment_status"`
 	PaymentType       domain.PaymentType   `json:"payment_type"`
+	ReceivedValue     float64              `json:"received_value"`
+	RemainingValue    float64              `json:"remaining_value"`
+	FineValue         float64              `json:"fine_value"`
+	InterestValue     float64              `json:"interest_value"`
+	CardBrand         domain.CardBrand     `json:"card_brand"`
+	CardFeeValue      float64              `json:"card_fee_value"`
+	NetValue          float64              `json:"net_value"`
+	SettlementDate    *string              `json:"settlement_date"`
 }
 
 type SaleItemsViewModel struct {
-	SkuId       int64   `json:"sku_id"`
-	Code        string  `json:"code"`
-	Description string  `json:"description"`
-	Quantity    float64 `json:"quantity"`
-	UnitPrice   float64 `json:"unit_price"`
-	TotalValue  float64 `json:"total_value"`
+	SkuId              int64                  `json:"sku_id"`
+	Code               string                 `json:"code"`
+	Description        string                 `json:"description"`
+	Quantity           float64                `json:"quantity"`
+	UnitPrice          float64                `json:"unit_price"`
+	GrossValue         float64                `json:"gross_value"`
+	Discount           *SaleDiscountViewModel `json:"discount"`
+	SaleDiscountAmount float64                `json:"sale_discount_amount"`
+	TotalValue         float64                `json:"total_value"`
+	UnitCost           *float64               `json:"unit_cost"`
+	GrossMargin        *float64               `json:"gross_margin"`
 }
 
 type SaleReturnViewModel struct {
-	Id         int64                    `json:"id"`
-	ReturnDate string                   `json:"return_date"`
-	Returner   string                   `json:"returner"`
-	Reason     string                   `json:"reason"`
+	Id         int64                     `json:"id"`
+	ReturnDate string                    `json:"return_date"`
+	Returner   string                    `json:"returner"`
+	Reason     string                    `json:"reason"`
 	Items      []SaleReturnItemViewModel `json:"items"`
 }
 
@@ -132,6 +180,33 @@ func ToSaleByIdViewModel(sale output.GetSaleByIdOutput, paymentGroupOutput []out
 		Payments:      toSalePaymentsViewModel(paymentGroupOutput),
 		Items:         toSaleItemsViewModel(itemsOutput),
 		Returns:       toSalesReturnsViewModel(returnsOutput),
+		Cancellation:  toSaleCancellationViewModel(sale.Cancellation),
+		Discount:      toSaleDiscountViewModel(sale.Discount, sale.DiscountValue),
+		CashReceived:  sale.CashReceived,
+		ChangeAmount:  sale.ChangeAmount,
+	}
+}
+
+func toSaleDiscountViewModel(discount domain.SalesDiscount, amount float64) *SaleDiscountViewModel {
+	if discount.IsEmpty() {
+		return nil
+	}
+	return &SaleDiscountViewModel{
+		Type:   discount.Type,
+		Value:  discount.Value,
+		Reason: discount.Reason,
+		Amount: amount,
+	}
+}
+
+func toSaleCancellationViewModel(cancellation *domain.GetSalesCancellationOutput) *SaleCancellationViewModel {
+	if cancellation == nil {
+		return nil
+	}
+	return &SaleCancellationViewModel{
+		CancelDate:  cancellation.CancelDate.Format(time.RFC3339),
+		Reason:      cancellation.Reason,
+		CancelledBy: cancellation.CancelledBy,
 	}
 }
 
@@ -154,6 +229,11 @@ func toSalePaymentsItemViewModel(payments []output.GetSalesPaymentOutput) []Sale
 		if payment.PaidDate != nil {
 			paidDate = payment.PaidDate.Format(time.DateOnly)
 		}
+		var settlementDate *string
+		if payment.SettlementDate != nil {
+			formatted := payment.SettlementDate.Format(time.DateOnly)
+			settlementDate = &formatted
+		}
 		paymentsViewModel[i] = SalePaymentsItemViewModel{
 			Id:                payment.Id,
 			InstallmentNumber: payment.InstallmentNumber,
@@ -162,6 +242,14 @@ func toSalePaymentsItemViewModel(payments []output.GetSalesPaymentOutput) []Sale
 			PaidDate:          &paidDate,
 			PaymentStatus:     payment.PaymentStatus,
 			PaymentType:       payment.PaymentType,
+			ReceivedValue:     payment.ReceivedValue,
+			RemainingValue:    payment.GetRemainingValue(),
+			FineValue:         payment.FineValue,
+			InterestValue:     payment.InterestValue,
+			CardBrand:         payment.CardBrand,
+			CardFeeValue:      payment.CardFeeValue,
+			NetValue:          payment.GetNetValue(),
+			SettlementDate:    settlementDate,
 		}
 	}
 	return paymentsViewModel
@@ -171,12 +259,17 @@ func toSaleItemsViewModel(itemsOutput []output.GetItemsOutput) []SaleItemsViewMo
 	itemsViewModel := make([]SaleItemsViewModel, len(itemsOutput))
 	for i, item := range itemsOutput {
 		itemsViewModel[i] = SaleItemsViewModel{
-			SkuId:       item.Sku.Id,
-			Code:        item.Sku.Code,
-			Description: item.Sku.GetName(),
-			Quantity:    item.Quantity,
-			UnitPrice:   item.Sku.Price,
-			TotalValue:  item.Sku.Price * item.Quantity,
+			SkuId:              item.Sku.Id,
+			Code:               item.Sku.Code,
+			Description:        item.Sku.GetName(),
+			Quantity:           item.Quantity,
+			UnitPrice:          item.Sku.Price,
+			GrossValue:         item.Sku.Price * item.Quantity,
+			Discount:           toSaleDiscountViewModel(item.Discount, item.DiscountAmount),
+			SaleDiscountAmount: item.SaleDiscountAmount,
+			TotalValue:         item.GetTotal(),
+			UnitCost:           item.UnitCost,
+			GrossMargin:        item.GetGrossMargin(),
 		}
 	}
 	return itemsViewModel
@@ -205,3 +298,126 @@ func toSalesReturnsViewModel(returnsOutput []output.GetSalesReturnOutput) []Sale
 	}
 	return viewModels
 }
+
+type SaleVersionViewModel struct {
+	Version      int                          `json:"version"`
+	Date         string                       `json:"date"`
+	Type         domain.SalesVersionType      `json:"type"`
+	CustomerName string                       `json:"customer_name"`
+	CreatedBy    string                       `json:"created_by"`
+	TotalValue   float64                      `json:"total_value"`
+	Items        []SaleItemsViewModel         `json:"items"`
+	Payments     []SalePaymentsViewModel      `json:"payments"`
+	Changes      *SaleVersionChangesViewModel `json:"changes"`
+}
+
+type SaleVersionChangesViewModel struct {
+	PreviousCustomerName *string                          `json:"previous_customer_name"`
+	PreviousTotalValue   float64                          `json:"previous_total_value"`
+	Items                []SaleVersionItemChangeViewModel `json:"items"`
+}
+
+type SaleVersionItemChangeViewModel struct {
+	SkuId             int64   `json:"sku_id"`
+	Code              string  `json:"code"`
+	Description       string  `json:"description"`
+	Type              string  `json:"type"`
+	PreviousQuantity  float64 `json:"previous_quantity"`
+	Quantity          float64 `json:"quantity"`
+	PreviousUnitPrice float64 `json:"previous_unit_price"`
+	UnitPrice         float64 `json:"unit_price"`
+}
+
+func ToSaleVersionsViewModel(versions []output.GetSalesVersionOutput) []SaleVersionViewModel {
+	viewModels := make([]SaleVersionViewModel, len(versions))
+	for i, version := range versions {
+		viewModels[i] = SaleVersionViewModel{
+			Version:      version.Version,
+			Date:         version.Date.Format(time.RFC3339),
+			Type:         version.Type,
+			CustomerName: version.CustomerName,
+			CreatedBy:    version.CreatedBy,
+			TotalValue:   version.TotalValue,
+			Items:        toSaleItemsViewModel(version.Items),
+			Payments:     toSalePaymentsViewModel(version.Payments),
+			Changes:      toSaleVersionChangesViewModel(version.Changes),
+		}
+	}
+	return viewModels
+}
+
+func toSaleVersionChangesViewModel(changes *output.GetSalesVersionChangesOutput) *SaleVersionChangesViewModel {
+	if changes == nil {
+		return nil
+	}
+	items := make([]SaleVersionItemChangeViewModel, len(changes.Items))
+	for i, item := range changes.Items {
+		items[i] = SaleVersionItemChangeViewModel{
+			SkuId:             item.Sku.Id,
+			Code:              item.Sku.Code,
+			Description:       item.Sku.GetName(),
+			Type:              item.Type,
+			PreviousQuantity:  item.PreviousQuantity,
+			Quantity:          item.Quantity,
+			PreviousUnitPrice: item.PreviousUnitPrice,
+			UnitPrice:         item.UnitPrice,
+		}
+	}
+	return &SaleVersionChangesViewModel{
+		PreviousCustomerName: changes.PreviousCustomerName,
+		PreviousTotalValue:   changes.PreviousTotalValue,
+		Items:                items,
+	}
+}
+
+type PaymentReceiptViewModel struct {
+	Id          int64              `json:"id"`
+	Value       float64            `json:"value"`
+	ReceiptDate string             `json:"receipt_date"`
+	PaymentType domain.PaymentType `json:"payment_type"`
+	ReceivedBy  string             `json:"received_by"`
+}
+
+func ToPaymentReceiptsViewModel(receipts []output.GetPaymentReceiptOutput) []PaymentReceiptViewModel {
+	receiptsViewModel := make([]PaymentReceiptViewModel, len(receipts))
+	for i, receipt := range receipts {
+		receiptsViewModel[i] = PaymentReceiptViewModel{
+			Id:          receipt.Id,
+			Value:       receipt.Value,
+			ReceiptDate: receipt.ReceiptDate.Format(time.DateOnly),
+			PaymentType: receipt.PaymentType,
+			ReceivedBy:  receipt.ReceivedBy,
+		}
+	}
+	return receiptsViewModel
+}
+
+type SaleRenegotiationViewModel struct {
+	Id                 int64   `json:"id"`
+	RenegotiationDate  string  `json:"renegotiation_date"`
+	Installments       int     `json:"installments"`
+	FirstDueDate       string  `json:"first_due_date"`
+	OpenValue          float64 `json:"open_value"`
+	InterestPercentage float64 `json:"interest_percentage"`
+	DiscountPercentage float64 `json:"discount_percentage"`
+	TotalValue         float64 `json:"total_value"`
+	CreatedBy          string  `json:"created_by"`
+}
+
+func ToSaleRenegotiationsViewModel(renegotiations []output.GetSalesRenegotiationOutput) []SaleRenegotiationViewModel {
+	renegotiationsViewModel := make([]SaleRenegotiationViewModel, len(renegotiations))
+	for i, renegotiation := range renegotiations {
+		renegotiationsViewModel[i] = SaleRenegotiationViewModel{
+			Id:                 renegotiation.Id,
+			RenegotiationDate:  renegotiation.RenegotiationDate.Format(time.RFC3339),
+			Installments:       renegotiation.Installments,
+			FirstDueDate:       renegotiation.FirstDueDate.Format(time.DateOnly),
+			OpenValue:          renegotiation.OpenValue,
+			InterestPercentage: renegotiation.InterestPercentage,
+			DiscountPercentage: renegotiation.DiscountPercentage,
+			TotalValue:         renegotiation.TotalValue,
+			CreatedBy:          renegotiation.CreatedBy,
+		}
+	}
+	return renegotiationsViewModel
+}
@@ -45,3 +45,75 @@ func TestToSaleByIdViewModelIncludesSkuIdOnItems(t *testing.T) {
 		t.Fatalf("expected sku_id 42, got %d", viewModel.Items[0].SkuId)
 	}
 }
+
+func TestToSaleByIdViewModelIncludesGrossMarginOnItems(t *testing.T) {
+	cost := 6.0
+	itemsOutput := []output.GetItemsOutput{
+		{Sku: domain.Sku{Id: 1, Price: 10}, Quantity: 3, UnitPrice: 10, UnitCost: &cost},
+		{Sku: domain.Sku{Id: 2, Price: 10}, Quantity: 1, UnitPrice: 10},
+	}
+
+	viewModel := ToSaleByIdViewModel(output.GetSaleByIdOutput{}, nil, itemsOutput, nil)
+	if viewModel.Items[0].GrossMargin == nil || *viewModel.Items[0].GrossMargin != 12 {
+		t.Fatalf("expected gross margin 12, got %v", viewModel.Items[0].GrossMargin)
+	}
+	if viewModel.Items[1].UnitCost != nil || viewModel.Items[1].GrossMargin != nil {
+		t.Fatalf("expected no margin without cost, got %+v", viewModel.Items[1])
+	}
+}
+
+func TestToSaleByIdViewModelIncludesCancellation(t *testing.T) {
+	cancelDate := time.Date(2026, 3, 10, 14, 0, 0, 0, time.UTC)
+	sale := output.GetSaleByIdOutput{
+		Id:            1,
+		PaymentStatus: domain.PaymentStatusCancel,
+		Cancellation: &domain.GetSalesCancellationOutput{
+			CancelDate:  cancelDate,
+			Reason:      "Venda errada",
+			CancelledBy: "Admin",
+		},
+	}
+
+	viewModel := ToSaleByIdViewModel(sale, nil, nil, nil)
+	if viewModel.Cancellation == nil {
+		t.Fatalf("expected cancellation to be mapped")
+	}
+	if viewModel.Cancellation.CancelDate != cancelDate.Format(time.RFC3339) || viewModel.Cancellation.Reason != "Venda errada" || viewModel.Cancellation.CancelledBy != "Admin" {
+		t.Fatalf("unexpected cancellation: %+v", viewModel.Cancellation)
+	}
+
+	sale.Cancellation = nil
+	if ToSaleByIdViewModel(sale, nil, nil, nil).Cancellation != nil {
+		t.Fatalf("expected nil cancellation for active sale")
+	}
+}
+
+func TestToSaleVersionsViewModel(t *testing.T) {
+	previousCustomer := "Ana"
+	versions := []output.GetSalesVersionOutput{
+		{GetSalesVersionOutput: domain.GetSalesVersionOutput{Version: 1, Type: domain.SalesVersionTypeSale, CustomerName: "Ana"}},
+		{
+			GetSalesVersionOutput: domain.GetSalesVersionOutput{Version: 2, Type: domain.SalesVersionTypeEdit, CustomerName: "Bia", CreatedBy: "Admin"},
+			TotalValue:            20,
+			Changes: &output.GetSalesVersionChangesOutput{
+				PreviousCustomerName: &previousCustomer,
+				PreviousTotalValue:   10,
+				Items: []output.GetSalesVersionItemChangeOutput{
+					{Sku: domain.Sku{Id: 1, Code: "A", Product: domain.Product{Name: "Camisa"}}, Type: output.SalesItemChangeChanged, PreviousQuantity: 1, Quantity: 2, PreviousUnitPrice: 10, UnitPrice: 10},
+				},
+			},
+		},
+	}
+
+	viewModels := ToSaleVersionsViewModel(versions)
+	if len(viewModels) != 2 || viewModels[0].Changes != nil {
+		t.Fatalf("unexpected view models: %+v", viewModels)
+	}
+	changes := viewModels[1].Changes
+	if viewModels[1].Type != domain.SalesVersionTypeEdit || viewModels[1].CreatedBy != "Admin" || changes == nil {
+		t.Fatalf("unexpected version: %+v", viewModels[1])
+	}
+	if *changes.PreviousCustomerName != "Ana" || len(changes.Items) != 1 || changes.Items[0].SkuId != 1 || changes.Items[0].Quantity != 2 {
+		t.Fatalf("unexpected changes: %+v", changes)
+	}
+}
@@ -45,12 +45,13 @@ func ToSkuInventoryViewModel(inventory domain.GetSkuInventoryOutput) SkuInventor
 }
 
 type SkuTransactionViewModel struct {
-	Date          string  `json:"date"`
-	Type          string  `json:"type"`
-	Quantity      float64 `json:"quantity"`
-	Origin        *string `json:"origin"`
-	Destination   *string `json:"destination"`
-	Justification *string `json:"justification"`
+	Date          string   `json:"date"`
+	Type          string   `json:"type"`
+	Quantity      float64  `json:"quantity"`
+	Origin        *string  `json:"origin"`
+	Destination   *string  `json:"destination"`
+	Justification *string  `json:"justification"`
+	UnitCost      *float64 `json:"unit_cost"`
 }
 
 func ToSkuTransactionViewModel(transaction domain.GetInventoryTransactionsOutput) SkuTransactionViewModel {
@@ -84,6 +85,7 @@ func ToSkuTransactionViewModel(transaction domain.GetInventoryTransactionsOutput
 		Origin:        origin,
 		Destination:   destination,
 		Justification: transaction.Justification,
+		UnitCost:      transaction.UnitCost,
 	}
 }
 
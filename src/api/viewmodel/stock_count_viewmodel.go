@@ -0,0 +1,115 @@
+package viewmodel
+
+import (
+	"math"
+	"time"
+
+	"github.com/bncunha/erp-api/src/application/service/output"
+	"github.com/bncunha/erp-api/src/domain"
+)
+
+var stockCountStatusMap = map[domain.StockCountStatus]string{
+	domain.StockCountStatusOpen:     "Em aberto",
+	domain.StockCountStatusPosted:   "Ajustada",
+	domain.StockCountStatusCanceled: "Cancelada",
+}
+
+type StockCountsViewModel struct {
+	Id            int64      `json:"id"`
+	Code          string     `json:"code"`
+	InventoryId   int64      `json:"inventory_id"`
+	InventoryType string     `json:"inventory_type"`
+	UserName      *string    `json:"user_name"`
+	Status        string     `json:"status"`
+	StatusLabel   string     `json:"status_label"`
+	OpenedAt      time.Time  `json:"opened_at"`
+	PostedAt      *time.Time `json:"posted_at"`
+	TotalItems    int64      `json:"total_items"`
+	CountedItems  int64      `json:"counted_items"`
+	VarianceItems int64      `json:"variance_items"`
+}
+
+func ToStockCountsViewModel(counts []output.GetStockCountsOutput) []StockCountsViewModel {
+	viewModels := make([]StockCountsViewModel, len(counts))
+	for i, count := range counts {
+		viewModels[i] = StockCountsViewModel{
+			Id:            count.Id,
+			Code:          count.Code,
+			InventoryId:   count.InventoryId,
+			InventoryType: inventoryTypeMap[count.InventoryType],
+			UserName:      count.UserName,
+			Status:        string(count.Status),
+			StatusLabel:   stockCountStatusMap[count.Status],
+			OpenedAt:      count.OpenedAt,
+			PostedAt:      count.PostedAt,
+			TotalItems:    count.TotalItems,
+			CountedItems:  count.CountedItems,
+			VarianceItems: count.VarianceItems,
+		}
+	}
+	return viewModels
+}
+
+type StockCountViewModel struct {
+	Id               int64                     `json:"id"`
+	Code             string                    `json:"code"`
+	InventoryId      int64                     `json:"inventory_id"`
+	InventoryType    string                    `json:"inventory_type"`
+	UserName         *string                   `json:"user_name"`
+	Status           string                    `json:"status"`
+	StatusLabel      string                    `json:"status_label"`
+	Notes            string                    `json:"notes"`
+	OpenedAt         time.Time                 `json:"opened_at"`
+	PostedAt         *time.Time                `json:"posted_at"`
+	SurplusQuantity  float64                   `json:"surplus_quantity"`
+	ShortageQuantity float64                   `json:"shortage_quantity"`
+	VarianceValue    float64                   `json:"variance_value"`
+	Items            []StockCountItemViewModel `json:"items"`
+}
+
+type StockCountItemViewModel struct {
+	SkuId            int64    `json:"sku_id"`
+	SkuCode          string   `json:"sku_code"`
+	ProductName      string   `json:"product_name"`
+	ExpectedQuantity float64  `json:"expected_quantity"`
+	CountedQuantity  *float64 `json:"counted_quantity"`
+	Variance         float64  `json:"variance"`
+	VarianceValue    float64  `json:"variance_value"`
+}
+
+func ToStockCountViewModel(count output.GetStockCountOutput) StockCountViewModel {
+	viewModel := StockCountViewModel{
+		Id:            count.Id,
+		Code:          count.Code,
+		InventoryId:   count.InventoryId,
+		InventoryType: inventoryTypeMap[count.InventoryType],
+		UserName:      count.UserName,
+		Status:        string(count.Status),
+		StatusLabel:   stockCountStatusMap[count.Status],
+		Notes:         count.Notes,
+		OpenedAt:      count.OpenedAt,
+		PostedAt:      count.PostedAt,
+		Items:         make([]StockCountItemViewModel, len(count.Items)),
+	}
+	for i, item := range count.Items {
+		variance := item.GetVariance()
+		varianceValue := math.Round(variance*item.Sku.Price*100) / 100
+		viewModel.Items[i] = StockCountItemViewModel{
+			SkuId:            item.Sku.Id,
+			SkuCode:          item.Sku.Code,
+			ProductName:      item.Sku.GetName(),
+			ExpectedQuantity: item.ExpectedQuantity,
+			CountedQuantity:  item.CountedQuantity,
+			Variance:         variance,
+			VarianceValue:    varianceValue,
+		}
+		if variance > 0 {
+			viewModel.SurplusQuantity += variance
+		} else {
+			viewModel.ShortageQuantity -= variance
+		}
+		viewModel.VarianceValue += varianceValue
+	}
+	viewModel.VarianceValue = math.Round(viewModel.VarianceValue*100) / 100
+	return viewModel
+}
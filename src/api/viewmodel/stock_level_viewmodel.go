@@ -0,0 +1,85 @@
+package viewmodel
+
+import (
+	"github.com/bncunha/erp-api/src/application/service/output"
+	"github.com/bncunha/erp-api/src/domain"
+)
+
+var stockReorderSourceMap = map[domain.StockReorderSource]string{
+	domain.StockReorderSourcePurchase: "Comprar",
+	domain.StockReorderSourceTransfer: "Transferir do estoque central",
+}
+
+type StockLevelViewModel struct {
+	Id            int64   `json:"id"`
+	InventoryId   int64   `json:"inventory_id"`
+	InventoryType string  `json:"inventory_type"`
+	UserName      *string `json:"user_name"`
+	SkuId         int64   `json:"sku_id"`
+	SkuCode       string  `json:"sku_code"`
+	ProductName   string  `json:"product_name"`
+	MinQuantity   float64 `json:"min_quantity"`
+	MaxQuantity   float64 `json:"max_quantity"`
+}
+
+func ToStockLevelsViewModel(levels []output.GetStockLevelOutput) []StockLevelViewModel {
+	viewModels := make([]StockLevelViewModel, len(levels))
+	for i, level := range levels {
+		viewModels[i] = StockLevelViewModel{
+			Id:            level.Id,
+			InventoryId:   level.InventoryId,
+			InventoryType: inventoryTypeMap[level.InventoryType],
+			UserName:      level.UserName,
+			SkuId:         level.Sku.Id,
+			SkuCode:       level.Sku.Code,
+			ProductName:   level.Sku.GetName(),
+			MinQuantity:   level.MinQuantity,
+			MaxQuantity:   level.MaxQuantity,
+		}
+	}
+	return viewModels
+}
+
+type StockReorderSuggestionViewModel struct {
+	Source            domain.StockReorderSource `json:"source"`
+	SourceLabel       string                    `json:"source_label"`
+	InventoryId       int64                     `json:"inventory_id"`
+	InventoryType     string                    `json:"inventory_type"`
+	UserName          *string                   `json:"user_name"`
+	SkuId             int64                     `json:"sku_id"`
+	SkuCode           string                    `json:"sku_code"`
+	ProductName       string                    `json:"product_name"`
+	Quantity          float64                   `json:"quantity"`
+	MinQuantity       float64                   `json:"min_quantity"`
+	MaxQuantity       float64                   `json:"max_quantity"`
+	SoldQuantity      float64                   `json:"sold_quantity"`
+	DailySales        float64                   `json:"daily_sales"`
+	PrimaryQuantity   float64                   `json:"primary_quantity"`
+	NeededQuantity    float64                   `json:"needed_quantity"`
+	SuggestedQuantity float64                   `json:"suggested_quantity"`
+}
+
+func ToStockReorderSuggestionsViewModel(suggestions []output.StockReorderSuggestionOutput) []StockReorderSuggestionViewModel {
+	viewModels := make([]StockReorderSuggestionViewModel, len(suggestions))
+	for i, suggestion := range suggestions {
+		viewModels[i] = StockReorderSuggestionViewModel{
+			Source:            suggestion.Source,
+			SourceLabel:       stockReorderSourceMap[suggestion.Source],
+			InventoryId:       suggestion.Level.InventoryId,
+			InventoryType:     inventoryTypeMap[suggestion.Level.InventoryType],
+			UserName:          suggestion.Level.UserName,
+			SkuId:             suggestion.Level.Sku.Id,
+			SkuCode:           suggestion.Level.Sku.Code,
+			ProductName:       suggestion.Level.Sku.GetName(),
+			Quantity:          suggestion.Quantity,
+			MinQuantity:       suggestion.Level.MinQuantity,
+			MaxQuantity:       suggestion.Level.MaxQuantity,
+			SoldQuantity:      suggestion.SoldQuantity,
+			DailySales:        suggestion.DailySales,
+			PrimaryQuantity:   suggestion.PrimaryQuantity,
+			NeededQuantity:    suggestion.NeededQuantity,
+			SuggestedQuantity: suggestion.SuggestedQuantity,
+		}
+	}
+	return viewModels
+}
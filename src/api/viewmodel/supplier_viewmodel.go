@@ -0,0 +1,31 @@
+package viewmodel
+
+import "github.com/bncunha/erp-api/src/domain"
+
+type SupplierViewModel struct {
+	Id          int64  `json:"id"`
+	Name        string `json:"name"`
+	Cnpj        string `json:"cnpj"`
+	ContactName string `json:"contact_name"`
+	PhoneNumber string `json:"phone_number"`
+	Email       string `json:"email"`
+}
+
+func ToSupplierViewModel(supplier domain.Supplier) SupplierViewModel {
+	return SupplierViewModel{
+		Id:          supplier.Id,
+		Name:        supplier.Name,
+		Cnpj:        supplier.Cnpj,
+		ContactName: supplier.ContactName,
+		PhoneNumber: supplier.PhoneNumber,
+		Email:       supplier.Email,
+	}
+}
+
+func ToSuppliersViewModel(suppliers []domain.Supplier) []SupplierViewModel {
+	viewModels := make([]SupplierViewModel, len(suppliers))
+	for i, supplier := range suppliers {
+		viewModels[i] = ToSupplierViewModel(supplier)
+	}
+	return viewModels
+}
@@ -0,0 +1,20 @@
+package ports
+
+// PdfPort gera um PDF a partir de um texto de marcação simples, uma instrução
+// por linha:
+//
+//	# texto            título
+//	## texto           subtítulo em negrito
+//	> texto            texto pequeno
+//	a | b | c          linha com colunas; a primeira alinhada à esquerda e as
+//	                   demais à direita (aceita os prefixos acima)
+//	---                linha horizontal
+//	- - -              linha tracejada de recorte
+//	[bloco] [/bloco]   mantém as linhas entre as marcações na mesma página
+//	[pagina]           quebra de página
+//
+// Linhas em branco geram um espaçamento e as demais linhas são texto comum,
+// quebrado automaticamente na largura da página.
+type PdfPort interface {
+	Render(markup string) ([]byte, error)
+}
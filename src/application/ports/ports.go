@@ -3,16 +3,22 @@ package ports
 import "github.com/bncunha/erp-api/src/domain"
 
 type Ports struct {
-	Encrypto  domain.Encrypto
-	EmailPort EmailPort
+	Encrypto   domain.Encrypto
+	EmailPort  EmailPort
+	PdfPort    PdfPort
+	QrCodePort QrCodePort
 }
 
 func NewPorts(
 	encrypto domain.Encrypto,
 	emailPort EmailPort,
+	pdfPort PdfPort,
+	qrCodePort QrCodePort,
 ) *Ports {
 	return &Ports{
-		Encrypto:  encrypto,
-		EmailPort: emailPort,
+		Encrypto:   encrypto,
+		EmailPort:  emailPort,
+		PdfPort:    pdfPort,
+		QrCodePort: qrCodePort,
 	}
 }
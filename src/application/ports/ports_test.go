@@ -39,10 +39,18 @@ func (f *fakeEmailPort) Send(senderEmail string, senderName string, toEmail stri
 	return nil
 }
 
+type fakePdfPort struct{}
+
+func (fakePdfPort) Render(markup string) ([]byte, error) { return []byte(markup), nil }
+
+type fakeQrCodePort struct{}
+
+func (fakeQrCodePort) Encode(content string, size int) ([]byte, error) { return []byte(content), nil }
+
 func TestNewPorts(t *testing.T) {
 	encrypto := fakeEncrypto{}
 	emailPort := &fakeEmailPort{}
-	ports := NewPorts(encrypto, emailPort)
+	ports := NewPorts(encrypto, emailPort, fakePdfPort{}, fakeQrCodePort{})
 	if ports.Encrypto == nil {
 		t.Fatalf("expected encrypto implementation to be set")
 	}
@@ -56,4 +64,10 @@ func TestNewPorts(t *testing.T) {
 	if err := ports.EmailPort.Send("sender@example.com", "Sender", "to@example.com", "To", "subject", "body"); err != nil {
 		t.Fatalf("expected email port to be callable")
 	}
+	if ports.PdfPort == nil {
+		t.Fatalf("expected pdf port to be set")
+	}
+	if ports.QrCodePort == nil {
+		t.Fatalf("expected qr code port to be set")
+	}
 }
@@ -0,0 +1,7 @@
+package ports
+
+// QrCodePort gera a imagem PNG de um QR Code com o conteúdo informado. size é
+// a largura aproximada da imagem em pixels, incluindo a margem obrigatória.
+type QrCodePort interface {
+	Encode(content string, size int) ([]byte, error)
+}
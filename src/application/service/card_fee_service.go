@@ -0,0 +1,55 @@
+package service
+
+import (
+	"context"
+
+	request "github.com/bncunha/erp-api/src/api/requests"
+	"github.com/bncunha/erp-api/src/application/service/output"
+	"github.com/bncunha/erp-api/src/domain"
+)
+
+type CardFeeService interface {
+	GetRules(ctx context.Context) ([]output.GetCardFeeRuleOutput, error)
+	SaveRule(ctx context.Context, request request.SaveCardFeeRuleRequest) (int64, error)
+	DeleteRule(ctx context.Context, id int64) error
+}
+
+type cardFeeService struct {
+	cardFeeRuleRepository domain.CardFeeRuleRepository
+}
+
+func NewCardFeeService(cardFeeRuleRepository domain.CardFeeRuleRepository) CardFeeService {
+	return &cardFeeService{cardFeeRuleRepository}
+}
+
+func (s *cardFeeService) GetRules(ctx context.Context) ([]output.GetCardFeeRuleOutput, error) {
+	return s.cardFeeRuleRepository.GetAll(ctx)
+}
+
+func (s *cardFeeService) SaveRule(ctx context.Context, request request.SaveCardFeeRuleRequest) (int64, error) {
+	if err := request.Validate(); err != nil {
+		return 0, err
+	}
+
+	installments := request.Installments
+	if installments == 0 {
+		installments = 1
+	}
+	settlementDays := domain.DefaultCreditSettlementDays
+	if request.PaymentType == domain.PaymentTypeDebitCard {
+		settlementDays = domain.DefaultDebitSettlementDays
+	}
+	if request.SettlementDays != nil {
+		settlementDays = *request.SettlementDays
+	}
+
+	rule := domain.NewCardFeeRule(request.Brand, request.PaymentType, installments, request.FeePercentage, settlementDays)
+	if err := rule.Validate(); err != nil {
+		return 0, err
+	}
+	return s.cardFeeRuleRepository.Save(ctx, rule)
+}
+
+func (s *cardFeeService) DeleteRule(ctx context.Context, id int64) error {
+	return s.cardFeeRuleRepository.Delete(ctx, id)
+}
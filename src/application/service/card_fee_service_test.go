@@ -0,0 +1,71 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	request "github.com/bncunha/erp-api/src/api/requests"
+	"github.com/bncunha/erp-api/src/domain"
+)
+
+func TestCardFeeServiceSaveRule(t *testing.T) {
+	repo := &stubCardFeeRuleRepository{}
+	service := NewCardFeeService(repo)
+
+	_, err := service.SaveRule(context.Background(), request.SaveCardFeeRuleRequest{
+		Brand:         domain.CardBrandVisa,
+		PaymentType:   domain.PaymentTypeCreditCard,
+		Installments:  3,
+		FeePercentage: 4.5,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if repo.saved == nil || repo.saved.Installments != 3 || repo.saved.FeePercentage != 4.5 || repo.saved.SettlementDays != domain.DefaultCreditSettlementDays {
+		t.Fatalf("unexpected saved rule: %+v", repo.saved)
+	}
+
+	days := 0
+	_, err = service.SaveRule(context.Background(), request.SaveCardFeeRuleRequest{
+		Brand:          domain.CardBrandElo,
+		PaymentType:    domain.PaymentTypeDebitCard,
+		FeePercentage:  1.2,
+		SettlementDays: &days,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if repo.saved.Installments != 1 || repo.saved.SettlementDays != 0 {
+		t.Fatalf("expected debit rule with one installment and same day settlement, got %+v", repo.saved)
+	}
+}
+
+func TestCardFeeServiceSaveRuleErrors(t *testing.T) {
+	repo := &stubCardFeeRuleRepository{}
+	service := NewCardFeeService(repo)
+
+	if _, err := service.SaveRule(context.Background(), request.SaveCardFeeRuleRequest{Brand: domain.CardBrandVisa, PaymentType: domain.PaymentTypePix}); err == nil {
+		t.Fatalf("expected validation error")
+	}
+	if _, err := service.SaveRule(context.Background(), request.SaveCardFeeRuleRequest{Brand: domain.CardBrandVisa, PaymentType: domain.PaymentTypeDebitCard, Installments: 2}); err != domain.ErrCardFeeInstallmentsInvalid {
+		t.Fatalf("expected installments error, got %v", err)
+	}
+	if repo.saved != nil {
+		t.Fatalf("expected rule not to be saved")
+	}
+
+	repo.saveErr = errors.New("db")
+	if _, err := service.SaveRule(context.Background(), request.SaveCardFeeRuleRequest{Brand: domain.CardBrandVisa, PaymentType: domain.PaymentTypeDebitCard}); err == nil {
+		t.Fatalf("expected repository error")
+	}
+}
+
+func TestCardFeeServiceDeleteRule(t *testing.T) {
+	repo := &stubCardFeeRuleRepository{}
+	service := NewCardFeeService(repo)
+
+	if err := service.DeleteRule(context.Background(), 7); err != nil || repo.deletedId != 7 {
+		t.Fatalf("expected rule 7 deleted, got %d (%v)", repo.deletedId, err)
+	}
+}
@@ -0,0 +1,144 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	request "github.com/bncunha/erp-api/src/api/requests"
+	"github.com/bncunha/erp-api/src/application/constants"
+	"github.com/bncunha/erp-api/src/application/service/output"
+	"github.com/bncunha/erp-api/src/domain"
+)
+
+type CashRegisterService interface {
+	GetSettings(ctx context.Context) (output.GetCashRegisterSettingsOutput, error)
+	SaveSettings(ctx context.Context, request request.SaveCashRegisterSettingsRequest) error
+	Open(ctx context.Context, request request.OpenCashRegisterRequest) (int64, error)
+	GetCurrent(ctx context.Context) (output.GetCashRegisterSessionOutput, error)
+	GetAll(ctx context.Context) ([]output.GetCashRegisterSessionOutput, error)
+	GetById(ctx context.Context, id int64) (output.GetCashRegisterSessionOutput, error)
+	CreateEntry(ctx context.Context, request request.CreateCashRegisterEntryRequest) (int64, error)
+	Close(ctx context.Context, request request.CloseCashRegisterRequest) (output.GetCashRegisterSessionOutput, error)
+}
+
+type cashRegisterService struct {
+	cashRegisterRepository domain.CashRegisterRepository
+	userRepository         domain.UserRepository
+}
+
+func NewCashRegisterService(cashRegisterRepository domain.CashRegisterRepository, userRepository domain.UserRepository) CashRegisterService {
+	return &cashRegisterService{cashRegisterRepository, userRepository}
+}
+
+func (s *cashRegisterService) GetSettings(ctx context.Context) (output.GetCashRegisterSettingsOutput, error) {
+	return s.cashRegisterRepository.GetSettings(ctx)
+}
+
+func (s *cashRegisterService) SaveSettings(ctx context.Context, request request.SaveCashRegisterSettingsRequest) error {
+	if err := request.Validate(); err != nil {
+		return err
+	}
+	return s.cashRegisterRepository.SaveSettings(ctx, domain.CashRegisterSettings{RequireOpenSession: request.RequireOpenSession})
+}
+
+func (s *cashRegisterService) Open(ctx context.Context, request request.OpenCashRegisterRequest) (int64, error) {
+	if err := request.Validate(); err != nil {
+		return 0, err
+	}
+
+	user, err := s.userRepository.GetById(ctx, s.getUserId(ctx))
+	if err != nil {
+		return 0, err
+	}
+	_, err = s.cashRegisterRepository.GetOpenByUserId(ctx, user.Id)
+	if err == nil {
+		return 0, domain.ErrCashRegisterSessionAlreadyOpen
+	}
+	if err != domain.ErrCashRegisterSessionNotOpen {
+		return 0, err
+	}
+
+	session := domain.NewCashRegisterSession(user, request.OpeningBalance, time.Now())
+	if err := session.Validate(); err != nil {
+		return 0, err
+	}
+	return s.cashRegisterRepository.Open(ctx, session)
+}
+
+// GetCurrent retorna o caixa aberto do usuário logado com seus lançamentos.
+func (s *cashRegisterService) GetCurrent(ctx context.Context) (output.GetCashRegisterSessionOutput, error) {
+	session, err := s.cashRegisterRepository.GetOpenByUserId(ctx, s.getUserId(ctx))
+	if err != nil {
+		return session, err
+	}
+	session.Entries, err = s.cashRegisterRepository.GetEntries(ctx, session.Id)
+	return session, err
+}
+
+func (s *cashRegisterService) GetAll(ctx context.Context) ([]output.GetCashRegisterSessionOutput, error) {
+	userRole, _ := ctx.Value(constants.ROLE_KEY).(string)
+	if userRole == "" {
+		return nil, ErrPermissionDenied
+	}
+	var input domain.GetCashRegisterSessionsInput
+	if userRole == string(domain.UserRoleReseller) {
+		userId := s.getUserId(ctx)
+		input.UserId = &userId
+	}
+	return s.cashRegisterRepository.GetAll(ctx, input)
+}
+
+func (s *cashRegisterService) GetById(ctx context.Context, id int64) (output.GetCashRegisterSessionOutput, error) {
+	session, err := s.cashRegisterRepository.GetById(ctx, id)
+	if err != nil {
+		return session, err
+	}
+	userRole, _ := ctx.Value(constants.ROLE_KEY).(string)
+	if userRole != string(domain.UserRoleAdmin) && session.User.Id != s.getUserId(ctx) {
+		return output.GetCashRegisterSessionOutput{}, domain.ErrCashRegisterSessionNotFound
+	}
+	session.Entries, err = s.cashRegisterRepository.GetEntries(ctx, session.Id)
+	return session, err
+}
+
+// CreateEntry registra uma sangria ou um suprimento no caixa aberto do usuário.
+func (s *cashRegisterService) CreateEntry(ctx context.Context, request request.CreateCashRegisterEntryRequest) (int64, error) {
+	if err := request.Validate(); err != nil {
+		return 0, err
+	}
+
+	session, err := s.GetCurrent(ctx)
+	if err != nil {
+		return 0, err
+	}
+	entry := domain.NewCashRegisterEntry(request.Type, request.Value, request.Description, session.User.Id, time.Now())
+	entry, err = session.AddEntry(entry)
+	if err != nil {
+		return 0, err
+	}
+	return s.cashRegisterRepository.CreateEntry(ctx, nil, entry)
+}
+
+// Close fecha o caixa aberto do usuário registrando o valor contado e a
+// diferença em relação ao valor esperado.
+func (s *cashRegisterService) Close(ctx context.Context, request request.CloseCashRegisterRequest) (output.GetCashRegisterSessionOutput, error) {
+	if err := request.Validate(); err != nil {
+		return output.GetCashRegisterSessionOutput{}, err
+	}
+
+	session, err := s.GetCurrent(ctx)
+	if err != nil {
+		return session, err
+	}
+	if err := session.Close(request.CountedAmount, request.Notes, time.Now()); err != nil {
+		return output.GetCashRegisterSessionOutput{}, err
+	}
+	if err := s.cashRegisterRepository.Close(ctx, session); err != nil {
+		return output.GetCashRegisterSessionOutput{}, err
+	}
+	return session, nil
+}
+
+func (s *cashRegisterService) getUserId(ctx context.Context) int64 {
+	return int64(ctx.Value(constants.USERID_KEY).(float64))
+}
@@ -0,0 +1,121 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	request "github.com/bncunha/erp-api/src/api/requests"
+	"github.com/bncunha/erp-api/src/domain"
+)
+
+func TestCashRegisterServiceOpen(t *testing.T) {
+	repo := &stubCashRegisterRepository{openedId: 3}
+	service := NewCashRegisterService(repo, &stubUserRepository{getById: domain.User{Id: 9}})
+	ctx := ctxWithRoleAndUser(domain.UserRoleReseller, 9)
+
+	id, err := service.Open(ctx, request.OpenCashRegisterRequest{OpeningBalance: 150})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if id != 3 || repo.opened == nil || repo.opened.User.Id != 9 || repo.opened.OpeningBalance != 150 || !repo.opened.IsOpen() {
+		t.Fatalf("unexpected opened session: %d %+v", id, repo.opened)
+	}
+
+	repo = &stubCashRegisterRepository{open: &domain.CashRegisterSession{Id: 1}}
+	service = NewCashRegisterService(repo, &stubUserRepository{getById: domain.User{Id: 9}})
+	if _, err := service.Open(ctx, request.OpenCashRegisterRequest{}); err != domain.ErrCashRegisterSessionAlreadyOpen {
+		t.Fatalf("expected already open error, got %v", err)
+	}
+
+	repo = &stubCashRegisterRepository{openErr: errors.New("db")}
+	service = NewCashRegisterService(repo, &stubUserRepository{getById: domain.User{Id: 9}})
+	if _, err := service.Open(ctx, request.OpenCashRegisterRequest{}); err == nil || repo.opened != nil {
+		t.Fatalf("expected repository error")
+	}
+}
+
+func TestCashRegisterServiceCreateEntry(t *testing.T) {
+	open := domain.NewCashRegisterSession(domain.User{Id: 9}, 100, time.Now())
+	open.Id = 4
+	repo := &stubCashRegisterRepository{open: &open, entries: []domain.CashRegisterEntry{
+		domain.NewCashRegisterEntry(domain.CashRegisterEntryTypeSale, 50, "Venda", 9, time.Now()),
+	}}
+	service := NewCashRegisterService(repo, &stubUserRepository{})
+	ctx := ctxWithRoleAndUser(domain.UserRoleReseller, 9)
+
+	if _, err := service.CreateEntry(ctx, request.CreateCashRegisterEntryRequest{Type: domain.CashRegisterEntryTypeWithdrawal, Value: 120, Description: "Sangria"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(repo.created) != 1 || repo.created[0].SessionId != 4 || repo.created[0].Value != 120 {
+		t.Fatalf("unexpected entry: %+v", repo.created)
+	}
+
+	if _, err := service.CreateEntry(ctx, request.CreateCashRegisterEntryRequest{Type: domain.CashRegisterEntryTypeWithdrawal, Value: 200}); err != domain.ErrCashRegisterWithdrawalExceeds {
+		t.Fatalf("expected withdrawal limit error, got %v", err)
+	}
+	if _, err := service.CreateEntry(ctx, request.CreateCashRegisterEntryRequest{Type: domain.CashRegisterEntryTypeSale, Value: 10}); err == nil {
+		t.Fatalf("expected manual sale entries to be rejected")
+	}
+
+	service = NewCashRegisterService(&stubCashRegisterRepository{}, &stubUserRepository{})
+	if _, err := service.CreateEntry(ctx, request.CreateCashRegisterEntryRequest{Type: domain.CashRegisterEntryTypeDeposit, Value: 10}); err != domain.ErrCashRegisterSessionNotOpen {
+		t.Fatalf("expected not open error, got %v", err)
+	}
+}
+
+func TestCashRegisterServiceClose(t *testing.T) {
+	open := domain.NewCashRegisterSession(domain.User{Id: 9}, 100, time.Now())
+	repo := &stubCashRegisterRepository{open: &open, entries: []domain.CashRegisterEntry{
+		domain.NewCashRegisterEntry(domain.CashRegisterEntryTypeSale, 50, "Venda", 9, time.Now()),
+	}}
+	service := NewCashRegisterService(repo, &stubUserRepository{})
+
+	session, err := service.Close(ctxWithRoleAndUser(domain.UserRoleReseller, 9), request.CloseCashRegisterRequest{CountedAmount: 152})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if repo.closed == nil || session.ExpectedAmount != 150 || *session.Difference != 2 {
+		t.Fatalf("unexpected closed session: %+v", session)
+	}
+
+	repo.closeErr = domain.ErrCashRegisterSessionClosed
+	if _, err := service.Close(ctxWithRoleAndUser(domain.UserRoleReseller, 9), request.CloseCashRegisterRequest{CountedAmount: 150}); err != domain.ErrCashRegisterSessionClosed {
+		t.Fatalf("expected closed error, got %v", err)
+	}
+}
+
+func TestCashRegisterServiceVisibility(t *testing.T) {
+	repo := &stubCashRegisterRepository{byId: domain.CashRegisterSession{Id: 2, User: domain.User{Id: 5}}}
+	service := NewCashRegisterService(repo, &stubUserRepository{})
+
+	if _, err := service.GetAll(ctxWithRoleAndUser(domain.UserRoleReseller, 9)); err != nil || repo.allInput.UserId == nil || *repo.allInput.UserId != 9 {
+		t.Fatalf("expected reseller filter, got %+v (%v)", repo.allInput, err)
+	}
+	if _, err := service.GetAll(ctxWithRoleAndUser(domain.UserRoleAdmin, 1)); err != nil || repo.allInput.UserId != nil {
+		t.Fatalf("expected admin to see every session, got %+v (%v)", repo.allInput, err)
+	}
+	if _, err := service.GetAll(context.Background()); err != ErrPermissionDenied {
+		t.Fatalf("expected permission error, got %v", err)
+	}
+
+	if _, err := service.GetById(ctxWithRoleAndUser(domain.UserRoleReseller, 9), 2); err != domain.ErrCashRegisterSessionNotFound {
+		t.Fatalf("expected other reseller session to be hidden, got %v", err)
+	}
+	if session, err := service.GetById(ctxWithRoleAndUser(domain.UserRoleAdmin, 1), 2); err != nil || session.Id != 2 {
+		t.Fatalf("expected admin to load session, got %+v (%v)", session, err)
+	}
+}
+
+func TestCashRegisterServiceSaveSettings(t *testing.T) {
+	repo := &stubCashRegisterRepository{}
+	service := NewCashRegisterService(repo, &stubUserRepository{})
+
+	if err := service.SaveSettings(context.Background(), request.SaveCashRegisterSettingsRequest{RequireOpenSession: true}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if repo.savedSetting == nil || !repo.savedSetting.RequireOpenSession {
+		t.Fatalf("expected settings to be saved, got %+v", repo.savedSetting)
+	}
+}
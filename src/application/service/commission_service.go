@@ -0,0 +1,208 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	request "github.com/bncunha/erp-api/src/api/requests"
+	"github.com/bncunha/erp-api/src/application/constants"
+	"github.com/bncunha/erp-api/src/application/service/output"
+	"github.com/bncunha/erp-api/src/domain"
+)
+
+type CommissionService interface {
+	GetRules(ctx context.Context) ([]output.GetCommissionRuleOutput, error)
+	CreateRule(ctx context.Context, request request.CreateCommissionRuleRequest) error
+	DeleteRule(ctx context.Context, id int64) error
+	GetStatement(ctx context.Context, request request.CommissionStatementRequest) (output.GetCommissionStatementOutput, error)
+	CloseStatement(ctx context.Context, request request.CommissionStatementRequest) error
+	PayStatement(ctx context.Context, request request.CommissionStatementRequest) error
+}
+
+type commissionService struct {
+	commissionRepository domain.CommissionRepository
+	userRepository       domain.UserRepository
+	productRepository    domain.ProductRepository
+	categoryRepository   domain.CategoryRepository
+	txManager            transactionManager
+}
+
+func NewCommissionService(commissionRepository domain.CommissionRepository, userRepository domain.UserRepository, productRepository domain.ProductRepository, categoryRepository domain.CategoryRepository, txManager transactionManager) CommissionService {
+	return &commissionService{commissionRepository, userRepository, productRepository, categoryRepository, txManager}
+}
+
+func (s *commissionService) GetRules(ctx context.Context) ([]output.GetCommissionRuleOutput, error) {
+	return s.commissionRepository.GetRules(ctx)
+}
+
+func (s *commissionService) CreateRule(ctx context.Context, request request.CreateCommissionRuleRequest) error {
+	if err := request.Validate(); err != nil {
+		return err
+	}
+
+	base := request.Base
+	if base == "" {
+		base = domain.CommissionBaseSaleValue
+	}
+	rule := domain.NewCommissionRule(request.Scope, request.ReferenceId, request.Percentage, base)
+	if err := rule.Validate(); err != nil {
+		return err
+	}
+	if err := s.validateReference(ctx, rule); err != nil {
+		return err
+	}
+
+	_, err := s.commissionRepository.CreateRule(ctx, rule)
+	return err
+}
+
+func (s *commissionService) validateReference(ctx context.Context, rule domain.CommissionRule) error {
+	switch rule.Scope {
+	case domain.CommissionScopeProduct:
+		_, err := s.productRepository.GetById(ctx, rule.ReferenceId)
+		return err
+	case domain.CommissionScopeCategory:
+		_, err := s.categoryRepository.GetById(ctx, rule.ReferenceId)
+		return err
+	default:
+		user, err := s.userRepository.GetById(ctx, rule.ReferenceId)
+		if err != nil {
+			return err
+		}
+		if user.Role != string(domain.UserRoleReseller) {
+			return domain.ErrCommissionResellerInvalid
+		}
+		return nil
+	}
+}
+
+func (s *commissionService) DeleteRule(ctx context.Context, id int64) error {
+	return s.commissionRepository.DeleteRule(ctx, id)
+}
+
+// GetStatement retorna o extrato fechado do mês ou, se ainda não foi fechado,
+// calcula a comissão das parcelas recebidas até o momento.
+func (s *commissionService) GetStatement(ctx context.Context, request request.CommissionStatementRequest) (output.GetCommissionStatementOutput, error) {
+	userRole, _ := ctx.Value(constants.ROLE_KEY).(string)
+	if userRole == "" {
+		return output.GetCommissionStatementOutput{}, ErrPermissionDenied
+	}
+	if userRole == string(domain.UserRoleReseller) {
+		if v, ok := ctx.Value(constants.USERID_KEY).(float64); ok {
+			request.UserId = int64(v)
+		}
+	}
+	if err := request.Validate(); err != nil {
+		return output.GetCommissionStatementOutput{}, err
+	}
+	month, err := domain.ParseCommissionMonth(request.Month)
+	if err != nil {
+		return output.GetCommissionStatementOutput{}, err
+	}
+
+	statement, err := s.commissionRepository.GetStatement(ctx, request.UserId, month)
+	if err != domain.ErrCommissionStatementNotFound {
+		return statement, err
+	}
+	return s.calculateStatement(ctx, request.UserId, month)
+}
+
+func (s *commissionService) CloseStatement(ctx context.Context, request request.CommissionStatementRequest) (err error) {
+	if err = request.Validate(); err != nil {
+		return err
+	}
+	month, err := domain.ParseCommissionMonth(request.Month)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	if month.AddDate(0, 1, 0).After(now) {
+		return domain.ErrCommissionMonthNotFinished
+	}
+
+	_, err = s.commissionRepository.GetStatement(ctx, request.UserId, month)
+	if err == nil {
+		return domain.ErrCommissionStatementAlreadyClosed
+	}
+	if err != domain.ErrCommissionStatementNotFound {
+		return err
+	}
+
+	statement, err := s.calculateStatement(ctx, request.UserId, month)
+	if err != nil {
+		return err
+	}
+	statement.Status = domain.CommissionStatementStatusClosed
+	statement.ClosedAt = &now
+
+	tx, err := s.txManager.BeginTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	statement.Id, err = s.commissionRepository.CreateStatement(ctx, tx, statement)
+	if err != nil {
+		return err
+	}
+	if err = s.commissionRepository.CreateStatementEntries(ctx, tx, statement.Id, statement.Entries); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (s *commissionService) PayStatement(ctx context.Context, request request.CommissionStatementRequest) error {
+	if err := request.Validate(); err != nil {
+		return err
+	}
+	month, err := domain.ParseCommissionMonth(request.Month)
+	if err != nil {
+		return err
+	}
+
+	statement, err := s.commissionRepository.GetStatement(ctx, request.UserId, month)
+	if err == domain.ErrCommissionStatementNotFound {
+		return domain.ErrCommissionStatementNotClosed
+	}
+	if err != nil {
+		return err
+	}
+	if err = statement.ValidatePayment(); err != nil {
+		return err
+	}
+	return s.commissionRepository.MarkStatementAsPaid(ctx, statement.Id)
+}
+
+func (s *commissionService) calculateStatement(ctx context.Context, userId int64, month time.Time) (domain.CommissionStatement, error) {
+	user, err := s.userRepository.GetById(ctx, userId)
+	if err != nil {
+		return domain.CommissionStatement{}, err
+	}
+	rules, err := s.commissionRepository.GetRules(ctx)
+	if err != nil {
+		return domain.CommissionStatement{}, err
+	}
+	installments, err := s.commissionRepository.GetReceivedInstallments(ctx, userId, month, month.AddDate(0, 1, 0))
+	if err != nil {
+		return domain.CommissionStatement{}, err
+	}
+
+	saleIds := make([]int64, 0)
+	seen := make(map[int64]bool)
+	for _, installment := range installments {
+		if !seen[installment.SaleId] {
+			seen[installment.SaleId] = true
+			saleIds = append(saleIds, installment.SaleId)
+		}
+	}
+	items, err := s.commissionRepository.GetSalesItems(ctx, saleIds)
+	if err != nil {
+		return domain.CommissionStatement{}, err
+	}
+
+	return domain.NewCommissionStatement(user, month, rules, installments, items), nil
+}
@@ -0,0 +1,149 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	request "github.com/bncunha/erp-api/src/api/requests"
+	"github.com/bncunha/erp-api/src/application/constants"
+	"github.com/bncunha/erp-api/src/domain"
+)
+
+func newCommissionContext(role domain.Role, userId int64) context.Context {
+	ctx := context.WithValue(context.Background(), constants.ROLE_KEY, string(role))
+	return context.WithValue(ctx, constants.USERID_KEY, float64(userId))
+}
+
+func newCommissionServiceForTest(repo *stubCommissionRepository, userRepo *stubUserRepository, txManager transactionManager) CommissionService {
+	return NewCommissionService(repo, userRepo, &stubProductRepository{}, &stubCategoryRepository{}, txManager)
+}
+
+func TestCommissionServiceCreateRule(t *testing.T) {
+	repo := &stubCommissionRepository{}
+	userRepo := &stubUserRepository{getById: domain.User{Id: 5, Role: string(domain.UserRoleReseller)}}
+	service := newCommissionServiceForTest(repo, userRepo, nil)
+
+	err := service.CreateRule(context.Background(), request.CreateCommissionRuleRequest{Scope: domain.CommissionScopeReseller, ReferenceId: 5, Percentage: 7})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if repo.createdRule.Base != domain.CommissionBaseSaleValue || repo.createdRule.Percentage != 7 {
+		t.Fatalf("unexpected rule: %+v", repo.createdRule)
+	}
+
+	userRepo.getById.Role = string(domain.UserRoleAdmin)
+	err = service.CreateRule(context.Background(), request.CreateCommissionRuleRequest{Scope: domain.CommissionScopeReseller, ReferenceId: 5, Percentage: 7})
+	if err != domain.ErrCommissionResellerInvalid {
+		t.Fatalf("expected reseller error, got %v", err)
+	}
+	if err := service.CreateRule(context.Background(), request.CreateCommissionRuleRequest{Scope: "OTHER", ReferenceId: 5}); err == nil {
+		t.Fatalf("expected validation error")
+	}
+}
+
+func TestCommissionServiceGetStatementCalculatesOpenMonth(t *testing.T) {
+	repo := &stubCommissionRepository{
+		statementErr: domain.ErrCommissionStatementNotFound,
+		rules:        []domain.CommissionRule{domain.NewCommissionRule(domain.CommissionScopeReseller, 3, 10, domain.CommissionBaseSaleValue)},
+		installments: []domain.CommissionInstallment{{PaymentDateId: 1, SaleId: 7, Value: 50}, {PaymentDateId: 2, SaleId: 7, Value: 50}},
+		items:        []domain.CommissionSaleItem{{SaleId: 7, ProductId: 1, Quantity: 1, Total: 100}},
+	}
+	userRepo := &stubUserRepository{getById: domain.User{Id: 3, Name: "Revendedor"}}
+	service := newCommissionServiceForTest(repo, userRepo, nil)
+
+	statement, err := service.GetStatement(newCommissionContext(domain.UserRoleReseller, 3), request.CommissionStatementRequest{UserId: 8, Month: "2026-09"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if statement.Status != domain.CommissionStatementStatusOpen || statement.GetTotal() != 10 {
+		t.Fatalf("unexpected statement: %+v", statement)
+	}
+	if len(repo.itemsSaleIds) != 1 || repo.itemsSaleIds[0] != 7 {
+		t.Fatalf("expected sale ids to be deduplicated, got %v", repo.itemsSaleIds)
+	}
+	if !repo.initialDate.Equal(time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC)) || !repo.finalDate.Equal(time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("unexpected period: %v - %v", repo.initialDate, repo.finalDate)
+	}
+}
+
+func TestCommissionServiceGetStatementErrors(t *testing.T) {
+	repo := &stubCommissionRepository{statement: domain.CommissionStatement{Status: domain.CommissionStatementStatusClosed}}
+	service := newCommissionServiceForTest(repo, &stubUserRepository{}, nil)
+
+	statement, err := service.GetStatement(newCommissionContext(domain.UserRoleAdmin, 1), request.CommissionStatementRequest{UserId: 3, Month: "2026-09"})
+	if err != nil || statement.Status != domain.CommissionStatementStatusClosed {
+		t.Fatalf("expected closed statement, got %+v %v", statement, err)
+	}
+	if _, err := service.GetStatement(context.Background(), request.CommissionStatementRequest{}); err != ErrPermissionDenied {
+		t.Fatalf("expected permission error, got %v", err)
+	}
+	if _, err := service.GetStatement(newCommissionContext(domain.UserRoleAdmin, 1), request.CommissionStatementRequest{Month: "2026-09"}); err == nil {
+		t.Fatalf("expected user required error")
+	}
+	if _, err := service.GetStatement(newCommissionContext(domain.UserRoleAdmin, 1), request.CommissionStatementRequest{UserId: 3, Month: "09-2026"}); err != domain.ErrCommissionMonthInvalid {
+		t.Fatalf("expected month error, got %v", err)
+	}
+}
+
+func TestCommissionServiceCloseStatement(t *testing.T) {
+	tx, fakeTx, cleanup := newTestSQLTx()
+	defer cleanup()
+	repo := &stubCommissionRepository{
+		statementErr: domain.ErrCommissionStatementNotFound,
+		rules:        []domain.CommissionRule{domain.NewCommissionRule(domain.CommissionScopeReseller, 3, 10, domain.CommissionBaseSaleValue)},
+		installments: []domain.CommissionInstallment{{PaymentDateId: 1, SaleId: 7, Value: 50}},
+		items:        []domain.CommissionSaleItem{{SaleId: 7, ProductId: 1, Quantity: 1, Total: 100}},
+	}
+	service := newCommissionServiceForTest(repo, &stubUserRepository{getById: domain.User{Id: 3}}, &stubTxManager{tx: tx})
+
+	if err := service.CloseStatement(context.Background(), request.CommissionStatementRequest{UserId: 3, Month: "2026-09"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if repo.created.Status != domain.CommissionStatementStatusClosed || repo.created.ClosedAt == nil || len(repo.createdEntries) != 1 || !fakeTx.committed {
+		t.Fatalf("expected statement to be persisted: %+v", repo.created)
+	}
+
+	month := time.Now().Format("2006-01")
+	if err := service.CloseStatement(context.Background(), request.CommissionStatementRequest{UserId: 3, Month: month}); err != domain.ErrCommissionMonthNotFinished {
+		t.Fatalf("expected month not finished error, got %v", err)
+	}
+
+	repo.statementErr = nil
+	if err := service.CloseStatement(context.Background(), request.CommissionStatementRequest{UserId: 3, Month: "2026-09"}); err != domain.ErrCommissionStatementAlreadyClosed {
+		t.Fatalf("expected already closed error, got %v", err)
+	}
+}
+
+func TestCommissionServiceCloseStatementRollback(t *testing.T) {
+	tx, fakeTx, cleanup := newTestSQLTx()
+	defer cleanup()
+	repo := &stubCommissionRepository{statementErr: domain.ErrCommissionStatementNotFound, createErr: errors.New("insert error")}
+	service := newCommissionServiceForTest(repo, &stubUserRepository{getById: domain.User{Id: 3}}, &stubTxManager{tx: tx})
+
+	if err := service.CloseStatement(context.Background(), request.CommissionStatementRequest{UserId: 3, Month: "2026-09"}); err == nil || err.Error() != "insert error" {
+		t.Fatalf("expected insert error, got %v", err)
+	}
+	if !fakeTx.rolledBack {
+		t.Fatalf("expected rollback")
+	}
+}
+
+func TestCommissionServicePayStatement(t *testing.T) {
+	repo := &stubCommissionRepository{statement: domain.CommissionStatement{Id: 4, Status: domain.CommissionStatementStatusClosed}}
+	service := newCommissionServiceForTest(repo, &stubUserRepository{}, nil)
+
+	if err := service.PayStatement(context.Background(), request.CommissionStatementRequest{UserId: 3, Month: "2026-09"}); err != nil || repo.paidId != 4 {
+		t.Fatalf("expected statement to be paid, got %v", err)
+	}
+
+	repo.statement.Status = domain.CommissionStatementStatusPaid
+	if err := service.PayStatement(context.Background(), request.CommissionStatementRequest{UserId: 3, Month: "2026-09"}); err != domain.ErrCommissionStatementAlreadyPaid {
+		t.Fatalf("expected already paid error, got %v", err)
+	}
+	repo.statementErr = domain.ErrCommissionStatementNotFound
+	if err := service.PayStatement(context.Background(), request.CommissionStatementRequest{UserId: 3, Month: "2026-09"}); err != domain.ErrCommissionStatementNotClosed {
+		t.Fatalf("expected not closed error, got %v", err)
+	}
+}
@@ -16,14 +16,19 @@ import (
 )
 
 type stubCompanyRepository struct {
-	id  int64
-	err error
+	id      int64
+	err     error
+	company domain.Company
 }
 
 func (s *stubCompanyRepository) CreateWithTx(ctx context.Context, tx *sql.Tx, company domain.Company) (int64, error) {
 	return s.id, s.err
 }
 
+func (s *stubCompanyRepository) Get(ctx context.Context) (domain.Company, error) {
+	return s.company, s.err
+}
+
 type stubAddressRepository struct {
 	err error
 }
@@ -94,6 +99,9 @@ func (s *stubCompanyUserRepository) CreateWithTx(ctx context.Context, tx *sql.Tx
 
 func (s *stubCompanyUserRepository) Update(ctx context.Context, user domain.User) error { return nil }
 func (s *stubCompanyUserRepository) Inactivate(ctx context.Context, id int64) error     { return nil }
+func (s *stubCompanyUserRepository) InactivateWithTx(ctx context.Context, tx *sql.Tx, id int64) error {
+	return nil
+}
 func (s *stubCompanyUserRepository) GetAll(ctx context.Context, input domain.GetAllUserInput) ([]domain.User, error) {
 	return nil, nil
 }
@@ -0,0 +1,166 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	request "github.com/bncunha/erp-api/src/api/requests"
+	"github.com/bncunha/erp-api/src/application/constants"
+	"github.com/bncunha/erp-api/src/application/service/output"
+	"github.com/bncunha/erp-api/src/application/usecase/inventory_usecase"
+	"github.com/bncunha/erp-api/src/domain"
+)
+
+type ConsignmentSettlementService interface {
+	Preview(ctx context.Context, request request.ConsignmentSettlementPreviewRequest) (output.GetConsignmentSettlementOutput, error)
+	Close(ctx context.Context, request request.CloseConsignmentSettlementRequest) (int64, error)
+	GetAll(ctx context.Context, request request.ListConsignmentSettlementsRequest) ([]output.GetConsignmentSettlementsOutput, error)
+	GetById(ctx context.Context, id int64) (output.GetConsignmentSettlementOutput, error)
+}
+
+type consignmentSettlementService struct {
+	consignmentSettlementRepository domain.ConsignmentSettlementRepository
+	userRepository                  domain.UserRepository
+	inventoryRepository             domain.InventoryRepository
+	inventoryUseCase                inventory_usecase.InventoryUseCase
+	txManager                       transactionManager
+}
+
+func NewConsignmentSettlementService(consignmentSettlementRepository domain.ConsignmentSettlementRepository, userRepository domain.UserRepository, inventoryRepository domain.InventoryRepository, inventoryUseCase inventory_usecase.InventoryUseCase, txManager transactionManager) ConsignmentSettlementService {
+	return &consignmentSettlementService{consignmentSettlementRepository, userRepository, inventoryRepository, inventoryUseCase, txManager}
+}
+
+// Preview calcula o acerto em aberto do revendedor, desde o fechamento do
+// acerto anterior até o momento, sem persistir nada.
+func (s *consignmentSettlementService) Preview(ctx context.Context, request request.ConsignmentSettlementPreviewRequest) (output.GetConsignmentSettlementOutput, error) {
+	userRole, _ := ctx.Value(constants.ROLE_KEY).(string)
+	if userRole == "" {
+		return output.GetConsignmentSettlementOutput{}, ErrPermissionDenied
+	}
+	if userRole == string(domain.UserRoleReseller) {
+		if v, ok := ctx.Value(constants.USERID_KEY).(float64); ok {
+			request.UserId = int64(v)
+		}
+	}
+	if err := request.Validate(); err != nil {
+		return output.GetConsignmentSettlementOutput{}, err
+	}
+	return s.calculateSettlement(ctx, request.UserId, time.Now())
+}
+
+// Close fecha o acerto do revendedor. Quando solicitado, o saldo que ainda está
+// com ele é transferido de volta ao estoque principal na mesma transação.
+func (s *consignmentSettlementService) Close(ctx context.Context, request request.CloseConsignmentSettlementRequest) (id int64, err error) {
+	if err = request.Validate(); err != nil {
+		return 0, err
+	}
+	createdByUserId := int64(ctx.Value(constants.USERID_KEY).(float64))
+
+	settlement, err := s.calculateSettlement(ctx, request.UserId, time.Now())
+	if err != nil {
+		return 0, err
+	}
+	settlement.Notes = request.Notes
+	if err = settlement.Validate(); err != nil {
+		return 0, err
+	}
+
+	tx, err := s.txManager.BeginTx(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	if request.ReturnUnsold {
+		unsold := settlement.ReturnUnsold()
+		if len(unsold) > 0 {
+			primaryInventory, err := s.inventoryRepository.GetPrimaryInventory(ctx)
+			if err != nil {
+				return 0, err
+			}
+			skus := make([]inventory_usecase.DoTransactionSkusInput, 0, len(unsold))
+			for _, item := range unsold {
+				skus = append(skus, inventory_usecase.DoTransactionSkusInput{
+					SkuId:    item.Sku.Id,
+					Quantity: item.ReturnedOnClosing,
+				})
+			}
+			err = s.inventoryUseCase.DoTransaction(ctx, tx, inventory_usecase.DoTransactionInput{
+				Type:                   domain.InventoryTransactionTypeTransfer,
+				Skus:                   skus,
+				InventoryOriginId:      settlement.InventoryId,
+				InventoryDestinationId: primaryInventory.Id,
+				Justification:          "Devolução no acerto " + settlement.Code,
+			})
+			if err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	// O período fecha depois da devolução para que a transferência de
+	// fechamento não seja contada novamente no próximo acerto.
+	settlement.PeriodEnd = time.Now()
+	id, err = s.consignmentSettlementRepository.Create(ctx, tx, settlement, createdByUserId)
+	if err != nil {
+		return 0, err
+	}
+	if err = s.consignmentSettlementRepository.CreateItems(ctx, tx, id, settlement.Items); err != nil {
+		return 0, err
+	}
+	return id, tx.Commit()
+}
+
+func (s *consignmentSettlementService) GetAll(ctx context.Context, request request.ListConsignmentSettlementsRequest) ([]output.GetConsignmentSettlementsOutput, error) {
+	userRole, _ := ctx.Value(constants.ROLE_KEY).(string)
+	if userRole == "" {
+		return nil, ErrPermissionDenied
+	}
+	userIds := request.UserId
+	if userRole == string(domain.UserRoleReseller) {
+		userIds = []int64{int64(ctx.Value(constants.USERID_KEY).(float64))}
+	}
+	return s.consignmentSettlementRepository.GetAll(ctx, userIds)
+}
+
+func (s *consignmentSettlementService) GetById(ctx context.Context, id int64) (output.GetConsignmentSettlementOutput, error) {
+	settlement, err := s.consignmentSettlementRepository.GetById(ctx, id)
+	if err != nil {
+		return settlement, err
+	}
+	userRole, _ := ctx.Value(constants.ROLE_KEY).(string)
+	if userRole == string(domain.UserRoleAdmin) {
+		return settlement, nil
+	}
+	if userRole == string(domain.UserRoleReseller) && settlement.User.Id == int64(ctx.Value(constants.USERID_KEY).(float64)) {
+		return settlement, nil
+	}
+	return output.GetConsignmentSettlementOutput{}, domain.ErrConsignmentSettlementNotFound
+}
+
+func (s *consignmentSettlementService) calculateSettlement(ctx context.Context, userId int64, periodEnd time.Time) (domain.ConsignmentSettlement, error) {
+	user, err := s.userRepository.GetById(ctx, userId)
+	if err != nil {
+		return domain.ConsignmentSettlement{}, err
+	}
+	inventory, err := s.inventoryRepository.GetByUserId(ctx, userId)
+	if err != nil {
+		return domain.ConsignmentSettlement{}, err
+	}
+	if err = domain.ValidateConsignmentInventory(inventory); err != nil {
+		return domain.ConsignmentSettlement{}, err
+	}
+	periodStart, err := s.consignmentSettlementRepository.GetLastPeriodEnd(ctx, userId)
+	if err != nil {
+		return domain.ConsignmentSettlement{}, err
+	}
+	items, err := s.consignmentSettlementRepository.GetItems(ctx, inventory.Id, userId, periodStart, periodEnd)
+	if err != nil {
+		return domain.ConsignmentSettlement{}, err
+	}
+	return domain.NewConsignmentSettlement(user, inventory, periodStart, periodEnd, items), nil
+}
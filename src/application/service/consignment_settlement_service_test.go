@@ -0,0 +1,107 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	request "github.com/bncunha/erp-api/src/api/requests"
+	"github.com/bncunha/erp-api/src/domain"
+)
+
+func newConsignmentSettlementServiceForTest(repo *stubConsignmentSettlementRepository, inventoryRepo *stubInventoryRepository, inventoryUseCase *stubInventoryUseCase, txManager transactionManager) ConsignmentSettlementService {
+	return NewConsignmentSettlementService(repo, &stubUserRepository{getById: domain.User{Id: 3, Name: "Revendedor"}}, inventoryRepo, inventoryUseCase, txManager)
+}
+
+func TestConsignmentSettlementServicePreview(t *testing.T) {
+	lastPeriodEnd := time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC)
+	repo := &stubConsignmentSettlementRepository{
+		lastPeriodEnd: &lastPeriodEnd,
+		items:         []domain.ConsignmentSettlementItem{{Sku: domain.Sku{Id: 1, Price: 10}, SentQuantity: 5, SoldQuantity: 2, SoldValue: 20, OnHandQuantity: 3}},
+	}
+	inventoryRepo := &stubInventoryRepository{getByUser: domain.Inventory{Id: 4, Type: domain.InventoryTypeReseller}}
+	service := newConsignmentSettlementServiceForTest(repo, inventoryRepo, &stubInventoryUseCase{}, nil)
+
+	settlement, err := service.Preview(newCommissionContext(domain.UserRoleReseller, 3), request.ConsignmentSettlementPreviewRequest{UserId: 8})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if settlement.User.Id != 3 || settlement.InventoryId != 4 || settlement.GetAmountDue() != 20 || settlement.GetOnHandValue() != 30 {
+		t.Fatalf("unexpected settlement: %+v", settlement)
+	}
+	if repo.itemsPeriodStart == nil || !repo.itemsPeriodStart.Equal(lastPeriodEnd) {
+		t.Fatalf("expected period to start at last settlement, got %v", repo.itemsPeriodStart)
+	}
+
+	inventoryRepo.getByUser.Type = domain.InventoryTypePrimary
+	if _, err := service.Preview(newCommissionContext(domain.UserRoleAdmin, 1), request.ConsignmentSettlementPreviewRequest{UserId: 3}); err != domain.ErrConsignmentInventoryNotReseller {
+		t.Fatalf("expected reseller inventory error, got %v", err)
+	}
+	if _, err := service.Preview(context.Background(), request.ConsignmentSettlementPreviewRequest{UserId: 3}); err != ErrPermissionDenied {
+		t.Fatalf("expected permission error, got %v", err)
+	}
+}
+
+func TestConsignmentSettlementServiceCloseReturnsUnsold(t *testing.T) {
+	tx, fakeTx, cleanup := newTestSQLTx()
+	defer cleanup()
+	repo := &stubConsignmentSettlementRepository{
+		items: []domain.ConsignmentSettlementItem{
+			{Sku: domain.Sku{Id: 1}, SentQuantity: 5, SoldQuantity: 2, SoldValue: 20, OnHandQuantity: 3},
+			{Sku: domain.Sku{Id: 2}, SentQuantity: 1, SoldQuantity: 1, SoldValue: 15},
+		},
+	}
+	inventoryRepo := &stubInventoryRepository{getByUser: domain.Inventory{Id: 4, Type: domain.InventoryTypeReseller}, getPrimary: domain.Inventory{Id: 1}}
+	inventoryUseCase := &stubInventoryUseCase{}
+	service := newConsignmentSettlementServiceForTest(repo, inventoryRepo, inventoryUseCase, &stubTxManager{tx: tx})
+
+	id, err := service.Close(newCommissionContext(domain.UserRoleAdmin, 1), request.CloseConsignmentSettlementRequest{UserId: 3, ReturnUnsold: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if id != 6 || repo.createdBy != 1 || !repo.created.ReturnedUnsold || len(repo.createdItems) != 2 || !fakeTx.committed {
+		t.Fatalf("expected settlement to be persisted: %+v", repo.created)
+	}
+	input := inventoryUseCase.receivedInput
+	if input.Type != domain.InventoryTransactionTypeTransfer || input.InventoryOriginId != 4 || input.InventoryDestinationId != 1 {
+		t.Fatalf("unexpected transfer: %+v", input)
+	}
+	if len(input.Skus) != 1 || input.Skus[0].SkuId != 1 || input.Skus[0].Quantity != 3 {
+		t.Fatalf("expected only unsold items to be returned, got %+v", input.Skus)
+	}
+}
+
+func TestConsignmentSettlementServiceCloseRollback(t *testing.T) {
+	tx, fakeTx, cleanup := newTestSQLTx()
+	defer cleanup()
+	repo := &stubConsignmentSettlementRepository{items: []domain.ConsignmentSettlementItem{{Sku: domain.Sku{Id: 1}, OnHandQuantity: 3}}}
+	inventoryRepo := &stubInventoryRepository{getByUser: domain.Inventory{Id: 4, Type: domain.InventoryTypeReseller}}
+	inventoryUseCase := &stubInventoryUseCase{err: errors.New("transfer error")}
+	service := newConsignmentSettlementServiceForTest(repo, inventoryRepo, inventoryUseCase, &stubTxManager{tx: tx})
+
+	if _, err := service.Close(newCommissionContext(domain.UserRoleAdmin, 1), request.CloseConsignmentSettlementRequest{UserId: 3, ReturnUnsold: true}); err == nil || err.Error() != "transfer error" {
+		t.Fatalf("expected transfer error, got %v", err)
+	}
+	if !fakeTx.rolledBack || repo.created.Code != "" {
+		t.Fatalf("expected rollback without persisting the settlement")
+	}
+}
+
+func TestConsignmentSettlementServiceOwnership(t *testing.T) {
+	repo := &stubConsignmentSettlementRepository{getById: domain.ConsignmentSettlement{Id: 2, User: domain.User{Id: 3}}}
+	service := newConsignmentSettlementServiceForTest(repo, &stubInventoryRepository{}, &stubInventoryUseCase{}, nil)
+
+	if _, err := service.GetById(newCommissionContext(domain.UserRoleReseller, 3), 2); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := service.GetById(newCommissionContext(domain.UserRoleReseller, 5), 2); err != domain.ErrConsignmentSettlementNotFound {
+		t.Fatalf("expected not found error, got %v", err)
+	}
+	if _, err := service.GetAll(newCommissionContext(domain.UserRoleReseller, 5), request.ListConsignmentSettlementsRequest{UserId: []int64{3}}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(repo.getAllUserIds) != 1 || repo.getAllUserIds[0] != 5 {
+		t.Fatalf("expected reseller to be restricted to own settlements, got %v", repo.getAllUserIds)
+	}
+}
@@ -4,7 +4,9 @@ import (
 	"context"
 
 	request "github.com/bncunha/erp-api/src/api/requests"
+	"github.com/bncunha/erp-api/src/application/constants"
 	"github.com/bncunha/erp-api/src/application/errors"
+	"github.com/bncunha/erp-api/src/application/service/output"
 	"github.com/bncunha/erp-api/src/domain"
 )
 
@@ -14,14 +16,17 @@ type CustomerService interface {
 	GetById(ctx context.Context, id int64) (domain.Customer, error)
 	Edit(ctx context.Context, input request.EditCustomerRequest) error
 	Inactivate(ctx context.Context, id int64) error
+	GetCredit(ctx context.Context, id int64) (output.GetCustomerCreditOutput, error)
+	AdjustCredit(ctx context.Context, id int64, input request.CreateCustomerCreditAdjustmentRequest) (int64, error)
 }
 
 type customerService struct {
-	customerRepository domain.CustomerRepository
+	customerRepository       domain.CustomerRepository
+	customerCreditRepository domain.CustomerCreditRepository
 }
 
-func NewCustomerService(customerRepository domain.CustomerRepository) CustomerService {
-	return &customerService{customerRepository}
+func NewCustomerService(customerRepository domain.CustomerRepository, customerCreditRepository domain.CustomerCreditRepository) CustomerService {
+	return &customerService{customerRepository, customerCreditRepository}
 }
 
 func (s *customerService) Create(ctx context.Context, input request.CreateCustomerRequest) (int64, error) {
@@ -77,3 +82,44 @@ func (s *customerService) Edit(ctx context.Context, input request.EditCustomerRe
 func (s *customerService) Inactivate(ctx context.Context, id int64) error {
 	return s.customerRepository.Inactivate(ctx, id)
 }
+
+func (s *customerService) GetCredit(ctx context.Context, id int64) (output.GetCustomerCreditOutput, error) {
+	customer, err := s.customerRepository.GetById(ctx, id)
+	if err != nil {
+		return output.GetCustomerCreditOutput{}, err
+	}
+	balance, err := s.customerCreditRepository.GetBalance(ctx, customer.Id)
+	if err != nil {
+		return output.GetCustomerCreditOutput{}, err
+	}
+	movements, err := s.customerCreditRepository.GetByCustomerId(ctx, customer.Id)
+	if err != nil {
+		return output.GetCustomerCreditOutput{}, err
+	}
+	return output.GetCustomerCreditOutput{
+		CustomerId: customer.Id,
+		Balance:    balance,
+		Movements:  movements,
+	}, nil
+}
+
+func (s *customerService) AdjustCredit(ctx context.Context, id int64, input request.CreateCustomerCreditAdjustmentRequest) (int64, error) {
+	if err := input.Validate(); err != nil {
+		return 0, err
+	}
+	customer, err := s.customerRepository.GetById(ctx, id)
+	if err != nil {
+		return 0, err
+	}
+	balance, err := s.customerCreditRepository.GetBalance(ctx, customer.Id)
+	if err != nil {
+		return 0, err
+	}
+
+	credit := domain.NewCustomerCredit(customer.Id, input.Type, domain.CustomerCreditOriginAdjustment, input.Value, input.Description)
+	credit.CreatedByUserId = int64(ctx.Value(constants.USERID_KEY).(float64))
+	if err := credit.Validate(balance); err != nil {
+		return 0, err
+	}
+	return s.customerCreditRepository.Create(ctx, credit)
+}
@@ -3,15 +3,17 @@ package service
 import (
 	"context"
 	"errors"
+	"strings"
 	"testing"
 
 	request "github.com/bncunha/erp-api/src/api/requests"
+	"github.com/bncunha/erp-api/src/application/constants"
 	"github.com/bncunha/erp-api/src/domain"
 )
 
 func TestCustomerServiceCreate(t *testing.T) {
 	repo := &stubCustomerRepository{}
-	service := NewCustomerService(repo)
+	service := NewCustomerService(repo, &stubCustomerCreditRepository{})
 
 	id, err := service.Create(context.Background(), request.CreateCustomerRequest{Name: "Alice", Cellphone: "123"})
 	if err != nil {
@@ -26,7 +28,7 @@ func TestCustomerServiceCreate(t *testing.T) {
 }
 
 func TestCustomerServiceCreateValidationError(t *testing.T) {
-	service := NewCustomerService(&stubCustomerRepository{})
+	service := NewCustomerService(&stubCustomerRepository{}, &stubCustomerCreditRepository{})
 	if _, err := service.Create(context.Background(), request.CreateCustomerRequest{}); err == nil {
 		t.Fatalf("expected validation error")
 	}
@@ -35,7 +37,7 @@ func TestCustomerServiceCreateValidationError(t *testing.T) {
 func TestCustomerServiceCreateRepositoryError(t *testing.T) {
 	expected := errors.New("fail")
 	repo := &stubCustomerRepository{createErr: expected}
-	service := NewCustomerService(repo)
+	service := NewCustomerService(repo, &stubCustomerCreditRepository{})
 	if _, err := service.Create(context.Background(), request.CreateCustomerRequest{Name: "Bob", Cellphone: "321"}); err != expected {
 		t.Fatalf("expected %v, got %v", expected, err)
 	}
@@ -43,7 +45,7 @@ func TestCustomerServiceCreateRepositoryError(t *testing.T) {
 
 func TestCustomerServiceGetAll(t *testing.T) {
 	repo := &stubCustomerRepository{getAll: []domain.Customer{{Id: 1}}}
-	service := NewCustomerService(repo)
+	service := NewCustomerService(repo, &stubCustomerCreditRepository{})
 	customers, err := service.GetAll(context.Background())
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
@@ -53,7 +55,7 @@ func TestCustomerServiceGetAll(t *testing.T) {
 	}
 
 	repo = &stubCustomerRepository{getAllErr: errors.New("fail")}
-	service = NewCustomerService(repo)
+	service = NewCustomerService(repo, &stubCustomerCreditRepository{})
 	if _, err := service.GetAll(context.Background()); err == nil {
 		t.Fatalf("expected error")
 	}
@@ -61,7 +63,7 @@ func TestCustomerServiceGetAll(t *testing.T) {
 
 func TestCustomerServiceGetById(t *testing.T) {
 	repo := &stubCustomerRepository{getById: domain.Customer{Id: 5}}
-	service := NewCustomerService(repo)
+	service := NewCustomerService(repo, &stubCustomerCreditRepository{})
 	customer, err := service.GetById(context.Background(), 5)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
@@ -71,7 +73,7 @@ func TestCustomerServiceGetById(t *testing.T) {
 	}
 
 	repo = &stubCustomerRepository{getByIdErr: errors.New("fail")}
-	service = NewCustomerService(repo)
+	service = NewCustomerService(repo, &stubCustomerCreditRepository{})
 	if _, err := service.GetById(context.Background(), 5); err == nil {
 		t.Fatalf("expected error")
 	}
@@ -79,7 +81,7 @@ func TestCustomerServiceGetById(t *testing.T) {
 
 func TestCustomerServiceEdit(t *testing.T) {
 	repo := &stubCustomerRepository{}
-	service := NewCustomerService(repo)
+	service := NewCustomerService(repo, &stubCustomerCreditRepository{})
 	err := service.Edit(context.Background(), request.EditCustomerRequest{Id: 1, Name: "Carol", Cellphone: "999"})
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
@@ -93,7 +95,7 @@ func TestCustomerServiceEdit(t *testing.T) {
 	}
 
 	repo = &stubCustomerRepository{editErr: errors.New("fail")}
-	service = NewCustomerService(repo)
+	service = NewCustomerService(repo, &stubCustomerCreditRepository{})
 	if err := service.Edit(context.Background(), request.EditCustomerRequest{Id: 1, Name: "Eve", Cellphone: "000"}); err == nil {
 		t.Fatalf("expected repository error")
 	}
@@ -101,14 +103,98 @@ func TestCustomerServiceEdit(t *testing.T) {
 
 func TestCustomerServiceInactivate(t *testing.T) {
 	repo := &stubCustomerRepository{}
-	service := NewCustomerService(repo)
+	service := NewCustomerService(repo, &stubCustomerCreditRepository{})
 	if err := service.Inactivate(context.Background(), 3); err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
 
 	repo = &stubCustomerRepository{inactivateErr: errors.New("fail")}
-	service = NewCustomerService(repo)
+	service = NewCustomerService(repo, &stubCustomerCreditRepository{})
 	if err := service.Inactivate(context.Background(), 3); err == nil {
 		t.Fatalf("expected error")
 	}
 }
+
+func TestCustomerServiceGetCredit(t *testing.T) {
+	repo := &stubCustomerRepository{getById: domain.Customer{Id: 3}}
+	creditRepo := &stubCustomerCreditRepository{balance: 15, movements: []domain.CustomerCredit{{Id: 1, Value: 15}}}
+	service := NewCustomerService(repo, creditRepo)
+
+	credit, err := service.GetCredit(context.Background(), 3)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if credit.CustomerId != 3 || credit.Balance != 15 || len(credit.Movements) != 1 {
+		t.Fatalf("unexpected credit: %+v", credit)
+	}
+}
+
+func TestCustomerServiceGetCreditErrors(t *testing.T) {
+	expected := errors.New("fail")
+	cases := map[string]CustomerService{
+		"customer":  NewCustomerService(&stubCustomerRepository{getByIdErr: expected}, &stubCustomerCreditRepository{}),
+		"balance":   NewCustomerService(&stubCustomerRepository{}, &stubCustomerCreditRepository{balanceErr: expected}),
+		"movements": NewCustomerService(&stubCustomerRepository{}, &stubCustomerCreditRepository{movementsErr: expected}),
+	}
+	for name, service := range cases {
+		t.Run(name, func(t *testing.T) {
+			if _, err := service.GetCredit(context.Background(), 3); err != expected {
+				t.Fatalf("expected %v, got %v", expected, err)
+			}
+		})
+	}
+}
+
+func TestCustomerServiceAdjustCredit(t *testing.T) {
+	ctx := context.WithValue(context.Background(), constants.USERID_KEY, float64(9))
+	repo := &stubCustomerRepository{getById: domain.Customer{Id: 3}}
+	creditRepo := &stubCustomerCreditRepository{balance: 5}
+	service := NewCustomerService(repo, creditRepo)
+
+	id, err := service.AdjustCredit(ctx, 3, request.CreateCustomerCreditAdjustmentRequest{Type: domain.CustomerCreditTypeCredit, Value: 10, Description: "Bonificação"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if id != 1 || len(creditRepo.created) != 1 {
+		t.Fatalf("expected credit to be created, got %+v", creditRepo.created)
+	}
+	created := creditRepo.created[0]
+	if created.CustomerId != 3 || created.Origin != domain.CustomerCreditOriginAdjustment || created.CreatedByUserId != 9 || created.Value != 10 {
+		t.Fatalf("unexpected credit: %+v", created)
+	}
+}
+
+func TestCustomerServiceAdjustCreditErrors(t *testing.T) {
+	ctx := context.WithValue(context.Background(), constants.USERID_KEY, float64(9))
+	debit := request.CreateCustomerCreditAdjustmentRequest{Type: domain.CustomerCreditTypeDebit, Value: 10, Description: "Estorno"}
+	expected := errors.New("fail")
+
+	service := NewCustomerService(&stubCustomerRepository{}, &stubCustomerCreditRepository{})
+	if _, err := service.AdjustCredit(ctx, 3, request.CreateCustomerCreditAdjustmentRequest{}); err == nil {
+		t.Fatalf("expected validation error")
+	}
+
+	service = NewCustomerService(&stubCustomerRepository{getByIdErr: expected}, &stubCustomerCreditRepository{})
+	if _, err := service.AdjustCredit(ctx, 3, debit); err != expected {
+		t.Fatalf("expected %v, got %v", expected, err)
+	}
+
+	service = NewCustomerService(&stubCustomerRepository{}, &stubCustomerCreditRepository{balanceErr: expected})
+	if _, err := service.AdjustCredit(ctx, 3, debit); err != expected {
+		t.Fatalf("expected %v, got %v", expected, err)
+	}
+
+	creditRepo := &stubCustomerCreditRepository{balance: 5}
+	service = NewCustomerService(&stubCustomerRepository{}, creditRepo)
+	if _, err := service.AdjustCredit(ctx, 3, debit); err == nil || !strings.Contains(err.Error(), domain.ErrCustomerCreditInsufficient.Error()) {
+		t.Fatalf("expected insufficient credit error, got %v", err)
+	}
+	if len(creditRepo.created) != 0 {
+		t.Fatalf("expected no credit to be created")
+	}
+
+	service = NewCustomerService(&stubCustomerRepository{}, &stubCustomerCreditRepository{balance: 10, createErr: expected})
+	if _, err := service.AdjustCredit(ctx, 3, debit); err != expected {
+		t.Fatalf("expected %v, got %v", expected, err)
+	}
+}
@@ -193,6 +193,33 @@ func (s *dashboardService) widgetDefinitions() []widgetDefinition {
 			Roles:       []domain.Role{domain.UserRoleAdmin},
 			Handler:     s.handleProdutosMaisVendidos,
 		},
+		{
+			Enum:        domain.DashboardWidgetMultasEJuros,
+			Type:        domain.DashboardWidgetTypeCard,
+			Order:       8,
+			Title:       "Multas e juros",
+			Description: "Encargos por atraso recebidos no período",
+			Roles:       []domain.Role{domain.UserRoleAdmin},
+			Handler:     s.handleMultasEJuros,
+		},
+		{
+			Enum:        domain.DashboardWidgetRecebimentosDeCartao,
+			Type:        domain.DashboardWidgetTypeLine,
+			Order:       9,
+			Title:       "Recebimentos de cartão",
+			Description: "Valor líquido previsto para depósito por dia",
+			Roles:       []domain.Role{domain.UserRoleAdmin},
+			Handler:     s.handleRecebimentosDeCartao,
+		},
+		{
+			Enum:        domain.DashboardWidgetTaxasDeCartao,
+			Type:        domain.DashboardWidgetTypeCard,
+			Order:       10,
+			Title:       "Taxas de cartão",
+			Description: "Taxas das maquininhas com recebimento no período",
+			Roles:       []domain.Role{domain.UserRoleAdmin},
+			Handler:     s.handleTaxasDeCartao,
+		},
 		{
 			Enum:        domain.DashboardWidgetMeuFaturamento,
 			Type:        domain.DashboardWidgetTypeCard,
@@ -229,6 +256,15 @@ func (s *dashboardService) widgetDefinitions() []widgetDefinition {
 			Roles:       []domain.Role{domain.UserRoleReseller},
 			Handler:     s.handleMeusProdutosMaisVendidos,
 		},
+		{
+			Enum:        domain.DashboardWidgetMinhasMultasEJuros,
+			Type:        domain.DashboardWidgetTypeCard,
+			Order:       5,
+			Title:       "Minhas multas e juros",
+			Description: "Encargos por atraso recebidos no período",
+			Roles:       []domain.Role{domain.UserRoleReseller},
+			Handler:     s.handleMinhasMultasEJuros,
+		},
 	}
 }
 
@@ -336,6 +372,47 @@ func (s *dashboardService) handleMeuFaturamento(ctx context.Context, input widge
 	return s.buildCardResponse(domain.DashboardWidgetMeuFaturamento, domain.DashboardWidgetTypeCard, "Meu faturamento", input.Period, current, previous, "BRL"), nil
 }
 
+func (s *dashboardService) handleMultasEJuros(ctx context.Context, input widgetInput) (output.DashboardWidgetDataOutput, error) {
+	current, previous, err := s.getLateFeeRevenue(ctx, input)
+	if err != nil {
+		return output.DashboardWidgetDataOutput{}, err
+	}
+
+	return s.buildCardResponse(domain.DashboardWidgetMultasEJuros, domain.DashboardWidgetTypeCard, "Multas e juros", input.Period, current, previous, "BRL"), nil
+}
+
+func (s *dashboardService) handleMinhasMultasEJuros(ctx context.Context, input widgetInput) (output.DashboardWidgetDataOutput, error) {
+	current, previous, err := s.getLateFeeRevenue(ctx, input)
+	if err != nil {
+		return output.DashboardWidgetDataOutput{}, err
+	}
+
+	return s.buildCardResponse(domain.DashboardWidgetMinhasMultasEJuros, domain.DashboardWidgetTypeCard, "Minhas multas e juros", input.Period, current, previous, "BRL"), nil
+}
+
+func (s *dashboardService) handleTaxasDeCartao(ctx context.Context, input widgetInput) (output.DashboardWidgetDataOutput, error) {
+	current, err := s.dashboardRepository.GetCardFees(ctx, domain.DashboardQueryInput{
+		From:       input.Period.From,
+		To:         input.Period.To,
+		ResellerId: input.ResellerId,
+	})
+	if err != nil {
+		return output.DashboardWidgetDataOutput{}, err
+	}
+
+	prevFrom, prevTo := s.previousPeriod(input.Period)
+	previous, err := s.dashboardRepository.GetCardFees(ctx, domain.DashboardQueryInput{
+		From:       prevFrom,
+		To:         prevTo,
+		ResellerId: input.ResellerId,
+	})
+	if err != nil {
+		return output.DashboardWidgetDataOutput{}, err
+	}
+
+	return s.buildCardResponse(domain.DashboardWidgetTaxasDeCartao, domain.DashboardWidgetTypeCard, "Taxas de cartão", input.Period, current, previous, "BRL"), nil
+}
+
 func (s *dashboardService) handleTotalVendas(ctx context.Context, input widgetInput) (output.DashboardWidgetDataOutput, error) {
 	current, err := s.dashboardRepository.GetSalesCount(ctx, domain.DashboardQueryInput{
 		From:       input.Period.From,
@@ -406,16 +483,24 @@ func (s *dashboardService) handleEstoqueBaixo(ctx context.Context, input widgetI
 
 	rows := make([]map[string]any, 0, len(items))
 	for _, item := range items {
+		inventory := "Central"
+		if item.UserName != nil {
+			inventory = *item.UserName
+		}
 		rows = append(rows, map[string]any{
-			"product": item.ProductName,
-			"qty":     item.Quantity,
+			"product":   item.Sku.GetName(),
+			"inventory": inventory,
+			"qty":       item.Quantity,
+			"min":       item.MinQuantity,
 		})
 	}
 
 	data := output.DashboardTableData{
 		Columns: []output.DashboardTableColumn{
 			{Key: "product", Label: "Produto"},
+			{Key: "inventory", Label: "Estoque"},
 			{Key: "qty", Label: "Qtd"},
+			{Key: "min", Label: "Mín"},
 		},
 		Rows: rows,
 	}
@@ -461,6 +546,38 @@ func (s *dashboardService) handleFaturamentoNoTempo(ctx context.Context, input w
 	}, nil
 }
 
+func (s *dashboardService) handleRecebimentosDeCartao(ctx context.Context, input widgetInput) (output.DashboardWidgetDataOutput, error) {
+	series, err := s.dashboardRepository.GetCardSettlementsByDay(ctx, domain.DashboardQueryInput{
+		From:       input.Period.From,
+		To:         input.Period.To,
+		ResellerId: input.ResellerId,
+	})
+	if err != nil {
+		return output.DashboardWidgetDataOutput{}, err
+	}
+
+	labels := make([]string, 0, len(series))
+	values := make([]float64, 0, len(series))
+	for _, item := range series {
+		labels = append(labels, item.Date.Format(time.DateOnly))
+		values = append(values, item.Value)
+	}
+
+	data := output.DashboardLineBarData{
+		Labels: labels,
+		Series: []output.DashboardSeries{
+			{Name: "Recebimentos de cartão", Values: values},
+		},
+	}
+
+	return output.DashboardWidgetDataOutput{
+		Enum: domain.DashboardWidgetRecebimentosDeCartao,
+		Type: domain.DashboardWidgetTypeLine,
+		Meta: s.buildMeta("Recebimentos de cartão", input.Period, "BRL", true),
+		Data: data,
+	}, nil
+}
+
 func (s *dashboardService) handleMinhasVendasNoTempo(ctx context.Context, input widgetInput) (output.DashboardWidgetDataOutput, error) {
 	series, err := s.dashboardRepository.GetSalesCountByDay(ctx, domain.DashboardQueryInput{
 		From:       input.Period.From,
@@ -603,6 +720,28 @@ func (s *dashboardService) getPreviousRevenue(ctx context.Context, input widgetI
 	})
 }
 
+func (s *dashboardService) getLateFeeRevenue(ctx context.Context, input widgetInput) (float64, float64, error) {
+	current, err := s.dashboardRepository.GetLateFeeRevenue(ctx, domain.DashboardQueryInput{
+		From:       input.Period.From,
+		To:         input.Period.To,
+		ResellerId: input.ResellerId,
+	})
+	if err != nil {
+		return 0, 0, err
+	}
+
+	prevFrom, prevTo := s.previousPeriod(input.Period)
+	previous, err := s.dashboardRepository.GetLateFeeRevenue(ctx, domain.DashboardQueryInput{
+		From:       prevFrom,
+		To:         prevTo,
+		ResellerId: input.ResellerId,
+	})
+	if err != nil {
+		return 0, 0, err
+	}
+	return current, previous, nil
+}
+
 func (s *dashboardService) getPreviousSalesCount(ctx context.Context, input widgetInput) (int64, error) {
 	prevFrom, prevTo := s.previousPeriod(input.Period)
 	return s.dashboardRepository.GetSalesCount(ctx, domain.DashboardQueryInput{
@@ -38,6 +38,46 @@ type stubDashboardRepository struct {
 	topProductsErr         error
 	topProductsInput       domain.DashboardQueryInput
 	topProductsLimit       int
+	lateFeeResponses       []float64
+	lateFeeErr             error
+	lateFeeInputs          []domain.DashboardQueryInput
+	cardFeeResponses       []float64
+	cardFeeErr             error
+	cardFeeInputs          []domain.DashboardQueryInput
+	cardSettlements        []domain.DashboardTimeSeriesItem
+	cardSettlementsErr     error
+	cardSettlementsInput   domain.DashboardQueryInput
+}
+
+func (s *stubDashboardRepository) GetCardFees(ctx context.Context, input domain.DashboardQueryInput) (float64, error) {
+	s.cardFeeInputs = append(s.cardFeeInputs, input)
+	if s.cardFeeErr != nil {
+		return 0, s.cardFeeErr
+	}
+	if len(s.cardFeeResponses) == 0 {
+		return 0, nil
+	}
+	value := s.cardFeeResponses[0]
+	s.cardFeeResponses = s.cardFeeResponses[1:]
+	return value, nil
+}
+
+func (s *stubDashboardRepository) GetCardSettlementsByDay(ctx context.Context, input domain.DashboardQueryInput) ([]domain.DashboardTimeSeriesItem, error) {
+	s.cardSettlementsInput = input
+	return s.cardSettlements, s.cardSettlementsErr
+}
+
+func (s *stubDashboardRepository) GetLateFeeRevenue(ctx context.Context, input domain.DashboardQueryInput) (float64, error) {
+	s.lateFeeInputs = append(s.lateFeeInputs, input)
+	if s.lateFeeErr != nil {
+		return 0, s.lateFeeErr
+	}
+	if len(s.lateFeeResponses) == 0 {
+		return 0, nil
+	}
+	value := s.lateFeeResponses[0]
+	s.lateFeeResponses = s.lateFeeResponses[1:]
+	return value, nil
 }
 
 func (s *stubDashboardRepository) GetRevenue(ctx context.Context, input domain.DashboardQueryInput) (float64, error) {
@@ -148,16 +188,16 @@ func TestDashboardServiceListWidgetsByRole(t *testing.T) {
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
-	if len(adminItems) != 7 {
-		t.Fatalf("expected 7 admin widgets, got %d", len(adminItems))
+	if len(adminItems) != 10 {
+		t.Fatalf("expected 10 admin widgets, got %d", len(adminItems))
 	}
 
 	resellerItems, err := service.ListWidgets(ctxWithRole(domain.UserRoleReseller))
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
-	if len(resellerItems) != 4 {
-		t.Fatalf("expected 4 reseller widgets, got %d", len(resellerItems))
+	if len(resellerItems) != 5 {
+		t.Fatalf("expected 5 reseller widgets, got %d", len(resellerItems))
 	}
 }
 
@@ -224,8 +264,8 @@ func TestDashboardServiceGetWidgetDataProdutosEmEstoque(t *testing.T) {
 func TestDashboardServiceGetWidgetDataEstoqueBaixo(t *testing.T) {
 	repo := &stubDashboardRepository{
 		lowStock: []domain.DashboardLowStockItem{
-			{ProductName: "A", Quantity: 0},
-			{ProductName: "B", Quantity: 2},
+			{ProductName: "A", Sku: domain.Sku{Product: domain.Product{Name: "A"}}, Quantity: 0},
+			{ProductName: "B", Sku: domain.Sku{Product: domain.Product{Name: "B"}, Size: "M"}, Quantity: 2, MinQuantity: 3},
 		},
 	}
 	service := newDashboardService(repo, &stubUserRepository{})
@@ -238,9 +278,12 @@ func TestDashboardServiceGetWidgetDataEstoqueBaixo(t *testing.T) {
 	if !ok {
 		t.Fatalf("expected table data")
 	}
-	if len(data.Rows) != 2 || len(data.Columns) != 2 {
+	if len(data.Rows) != 2 || len(data.Columns) != 4 {
 		t.Fatalf("unexpected table data: %+v", data)
 	}
+	if data.Rows[1]["product"] != "B - M" || data.Rows[1]["inventory"] != "Central" || data.Rows[1]["min"] != 3.0 {
+		t.Fatalf("unexpected row: %+v", data.Rows[1])
+	}
 	if repo.lowStockInput.Threshold != 0 {
 		t.Fatalf("expected threshold 0")
 	}
@@ -521,3 +564,94 @@ func TestDashboardServiceHandleMeusProdutosMaisVendidos(t *testing.T) {
 		t.Fatalf("unexpected bar data: %+v", data)
 	}
 }
+
+func TestDashboardServiceGetWidgetDataMultasEJuros(t *testing.T) {
+	repo := &stubDashboardRepository{
+		revenueResponses: []float64{1000, 1000},
+		lateFeeResponses: []float64{30, 20},
+	}
+	service := newDashboardService(repo, &stubUserRepository{})
+
+	resp, err := service.GetWidgetData(ctxWithRole(domain.UserRoleAdmin), newDashboardRequest(domain.DashboardWidgetMultasEJuros))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	data, ok := resp.Data.(output.DashboardCardData)
+	if !ok {
+		t.Fatalf("expected card data")
+	}
+	if data.Value != 30 || data.PreviousValue != 20 || data.DeltaPercent != 50 {
+		t.Fatalf("expected fees reported apart from revenue, got %+v", data)
+	}
+	if len(repo.revenueInputs) != 0 || len(repo.lateFeeInputs) != 2 {
+		t.Fatalf("expected only late fee queries, got %d revenue and %d fee queries", len(repo.revenueInputs), len(repo.lateFeeInputs))
+	}
+}
+
+func TestDashboardServiceGetWidgetDataTaxasDeCartao(t *testing.T) {
+	repo := &stubDashboardRepository{cardFeeResponses: []float64{12, 8}}
+	service := newDashboardService(repo, &stubUserRepository{})
+
+	resp, err := service.GetWidgetData(ctxWithRole(domain.UserRoleAdmin), newDashboardRequest(domain.DashboardWidgetTaxasDeCartao))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	data, ok := resp.Data.(output.DashboardCardData)
+	if !ok {
+		t.Fatalf("expected card data")
+	}
+	if data.Value != 12 || data.PreviousValue != 8 || data.DeltaPercent != 50 {
+		t.Fatalf("unexpected card values: %+v", data)
+	}
+	if len(repo.cardFeeInputs) != 2 {
+		t.Fatalf("expected current and previous period queries, got %d", len(repo.cardFeeInputs))
+	}
+}
+
+func TestDashboardServiceGetWidgetDataRecebimentosDeCartao(t *testing.T) {
+	repo := &stubDashboardRepository{cardSettlements: []domain.DashboardTimeSeriesItem{
+		{Date: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), Value: 97.5},
+		{Date: time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC), Value: 48.75},
+	}}
+	service := newDashboardService(repo, &stubUserRepository{})
+
+	resp, err := service.GetWidgetData(ctxWithRole(domain.UserRoleAdmin), newDashboardRequest(domain.DashboardWidgetRecebimentosDeCartao))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	data, ok := resp.Data.(output.DashboardLineBarData)
+	if !ok {
+		t.Fatalf("expected line data")
+	}
+	if len(data.Labels) != 2 || data.Labels[1] != "2024-01-31" || data.Series[0].Values[0] != 97.5 {
+		t.Fatalf("unexpected series: %+v", data)
+	}
+
+	repo = &stubDashboardRepository{cardSettlementsErr: errors.New("db")}
+	service = newDashboardService(repo, &stubUserRepository{})
+	if _, err := service.GetWidgetData(ctxWithRole(domain.UserRoleAdmin), newDashboardRequest(domain.DashboardWidgetRecebimentosDeCartao)); err == nil {
+		t.Fatalf("expected repository error")
+	}
+}
+
+func TestDashboardServiceGetWidgetDataMinhasMultasEJuros(t *testing.T) {
+	repo := &stubDashboardRepository{lateFeeResponses: []float64{5, 0}}
+	service := newDashboardService(repo, &stubUserRepository{})
+
+	resp, err := service.GetWidgetData(ctxWithRoleAndUser(domain.UserRoleReseller, 9), newDashboardRequest(domain.DashboardWidgetMinhasMultasEJuros))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if data := resp.Data.(output.DashboardCardData); data.Value != 5 {
+		t.Fatalf("unexpected card values: %+v", data)
+	}
+	if repo.lateFeeInputs[0].ResellerId == nil || *repo.lateFeeInputs[0].ResellerId != 9 {
+		t.Fatalf("expected reseller filter, got %+v", repo.lateFeeInputs[0])
+	}
+
+	repo = &stubDashboardRepository{lateFeeErr: errors.New("db")}
+	service = newDashboardService(repo, &stubUserRepository{})
+	if _, err := service.GetWidgetData(ctxWithRoleAndUser(domain.UserRoleReseller, 9), newDashboardRequest(domain.DashboardWidgetMinhasMultasEJuros)); err == nil {
+		t.Fatalf("expected repository error")
+	}
+}
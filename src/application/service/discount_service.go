@@ -0,0 +1,38 @@
+package service
+
+import (
+	"context"
+
+	request "github.com/bncunha/erp-api/src/api/requests"
+	"github.com/bncunha/erp-api/src/application/service/output"
+	"github.com/bncunha/erp-api/src/domain"
+)
+
+type DiscountService interface {
+	GetRule(ctx context.Context) (output.GetDiscountRuleOutput, error)
+	UpdateRule(ctx context.Context, request request.UpdateDiscountRuleRequest) error
+}
+
+type discountService struct {
+	discountRuleRepository domain.DiscountRuleRepository
+}
+
+func NewDiscountService(discountRuleRepository domain.DiscountRuleRepository) DiscountService {
+	return &discountService{discountRuleRepository}
+}
+
+func (s *discountService) GetRule(ctx context.Context) (output.GetDiscountRuleOutput, error) {
+	return s.discountRuleRepository.Get(ctx)
+}
+
+func (s *discountService) UpdateRule(ctx context.Context, request request.UpdateDiscountRuleRequest) error {
+	if err := request.Validate(); err != nil {
+		return err
+	}
+
+	rule := domain.NewDiscountRule(request.ResellerMaxPercentage)
+	if err := rule.Validate(); err != nil {
+		return err
+	}
+	return s.discountRuleRepository.Save(ctx, rule)
+}
@@ -0,0 +1,51 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	request "github.com/bncunha/erp-api/src/api/requests"
+	"github.com/bncunha/erp-api/src/domain"
+)
+
+func TestDiscountServiceGetRule(t *testing.T) {
+	service := NewDiscountService(&stubDiscountRuleRepository{rule: domain.NewDiscountRule(10)})
+
+	rule, err := service.GetRule(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if rule.ResellerMaxPercentage != 10 {
+		t.Fatalf("unexpected rule: %+v", rule)
+	}
+}
+
+func TestDiscountServiceUpdateRule(t *testing.T) {
+	repo := &stubDiscountRuleRepository{}
+	service := NewDiscountService(repo)
+
+	if err := service.UpdateRule(context.Background(), request.UpdateDiscountRuleRequest{ResellerMaxPercentage: 15}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if repo.saved == nil || repo.saved.ResellerMaxPercentage != 15 {
+		t.Fatalf("unexpected saved rule: %+v", repo.saved)
+	}
+}
+
+func TestDiscountServiceUpdateRuleErrors(t *testing.T) {
+	repo := &stubDiscountRuleRepository{}
+	service := NewDiscountService(repo)
+
+	if err := service.UpdateRule(context.Background(), request.UpdateDiscountRuleRequest{ResellerMaxPercentage: 101}); err == nil {
+		t.Fatalf("expected validation error")
+	}
+	if repo.saved != nil {
+		t.Fatalf("expected rule not to be saved")
+	}
+
+	repo.saveErr = errors.New("db")
+	if err := service.UpdateRule(context.Background(), request.UpdateDiscountRuleRequest{ResellerMaxPercentage: 10}); err == nil {
+		t.Fatalf("expected repository error")
+	}
+}
@@ -0,0 +1,168 @@
+package service
+
+import (
+	"context"
+	"math"
+	"time"
+
+	request "github.com/bncunha/erp-api/src/api/requests"
+	"github.com/bncunha/erp-api/src/application/ports"
+	"github.com/bncunha/erp-api/src/application/service/output"
+	"github.com/bncunha/erp-api/src/domain"
+)
+
+type DocumentService interface {
+	GetSaleReceipt(ctx context.Context, saleId int64) ([]byte, error)
+	GetSaleCarne(ctx context.Context, saleId int64) ([]byte, error)
+	GetTemplates(ctx context.Context) ([]output.GetDocumentTemplateOutput, error)
+	SaveTemplate(ctx context.Context, request request.SaveDocumentTemplateRequest) error
+	ResetTemplate(ctx context.Context, templateType domain.DocumentTemplateType) error
+}
+
+type documentService struct {
+	salesService               SalesService
+	companyRepository          domain.CompanyRepository
+	documentTemplateRepository domain.DocumentTemplateRepository
+	pdfPort                    ports.PdfPort
+}
+
+func NewDocumentService(salesService SalesService, companyRepository domain.CompanyRepository, documentTemplateRepository domain.DocumentTemplateRepository, pdfPort ports.PdfPort) DocumentService {
+	return &documentService{
+		salesService:               salesService,
+		companyRepository:          companyRepository,
+		documentTemplateRepository: documentTemplateRepository,
+		pdfPort:                    pdfPort,
+	}
+}
+
+func (s *documentService) GetSaleReceipt(ctx context.Context, saleId int64) ([]byte, error) {
+	data, err := s.buildSaleData(ctx, saleId)
+	if err != nil {
+		return nil, err
+	}
+	return s.render(ctx, domain.DocumentTemplateTypeSaleReceipt, data)
+}
+
+// GetSaleCarne gera o carnê com uma lâmina para cada parcela de notinha da
+// venda.
+func (s *documentService) GetSaleCarne(ctx context.Context, saleId int64) ([]byte, error) {
+	data, err := s.buildSaleData(ctx, saleId)
+	if err != nil {
+		return nil, err
+	}
+	for _, payment := range data.Payments {
+		if payment.Type == domain.PaymentTypeCreditStore {
+			data.Installments = payment.Installments
+		}
+	}
+	if len(data.Installments) == 0 {
+		return nil, domain.ErrSaleWithoutCreditStore
+	}
+	return s.render(ctx, domain.DocumentTemplateTypeCarne, data)
+}
+
+func (s *documentService) GetTemplates(ctx context.Context) ([]output.GetDocumentTemplateOutput, error) {
+	templates := make([]output.GetDocumentTemplateOutput, 0, 2)
+	for _, templateType := range []domain.DocumentTemplateType{domain.DocumentTemplateTypeSaleReceipt, domain.DocumentTemplateTypeCarne} {
+		template, err := s.documentTemplateRepository.GetByType(ctx, templateType)
+		if err != nil {
+			return nil, err
+		}
+		templates = append(templates, template)
+	}
+	return templates, nil
+}
+
+func (s *documentService) SaveTemplate(ctx context.Context, request request.SaveDocumentTemplateRequest) error {
+	if err := request.Validate(); err != nil {
+		return err
+	}
+	template := domain.NewDocumentTemplate(request.Type, request.Content)
+	if err := template.Validate(); err != nil {
+		return err
+	}
+	return s.documentTemplateRepository.Save(ctx, template)
+}
+
+// ResetTemplate remove o modelo personalizado e volta a usar o padrão.
+func (s *documentService) ResetTemplate(ctx context.Context, templateType domain.DocumentTemplateType) error {
+	if !domain.IsDocumentTemplateTypeValid(templateType) {
+		return domain.ErrDocumentTemplateTypeInvalid
+	}
+	return s.documentTemplateRepository.Delete(ctx, templateType)
+}
+
+func (s *documentService) render(ctx context.Context, templateType domain.DocumentTemplateType, data domain.DocumentData) ([]byte, error) {
+	template, err := s.documentTemplateRepository.GetByType(ctx, templateType)
+	if err != nil {
+		return nil, err
+	}
+	markup, err := template.Execute(data)
+	if err != nil {
+		return nil, err
+	}
+	return s.pdfPort.Render(markup)
+}
+
+func (s *documentService) buildSaleData(ctx context.Context, saleId int64) (domain.DocumentData, error) {
+	sale, paymentGroups, items, _, err := s.salesService.GetById(ctx, saleId)
+	if err != nil {
+		return domain.DocumentData{}, err
+	}
+	company, err := s.companyRepository.Get(ctx)
+	if err != nil {
+		return domain.DocumentData{}, err
+	}
+
+	data := domain.DocumentData{
+		Company: domain.NewDocumentCompany(company),
+		Sale: domain.DocumentSale{
+			Code:          sale.Code,
+			Date:          sale.Date,
+			SellerName:    sale.SellerName,
+			CustomerName:  sale.CustomerName,
+			ReceivedValue: sale.ReceivedValue,
+			FutureRevenue: sale.FutureRevenue,
+			CashReceived:  sale.CashReceived,
+			ChangeAmount:  sale.ChangeAmount,
+			PaymentStatus: sale.PaymentStatus,
+		},
+		Items:       make([]domain.DocumentItem, 0, len(items)),
+		Payments:    make([]domain.DocumentPayment, 0, len(paymentGroups)),
+		GeneratedAt: time.Now(),
+	}
+
+	for _, item := range items {
+		gross := item.UnitPrice * item.Quantity
+		total := item.GetTotal()
+		data.Sale.Subtotal += gross
+		data.Sale.Total += total
+		data.Items = append(data.Items, domain.DocumentItem{
+			Code:        item.Sku.Code,
+			Description: item.Sku.GetName(),
+			Quantity:    item.Quantity,
+			UnitPrice:   item.UnitPrice,
+			Discount:    math.Round((gross-total)*100) / 100,
+			Total:       total,
+		})
+	}
+	data.Sale.Subtotal = math.Round(data.Sale.Subtotal*100) / 100
+	data.Sale.Total = math.Round(data.Sale.Total*100) / 100
+	data.Sale.Discount = math.Round((data.Sale.Subtotal-data.Sale.Total)*100) / 100
+
+	for _, group := range paymentGroups {
+		payment := domain.DocumentPayment{Type: group.PaymentType, Installments: make([]domain.DocumentInstallment, 0, len(group.Installments))}
+		for _, installment := range group.Installments {
+			payment.Installments = append(payment.Installments, domain.DocumentInstallment{
+				Number:   installment.InstallmentNumber,
+				Of:       len(group.Installments),
+				DueDate:  installment.DueDate,
+				PaidDate: installment.PaidDate,
+				Value:    installment.InstallmentValue,
+				Status:   installment.PaymentStatus,
+			})
+		}
+		data.Payments = append(data.Payments, payment)
+	}
+	return data, nil
+}
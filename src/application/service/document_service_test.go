@@ -0,0 +1,97 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	request "github.com/bncunha/erp-api/src/api/requests"
+	"github.com/bncunha/erp-api/src/application/service/output"
+	"github.com/bncunha/erp-api/src/domain"
+)
+
+func newDocumentSalesRepository(paymentType domain.PaymentType) *stubSalesRepository {
+	return &stubSalesRepository{
+		saleByIdOutput: output.GetSaleByIdOutput{Id: 1, Code: "V-1", Date: time.Now(), CustomerName: "Maria"},
+		paymentsOutput: []output.GetSalesPaymentOutput{
+			{Id: 1, InstallmentNumber: 1, InstallmentValue: 45, DueDate: time.Now().AddDate(0, 1, 0), PaymentStatus: domain.PaymentStatusPending, PaymentType: paymentType},
+			{Id: 2, InstallmentNumber: 2, InstallmentValue: 45, DueDate: time.Now().AddDate(0, 2, 0), PaymentStatus: domain.PaymentStatusPending, PaymentType: paymentType},
+		},
+		itemsOutput: []output.GetItemsOutput{
+			{Sku: domain.Sku{Code: "B-P", Product: domain.Product{Name: "Blusa"}}, Quantity: 2, UnitPrice: 50, DiscountAmount: 10},
+		},
+	}
+}
+
+func newDocumentSalesService(salesRepository *stubSalesRepository) SalesService {
+	return NewSalesService(&stubSalesUseCase{}, salesRepository, &stubInventoryRepository{}, &stubLateFeeRuleRepository{})
+}
+
+func TestDocumentServiceGetSaleReceipt(t *testing.T) {
+	pdfPort := &stubPdfPort{}
+	service := NewDocumentService(newDocumentSalesService(newDocumentSalesRepository(domain.PaymentTypeCreditStore)), &stubCompanyRepository{company: domain.Company{Name: "Loja"}}, &stubDocumentTemplateRepository{}, pdfPort)
+
+	pdf, err := service.GetSaleReceipt(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(pdf) != "%PDF" {
+		t.Fatalf("unexpected pdf: %s", pdf)
+	}
+	for _, expected := range []string{"# Loja", "Recibo de venda V-1", "Subtotal | R$ 100,00", "Desconto | -R$ 10,00", "## Total | R$ 90,00", "Parcela 2/2"} {
+		if !strings.Contains(pdfPort.markup, expected) {
+			t.Fatalf("expected markup to contain %q:\n%s", expected, pdfPort.markup)
+		}
+	}
+}
+
+func TestDocumentServiceGetSaleCarne(t *testing.T) {
+	pdfPort := &stubPdfPort{}
+	templates := &stubDocumentTemplateRepository{templates: map[domain.DocumentTemplateType]domain.DocumentTemplate{
+		domain.DocumentTemplateTypeCarne: domain.NewDocumentTemplate(domain.DocumentTemplateTypeCarne, "{{range .Installments}}{{.Number}}/{{.Of}} {{money .Value}};{{end}}"),
+	}}
+	service := NewDocumentService(newDocumentSalesService(newDocumentSalesRepository(domain.PaymentTypeCreditStore)), &stubCompanyRepository{}, templates, pdfPort)
+
+	if _, err := service.GetSaleCarne(context.Background(), 1); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if pdfPort.markup != "1/2 R$ 45,00;2/2 R$ 45,00;" {
+		t.Fatalf("unexpected markup: %s", pdfPort.markup)
+	}
+
+	service = NewDocumentService(newDocumentSalesService(newDocumentSalesRepository(domain.PaymentTypePix)), &stubCompanyRepository{}, templates, pdfPort)
+	if _, err := service.GetSaleCarne(context.Background(), 1); err != domain.ErrSaleWithoutCreditStore {
+		t.Fatalf("expected credit store error, got %v", err)
+	}
+}
+
+func TestDocumentServiceTemplates(t *testing.T) {
+	templates := &stubDocumentTemplateRepository{}
+	service := NewDocumentService(newDocumentSalesService(&stubSalesRepository{}), &stubCompanyRepository{}, templates, &stubPdfPort{})
+
+	list, err := service.GetTemplates(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(list) != 2 || !list[0].IsDefault || list[1].Type != domain.DocumentTemplateTypeCarne {
+		t.Fatalf("unexpected templates: %+v", list)
+	}
+
+	if err := service.SaveTemplate(context.Background(), request.SaveDocumentTemplateRequest{Type: domain.DocumentTemplateTypeSaleReceipt, Content: "{{if .Sale.Code}}"}); err == nil || templates.saved != nil {
+		t.Fatalf("expected invalid template error, got %v", err)
+	}
+	if err := service.SaveTemplate(context.Background(), request.SaveDocumentTemplateRequest{Type: domain.DocumentTemplateTypeSaleReceipt, Content: "# {{.Company.Name}}"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if templates.saved == nil || templates.saved.Content != "# {{.Company.Name}}" {
+		t.Fatalf("expected template to be saved, got %+v", templates.saved)
+	}
+
+	if err := service.ResetTemplate(context.Background(), "NOTA"); err != domain.ErrDocumentTemplateTypeInvalid {
+		t.Fatalf("expected type error, got %v", err)
+	}
+	if err := service.ResetTemplate(context.Background(), domain.DocumentTemplateTypeCarne); err != nil || templates.deleted != domain.DocumentTemplateTypeCarne {
+		t.Fatalf("expected template to be deleted, got %v", err)
+	}
+}
@@ -0,0 +1,59 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/bncunha/erp-api/src/application/service/output"
+	"github.com/bncunha/erp-api/src/domain"
+)
+
+type IdempotencyService interface {
+	Begin(ctx context.Context, key string, scope string, payload interface{}) (output.IdempotencyKeyOutput, bool, error)
+	Complete(ctx context.Context, key output.IdempotencyKeyOutput, responseStatus int, responseBody interface{}) error
+	Release(ctx context.Context, key output.IdempotencyKeyOutput) error
+}
+
+type idempotencyService struct {
+	idempotencyKeyRepository domain.IdempotencyKeyRepository
+}
+
+func NewIdempotencyService(idempotencyKeyRepository domain.IdempotencyKeyRepository) IdempotencyService {
+	return &idempotencyService{idempotencyKeyRepository}
+}
+
+// Begin reserva a chave para a requisição. Quando a chave já foi concluída com
+// a mesma requisição, retorna o registro gravado e true para que a resposta
+// original seja repetida.
+func (s *idempotencyService) Begin(ctx context.Context, key string, scope string, payload interface{}) (output.IdempotencyKeyOutput, bool, error) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return output.IdempotencyKeyOutput{}, false, err
+	}
+	idempotencyKey := domain.NewIdempotencyKey(key, scope, body)
+	if err := idempotencyKey.Validate(); err != nil {
+		return idempotencyKey, false, err
+	}
+
+	stored, reserved, err := s.idempotencyKeyRepository.Reserve(ctx, idempotencyKey)
+	if err != nil || reserved {
+		return stored, false, err
+	}
+	if err := stored.CheckReplay(idempotencyKey.RequestHash); err != nil {
+		return stored, false, err
+	}
+	return stored, true, nil
+}
+
+func (s *idempotencyService) Complete(ctx context.Context, key output.IdempotencyKeyOutput, responseStatus int, responseBody interface{}) error {
+	body, err := json.Marshal(responseBody)
+	if err != nil {
+		return err
+	}
+	key.Complete(responseStatus, body)
+	return s.idempotencyKeyRepository.Complete(ctx, key)
+}
+
+func (s *idempotencyService) Release(ctx context.Context, key output.IdempotencyKeyOutput) error {
+	return s.idempotencyKeyRepository.Release(ctx, key)
+}
@@ -0,0 +1,67 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/bncunha/erp-api/src/domain"
+)
+
+func TestIdempotencyServiceBeginReserves(t *testing.T) {
+	repo := &stubIdempotencyKeyRepository{reserved: true}
+	svc := NewIdempotencyService(repo)
+
+	key, replay, err := svc.Begin(context.Background(), "abc", "POST /sales", map[string]int{"a": 1})
+	if err != nil || replay {
+		t.Fatalf("unexpected result: replay=%v err=%v", replay, err)
+	}
+	if key.Key != "abc" || key.Status != domain.IdempotencyKeyStatusProcessing {
+		t.Fatalf("unexpected key: %+v", key)
+	}
+}
+
+func TestIdempotencyServiceBeginInvalidKey(t *testing.T) {
+	svc := NewIdempotencyService(&stubIdempotencyKeyRepository{reserved: true})
+	if _, _, err := svc.Begin(context.Background(), " ", "POST /sales", nil); err != domain.ErrIdempotencyKeyInvalid {
+		t.Fatalf("expected invalid key, got %v", err)
+	}
+}
+
+func TestIdempotencyServiceBeginReplay(t *testing.T) {
+	payload := map[string]int{"a": 1}
+	body, _ := json.Marshal(payload)
+	stored := domain.NewIdempotencyKey("abc", "POST /sales", body)
+	svc := NewIdempotencyService(&stubIdempotencyKeyRepository{stored: stored})
+
+	if _, _, err := svc.Begin(context.Background(), "abc", "POST /sales", payload); err != domain.ErrIdempotencyKeyInProgress {
+		t.Fatalf("expected in progress, got %v", err)
+	}
+
+	stored.Complete(201, []byte("null"))
+	svc = NewIdempotencyService(&stubIdempotencyKeyRepository{stored: stored})
+	key, replay, err := svc.Begin(context.Background(), "abc", "POST /sales", payload)
+	if err != nil || !replay || key.ResponseStatus != 201 {
+		t.Fatalf("unexpected result: key=%+v replay=%v err=%v", key, replay, err)
+	}
+
+	if _, _, err := svc.Begin(context.Background(), "abc", "POST /sales", map[string]int{"a": 2}); err != domain.ErrIdempotencyKeyReused {
+		t.Fatalf("expected reused, got %v", err)
+	}
+}
+
+func TestIdempotencyServiceCompleteAndRelease(t *testing.T) {
+	repo := &stubIdempotencyKeyRepository{}
+	svc := NewIdempotencyService(repo)
+	key := domain.NewIdempotencyKey("abc", "POST /sales", nil)
+
+	if err := svc.Complete(context.Background(), key, 201, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if repo.completed.Status != domain.IdempotencyKeyStatusCompleted || repo.completed.ResponseStatus != 201 || string(repo.completed.ResponseBody) != "null" {
+		t.Fatalf("unexpected completed key: %+v", repo.completed)
+	}
+	if err := svc.Release(context.Background(), key); err != nil || repo.released.Key != "abc" {
+		t.Fatalf("expected key to be released, err=%v", err)
+	}
+}
@@ -16,6 +16,7 @@ type transactionManager interface {
 
 type InventoryService interface {
 	DoTransaction(ctx context.Context, request request.CreateInventoryTransactionRequest) error
+	TransferAll(ctx context.Context, id int64, request request.TransferAllInventoryRequest) error
 	GetAllInventoryItems(ctx context.Context) ([]output.GetInventoryItemsOutput, error)
 	GetInventoryItemsByInventoryId(ctx context.Context, id int64) ([]output.GetInventoryItemsOutput, error)
 	GetInventoryTransactionsByInventoryId(ctx context.Context, id int64) ([]output.GetInventoryTransactionsOutput, error)
@@ -47,6 +48,7 @@ func (s *inventoryService) DoTransaction(ctx context.Context, request request.Cr
 		inputSkus = append(inputSkus, inventory_usecase.DoTransactionSkusInput{
 			SkuId:    sku.SkuId,
 			Quantity: sku.Quantity,
+			UnitCost: sku.UnitCost,
 		})
 	}
 
@@ -79,6 +81,49 @@ func (s *inventoryService) DoTransaction(ctx context.Context, request request.Cr
 	return nil
 }
 
+// TransferAll esvazia o estoque informado, transferindo todo o saldo para o
+// destino. Sem destino, o saldo volta para o estoque principal.
+func (s *inventoryService) TransferAll(ctx context.Context, id int64, request request.TransferAllInventoryRequest) (err error) {
+	if err = request.Validate(); err != nil {
+		return err
+	}
+	if _, err = s.inventoryRepository.GetById(ctx, id); err != nil {
+		return err
+	}
+	destinationId := request.InventoryDestinationId
+	if destinationId == 0 {
+		primaryInventory, err := s.inventoryRepository.GetPrimaryInventory(ctx)
+		if err != nil {
+			return err
+		}
+		destinationId = primaryInventory.Id
+	}
+	justification := request.Justification
+	if justification == "" {
+		justification = "Transferência de todo o estoque"
+	}
+
+	tx, err := s.txManager.BeginTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	err = s.inventoryUseCase.DoTransferAll(ctx, tx, inventory_usecase.DoTransferAllInput{
+		InventoryOriginId:      id,
+		InventoryDestinationId: destinationId,
+		Justification:          justification,
+	})
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
 func (s *inventoryService) GetAllInventoryItems(ctx context.Context) ([]output.GetInventoryItemsOutput, error) {
 	return s.inventoryItemRepository.GetAll(ctx)
 }
@@ -0,0 +1,38 @@
+package service
+
+import (
+	"context"
+
+	request "github.com/bncunha/erp-api/src/api/requests"
+	"github.com/bncunha/erp-api/src/application/service/output"
+	"github.com/bncunha/erp-api/src/domain"
+)
+
+type LateFeeService interface {
+	GetRule(ctx context.Context) (output.GetLateFeeRuleOutput, error)
+	UpdateRule(ctx context.Context, request request.UpdateLateFeeRuleRequest) error
+}
+
+type lateFeeService struct {
+	lateFeeRuleRepository domain.LateFeeRuleRepository
+}
+
+func NewLateFeeService(lateFeeRuleRepository domain.LateFeeRuleRepository) LateFeeService {
+	return &lateFeeService{lateFeeRuleRepository}
+}
+
+func (s *lateFeeService) GetRule(ctx context.Context) (output.GetLateFeeRuleOutput, error) {
+	return s.lateFeeRuleRepository.Get(ctx)
+}
+
+func (s *lateFeeService) UpdateRule(ctx context.Context, request request.UpdateLateFeeRuleRequest) error {
+	if err := request.Validate(); err != nil {
+		return err
+	}
+
+	rule := domain.NewLateFeeRule(request.FinePercentage, request.DailyInterestPercentage)
+	if err := rule.Validate(); err != nil {
+		return err
+	}
+	return s.lateFeeRuleRepository.Save(ctx, rule)
+}
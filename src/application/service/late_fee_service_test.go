@@ -0,0 +1,53 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	request "github.com/bncunha/erp-api/src/api/requests"
+	"github.com/bncunha/erp-api/src/domain"
+)
+
+func TestLateFeeServiceGetRule(t *testing.T) {
+	repo := &stubLateFeeRuleRepository{rule: domain.NewLateFeeRule(2, 0.033)}
+	service := NewLateFeeService(repo)
+
+	rule, err := service.GetRule(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if rule.FinePercentage != 2 || rule.DailyInterestPercentage != 0.033 {
+		t.Fatalf("unexpected rule: %+v", rule)
+	}
+}
+
+func TestLateFeeServiceUpdateRule(t *testing.T) {
+	repo := &stubLateFeeRuleRepository{}
+	service := NewLateFeeService(repo)
+
+	err := service.UpdateRule(context.Background(), request.UpdateLateFeeRuleRequest{FinePercentage: 2, DailyInterestPercentage: 0.033})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if repo.saved == nil || repo.saved.FinePercentage != 2 || repo.saved.DailyInterestPercentage != 0.033 {
+		t.Fatalf("unexpected saved rule: %+v", repo.saved)
+	}
+}
+
+func TestLateFeeServiceUpdateRuleErrors(t *testing.T) {
+	repo := &stubLateFeeRuleRepository{}
+	service := NewLateFeeService(repo)
+
+	if err := service.UpdateRule(context.Background(), request.UpdateLateFeeRuleRequest{FinePercentage: 150}); err == nil {
+		t.Fatalf("expected validation error")
+	}
+	if repo.saved != nil {
+		t.Fatalf("expected rule not to be saved")
+	}
+
+	repo.saveErr = errors.New("db")
+	if err := service.UpdateRule(context.Background(), request.UpdateLateFeeRuleRequest{FinePercentage: 2}); err == nil {
+		t.Fatalf("expected repository error")
+	}
+}
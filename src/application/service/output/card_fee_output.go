@@ -0,0 +1,5 @@
+package output
+
+import "github.com/bncunha/erp-api/src/domain"
+
+type GetCardFeeRuleOutput = domain.CardFeeRule
@@ -0,0 +1,7 @@
+package output
+
+import "github.com/bncunha/erp-api/src/domain"
+
+type GetCommissionRuleOutput = domain.CommissionRule
+
+type GetCommissionStatementOutput = domain.CommissionStatement
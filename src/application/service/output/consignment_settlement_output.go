@@ -0,0 +1,7 @@
+package output
+
+import "github.com/bncunha/erp-api/src/domain"
+
+type GetConsignmentSettlementOutput = domain.ConsignmentSettlement
+
+type GetConsignmentSettlementsOutput = domain.GetConsignmentSettlementsOutput
@@ -0,0 +1,9 @@
+package output
+
+import "github.com/bncunha/erp-api/src/domain"
+
+type GetCustomerCreditOutput struct {
+	CustomerId int64
+	Balance    float64
+	Movements  []domain.CustomerCredit
+}
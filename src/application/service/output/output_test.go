@@ -2,6 +2,7 @@ package output
 
 import (
 	"testing"
+	"time"
 
 	"github.com/bncunha/erp-api/src/domain"
 )
@@ -35,19 +36,23 @@ func TestLoginOutput(t *testing.T) {
 }
 
 func TestGetSalesOutputGetSummary(t *testing.T) {
-	sales := []GetSalesItemOutput{
-		{ReceivedValue: 10, FutureRevenue: 5, TotalItems: 2, TotalValue: 20},
-		{ReceivedValue: 5, FutureRevenue: 10, TotalItems: 1, TotalValue: 10},
+	out := GetSalesOutput{
+		Sales:    []GetSalesItemOutput{{ReceivedValue: 10, FutureRevenue: 5, TotalItems: 2, TotalValue: 20}},
+		Totals:   GetSalesTotalsOutput{TotalSales: 2, TotalItems: 3, TotalValue: 30, ReceivedValue: 15, FutureRevenue: 15},
+		Page:     1,
+		PageSize: 1,
 	}
-	out := GetSalesOutput{Sales: sales}
 	summary := out.GetSummary()
 
-	if summary.TotalSales != 2 || summary.AverageTicket != 15 {
+	if summary.TotalSales != 2 || summary.AverageTicket != 15 || summary.TotalValue != 30 {
 		t.Fatalf("unexpected summary calculation: %+v", summary)
 	}
 	if summary.ReceivedValue != 15 || summary.FutureRevenue != 15 || summary.TotalItems != 3 {
 		t.Fatalf("unexpected aggregate values: %+v", summary)
 	}
+	if out.GetTotalPages() != 2 {
+		t.Fatalf("expected 2 pages, got %d", out.GetTotalPages())
+	}
 }
 
 func TestGetSalesOutputGetSummaryEmpty(t *testing.T) {
@@ -57,3 +62,40 @@ func TestGetSalesOutputGetSummaryEmpty(t *testing.T) {
 		t.Fatalf("expected zeroed summary, got %+v", summary)
 	}
 }
+
+func TestGetReceivablesOutputGetSummary(t *testing.T) {
+	today := time.Date(2025, 6, 30, 0, 0, 0, 0, time.UTC)
+	out := GetReceivablesOutput{
+		Today: today,
+		Receivables: []domain.Receivable{
+			{CustomerId: 1, CustomerName: "Ana", InstallmentValue: 10.1, DueDate: today.AddDate(0, 0, 5), Status: domain.PaymentStatusPending},
+			{CustomerId: 1, CustomerName: "Ana", InstallmentValue: 20.2, DueDate: today.AddDate(0, 0, -10), Status: domain.PaymentStatusDelayed},
+			{CustomerId: 2, CustomerName: "Bia", InstallmentValue: 50, DueDate: today.AddDate(0, 0, -100), Status: domain.PaymentStatusDelayed},
+		},
+	}
+
+	summary := out.GetSummary()
+	if summary.TotalInstallments != 3 || summary.TotalValue != 80.3 || summary.PendingValue != 10.1 || summary.DelayedValue != 70.2 {
+		t.Fatalf("unexpected totals: %+v", summary)
+	}
+	expectedAging := map[domain.ReceivableAgingBucket]float64{
+		domain.ReceivableAgingNotDue: 10.1,
+		domain.ReceivableAging1To30:  20.2,
+		domain.ReceivableAgingOver90: 50,
+	}
+	if len(summary.Aging) != len(domain.ReceivableAgingBuckets) {
+		t.Fatalf("expected all aging buckets, got %+v", summary.Aging)
+	}
+	for _, aging := range summary.Aging {
+		if aging.Value != expectedAging[aging.Bucket] {
+			t.Fatalf("unexpected value for bucket %s: %v", aging.Bucket, aging.Value)
+		}
+	}
+	if len(summary.Customers) != 2 || summary.Customers[0].CustomerId != 2 {
+		t.Fatalf("expected customers ordered by total, got %+v", summary.Customers)
+	}
+	ana := summary.Customers[1]
+	if ana.Installments != 2 || ana.TotalValue != 30.3 || ana.DelayedValue != 20.2 || !ana.OldestDueDate.Equal(today.AddDate(0, 0, -10)) {
+		t.Fatalf("unexpected customer summary: %+v", ana)
+	}
+}
@@ -0,0 +1,20 @@
+package output
+
+import "github.com/bncunha/erp-api/src/domain"
+
+type GetPixSettingsOutput = domain.PixSettings
+
+type GetPixChargeOutput = domain.PixCharge
+
+type PixReconciliationOutput struct {
+	Matches   []domain.PixReconciliationMatch
+	Unmatched []domain.BankStatementTransaction
+	Failed    []PixReconciliationFailure
+}
+
+// PixReconciliationFailure é um crédito relacionado a uma parcela que não pôde
+// ser baixada, devolvido com o motivo para conferência manual.
+type PixReconciliationFailure struct {
+	Match domain.PixReconciliationMatch
+	Error string
+}
@@ -0,0 +1,9 @@
+package output
+
+import "github.com/bncunha/erp-api/src/domain"
+
+type GetPurchaseOrderOutput = domain.PurchaseOrder
+
+type GetPurchaseOrdersOutput = domain.GetPurchaseOrdersOutput
+
+type GetSkuPurchasesOutput = domain.GetSkuPurchasesOutput
@@ -0,0 +1,7 @@
+package output
+
+import "github.com/bncunha/erp-api/src/domain"
+
+type GetQuotesOutput = domain.GetQuotesOutput
+
+type GetQuoteByIdOutput = domain.Quote
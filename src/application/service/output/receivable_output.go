@@ -0,0 +1,107 @@
+package output
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/bncunha/erp-api/src/domain"
+)
+
+type GetReceivablesOutput struct {
+	Today       time.Time
+	Receivables []domain.Receivable
+}
+
+type GetReceivablesSummaryOutput struct {
+	TotalInstallments int
+	TotalValue        float64
+	PendingValue      float64
+	DelayedValue      float64
+	LateFeeValue      float64
+	Aging             []GetReceivablesAgingOutput
+	Customers         []GetReceivablesCustomerOutput
+}
+
+type GetReceivablesAgingOutput struct {
+	Bucket       domain.ReceivableAgingBucket
+	Installments int
+	Value        float64
+}
+
+type GetReceivablesCustomerOutput struct {
+	CustomerId    int64
+	CustomerName  string
+	Installments  int
+	TotalValue    float64
+	DelayedValue  float64
+	OldestDueDate time.Time
+}
+
+func (o *GetReceivablesOutput) GetSummary() GetReceivablesSummaryOutput {
+	summary := GetReceivablesSummaryOutput{
+		Aging:     make([]GetReceivablesAgingOutput, len(domain.ReceivableAgingBuckets)),
+		Customers: make([]GetReceivablesCustomerOutput, 0),
+	}
+	agingIndex := make(map[domain.ReceivableAgingBucket]int)
+	for i, bucket := range domain.ReceivableAgingBuckets {
+		summary.Aging[i].Bucket = bucket
+		agingIndex[bucket] = i
+	}
+
+	customerIndex := make(map[int64]int)
+	for _, receivable := range o.Receivables {
+		summary.TotalInstallments++
+		summary.TotalValue += receivable.GetOpenValue()
+		if receivable.Status == domain.PaymentStatusDelayed {
+			summary.DelayedValue += receivable.GetOpenValue()
+		} else {
+			summary.PendingValue += receivable.GetOpenValue()
+		}
+		summary.LateFeeValue += receivable.LateFee.GetTotal()
+
+		aging := &summary.Aging[agingIndex[receivable.GetAgingBucket(o.Today)]]
+		aging.Installments++
+		aging.Value += receivable.GetOpenValue()
+
+		i, ok := customerIndex[receivable.CustomerId]
+		if !ok {
+			i = len(summary.Customers)
+			customerIndex[receivable.CustomerId] = i
+			summary.Customers = append(summary.Customers, GetReceivablesCustomerOutput{
+				CustomerId:    receivable.CustomerId,
+				CustomerName:  receivable.CustomerName,
+				OldestDueDate: receivable.DueDate,
+			})
+		}
+		customer := &summary.Customers[i]
+		customer.Installments++
+		customer.TotalValue += receivable.GetOpenValue()
+		if receivable.Status == domain.PaymentStatusDelayed {
+			customer.DelayedValue += receivable.GetOpenValue()
+		}
+		if receivable.DueDate.Before(customer.OldestDueDate) {
+			customer.OldestDueDate = receivable.DueDate
+		}
+	}
+
+	summary.TotalValue = roundValue(summary.TotalValue)
+	summary.PendingValue = roundValue(summary.PendingValue)
+	summary.DelayedValue = roundValue(summary.DelayedValue)
+	summary.LateFeeValue = roundValue(summary.LateFeeValue)
+	for i := range summary.Aging {
+		summary.Aging[i].Value = roundValue(summary.Aging[i].Value)
+	}
+	for i := range summary.Customers {
+		summary.Customers[i].TotalValue = roundValue(summary.Customers[i].TotalValue)
+		summary.Customers[i].DelayedValue = roundValue(summary.Customers[i].DelayedValue)
+	}
+	sort.SliceStable(summary.Customers, func(i, j int) bool {
+		return summary.Customers[i].TotalValue > summary.Customers[j].TotalValue
+	})
+	return summary
+}
+
+func roundValue(value float64) float64 {
+	return math.Round(value*100) / 100
+}
@@ -7,38 +7,44 @@ import (
 )
 
 type GetSalesOutput struct {
-	Sales []GetSalesItemOutput
+	Sales    []GetSalesItemOutput
+	Totals   GetSalesTotalsOutput
+	Page     int
+	PageSize int
 }
 
+// GetSummary resume todas as vendas filtradas, não apenas as da página.
 func (o *GetSalesOutput) GetSummary() GetSalesSummaryOutput {
-	summary := GetSalesSummaryOutput{}
-	totalSum := 0.0
-	summary.TotalSales = 0
-	summary.ReceivedValue = 0
-	summary.FutureRevenue = 0
-	summary.AverageTicket = 0
-
-	for _, sale := range o.Sales {
-		summary.ReceivedValue += sale.ReceivedValue
-		summary.FutureRevenue += sale.FutureRevenue
-		summary.TotalItems += sale.TotalItems
-		totalSum += sale.TotalValue
+	summary := GetSalesSummaryOutput{
+		TotalItems:    o.Totals.TotalItems,
+		TotalSales:    float64(o.Totals.TotalSales),
+		TotalValue:    o.Totals.TotalValue,
+		ReceivedValue: o.Totals.ReceivedValue,
+		FutureRevenue: o.Totals.FutureRevenue,
 	}
-	if len(o.Sales) > 0 {
-		summary.AverageTicket = totalSum / float64(len(o.Sales))
-		summary.TotalSales = float64(len(o.Sales))
+	if o.Totals.TotalSales > 0 {
+		summary.AverageTicket = o.Totals.TotalValue / float64(o.Totals.TotalSales)
 	}
 	return summary
 }
 
+func (o *GetSalesOutput) GetTotalPages() int {
+	if o.PageSize <= 0 {
+		return 0
+	}
+	return int((o.Totals.TotalSales + int64(o.PageSize) - 1) / int64(o.PageSize))
+}
+
 type GetSalesSummaryOutput struct {
 	TotalItems    float64
 	TotalSales    float64
+	TotalValue    float64
 	ReceivedValue float64
 	FutureRevenue float64
 	AverageTicket float64
 }
 
+type GetSalesTotalsOutput = domain.GetSalesTotalsOutput
 type GetSalesItemOutput = domain.GetSalesItemOutput
 type GetSaleByIdOutput = domain.GetSaleByIdOutput
 
@@ -59,6 +65,7 @@ type GetSaleByIdItem struct {
 }
 
 type GetSalesPaymentOutput = domain.GetSalesPaymentOutput
+type GetPaymentReceiptOutput = domain.PaymentReceipt
 
 type GetSalesPaymentGroupOutput struct {
 	PaymentType  domain.PaymentType
@@ -67,3 +74,33 @@ type GetSalesPaymentGroupOutput struct {
 
 type GetItemsOutput = domain.GetItemsOutput
 type GetSalesReturnOutput = domain.GetSalesReturnOutput
+type GetSalesRenegotiationOutput = domain.GetSalesRenegotiationOutput
+
+const (
+	SalesItemChangeAdded   = "ADDED"
+	SalesItemChangeRemoved = "REMOVED"
+	SalesItemChangeChanged = "CHANGED"
+)
+
+type GetSalesVersionOutput struct {
+	domain.GetSalesVersionOutput
+	TotalValue float64
+	Items      []GetItemsOutput
+	Payments   []GetSalesPaymentGroupOutput
+	Changes    *GetSalesVersionChangesOutput
+}
+
+type GetSalesVersionChangesOutput struct {
+	PreviousCustomerName *string
+	PreviousTotalValue   float64
+	Items                []GetSalesVersionItemChangeOutput
+}
+
+type GetSalesVersionItemChangeOutput struct {
+	Sku               domain.Sku
+	Type              string
+	PreviousQuantity  float64
+	Quantity          float64
+	PreviousUnitPrice float64
+	UnitPrice         float64
+}
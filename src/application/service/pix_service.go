@@ -0,0 +1,136 @@
+package service
+
+import (
+	"context"
+
+	request "github.com/bncunha/erp-api/src/api/requests"
+	"github.com/bncunha/erp-api/src/application/ports"
+	"github.com/bncunha/erp-api/src/application/service/output"
+	"github.com/bncunha/erp-api/src/domain"
+)
+
+// pixQrCodeSize é a largura, em pixels, da imagem do QR Code da cobrança.
+const pixQrCodeSize = 300
+
+type PixService interface {
+	GetSettings(ctx context.Context) (output.GetPixSettingsOutput, error)
+	SaveSettings(ctx context.Context, request request.SavePixSettingsRequest) error
+	GetCharge(ctx context.Context, saleId int64, paymentDateId int64) (output.GetPixChargeOutput, error)
+	GetChargeQrCode(ctx context.Context, saleId int64, paymentDateId int64) ([]byte, error)
+	Reconcile(ctx context.Context, request request.ReconcilePixRequest) (output.PixReconciliationOutput, error)
+}
+
+type pixService struct {
+	salesService    SalesService
+	salesRepository domain.SalesRepository
+	pixRepository   domain.PixRepository
+	qrCodePort      ports.QrCodePort
+}
+
+func NewPixService(salesService SalesService, salesRepository domain.SalesRepository, pixRepository domain.PixRepository, qrCodePort ports.QrCodePort) PixService {
+	return &pixService{
+		salesService:    salesService,
+		salesRepository: salesRepository,
+		pixRepository:   pixRepository,
+		qrCodePort:      qrCodePort,
+	}
+}
+
+func (s *pixService) GetSettings(ctx context.Context) (output.GetPixSettingsOutput, error) {
+	return s.pixRepository.GetSettings(ctx)
+}
+
+func (s *pixService) SaveSettings(ctx context.Context, request request.SavePixSettingsRequest) error {
+	if err := request.Validate(); err != nil {
+		return err
+	}
+
+	settings := domain.NewPixSettings(request.Key, request.MerchantName, request.MerchantCity)
+	if err := settings.Validate(); err != nil {
+		return err
+	}
+	return s.pixRepository.SaveSettings(ctx, settings)
+}
+
+// GetCharge gera o BR Code da parcela PIX pendente, cobrando o valor que
+// ainda falta receber.
+func (s *pixService) GetCharge(ctx context.Context, saleId int64, paymentDateId int64) (output.GetPixChargeOutput, error) {
+	settings, err := s.pixRepository.GetSettings(ctx)
+	if err != nil {
+		return output.GetPixChargeOutput{}, err
+	}
+	if !settings.IsConfigured() {
+		return output.GetPixChargeOutput{}, domain.ErrPixSettingsNotConfigured
+	}
+
+	paymentDate, err := s.salesRepository.GetPaymentDatesBySaleIdAndPaymentDateId(ctx, saleId, paymentDateId)
+	if err != nil {
+		return output.GetPixChargeOutput{}, err
+	}
+	if paymentDate.PaymentType != domain.PaymentTypePix {
+		return output.GetPixChargeOutput{}, domain.ErrPaymentNotPix
+	}
+	if paymentDate.Status != domain.PaymentStatusPending && paymentDate.Status != domain.PaymentStatusDelayed {
+		return output.GetPixChargeOutput{}, domain.ErrPaymentNotPending
+	}
+
+	receipts, err := s.salesRepository.GetPaymentReceiptsByPaymentDateId(ctx, paymentDateId)
+	if err != nil {
+		return output.GetPixChargeOutput{}, err
+	}
+	amount := paymentDate.InstallmentValue
+	for _, receipt := range receipts {
+		amount -= receipt.Value
+	}
+	if amount <= 0 {
+		return output.GetPixChargeOutput{}, domain.ErrPaymentNotPending
+	}
+
+	txId := domain.PixTxId(paymentDateId)
+	return output.GetPixChargeOutput{
+		PaymentDateId: paymentDateId,
+		TxId:          txId,
+		Amount:        amount,
+		Payload:       domain.NewPixPayload(settings, amount, txId),
+	}, nil
+}
+
+func (s *pixService) GetChargeQrCode(ctx context.Context, saleId int64, paymentDateId int64) ([]byte, error) {
+	charge, err := s.GetCharge(ctx, saleId, paymentDateId)
+	if err != nil {
+		return nil, err
+	}
+	return s.qrCodePort.Encode(charge.Payload, pixQrCodeSize)
+}
+
+// Reconcile lê o extrato bancário e baixa como pagas, na data do crédito, as
+// parcelas PIX encontradas. Cada parcela é baixada separadamente; as que
+// falharem e os créditos sem parcela correspondente são devolvidos para
+// conferência manual.
+func (s *pixService) Reconcile(ctx context.Context, reconcilePixRequest request.ReconcilePixRequest) (output.PixReconciliationOutput, error) {
+	transactions, err := domain.ParseBankStatement(reconcilePixRequest.FileName, reconcilePixRequest.Content)
+	if err != nil {
+		return output.PixReconciliationOutput{}, err
+	}
+
+	pending, err := s.pixRepository.GetPendingPayments(ctx)
+	if err != nil {
+		return output.PixReconciliationOutput{}, err
+	}
+
+	matches, unmatched := domain.ReconcilePix(transactions, pending)
+	reconciliation := output.PixReconciliationOutput{
+		Matches:   make([]domain.PixReconciliationMatch, 0, len(matches)),
+		Unmatched: unmatched,
+		Failed:    make([]output.PixReconciliationFailure, 0),
+	}
+	for _, match := range matches {
+		paid := request.ChangePaymentStatusRequest{Status: string(domain.PaymentStatusPaid), Date: match.Transaction.Date}
+		if err := s.salesService.ChangePaymentStatus(ctx, match.Payment.SaleId, match.Payment.PaymentDateId, paid); err != nil {
+			reconciliation.Failed = append(reconciliation.Failed, output.PixReconciliationFailure{Match: match, Error: err.Error()})
+			continue
+		}
+		reconciliation.Matches = append(reconciliation.Matches, match)
+	}
+	return reconciliation, nil
+}
@@ -0,0 +1,145 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	request "github.com/bncunha/erp-api/src/api/requests"
+	"github.com/bncunha/erp-api/src/domain"
+)
+
+func newPixServiceForTest(salesRepo *stubSalesRepository, pixRepo *stubPixRepository, qrCode *stubQrCodePort) PixService {
+	salesService := NewSalesService(&stubSalesUseCase{}, salesRepo, &stubInventoryRepository{}, &stubLateFeeRuleRepository{})
+	return NewPixService(salesService, salesRepo, pixRepo, qrCode)
+}
+
+func TestPixServiceSaveSettings(t *testing.T) {
+	pixRepo := &stubPixRepository{}
+	service := newPixServiceForTest(&stubSalesRepository{}, pixRepo, &stubQrCodePort{})
+
+	err := service.SaveSettings(context.Background(), request.SavePixSettingsRequest{Key: "loja@example.com", MerchantName: "Loja", MerchantCity: "Natal"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if pixRepo.saved == nil || pixRepo.saved.Key != "loja@example.com" {
+		t.Fatalf("unexpected saved settings: %+v", pixRepo.saved)
+	}
+
+	pixRepo.saved = nil
+	err = service.SaveSettings(context.Background(), request.SavePixSettingsRequest{Key: "invalida", MerchantName: "Loja", MerchantCity: "Natal"})
+	if err != domain.ErrPixKeyInvalid {
+		t.Fatalf("expected invalid key error, got %v", err)
+	}
+	if pixRepo.saved != nil {
+		t.Fatalf("expected settings not to be saved")
+	}
+}
+
+func TestPixServiceGetCharge(t *testing.T) {
+	salesRepo := &stubSalesRepository{
+		paymentDateBySaleAndPaymentId: domain.SalesPaymentDates{Id: 42, InstallmentValue: 100, Status: domain.PaymentStatusPending, PaymentType: domain.PaymentTypePix},
+		receiptsOutput:                []domain.PaymentReceipt{{Value: 30}},
+	}
+	pixRepo := &stubPixRepository{settings: domain.NewPixSettings("loja@example.com", "Loja", "Natal")}
+	qrCode := &stubQrCodePort{}
+	service := newPixServiceForTest(salesRepo, pixRepo, qrCode)
+
+	charge, err := service.GetCharge(context.Background(), 1, 42)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if charge.Amount != 70 || charge.TxId != "PD0000000042" {
+		t.Fatalf("unexpected charge: %+v", charge)
+	}
+	if !strings.Contains(charge.Payload, "540570.00") || !strings.Contains(charge.Payload, "0512PD0000000042") {
+		t.Fatalf("unexpected payload: %s", charge.Payload)
+	}
+
+	image, err := service.GetChargeQrCode(context.Background(), 1, 42)
+	if err != nil || string(image) != "PNG" {
+		t.Fatalf("unexpected qr code: %s %v", image, err)
+	}
+	if qrCode.content != charge.Payload || qrCode.size != pixQrCodeSize {
+		t.Fatalf("unexpected qr code input: %+v", qrCode)
+	}
+}
+
+func TestPixServiceGetChargeErrors(t *testing.T) {
+	salesRepo := &stubSalesRepository{
+		paymentDateBySaleAndPaymentId: domain.SalesPaymentDates{InstallmentValue: 100, Status: domain.PaymentStatusPending, PaymentType: domain.PaymentTypeCash},
+	}
+	pixRepo := &stubPixRepository{}
+	service := newPixServiceForTest(salesRepo, pixRepo, &stubQrCodePort{})
+
+	if _, err := service.GetCharge(context.Background(), 1, 2); err != domain.ErrPixSettingsNotConfigured {
+		t.Fatalf("expected not configured error, got %v", err)
+	}
+
+	pixRepo.settings = domain.NewPixSettings("loja@example.com", "Loja", "Natal")
+	if _, err := service.GetCharge(context.Background(), 1, 2); err != domain.ErrPaymentNotPix {
+		t.Fatalf("expected not pix error, got %v", err)
+	}
+
+	salesRepo.paymentDateBySaleAndPaymentId.PaymentType = domain.PaymentTypePix
+	salesRepo.paymentDateBySaleAndPaymentId.Status = domain.PaymentStatusPaid
+	if _, err := service.GetCharge(context.Background(), 1, 2); err != domain.ErrPaymentNotPending {
+		t.Fatalf("expected not pending error, got %v", err)
+	}
+
+	salesRepo.paymentDateBySaleAndPaymentId.Status = domain.PaymentStatusDelayed
+	salesRepo.receiptsOutput = []domain.PaymentReceipt{{Value: 100}}
+	if _, err := service.GetCharge(context.Background(), 1, 2); err != domain.ErrPaymentNotPending {
+		t.Fatalf("expected fully received installment to be rejected, got %v", err)
+	}
+}
+
+func TestPixServiceReconcile(t *testing.T) {
+	salesRepo := &stubSalesRepository{
+		paymentDateBySaleAndPaymentId: domain.SalesPaymentDates{Id: 42, InstallmentValue: 80, Status: domain.PaymentStatusPending, PaymentType: domain.PaymentTypePix},
+	}
+	useCase := &stubSalesUseCase{}
+	salesService := NewSalesService(useCase, salesRepo, &stubInventoryRepository{}, &stubLateFeeRuleRepository{})
+	pixRepo := &stubPixRepository{pending: []domain.PixPendingPayment{{PaymentDateId: 42, SaleId: 7, Value: 80}}}
+	service := NewPixService(salesService, salesRepo, pixRepo, &stubQrCodePort{})
+
+	content := "data;descricao;valor\n15/10/2026;PIX RECEBIDO PD0000000042;80,00\n16/10/2026;PIX RECEBIDO;33,00\n"
+	reconciliation, err := service.Reconcile(ctxWithRoleAndUser(domain.UserRoleAdmin, 1), request.ReconcilePixRequest{FileName: "extrato.csv", Content: []byte(content)})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(reconciliation.Matches) != 1 || len(reconciliation.Unmatched) != 1 || len(reconciliation.Failed) != 0 {
+		t.Fatalf("unexpected reconciliation: %+v", reconciliation)
+	}
+	receipt := useCase.receivedReceipt
+	if receipt.SaleId != 7 || receipt.PaymentDateId != 42 || receipt.PaymentType != domain.PaymentTypePix || !receipt.SettleRemaining {
+		t.Fatalf("expected installment to be received, got %+v", receipt)
+	}
+	paidDate := time.Date(2026, 10, 15, 0, 0, 0, 0, time.UTC)
+	if !receipt.ReceiptDate.Equal(paidDate) {
+		t.Fatalf("expected paid date from the statement, got %v", receipt.ReceiptDate)
+	}
+}
+
+func TestPixServiceReconcileReportsFailedSettlements(t *testing.T) {
+	salesRepo := &stubSalesRepository{
+		paymentDateBySaleAndPaymentId: domain.SalesPaymentDates{Id: 42, InstallmentValue: 80, Status: domain.PaymentStatusPending, PaymentType: domain.PaymentTypePix},
+	}
+	salesService := NewSalesService(&stubSalesUseCase{err: errors.New("db")}, salesRepo, &stubInventoryRepository{}, &stubLateFeeRuleRepository{})
+	pixRepo := &stubPixRepository{pending: []domain.PixPendingPayment{{PaymentDateId: 42, SaleId: 7, Value: 80}}}
+	service := NewPixService(salesService, salesRepo, pixRepo, &stubQrCodePort{})
+
+	content := "data;descricao;valor\n15/10/2026;PIX RECEBIDO PD0000000042;80,00\n"
+	reconciliation, err := service.Reconcile(ctxWithRoleAndUser(domain.UserRoleAdmin, 1), request.ReconcilePixRequest{FileName: "extrato.csv", Content: []byte(content)})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(reconciliation.Matches) != 0 || len(reconciliation.Failed) != 1 {
+		t.Fatalf("expected settlement failure to be reported, got %+v", reconciliation)
+	}
+	if reconciliation.Failed[0].Match.Payment.PaymentDateId != 42 || reconciliation.Failed[0].Error != "db" {
+		t.Fatalf("unexpected failure: %+v", reconciliation.Failed[0])
+	}
+}
@@ -0,0 +1,207 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	request "github.com/bncunha/erp-api/src/api/requests"
+	"github.com/bncunha/erp-api/src/application/constants"
+	"github.com/bncunha/erp-api/src/application/errors"
+	"github.com/bncunha/erp-api/src/application/service/output"
+	"github.com/bncunha/erp-api/src/application/usecase/inventory_usecase"
+	"github.com/bncunha/erp-api/src/domain"
+)
+
+type PurchaseOrderService interface {
+	Create(ctx context.Context, request request.CreatePurchaseOrderRequest) (int64, error)
+	GetAll(ctx context.Context, request request.ListPurchaseOrdersRequest) ([]output.GetPurchaseOrdersOutput, error)
+	GetById(ctx context.Context, id int64) (output.GetPurchaseOrderOutput, error)
+	Receive(ctx context.Context, id int64, request request.ReceivePurchaseOrderRequest) (int64, error)
+	Cancel(ctx context.Context, id int64) error
+	GetSkuPurchases(ctx context.Context, skuId int64) ([]output.GetSkuPurchasesOutput, error)
+}
+
+type purchaseOrderService struct {
+	purchaseOrderRepository domain.PurchaseOrderRepository
+	supplierRepository      domain.SupplierRepository
+	skuRepository           domain.SkuRepository
+	inventoryRepository     domain.InventoryRepository
+	inventoryUseCase        inventory_usecase.InventoryUseCase
+	txManager               transactionManager
+}
+
+func NewPurchaseOrderService(purchaseOrderRepository domain.PurchaseOrderRepository, supplierRepository domain.SupplierRepository, skuRepository domain.SkuRepository, inventoryRepository domain.InventoryRepository, inventoryUseCase inventory_usecase.InventoryUseCase, txManager transactionManager) PurchaseOrderService {
+	return &purchaseOrderService{purchaseOrderRepository, supplierRepository, skuRepository, inventoryRepository, inventoryUseCase, txManager}
+}
+
+func (s *purchaseOrderService) Create(ctx context.Context, request request.CreatePurchaseOrderRequest) (id int64, err error) {
+	if err = request.Validate(); err != nil {
+		return 0, err
+	}
+	createdByUserId := int64(ctx.Value(constants.USERID_KEY).(float64))
+
+	supplier, err := s.supplierRepository.GetById(ctx, request.SupplierId)
+	if err != nil {
+		return 0, err
+	}
+
+	skuIds := make([]int64, 0, len(request.Items))
+	for _, item := range request.Items {
+		skuIds = append(skuIds, item.SkuId)
+	}
+	skus, err := s.skuRepository.GetByManyIds(ctx, skuIds)
+	if err != nil {
+		return 0, err
+	}
+	skusById := make(map[int64]domain.Sku, len(skus))
+	for _, sku := range skus {
+		skusById[sku.Id] = sku
+	}
+
+	items := make([]domain.PurchaseOrderItem, 0, len(request.Items))
+	for _, item := range request.Items {
+		sku, ok := skusById[item.SkuId]
+		if !ok {
+			return 0, errors.New("SKU não encontrada")
+		}
+		items = append(items, domain.PurchaseOrderItem{Sku: sku, Quantity: item.Quantity, UnitCost: item.UnitCost})
+	}
+
+	order := domain.NewPurchaseOrder(supplier, items, time.Now())
+	order.ExpectedDate = request.ExpectedDate
+	order.Notes = request.Notes
+	if err = order.Validate(); err != nil {
+		return 0, err
+	}
+
+	tx, err := s.txManager.BeginTx(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	id, err = s.purchaseOrderRepository.Create(ctx, tx, order, createdByUserId)
+	if err != nil {
+		return 0, err
+	}
+	return id, tx.Commit()
+}
+
+func (s *purchaseOrderService) GetAll(ctx context.Context, request request.ListPurchaseOrdersRequest) ([]output.GetPurchaseOrdersOutput, error) {
+	return s.purchaseOrderRepository.GetAll(ctx, domain.GetPurchaseOrdersInput{
+		SupplierId: request.SupplierId,
+		Status:     request.Status,
+	})
+}
+
+func (s *purchaseOrderService) GetById(ctx context.Context, id int64) (output.GetPurchaseOrderOutput, error) {
+	return s.purchaseOrderRepository.GetById(ctx, id)
+}
+
+// Receive dá entrada no estoque principal do que chegou do pedido, com o custo
+// de cada item recalculando o custo médio dos SKUs, e registra o recebimento,
+// tudo na mesma transação.
+func (s *purchaseOrderService) Receive(ctx context.Context, id int64, request request.ReceivePurchaseOrderRequest) (receiptId int64, err error) {
+	if err = request.Validate(); err != nil {
+		return 0, err
+	}
+	receivedByUserId := int64(ctx.Value(constants.USERID_KEY).(float64))
+
+	order, err := s.purchaseOrderRepository.GetById(ctx, id)
+	if err != nil {
+		return 0, err
+	}
+	items := make([]domain.ReceivePurchaseOrderItemInput, 0, len(request.Items))
+	for _, item := range request.Items {
+		items = append(items, domain.ReceivePurchaseOrderItemInput{
+			SkuId:    item.SkuId,
+			Quantity: item.Quantity,
+			UnitCost: item.UnitCost,
+		})
+	}
+	receipt, err := order.Receive(items, time.Now())
+	if err != nil {
+		return 0, err
+	}
+	receipt.Notes = request.Notes
+
+	primaryInventory, err := s.inventoryRepository.GetPrimaryInventory(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	tx, err := s.txManager.BeginTx(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	skus := make([]inventory_usecase.DoTransactionSkusInput, 0, len(receipt.Items))
+	for _, item := range receipt.Items {
+		unitCost := item.UnitCost
+		skus = append(skus, inventory_usecase.DoTransactionSkusInput{
+			SkuId:    item.Sku.Id,
+			Quantity: item.Quantity,
+			UnitCost: &unitCost,
+		})
+	}
+	err = s.inventoryUseCase.DoTransaction(ctx, tx, inventory_usecase.DoTransactionInput{
+		Type:                   domain.InventoryTransactionTypeIn,
+		Skus:                   skus,
+		InventoryDestinationId: primaryInventory.Id,
+		Justification:          "Recebimento do pedido de compra " + order.Code,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	receiptId, err = s.purchaseOrderRepository.CreateReceipt(ctx, tx, order.Id, receipt, receivedByUserId)
+	if err != nil {
+		return 0, err
+	}
+	if err = s.purchaseOrderRepository.UpdateStatus(ctx, tx, order); err != nil {
+		return 0, err
+	}
+	return receiptId, tx.Commit()
+}
+
+// Cancel encerra o pedido. O que já foi recebido permanece no estoque.
+func (s *purchaseOrderService) Cancel(ctx context.Context, id int64) (err error) {
+	order, err := s.purchaseOrderRepository.GetById(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err = order.Cancel(); err != nil {
+		return err
+	}
+
+	tx, err := s.txManager.BeginTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	if err = s.purchaseOrderRepository.UpdateStatus(ctx, tx, order); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (s *purchaseOrderService) GetSkuPurchases(ctx context.Context, skuId int64) ([]output.GetSkuPurchasesOutput, error) {
+	if _, err := s.skuRepository.GetById(ctx, skuId); err != nil {
+		return nil, err
+	}
+	return s.purchaseOrderRepository.GetPurchasesBySkuId(ctx, skuId)
+}
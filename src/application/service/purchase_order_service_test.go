@@ -0,0 +1,135 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	request "github.com/bncunha/erp-api/src/api/requests"
+	"github.com/bncunha/erp-api/src/domain"
+)
+
+func newPurchaseOrderServiceForTest(repo *stubPurchaseOrderRepository, skuRepo *stubSkuRepository, inventoryUseCase *stubInventoryUseCase, txManager transactionManager) PurchaseOrderService {
+	supplierRepo := &stubSupplierRepository{getById: domain.Supplier{Id: 1, Name: "Fornecedor"}}
+	inventoryRepo := &stubInventoryRepository{getPrimary: domain.Inventory{Id: 2, Type: domain.InventoryTypePrimary}}
+	return NewPurchaseOrderService(repo, supplierRepo, skuRepo, inventoryRepo, inventoryUseCase, txManager)
+}
+
+func newOpenPurchaseOrder() domain.PurchaseOrder {
+	return domain.PurchaseOrder{
+		Id:     3,
+		Code:   "C-1",
+		Status: domain.PurchaseOrderStatusOpen,
+		Items: []domain.PurchaseOrderItem{
+			{Id: 10, Sku: domain.Sku{Id: 1}, Quantity: 10, UnitCost: 5},
+			{Id: 11, Sku: domain.Sku{Id: 2}, Quantity: 4, UnitCost: 12.5},
+		},
+	}
+}
+
+func TestPurchaseOrderServiceCreate(t *testing.T) {
+	tx, fakeTx, cleanup := newTestSQLTx()
+	defer cleanup()
+	repo := &stubPurchaseOrderRepository{}
+	skuRepo := &stubSkuRepository{getByManyIds: []domain.Sku{{Id: 1}}}
+	service := newPurchaseOrderServiceForTest(repo, skuRepo, &stubInventoryUseCase{}, &stubTxManager{tx: tx})
+
+	id, err := service.Create(newCommissionContext(domain.UserRoleAdmin, 1), request.CreatePurchaseOrderRequest{
+		SupplierId: 1,
+		Items:      []request.CreatePurchaseOrderItemRequest{{SkuId: 1, Quantity: 3, UnitCost: 7}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if id != 8 || repo.createdBy != 1 || !fakeTx.committed {
+		t.Fatalf("expected order to be persisted")
+	}
+	if repo.created.Supplier.Id != 1 || repo.created.Status != domain.PurchaseOrderStatusOpen || len(repo.created.Items) != 1 {
+		t.Fatalf("unexpected order: %+v", repo.created)
+	}
+
+	_, err = service.Create(newCommissionContext(domain.UserRoleAdmin, 1), request.CreatePurchaseOrderRequest{
+		SupplierId: 1,
+		Items:      []request.CreatePurchaseOrderItemRequest{{SkuId: 9, Quantity: 1}},
+	})
+	if err == nil || err.Error() != "SKU não encontrada" {
+		t.Fatalf("expected sku not found, got %v", err)
+	}
+}
+
+func TestPurchaseOrderServiceReceiveIntoPrimaryInventory(t *testing.T) {
+	tx, fakeTx, cleanup := newTestSQLTx()
+	defer cleanup()
+	repo := &stubPurchaseOrderRepository{getById: newOpenPurchaseOrder()}
+	skuRepo := &stubSkuRepository{}
+	inventoryUseCase := &stubInventoryUseCase{}
+	service := newPurchaseOrderServiceForTest(repo, skuRepo, inventoryUseCase, &stubTxManager{tx: tx})
+	cost := 4.5
+
+	receiptId, err := service.Receive(newCommissionContext(domain.UserRoleAdmin, 1), 3, request.ReceivePurchaseOrderRequest{
+		Items: []request.ReceivePurchaseOrderItemRequest{{SkuId: 1, Quantity: 6, UnitCost: &cost}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	input := inventoryUseCase.receivedInput
+	if input.Type != domain.InventoryTransactionTypeIn || input.InventoryDestinationId != 2 || len(input.Skus) != 1 || input.Skus[0].Quantity != 6 {
+		t.Fatalf("unexpected inventory transaction: %+v", input)
+	}
+	if input.Justification != "Recebimento do pedido de compra C-1" {
+		t.Fatalf("unexpected justification: %s", input.Justification)
+	}
+	if input.Skus[0].UnitCost == nil || *input.Skus[0].UnitCost != 4.5 {
+		t.Fatalf("expected entry cost to be forwarded, got %+v", input.Skus[0])
+	}
+	if receiptId != 11 || repo.receivedBy != 1 || len(repo.receipt.Items) != 1 || repo.updated.Status != domain.PurchaseOrderStatusPartiallyReceived || !fakeTx.committed {
+		t.Fatalf("expected partial receipt to be persisted, got %+v", repo.updated)
+	}
+}
+
+func TestPurchaseOrderServiceReceiveRollback(t *testing.T) {
+	tx, fakeTx, cleanup := newTestSQLTx()
+	defer cleanup()
+	repo := &stubPurchaseOrderRepository{getById: newOpenPurchaseOrder()}
+	skuRepo := &stubSkuRepository{}
+	service := newPurchaseOrderServiceForTest(repo, skuRepo, &stubInventoryUseCase{err: errors.New("inventory error")}, &stubTxManager{tx: tx})
+
+	_, err := service.Receive(newCommissionContext(domain.UserRoleAdmin, 1), 3, request.ReceivePurchaseOrderRequest{
+		Items: []request.ReceivePurchaseOrderItemRequest{{SkuId: 1, Quantity: 1}},
+	})
+	if err == nil || err.Error() != "inventory error" {
+		t.Fatalf("expected inventory error, got %v", err)
+	}
+	if !fakeTx.rolledBack || repo.updated.Id != 0 {
+		t.Fatalf("expected rollback without receipt")
+	}
+}
+
+func TestPurchaseOrderServiceReceiveExceedingPending(t *testing.T) {
+	repo := &stubPurchaseOrderRepository{getById: newOpenPurchaseOrder()}
+	inventoryUseCase := &stubInventoryUseCase{}
+	service := newPurchaseOrderServiceForTest(repo, &stubSkuRepository{}, inventoryUseCase, nil)
+
+	_, err := service.Receive(newCommissionContext(domain.UserRoleAdmin, 1), 3, request.ReceivePurchaseOrderRequest{
+		Items: []request.ReceivePurchaseOrderItemRequest{{SkuId: 2, Quantity: 5}},
+	})
+	if err != domain.ErrPurchaseReceiptExceedsPending {
+		t.Fatalf("expected exceeds pending, got %v", err)
+	}
+	if inventoryUseCase.receivedInput.InventoryDestinationId != 0 {
+		t.Fatalf("expected no inventory transaction")
+	}
+}
+
+func TestPurchaseOrderServiceCancel(t *testing.T) {
+	tx, fakeTx, cleanup := newTestSQLTx()
+	defer cleanup()
+	repo := &stubPurchaseOrderRepository{getById: newOpenPurchaseOrder()}
+	service := newPurchaseOrderServiceForTest(repo, &stubSkuRepository{}, &stubInventoryUseCase{}, &stubTxManager{tx: tx})
+
+	if err := service.Cancel(newCommissionContext(domain.UserRoleAdmin, 1), 3); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if repo.updated.Status != domain.PurchaseOrderStatusCanceled || !fakeTx.committed {
+		t.Fatalf("expected order canceled")
+	}
+}
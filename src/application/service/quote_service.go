@@ -0,0 +1,168 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	request "github.com/bncunha/erp-api/src/api/requests"
+	"github.com/bncunha/erp-api/src/application/constants"
+	"github.com/bncunha/erp-api/src/application/service/output"
+	"github.com/bncunha/erp-api/src/application/usecase/sales_usecase"
+	"github.com/bncunha/erp-api/src/domain"
+)
+
+type QuoteService interface {
+	Create(ctx context.Context, request request.CreateQuoteRequest) error
+	GetAll(ctx context.Context, request request.ListQuotesRequest) ([]output.GetQuotesOutput, error)
+	GetById(ctx context.Context, id int64) (output.GetQuoteByIdOutput, error)
+	Cancel(ctx context.Context, id int64) error
+	Convert(ctx context.Context, id int64) error
+}
+
+type quoteService struct {
+	salesUsecase    sales_usecase.SalesUseCase
+	quoteRepository domain.QuoteRepository
+}
+
+func NewQuoteService(salesUsecase sales_usecase.SalesUseCase, quoteRepository domain.QuoteRepository) QuoteService {
+	return &quoteService{
+		salesUsecase:    salesUsecase,
+		quoteRepository: quoteRepository,
+	}
+}
+
+func (s *quoteService) Create(ctx context.Context, request request.CreateQuoteRequest) error {
+	if err := request.Validate(); err != nil {
+		return err
+	}
+
+	userId := int64(ctx.Value(constants.USERID_KEY).(float64))
+
+	plan := make([]domain.QuotePayment, 0, len(request.Payments))
+	for _, payment := range request.Payments {
+		plan = append(plan, domain.QuotePayment{
+			PaymentType:          payment.PaymentType,
+			Value:                payment.Value,
+			InstallmentsQuantity: payment.InstallmentsQuantity,
+			FirstInstallmentDate: payment.FirstInstallmentDate,
+		})
+	}
+
+	return s.salesUsecase.DoQuote(ctx, sales_usecase.DoQuoteInput{
+		UserId:       userId,
+		CustomerId:   request.CustomerId,
+		Items:        buildItemsInput(request.Items),
+		Payments:     buildPaymentsInput(request.Payments),
+		Plan:         plan,
+		Discount:     request.Discount.ToDomain(),
+		ExpiresAt:    request.ExpiresAt,
+		ReserveStock: request.ReserveStock,
+	})
+}
+
+func (s *quoteService) GetAll(ctx context.Context, request request.ListQuotesRequest) ([]output.GetQuotesOutput, error) {
+	if err := request.Validate(); err != nil {
+		return nil, err
+	}
+
+	userRole, _ := ctx.Value(constants.ROLE_KEY).(string)
+	if userRole == "" {
+		return nil, ErrPermissionDenied
+	}
+
+	input := domain.GetQuotesInput{
+		UserId:     request.UserId,
+		CustomerId: request.CustomerId,
+		Status:     request.Status,
+	}
+	if userRole == string(domain.UserRoleReseller) {
+		input.UserId = []int64{int64(ctx.Value(constants.USERID_KEY).(float64))}
+	}
+	return s.quoteRepository.GetAll(ctx, input)
+}
+
+func (s *quoteService) GetById(ctx context.Context, id int64) (output.GetQuoteByIdOutput, error) {
+	quote, err := s.quoteRepository.GetById(ctx, id)
+	if err != nil {
+		return quote, err
+	}
+	if err = s.validateOwnership(ctx, quote); err != nil {
+		return output.GetQuoteByIdOutput{}, domain.ErrQuoteNotFound
+	}
+	quote.Status = quote.GetStatus(time.Now())
+	return quote, nil
+}
+
+func (s *quoteService) Cancel(ctx context.Context, id int64) error {
+	quote, err := s.quoteRepository.GetById(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err = s.validateOwnership(ctx, quote); err != nil {
+		return err
+	}
+	return s.quoteRepository.Cancel(ctx, id)
+}
+
+// Convert registra o orçamento como venda pelo mesmo fluxo de DoSale, mantendo
+// os preços e descontos orçados. Estoque e pagamentos são validados novamente.
+// Quando um administrador converte o orçamento, ele aprova o desconto concedido.
+func (s *quoteService) Convert(ctx context.Context, id int64) error {
+	quote, err := s.quoteRepository.GetById(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err = s.validateOwnership(ctx, quote); err != nil {
+		return err
+	}
+	if err = quote.ValidateConversion(time.Now()); err != nil {
+		return err
+	}
+
+	items := make([]sales_usecase.DoSaleItemsInput, 0, len(quote.Items))
+	for _, item := range quote.Items {
+		items = append(items, sales_usecase.DoSaleItemsInput{
+			SkuId:     item.Sku.Id,
+			Quantity:  item.Quantity,
+			Discount:  item.Discount,
+			UnitPrice: item.Sku.Price,
+		})
+	}
+	payments := make([]request.CreateSaleRequestPayments, 0, len(quote.Payments))
+	for _, payment := range quote.Payments {
+		payments = append(payments, request.CreateSaleRequestPayments{
+			PaymentType:          payment.PaymentType,
+			Value:                payment.Value,
+			InstallmentsQuantity: payment.InstallmentsQuantity,
+			FirstInstallmentDate: payment.FirstInstallmentDate,
+		})
+	}
+
+	var discountApproverId int64
+	if ctx.Value(constants.ROLE_KEY).(string) == string(domain.UserRoleAdmin) {
+		discountApproverId = int64(ctx.Value(constants.USERID_KEY).(float64))
+	}
+
+	return s.salesUsecase.DoSale(ctx, sales_usecase.DoSaleInput{
+		Date:               time.Now(),
+		UserId:             quote.User.Id,
+		CustomerId:         quote.Customer.Id,
+		Items:              items,
+		Payments:           buildPaymentsInput(payments),
+		Discount:           quote.Discount,
+		QuoteId:            quote.Id,
+		DiscountApproverId: discountApproverId,
+	})
+}
+
+func (s *quoteService) validateOwnership(ctx context.Context, quote domain.Quote) error {
+	userRole := ctx.Value(constants.ROLE_KEY).(string)
+	if userRole == string(domain.UserRoleAdmin) {
+		return nil
+	}
+	userId := int64(ctx.Value(constants.USERID_KEY).(float64))
+	if userRole == string(domain.UserRoleReseller) && quote.User.Id == userId {
+		return nil
+	}
+	return domain.ErrQuoteNotAllowed
+}
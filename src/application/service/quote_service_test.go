@@ -0,0 +1,165 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	request "github.com/bncunha/erp-api/src/api/requests"
+	"github.com/bncunha/erp-api/src/application/constants"
+	"github.com/bncunha/erp-api/src/domain"
+)
+
+func newQuoteContext(role domain.Role, userId int64) context.Context {
+	ctx := context.WithValue(context.Background(), constants.ROLE_KEY, string(role))
+	return context.WithValue(ctx, constants.USERID_KEY, float64(userId))
+}
+
+func newOpenQuote(userId int64) domain.Quote {
+	installments := 2
+	firstDate := time.Now().AddDate(0, 1, 0)
+	expiresAt := time.Now().Add(24 * time.Hour)
+	return domain.Quote{
+		Id:        10,
+		Status:    domain.QuoteStatusOpen,
+		ExpiresAt: &expiresAt,
+		User:      domain.User{Id: userId},
+		Customer:  domain.Customer{Id: 4},
+		Items: []domain.SalesItem{{
+			Sku:      domain.Sku{Id: 3, Price: 9},
+			Quantity: 2,
+			Discount: domain.NewSalesDiscount(domain.DiscountTypeFixed, 1, "Avaria"),
+		}},
+		Payments: []domain.QuotePayment{{
+			PaymentType:          domain.PaymentTypeCreditStore,
+			Value:                17,
+			InstallmentsQuantity: &installments,
+			FirstInstallmentDate: &firstDate,
+		}},
+		Discount: domain.NewSalesDiscount(domain.DiscountTypeFixed, 1, "Cliente fiel"),
+	}
+}
+
+func TestQuoteServiceCreate(t *testing.T) {
+	useCase := &stubSalesUseCase{}
+	service := NewQuoteService(useCase, &stubQuoteRepository{})
+	installments := 2
+	firstDate := time.Now().AddDate(0, 1, 0)
+	expiresAt := time.Now().Add(48 * time.Hour)
+
+	err := service.Create(newQuoteContext(domain.UserRoleReseller, 5), request.CreateQuoteRequest{
+		CustomerId: 4,
+		Items:      []request.CreateSaleRequestItems{{SkuId: 3, Quantity: 2}},
+		Payments: []request.CreateSaleRequestPayments{{
+			PaymentType:          domain.PaymentTypeCreditStore,
+			Value:                20,
+			InstallmentsQuantity: &installments,
+			FirstInstallmentDate: &firstDate,
+		}},
+		ExpiresAt:    &expiresAt,
+		ReserveStock: true,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	input := useCase.receivedQuote
+	if input.UserId != 5 || input.CustomerId != 4 || !input.ReserveStock || input.ExpiresAt != &expiresAt {
+		t.Fatalf("unexpected input: %+v", input)
+	}
+	if len(input.Plan) != 1 || *input.Plan[0].InstallmentsQuantity != 2 || len(input.Payments[0].Dates) != 2 {
+		t.Fatalf("expected plan and installments to be built: %+v", input)
+	}
+
+	if err := service.Create(newQuoteContext(domain.UserRoleReseller, 5), request.CreateQuoteRequest{}); err == nil {
+		t.Fatalf("expected validation error")
+	}
+}
+
+func TestQuoteServiceGetAll(t *testing.T) {
+	repo := &stubQuoteRepository{}
+	service := NewQuoteService(&stubSalesUseCase{}, repo)
+	status := domain.QuoteStatusExpired
+
+	if _, err := service.GetAll(newQuoteContext(domain.UserRoleReseller, 3), request.ListQuotesRequest{UserId: []int64{5}, Status: &status}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(repo.input.UserId) != 1 || repo.input.UserId[0] != 3 || *repo.input.Status != status {
+		t.Fatalf("expected reseller filter, got %+v", repo.input)
+	}
+
+	if _, err := service.GetAll(context.Background(), request.ListQuotesRequest{}); err != ErrPermissionDenied {
+		t.Fatalf("expected permission error, got %v", err)
+	}
+	invalid := domain.QuoteStatus("OTHER")
+	if _, err := service.GetAll(newQuoteContext(domain.UserRoleAdmin, 1), request.ListQuotesRequest{Status: &invalid}); err == nil {
+		t.Fatalf("expected validation error")
+	}
+}
+
+func TestQuoteServiceGetById(t *testing.T) {
+	repo := &stubQuoteRepository{quote: newOpenQuote(5)}
+	expired := time.Now().Add(-time.Hour)
+	repo.quote.ExpiresAt = &expired
+	service := NewQuoteService(&stubSalesUseCase{}, repo)
+
+	quote, err := service.GetById(newQuoteContext(domain.UserRoleAdmin, 1), 10)
+	if err != nil || quote.Status != domain.QuoteStatusExpired {
+		t.Fatalf("expected expired quote, got %v %v", quote.Status, err)
+	}
+	if _, err := service.GetById(newQuoteContext(domain.UserRoleReseller, 6), 10); err != domain.ErrQuoteNotFound {
+		t.Fatalf("expected not found for other reseller, got %v", err)
+	}
+}
+
+func TestQuoteServiceCancel(t *testing.T) {
+	repo := &stubQuoteRepository{quote: newOpenQuote(5)}
+	service := NewQuoteService(&stubSalesUseCase{}, repo)
+
+	if err := service.Cancel(newQuoteContext(domain.UserRoleReseller, 6), 10); err != domain.ErrQuoteNotAllowed {
+		t.Fatalf("expected not allowed error, got %v", err)
+	}
+	if err := service.Cancel(newQuoteContext(domain.UserRoleReseller, 5), 10); err != nil || repo.cancelledId != 10 {
+		t.Fatalf("expected quote to be cancelled, got %v", err)
+	}
+}
+
+func TestQuoteServiceConvert(t *testing.T) {
+	useCase := &stubSalesUseCase{}
+	repo := &stubQuoteRepository{quote: newOpenQuote(5)}
+	service := NewQuoteService(useCase, repo)
+
+	if err := service.Convert(newQuoteContext(domain.UserRoleAdmin, 1), 10); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	input := useCase.receivedInput
+	if input.QuoteId != 10 || input.UserId != 5 || input.CustomerId != 4 || input.Discount.Reason != "Cliente fiel" {
+		t.Fatalf("unexpected sale input: %+v", input)
+	}
+	if len(input.Items) != 1 || input.Items[0].UnitPrice != 9 || input.Items[0].Discount.Reason != "Avaria" {
+		t.Fatalf("expected quoted items to be kept: %+v", input.Items)
+	}
+	if len(input.Payments) != 1 || len(input.Payments[0].Dates) != 2 || input.Payments[0].Dates[0].InstallmentValue != 8.5 {
+		t.Fatalf("expected installments to be generated from the plan: %+v", input.Payments)
+	}
+	if input.DiscountApproverId != 1 {
+		t.Fatalf("expected admin conversion to approve the discount, got %d", input.DiscountApproverId)
+	}
+	if err := service.Convert(newQuoteContext(domain.UserRoleReseller, 5), 10); err != nil || useCase.receivedInput.DiscountApproverId != 0 {
+		t.Fatalf("expected reseller conversion without approval, got %v and %+v", err, useCase.receivedInput)
+	}
+
+	expired := time.Now().Add(-time.Hour)
+	repo.quote.ExpiresAt = &expired
+	if err := service.Convert(newQuoteContext(domain.UserRoleAdmin, 1), 10); err != domain.ErrQuoteExpired {
+		t.Fatalf("expected expired error, got %v", err)
+	}
+	repo.quote = newOpenQuote(5)
+	repo.quote.Status = domain.QuoteStatusCancelled
+	if err := service.Convert(newQuoteContext(domain.UserRoleAdmin, 1), 10); err != domain.ErrQuoteNotOpen {
+		t.Fatalf("expected not open error, got %v", err)
+	}
+	repo.getByIdErr = domain.ErrQuoteNotFound
+	if err := service.Convert(newQuoteContext(domain.UserRoleAdmin, 1), 10); err != domain.ErrQuoteNotFound {
+		t.Fatalf("expected not found error, got %v", err)
+	}
+}
@@ -0,0 +1,73 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	request "github.com/bncunha/erp-api/src/api/requests"
+	"github.com/bncunha/erp-api/src/application/constants"
+	"github.com/bncunha/erp-api/src/application/service/output"
+	"github.com/bncunha/erp-api/src/domain"
+)
+
+type ReceivableService interface {
+	GetAll(ctx context.Context, request request.ListReceivablesRequest) (output.GetReceivablesOutput, error)
+	UpdateDelayedPayments(ctx context.Context) error
+}
+
+type receivableService struct {
+	receivableRepository  domain.ReceivableRepository
+	lateFeeRuleRepository domain.LateFeeRuleRepository
+}
+
+func NewReceivableService(receivableRepository domain.ReceivableRepository, lateFeeRuleRepository domain.LateFeeRuleRepository) ReceivableService {
+	return &receivableService{receivableRepository, lateFeeRuleRepository}
+}
+
+func (s *receivableService) GetAll(ctx context.Context, request request.ListReceivablesRequest) (output output.GetReceivablesOutput, err error) {
+	if err = request.Validate(); err != nil {
+		return output, err
+	}
+
+	userRole, _ := ctx.Value(constants.ROLE_KEY).(string)
+	if userRole == "" {
+		return output, ErrPermissionDenied
+	}
+
+	var userId []int64
+	if userRole == string(domain.UserRoleAdmin) {
+		userId = request.UserId
+	} else {
+		if v, ok := ctx.Value(constants.USERID_KEY).(float64); ok {
+			userId = []int64{int64(v)}
+		}
+	}
+
+	receivables, err := s.receivableRepository.GetAll(ctx, domain.GetReceivablesInput{
+		InitialDueDate: request.MinDueDate,
+		FinalDueDate:   request.MaxDueDate,
+		UserId:         userId,
+		CustomerId:     request.CustomerId,
+		PaymentStatus:  request.PaymentStatus,
+	})
+	if err != nil {
+		return output, err
+	}
+
+	rule, err := s.lateFeeRuleRepository.Get(ctx)
+	if err != nil {
+		return output, err
+	}
+
+	output.Today = time.Now()
+	for i := range receivables {
+		receivables[i].LateFee = rule.Calculate(receivables[i].PaymentType, receivables[i].GetOpenValue(), receivables[i].DueDate, output.Today)
+	}
+	output.Receivables = receivables
+	return output, nil
+}
+
+func (s *receivableService) UpdateDelayedPayments(ctx context.Context) error {
+	_, err := s.receivableRepository.UpdateDelayedPaymentDates(ctx)
+	return err
+}
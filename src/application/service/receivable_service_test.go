@@ -0,0 +1,106 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	request "github.com/bncunha/erp-api/src/api/requests"
+	"github.com/bncunha/erp-api/src/application/constants"
+	"github.com/bncunha/erp-api/src/domain"
+)
+
+func newReceivableContext(role domain.Role, userId int64) context.Context {
+	ctx := context.WithValue(context.Background(), constants.ROLE_KEY, string(role))
+	return context.WithValue(ctx, constants.USERID_KEY, float64(userId))
+}
+
+func TestReceivableServiceGetAllAdmin(t *testing.T) {
+	repo := &stubReceivableRepository{receivables: []domain.Receivable{{PaymentDateId: 1}}}
+	service := NewReceivableService(repo, &stubLateFeeRuleRepository{})
+	status := domain.PaymentStatusDelayed
+
+	out, err := service.GetAll(newReceivableContext(domain.UserRoleAdmin, 1), request.ListReceivablesRequest{
+		UserId:        []int64{5},
+		CustomerId:    []int64{7},
+		PaymentStatus: &status,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(out.Receivables) != 1 || out.Today.IsZero() {
+		t.Fatalf("unexpected output: %+v", out)
+	}
+	if len(repo.input.UserId) != 1 || repo.input.UserId[0] != 5 || repo.input.CustomerId[0] != 7 || *repo.input.PaymentStatus != status {
+		t.Fatalf("unexpected filters: %+v", repo.input)
+	}
+}
+
+func TestReceivableServiceGetAllResellerSeesOwnSales(t *testing.T) {
+	repo := &stubReceivableRepository{}
+	service := NewReceivableService(repo, &stubLateFeeRuleRepository{})
+
+	if _, err := service.GetAll(newReceivableContext(domain.UserRoleReseller, 3), request.ListReceivablesRequest{UserId: []int64{5}}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(repo.input.UserId) != 1 || repo.input.UserId[0] != 3 {
+		t.Fatalf("expected reseller filter, got %+v", repo.input.UserId)
+	}
+}
+
+func TestReceivableServiceGetAllErrors(t *testing.T) {
+	service := NewReceivableService(&stubReceivableRepository{}, &stubLateFeeRuleRepository{})
+	if _, err := service.GetAll(context.Background(), request.ListReceivablesRequest{}); err != ErrPermissionDenied {
+		t.Fatalf("expected permission error, got %v", err)
+	}
+
+	status := domain.PaymentStatusPaid
+	if _, err := service.GetAll(newReceivableContext(domain.UserRoleAdmin, 1), request.ListReceivablesRequest{PaymentStatus: &status}); err == nil {
+		t.Fatalf("expected validation error")
+	}
+
+	expected := errors.New("fail")
+	service = NewReceivableService(&stubReceivableRepository{getAllErr: expected}, &stubLateFeeRuleRepository{})
+	if _, err := service.GetAll(newReceivableContext(domain.UserRoleAdmin, 1), request.ListReceivablesRequest{}); err != expected {
+		t.Fatalf("expected %v, got %v", expected, err)
+	}
+}
+
+func TestReceivableServiceUpdateDelayedPayments(t *testing.T) {
+	service := NewReceivableService(&stubReceivableRepository{updated: 2}, &stubLateFeeRuleRepository{})
+	if err := service.UpdateDelayedPayments(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := errors.New("fail")
+	service = NewReceivableService(&stubReceivableRepository{updateErr: expected}, &stubLateFeeRuleRepository{})
+	if err := service.UpdateDelayedPayments(context.Background()); err != expected {
+		t.Fatalf("expected %v, got %v", expected, err)
+	}
+}
+
+func TestReceivableServiceGetAllAppliesLateFees(t *testing.T) {
+	repo := &stubReceivableRepository{receivables: []domain.Receivable{
+		{PaymentDateId: 1, PaymentType: domain.PaymentTypeCreditStore, InstallmentValue: 100, ReceivedValue: 50, DueDate: time.Now().AddDate(0, 0, -3)},
+		{PaymentDateId: 2, PaymentType: domain.PaymentTypeCreditStore, InstallmentValue: 100, DueDate: time.Now().AddDate(0, 0, 3)},
+	}}
+	service := NewReceivableService(repo, &stubLateFeeRuleRepository{rule: domain.NewLateFeeRule(2, 1)})
+
+	out, err := service.GetAll(newReceivableContext(domain.UserRoleAdmin, 1), request.ListReceivablesRequest{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	overdue := out.Receivables[0]
+	if overdue.LateFee.FineValue != 1 || overdue.LateFee.InterestValue != 1.5 || overdue.GetAmountDue() != 52.5 {
+		t.Fatalf("unexpected late fee on open value: %+v", overdue.LateFee)
+	}
+	if out.Receivables[1].LateFee != (domain.LateFee{}) {
+		t.Fatalf("expected no fee before due date, got %+v", out.Receivables[1].LateFee)
+	}
+
+	service = NewReceivableService(repo, &stubLateFeeRuleRepository{getErr: errors.New("db")})
+	if _, err := service.GetAll(newReceivableContext(domain.UserRoleAdmin, 1), request.ListReceivablesRequest{}); err == nil {
+		t.Fatalf("expected rule error")
+	}
+}
@@ -16,27 +16,38 @@ import (
 
 var (
 	ErrPermissionDenied = errors.New("Acesso negado.")
+	ErrSaleNotFound     = errors.New("Venda não encontrada")
 )
 
 type SalesService interface {
 	CreateSales(ctx context.Context, request request.CreateSaleRequest) error
 	CreateReturn(ctx context.Context, saleId int64, request request.CreateSalesReturnRequest) error
+	CancelSale(ctx context.Context, saleId int64, request request.CancelSaleRequest) error
+	EditSale(ctx context.Context, saleId int64, request request.EditSaleRequest) error
+	ExchangeSale(ctx context.Context, saleId int64, request request.ExchangeSaleRequest) error
 	GetSales(ctx context.Context, request request.ListSalesRequest) (output output.GetSalesOutput, err error)
 	GetById(ctx context.Context, id int64) (saleOutput output.GetSaleByIdOutput, paymentGroupOutput []output.GetSalesPaymentGroupOutput, itemsOutput []output.GetItemsOutput, returnsOutput []output.GetSalesReturnOutput, err error)
+	GetVersions(ctx context.Context, id int64) ([]output.GetSalesVersionOutput, error)
 	ChangePaymentStatus(ctx context.Context, id int64, paymentId int64, request request.ChangePaymentStatusRequest) error
+	CreatePaymentReceipt(ctx context.Context, id int64, paymentId int64, request request.CreatePaymentReceiptRequest) error
+	GetPaymentReceipts(ctx context.Context, id int64, paymentId int64) ([]output.GetPaymentReceiptOutput, error)
+	RenegotiateSale(ctx context.Context, saleId int64, request request.RenegotiateSaleRequest) error
+	GetRenegotiations(ctx context.Context, id int64) ([]output.GetSalesRenegotiationOutput, error)
 }
 
 type salesService struct {
-	salesUsecase        sales_usecase.SalesUseCase
-	salesRepository     domain.SalesRepository
-	inventoryRepository domain.InventoryRepository
+	salesUsecase          sales_usecase.SalesUseCase
+	salesRepository       domain.SalesRepository
+	inventoryRepository   domain.InventoryRepository
+	lateFeeRuleRepository domain.LateFeeRuleRepository
 }
 
-func NewSalesService(salesUsecase sales_usecase.SalesUseCase, salesRepository domain.SalesRepository, inventoryRepository domain.InventoryRepository) SalesService {
+func NewSalesService(salesUsecase sales_usecase.SalesUseCase, salesRepository domain.SalesRepository, inventoryRepository domain.InventoryRepository, lateFeeRuleRepository domain.LateFeeRuleRepository) SalesService {
 	return &salesService{
-		salesUsecase:        salesUsecase,
-		salesRepository:     salesRepository,
-		inventoryRepository: inventoryRepository,
+		salesUsecase:          salesUsecase,
+		salesRepository:       salesRepository,
+		inventoryRepository:   inventoryRepository,
+		lateFeeRuleRepository: lateFeeRuleRepository,
 	}
 }
 
@@ -47,22 +58,39 @@ func (s *salesService) CreateSales(ctx context.Context, request request.CreateSa
 
 	userId := int64(ctx.Value(constants.USERID_KEY).(float64))
 
+	input := sales_usecase.DoSaleInput{
+		CustomerId: request.CustomerId,
+		UserId:     userId,
+		Date:       time.Now(),
+		Items:      buildItemsInput(request.Items),
+		Payments:   buildPaymentsInput(request.Payments),
+		Discount:   request.Discount.ToDomain(),
+	}
+
+	return s.salesUsecase.DoSale(ctx, input)
+}
+
+func buildItemsInput(requestItems []request.CreateSaleRequestItems) []sales_usecase.DoSaleItemsInput {
 	items := make([]sales_usecase.DoSaleItemsInput, 0)
-	payments := make([]sales_usecase.DoSalePaymentsInput, 0)
-	for _, item := range request.Items {
+	for _, item := range requestItems {
 		items = append(items, sales_usecase.DoSaleItemsInput{
 			SkuId:    item.SkuId,
 			Quantity: item.Quantity,
+			Discount: item.Discount.ToDomain(),
 		})
 	}
+	return items
+}
 
-	for _, payment := range request.Payments {
+func buildPaymentsInput(requestPayments []request.CreateSaleRequestPayments) []sales_usecase.DoSalePaymentsInput {
+	payments := make([]sales_usecase.DoSalePaymentsInput, 0)
+	for _, payment := range requestPayments {
 		dates := make([]sales_usecase.DoSalePaymentDatesInput, 0)
 		installmentQuantity := 1
 		if payment.InstallmentsQuantity != nil {
 			installmentQuantity = *payment.InstallmentsQuantity
 		}
-		installmentsValue := s.calculateTotalValue(payment.Value, installmentQuantity)
+		installmentsValue := calculateTotalValue(payment.Value, installmentQuantity)
 		dateInformed := payment.FirstInstallmentDate != nil
 		for i := 0; i < installmentQuantity; i++ {
 			dueDate := time.Now()
@@ -77,24 +105,17 @@ func (s *salesService) CreateSales(ctx context.Context, request request.CreateSa
 			})
 		}
 		payments = append(payments, sales_usecase.DoSalePaymentsInput{
-			PaymentType: payment.PaymentType,
-			Value:       payment.Value,
-			Dates:       dates,
+			PaymentType:    payment.PaymentType,
+			Value:          payment.Value,
+			Dates:          dates,
+			ReceivedAmount: payment.ReceivedAmount,
+			CardBrand:      payment.CardBrand,
 		})
 	}
-
-	input := sales_usecase.DoSaleInput{
-		CustomerId: request.CustomerId,
-		UserId:     userId,
-		Date:       time.Now(),
-		Items:      items,
-		Payments:   payments,
-	}
-
-	return s.salesUsecase.DoSale(ctx, input)
+	return payments
 }
 
-func (s *salesService) calculateTotalValue(total float64, n int) []float64 {
+func calculateTotalValue(total float64, n int) []float64 {
 	totalCents := int64(math.Round(total * 100)) // ex.: 14.00 -> 1400
 	base := totalCents / int64(n)
 	rem := totalCents % int64(n)
@@ -128,18 +149,47 @@ func (s *salesService) GetSales(ctx context.Context, request request.ListSalesRe
 		}
 	}
 
-	sales, err := s.salesRepository.GetSales(ctx, input.GetSalesInput{
+	if err = request.Validate(); err != nil {
+		return output, err
+	}
+
+	page, pageSize := request.GetPage(), request.GetPageSize()
+	salesInput := input.GetSalesInput{
 		InitialDate:   request.MinDate,
 		FinalDate:     request.MaxDate,
 		UserId:        userId,
 		CustomerId:    request.CustomerId,
 		PaymentStatus: request.PaymentStatus,
-	})
+		ProductId:     request.ProductId,
+		SkuId:         request.SkuId,
+		CategoryId:    request.CategoryId,
+		PaymentType:   request.PaymentType,
+		HasReturns:    request.HasReturns,
+		MinValue:      request.MinValue,
+		MaxValue:      request.MaxValue,
+		Search:        request.Search,
+		Sort:          request.Sort,
+		Descending:    request.Order != "asc",
+		Limit:         pageSize,
+		Offset:        (page - 1) * pageSize,
+	}
+	if salesInput.Sort == "" {
+		salesInput.Sort = domain.SalesSortDate
+	}
+
+	sales, err := s.salesRepository.GetSales(ctx, salesInput)
+	if err != nil {
+		return output, err
+	}
+	totals, err := s.salesRepository.GetSalesTotals(ctx, salesInput)
 	if err != nil {
 		return output, err
 	}
 
 	output.Sales = sales
+	output.Totals = totals
+	output.Page = page
+	output.PageSize = pageSize
 	return output, nil
 }
 
@@ -153,12 +203,21 @@ func (s *salesService) GetById(ctx context.Context, id int64) (saleOutput output
 	if err != nil {
 		return saleOutput, paymentGroupOutput, itemsOutput, returnsOutput, err
 	}
+	if err = s.applyLateFees(ctx, paymentOutput); err != nil {
+		return saleOutput, paymentGroupOutput, itemsOutput, returnsOutput, err
+	}
 	paymentGroupOutput = s.groupPaymentsByPaymentType(paymentOutput)
 
 	itemsOutput, err = s.salesRepository.GetItemsBySaleId(ctx, id)
 	if err != nil {
 		return saleOutput, paymentGroupOutput, itemsOutput, returnsOutput, err
 	}
+	// Custo e margem ficam restritos ao administrador.
+	if userRole, _ := ctx.Value(constants.ROLE_KEY).(string); userRole == string(domain.UserRoleReseller) {
+		for i := range itemsOutput {
+			itemsOutput[i].UnitCost = nil
+		}
+	}
 
 	returnsOutput, err = s.salesRepository.GetReturnsBySaleId(ctx, id)
 	if err != nil {
@@ -206,6 +265,175 @@ func (s *salesService) CreateReturn(ctx context.Context, saleId int64, request r
 	})
 }
 
+func (s *salesService) CancelSale(ctx context.Context, saleId int64, request request.CancelSaleRequest) error {
+	if err := request.Validate(); err != nil {
+		return err
+	}
+
+	userID := int64(ctx.Value(constants.USERID_KEY).(float64))
+
+	return s.salesUsecase.DoCancel(ctx, sales_usecase.DoCancelInput{
+		SaleId: saleId,
+		UserId: userID,
+		Reason: request.Reason,
+	})
+}
+
+func (s *salesService) EditSale(ctx context.Context, saleId int64, request request.EditSaleRequest) error {
+	if err := request.Validate(); err != nil {
+		return err
+	}
+
+	userID := int64(ctx.Value(constants.USERID_KEY).(float64))
+
+	return s.salesUsecase.DoEdit(ctx, sales_usecase.DoEditInput{
+		SaleId:     saleId,
+		UserId:     userID,
+		CustomerId: request.CustomerId,
+		Items:      buildItemsInput(request.Items),
+		Payments:   buildPaymentsInput(request.Payments),
+	})
+}
+
+func (s *salesService) ExchangeSale(ctx context.Context, saleId int64, request request.ExchangeSaleRequest) error {
+	if err := request.Validate(); err != nil {
+		return err
+	}
+
+	userID := int64(ctx.Value(constants.USERID_KEY).(float64))
+
+	returnedItems := make([]sales_usecase.DoReturnItemInput, 0, len(request.ReturnedItems))
+	for _, item := range request.ReturnedItems {
+		returnedItems = append(returnedItems, sales_usecase.DoReturnItemInput{
+			SkuId:    item.SkuId,
+			Quantity: item.Quantity,
+		})
+	}
+
+	return s.salesUsecase.DoExchange(ctx, sales_usecase.DoExchangeInput{
+		SaleId:        saleId,
+		UserId:        userID,
+		Reason:        request.Reason,
+		ReturnedItems: returnedItems,
+		Items:         buildItemsInput(request.Items),
+		Payments:      buildPaymentsInput(request.Payments),
+	})
+}
+
+func (s *salesService) RenegotiateSale(ctx context.Context, saleId int64, request request.RenegotiateSaleRequest) error {
+	if err := request.Validate(); err != nil {
+		return err
+	}
+
+	userID := int64(ctx.Value(constants.USERID_KEY).(float64))
+
+	return s.salesUsecase.DoRenegotiation(ctx, sales_usecase.DoRenegotiationInput{
+		SaleId:             saleId,
+		UserId:             userID,
+		Installments:       request.Installments,
+		FirstDueDate:       request.FirstDueDate,
+		InterestPercentage: request.InterestPercentage,
+		DiscountPercentage: request.DiscountPercentage,
+	})
+}
+
+func (s *salesService) GetRenegotiations(ctx context.Context, id int64) ([]output.GetSalesRenegotiationOutput, error) {
+	return s.salesRepository.GetRenegotiationsBySaleId(ctx, id)
+}
+
+func (s *salesService) GetVersions(ctx context.Context, id int64) ([]output.GetSalesVersionOutput, error) {
+	versions, err := s.salesRepository.GetVersionsBySaleId(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if len(versions) == 0 {
+		return nil, ErrSaleNotFound
+	}
+
+	versionsOutput := make([]output.GetSalesVersionOutput, 0, len(versions))
+	for i, version := range versions {
+		items, err := s.salesRepository.GetItemsBySaleVersionId(ctx, version.Id)
+		if err != nil {
+			return nil, err
+		}
+		payments, err := s.salesRepository.GetPaymentsBySaleVersionId(ctx, version.Id)
+		if err != nil {
+			return nil, err
+		}
+
+		versionOutput := output.GetSalesVersionOutput{
+			GetSalesVersionOutput: version,
+			TotalValue:            s.sumItems(items),
+			Items:                 items,
+			Payments:              s.groupPaymentsByPaymentType(payments),
+		}
+		if i > 0 {
+			versionOutput.Changes = s.diffSalesVersions(versionsOutput[i-1], versionOutput)
+		}
+		versionsOutput = append(versionsOutput, versionOutput)
+	}
+	return versionsOutput, nil
+}
+
+func (s *salesService) diffSalesVersions(previous output.GetSalesVersionOutput, current output.GetSalesVersionOutput) *output.GetSalesVersionChangesOutput {
+	changes := &output.GetSalesVersionChangesOutput{
+		PreviousTotalValue: previous.TotalValue,
+		Items:              make([]output.GetSalesVersionItemChangeOutput, 0),
+	}
+	if previous.CustomerId != current.CustomerId {
+		changes.PreviousCustomerName = &previous.CustomerName
+	}
+
+	previousBySku := make(map[int64]output.GetItemsOutput)
+	for _, item := range previous.Items {
+		previousBySku[item.Sku.Id] = item
+	}
+	currentBySku := make(map[int64]bool)
+	for _, item := range current.Items {
+		currentBySku[item.Sku.Id] = true
+		previousItem, ok := previousBySku[item.Sku.Id]
+		if !ok {
+			changes.Items = append(changes.Items, output.GetSalesVersionItemChangeOutput{
+				Sku:       item.Sku,
+				Type:      output.SalesItemChangeAdded,
+				Quantity:  item.Quantity,
+				UnitPrice: item.UnitPrice,
+			})
+			continue
+		}
+		if previousItem.Quantity != item.Quantity || previousItem.UnitPrice != item.UnitPrice {
+			changes.Items = append(changes.Items, output.GetSalesVersionItemChangeOutput{
+				Sku:               item.Sku,
+				Type:              output.SalesItemChangeChanged,
+				PreviousQuantity:  previousItem.Quantity,
+				Quantity:          item.Quantity,
+				PreviousUnitPrice: previousItem.UnitPrice,
+				UnitPrice:         item.UnitPrice,
+			})
+		}
+	}
+	for _, item := range previous.Items {
+		if currentBySku[item.Sku.Id] {
+			continue
+		}
+		changes.Items = append(changes.Items, output.GetSalesVersionItemChangeOutput{
+			Sku:               item.Sku,
+			Type:              output.SalesItemChangeRemoved,
+			PreviousQuantity:  item.Quantity,
+			PreviousUnitPrice: item.UnitPrice,
+		})
+	}
+	return changes
+}
+
+func (s *salesService) sumItems(items []output.GetItemsOutput) float64 {
+	total := 0.0
+	for _, item := range items {
+		total += item.GetTotal()
+	}
+	return math.Round(total*100) / 100
+}
+
 func (s *salesService) groupPaymentsByPaymentType(payments []output.GetSalesPaymentOutput) []output.GetSalesPaymentGroupOutput {
 	items := make([]output.GetSalesPaymentGroupOutput, 0)
 
@@ -234,24 +462,89 @@ func (s *salesService) ChangePaymentStatus(ctx context.Context, id int64, paymen
 		return err
 	}
 
-	_, err := s.salesRepository.GetPaymentDatesBySaleIdAndPaymentDateId(ctx, id, paymentId)
+	paymentDate, err := s.salesRepository.GetPaymentDatesBySaleIdAndPaymentDateId(ctx, id, paymentId)
 	if err != nil {
 		return err
 	}
 
+	if request.Status == string(domain.PaymentStatusPaid) {
+		return s.settlePaymentDate(ctx, id, paymentDate, request)
+	}
+
 	_, err = s.salesRepository.ChangePaymentStatus(ctx, paymentId, domain.PaymentStatus(request.Status))
+	return err
+}
+
+// settlePaymentDate quita a parcela registrando um recebimento do saldo em
+// aberto. Sem forma informada, usa a da parcela quando ela aceita recebimentos
+// e dinheiro nos demais casos, como o crediário. Sem data, considera hoje.
+func (s *salesService) settlePaymentDate(ctx context.Context, id int64, paymentDate domain.SalesPaymentDates, request request.ChangePaymentStatusRequest) error {
+	paymentType := domain.PaymentType(request.PaymentType)
+	if paymentType == "" {
+		paymentType = paymentDate.PaymentType
+	}
+	if !domain.IsPaymentReceiptType(paymentType) {
+		paymentType = domain.PaymentTypeCash
+	}
+	receiptDate := request.Date
+	if receiptDate.IsZero() {
+		receiptDate = time.Now()
+	}
+
+	userID := int64(ctx.Value(constants.USERID_KEY).(float64))
+
+	return s.salesUsecase.DoPaymentReceipt(ctx, sales_usecase.DoPaymentReceiptInput{
+		SaleId:          id,
+		PaymentDateId:   paymentDate.Id,
+		UserId:          userID,
+		ReceiptDate:     receiptDate,
+		PaymentType:     paymentType,
+		SettleRemaining: true,
+	})
+}
+
+// applyLateFees calcula na data de hoje os encargos das parcelas ainda em aberto.
+// Parcelas pagas mantêm os encargos registrados na baixa.
+func (s *salesService) applyLateFees(ctx context.Context, payments []output.GetSalesPaymentOutput) error {
+	rule, err := s.lateFeeRuleRepository.Get(ctx)
 	if err != nil {
 		return err
 	}
 
-	var date *time.Time
-	if request.Status == string(domain.PaymentStatusPaid) {
-		date = &request.Date
+	today := time.Now()
+	for i, payment := range payments {
+		if payment.PaymentStatus != domain.PaymentStatusPending && payment.PaymentStatus != domain.PaymentStatusDelayed {
+			continue
+		}
+		lateFee := rule.Calculate(payment.PaymentType, payment.GetRemainingValue(), payment.DueDate, today)
+		payments[i].FineValue = lateFee.FineValue
+		payments[i].InterestValue = lateFee.InterestValue
 	}
-	_, err = s.salesRepository.ChangePaymentDate(ctx, paymentId, date)
-	if err != nil {
+	return nil
+}
+
+func (s *salesService) CreatePaymentReceipt(ctx context.Context, id int64, paymentId int64, request request.CreatePaymentReceiptRequest) error {
+	if err := request.Validate(); err != nil {
 		return err
 	}
 
-	return err
+	userID := int64(ctx.Value(constants.USERID_KEY).(float64))
+
+	return s.salesUsecase.DoPaymentReceipt(ctx, sales_usecase.DoPaymentReceiptInput{
+		SaleId:        id,
+		PaymentDateId: paymentId,
+		UserId:        userID,
+		Value:         request.Value,
+		ReceiptDate:   request.Date,
+		PaymentType:   domain.PaymentType(request.PaymentType),
+	})
+}
+
+func (s *salesService) GetPaymentReceipts(ctx context.Context, id int64, paymentId int64) ([]output.GetPaymentReceiptOutput, error) {
+	_, err := s.salesRepository.GetPaymentDatesBySaleIdAndPaymentDateId(ctx, id, paymentId)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.salesRepository.GetPaymentReceiptsByPaymentDateId(ctx, paymentId)
 }
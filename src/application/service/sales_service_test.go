@@ -15,7 +15,7 @@ import (
 func TestSalesServiceCreateSales(t *testing.T) {
 	useCase := &stubSalesUseCase{}
 	repo := &stubSalesRepository{}
-	service := NewSalesService(useCase, repo, &stubInventoryRepository{})
+	service := NewSalesService(useCase, repo, &stubInventoryRepository{}, &stubLateFeeRuleRepository{})
 
 	ctx := context.WithValue(context.Background(), constants.USERID_KEY, float64(42))
 	firstInstallment := time.Now().AddDate(0, 0, 10)
@@ -69,8 +69,81 @@ func TestSalesServiceCreateSales(t *testing.T) {
 	}
 }
 
+func TestSalesServiceCreateSalesWithCashReceivedAmount(t *testing.T) {
+	useCase := &stubSalesUseCase{}
+	service := NewSalesService(useCase, &stubSalesRepository{}, &stubInventoryRepository{}, &stubLateFeeRuleRepository{})
+	ctx := context.WithValue(context.Background(), constants.USERID_KEY, float64(42))
+
+	req := request.CreateSaleRequest{
+		CustomerId: 99,
+		Items:      []request.CreateSaleRequestItems{{SkuId: 11, Quantity: 1}},
+		Payments:   []request.CreateSaleRequestPayments{{PaymentType: domain.PaymentTypeCash, Value: 87, ReceivedAmount: 100}},
+	}
+	if err := service.CreateSales(ctx, req); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	payment := useCase.receivedInput.Payments[0]
+	if payment.ReceivedAmount != 100 || payment.Dates[0].InstallmentValue != 87 {
+		t.Fatalf("unexpected payment input: %+v", payment)
+	}
+
+	req.Payments[0].PaymentType = domain.PaymentTypePix
+	if err := service.CreateSales(ctx, req); err != domain.ErrReceivedAmountOnlyCash {
+		t.Fatalf("expected cash only error, got %v", err)
+	}
+}
+
+func TestSalesServiceCreateSalesWithDiscounts(t *testing.T) {
+	useCase := &stubSalesUseCase{}
+	service := NewSalesService(useCase, &stubSalesRepository{}, &stubInventoryRepository{}, &stubLateFeeRuleRepository{})
+
+	ctx := context.WithValue(context.Background(), constants.USERID_KEY, float64(42))
+	req := request.CreateSaleRequest{
+		CustomerId: 99,
+		Items: []request.CreateSaleRequestItems{
+			{SkuId: 11, Quantity: 2, Discount: &request.SaleDiscountRequest{Type: domain.DiscountTypePercentage, Value: 10, Reason: " Avaria "}},
+			{SkuId: 12, Quantity: 1},
+		},
+		Payments: []request.CreateSaleRequestPayments{{PaymentType: domain.PaymentTypeCash, Value: 50}},
+		Discount: &request.SaleDiscountRequest{Type: domain.DiscountTypeFixed, Value: 5, Reason: "Cliente fiel"},
+	}
+
+	if err := service.CreateSales(ctx, req); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	items := useCase.receivedInput.Items
+	if items[0].Discount != domain.NewSalesDiscount(domain.DiscountTypePercentage, 10, "Avaria") || !items[1].Discount.IsEmpty() {
+		t.Fatalf("unexpected item discounts: %+v", items)
+	}
+	if useCase.receivedInput.Discount != domain.NewSalesDiscount(domain.DiscountTypeFixed, 5, "Cliente fiel") {
+		t.Fatalf("unexpected sale discount: %+v", useCase.receivedInput.Discount)
+	}
+
+	req.Discount = &request.SaleDiscountRequest{Type: domain.DiscountTypePercentage, Value: 120, Reason: "Cliente fiel"}
+	if err := service.CreateSales(ctx, req); err != domain.ErrDiscountPercentageInvalid {
+		t.Fatalf("expected percentage error, got %v", err)
+	}
+	req.Discount = &request.SaleDiscountRequest{Type: domain.DiscountTypeFixed, Value: 5}
+	if err := service.CreateSales(ctx, req); err == nil {
+		t.Fatalf("expected reason validation error")
+	}
+}
+
+func TestSalesServiceEditSaleRejectsDiscounts(t *testing.T) {
+	service := NewSalesService(&stubSalesUseCase{}, &stubSalesRepository{}, &stubInventoryRepository{}, &stubLateFeeRuleRepository{})
+
+	ctx := context.WithValue(context.Background(), constants.USERID_KEY, float64(42))
+	err := service.EditSale(ctx, 1, request.EditSaleRequest{
+		CustomerId: 99,
+		Items:      []request.CreateSaleRequestItems{{SkuId: 11, Quantity: 1, Discount: &request.SaleDiscountRequest{Type: domain.DiscountTypeFixed, Value: 1, Reason: "Motivo"}}},
+	})
+	if err != domain.ErrDiscountOnlyOnSale {
+		t.Fatalf("expected discount error, got %v", err)
+	}
+}
+
 func TestSalesServiceCreateSalesValidationError(t *testing.T) {
-	service := NewSalesService(&stubSalesUseCase{}, &stubSalesRepository{}, &stubInventoryRepository{})
+	service := NewSalesService(&stubSalesUseCase{}, &stubSalesRepository{}, &stubInventoryRepository{}, &stubLateFeeRuleRepository{})
 
 	err := service.CreateSales(context.Background(), request.CreateSaleRequest{})
 	if err == nil {
@@ -82,7 +155,7 @@ func TestSalesServiceGetSales(t *testing.T) {
 	repo := &stubSalesRepository{
 		getSalesOutput: []output.GetSalesItemOutput{{Id: 1}},
 	}
-	service := NewSalesService(&stubSalesUseCase{}, repo, &stubInventoryRepository{})
+	service := NewSalesService(&stubSalesUseCase{}, repo, &stubInventoryRepository{}, &stubLateFeeRuleRepository{})
 
 	ctx := context.WithValue(context.Background(), constants.ROLE_KEY, string(domain.UserRoleAdmin))
 	req := request.ListSalesRequest{UserId: []int64{7}}
@@ -97,10 +170,48 @@ func TestSalesServiceGetSales(t *testing.T) {
 	if len(repo.getSalesInput.UserId) != 1 || repo.getSalesInput.UserId[0] != 7 {
 		t.Fatalf("expected repository to receive user id 7, got %+v", repo.getSalesInput.UserId)
 	}
+	if repo.getSalesInput.Sort != domain.SalesSortDate || !repo.getSalesInput.Descending || repo.getSalesInput.Limit != request.DefaultSalesPageSize || repo.getSalesInput.Offset != 0 {
+		t.Fatalf("expected newest sales on the first page by default, got %+v", repo.getSalesInput)
+	}
+}
+
+func TestSalesServiceGetSalesPagination(t *testing.T) {
+	repo := &stubSalesRepository{
+		getSalesOutput:       []output.GetSalesItemOutput{{Id: 1}},
+		getSalesTotalsOutput: output.GetSalesTotalsOutput{TotalSales: 45, TotalValue: 900},
+	}
+	service := NewSalesService(&stubSalesUseCase{}, repo, &stubInventoryRepository{}, &stubLateFeeRuleRepository{})
+	ctx := context.WithValue(context.Background(), constants.ROLE_KEY, string(domain.UserRoleAdmin))
+	search := "maria"
+
+	out, err := service.GetSales(ctx, request.ListSalesRequest{Search: &search, Sort: domain.SalesSortTotal, Order: "asc", Page: 3, PageSize: 20})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if repo.getSalesInput.Sort != domain.SalesSortTotal || repo.getSalesInput.Descending || repo.getSalesInput.Limit != 20 || repo.getSalesInput.Offset != 40 || *repo.getSalesInput.Search != "maria" {
+		t.Fatalf("unexpected repository input: %+v", repo.getSalesInput)
+	}
+	if out.Page != 3 || out.GetTotalPages() != 3 || out.GetSummary().AverageTicket != 20 {
+		t.Fatalf("unexpected output: %+v", out)
+	}
+
+	if _, err := service.GetSales(ctx, request.ListSalesRequest{Sort: "seller"}); err == nil {
+		t.Fatalf("expected invalid sort error")
+	}
+	if _, err := service.GetSales(ctx, request.ListSalesRequest{PageSize: 500}); err == nil {
+		t.Fatalf("expected invalid page size error")
+	}
+	minValue, maxValue := 100.0, 10.0
+	if _, err := service.GetSales(ctx, request.ListSalesRequest{MinValue: &minValue, MaxValue: &maxValue}); err == nil {
+		t.Fatalf("expected invalid value range error")
+	}
+	if _, err := service.GetSales(ctx, request.ListSalesRequest{PaymentType: []domain.PaymentType{"BOLETO"}}); err == nil {
+		t.Fatalf("expected invalid payment type error")
+	}
 }
 
 func TestSalesServiceGetSalesPermissionDenied(t *testing.T) {
-	service := NewSalesService(&stubSalesUseCase{}, &stubSalesRepository{}, &stubInventoryRepository{})
+	service := NewSalesService(&stubSalesUseCase{}, &stubSalesRepository{}, &stubInventoryRepository{}, &stubLateFeeRuleRepository{})
 	ctx := context.WithValue(context.Background(), constants.ROLE_KEY, "")
 
 	_, err := service.GetSales(ctx, request.ListSalesRequest{})
@@ -115,7 +226,7 @@ func TestSalesServiceGetById(t *testing.T) {
 		paymentsOutput: []output.GetSalesPaymentOutput{{PaymentType: domain.PaymentTypeCash}, {PaymentType: domain.PaymentTypeCash}},
 		itemsOutput:    []output.GetItemsOutput{{Sku: domain.Sku{Id: 3}}},
 	}
-	service := NewSalesService(&stubSalesUseCase{}, repo, &stubInventoryRepository{})
+	service := NewSalesService(&stubSalesUseCase{}, repo, &stubInventoryRepository{}, &stubLateFeeRuleRepository{})
 
 	sale, payments, items, returnsOutput, err := service.GetById(context.Background(), 2)
 	if err != nil {
@@ -137,30 +248,77 @@ func TestSalesServiceGetById(t *testing.T) {
 
 func TestSalesServiceChangePaymentStatus(t *testing.T) {
 	now := time.Now()
+	useCase := &stubSalesUseCase{}
 	repo := &stubSalesRepository{
-		paymentDateBySaleAndPaymentId: domain.SalesPaymentDates{Id: 5},
+		paymentDateBySaleAndPaymentId: domain.SalesPaymentDates{Id: 5, PaymentType: domain.PaymentTypePix},
 	}
-	service := NewSalesService(&stubSalesUseCase{}, repo, &stubInventoryRepository{})
+	service := NewSalesService(useCase, repo, &stubInventoryRepository{}, &stubLateFeeRuleRepository{})
 
 	req := request.ChangePaymentStatusRequest{
 		Status: string(domain.PaymentStatusPaid),
 		Date:   now,
 	}
 
-	err := service.ChangePaymentStatus(context.Background(), 10, 20, req)
+	err := service.ChangePaymentStatus(ctxWithRoleAndUser(domain.UserRoleAdmin, 9), 10, 20, req)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
-	if repo.changePaymentStatusCalledWith.id != 20 || repo.changePaymentStatusCalledWith.status != domain.PaymentStatus(req.Status) {
-		t.Fatalf("unexpected status change args: %+v", repo.changePaymentStatusCalledWith)
+	receipt := useCase.receivedReceipt
+	if receipt.SaleId != 10 || receipt.PaymentDateId != 5 || receipt.UserId != 9 || !receipt.SettleRemaining || !receipt.ReceiptDate.Equal(now) {
+		t.Fatalf("expected remaining balance to be received, got %+v", receipt)
+	}
+	if receipt.PaymentType != domain.PaymentTypePix {
+		t.Fatalf("expected installment payment type to be used, got %s", receipt.PaymentType)
+	}
+	if repo.changePaymentStatusCalledWith.id != 0 {
+		t.Fatalf("expected installment to be settled only through a receipt")
+	}
+}
+
+func TestSalesServiceChangePaymentStatusUsesInformedPaymentType(t *testing.T) {
+	useCase := &stubSalesUseCase{}
+	repo := &stubSalesRepository{
+		paymentDateBySaleAndPaymentId: domain.SalesPaymentDates{Id: 5, PaymentType: domain.PaymentTypeCreditStore},
+	}
+	service := NewSalesService(useCase, repo, &stubInventoryRepository{}, &stubLateFeeRuleRepository{})
+	req := request.ChangePaymentStatusRequest{Status: string(domain.PaymentStatusPaid), Date: time.Now(), PaymentType: string(domain.PaymentTypeCash)}
+
+	if err := service.ChangePaymentStatus(ctxWithRoleAndUser(domain.UserRoleReseller, 9), 10, 5, req); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if useCase.receivedReceipt.PaymentType != domain.PaymentTypeCash {
+		t.Fatalf("expected informed payment type, got %+v", useCase.receivedReceipt)
+	}
+
+}
+
+func TestSalesServiceChangePaymentStatusDefaultsStoreCreditToCash(t *testing.T) {
+	useCase := &stubSalesUseCase{}
+	repo := &stubSalesRepository{
+		paymentDateBySaleAndPaymentId: domain.SalesPaymentDates{Id: 5, PaymentType: domain.PaymentTypeCreditStore},
+	}
+	service := NewSalesService(useCase, repo, &stubInventoryRepository{}, &stubLateFeeRuleRepository{})
+	req := request.ChangePaymentStatusRequest{Status: string(domain.PaymentStatusPaid)}
+
+	before := time.Now()
+	if err := service.ChangePaymentStatus(ctxWithRoleAndUser(domain.UserRoleReseller, 9), 10, 5, req); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	receipt := useCase.receivedReceipt
+	if receipt.PaymentType != domain.PaymentTypeCash || !receipt.SettleRemaining {
+		t.Fatalf("expected store credit installment to be settled in cash, got %+v", receipt)
+	}
+	if receipt.ReceiptDate.Before(before) || receipt.ReceiptDate.After(time.Now()) {
+		t.Fatalf("expected receipt date to default to now, got %v", receipt.ReceiptDate)
 	}
-	if repo.changePaymentDateCalledWith.date == nil || !repo.changePaymentDateCalledWith.date.Equal(now) {
-		t.Fatalf("expected payment date to be set: %+v", repo.changePaymentDateCalledWith)
+	settlement := domain.NewPaymentReceipt(5, 10, receipt.ReceiptDate, receipt.PaymentType, 9)
+	if err := settlement.Validate(domain.SalesPaymentDates{Status: domain.PaymentStatusPending, InstallmentValue: 10}, 0); err != nil {
+		t.Fatalf("expected defaulted receipt to be valid, got %v", err)
 	}
 }
 
 func TestSalesServiceChangePaymentStatusValidationError(t *testing.T) {
-	service := NewSalesService(&stubSalesUseCase{}, &stubSalesRepository{}, &stubInventoryRepository{})
+	service := NewSalesService(&stubSalesUseCase{}, &stubSalesRepository{}, &stubInventoryRepository{}, &stubLateFeeRuleRepository{})
 
 	err := service.ChangePaymentStatus(context.Background(), 1, 2, request.ChangePaymentStatusRequest{})
 	if err == nil {
@@ -186,8 +344,7 @@ func TestSalesServiceGroupPaymentsByPaymentType(t *testing.T) {
 }
 
 func TestSalesServiceCalculateTotalValueRemainder(t *testing.T) {
-	service := &salesService{}
-	out := service.calculateTotalValue(10.0, 3)
+	out := calculateTotalValue(10.0, 3)
 	if len(out) != 3 {
 		t.Fatalf("expected 3 installments")
 	}
@@ -200,7 +357,7 @@ func TestSalesServiceGetSalesNonAdminUsesContextUser(t *testing.T) {
 	repo := &stubSalesRepository{
 		getSalesOutput: []output.GetSalesItemOutput{},
 	}
-	service := NewSalesService(&stubSalesUseCase{}, repo, &stubInventoryRepository{})
+	service := NewSalesService(&stubSalesUseCase{}, repo, &stubInventoryRepository{}, &stubLateFeeRuleRepository{})
 
 	ctx := context.WithValue(context.Background(), constants.ROLE_KEY, string(domain.UserRoleReseller))
 	ctx = context.WithValue(ctx, constants.USERID_KEY, float64(55))
@@ -213,11 +370,12 @@ func TestSalesServiceGetSalesNonAdminUsesContextUser(t *testing.T) {
 	}
 }
 
-func TestSalesServiceChangePaymentStatusUsesNilDateWhenNotPaid(t *testing.T) {
+func TestSalesServiceChangePaymentStatusWhenNotPaid(t *testing.T) {
+	useCase := &stubSalesUseCase{}
 	repo := &stubSalesRepository{
 		paymentDateBySaleAndPaymentId: domain.SalesPaymentDates{},
 	}
-	service := NewSalesService(&stubSalesUseCase{}, repo, &stubInventoryRepository{})
+	service := NewSalesService(useCase, repo, &stubInventoryRepository{}, &stubLateFeeRuleRepository{})
 
 	req := request.ChangePaymentStatusRequest{
 		Status: string(domain.PaymentStatusPending),
@@ -226,14 +384,17 @@ func TestSalesServiceChangePaymentStatusUsesNilDateWhenNotPaid(t *testing.T) {
 	if err := service.ChangePaymentStatus(context.Background(), 1, 2, req); err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
-	if repo.changePaymentDateCalledWith.date != nil {
-		t.Fatalf("expected nil date when status not paid")
+	if repo.changePaymentStatusCalledWith.id != 2 || repo.changePaymentStatusCalledWith.status != domain.PaymentStatusPending {
+		t.Fatalf("unexpected status change args: %+v", repo.changePaymentStatusCalledWith)
+	}
+	if useCase.receivedReceipt.PaymentDateId != 0 {
+		t.Fatalf("expected no receipt when status not paid")
 	}
 }
 
 func TestSalesServiceCreateSalesUsesDefaultDueDate(t *testing.T) {
 	useCase := &stubSalesUseCase{}
-	service := NewSalesService(useCase, &stubSalesRepository{}, &stubInventoryRepository{})
+	service := NewSalesService(useCase, &stubSalesRepository{}, &stubInventoryRepository{}, &stubLateFeeRuleRepository{})
 
 	ctx := context.WithValue(context.Background(), constants.USERID_KEY, float64(9))
 	req := request.CreateSaleRequest{
@@ -263,7 +424,7 @@ func TestSalesServiceCreateSalesUsesDefaultDueDate(t *testing.T) {
 func TestSalesServiceCreateSalesUseCaseError(t *testing.T) {
 	expected := errors.New("boom")
 	useCase := &stubSalesUseCase{err: expected}
-	service := NewSalesService(useCase, &stubSalesRepository{}, &stubInventoryRepository{})
+	service := NewSalesService(useCase, &stubSalesRepository{}, &stubInventoryRepository{}, &stubLateFeeRuleRepository{})
 
 	ctx := context.WithValue(context.Background(), constants.USERID_KEY, float64(1))
 	installments := 1
@@ -285,7 +446,7 @@ func TestSalesServiceCreateSalesUseCaseError(t *testing.T) {
 
 func TestSalesServiceGetByIdErrors(t *testing.T) {
 	repo := &stubSalesRepository{saleByIdErr: errors.New("fail")}
-	service := NewSalesService(&stubSalesUseCase{}, repo, &stubInventoryRepository{})
+	service := NewSalesService(&stubSalesUseCase{}, repo, &stubInventoryRepository{}, &stubLateFeeRuleRepository{})
 	if _, _, _, _, err := service.GetById(context.Background(), 1); err == nil {
 		t.Fatalf("expected error")
 	}
@@ -297,7 +458,7 @@ func TestSalesServiceGetByIdErrors(t *testing.T) {
 	}
 
 	repo = &stubSalesRepository{saleByIdOutput: output.GetSaleByIdOutput{Id: 1}, paymentsErr: errors.New("payments")}
-	service = NewSalesService(&stubSalesUseCase{}, repo, &stubInventoryRepository{})
+	service = NewSalesService(&stubSalesUseCase{}, repo, &stubInventoryRepository{}, &stubLateFeeRuleRepository{})
 	if _, _, _, _, err := service.GetById(context.Background(), 1); err == nil {
 		t.Fatalf("expected payments error")
 	}
@@ -310,7 +471,7 @@ func TestSalesServiceGetByIdErrors(t *testing.T) {
 		paymentsOutput: []output.GetSalesPaymentOutput{},
 		itemsErr:       errors.New("items"),
 	}
-	service = NewSalesService(&stubSalesUseCase{}, repo, &stubInventoryRepository{})
+	service = NewSalesService(&stubSalesUseCase{}, repo, &stubInventoryRepository{}, &stubLateFeeRuleRepository{})
 	if _, _, _, _, err := service.GetById(context.Background(), 1); err == nil {
 		t.Fatalf("expected items error")
 	}
@@ -321,7 +482,7 @@ func TestSalesServiceGetByIdErrors(t *testing.T) {
 
 func TestSalesServiceChangePaymentStatusErrors(t *testing.T) {
 	repo := &stubSalesRepository{paymentDateErr: errors.New("dates")}
-	service := NewSalesService(&stubSalesUseCase{}, repo, &stubInventoryRepository{})
+	service := NewSalesService(&stubSalesUseCase{}, repo, &stubInventoryRepository{}, &stubLateFeeRuleRepository{})
 	req := request.ChangePaymentStatusRequest{Status: string(domain.PaymentStatusPending)}
 	if err := service.ChangePaymentStatus(context.Background(), 1, 2, req); err == nil {
 		t.Fatalf("expected error from payment date lookup")
@@ -331,7 +492,7 @@ func TestSalesServiceChangePaymentStatusErrors(t *testing.T) {
 	}
 
 	repo = &stubSalesRepository{paymentDateBySaleAndPaymentId: domain.SalesPaymentDates{}, changePaymentStatusErr: errors.New("status")}
-	service = NewSalesService(&stubSalesUseCase{}, repo, &stubInventoryRepository{})
+	service = NewSalesService(&stubSalesUseCase{}, repo, &stubInventoryRepository{}, &stubLateFeeRuleRepository{})
 	if err := service.ChangePaymentStatus(context.Background(), 1, 2, req); err == nil {
 		t.Fatalf("expected status change error")
 	}
@@ -342,7 +503,7 @@ func TestSalesServiceCreateReturnResellerUsesOwnInventory(t *testing.T) {
 	inventoryRepo := &stubInventoryRepository{
 		getByUser: domain.Inventory{Id: 77},
 	}
-	service := NewSalesService(useCase, &stubSalesRepository{}, inventoryRepo)
+	service := NewSalesService(useCase, &stubSalesRepository{}, inventoryRepo, &stubLateFeeRuleRepository{})
 
 	ctx := context.WithValue(context.Background(), constants.USERID_KEY, float64(42))
 	ctx = context.WithValue(ctx, constants.ROLE_KEY, string(domain.UserRoleReseller))
@@ -364,7 +525,7 @@ func TestSalesServiceCreateReturnResellerUsesOwnInventory(t *testing.T) {
 }
 
 func TestSalesServiceCreateReturnAdminRequiresInventory(t *testing.T) {
-	service := NewSalesService(&stubSalesUseCase{}, &stubSalesRepository{}, &stubInventoryRepository{})
+	service := NewSalesService(&stubSalesUseCase{}, &stubSalesRepository{}, &stubInventoryRepository{}, &stubLateFeeRuleRepository{})
 	ctx := context.WithValue(context.Background(), constants.USERID_KEY, float64(1))
 	ctx = context.WithValue(ctx, constants.ROLE_KEY, string(domain.UserRoleAdmin))
 
@@ -380,3 +541,248 @@ func TestSalesServiceCreateReturnAdminRequiresInventory(t *testing.T) {
 		t.Fatalf("expected admin inventory validation error")
 	}
 }
+
+func TestSalesServiceCancelSale(t *testing.T) {
+	useCase := &stubSalesUseCase{}
+	service := NewSalesService(useCase, &stubSalesRepository{}, &stubInventoryRepository{}, &stubLateFeeRuleRepository{})
+	ctx := context.WithValue(context.Background(), constants.USERID_KEY, float64(42))
+
+	if err := service.CancelSale(ctx, 10, request.CancelSaleRequest{Reason: "Venda errada"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if useCase.receivedCancelInput.SaleId != 10 || useCase.receivedCancelInput.UserId != 42 || useCase.receivedCancelInput.Reason != "Venda errada" {
+		t.Fatalf("unexpected cancel input: %+v", useCase.receivedCancelInput)
+	}
+}
+
+func TestSalesServiceCancelSaleValidationError(t *testing.T) {
+	useCase := &stubSalesUseCase{}
+	service := NewSalesService(useCase, &stubSalesRepository{}, &stubInventoryRepository{}, &stubLateFeeRuleRepository{})
+	ctx := context.WithValue(context.Background(), constants.USERID_KEY, float64(42))
+
+	if err := service.CancelSale(ctx, 10, request.CancelSaleRequest{}); err == nil {
+		t.Fatalf("expected validation error")
+	}
+	if useCase.receivedCancelInput.SaleId != 0 {
+		t.Fatalf("expected use case not to be called")
+	}
+}
+
+func TestSalesServiceEditSale(t *testing.T) {
+	useCase := &stubSalesUseCase{}
+	service := NewSalesService(useCase, &stubSalesRepository{}, &stubInventoryRepository{}, &stubLateFeeRuleRepository{})
+	ctx := context.WithValue(context.Background(), constants.USERID_KEY, float64(42))
+	installments := 2
+	firstDate := time.Now().AddDate(0, 1, 0)
+
+	err := service.EditSale(ctx, 10, request.EditSaleRequest{
+		CustomerId: 3,
+		Items:      []request.CreateSaleRequestItems{{SkuId: 5, Quantity: 2}},
+		Payments: []request.CreateSaleRequestPayments{{
+			PaymentType:          domain.PaymentTypeCreditStore,
+			Value:                25,
+			InstallmentsQuantity: &installments,
+			FirstInstallmentDate: &firstDate,
+		}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	received := useCase.receivedEditInput
+	if received.SaleId != 10 || received.UserId != 42 || received.CustomerId != 3 {
+		t.Fatalf("unexpected edit input: %+v", received)
+	}
+	if len(received.Items) != 1 || received.Items[0].SkuId != 5 || received.Items[0].Quantity != 2 {
+		t.Fatalf("unexpected items: %+v", received.Items)
+	}
+	if len(received.Payments) != 1 || len(received.Payments[0].Dates) != 2 || received.Payments[0].Dates[0].InstallmentValue != 12.5 {
+		t.Fatalf("unexpected payments: %+v", received.Payments)
+	}
+}
+
+func TestSalesServiceEditSaleValidationError(t *testing.T) {
+	useCase := &stubSalesUseCase{}
+	service := NewSalesService(useCase, &stubSalesRepository{}, &stubInventoryRepository{}, &stubLateFeeRuleRepository{})
+	ctx := context.WithValue(context.Background(), constants.USERID_KEY, float64(42))
+
+	if err := service.EditSale(ctx, 10, request.EditSaleRequest{CustomerId: 3}); err == nil {
+		t.Fatalf("expected validation error")
+	}
+	if useCase.receivedEditInput.SaleId != 0 {
+		t.Fatalf("expected use case not to be called")
+	}
+}
+
+func TestSalesServiceGetVersions(t *testing.T) {
+	skuA := domain.Sku{Id: 1, Code: "A"}
+	skuB := domain.Sku{Id: 2, Code: "B"}
+	skuC := domain.Sku{Id: 3, Code: "C"}
+	repo := &stubSalesRepository{
+		versionsOutput: []domain.GetSalesVersionOutput{
+			{Id: 11, Version: 1, Type: domain.SalesVersionTypeSale, CustomerId: 1, CustomerName: "Ana"},
+			{Id: 12, Version: 2, Type: domain.SalesVersionTypeEdit, CustomerId: 2, CustomerName: "Bia"},
+		},
+		itemsByVersionId: map[int64][]output.GetItemsOutput{
+			11: {{Sku: skuA, Quantity: 2, UnitPrice: 10}, {Sku: skuB, Quantity: 1, UnitPrice: 5}},
+			12: {{Sku: skuA, Quantity: 1, UnitPrice: 10}, {Sku: skuC, Quantity: 3, UnitPrice: 2}},
+		},
+		paymentsOutput: []output.GetSalesPaymentOutput{{PaymentType: domain.PaymentTypePix, InstallmentValue: 25}},
+	}
+	service := NewSalesService(&stubSalesUseCase{}, repo, &stubInventoryRepository{}, &stubLateFeeRuleRepository{})
+
+	versions, err := service.GetVersions(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(versions) != 2 {
+		t.Fatalf("expected two versions, got %d", len(versions))
+	}
+	if versions[0].Changes != nil || versions[0].TotalValue != 25 || len(versions[0].Payments) != 1 {
+		t.Fatalf("unexpected first version: %+v", versions[0])
+	}
+
+	changes := versions[1].Changes
+	if changes == nil || changes.PreviousCustomerName == nil || *changes.PreviousCustomerName != "Ana" || changes.PreviousTotalValue != 25 {
+		t.Fatalf("unexpected changes: %+v", changes)
+	}
+	if versions[1].TotalValue != 16 {
+		t.Fatalf("expected total 16, got %v", versions[1].TotalValue)
+	}
+	expected := map[int64]string{1: output.SalesItemChangeChanged, 2: output.SalesItemChangeRemoved, 3: output.SalesItemChangeAdded}
+	if len(changes.Items) != len(expected) {
+		t.Fatalf("unexpected item changes: %+v", changes.Items)
+	}
+	for _, item := range changes.Items {
+		if expected[item.Sku.Id] != item.Type {
+			t.Fatalf("unexpected change for sku %d: %s", item.Sku.Id, item.Type)
+		}
+	}
+}
+
+func TestSalesServiceGetVersionsErrors(t *testing.T) {
+	service := NewSalesService(&stubSalesUseCase{}, &stubSalesRepository{}, &stubInventoryRepository{}, &stubLateFeeRuleRepository{})
+	if _, err := service.GetVersions(context.Background(), 1); err != ErrSaleNotFound {
+		t.Fatalf("expected sale not found, got %v", err)
+	}
+
+	expectedErr := errors.New("failure")
+	version := []domain.GetSalesVersionOutput{{Id: 11, Version: 1}}
+	cases := []*stubSalesRepository{
+		{versionsErr: expectedErr},
+		{versionsOutput: version, itemsErr: expectedErr},
+		{versionsOutput: version, paymentsErr: expectedErr},
+	}
+	for _, repo := range cases {
+		service := NewSalesService(&stubSalesUseCase{}, repo, &stubInventoryRepository{}, &stubLateFeeRuleRepository{})
+		if _, err := service.GetVersions(context.Background(), 1); err != expectedErr {
+			t.Fatalf("expected %v, got %v", expectedErr, err)
+		}
+	}
+}
+
+func TestSalesServiceExchangeSale(t *testing.T) {
+	useCase := &stubSalesUseCase{}
+	service := NewSalesService(useCase, &stubSalesRepository{}, &stubInventoryRepository{}, &stubLateFeeRuleRepository{})
+	ctx := context.WithValue(context.Background(), constants.USERID_KEY, float64(42))
+
+	err := service.ExchangeSale(ctx, 10, request.ExchangeSaleRequest{
+		Reason:        "Tamanho errado",
+		ReturnedItems: []request.CreateSalesReturnItemRequest{{SkuId: 1, Quantity: 1}},
+		Items:         []request.CreateSaleRequestItems{{SkuId: 2, Quantity: 1}},
+		Payments:      []request.CreateSaleRequestPayments{{PaymentType: domain.PaymentTypePix, Value: 5}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	received := useCase.receivedExchange
+	if received.SaleId != 10 || received.UserId != 42 || received.Reason != "Tamanho errado" {
+		t.Fatalf("unexpected exchange input: %+v", received)
+	}
+	if len(received.ReturnedItems) != 1 || received.ReturnedItems[0].SkuId != 1 || len(received.Items) != 1 || received.Items[0].SkuId != 2 {
+		t.Fatalf("unexpected exchange items: %+v", received)
+	}
+	if len(received.Payments) != 1 || received.Payments[0].Dates[0].InstallmentValue != 5 {
+		t.Fatalf("unexpected payments: %+v", received.Payments)
+	}
+
+	if err := service.ExchangeSale(ctx, 10, request.ExchangeSaleRequest{Reason: "Troca"}); err == nil {
+		t.Fatalf("expected validation error")
+	}
+}
+
+func TestSalesServiceCreatePaymentReceipt(t *testing.T) {
+	useCase := &stubSalesUseCase{}
+	service := NewSalesService(useCase, &stubSalesRepository{}, &stubInventoryRepository{}, &stubLateFeeRuleRepository{})
+	ctx := context.WithValue(context.Background(), constants.USERID_KEY, float64(7))
+	now := time.Now()
+
+	err := service.CreatePaymentReceipt(ctx, 10, 20, request.CreatePaymentReceiptRequest{Value: 15.5, Date: now, PaymentType: "PIX"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	received := useCase.receivedReceipt
+	if received.SaleId != 10 || received.PaymentDateId != 20 || received.UserId != 7 || received.Value != 15.5 || !received.ReceiptDate.Equal(now) || received.PaymentType != domain.PaymentTypePix {
+		t.Fatalf("unexpected receipt input: %+v", received)
+	}
+}
+
+func TestSalesServiceCreatePaymentReceiptValidationError(t *testing.T) {
+	useCase := &stubSalesUseCase{}
+	service := NewSalesService(useCase, &stubSalesRepository{}, &stubInventoryRepository{}, &stubLateFeeRuleRepository{})
+
+	err := service.CreatePaymentReceipt(context.Background(), 10, 20, request.CreatePaymentReceiptRequest{Value: 10, Date: time.Now(), PaymentType: "CREDIT_STORE"})
+	if err == nil {
+		t.Fatalf("expected validation error")
+	}
+}
+
+func TestSalesServiceGetPaymentReceipts(t *testing.T) {
+	repo := &stubSalesRepository{receiptsOutput: []domain.PaymentReceipt{{Id: 1, Value: 10}}}
+	service := NewSalesService(&stubSalesUseCase{}, repo, &stubInventoryRepository{}, &stubLateFeeRuleRepository{})
+
+	receipts, err := service.GetPaymentReceipts(context.Background(), 10, 20)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(receipts) != 1 || receipts[0].Id != 1 {
+		t.Fatalf("unexpected receipts: %+v", receipts)
+	}
+
+	repo = &stubSalesRepository{paymentDateErr: errors.New("Pagamento não encontrado")}
+	service = NewSalesService(&stubSalesUseCase{}, repo, &stubInventoryRepository{}, &stubLateFeeRuleRepository{})
+	if _, err := service.GetPaymentReceipts(context.Background(), 10, 20); err == nil || repo.getReceiptsCalled {
+		t.Fatalf("expected payment date lookup error before listing receipts, got %v", err)
+	}
+}
+
+func TestSalesServiceRenegotiateSale(t *testing.T) {
+	useCase := &stubSalesUseCase{}
+	service := NewSalesService(useCase, &stubSalesRepository{}, &stubInventoryRepository{}, &stubLateFeeRuleRepository{})
+	ctx := context.WithValue(context.Background(), constants.USERID_KEY, float64(3))
+	firstDueDate := time.Now().AddDate(0, 0, 10)
+
+	err := service.RenegotiateSale(ctx, 10, request.RenegotiateSaleRequest{Installments: 4, FirstDueDate: firstDueDate, DiscountPercentage: 5})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	received := useCase.receivedRenegotiate
+	if received.SaleId != 10 || received.UserId != 3 || received.Installments != 4 || !received.FirstDueDate.Equal(firstDueDate) || received.DiscountPercentage != 5 {
+		t.Fatalf("unexpected renegotiation input: %+v", received)
+	}
+
+	if err := service.RenegotiateSale(ctx, 10, request.RenegotiateSaleRequest{Installments: 0, FirstDueDate: firstDueDate}); err == nil {
+		t.Fatalf("expected validation error")
+	}
+}
+
+func TestSalesServiceGetRenegotiations(t *testing.T) {
+	repo := &stubSalesRepository{renegotiationsOutput: []output.GetSalesRenegotiationOutput{{Id: 1, TotalValue: 90}}}
+	service := NewSalesService(&stubSalesUseCase{}, repo, &stubInventoryRepository{}, &stubLateFeeRuleRepository{})
+
+	renegotiations, err := service.GetRenegotiations(context.Background(), 10)
+	if err != nil || len(renegotiations) != 1 || renegotiations[0].TotalValue != 90 {
+		t.Fatalf("unexpected renegotiations: %+v %v", renegotiations, err)
+	}
+}
@@ -7,22 +7,37 @@ import (
 )
 
 type ApplicationService struct {
-	ProductService   ProductService
-	SkuService       SkuService
-	CategoryService  CategoryService
-	AuthService      AuthService
-	UserService      UserService
-	InventoryService InventoryService
-	SalesService     SalesService
-	CustomerService  CustomerService
-	CompanyService   CompanyService
-	UserTokenService UserTokenService
-	DashboardService DashboardService
-	BillingService   BillingService
-	NewsService      NewsService
-	repositories     *repository.Repository
-	useCases         *usecase.ApplicationUseCase
-	ports            *ports.Ports
+	ProductService               ProductService
+	SkuService                   SkuService
+	CategoryService              CategoryService
+	AuthService                  AuthService
+	UserService                  UserService
+	InventoryService             InventoryService
+	SalesService                 SalesService
+	CustomerService              CustomerService
+	CompanyService               CompanyService
+	UserTokenService             UserTokenService
+	DashboardService             DashboardService
+	BillingService               BillingService
+	NewsService                  NewsService
+	ReceivableService            ReceivableService
+	LateFeeService               LateFeeService
+	DiscountService              DiscountService
+	QuoteService                 QuoteService
+	CommissionService            CommissionService
+	ConsignmentSettlementService ConsignmentSettlementService
+	DocumentService              DocumentService
+	PixService                   PixService
+	CashRegisterService          CashRegisterService
+	CardFeeService               CardFeeService
+	IdempotencyService           IdempotencyService
+	StockCountService            StockCountService
+	StockLevelService            StockLevelService
+	SupplierService              SupplierService
+	PurchaseOrderService         PurchaseOrderService
+	repositories                 *repository.Repository
+	useCases                     *usecase.ApplicationUseCase
+	ports                        *ports.Ports
 }
 
 func NewApplicationService(repositories *repository.Repository, useCases *usecase.ApplicationUseCase, ports *ports.Ports) *ApplicationService {
@@ -43,11 +58,26 @@ func (s *ApplicationService) SetupServices() {
 	s.CategoryService = NewCategoryService(s.repositories.CategoryRepository)
 	s.BillingService = NewBillingService(s.repositories.PlanRepository, s.repositories.SubscriptionRepository, s.repositories.BillingPaymentRepository, s.repositories)
 	s.AuthService = NewAuthService(s.repositories.UserRepository, s.ports.Encrypto, s.BillingService)
-	s.UserService = NewUserService(s.repositories.UserRepository, s.repositories.InventoryRepository, s.ports.Encrypto, s.UserTokenService, s.useCases.EmailUseCase, s.repositories.UserTokenRepository, s.repositories.LegalDocumentRepository, s.repositories.LegalAcceptanceRepository, s.repositories)
 	s.InventoryService = NewInventoryService(s.useCases.InventoryUseCase, s.repositories.InventoryItemRepository, s.repositories.InventoryTransactionRepository, s.repositories.InventoryRepository, s.repositories)
-	s.SalesService = NewSalesService(s.useCases.SalesUsecase, s.repositories.SalesRepository, s.repositories.InventoryRepository)
-	s.CustomerService = NewCustomerService(s.repositories.CustomerRepository)
+	s.UserService = NewUserService(s.repositories.UserRepository, s.repositories.InventoryRepository, s.ports.Encrypto, s.UserTokenService, s.useCases.EmailUseCase, s.repositories.UserTokenRepository, s.repositories.LegalDocumentRepository, s.repositories.LegalAcceptanceRepository, s.repositories.InventoryItemRepository, s.repositories.ReceivableRepository, s.useCases.InventoryUseCase, s.repositories)
+	s.SalesService = NewSalesService(s.useCases.SalesUsecase, s.repositories.SalesRepository, s.repositories.InventoryRepository, s.repositories.LateFeeRuleRepository)
+	s.CustomerService = NewCustomerService(s.repositories.CustomerRepository, s.repositories.CustomerCreditRepository)
 	s.CompanyService = NewCompanyService(s.repositories.CompanyRepository, s.repositories.AddressRepository, s.repositories.InventoryRepository, s.repositories.UserRepository, s.ports.Encrypto, s.useCases.EmailUseCase, s.repositories.LegalDocumentRepository, s.repositories.LegalAcceptanceRepository, s.repositories)
 	s.DashboardService = NewDashboardService(s.repositories.DashboardRepository, s.repositories.UserRepository)
 	s.NewsService = NewNewsService(s.repositories.NewsRepository)
+	s.ReceivableService = NewReceivableService(s.repositories.ReceivableRepository, s.repositories.LateFeeRuleRepository)
+	s.LateFeeService = NewLateFeeService(s.repositories.LateFeeRuleRepository)
+	s.DiscountService = NewDiscountService(s.repositories.DiscountRuleRepository)
+	s.QuoteService = NewQuoteService(s.useCases.SalesUsecase, s.repositories.QuoteRepository)
+	s.CommissionService = NewCommissionService(s.repositories.CommissionRepository, s.repositories.UserRepository, s.repositories.ProductRepository, s.repositories.CategoryRepository, s.repositories)
+	s.ConsignmentSettlementService = NewConsignmentSettlementService(s.repositories.ConsignmentSettlementRepository, s.repositories.UserRepository, s.repositories.InventoryRepository, s.useCases.InventoryUseCase, s.repositories)
+	s.DocumentService = NewDocumentService(s.SalesService, s.repositories.CompanyRepository, s.repositories.DocumentTemplateRepository, s.ports.PdfPort)
+	s.PixService = NewPixService(s.SalesService, s.repositories.SalesRepository, s.repositories.PixRepository, s.ports.QrCodePort)
+	s.CardFeeService = NewCardFeeService(s.repositories.CardFeeRuleRepository)
+	s.CashRegisterService = NewCashRegisterService(s.repositories.CashRegisterRepository, s.repositories.UserRepository)
+	s.IdempotencyService = NewIdempotencyService(s.repositories.IdempotencyKeyRepository)
+	s.StockLevelService = NewStockLevelService(s.repositories.StockLevelRepository, s.repositories.InventoryRepository, s.repositories.SkuRepository)
+	s.StockCountService = NewStockCountService(s.repositories.StockCountRepository, s.repositories.InventoryRepository, s.repositories.InventoryItemRepository, s.repositories.SkuRepository, s.useCases.InventoryUseCase, s.repositories)
+	s.SupplierService = NewSupplierService(s.repositories.SupplierRepository)
+	s.PurchaseOrderService = NewPurchaseOrderService(s.repositories.PurchaseOrderRepository, s.repositories.SupplierRepository, s.repositories.SkuRepository, s.repositories.InventoryRepository, s.useCases.InventoryUseCase, s.repositories)
 }
@@ -12,7 +12,7 @@ import (
 func TestNewApplicationService(t *testing.T) {
 	repos := &repository.Repository{}
 	useCases := &usecase.ApplicationUseCase{}
-	service := NewApplicationService(repos, useCases, ports.NewPorts(&stubEncrypto{}, &stubEmailPort{}))
+	service := NewApplicationService(repos, useCases, ports.NewPorts(&stubEncrypto{}, &stubEmailPort{}, &stubPdfPort{}, &stubQrCodePort{}))
 	if service == nil {
 		t.Fatalf("expected service to be created")
 	}
@@ -30,10 +30,10 @@ func TestApplicationServiceSetup(t *testing.T) {
 		InventoryTransactionRepository: &stubInventoryTransactionRepository{},
 	}
 	useCases := &usecase.ApplicationUseCase{
-		InventoryUseCase: inventory_usecase.NewInventoryUseCase(nil, repos.InventoryRepository, repos.InventoryItemRepository, repos.InventoryTransactionRepository, repos.SkuRepository),
+		InventoryUseCase: inventory_usecase.NewInventoryUseCase(nil, repos.InventoryRepository, repos.InventoryItemRepository, repos.InventoryTransactionRepository, repos.SkuRepository, repos.QuoteRepository),
 	}
 
-	service := NewApplicationService(repos, useCases, ports.NewPorts(&stubEncrypto{}, &stubEmailPort{}))
+	service := NewApplicationService(repos, useCases, ports.NewPorts(&stubEncrypto{}, &stubEmailPort{}, &stubPdfPort{}, &stubQrCodePort{}))
 	service.SetupServices()
 
 	if service.ProductService == nil || service.InventoryService == nil {
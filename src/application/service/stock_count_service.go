@@ -0,0 +1,216 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	request "github.com/bncunha/erp-api/src/api/requests"
+	"github.com/bncunha/erp-api/src/application/constants"
+	"github.com/bncunha/erp-api/src/application/service/output"
+	"github.com/bncunha/erp-api/src/application/usecase/inventory_usecase"
+	"github.com/bncunha/erp-api/src/domain"
+)
+
+type StockCountService interface {
+	Open(ctx context.Context, request request.OpenStockCountRequest) (int64, error)
+	GetAll(ctx context.Context, request request.ListStockCountsRequest) ([]output.GetStockCountsOutput, error)
+	GetById(ctx context.Context, id int64) (output.GetStockCountOutput, error)
+	Count(ctx context.Context, id int64, request request.CountStockRequest) (output.GetStockCountOutput, error)
+	Post(ctx context.Context, id int64) error
+	Cancel(ctx context.Context, id int64) error
+}
+
+type stockCountService struct {
+	stockCountRepository    domain.StockCountRepository
+	inventoryRepository     domain.InventoryRepository
+	inventoryItemRepository domain.InventoryItemRepository
+	skuRepository           domain.SkuRepository
+	inventoryUseCase        inventory_usecase.InventoryUseCase
+	txManager               transactionManager
+}
+
+func NewStockCountService(stockCountRepository domain.StockCountRepository, inventoryRepository domain.InventoryRepository, inventoryItemRepository domain.InventoryItemRepository, skuRepository domain.SkuRepository, inventoryUseCase inventory_usecase.InventoryUseCase, txManager transactionManager) StockCountService {
+	return &stockCountService{stockCountRepository, inventoryRepository, inventoryItemRepository, skuRepository, inventoryUseCase, txManager}
+}
+
+// Open abre a contagem de um estoque guardando o saldo atual de cada item, que
+// será a referência para as diferenças.
+func (s *stockCountService) Open(ctx context.Context, request request.OpenStockCountRequest) (id int64, err error) {
+	if err = request.Validate(); err != nil {
+		return 0, err
+	}
+	openedByUserId := int64(ctx.Value(constants.USERID_KEY).(float64))
+
+	inventory, err := s.inventoryRepository.GetById(ctx, request.InventoryId)
+	if err != nil {
+		return 0, err
+	}
+	inventoryItems, err := s.inventoryItemRepository.GetByInventoryId(ctx, inventory.Id)
+	if err != nil {
+		return 0, err
+	}
+	items := make([]domain.InventoryItem, 0, len(inventoryItems))
+	for _, inventoryItem := range inventoryItems {
+		items = append(items, domain.NewInventoryItem(inventory.Id, domain.Sku{Id: inventoryItem.SkuId}, inventoryItem.Quantity))
+	}
+
+	count := domain.NewStockCount(inventory, items, time.Now())
+	count.Notes = request.Notes
+	if err = count.Validate(); err != nil {
+		return 0, err
+	}
+
+	tx, err := s.txManager.BeginTx(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	id, err = s.stockCountRepository.Create(ctx, tx, count, openedByUserId)
+	if err != nil {
+		return 0, err
+	}
+	return id, tx.Commit()
+}
+
+func (s *stockCountService) GetAll(ctx context.Context, request request.ListStockCountsRequest) ([]output.GetStockCountsOutput, error) {
+	return s.stockCountRepository.GetAll(ctx, domain.GetStockCountsInput{
+		InventoryId: request.InventoryId,
+		Status:      request.Status,
+	})
+}
+
+func (s *stockCountService) GetById(ctx context.Context, id int64) (output.GetStockCountOutput, error) {
+	return s.stockCountRepository.GetById(ctx, id)
+}
+
+// Count registra as quantidades contadas e retorna a contagem atualizada com as
+// diferenças para revisão.
+func (s *stockCountService) Count(ctx context.Context, id int64, request request.CountStockRequest) (output.GetStockCountOutput, error) {
+	if err := request.Validate(); err != nil {
+		return output.GetStockCountOutput{}, err
+	}
+	count, err := s.stockCountRepository.GetById(ctx, id)
+	if err != nil {
+		return count, err
+	}
+	if !count.IsOpen() {
+		return count, domain.ErrStockCountNotOpen
+	}
+
+	changed := make(map[int64]domain.StockCountItem)
+	order := make([]int64, 0, len(request.Items))
+	for _, requestItem := range request.Items {
+		sku, err := s.findSku(ctx, count, requestItem)
+		if err != nil {
+			return count, err
+		}
+		item, err := count.Count(sku, requestItem.Quantity, request.Accumulate)
+		if err != nil {
+			return count, err
+		}
+		if _, ok := changed[sku.Id]; !ok {
+			order = append(order, sku.Id)
+		}
+		changed[sku.Id] = item
+	}
+
+	items := make([]domain.StockCountItem, 0, len(order))
+	for _, skuId := range order {
+		items = append(items, changed[skuId])
+	}
+	if err = s.stockCountRepository.SaveItems(ctx, count.Id, items); err != nil {
+		return count, err
+	}
+	return count, nil
+}
+
+// Post encerra a contagem e lança as diferenças dos itens contados como
+// transações de ajuste no estoque. Itens não contados permanecem como estão.
+func (s *stockCountService) Post(ctx context.Context, id int64) (err error) {
+	count, err := s.stockCountRepository.GetById(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err = count.Post(time.Now()); err != nil {
+		return err
+	}
+
+	tx, err := s.txManager.BeginTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	variances := count.GetVariances()
+	skus := make([]inventory_usecase.DoAdjustmentSkusInput, 0, len(variances))
+	for _, item := range variances {
+		skus = append(skus, inventory_usecase.DoAdjustmentSkusInput{
+			SkuId:    item.Sku.Id,
+			Quantity: item.GetVariance(),
+		})
+	}
+	err = s.inventoryUseCase.DoAdjustment(ctx, tx, inventory_usecase.DoAdjustmentInput{
+		InventoryId:   count.InventoryId,
+		Skus:          skus,
+		Justification: "Ajuste da contagem " + count.Code,
+	})
+	if err != nil {
+		return err
+	}
+	if err = s.stockCountRepository.UpdateStatus(ctx, tx, count); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (s *stockCountService) Cancel(ctx context.Context, id int64) (err error) {
+	count, err := s.stockCountRepository.GetById(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err = count.Cancel(); err != nil {
+		return err
+	}
+
+	tx, err := s.txManager.BeginTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	if err = s.stockCountRepository.UpdateStatus(ctx, tx, count); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// findSku localiza o SKU informado pelo id ou pelo código de barras,
+// procurando primeiro entre os itens da contagem.
+func (s *stockCountService) findSku(ctx context.Context, count domain.StockCount, item request.CountStockItemRequest) (domain.Sku, error) {
+	if item.SkuId != 0 {
+		for _, countItem := range count.Items {
+			if countItem.Sku.Id == item.SkuId {
+				return countItem.Sku, nil
+			}
+		}
+		return s.skuRepository.GetById(ctx, item.SkuId)
+	}
+	if sku, ok := count.FindSkuByCode(item.Code); ok {
+		return sku, nil
+	}
+	return s.skuRepository.GetByCode(ctx, strings.TrimSpace(item.Code))
+}
@@ -0,0 +1,119 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	request "github.com/bncunha/erp-api/src/api/requests"
+	"github.com/bncunha/erp-api/src/application/service/output"
+	"github.com/bncunha/erp-api/src/domain"
+)
+
+func newStockCountServiceForTest(repo *stubStockCountRepository, itemRepo *stubInventoryItemRepository, skuRepo *stubSkuRepository, inventoryUseCase *stubInventoryUseCase, txManager transactionManager) StockCountService {
+	inventoryRepo := &stubInventoryRepository{getById: domain.Inventory{Id: 4, Type: domain.InventoryTypeReseller}}
+	return NewStockCountService(repo, inventoryRepo, itemRepo, skuRepo, inventoryUseCase, txManager)
+}
+
+func newOpenStockCount() domain.StockCount {
+	expected := 2.0
+	return domain.StockCount{
+		Id:          3,
+		Code:        "I-1",
+		InventoryId: 4,
+		Status:      domain.StockCountStatusOpen,
+		Items: []domain.StockCountItem{
+			{Sku: domain.Sku{Id: 1, Code: "789001"}, ExpectedQuantity: 5},
+			{Sku: domain.Sku{Id: 2, Code: "789002"}, ExpectedQuantity: 2, CountedQuantity: &expected},
+		},
+	}
+}
+
+func TestStockCountServiceOpenSnapshotsInventory(t *testing.T) {
+	tx, fakeTx, cleanup := newTestSQLTx()
+	defer cleanup()
+	repo := &stubStockCountRepository{}
+	itemRepo := &stubInventoryItemRepository{getByInventory: []output.GetInventoryItemsOutput{{SkuId: 1, Quantity: 5}, {SkuId: 2, Quantity: 0}}}
+	service := newStockCountServiceForTest(repo, itemRepo, &stubSkuRepository{}, &stubInventoryUseCase{}, &stubTxManager{tx: tx})
+
+	id, err := service.Open(newCommissionContext(domain.UserRoleAdmin, 1), request.OpenStockCountRequest{InventoryId: 4})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if id != 7 || repo.createdBy != 1 || !fakeTx.committed {
+		t.Fatalf("expected count to be persisted")
+	}
+	if repo.created.InventoryId != 4 || repo.created.Status != domain.StockCountStatusOpen || len(repo.created.Items) != 2 || repo.created.Items[0].ExpectedQuantity != 5 {
+		t.Fatalf("unexpected snapshot: %+v", repo.created)
+	}
+}
+
+func TestStockCountServiceCountByBarcode(t *testing.T) {
+	repo := &stubStockCountRepository{getById: newOpenStockCount()}
+	skuRepo := &stubSkuRepository{getByCode: domain.Sku{Id: 9, Code: "789009"}}
+	service := newStockCountServiceForTest(repo, &stubInventoryItemRepository{}, skuRepo, &stubInventoryUseCase{}, nil)
+
+	count, err := service.Count(newCommissionContext(domain.UserRoleAdmin, 1), 3, request.CountStockRequest{
+		Accumulate: true,
+		Items: []request.CountStockItemRequest{
+			{Code: "789001", Quantity: 1},
+			{Code: "789001", Quantity: 1},
+			{Code: "789009", Quantity: 1},
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(repo.savedItems) != 2 || *repo.savedItems[0].CountedQuantity != 2 || repo.savedItems[1].Sku.Id != 9 {
+		t.Fatalf("unexpected saved items: %+v", repo.savedItems)
+	}
+	if variances := count.GetVariances(); len(variances) != 2 || variances[0].GetVariance() != -3 || variances[1].GetVariance() != 1 {
+		t.Fatalf("unexpected variances: %+v", variances)
+	}
+}
+
+func TestStockCountServiceCountRequiresOpenCount(t *testing.T) {
+	count := newOpenStockCount()
+	count.Status = domain.StockCountStatusPosted
+	service := newStockCountServiceForTest(&stubStockCountRepository{getById: count}, &stubInventoryItemRepository{}, &stubSkuRepository{}, &stubInventoryUseCase{}, nil)
+
+	_, err := service.Count(newCommissionContext(domain.UserRoleAdmin, 1), 3, request.CountStockRequest{Items: []request.CountStockItemRequest{{SkuId: 1, Quantity: 1}}})
+	if err != domain.ErrStockCountNotOpen {
+		t.Fatalf("expected not open error, got %v", err)
+	}
+}
+
+func TestStockCountServicePostAdjustsVariances(t *testing.T) {
+	tx, fakeTx, cleanup := newTestSQLTx()
+	defer cleanup()
+	count := newOpenStockCount()
+	counted := 4.0
+	count.Items[0].CountedQuantity = &counted
+	repo := &stubStockCountRepository{getById: count}
+	inventoryUseCase := &stubInventoryUseCase{}
+	service := newStockCountServiceForTest(repo, &stubInventoryItemRepository{}, &stubSkuRepository{}, inventoryUseCase, &stubTxManager{tx: tx})
+
+	if err := service.Post(newCommissionContext(domain.UserRoleAdmin, 1), 3); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	input := inventoryUseCase.receivedAdjustment
+	if input.InventoryId != 4 || len(input.Skus) != 1 || input.Skus[0].SkuId != 1 || input.Skus[0].Quantity != -1 {
+		t.Fatalf("unexpected adjustment: %+v", input)
+	}
+	if repo.updated.Status != domain.StockCountStatusPosted || repo.updated.PostedAt == nil || !fakeTx.committed {
+		t.Fatalf("expected count to be posted")
+	}
+}
+
+func TestStockCountServicePostRollback(t *testing.T) {
+	tx, fakeTx, cleanup := newTestSQLTx()
+	defer cleanup()
+	repo := &stubStockCountRepository{getById: newOpenStockCount()}
+	service := newStockCountServiceForTest(repo, &stubInventoryItemRepository{}, &stubSkuRepository{}, &stubInventoryUseCase{err: errors.New("adjustment error")}, &stubTxManager{tx: tx})
+
+	if err := service.Post(newCommissionContext(domain.UserRoleAdmin, 1), 3); err == nil || err.Error() != "adjustment error" {
+		t.Fatalf("expected adjustment error, got %v", err)
+	}
+	if !fakeTx.rolledBack || repo.updated.Id != 0 {
+		t.Fatalf("expected rollback without posting the count")
+	}
+}
@@ -0,0 +1,72 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	request "github.com/bncunha/erp-api/src/api/requests"
+	"github.com/bncunha/erp-api/src/application/service/output"
+	"github.com/bncunha/erp-api/src/domain"
+)
+
+type StockLevelService interface {
+	GetLevels(ctx context.Context, request request.ListStockLevelsRequest) ([]output.GetStockLevelOutput, error)
+	SaveLevel(ctx context.Context, request request.SaveStockLevelRequest) (int64, error)
+	DeleteLevel(ctx context.Context, id int64) error
+	GetReorderSuggestions(ctx context.Context, request request.StockReorderRequest) ([]output.StockReorderSuggestionOutput, error)
+}
+
+type stockLevelService struct {
+	stockLevelRepository domain.StockLevelRepository
+	inventoryRepository  domain.InventoryRepository
+	skuRepository        domain.SkuRepository
+}
+
+func NewStockLevelService(stockLevelRepository domain.StockLevelRepository, inventoryRepository domain.InventoryRepository, skuRepository domain.SkuRepository) StockLevelService {
+	return &stockLevelService{stockLevelRepository, inventoryRepository, skuRepository}
+}
+
+func (s *stockLevelService) GetLevels(ctx context.Context, request request.ListStockLevelsRequest) ([]output.GetStockLevelOutput, error) {
+	return s.stockLevelRepository.GetAll(ctx, domain.GetStockLevelsInput{
+		InventoryId: request.InventoryId,
+		SkuId:       request.SkuId,
+	})
+}
+
+func (s *stockLevelService) SaveLevel(ctx context.Context, request request.SaveStockLevelRequest) (int64, error) {
+	if err := request.Validate(); err != nil {
+		return 0, err
+	}
+	level := domain.NewStockLevel(request.InventoryId, request.SkuId, request.MinQuantity, request.MaxQuantity)
+	if err := level.Validate(); err != nil {
+		return 0, err
+	}
+	if _, err := s.inventoryRepository.GetById(ctx, request.InventoryId); err != nil {
+		return 0, err
+	}
+	if _, err := s.skuRepository.GetById(ctx, request.SkuId); err != nil {
+		return 0, err
+	}
+	return s.stockLevelRepository.Save(ctx, level)
+}
+
+func (s *stockLevelService) DeleteLevel(ctx context.Context, id int64) error {
+	return s.stockLevelRepository.Delete(ctx, id)
+}
+
+// GetReorderSuggestions usa as vendas dos últimos dias para sugerir compras
+// para o estoque principal e transferências para os revendedores.
+func (s *stockLevelService) GetReorderSuggestions(ctx context.Context, request request.StockReorderRequest) ([]output.StockReorderSuggestionOutput, error) {
+	days := request.Days
+	if days == 0 {
+		days = domain.DefaultStockReorderDays
+	}
+	if err := domain.ValidateStockReorderDays(days); err != nil {
+		return nil, err
+	}
+	candidates, err := s.stockLevelRepository.GetReorderCandidates(ctx, time.Now().AddDate(0, 0, -days))
+	if err != nil {
+		return nil, err
+	}
+	return domain.SuggestStockReorder(candidates, days), nil
+}
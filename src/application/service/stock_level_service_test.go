@@ -0,0 +1,63 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	request "github.com/bncunha/erp-api/src/api/requests"
+	"github.com/bncunha/erp-api/src/domain"
+)
+
+func TestStockLevelServiceSaveLevel(t *testing.T) {
+	repo := &stubStockLevelRepository{}
+	service := NewStockLevelService(repo, &stubInventoryRepository{}, &stubSkuRepository{})
+
+	id, err := service.SaveLevel(context.Background(), request.SaveStockLevelRequest{InventoryId: 2, SkuId: 3, MinQuantity: 1, MaxQuantity: 4})
+	if err != nil || id != 5 {
+		t.Fatalf("unexpected result: id=%d err=%v", id, err)
+	}
+	if repo.saved.InventoryId != 2 || repo.saved.Sku.Id != 3 || repo.saved.MinQuantity != 1 || repo.saved.MaxQuantity != 4 {
+		t.Fatalf("unexpected level: %+v", repo.saved)
+	}
+
+	if _, err := service.SaveLevel(context.Background(), request.SaveStockLevelRequest{InventoryId: 2, SkuId: 3, MinQuantity: 5, MaxQuantity: 4}); err != domain.ErrStockLevelMaxInvalid {
+		t.Fatalf("expected max invalid, got %v", err)
+	}
+}
+
+func TestStockLevelServiceSaveLevelInventoryNotFound(t *testing.T) {
+	repo := &stubStockLevelRepository{}
+	service := NewStockLevelService(repo, &stubInventoryRepository{getByIdErr: domain.ErrInventoryNotFound}, &stubSkuRepository{})
+
+	if _, err := service.SaveLevel(context.Background(), request.SaveStockLevelRequest{InventoryId: 2, SkuId: 3, MaxQuantity: 4}); !errors.Is(err, domain.ErrInventoryNotFound) {
+		t.Fatalf("expected inventory not found, got %v", err)
+	}
+	if repo.saved.Sku.Id != 0 {
+		t.Fatalf("expected level not saved")
+	}
+}
+
+func TestStockLevelServiceGetReorderSuggestions(t *testing.T) {
+	level := domain.NewStockLevel(2, 1, 2, 6)
+	level.InventoryType = domain.InventoryTypeReseller
+	repo := &stubStockLevelRepository{candidates: []domain.StockReorderCandidate{{Level: level, Quantity: 1, PrimaryQuantity: 10}}}
+	service := NewStockLevelService(repo, &stubInventoryRepository{}, &stubSkuRepository{})
+
+	suggestions, err := service.GetReorderSuggestions(context.Background(), request.StockReorderRequest{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(suggestions) != 1 || suggestions[0].Source != domain.StockReorderSourceTransfer || suggestions[0].SuggestedQuantity != 5 {
+		t.Fatalf("unexpected suggestions: %+v", suggestions)
+	}
+	since := time.Since(repo.candidatesSince)
+	if since < 29*24*time.Hour || since > 31*24*time.Hour {
+		t.Fatalf("expected default period of 30 days, got %v", since)
+	}
+
+	if _, err := service.GetReorderSuggestions(context.Background(), request.StockReorderRequest{Days: 400}); err != domain.ErrStockReorderDaysInvalid {
+		t.Fatalf("expected invalid days, got %v", err)
+	}
+}
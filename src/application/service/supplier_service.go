@@ -0,0 +1,63 @@
+package service
+
+import (
+	"context"
+
+	request "github.com/bncunha/erp-api/src/api/requests"
+	"github.com/bncunha/erp-api/src/domain"
+)
+
+type SupplierService interface {
+	Create(ctx context.Context, request request.SaveSupplierRequest) (int64, error)
+	Edit(ctx context.Context, id int64, request request.SaveSupplierRequest) error
+	GetById(ctx context.Context, id int64) (domain.Supplier, error)
+	GetAll(ctx context.Context) ([]domain.Supplier, error)
+	Inactivate(ctx context.Context, id int64) error
+}
+
+type supplierService struct {
+	supplierRepository domain.SupplierRepository
+}
+
+func NewSupplierService(supplierRepository domain.SupplierRepository) SupplierService {
+	return &supplierService{supplierRepository}
+}
+
+func (s *supplierService) Create(ctx context.Context, request request.SaveSupplierRequest) (int64, error) {
+	if err := request.Validate(); err != nil {
+		return 0, err
+	}
+	return s.supplierRepository.Create(ctx, domain.Supplier{
+		Name:        request.Name,
+		Cnpj:        request.Cnpj,
+		ContactName: request.ContactName,
+		PhoneNumber: request.PhoneNumber,
+		Email:       request.Email,
+	})
+}
+
+func (s *supplierService) Edit(ctx context.Context, id int64, request request.SaveSupplierRequest) error {
+	if err := request.Validate(); err != nil {
+		return err
+	}
+	return s.supplierRepository.Edit(ctx, domain.Supplier{
+		Id:          id,
+		Name:        request.Name,
+		Cnpj:        request.Cnpj,
+		ContactName: request.ContactName,
+		PhoneNumber: request.PhoneNumber,
+		Email:       request.Email,
+	})
+}
+
+func (s *supplierService) GetById(ctx context.Context, id int64) (domain.Supplier, error) {
+	return s.supplierRepository.GetById(ctx, id)
+}
+
+func (s *supplierService) GetAll(ctx context.Context) ([]domain.Supplier, error) {
+	return s.supplierRepository.GetAll(ctx)
+}
+
+func (s *supplierService) Inactivate(ctx context.Context, id int64) error {
+	return s.supplierRepository.Inactivate(ctx, id)
+}
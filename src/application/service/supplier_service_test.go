@@ -0,0 +1,30 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	request "github.com/bncunha/erp-api/src/api/requests"
+)
+
+func TestSupplierServiceCreateSanitizesCnpj(t *testing.T) {
+	repo := &stubSupplierRepository{}
+	service := NewSupplierService(repo)
+
+	id, err := service.Create(context.Background(), request.SaveSupplierRequest{Name: "Fornecedor", Cnpj: "11.222.333/0001-81"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if id != 5 || repo.created.Cnpj != "11222333000181" {
+		t.Fatalf("unexpected supplier: %+v", repo.created)
+	}
+}
+
+func TestSupplierServiceCreateInvalidCnpj(t *testing.T) {
+	service := NewSupplierService(&stubSupplierRepository{})
+
+	_, err := service.Create(context.Background(), request.SaveSupplierRequest{Name: "Fornecedor", Cnpj: "11.222.333/0001-00"})
+	if err == nil || err.Error() != "CNPJ inválido" {
+		t.Fatalf("expected invalid cnpj, got %v", err)
+	}
+}
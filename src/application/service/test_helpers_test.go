@@ -139,6 +139,28 @@ func (s *stubEmailPort) Send(senderEmail string, senderName string, toEmail stri
 	return nil
 }
 
+type stubPdfPort struct {
+	markup string
+	err    error
+}
+
+func (s *stubPdfPort) Render(markup string) ([]byte, error) {
+	s.markup = markup
+	return []byte("%PDF"), s.err
+}
+
+type stubQrCodePort struct {
+	content string
+	size    int
+	err     error
+}
+
+func (s *stubQrCodePort) Encode(content string, size int) ([]byte, error) {
+	s.content = content
+	s.size = size
+	return []byte("PNG"), s.err
+}
+
 type stubUserTokenService struct {
 	createdInput input.CreateUserTokenInput
 	output       domain.UserToken
@@ -379,6 +401,39 @@ func (s *stubCustomerRepository) Inactivate(ctx context.Context, id int64) error
 	return s.inactivateErr
 }
 
+type stubCustomerCreditRepository struct {
+	created      []domain.CustomerCredit
+	createErr    error
+	balance      float64
+	balanceErr   error
+	movements    []domain.CustomerCredit
+	movementsErr error
+}
+
+func (s *stubCustomerCreditRepository) Create(ctx context.Context, credit domain.CustomerCredit) (int64, error) {
+	if s.createErr != nil {
+		return 0, s.createErr
+	}
+	s.created = append(s.created, credit)
+	return int64(len(s.created)), nil
+}
+
+func (s *stubCustomerCreditRepository) CreateWithTx(ctx context.Context, tx *sql.Tx, credit domain.CustomerCredit) (int64, error) {
+	return s.Create(ctx, credit)
+}
+
+func (s *stubCustomerCreditRepository) GetBalance(ctx context.Context, customerId int64) (float64, error) {
+	return s.balance, s.balanceErr
+}
+
+func (s *stubCustomerCreditRepository) GetBalanceForUpdate(ctx context.Context, tx *sql.Tx, customerId int64) (float64, error) {
+	return s.balance, s.balanceErr
+}
+
+func (s *stubCustomerCreditRepository) GetByCustomerId(ctx context.Context, customerId int64) ([]domain.CustomerCredit, error) {
+	return s.movements, s.movementsErr
+}
+
 type stubSkuRepository struct {
 	created         []domain.Sku
 	createErr       error
@@ -392,6 +447,11 @@ type stubSkuRepository struct {
 	getAllErr       error
 	inactivateErr   error
 	getAllInput     input.GetSkusInput
+	getByCode       domain.Sku
+	getByCodeErr    error
+	updatedCosts    map[int64]float64
+	updateCostErr   error
+	getByManyIds    []domain.Sku
 }
 
 func (s *stubSkuRepository) Create(ctx context.Context, sku domain.Sku, productId int64) (int64, error) {
@@ -422,12 +482,27 @@ func (s *stubSkuRepository) Update(ctx context.Context, sku domain.Sku) error {
 	return nil
 }
 
+func (s *stubSkuRepository) UpdateCost(ctx context.Context, tx *sql.Tx, skuId int64, cost float64) error {
+	if s.updateCostErr != nil {
+		return s.updateCostErr
+	}
+	if s.updatedCosts == nil {
+		s.updatedCosts = make(map[int64]float64)
+	}
+	s.updatedCosts[skuId] = cost
+	return nil
+}
+
 func (s *stubSkuRepository) GetById(ctx context.Context, id int64) (domain.Sku, error) {
 	return s.getById, s.getByIdErr
 }
 
 func (s *stubSkuRepository) GetByManyIds(ctx context.Context, ids []int64) ([]domain.Sku, error) {
-	return nil, nil
+	return s.getByManyIds, nil
+}
+
+func (s *stubSkuRepository) GetByCode(ctx context.Context, code string) (domain.Sku, error) {
+	return s.getByCode, s.getByCodeErr
 }
 
 func (s *stubSkuRepository) GetAll(ctx context.Context, in input.GetSkusInput) ([]domain.Sku, error) {
@@ -451,6 +526,8 @@ type stubUserRepository struct {
 	getAllErr         error
 	getAllInput       input.GetAllUserInput
 	inactivateErr     error
+	inactivated       int64
+	inactivatedWithTx bool
 	getByUsername     domain.User
 	getByUsernameErr  error
 	getByEmail        domain.User
@@ -506,7 +583,20 @@ func (s *stubUserRepository) GetAll(ctx context.Context, in input.GetAllUserInpu
 }
 
 func (s *stubUserRepository) Inactivate(ctx context.Context, id int64) error {
-	return s.inactivateErr
+	if s.inactivateErr != nil {
+		return s.inactivateErr
+	}
+	s.inactivated = id
+	return nil
+}
+
+func (s *stubUserRepository) InactivateWithTx(ctx context.Context, tx *sql.Tx, id int64) error {
+	if s.inactivateErr != nil {
+		return s.inactivateErr
+	}
+	s.inactivated = id
+	s.inactivatedWithTx = tx != nil
+	return nil
 }
 
 func (s *stubUserRepository) GetByUsername(ctx context.Context, username string) (domain.User, error) {
@@ -602,6 +692,10 @@ func (s *stubInventoryItemRepository) GetBySkuId(ctx context.Context, skuId int6
 	return s.getBySku, s.getBySkuErr
 }
 
+func (s *stubInventoryItemRepository) GetTotalQuantityBySkuIds(ctx context.Context, tx *sql.Tx, skuIds []int64) (map[int64]float64, error) {
+	return nil, nil
+}
+
 func (s *stubInventoryItemRepository) Create(ctx context.Context, tx *sql.Tx, inventoryItem domain.InventoryItem) (int64, error) {
 	return 0, nil
 }
@@ -668,8 +762,10 @@ func (s *stubRepository) BeginTx(ctx context.Context) (*sql.Tx, error) {
 }
 
 type stubInventoryUseCase struct {
-	receivedInput inventory_usecase.DoTransactionInput
-	err           error
+	receivedInput       inventory_usecase.DoTransactionInput
+	receivedAdjustment  inventory_usecase.DoAdjustmentInput
+	receivedTransferAll inventory_usecase.DoTransferAllInput
+	err                 error
 }
 
 func (s *stubInventoryUseCase) DoTransaction(ctx context.Context, tx *sql.Tx, input inventory_usecase.DoTransactionInput) error {
@@ -677,10 +773,26 @@ func (s *stubInventoryUseCase) DoTransaction(ctx context.Context, tx *sql.Tx, in
 	return s.err
 }
 
+func (s *stubInventoryUseCase) DoAdjustment(ctx context.Context, tx *sql.Tx, input inventory_usecase.DoAdjustmentInput) error {
+	s.receivedAdjustment = input
+	return s.err
+}
+
+func (s *stubInventoryUseCase) DoTransferAll(ctx context.Context, tx *sql.Tx, input inventory_usecase.DoTransferAllInput) error {
+	s.receivedTransferAll = input
+	return s.err
+}
+
 type stubSalesUseCase struct {
-	receivedInput sales_usecase.DoSaleInput
+	receivedInput       sales_usecase.DoSaleInput
 	receivedReturnInput sales_usecase.DoReturnInput
-	err           error
+	receivedCancelInput sales_usecase.DoCancelInput
+	receivedEditInput   sales_usecase.DoEditInput
+	receivedExchange    sales_usecase.DoExchangeInput
+	receivedReceipt     sales_usecase.DoPaymentReceiptInput
+	receivedRenegotiate sales_usecase.DoRenegotiationInput
+	receivedQuote       sales_usecase.DoQuoteInput
+	err                 error
 }
 
 func (s *stubSalesUseCase) DoSale(ctx context.Context, input sales_usecase.DoSaleInput) error {
@@ -693,10 +805,41 @@ func (s *stubSalesUseCase) DoReturn(ctx context.Context, input sales_usecase.DoR
 	return s.err
 }
 
+func (s *stubSalesUseCase) DoCancel(ctx context.Context, input sales_usecase.DoCancelInput) error {
+	s.receivedCancelInput = input
+	return s.err
+}
+
+func (s *stubSalesUseCase) DoEdit(ctx context.Context, input sales_usecase.DoEditInput) error {
+	s.receivedEditInput = input
+	return s.err
+}
+
+func (s *stubSalesUseCase) DoExchange(ctx context.Context, input sales_usecase.DoExchangeInput) error {
+	s.receivedExchange = input
+	return s.err
+}
+
+func (s *stubSalesUseCase) DoPaymentReceipt(ctx context.Context, input sales_usecase.DoPaymentReceiptInput) error {
+	s.receivedReceipt = input
+	return s.err
+}
+
+func (s *stubSalesUseCase) DoRenegotiation(ctx context.Context, input sales_usecase.DoRenegotiationInput) error {
+	s.receivedRenegotiate = input
+	return s.err
+}
+
+func (s *stubSalesUseCase) DoQuote(ctx context.Context, input sales_usecase.DoQuoteInput) error {
+	s.receivedQuote = input
+	return s.err
+}
+
 type stubSalesRepository struct {
 	getSalesInput                 input.GetSalesInput
 	getSalesOutput                []output.GetSalesItemOutput
 	getSalesErr                   error
+	getSalesTotalsOutput          output.GetSalesTotalsOutput
 	saleByIdOutput                output.GetSaleByIdOutput
 	saleByIdErr                   error
 	paymentsOutput                []output.GetSalesPaymentOutput
@@ -712,21 +855,24 @@ type stubSalesRepository struct {
 		id     int64
 		status domain.PaymentStatus
 	}
-	changePaymentStatusErr      error
-	changePaymentDateCalledWith struct {
-		id   int64
-		date *time.Time
-	}
-	changePaymentDateErr          error
+	changePaymentStatusErr        error
 	paymentDateBySaleAndPaymentId domain.SalesPaymentDates
 	paymentDateErr                error
+	versionsOutput                []domain.GetSalesVersionOutput
+	versionsErr                   error
+	itemsByVersionId              map[int64][]output.GetItemsOutput
+	receiptsOutput                []domain.PaymentReceipt
+	receiptsErr                   error
+	getReceiptsCalled             bool
+	renegotiationsOutput          []output.GetSalesRenegotiationOutput
+	renegotiationsErr             error
 }
 
 func (s *stubSalesRepository) CreateSale(ctx context.Context, tx *sql.Tx, sale domain.Sales) (int64, error) {
 	return 0, nil
 }
 
-func (s *stubSalesRepository) CreateSaleVersion(ctx context.Context, tx *sql.Tx, saleId int64, version int, date time.Time) (int64, error) {
+func (s *stubSalesRepository) CreateSaleVersion(ctx context.Context, tx *sql.Tx, saleId int64, version int, date time.Time, adjustmentAmount float64) (int64, error) {
 	return 1, nil
 }
 
@@ -750,6 +896,26 @@ func (s *stubSalesRepository) CreateSalesReturnItems(ctx context.Context, tx *sq
 	return []int64{1}, nil
 }
 
+func (s *stubSalesRepository) CreateSalesCancellation(ctx context.Context, tx *sql.Tx, saleId int64, fromSalesVersionId int64, toSalesVersionId int64, cancellation domain.SalesCancellation, createdByUserId int64) (int64, error) {
+	return 1, nil
+}
+
+func (s *stubSalesRepository) CreateSalesExchange(ctx context.Context, tx *sql.Tx, saleId int64, fromSalesVersionId int64, toSalesVersionId int64, salesReturnId int64, exchange domain.SalesExchange, createdByUserId int64) (int64, error) {
+	return 1, nil
+}
+
+func (s *stubSalesRepository) CreateSalesEdit(ctx context.Context, tx *sql.Tx, saleId int64, fromSalesVersionId int64, toSalesVersionId int64, editDate time.Time, createdByUserId int64) (int64, error) {
+	return 1, nil
+}
+
+func (s *stubSalesRepository) UpdateSaleCustomer(ctx context.Context, tx *sql.Tx, saleId int64, customerId int64) error {
+	return nil
+}
+
+func (s *stubSalesRepository) UpdateSaleDiscountAmount(ctx context.Context, tx *sql.Tx, saleId int64, discountAmount float64) error {
+	return nil
+}
+
 func (s *stubSalesRepository) UpdateSaleLastVersion(ctx context.Context, tx *sql.Tx, saleId int64, version int) error {
 	return nil
 }
@@ -771,6 +937,10 @@ func (s *stubSalesRepository) GetSales(ctx context.Context, input input.GetSales
 	return s.getSalesOutput, s.getSalesErr
 }
 
+func (s *stubSalesRepository) GetSalesTotals(ctx context.Context, input input.GetSalesInput) (output.GetSalesTotalsOutput, error) {
+	return s.getSalesTotalsOutput, nil
+}
+
 func (s *stubSalesRepository) GetSaleById(ctx context.Context, id int64) (output.GetSaleByIdOutput, error) {
 	s.getSaleByIdCalled = true
 	return s.saleByIdOutput, s.saleByIdErr
@@ -791,9 +961,16 @@ func (s *stubSalesRepository) GetPaymentsBySaleVersionId(ctx context.Context, sa
 }
 
 func (s *stubSalesRepository) GetItemsBySaleVersionId(ctx context.Context, saleVersionId int64) ([]output.GetItemsOutput, error) {
+	if s.itemsByVersionId != nil {
+		return s.itemsByVersionId[saleVersionId], s.itemsErr
+	}
 	return s.itemsOutput, s.itemsErr
 }
 
+func (s *stubSalesRepository) GetVersionsBySaleId(ctx context.Context, id int64) ([]domain.GetSalesVersionOutput, error) {
+	return s.versionsOutput, s.versionsErr
+}
+
 func (s *stubSalesRepository) GetReturnsBySaleId(ctx context.Context, id int64) ([]output.GetSalesReturnOutput, error) {
 	return s.returnsOutput, s.returnsErr
 }
@@ -806,14 +983,542 @@ func (s *stubSalesRepository) ChangePaymentStatus(ctx context.Context, id int64,
 	return id, s.changePaymentStatusErr
 }
 
-func (s *stubSalesRepository) ChangePaymentDate(ctx context.Context, id int64, date *time.Time) (int64, error) {
-	s.changePaymentDateCalledWith = struct {
-		id   int64
-		date *time.Time
-	}{id: id, date: date}
-	return id, s.changePaymentDateErr
-}
-
 func (s *stubSalesRepository) GetPaymentDatesBySaleIdAndPaymentDateId(ctx context.Context, id int64, paymentDateId int64) (domain.SalesPaymentDates, error) {
 	return s.paymentDateBySaleAndPaymentId, s.paymentDateErr
 }
+
+func (s *stubSalesRepository) CreateSalesRenegotiation(ctx context.Context, tx *sql.Tx, saleId int64, fromSalesVersionId int64, toSalesVersionId int64, renegotiation domain.SalesRenegotiation, createdByUserId int64) (int64, error) {
+	return 0, nil
+}
+
+func (s *stubSalesRepository) GetRenegotiationsBySaleId(ctx context.Context, id int64) ([]output.GetSalesRenegotiationOutput, error) {
+	return s.renegotiationsOutput, s.renegotiationsErr
+}
+
+func (s *stubSalesRepository) CreatePaymentReceipt(ctx context.Context, tx *sql.Tx, receipt domain.PaymentReceipt) (int64, error) {
+	return 0, nil
+}
+
+func (s *stubSalesRepository) SettlePaymentDate(ctx context.Context, tx *sql.Tx, paymentDateId int64, paidDate time.Time, lateFee domain.LateFee) error {
+	return nil
+}
+
+func (s *stubSalesRepository) GetPaymentReceiptsByPaymentDateId(ctx context.Context, paymentDateId int64) ([]domain.PaymentReceipt, error) {
+	s.getReceiptsCalled = true
+	return s.receiptsOutput, s.receiptsErr
+}
+
+type stubLateFeeRuleRepository struct {
+	rule    domain.LateFeeRule
+	getErr  error
+	saved   *domain.LateFeeRule
+	saveErr error
+}
+
+func (s *stubLateFeeRuleRepository) Get(ctx context.Context) (domain.LateFeeRule, error) {
+	return s.rule, s.getErr
+}
+
+func (s *stubLateFeeRuleRepository) Save(ctx context.Context, rule domain.LateFeeRule) error {
+	s.saved = &rule
+	return s.saveErr
+}
+
+type stubDiscountRuleRepository struct {
+	rule    domain.DiscountRule
+	getErr  error
+	saved   *domain.DiscountRule
+	saveErr error
+}
+
+func (s *stubDiscountRuleRepository) Get(ctx context.Context) (domain.DiscountRule, error) {
+	return s.rule, s.getErr
+}
+
+func (s *stubDiscountRuleRepository) Save(ctx context.Context, rule domain.DiscountRule) error {
+	s.saved = &rule
+	return s.saveErr
+}
+
+type stubQuoteRepository struct {
+	quote       domain.Quote
+	getByIdErr  error
+	quotes      []domain.GetQuotesOutput
+	input       domain.GetQuotesInput
+	cancelledId int64
+	cancelErr   error
+}
+
+func (s *stubQuoteRepository) Create(ctx context.Context, tx *sql.Tx, quote domain.Quote) (int64, error) {
+	return 0, nil
+}
+
+func (s *stubQuoteRepository) CreateItems(ctx context.Context, tx *sql.Tx, quoteId int64, items []domain.SalesItem) error {
+	return nil
+}
+
+func (s *stubQuoteRepository) CreatePayments(ctx context.Context, tx *sql.Tx, quoteId int64, payments []domain.QuotePayment) error {
+	return nil
+}
+
+func (s *stubQuoteRepository) GetAll(ctx context.Context, input domain.GetQuotesInput) ([]domain.GetQuotesOutput, error) {
+	s.input = input
+	return s.quotes, nil
+}
+
+func (s *stubQuoteRepository) GetById(ctx context.Context, id int64) (domain.Quote, error) {
+	return s.quote, s.getByIdErr
+}
+
+func (s *stubQuoteRepository) Cancel(ctx context.Context, id int64) error {
+	s.cancelledId = id
+	return s.cancelErr
+}
+
+func (s *stubQuoteRepository) MarkAsConverted(ctx context.Context, tx *sql.Tx, id int64, salesId int64) error {
+	return nil
+}
+
+func (s *stubQuoteRepository) GetReservedQuantitiesForUpdate(ctx context.Context, tx *sql.Tx, inventoryId int64, skuIds []int64, exceptQuoteId int64) (map[int64]float64, error) {
+	return nil, nil
+}
+
+type stubCommissionRepository struct {
+	rules           []domain.CommissionRule
+	createdRule     domain.CommissionRule
+	createRuleErr   error
+	deletedRuleId   int64
+	installments    []domain.CommissionInstallment
+	installmentsErr error
+	initialDate     time.Time
+	finalDate       time.Time
+	items           []domain.CommissionSaleItem
+	itemsSaleIds    []int64
+	statement       domain.CommissionStatement
+	statementErr    error
+	created         domain.CommissionStatement
+	createdEntries  []domain.CommissionEntry
+	createErr       error
+	paidId          int64
+}
+
+func (s *stubCommissionRepository) GetRules(ctx context.Context) ([]domain.CommissionRule, error) {
+	return s.rules, nil
+}
+
+func (s *stubCommissionRepository) CreateRule(ctx context.Context, rule domain.CommissionRule) (int64, error) {
+	s.createdRule = rule
+	return 1, s.createRuleErr
+}
+
+func (s *stubCommissionRepository) DeleteRule(ctx context.Context, id int64) error {
+	s.deletedRuleId = id
+	return nil
+}
+
+func (s *stubCommissionRepository) GetReceivedInstallments(ctx context.Context, userId int64, initialDate time.Time, finalDate time.Time) ([]domain.CommissionInstallment, error) {
+	s.initialDate = initialDate
+	s.finalDate = finalDate
+	return s.installments, s.installmentsErr
+}
+
+func (s *stubCommissionRepository) GetSalesItems(ctx context.Context, saleIds []int64) ([]domain.CommissionSaleItem, error) {
+	s.itemsSaleIds = saleIds
+	return s.items, nil
+}
+
+func (s *stubCommissionRepository) GetStatement(ctx context.Context, userId int64, month time.Time) (domain.CommissionStatement, error) {
+	return s.statement, s.statementErr
+}
+
+func (s *stubCommissionRepository) CreateStatement(ctx context.Context, tx *sql.Tx, statement domain.CommissionStatement) (int64, error) {
+	s.created = statement
+	return 9, s.createErr
+}
+
+func (s *stubCommissionRepository) CreateStatementEntries(ctx context.Context, tx *sql.Tx, statementId int64, entries []domain.CommissionEntry) error {
+	s.createdEntries = entries
+	return nil
+}
+
+func (s *stubCommissionRepository) MarkStatementAsPaid(ctx context.Context, id int64) error {
+	s.paidId = id
+	return nil
+}
+
+type stubConsignmentSettlementRepository struct {
+	items            []domain.ConsignmentSettlementItem
+	itemsErr         error
+	itemsPeriodStart *time.Time
+	lastPeriodEnd    *time.Time
+	created          domain.ConsignmentSettlement
+	createdBy        int64
+	createdItems     []domain.ConsignmentSettlementItem
+	createErr        error
+	getAllUserIds    []int64
+	getById          domain.ConsignmentSettlement
+	getByIdErr       error
+}
+
+func (s *stubConsignmentSettlementRepository) GetItems(ctx context.Context, inventoryId int64, userId int64, periodStart *time.Time, periodEnd time.Time) ([]domain.ConsignmentSettlementItem, error) {
+	s.itemsPeriodStart = periodStart
+	return s.items, s.itemsErr
+}
+
+func (s *stubConsignmentSettlementRepository) GetLastPeriodEnd(ctx context.Context, userId int64) (*time.Time, error) {
+	return s.lastPeriodEnd, nil
+}
+
+func (s *stubConsignmentSettlementRepository) Create(ctx context.Context, tx *sql.Tx, settlement domain.ConsignmentSettlement, createdByUserId int64) (int64, error) {
+	s.created = settlement
+	s.createdBy = createdByUserId
+	return 6, s.createErr
+}
+
+func (s *stubConsignmentSettlementRepository) CreateItems(ctx context.Context, tx *sql.Tx, settlementId int64, items []domain.ConsignmentSettlementItem) error {
+	s.createdItems = items
+	return nil
+}
+
+func (s *stubConsignmentSettlementRepository) GetAll(ctx context.Context, userIds []int64) ([]domain.GetConsignmentSettlementsOutput, error) {
+	s.getAllUserIds = userIds
+	return []domain.GetConsignmentSettlementsOutput{}, nil
+}
+
+func (s *stubConsignmentSettlementRepository) GetById(ctx context.Context, id int64) (domain.ConsignmentSettlement, error) {
+	return s.getById, s.getByIdErr
+}
+
+type stubReceivableRepository struct {
+	receivables      []domain.Receivable
+	getAllErr        error
+	input            domain.GetReceivablesInput
+	updated          int64
+	updateErr        error
+	hasOpen          bool
+	hasOpenErr       error
+	hasOpenForUserId int64
+}
+
+func (s *stubReceivableRepository) GetAll(ctx context.Context, input domain.GetReceivablesInput) ([]domain.Receivable, error) {
+	s.input = input
+	return s.receivables, s.getAllErr
+}
+
+func (s *stubReceivableRepository) UpdateDelayedPaymentDates(ctx context.Context) (int64, error) {
+	return s.updated, s.updateErr
+}
+
+func (s *stubReceivableRepository) HasOpenInstallmentsByUserId(ctx context.Context, userId int64) (bool, error) {
+	s.hasOpenForUserId = userId
+	return s.hasOpen, s.hasOpenErr
+}
+
+type stubDocumentTemplateRepository struct {
+	templates map[domain.DocumentTemplateType]domain.DocumentTemplate
+	saved     *domain.DocumentTemplate
+	deleted   domain.DocumentTemplateType
+}
+
+func (s *stubDocumentTemplateRepository) GetByType(ctx context.Context, templateType domain.DocumentTemplateType) (domain.DocumentTemplate, error) {
+	if template, ok := s.templates[templateType]; ok {
+		return template, nil
+	}
+	return domain.DefaultDocumentTemplate(templateType), nil
+}
+
+func (s *stubDocumentTemplateRepository) Save(ctx context.Context, template domain.DocumentTemplate) error {
+	s.saved = &template
+	return nil
+}
+
+func (s *stubDocumentTemplateRepository) Delete(ctx context.Context, templateType domain.DocumentTemplateType) error {
+	s.deleted = templateType
+	return nil
+}
+
+type stubPixRepository struct {
+	settings   domain.PixSettings
+	getErr     error
+	saved      *domain.PixSettings
+	saveErr    error
+	pending    []domain.PixPendingPayment
+	pendingErr error
+}
+
+func (s *stubPixRepository) GetSettings(ctx context.Context) (domain.PixSettings, error) {
+	return s.settings, s.getErr
+}
+
+func (s *stubPixRepository) SaveSettings(ctx context.Context, settings domain.PixSettings) error {
+	s.saved = &settings
+	return s.saveErr
+}
+
+func (s *stubPixRepository) GetPendingPayments(ctx context.Context) ([]domain.PixPendingPayment, error) {
+	return s.pending, s.pendingErr
+}
+
+type stubCardFeeRuleRepository struct {
+	rules     []domain.CardFeeRule
+	saved     *domain.CardFeeRule
+	saveErr   error
+	deletedId int64
+	deleteErr error
+}
+
+func (s *stubCardFeeRuleRepository) GetAll(ctx context.Context) ([]domain.CardFeeRule, error) {
+	return s.rules, nil
+}
+
+func (s *stubCardFeeRuleRepository) Save(ctx context.Context, rule domain.CardFeeRule) (int64, error) {
+	s.saved = &rule
+	return 1, s.saveErr
+}
+
+func (s *stubCardFeeRuleRepository) Delete(ctx context.Context, id int64) error {
+	s.deletedId = id
+	return s.deleteErr
+}
+
+type stubCashRegisterRepository struct {
+	settings     domain.CashRegisterSettings
+	open         *domain.CashRegisterSession
+	openErr      error
+	openedId     int64
+	opened       *domain.CashRegisterSession
+	byId         domain.CashRegisterSession
+	byIdErr      error
+	sessions     []domain.CashRegisterSession
+	allInput     domain.GetCashRegisterSessionsInput
+	entries      []domain.CashRegisterEntry
+	created      []domain.CashRegisterEntry
+	createErr    error
+	closed       *domain.CashRegisterSession
+	closeErr     error
+	savedSetting *domain.CashRegisterSettings
+}
+
+func (s *stubCashRegisterRepository) GetSettings(ctx context.Context) (domain.CashRegisterSettings, error) {
+	return s.settings, nil
+}
+
+func (s *stubCashRegisterRepository) SaveSettings(ctx context.Context, settings domain.CashRegisterSettings) error {
+	s.savedSetting = &settings
+	return nil
+}
+
+func (s *stubCashRegisterRepository) Open(ctx context.Context, session domain.CashRegisterSession) (int64, error) {
+	s.opened = &session
+	return s.openedId, nil
+}
+
+func (s *stubCashRegisterRepository) GetOpenByUserId(ctx context.Context, userId int64) (domain.CashRegisterSession, error) {
+	if s.openErr != nil {
+		return domain.CashRegisterSession{}, s.openErr
+	}
+	if s.open == nil {
+		return domain.CashRegisterSession{}, domain.ErrCashRegisterSessionNotOpen
+	}
+	return *s.open, nil
+}
+
+func (s *stubCashRegisterRepository) GetById(ctx context.Context, id int64) (domain.CashRegisterSession, error) {
+	return s.byId, s.byIdErr
+}
+
+func (s *stubCashRegisterRepository) GetAll(ctx context.Context, input domain.GetCashRegisterSessionsInput) ([]domain.CashRegisterSession, error) {
+	s.allInput = input
+	return s.sessions, nil
+}
+
+func (s *stubCashRegisterRepository) GetEntries(ctx context.Context, sessionId int64) ([]domain.CashRegisterEntry, error) {
+	return s.entries, nil
+}
+
+func (s *stubCashRegisterRepository) CreateEntry(ctx context.Context, tx *sql.Tx, entry domain.CashRegisterEntry) (int64, error) {
+	s.created = append(s.created, entry)
+	return int64(len(s.created)), s.createErr
+}
+
+func (s *stubCashRegisterRepository) Close(ctx context.Context, session domain.CashRegisterSession) error {
+	s.closed = &session
+	return s.closeErr
+}
+
+type stubIdempotencyKeyRepository struct {
+	stored     domain.IdempotencyKey
+	reserved   bool
+	reserveErr error
+	completed  domain.IdempotencyKey
+	released   domain.IdempotencyKey
+}
+
+func (s *stubIdempotencyKeyRepository) Reserve(ctx context.Context, key domain.IdempotencyKey) (domain.IdempotencyKey, bool, error) {
+	if s.reserved {
+		return key, true, s.reserveErr
+	}
+	return s.stored, false, s.reserveErr
+}
+
+func (s *stubIdempotencyKeyRepository) Complete(ctx context.Context, key domain.IdempotencyKey) error {
+	s.completed = key
+	return nil
+}
+
+func (s *stubIdempotencyKeyRepository) Release(ctx context.Context, key domain.IdempotencyKey) error {
+	s.released = key
+	return nil
+}
+
+type stubStockCountRepository struct {
+	created      domain.StockCount
+	createdBy    int64
+	createErr    error
+	getById      domain.StockCount
+	getByIdErr   error
+	getAll       []domain.GetStockCountsOutput
+	getAllInput  domain.GetStockCountsInput
+	savedItems   []domain.StockCountItem
+	saveItemsErr error
+	updated      domain.StockCount
+	updateErr    error
+}
+
+func (s *stubStockCountRepository) Create(ctx context.Context, tx *sql.Tx, count domain.StockCount, openedByUserId int64) (int64, error) {
+	if s.createErr != nil {
+		return 0, s.createErr
+	}
+	s.created = count
+	s.createdBy = openedByUserId
+	return 7, nil
+}
+
+func (s *stubStockCountRepository) GetById(ctx context.Context, id int64) (domain.StockCount, error) {
+	return s.getById, s.getByIdErr
+}
+
+func (s *stubStockCountRepository) GetAll(ctx context.Context, input domain.GetStockCountsInput) ([]domain.GetStockCountsOutput, error) {
+	s.getAllInput = input
+	return s.getAll, nil
+}
+
+func (s *stubStockCountRepository) SaveItems(ctx context.Context, countId int64, items []domain.StockCountItem) error {
+	s.savedItems = items
+	return s.saveItemsErr
+}
+
+func (s *stubStockCountRepository) UpdateStatus(ctx context.Context, tx *sql.Tx, count domain.StockCount) error {
+	s.updated = count
+	return s.updateErr
+}
+
+type stubStockLevelRepository struct {
+	levels          []domain.StockLevel
+	getAllInput     domain.GetStockLevelsInput
+	saved           domain.StockLevel
+	deleteErr       error
+	candidates      []domain.StockReorderCandidate
+	candidatesSince time.Time
+}
+
+func (s *stubStockLevelRepository) GetAll(ctx context.Context, input domain.GetStockLevelsInput) ([]domain.StockLevel, error) {
+	s.getAllInput = input
+	return s.levels, nil
+}
+
+func (s *stubStockLevelRepository) Save(ctx context.Context, level domain.StockLevel) (int64, error) {
+	s.saved = level
+	return 5, nil
+}
+
+func (s *stubStockLevelRepository) Delete(ctx context.Context, id int64) error {
+	return s.deleteErr
+}
+
+func (s *stubStockLevelRepository) GetReorderCandidates(ctx context.Context, salesSince time.Time) ([]domain.StockReorderCandidate, error) {
+	s.candidatesSince = salesSince
+	return s.candidates, nil
+}
+
+type stubSupplierRepository struct {
+	created       domain.Supplier
+	createErr     error
+	edited        domain.Supplier
+	getById       domain.Supplier
+	getByIdErr    error
+	getAll        []domain.Supplier
+	inactivateErr error
+}
+
+func (s *stubSupplierRepository) Create(ctx context.Context, supplier domain.Supplier) (int64, error) {
+	if s.createErr != nil {
+		return 0, s.createErr
+	}
+	s.created = supplier
+	return 5, nil
+}
+
+func (s *stubSupplierRepository) Edit(ctx context.Context, supplier domain.Supplier) error {
+	s.edited = supplier
+	return nil
+}
+
+func (s *stubSupplierRepository) GetById(ctx context.Context, id int64) (domain.Supplier, error) {
+	return s.getById, s.getByIdErr
+}
+
+func (s *stubSupplierRepository) GetAll(ctx context.Context) ([]domain.Supplier, error) {
+	return s.getAll, nil
+}
+
+func (s *stubSupplierRepository) Inactivate(ctx context.Context, id int64) error {
+	return s.inactivateErr
+}
+
+type stubPurchaseOrderRepository struct {
+	created          domain.PurchaseOrder
+	createdBy        int64
+	getById          domain.PurchaseOrder
+	getByIdErr       error
+	getAllInput      domain.GetPurchaseOrdersInput
+	receipt          domain.PurchaseReceipt
+	receivedBy       int64
+	createReceiptErr error
+	updated          domain.PurchaseOrder
+	updateErr        error
+	purchases        []domain.GetSkuPurchasesOutput
+}
+
+func (s *stubPurchaseOrderRepository) Create(ctx context.Context, tx *sql.Tx, order domain.PurchaseOrder, createdByUserId int64) (int64, error) {
+	s.created = order
+	s.createdBy = createdByUserId
+	return 8, nil
+}
+
+func (s *stubPurchaseOrderRepository) GetById(ctx context.Context, id int64) (domain.PurchaseOrder, error) {
+	return s.getById, s.getByIdErr
+}
+
+func (s *stubPurchaseOrderRepository) GetAll(ctx context.Context, input domain.GetPurchaseOrdersInput) ([]domain.GetPurchaseOrdersOutput, error) {
+	s.getAllInput = input
+	return nil, nil
+}
+
+func (s *stubPurchaseOrderRepository) CreateReceipt(ctx context.Context, tx *sql.Tx, orderId int64, receipt domain.PurchaseReceipt, receivedByUserId int64) (int64, error) {
+	if s.createReceiptErr != nil {
+		return 0, s.createReceiptErr
+	}
+	s.receipt = receipt
+	s.receivedBy = receivedByUserId
+	return 11, nil
+}
+
+func (s *stubPurchaseOrderRepository) UpdateStatus(ctx context.Context, tx *sql.Tx, order domain.PurchaseOrder) error {
+	s.updated = order
+	return s.updateErr
+}
+
+func (s *stubPurchaseOrderRepository) GetPurchasesBySkuId(ctx context.Context, skuId int64) ([]domain.GetSkuPurchasesOutput, error) {
+	return s.purchases, nil
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/bncunha/erp-api/src/application/errors"
 	"github.com/bncunha/erp-api/src/application/service/input"
 	emailusecase "github.com/bncunha/erp-api/src/application/usecase/email_usecase"
+	"github.com/bncunha/erp-api/src/application/usecase/inventory_usecase"
 	"github.com/bncunha/erp-api/src/domain"
 	"github.com/bncunha/erp-api/src/infrastructure/logs"
 )
@@ -20,25 +21,28 @@ type UserService interface {
 	GetAll(ctx context.Context, request request.GetAllUserRequest) ([]domain.User, error)
 	GetActiveLegalTerms(ctx context.Context, userId int64) ([]domain.LegalTermStatus, error)
 	AcceptLegalTerms(ctx context.Context, userId int64, terms []request.AcceptLegalTermRequest) error
-	Inactivate(ctx context.Context, id int64) error
+	Inactivate(ctx context.Context, id int64, request request.InactivateUserRequest) error
 	ResetPassword(ctx context.Context, request request.ResetPasswordRequest) error
 	ForgotPassword(ctx context.Context, request request.ForgotPasswordRequest) error
 }
 
 type userService struct {
-	userRepository      domain.UserRepository
-	inventoryRepository domain.InventoryRepository
-	encrypto            domain.Encrypto
-	userTokenService    UserTokenService
-	emailUsecase        emailusecase.EmailUseCase
-	userTokenRepository domain.UserTokenRepository
-	legalDocumentRepo   domain.LegalDocumentRepository
-	legalAcceptanceRepo domain.LegalAcceptanceRepository
-	txManager           transactionManager
+	userRepository          domain.UserRepository
+	inventoryRepository     domain.InventoryRepository
+	encrypto                domain.Encrypto
+	userTokenService        UserTokenService
+	emailUsecase            emailusecase.EmailUseCase
+	userTokenRepository     domain.UserTokenRepository
+	legalDocumentRepo       domain.LegalDocumentRepository
+	legalAcceptanceRepo     domain.LegalAcceptanceRepository
+	inventoryItemRepository domain.InventoryItemRepository
+	receivableRepository    domain.ReceivableRepository
+	inventoryUseCase        inventory_usecase.InventoryUseCase
+	txManager               transactionManager
 }
 
-func NewUserService(userRepository domain.UserRepository, inventoryRepository domain.InventoryRepository, encrypto domain.Encrypto, userTokenService UserTokenService, emailUsecase emailusecase.EmailUseCase, userTokenRepository domain.UserTokenRepository, legalDocumentRepo domain.LegalDocumentRepository, legalAcceptanceRepo domain.LegalAcceptanceRepository, txManager transactionManager) UserService {
-	return &userService{userRepository, inventoryRepository, encrypto, userTokenService, emailUsecase, userTokenRepository, legalDocumentRepo, legalAcceptanceRepo, txManager}
+func NewUserService(userRepository domain.UserRepository, inventoryRepository domain.InventoryRepository, encrypto domain.Encrypto, userTokenService UserTokenService, emailUsecase emailusecase.EmailUseCase, userTokenRepository domain.UserTokenRepository, legalDocumentRepo domain.LegalDocumentRepository, legalAcceptanceRepo domain.LegalAcceptanceRepository, inventoryItemRepository domain.InventoryItemRepository, receivableRepository domain.ReceivableRepository, inventoryUseCase inventory_usecase.InventoryUseCase, txManager transactionManager) UserService {
+	return &userService{userRepository, inventoryRepository, encrypto, userTokenService, emailUsecase, userTokenRepository, legalDocumentRepo, legalAcceptanceRepo, inventoryItemRepository, receivableRepository, inventoryUseCase, txManager}
 }
 
 func (s *userService) Create(ctx context.Context, request request.CreateUserRequest) error {
@@ -249,8 +253,90 @@ func (s *userService) AcceptLegalTerms(ctx context.Context, userId int64, terms
 	return nil
 }
 
-func (s *userService) Inactivate(ctx context.Context, id int64) error {
-	return s.userRepository.Inactivate(ctx, id)
+// Inactivate inativa o usuário, mantendo o cadastro para o histórico de vendas e
+// estoques. Revendedores com parcelas em aberto não podem ser inativados e, se
+// tiverem saldo em estoque, o saldo é transferido na mesma transação.
+func (s *userService) Inactivate(ctx context.Context, id int64, inactivateRequest request.InactivateUserRequest) (err error) {
+	user, err := s.userRepository.GetById(ctx, id)
+	if err != nil {
+		return err
+	}
+	if user.Role != string(domain.UserRoleReseller) {
+		return s.userRepository.Inactivate(ctx, id)
+	}
+
+	hasOpenInstallments, err := s.receivableRepository.HasOpenInstallmentsByUserId(ctx, id)
+	if err != nil {
+		return err
+	}
+	if hasOpenInstallments {
+		return domain.ErrUserHasOpenReceivables
+	}
+
+	var transferInput *inventory_usecase.DoTransferAllInput
+	inventory, err := s.inventoryRepository.GetByUserId(ctx, id)
+	if err != nil && !errors.Is(err, domain.ErrInventoryNotFound) {
+		return err
+	}
+	if err == nil {
+		var hasStock bool
+		hasStock, err = s.hasStock(ctx, inventory.Id)
+		if err != nil {
+			return err
+		}
+		if hasStock {
+			if !inactivateRequest.TransferInventory {
+				return domain.ErrUserHasStock
+			}
+			destinationId := inactivateRequest.InventoryDestinationId
+			if destinationId == 0 {
+				var primaryInventory domain.Inventory
+				primaryInventory, err = s.inventoryRepository.GetPrimaryInventory(ctx)
+				if err != nil {
+					return err
+				}
+				destinationId = primaryInventory.Id
+			}
+			transferInput = &inventory_usecase.DoTransferAllInput{
+				InventoryOriginId:      inventory.Id,
+				InventoryDestinationId: destinationId,
+				Justification:          "Inativação do revendedor " + user.Name,
+			}
+		}
+	}
+
+	tx, err := s.txManager.BeginTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	if transferInput != nil {
+		if err = s.inventoryUseCase.DoTransferAll(ctx, tx, *transferInput); err != nil {
+			return err
+		}
+	}
+	if err = s.userRepository.InactivateWithTx(ctx, tx, id); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (s *userService) hasStock(ctx context.Context, inventoryId int64) (bool, error) {
+	inventoryItems, err := s.inventoryItemRepository.GetByInventoryId(ctx, inventoryId)
+	if err != nil {
+		return false, err
+	}
+	for _, inventoryItem := range inventoryItems {
+		if inventoryItem.Quantity > 0 {
+			return true, nil
+		}
+	}
+	return false, nil
 }
 
 func (s *userService) ResetPassword(ctx context.Context, request request.ResetPasswordRequest) error {
@@ -203,9 +203,78 @@ func TestUserServiceInactivate(t *testing.T) {
 	userRepo := &stubUserRepository{}
 	service, _, _, _ := newUserServiceTest(userRepo, &stubInventoryRepository{})
 
-	if err := service.Inactivate(context.Background(), 1); err != nil {
+	if err := service.Inactivate(context.Background(), 1, request.InactivateUserRequest{}); err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
+	if userRepo.inactivated != 1 {
+		t.Fatalf("expected user to be inactivated")
+	}
+}
+
+func TestUserServiceInactivateResellerWithOpenReceivables(t *testing.T) {
+	userRepo := &stubUserRepository{getById: domain.User{Id: 1, Role: string(domain.UserRoleReseller)}}
+	service, _, _, _ := newUserServiceTest(userRepo, &stubInventoryRepository{})
+	receivableRepo := &stubReceivableRepository{hasOpen: true}
+	service.receivableRepository = receivableRepo
+
+	err := service.Inactivate(context.Background(), 1, request.InactivateUserRequest{TransferInventory: true})
+	if err != domain.ErrUserHasOpenReceivables || userRepo.inactivated != 0 {
+		t.Fatalf("expected open receivables error, got %v", err)
+	}
+	if receivableRepo.hasOpenForUserId != 1 {
+		t.Fatalf("expected open installments of the reseller to be checked, got %d", receivableRepo.hasOpenForUserId)
+	}
+}
+
+func TestUserServiceInactivateResellerWithStock(t *testing.T) {
+	userRepo := &stubUserRepository{getById: domain.User{Id: 1, Name: "Ana", Role: string(domain.UserRoleReseller)}}
+	inventoryRepo := &stubInventoryRepository{getByUser: domain.Inventory{Id: 5}, getById: domain.Inventory{Id: 5}, getPrimary: domain.Inventory{Id: 1}}
+	service, _, _, _ := newUserServiceTest(userRepo, inventoryRepo)
+	service.receivableRepository = &stubReceivableRepository{}
+	service.inventoryItemRepository = &stubInventoryItemRepository{getByInventory: []domain.GetInventoryItemsOutput{{SkuId: 1, Quantity: 0}, {SkuId: 2, Quantity: 3}}}
+
+	err := service.Inactivate(context.Background(), 1, request.InactivateUserRequest{})
+	if err != domain.ErrUserHasStock || userRepo.inactivated != 0 {
+		t.Fatalf("expected stock error, got %v", err)
+	}
+
+	tx, fakeTx, cleanup := newTestSQLTx()
+	defer cleanup()
+	useCase := &stubInventoryUseCase{}
+	service.inventoryUseCase = useCase
+	service.txManager = &stubTxManager{tx: tx}
+
+	if err := service.Inactivate(context.Background(), 1, request.InactivateUserRequest{TransferInventory: true}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	transfer := useCase.receivedTransferAll
+	if transfer.InventoryOriginId != 5 || transfer.InventoryDestinationId != 1 || transfer.Justification != "Inativação do revendedor Ana" {
+		t.Fatalf("unexpected transfer: %+v", transfer)
+	}
+	if !fakeTx.committed || userRepo.inactivated != 1 || !userRepo.inactivatedWithTx {
+		t.Fatalf("expected stock transferred and user inactivated in the same transaction")
+	}
+}
+
+func TestUserServiceInactivateResellerRollsBackTransferWhenInactivationFails(t *testing.T) {
+	userRepo := &stubUserRepository{getById: domain.User{Id: 1, Name: "Ana", Role: string(domain.UserRoleReseller)}, inactivateErr: errors.New("fail")}
+	inventoryRepo := &stubInventoryRepository{getByUser: domain.Inventory{Id: 5}, getPrimary: domain.Inventory{Id: 1}}
+	service, _, _, _ := newUserServiceTest(userRepo, inventoryRepo)
+	service.receivableRepository = &stubReceivableRepository{}
+	service.inventoryItemRepository = &stubInventoryItemRepository{getByInventory: []domain.GetInventoryItemsOutput{{SkuId: 2, Quantity: 3}}}
+	tx, fakeTx, cleanup := newTestSQLTx()
+	defer cleanup()
+	useCase := &stubInventoryUseCase{}
+	service.inventoryUseCase = useCase
+	service.txManager = &stubTxManager{tx: tx}
+
+	err := service.Inactivate(context.Background(), 1, request.InactivateUserRequest{TransferInventory: true})
+	if err == nil || err.Error() != "fail" {
+		t.Fatalf("expected inactivation error, got %v", err)
+	}
+	if useCase.receivedTransferAll.InventoryOriginId != 5 || fakeTx.committed || !fakeTx.rolledBack {
+		t.Fatalf("expected transfer to be rolled back")
+	}
 }
 
 func TestUserServiceCreateValidationError(t *testing.T) {
@@ -0,0 +1,101 @@
+package inventory_usecase
+
+import (
+	"context"
+	"database/sql"
+	"math"
+	"time"
+
+	"github.com/bncunha/erp-api/src/domain"
+)
+
+// DoAdjustment lança as diferenças de uma contagem de estoque como transações
+// de ajuste. Sobras entram no estoque e faltas saem dele, aplicadas sobre o
+// saldo atual de cada item.
+func (s *inventoryUseCase) DoAdjustment(ctx context.Context, tx *sql.Tx, input DoAdjustmentInput) error {
+	if len(input.Skus) == 0 {
+		return nil
+	}
+	inventory, err := s.inventoryRepository.GetById(ctx, input.InventoryId)
+	if err != nil {
+		return err
+	}
+
+	skusIds := make([]int64, 0, len(input.Skus))
+	for _, inputSku := range input.Skus {
+		skusIds = append(skusIds, inputSku.SkuId)
+	}
+	skus, err := s.skuRepository.GetByManyIds(ctx, skusIds)
+	if err != nil {
+		return err
+	}
+	if err = s.validateDuplicatedSkus(skus, skusIds); err != nil {
+		return err
+	}
+	if err = s.validateExistsSkus(skus, skusIds); err != nil {
+		return err
+	}
+
+	var skusIn, skusOut []domain.Sku
+	for _, sku := range skus {
+		for _, inputSku := range input.Skus {
+			if sku.Id != inputSku.SkuId {
+				continue
+			}
+			sku.Quantity = math.Abs(inputSku.Quantity)
+			if inputSku.Quantity > 0 {
+				skusIn = append(skusIn, sku)
+			} else if inputSku.Quantity < 0 {
+				skusOut = append(skusOut, sku)
+			}
+		}
+	}
+
+	inventoryItems, err := s.inventoryItemRepository.GetByManySkuIdsAndInventoryId(ctx, skusIds, inventory.Id)
+	if err != nil {
+		return err
+	}
+	inventoryItems, err = s.createInventoryItemInIfNotExists(ctx, tx, skusIn, inventoryItems, domain.InventoryTransactionTypeIn, inventory)
+	if err != nil {
+		return err
+	}
+	if err = s.validateExistingInventoryItemOut(inventoryItems, skusOut); err != nil {
+		return err
+	}
+	if err = s.validateIInventotyItemOutQuantities(inventoryItems, skusOut); err != nil {
+		return err
+	}
+
+	for _, sku := range skusIn {
+		if err = s.createAdjustmentTransaction(ctx, tx, inventoryItems, domain.Inventory{}, inventory, sku, input.Justification); err != nil {
+			return err
+		}
+	}
+	for _, sku := range skusOut {
+		if err = s.createAdjustmentTransaction(ctx, tx, inventoryItems, inventory, domain.Inventory{}, sku, input.Justification); err != nil {
+			return err
+		}
+	}
+	if err = s.addQuantity(ctx, tx, inventoryItems, skusIn); err != nil {
+		return err
+	}
+	return s.subQuantity(ctx, tx, inventoryItems, skusOut)
+}
+
+func (s *inventoryUseCase) createAdjustmentTransaction(ctx context.Context, tx *sql.Tx, inventoryItems []domain.InventoryItem, inventoryOut domain.Inventory, inventoryIn domain.Inventory, sku domain.Sku, justification string) error {
+	inventoryItem := s.findInventoryItem(inventoryItems, sku.Id)
+	if inventoryItem == nil {
+		return ErrInventoryItemNotFound
+	}
+	_, err := s.inventoryTransactionRepo.Create(ctx, tx, domain.InventoryTransaction{
+		Quantity:      sku.Quantity,
+		Date:          time.Now(),
+		InventoryOut:  inventoryOut,
+		InventoryIn:   inventoryIn,
+		InventoryItem: *inventoryItem,
+		Justification: justification,
+		Type:          domain.InventoryTransactionTypeAdjustment,
+		UnitCost:      sku.Cost,
+	})
+	return err
+}
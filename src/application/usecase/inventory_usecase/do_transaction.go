@@ -45,10 +45,14 @@ func (s *inventoryUseCase) DoTransaction(ctx context.Context, tx *sql.Tx, input
 		return err
 	}
 
+	entryCosts := make(map[int64]float64)
 	for i, sku := range skus {
 		for _, inputSku := range input.Skus {
 			if sku.Id == inputSku.SkuId {
 				skus[i].Quantity = inputSku.Quantity
+				if input.Type == domain.InventoryTransactionTypeIn && inputSku.UnitCost != nil {
+					entryCosts[sku.Id] = *inputSku.UnitCost
+				}
 			}
 		}
 	}
@@ -68,12 +72,24 @@ func (s *inventoryUseCase) DoTransaction(ctx context.Context, tx *sql.Tx, input
 		return err
 	}
 
+	if input.Type == domain.InventoryTransactionTypeTransfer || input.Type == domain.InventoryTransactionTypeOut {
+		err = s.validateReservedQuantities(ctx, tx, inventoryOut, inventoryItemOut, skus, input.ExceptQuoteId)
+		if err != nil {
+			return err
+		}
+	}
+
 	inventoryItemIn, err = s.createInventoryItemInIfNotExists(ctx, tx, skus, inventoryItemIn, domain.InventoryTransactionType(input.Type), inventoryIn)
 	if err != nil {
 		return err
 	}
 
-	err = s.createTransactions(ctx, tx, inventoryItemOut, inventoryItemIn, inventoryOut, inventoryIn, skus, input.Type, input.Justification, input.Sale)
+	err = s.applyEntryCosts(ctx, tx, skus, entryCosts)
+	if err != nil {
+		return err
+	}
+
+	err = s.createTransactions(ctx, tx, inventoryItemOut, inventoryItemIn, inventoryOut, inventoryIn, skus, entryCosts, input.Type, input.Justification, input.Sale)
 	if err != nil {
 		return err
 	}
@@ -116,11 +132,46 @@ func (s *inventoryUseCase) findInventoryItem(inventoryItems []domain.InventoryIt
 	return nil
 }
 
-func (s *inventoryUseCase) createTransactions(ctx context.Context, tx *sql.Tx, inventoryItemsOut []domain.InventoryItem, inventoryItemsIn []domain.InventoryItem, inventoryOut domain.Inventory, inventoryIn domain.Inventory, inputSkus []domain.Sku, transactionType domain.InventoryTransactionType, justification string, sale domain.Sales) error {
+// applyEntryCosts recalcula o custo médio ponderado dos SKUs que entram com
+// custo unitário. Usa o saldo de todos os estoques antes da entrada, por isso
+// precisa ser chamado antes de as quantidades serem somadas.
+func (s *inventoryUseCase) applyEntryCosts(ctx context.Context, tx *sql.Tx, skus []domain.Sku, entryCosts map[int64]float64) error {
+	if len(entryCosts) == 0 {
+		return nil
+	}
+	skuIds := make([]int64, 0, len(entryCosts))
+	for skuId := range entryCosts {
+		skuIds = append(skuIds, skuId)
+	}
+	stockQuantities, err := s.inventoryItemRepository.GetTotalQuantityBySkuIds(ctx, tx, skuIds)
+	if err != nil {
+		return err
+	}
+	for i := range skus {
+		unitCost, ok := entryCosts[skus[i].Id]
+		if !ok {
+			continue
+		}
+		cost := skus[i].ApplyEntryCost(stockQuantities[skus[i].Id], skus[i].Quantity, unitCost)
+		if err := s.skuRepository.UpdateCost(ctx, tx, skus[i].Id, cost); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// createTransactions grava uma transação por SKU com o custo unitário da
+// movimentação: o custo pago nas entradas com custo e o custo médio vigente
+// nas demais.
+func (s *inventoryUseCase) createTransactions(ctx context.Context, tx *sql.Tx, inventoryItemsOut []domain.InventoryItem, inventoryItemsIn []domain.InventoryItem, inventoryOut domain.Inventory, inventoryIn domain.Inventory, inputSkus []domain.Sku, entryCosts map[int64]float64, transactionType domain.InventoryTransactionType, justification string, sale domain.Sales) error {
 	for _, inputSku := range inputSkus {
 		findedInventoryItemOut := s.findInventoryItem(inventoryItemsOut, inputSku.Id)
 		findedInventoryItemIn := s.findInventoryItem(inventoryItemsIn, inputSku.Id)
 
+		unitCost := inputSku.Cost
+		if entryCost, ok := entryCosts[inputSku.Id]; ok {
+			unitCost = &entryCost
+		}
 		transaction := domain.InventoryTransaction{
 			Quantity:      inputSku.Quantity,
 			Date:          time.Now(),
@@ -129,6 +180,7 @@ func (s *inventoryUseCase) createTransactions(ctx context.Context, tx *sql.Tx, i
 			Justification: justification,
 			Type:          transactionType,
 			Sale:          sale,
+			UnitCost:      unitCost,
 		}
 		if transactionType == domain.InventoryTransactionTypeTransfer || transactionType == domain.InventoryTransactionTypeOut {
 			transaction.InventoryItem = *findedInventoryItemOut
@@ -182,6 +234,26 @@ func (s *inventoryUseCase) validateInventoryTransaction(inventoryIn domain.Inven
 	return nil
 }
 
+// validateReservedQuantities impede que a saída use o saldo reservado por
+// orçamentos em aberto no estoque de origem.
+func (s *inventoryUseCase) validateReservedQuantities(ctx context.Context, tx *sql.Tx, inventoryOut domain.Inventory, inventoryItemsOut []domain.InventoryItem, skusInput []domain.Sku, exceptQuoteId int64) error {
+	skuIds := make([]int64, len(skusInput))
+	for i, sku := range skusInput {
+		skuIds[i] = sku.Id
+	}
+	reserved, err := s.quoteRepository.GetReservedQuantitiesForUpdate(ctx, tx, inventoryOut.Id, skuIds, exceptQuoteId)
+	if err != nil {
+		return err
+	}
+	for _, sku := range skusInput {
+		inventoryItem := s.findInventoryItem(inventoryItemsOut, sku.Id)
+		if inventoryItem != nil && sku.Quantity > inventoryItem.Quantity-reserved[sku.Id] {
+			return errors.New(ErrQuantityReserved.Error() + fmt.Sprintf(": (%d) %s", sku.Id, sku.GetName()))
+		}
+	}
+	return nil
+}
+
 func (s *inventoryUseCase) validateExistingInventoryItemOut(inventoryItemOut []domain.InventoryItem, skusInput []domain.Sku) error {
 	notExistingSkus := make([]string, 0)
 	for _, sku := range skusInput {
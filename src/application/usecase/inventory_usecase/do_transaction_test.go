@@ -24,6 +24,7 @@ type stubInventoryItemRepository struct {
 	updateErr             error
 	getManyErr            error
 	items                 map[int64][]domain.InventoryItem
+	totalQuantities       map[int64]float64
 }
 
 func (s *stubInventoryItemRepository) Create(ctx context.Context, tx *sql.Tx, inventoryItem domain.InventoryItem) (int64, error) {
@@ -78,6 +79,10 @@ func (s *stubInventoryItemRepository) GetBySkuId(ctx context.Context, skuId int6
 	return nil, nil
 }
 
+func (s *stubInventoryItemRepository) GetTotalQuantityBySkuIds(ctx context.Context, tx *sql.Tx, skuIds []int64) (map[int64]float64, error) {
+	return s.totalQuantities, nil
+}
+
 type stubInventoryTransactionRepository struct {
 	created []domain.InventoryTransaction
 	err     error
@@ -105,7 +110,7 @@ func (s *stubInventoryTransactionRepository) GetBySkuId(ctx context.Context, sku
 
 func TestNewInventoryUseCase(t *testing.T) {
 	repo := &repository.Repository{}
-	uc := NewInventoryUseCase(repo, nil, nil, nil, nil)
+	uc := NewInventoryUseCase(repo, nil, nil, nil, nil, nil)
 	if uc == nil {
 		t.Fatalf("expected inventory use case instance")
 	}
@@ -327,7 +332,7 @@ func TestCreateTransactions(t *testing.T) {
 	inItems := []domain.InventoryItem{{Sku: domain.Sku{Id: 1}, Id: 2}}
 	skus := []domain.Sku{{Id: 1, Quantity: 2}}
 
-	if err := uc.createTransactions(context.Background(), tx, outItems, inItems, domain.Inventory{}, domain.Inventory{}, skus, domain.InventoryTransactionTypeIn, "just", domain.Sales{}); err != nil {
+	if err := uc.createTransactions(context.Background(), tx, outItems, inItems, domain.Inventory{}, domain.Inventory{}, skus, nil, domain.InventoryTransactionTypeIn, "just", domain.Sales{}); err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
 	if len(repo.created) != 1 {
@@ -427,6 +432,7 @@ type doTxInventoryItemRepository struct {
 	updatedItems   []domain.InventoryItem
 	nextID         int64
 	lastCreatedFor map[int64]domain.InventoryItem
+	totals         map[int64]float64
 }
 
 func newDoTxInventoryItemRepository() *doTxInventoryItemRepository {
@@ -464,13 +470,21 @@ func (r *doTxInventoryItemRepository) GetAll(ctx context.Context) ([]output.GetI
 }
 
 func (r *doTxInventoryItemRepository) GetByInventoryId(ctx context.Context, id int64) ([]output.GetInventoryItemsOutput, error) {
-	return nil, nil
+	items := make([]output.GetInventoryItemsOutput, 0, len(r.items[id]))
+	for _, item := range r.items[id] {
+		items = append(items, output.GetInventoryItemsOutput{InventoryItemId: item.Id, SkuId: item.Sku.Id, Quantity: item.Quantity})
+	}
+	return items, nil
 }
 
 func (r *doTxInventoryItemRepository) GetBySkuId(ctx context.Context, skuId int64) ([]domain.GetSkuInventoryOutput, error) {
 	return nil, nil
 }
 
+func (r *doTxInventoryItemRepository) GetTotalQuantityBySkuIds(ctx context.Context, tx *sql.Tx, skuIds []int64) (map[int64]float64, error) {
+	return r.totals, nil
+}
+
 type doTxInventoryTransactionRepository struct {
 	transactions []domain.InventoryTransaction
 }
@@ -493,7 +507,8 @@ func (r *doTxInventoryTransactionRepository) GetByInventoryId(ctx context.Contex
 }
 
 type doTxSkuRepository struct {
-	skus []domain.Sku
+	skus         []domain.Sku
+	updatedCosts map[int64]float64
 }
 
 func (r *doTxSkuRepository) GetByManyIds(ctx context.Context, ids []int64) ([]domain.Sku, error) {
@@ -510,14 +525,55 @@ func (r *doTxSkuRepository) GetByProductId(ctx context.Context, productId int64)
 	return nil, nil
 }
 func (r *doTxSkuRepository) Update(ctx context.Context, sku domain.Sku) error { return nil }
+func (r *doTxSkuRepository) UpdateCost(ctx context.Context, tx *sql.Tx, skuId int64, cost float64) error {
+	if r.updatedCosts == nil {
+		r.updatedCosts = make(map[int64]float64)
+	}
+	r.updatedCosts[skuId] = cost
+	return nil
+}
 func (r *doTxSkuRepository) GetById(ctx context.Context, id int64) (domain.Sku, error) {
 	return domain.Sku{}, nil
 }
+func (r *doTxSkuRepository) GetByCode(ctx context.Context, code string) (domain.Sku, error) {
+	return domain.Sku{}, nil
+}
 func (r *doTxSkuRepository) GetAll(ctx context.Context, _ input.GetSkusInput) ([]domain.Sku, error) {
 	return nil, nil
 }
 func (r *doTxSkuRepository) Inactivate(ctx context.Context, id int64) error { return nil }
 
+type doTxQuoteRepository struct {
+	reserved      map[int64]float64
+	inventoryId   int64
+	exceptQuoteId int64
+}
+
+func (r *doTxQuoteRepository) Create(ctx context.Context, tx *sql.Tx, quote domain.Quote) (int64, error) {
+	return 0, nil
+}
+func (r *doTxQuoteRepository) CreateItems(ctx context.Context, tx *sql.Tx, quoteId int64, items []domain.SalesItem) error {
+	return nil
+}
+func (r *doTxQuoteRepository) CreatePayments(ctx context.Context, tx *sql.Tx, quoteId int64, payments []domain.QuotePayment) error {
+	return nil
+}
+func (r *doTxQuoteRepository) GetAll(ctx context.Context, input domain.GetQuotesInput) ([]domain.GetQuotesOutput, error) {
+	return nil, nil
+}
+func (r *doTxQuoteRepository) GetById(ctx context.Context, id int64) (domain.Quote, error) {
+	return domain.Quote{}, nil
+}
+func (r *doTxQuoteRepository) Cancel(ctx context.Context, id int64) error { return nil }
+func (r *doTxQuoteRepository) MarkAsConverted(ctx context.Context, tx *sql.Tx, id int64, salesId int64) error {
+	return nil
+}
+func (r *doTxQuoteRepository) GetReservedQuantitiesForUpdate(ctx context.Context, tx *sql.Tx, inventoryId int64, skuIds []int64, exceptQuoteId int64) (map[int64]float64, error) {
+	r.inventoryId = inventoryId
+	r.exceptQuoteId = exceptQuoteId
+	return r.reserved, nil
+}
+
 var fakeDriverCounter int64
 
 func newFakeDB(tx *fakeTx) *sql.DB {
@@ -545,6 +601,7 @@ func TestInventoryUseCaseDoTransaction(t *testing.T) {
 		inventoryItemRepository:  itemRepo,
 		inventoryTransactionRepo: txRepo,
 		skuRepository:            skuRepo,
+		quoteRepository:          &doTxQuoteRepository{},
 	}
 
 	err := uc.DoTransaction(context.Background(), tx, DoTransactionInput{
@@ -603,7 +660,7 @@ func TestInventoryUseCaseDoTransactionTypeIn(t *testing.T) {
 	txRepo := &doTxInventoryTransactionRepository{}
 	skuRepo := &doTxSkuRepository{skus: []domain.Sku{{Id: 1, Code: "A", Product: domain.Product{Name: "Prod"}}}}
 
-	uc := &inventoryUseCase{repository: repos, inventoryRepository: inventoryRepo, inventoryItemRepository: itemRepo, inventoryTransactionRepo: txRepo, skuRepository: skuRepo}
+	uc := &inventoryUseCase{repository: repos, inventoryRepository: inventoryRepo, inventoryItemRepository: itemRepo, inventoryTransactionRepo: txRepo, skuRepository: skuRepo, quoteRepository: &doTxQuoteRepository{}}
 
 	err := uc.DoTransaction(context.Background(), tx, DoTransactionInput{Type: domain.InventoryTransactionTypeIn, InventoryDestinationId: 2, Skus: []DoTransactionSkusInput{{SkuId: 1, Quantity: 3}}})
 	if err != nil {
@@ -614,6 +671,33 @@ func TestInventoryUseCaseDoTransactionTypeIn(t *testing.T) {
 	}
 }
 
+func TestInventoryUseCaseDoTransactionTypeInUpdatesAverageCost(t *testing.T) {
+	fakeTx := &fakeTx{}
+	db := newFakeDB(fakeTx)
+	repos := repository.NewRepository(db)
+	tx, _ := db.BeginTx(context.Background(), nil)
+	inventoryRepo := &doTxInventoryRepository{inventories: map[int64]domain.Inventory{2: {Id: 2}}}
+	itemRepo := newDoTxInventoryItemRepository()
+	itemRepo.totals = map[int64]float64{1: 10}
+	txRepo := &doTxInventoryTransactionRepository{}
+	cost := 10.0
+	skuRepo := &doTxSkuRepository{skus: []domain.Sku{{Id: 1, Code: "A", Cost: &cost, Product: domain.Product{Name: "Prod"}}}}
+
+	uc := &inventoryUseCase{repository: repos, inventoryRepository: inventoryRepo, inventoryItemRepository: itemRepo, inventoryTransactionRepo: txRepo, skuRepository: skuRepo, quoteRepository: &doTxQuoteRepository{}}
+
+	entryCost := 16.0
+	err := uc.DoTransaction(context.Background(), tx, DoTransactionInput{Type: domain.InventoryTransactionTypeIn, InventoryDestinationId: 2, Skus: []DoTransactionSkusInput{{SkuId: 1, Quantity: 5, UnitCost: &entryCost}}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if skuRepo.updatedCosts[1] != 12 {
+		t.Fatalf("expected average cost 12, got %+v", skuRepo.updatedCosts)
+	}
+	if len(txRepo.transactions) != 1 || txRepo.transactions[0].UnitCost == nil || *txRepo.transactions[0].UnitCost != 16 {
+		t.Fatalf("expected transaction with entry cost, got %+v", txRepo.transactions)
+	}
+}
+
 func TestInventoryUseCaseDoTransactionTypeOut(t *testing.T) {
 	fakeTx := &fakeTx{}
 	db := newFakeDB(fakeTx)
@@ -625,7 +709,7 @@ func TestInventoryUseCaseDoTransactionTypeOut(t *testing.T) {
 	txRepo := &doTxInventoryTransactionRepository{}
 	skuRepo := &doTxSkuRepository{skus: []domain.Sku{{Id: 1, Code: "A", Product: domain.Product{Name: "Prod"}}}}
 
-	uc := &inventoryUseCase{repository: repos, inventoryRepository: inventoryRepo, inventoryItemRepository: itemRepo, inventoryTransactionRepo: txRepo, skuRepository: skuRepo}
+	uc := &inventoryUseCase{repository: repos, inventoryRepository: inventoryRepo, inventoryItemRepository: itemRepo, inventoryTransactionRepo: txRepo, skuRepository: skuRepo, quoteRepository: &doTxQuoteRepository{}}
 
 	err := uc.DoTransaction(context.Background(), tx, DoTransactionInput{Type: domain.InventoryTransactionTypeOut, InventoryOriginId: 1, Skus: []DoTransactionSkusInput{{SkuId: 1, Quantity: 2}}})
 	if err != nil {
@@ -636,6 +720,90 @@ func TestInventoryUseCaseDoTransactionTypeOut(t *testing.T) {
 	}
 }
 
+func TestInventoryUseCaseDoTransactionRespectsStockReservations(t *testing.T) {
+	fakeTx := &fakeTx{}
+	db := newFakeDB(fakeTx)
+	repos := repository.NewRepository(db)
+	tx, _ := db.BeginTx(context.Background(), nil)
+	inventoryRepo := &doTxInventoryRepository{inventories: map[int64]domain.Inventory{1: {Id: 1}}}
+	itemRepo := newDoTxInventoryItemRepository()
+	itemRepo.items[1] = []domain.InventoryItem{{Id: 1, InventoryId: 1, Sku: domain.Sku{Id: 1}, Quantity: 5}}
+	txRepo := &doTxInventoryTransactionRepository{}
+	skuRepo := &doTxSkuRepository{skus: []domain.Sku{{Id: 1, Code: "A", Product: domain.Product{Name: "Prod"}}}}
+	quoteRepo := &doTxQuoteRepository{reserved: map[int64]float64{1: 4}}
+
+	uc := &inventoryUseCase{repository: repos, inventoryRepository: inventoryRepo, inventoryItemRepository: itemRepo, inventoryTransactionRepo: txRepo, skuRepository: skuRepo, quoteRepository: quoteRepo}
+
+	err := uc.DoTransaction(context.Background(), tx, DoTransactionInput{Type: domain.InventoryTransactionTypeOut, InventoryOriginId: 1, ExceptQuoteId: 7, Skus: []DoTransactionSkusInput{{SkuId: 1, Quantity: 2}}})
+	if err == nil || !strings.HasPrefix(err.Error(), ErrQuantityReserved.Error()) {
+		t.Fatalf("expected reserved quantity error, got %v", err)
+	}
+	if quoteRepo.inventoryId != 1 || quoteRepo.exceptQuoteId != 7 {
+		t.Fatalf("unexpected reservation lookup: %+v", quoteRepo)
+	}
+	if len(itemRepo.updatedItems) != 0 || len(txRepo.transactions) != 0 {
+		t.Fatalf("expected no stock movement")
+	}
+
+	err = uc.DoTransaction(context.Background(), tx, DoTransactionInput{Type: domain.InventoryTransactionTypeOut, InventoryOriginId: 1, Skus: []DoTransactionSkusInput{{SkuId: 1, Quantity: 1}}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestInventoryUseCaseDoAdjustment(t *testing.T) {
+	fakeTx := &fakeTx{}
+	db := newFakeDB(fakeTx)
+	repos := repository.NewRepository(db)
+	tx, _ := db.BeginTx(context.Background(), nil)
+	inventoryRepo := &doTxInventoryRepository{inventories: map[int64]domain.Inventory{1: {Id: 1}}}
+	itemRepo := newDoTxInventoryItemRepository()
+	itemRepo.items[1] = []domain.InventoryItem{{Id: 1, InventoryId: 1, Sku: domain.Sku{Id: 1}, Quantity: 5}}
+	txRepo := &doTxInventoryTransactionRepository{}
+	skuRepo := &doTxSkuRepository{skus: []domain.Sku{{Id: 1, Code: "A"}, {Id: 2, Code: "B"}}}
+
+	uc := &inventoryUseCase{repository: repos, inventoryRepository: inventoryRepo, inventoryItemRepository: itemRepo, inventoryTransactionRepo: txRepo, skuRepository: skuRepo, quoteRepository: &doTxQuoteRepository{}}
+
+	err := uc.DoAdjustment(context.Background(), tx, DoAdjustmentInput{
+		InventoryId:   1,
+		Justification: "Contagem",
+		Skus:          []DoAdjustmentSkusInput{{SkuId: 1, Quantity: -2}, {SkuId: 2, Quantity: 3}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(itemRepo.createdItems) != 1 || len(itemRepo.updatedItems) != 2 {
+		t.Fatalf("expected missing item created and both items updated")
+	}
+	if len(txRepo.transactions) != 2 {
+		t.Fatalf("expected two adjustment transactions")
+	}
+	for _, transaction := range txRepo.transactions {
+		if transaction.Type != domain.InventoryTransactionTypeAdjustment || transaction.Quantity <= 0 {
+			t.Fatalf("unexpected transaction: %+v", transaction)
+		}
+	}
+	if txRepo.transactions[0].InventoryIn.Id != 1 || txRepo.transactions[1].InventoryOut.Id != 1 {
+		t.Fatalf("expected surplus in and shortage out of inventory")
+	}
+	if itemRepo.updatedItems[1].Quantity != 3 {
+		t.Fatalf("expected shortage subtracted from current quantity, got %v", itemRepo.updatedItems[1].Quantity)
+	}
+}
+
+func TestInventoryUseCaseDoAdjustmentQuantityInsufficient(t *testing.T) {
+	inventoryRepo := &doTxInventoryRepository{inventories: map[int64]domain.Inventory{1: {Id: 1}}}
+	itemRepo := newDoTxInventoryItemRepository()
+	itemRepo.items[1] = []domain.InventoryItem{{Id: 1, InventoryId: 1, Sku: domain.Sku{Id: 1}, Quantity: 1}}
+	skuRepo := &doTxSkuRepository{skus: []domain.Sku{{Id: 1, Code: "A"}}}
+	uc := &inventoryUseCase{inventoryRepository: inventoryRepo, inventoryItemRepository: itemRepo, inventoryTransactionRepo: &doTxInventoryTransactionRepository{}, skuRepository: skuRepo}
+
+	err := uc.DoAdjustment(context.Background(), nil, DoAdjustmentInput{InventoryId: 1, Skus: []DoAdjustmentSkusInput{{SkuId: 1, Quantity: -2}}})
+	if err == nil || !strings.Contains(err.Error(), ErrQuantityInsufficient.Error()) {
+		t.Fatalf("expected insufficient quantity error, got %v", err)
+	}
+}
+
 func TestInventoryUseCaseDoTransactionInventoryNotFound(t *testing.T) {
 	inventoryRepo := &doTxInventoryRepository{inventories: map[int64]domain.Inventory{}}
 	skuRepo := &doTxSkuRepository{skus: []domain.Sku{{Id: 1, Code: "A", Product: domain.Product{Name: "Prod"}}}}
@@ -655,7 +823,7 @@ func TestInventoryUseCaseDoTransactionCreateInventoryItemError(t *testing.T) {
 	itemRepo := &stubInventoryItemRepository{createErr: errors.New("fail"), items: make(map[int64][]domain.InventoryItem)}
 	txRepo := &doTxInventoryTransactionRepository{}
 	skuRepo := &doTxSkuRepository{skus: []domain.Sku{{Id: 1, Code: "A", Product: domain.Product{Name: "Prod"}}}}
-	uc := &inventoryUseCase{repository: repos, inventoryRepository: inventoryRepo, inventoryItemRepository: itemRepo, inventoryTransactionRepo: txRepo, skuRepository: skuRepo}
+	uc := &inventoryUseCase{repository: repos, inventoryRepository: inventoryRepo, inventoryItemRepository: itemRepo, inventoryTransactionRepo: txRepo, skuRepository: skuRepo, quoteRepository: &doTxQuoteRepository{}}
 
 	err := uc.DoTransaction(context.Background(), tx, DoTransactionInput{Type: domain.InventoryTransactionTypeIn, InventoryDestinationId: 2, Skus: []DoTransactionSkusInput{{SkuId: 1, Quantity: 1}}})
 	if err == nil || err.Error() != "fail" {
@@ -673,7 +841,7 @@ func TestInventoryUseCaseDoTransactionTransactionError(t *testing.T) {
 	itemRepo.items[1] = []domain.InventoryItem{{Id: 1, InventoryId: 1, Sku: domain.Sku{Id: 1}, Quantity: 5}}
 	txRepo := &stubInventoryTransactionRepository{err: errors.New("fail")}
 	skuRepo := &doTxSkuRepository{skus: []domain.Sku{{Id: 1, Code: "A", Product: domain.Product{Name: "Prod"}}}}
-	uc := &inventoryUseCase{repository: repos, inventoryRepository: inventoryRepo, inventoryItemRepository: itemRepo, inventoryTransactionRepo: txRepo, skuRepository: skuRepo}
+	uc := &inventoryUseCase{repository: repos, inventoryRepository: inventoryRepo, inventoryItemRepository: itemRepo, inventoryTransactionRepo: txRepo, skuRepository: skuRepo, quoteRepository: &doTxQuoteRepository{}}
 
 	err := uc.DoTransaction(context.Background(), tx, DoTransactionInput{Type: domain.InventoryTransactionTypeTransfer, InventoryOriginId: 1, InventoryDestinationId: 2, Skus: []DoTransactionSkusInput{{SkuId: 1, Quantity: 1}}})
 	if err == nil || err.Error() != "fail" {
@@ -690,10 +858,74 @@ func TestInventoryUseCaseDoTransactionUpdateQuantityError(t *testing.T) {
 	itemRepo := &stubInventoryItemRepository{updateErr: errors.New("fail"), items: map[int64][]domain.InventoryItem{1: {{Id: 1, InventoryId: 1, Sku: domain.Sku{Id: 1}, Quantity: 5}}, 2: {{Id: 2, InventoryId: 2, Sku: domain.Sku{Id: 1}, Quantity: 0}}}, returnedInventoryItem: domain.InventoryItem{Id: 2, InventoryId: 2, Sku: domain.Sku{Id: 1}, Quantity: 0}}
 	txRepo := &doTxInventoryTransactionRepository{}
 	skuRepo := &doTxSkuRepository{skus: []domain.Sku{{Id: 1, Code: "A", Product: domain.Product{Name: "Prod"}}}}
-	uc := &inventoryUseCase{repository: repos, inventoryRepository: inventoryRepo, inventoryItemRepository: itemRepo, inventoryTransactionRepo: txRepo, skuRepository: skuRepo}
+	uc := &inventoryUseCase{repository: repos, inventoryRepository: inventoryRepo, inventoryItemRepository: itemRepo, inventoryTransactionRepo: txRepo, skuRepository: skuRepo, quoteRepository: &doTxQuoteRepository{}}
 
 	err := uc.DoTransaction(context.Background(), tx, DoTransactionInput{Type: domain.InventoryTransactionTypeTransfer, InventoryOriginId: 1, InventoryDestinationId: 2, Skus: []DoTransactionSkusInput{{SkuId: 1, Quantity: 1}}})
 	if err == nil || err.Error() != "fail" {
 		t.Fatalf("expected update quantity error")
 	}
 }
+
+func TestInventoryUseCaseDoTransferAll(t *testing.T) {
+	fakeTx := &fakeTx{}
+	db := newFakeDB(fakeTx)
+	repos := repository.NewRepository(db)
+	tx, _ := db.BeginTx(context.Background(), nil)
+
+	inventoryRepo := &doTxInventoryRepository{inventories: map[int64]domain.Inventory{1: {Id: 1}, 2: {Id: 2}}}
+	itemRepo := newDoTxInventoryItemRepository()
+	itemRepo.items[1] = []domain.InventoryItem{
+		{Id: 1, InventoryId: 1, Sku: domain.Sku{Id: 1}, Quantity: 5},
+		{Id: 2, InventoryId: 1, Sku: domain.Sku{Id: 2}, Quantity: 0},
+	}
+	txRepo := &doTxInventoryTransactionRepository{}
+	skuRepo := &doTxSkuRepository{skus: []domain.Sku{{Id: 1, Code: "A", Product: domain.Product{Name: "Prod"}}}}
+	uc := &inventoryUseCase{repository: repos, inventoryRepository: inventoryRepo, inventoryItemRepository: itemRepo, inventoryTransactionRepo: txRepo, skuRepository: skuRepo, quoteRepository: &doTxQuoteRepository{}}
+
+	err := uc.DoTransferAll(context.Background(), tx, DoTransferAllInput{InventoryOriginId: 1, InventoryDestinationId: 2, Justification: "saida"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(txRepo.transactions) != 1 || txRepo.transactions[0].Type != domain.InventoryTransactionTypeTransfer || txRepo.transactions[0].Quantity != 5 {
+		t.Fatalf("expected single transfer of non-zero items, got %+v", txRepo.transactions)
+	}
+}
+
+func TestInventoryUseCaseDoTransferAllRespectsStockReservations(t *testing.T) {
+	fakeTx := &fakeTx{}
+	db := newFakeDB(fakeTx)
+	repos := repository.NewRepository(db)
+	tx, _ := db.BeginTx(context.Background(), nil)
+
+	inventoryRepo := &doTxInventoryRepository{inventories: map[int64]domain.Inventory{1: {Id: 1}, 2: {Id: 2}}}
+	itemRepo := newDoTxInventoryItemRepository()
+	itemRepo.items[1] = []domain.InventoryItem{{Id: 1, InventoryId: 1, Sku: domain.Sku{Id: 1}, Quantity: 5}}
+	txRepo := &doTxInventoryTransactionRepository{}
+	skuRepo := &doTxSkuRepository{skus: []domain.Sku{{Id: 1, Code: "A", Product: domain.Product{Name: "Prod"}}}}
+	uc := &inventoryUseCase{repository: repos, inventoryRepository: inventoryRepo, inventoryItemRepository: itemRepo, inventoryTransactionRepo: txRepo, skuRepository: skuRepo, quoteRepository: &doTxQuoteRepository{reserved: map[int64]float64{1: 1}}}
+
+	err := uc.DoTransferAll(context.Background(), tx, DoTransferAllInput{InventoryOriginId: 1, InventoryDestinationId: 2, Justification: "saida"})
+	if err == nil || !strings.HasPrefix(err.Error(), ErrQuantityReserved.Error()) {
+		t.Fatalf("expected reserved quantity error, got %v", err)
+	}
+	if len(txRepo.transactions) != 0 {
+		t.Fatalf("expected no transfer, got %+v", txRepo.transactions)
+	}
+}
+
+func TestInventoryUseCaseDoTransferAllWithoutStock(t *testing.T) {
+	itemRepo := newDoTxInventoryItemRepository()
+	itemRepo.items[1] = []domain.InventoryItem{{Id: 1, InventoryId: 1, Sku: domain.Sku{Id: 1}, Quantity: 0}}
+	txRepo := &doTxInventoryTransactionRepository{}
+	uc := &inventoryUseCase{inventoryItemRepository: itemRepo, inventoryTransactionRepo: txRepo}
+
+	if err := uc.DoTransferAll(context.Background(), nil, DoTransferAllInput{InventoryOriginId: 1, InventoryDestinationId: 2}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(txRepo.transactions) != 0 {
+		t.Fatalf("expected no transaction without stock")
+	}
+	if err := uc.DoTransferAll(context.Background(), nil, DoTransferAllInput{InventoryOriginId: 1, InventoryDestinationId: 1}); err != ErrInventoryesTransferEquais {
+		t.Fatalf("expected same inventory error, got %v", err)
+	}
+}
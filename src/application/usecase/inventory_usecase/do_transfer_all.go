@@ -0,0 +1,43 @@
+package inventory_usecase
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/bncunha/erp-api/src/domain"
+)
+
+// DoTransferAll transfere todo o saldo de um estoque para outro em uma única
+// transação de transferência. Itens zerados são ignorados e, sem saldo algum,
+// nada é lançado.
+func (s *inventoryUseCase) DoTransferAll(ctx context.Context, tx *sql.Tx, input DoTransferAllInput) error {
+	if input.InventoryOriginId == input.InventoryDestinationId {
+		return ErrInventoryesTransferEquais
+	}
+	inventoryItems, err := s.inventoryItemRepository.GetByInventoryId(ctx, input.InventoryOriginId)
+	if err != nil {
+		return err
+	}
+
+	skus := make([]DoTransactionSkusInput, 0, len(inventoryItems))
+	for _, inventoryItem := range inventoryItems {
+		if inventoryItem.Quantity <= 0 {
+			continue
+		}
+		skus = append(skus, DoTransactionSkusInput{
+			SkuId:    inventoryItem.SkuId,
+			Quantity: inventoryItem.Quantity,
+		})
+	}
+	if len(skus) == 0 {
+		return nil
+	}
+
+	return s.DoTransaction(ctx, tx, DoTransactionInput{
+		Type:                   domain.InventoryTransactionTypeTransfer,
+		InventoryOriginId:      input.InventoryOriginId,
+		InventoryDestinationId: input.InventoryDestinationId,
+		Justification:          input.Justification,
+		Skus:                   skus,
+	})
+}
@@ -2,6 +2,9 @@ package inventory_usecase
 
 import "github.com/bncunha/erp-api/src/domain"
 
+// DoTransactionInput descreve a movimentação. Saídas e transferências não
+// podem usar o saldo reservado por orçamentos, exceto o de ExceptQuoteId, o
+// orçamento que está sendo convertido em venda.
 type DoTransactionInput struct {
 	Type                   domain.InventoryTransactionType
 	Skus                   []DoTransactionSkusInput
@@ -9,9 +12,32 @@ type DoTransactionInput struct {
 	InventoryDestinationId int64
 	Justification          string
 	Sale                   domain.Sales
+	ExceptQuoteId          int64
 }
 
+// DoTransactionSkusInput traz a quantidade movimentada de cada SKU. Em
+// entradas, UnitCost é o custo pago por unidade e recalcula o custo médio.
 type DoTransactionSkusInput struct {
 	SkuId    int64
 	Quantity float64
+	UnitCost *float64
+}
+
+// DoAdjustmentSkusInput traz a diferença a ajustar de cada SKU: positiva para
+// entrada e negativa para saída.
+type DoAdjustmentSkusInput struct {
+	SkuId    int64
+	Quantity float64
+}
+
+type DoAdjustmentInput struct {
+	InventoryId   int64
+	Skus          []DoAdjustmentSkusInput
+	Justification string
+}
+
+type DoTransferAllInput struct {
+	InventoryOriginId      int64
+	InventoryDestinationId int64
+	Justification          string
 }
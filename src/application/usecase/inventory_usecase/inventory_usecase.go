@@ -13,6 +13,7 @@ var (
 	ErrEnventoryItemDestinationNotFound = errors.New("Item de estoque não encontrado no destino")
 	ErrInventoryItemOriginNotFound      = errors.New("Item de estoque não encontrado na origem")
 	ErrQuantityInsufficient             = errors.New("Quantidade insuficiente")
+	ErrQuantityReserved                 = errors.New("Quantidade reservada em orçamentos em aberto")
 	ErrInventoryesTransferEquais        = errors.New("Inventários de origem e de destino precisam ser diferentes")
 	ErrInventoryItemNotFound            = errors.New("Item de estoque não encontrado")
 	ErrSkusNotFound                     = errors.New("SKUs não encontrados")
@@ -21,6 +22,8 @@ var (
 
 type InventoryUseCase interface {
 	DoTransaction(ctx context.Context, tx *sql.Tx, input DoTransactionInput) error
+	DoAdjustment(ctx context.Context, tx *sql.Tx, input DoAdjustmentInput) error
+	DoTransferAll(ctx context.Context, tx *sql.Tx, input DoTransferAllInput) error
 }
 
 type inventoryUseCase struct {
@@ -29,8 +32,9 @@ type inventoryUseCase struct {
 	inventoryItemRepository  domain.InventoryItemRepository
 	inventoryTransactionRepo domain.InventoryTransactionRepository
 	skuRepository            domain.SkuRepository
+	quoteRepository          domain.QuoteRepository
 }
 
-func NewInventoryUseCase(repository *repository.Repository, inventoryRepository domain.InventoryRepository, inventoryItemRepository domain.InventoryItemRepository, inventoryTransactionRepo domain.InventoryTransactionRepository, skuRepository domain.SkuRepository) InventoryUseCase {
-	return &inventoryUseCase{repository, inventoryRepository, inventoryItemRepository, inventoryTransactionRepo, skuRepository}
+func NewInventoryUseCase(repository *repository.Repository, inventoryRepository domain.InventoryRepository, inventoryItemRepository domain.InventoryItemRepository, inventoryTransactionRepo domain.InventoryTransactionRepository, skuRepository domain.SkuRepository, quoteRepository domain.QuoteRepository) InventoryUseCase {
+	return &inventoryUseCase{repository, inventoryRepository, inventoryItemRepository, inventoryTransactionRepo, skuRepository, quoteRepository}
 }
@@ -0,0 +1,127 @@
+package sales_usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/bncunha/erp-api/src/application/usecase/inventory_usecase"
+	"github.com/bncunha/erp-api/src/domain"
+)
+
+func (s *salesUseCase) DoCancel(ctx context.Context, input DoCancelInput) (err error) {
+	user, err := s.userRepository.GetById(ctx, input.UserId)
+	if err != nil {
+		return err
+	}
+
+	tx, err := s.repository.BeginTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	sale, err := s.saleRepository.GetSaleByIdForUpdate(ctx, tx, input.SaleId)
+	if err != nil {
+		return err
+	}
+	if user.Role != string(domain.UserRoleAdmin) && sale.UserId != user.Id {
+		return domain.ErrSaleEditNotAllowed
+	}
+
+	currentItems, err := s.saleRepository.GetItemsBySaleVersionId(ctx, sale.SalesVersionId)
+	if err != nil {
+		return err
+	}
+
+	saleDomainItems := make([]domain.SalesItem, 0, len(currentItems))
+	for _, item := range currentItems {
+		saleDomainItems = append(saleDomainItems, domain.SalesItem{
+			Sku:       item.Sku,
+			Quantity:  item.Quantity,
+			UnitPrice: item.UnitPrice,
+		})
+	}
+
+	cancellation := domain.NewSalesCancellation(input.Reason)
+	if err = cancellation.Validate(saleDomainItems); err != nil {
+		return err
+	}
+
+	seller, err := s.userRepository.GetById(ctx, sale.UserId)
+	if err != nil {
+		return err
+	}
+	sellerInventory, err := s.getSellerInventory(ctx, seller)
+	if err != nil {
+		return err
+	}
+
+	nextVersion := sale.LastVersion + 1
+	nextSaleVersionId, err := s.saleRepository.CreateSaleVersion(ctx, tx, sale.Id, nextVersion, cancellation.CancelDate, 0)
+	if err != nil {
+		return err
+	}
+	saleToCreate := domain.Sales{
+		Id:             sale.Id,
+		SalesVersionId: nextSaleVersionId,
+	}
+
+	oldPayments, err := s.saleRepository.GetPaymentsBySaleVersionId(ctx, sale.SalesVersionId)
+	if err != nil {
+		return err
+	}
+	oldPayments = splitPartialReceipts(oldPayments)
+	newPayments := s.recalculatePayments(oldPayments, nil, 0)
+	for _, payment := range newPayments {
+		payment.Id, err = s.saleRepository.CreatePayment(ctx, tx, saleToCreate, payment)
+		if err != nil {
+			return err
+		}
+		if _, err = s.saleRepository.CreateManyPaymentDates(ctx, tx, payment, payment.Dates); err != nil {
+			return err
+		}
+	}
+
+	if err = s.creditRefund(ctx, tx, sale, nextSaleVersionId, refundedValue(oldPayments, newPayments), user.Id); err != nil {
+		return err
+	}
+
+	if _, err = s.saleRepository.CreateSalesCancellation(ctx, tx, sale.Id, sale.SalesVersionId, nextSaleVersionId, cancellation, user.Id); err != nil {
+		return err
+	}
+	if err = s.saleRepository.CancelPaymentDatesBySaleVersionId(ctx, tx, sale.SalesVersionId); err != nil {
+		return err
+	}
+	if err = s.saleRepository.UpdateSaleDiscountAmount(ctx, tx, sale.Id, saleToCreate.GetSaleDiscountAmount()); err != nil {
+		return err
+	}
+	if err = s.saleRepository.UpdateSaleLastVersion(ctx, tx, sale.Id, nextVersion); err != nil {
+		return err
+	}
+
+	stockItems := make([]inventory_usecase.DoTransactionSkusInput, 0, len(saleDomainItems))
+	for _, item := range saleDomainItems {
+		stockItems = append(stockItems, inventory_usecase.DoTransactionSkusInput{
+			SkuId:    item.Sku.Id,
+			Quantity: item.Quantity,
+		})
+	}
+
+	err = s.inventoryUseCase.DoTransaction(ctx, tx, inventory_usecase.DoTransactionInput{
+		Type:                   domain.InventoryTransactionTypeIn,
+		InventoryDestinationId: sellerInventory.Id,
+		Skus:                   stockItems,
+		Sale:                   saleToCreate,
+		Justification:          fmt.Sprintf("Venda cancelada no dia %s por %s", time.Now().Format("02/01/2006"), user.Name),
+	})
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
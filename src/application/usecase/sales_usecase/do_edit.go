@@ -0,0 +1,326 @@
+package sales_usecase
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/bncunha/erp-api/src/application/errors"
+	"github.com/bncunha/erp-api/src/application/usecase/inventory_usecase"
+	"github.com/bncunha/erp-api/src/domain"
+)
+
+func (s *salesUseCase) DoEdit(ctx context.Context, input DoEditInput) (err error) {
+	user, err := s.userRepository.GetById(ctx, input.UserId)
+	if err != nil {
+		return err
+	}
+
+	customer, err := s.customerRepository.GetById(ctx, input.CustomerId)
+	if err != nil {
+		return err
+	}
+
+	skusIds := s.detachIds(input.Items)
+	skus, err := s.skuRepository.GetByManyIds(ctx, skusIds)
+	if err != nil {
+		return err
+	}
+	if err = s.validateDuplicatedSkus(skus, skusIds); err != nil {
+		return err
+	}
+
+	tx, err := s.repository.BeginTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	sale, err := s.saleRepository.GetSaleByIdForUpdate(ctx, tx, input.SaleId)
+	if err != nil {
+		return err
+	}
+	if user.Role != string(domain.UserRoleAdmin) && sale.UserId != user.Id {
+		return domain.ErrSaleEditNotAllowed
+	}
+
+	currentItems, err := s.saleRepository.GetItemsBySaleVersionId(ctx, sale.SalesVersionId)
+	if err != nil {
+		return err
+	}
+	if len(currentItems) == 0 {
+		return domain.ErrSaleAlreadyCancelled
+	}
+
+	seller, err := s.userRepository.GetById(ctx, sale.UserId)
+	if err != nil {
+		return err
+	}
+	sellerInventory, err := s.getSellerInventory(ctx, seller)
+	if err != nil {
+		return err
+	}
+
+	inventoryItems, err := s.inventoryItemRepository.GetByManySkuIdsAndInventoryId(ctx, skusIds, sellerInventory.Id)
+	if err != nil {
+		return err
+	}
+	items, err := s.buildEditedItems(currentItems, inventoryItems, input.Items)
+	if err != nil {
+		return err
+	}
+
+	oldPayments, err := s.saleRepository.GetPaymentsBySaleVersionId(ctx, sale.SalesVersionId)
+	if err != nil {
+		return err
+	}
+	oldPayments = splitPartialReceipts(oldPayments)
+
+	payments := s.createPayments(input.Payments)
+	if err = s.applyCardFees(ctx, payments, time.Now()); err != nil {
+		return err
+	}
+
+	editedSale := domain.Sales{
+		Id:       sale.Id,
+		Code:     sale.Code,
+		Date:     sale.Date,
+		User:     seller,
+		Customer: customer,
+		Items:    items,
+		Payments: payments,
+	}
+	editedSale.AdjustmentAmount = carriedAdjustment(sale.AdjustmentAmount, editedSale.Items)
+	if err = editedSale.ValidateEdit(settledTotal(oldPayments)); err != nil {
+		return err
+	}
+
+	editDate := time.Now()
+	if customer.Id != sale.CustomerId {
+		if err = s.saleRepository.UpdateSaleCustomer(ctx, tx, sale.Id, customer.Id); err != nil {
+			return err
+		}
+	}
+
+	nextVersion := sale.LastVersion + 1
+	editedSale.SalesVersionId, err = s.saleRepository.CreateSaleVersion(ctx, tx, sale.Id, nextVersion, editDate, editedSale.AdjustmentAmount)
+	if err != nil {
+		return err
+	}
+
+	if _, err = s.saleRepository.CreateManySaleItem(ctx, tx, editedSale, editedSale.Items); err != nil {
+		return err
+	}
+
+	newPayments := s.buildEditedPayments(oldPayments, editedSale.Payments, editedSale.GetTotal())
+	for _, payment := range newPayments {
+		payment.Id, err = s.saleRepository.CreatePayment(ctx, tx, editedSale, payment)
+		if err != nil {
+			return err
+		}
+		if _, err = s.saleRepository.CreateManyPaymentDates(ctx, tx, payment, payment.Dates); err != nil {
+			return err
+		}
+	}
+	if err = s.creditRefund(ctx, tx, sale, editedSale.SalesVersionId, refundedValue(oldPayments, newPayments), user.Id); err != nil {
+		return err
+	}
+	if err = s.debitCredit(ctx, tx, customer.Id, sale, editedSale.SalesVersionId, editedSale.GetCustomerCreditTotal(), user.Id); err != nil {
+		return err
+	}
+
+	if _, err = s.saleRepository.CreateSalesEdit(ctx, tx, sale.Id, sale.SalesVersionId, editedSale.SalesVersionId, editDate, user.Id); err != nil {
+		return err
+	}
+	if err = s.saleRepository.CancelPaymentDatesBySaleVersionId(ctx, tx, sale.SalesVersionId); err != nil {
+		return err
+	}
+	if err = s.saleRepository.UpdateSaleDiscountAmount(ctx, tx, sale.Id, editedSale.GetSaleDiscountAmount()); err != nil {
+		return err
+	}
+	if err = s.saleRepository.UpdateSaleLastVersion(ctx, tx, sale.Id, nextVersion); err != nil {
+		return err
+	}
+
+	stockIn, stockOut := s.buildInventoryDeltas(currentItems, editedSale.Items)
+	transactionSale := domain.Sales{
+		Id:             sale.Id,
+		SalesVersionId: editedSale.SalesVersionId,
+	}
+	justification := fmt.Sprintf("Venda editada no dia %s por %s", editDate.Format("02/01/2006"), user.Name)
+	if len(stockIn) > 0 {
+		err = s.inventoryUseCase.DoTransaction(ctx, tx, inventory_usecase.DoTransactionInput{
+			Type:                   domain.InventoryTransactionTypeIn,
+			InventoryDestinationId: sellerInventory.Id,
+			Skus:                   stockIn,
+			Sale:                   transactionSale,
+			Justification:          justification,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	if len(stockOut) > 0 {
+		err = s.inventoryUseCase.DoTransaction(ctx, tx, inventory_usecase.DoTransactionInput{
+			Type:              domain.InventoryTransactionTypeOut,
+			InventoryOriginId: sellerInventory.Id,
+			Skus:              stockOut,
+			Sale:              transactionSale,
+			Justification:     justification,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (s *salesUseCase) buildEditedItems(current []domain.GetItemsOutput, inventoryItems []domain.InventoryItem, itemsInput []DoSaleItemsInput) ([]domain.SalesItem, error) {
+	currentBySku := make(map[int64]domain.GetItemsOutput)
+	for _, item := range current {
+		currentBySku[item.Sku.Id] = item
+	}
+	inventoryBySku := make(map[int64]domain.InventoryItem)
+	for _, item := range inventoryItems {
+		inventoryBySku[item.Sku.Id] = item
+	}
+
+	items := make([]domain.SalesItem, 0, len(itemsInput))
+	for _, input := range itemsInput {
+		inventoryItem, inInventory := inventoryBySku[input.SkuId]
+		currentItem, inSale := currentBySku[input.SkuId]
+		if !inSale && !inInventory {
+			return nil, errors.New(ErrSkusNotFound.Error() + fmt.Sprintf(": %v", input.SkuId))
+		}
+
+		// Itens que já estavam na venda mantêm o preço e o desconto por unidade
+		// originais e podem usar a quantidade vendida anteriormente, que volta
+		// para o estoque.
+		if inSale {
+			item := currentItem.ToSalesItem().WithQuantity(input.Quantity)
+			item.Sku.Quantity = inventoryItem.Quantity + currentItem.Quantity
+			items = append(items, item)
+			continue
+		}
+		item := domain.NewSalesItem(inventoryItem.Sku, input.Quantity)
+		item.UnitPrice = inventoryItem.Sku.Price
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+func (s *salesUseCase) buildEditedPayments(old []domain.GetSalesPaymentOutput, planned []domain.SalesPayment, newTotal float64) []domain.SalesPayment {
+	carried := make([]domain.SalesPaymentDates, 0, len(old))
+	for _, p := range old {
+		if p.PaymentStatus == domain.PaymentStatusPaid || p.PaymentStatus == domain.PaymentStatusReversal {
+			carried = append(carried, toSalesPaymentDates(p, p.PaymentStatus))
+		}
+	}
+
+	paidTotal := settledTotal(old)
+	newTotal = round2(newTotal)
+	if paidTotal > newTotal {
+		now := time.Now()
+		d := domain.NewSalesPaymentDates(now, &now, 1, -round2(paidTotal-newTotal), domain.PaymentStatusReversal)
+		d.PaymentType = domain.PaymentTypeReturn
+		carried = append(carried, d)
+	}
+	return s.mergePayments(carried, planned)
+}
+
+func (s *salesUseCase) mergePayments(carried []domain.SalesPaymentDates, planned []domain.SalesPayment) []domain.SalesPayment {
+	paymentsMap := make(map[domain.PaymentType]*domain.SalesPayment)
+	ensurePayment := func(t domain.PaymentType) *domain.SalesPayment {
+		if p, ok := paymentsMap[t]; ok {
+			return p
+		}
+		p := domain.NewSalesPayment(t)
+		paymentsMap[t] = &p
+		return &p
+	}
+
+	for _, date := range carried {
+		payment := ensurePayment(date.PaymentType)
+		if payment.CardBrand == "" {
+			payment.CardBrand = date.CardBrand
+		}
+		payment.Dates = append(payment.Dates, date)
+	}
+
+	for _, p := range planned {
+		payment := ensurePayment(p.PaymentType)
+		payment.ReceivedAmount += p.ReceivedAmount
+		payment.ChangeAmount += p.ChangeAmount
+		if payment.CardBrand == "" {
+			payment.CardBrand = p.CardBrand
+		}
+		offset := len(payment.Dates)
+		for _, date := range p.Dates {
+			date.InstallmentNumber += offset
+			payment.Dates = append(payment.Dates, date)
+		}
+	}
+
+	payments := make([]domain.SalesPayment, 0, len(paymentsMap))
+	for _, payment := range paymentsMap {
+		payments = append(payments, *payment)
+	}
+	sort.Slice(payments, func(i, j int) bool {
+		return payments[i].PaymentType < payments[j].PaymentType
+	})
+	return payments
+}
+
+func (s *salesUseCase) buildInventoryDeltas(current []domain.GetItemsOutput, edited []domain.SalesItem) (stockIn []inventory_usecase.DoTransactionSkusInput, stockOut []inventory_usecase.DoTransactionSkusInput) {
+	deltas := make(map[int64]float64)
+	skuIds := make([]int64, 0, len(current)+len(edited))
+	for _, item := range current {
+		if _, ok := deltas[item.Sku.Id]; !ok {
+			skuIds = append(skuIds, item.Sku.Id)
+		}
+		deltas[item.Sku.Id] -= item.Quantity
+	}
+	for _, item := range edited {
+		if _, ok := deltas[item.Sku.Id]; !ok {
+			skuIds = append(skuIds, item.Sku.Id)
+		}
+		deltas[item.Sku.Id] += item.Quantity
+	}
+
+	for _, skuId := range skuIds {
+		delta := deltas[skuId]
+		if delta > 0 {
+			stockOut = append(stockOut, inventory_usecase.DoTransactionSkusInput{SkuId: skuId, Quantity: delta})
+		} else if delta < 0 {
+			stockIn = append(stockIn, inventory_usecase.DoTransactionSkusInput{SkuId: skuId, Quantity: -delta})
+		}
+	}
+	return stockIn, stockOut
+}
+
+func toSalesPaymentDates(p domain.GetSalesPaymentOutput, status domain.PaymentStatus) domain.SalesPaymentDates {
+	d := domain.NewSalesPaymentDates(p.DueDate, p.PaidDate, int(p.InstallmentNumber), p.InstallmentValue, status)
+	d.PaymentType = p.PaymentType
+	d.FineValue = p.FineValue
+	d.InterestValue = p.InterestValue
+	d.CardBrand = p.CardBrand
+	d.CardFeeValue = p.CardFeeValue
+	d.SettlementDate = p.SettlementDate
+	return d
+}
+
+func settledTotal(payments []domain.GetSalesPaymentOutput) float64 {
+	total := 0.0
+	for _, payment := range payments {
+		if payment.PaymentStatus == domain.PaymentStatusPaid || payment.PaymentStatus == domain.PaymentStatusReversal {
+			total += payment.InstallmentValue
+		}
+	}
+	return round2(total)
+}
@@ -0,0 +1,231 @@
+package sales_usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/bncunha/erp-api/src/application/usecase/inventory_usecase"
+	"github.com/bncunha/erp-api/src/domain"
+)
+
+func (s *salesUseCase) DoExchange(ctx context.Context, input DoExchangeInput) (err error) {
+	user, err := s.userRepository.GetById(ctx, input.UserId)
+	if err != nil {
+		return err
+	}
+
+	skusIds := s.detachIds(input.Items)
+	skus, err := s.skuRepository.GetByManyIds(ctx, skusIds)
+	if err != nil {
+		return err
+	}
+	if err = s.validateDuplicatedSkus(skus, skusIds); err != nil {
+		return err
+	}
+
+	tx, err := s.repository.BeginTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	sale, err := s.saleRepository.GetSaleByIdForUpdate(ctx, tx, input.SaleId)
+	if err != nil {
+		return err
+	}
+	if user.Role != string(domain.UserRoleAdmin) && sale.UserId != user.Id {
+		return domain.ErrSaleEditNotAllowed
+	}
+
+	seller, err := s.userRepository.GetById(ctx, sale.UserId)
+	if err != nil {
+		return err
+	}
+	inventory, err := s.getSellerInventory(ctx, seller)
+	if err != nil {
+		return err
+	}
+	inventoryItems, err := s.inventoryItemRepository.GetByManySkuIdsAndInventoryId(ctx, skusIds, inventory.Id)
+	if err != nil {
+		return err
+	}
+	if err = s.validateExistsInventoryItem(inventoryItems, skusIds); err != nil {
+		return err
+	}
+
+	currentItems, err := s.saleRepository.GetItemsBySaleVersionId(ctx, sale.SalesVersionId)
+	if err != nil {
+		return err
+	}
+
+	returnItems := make([]domain.SalesReturnItem, 0, len(input.ReturnedItems))
+	for _, item := range input.ReturnedItems {
+		for _, saleItem := range currentItems {
+			if saleItem.Sku.Id == item.SkuId {
+				returnItems = append(returnItems, domain.SalesReturnItem{
+					Sku:       saleItem.Sku,
+					Quantity:  item.Quantity,
+					UnitPrice: saleItem.GetNetUnitPrice(),
+				})
+				break
+			}
+		}
+	}
+
+	saleDomainItems := make([]domain.SalesItem, 0, len(currentItems))
+	for _, item := range currentItems {
+		saleDomainItems = append(saleDomainItems, item.ToSalesItem())
+	}
+
+	newItems := make([]domain.SalesItem, 0, len(input.Items))
+	for _, item := range input.Items {
+		for _, inventoryItem := range inventoryItems {
+			if inventoryItem.Sku.Id == item.SkuId {
+				newItem := domain.NewSalesItem(inventoryItem.Sku, item.Quantity)
+				newItem.UnitPrice = inventoryItem.Sku.Price
+				newItems = append(newItems, newItem)
+				break
+			}
+		}
+	}
+
+	payments := s.createPayments(input.Payments)
+	if err = s.applyCardFees(ctx, payments, time.Now()); err != nil {
+		return err
+	}
+
+	exchange := domain.NewSalesExchange(domain.NewSalesReturn(sale.CustomerName, input.Reason, returnItems), newItems, payments)
+	if err = exchange.Validate(saleDomainItems); err != nil {
+		return err
+	}
+
+	newSaleItems := append(s.buildRemainingItems(currentItems, exchange.Return.Items), exchange.Items...)
+	adjustment := carriedAdjustment(sale.AdjustmentAmount, newSaleItems)
+
+	nextVersion := sale.LastVersion + 1
+	nextSaleVersionId, err := s.saleRepository.CreateSaleVersion(ctx, tx, sale.Id, nextVersion, exchange.ExchangeDate, adjustment)
+	if err != nil {
+		return err
+	}
+
+	saleToCreate := domain.Sales{
+		Id:             sale.Id,
+		SalesVersionId: nextSaleVersionId,
+		Items:          newSaleItems,
+	}
+	if _, err = s.saleRepository.CreateManySaleItem(ctx, tx, saleToCreate, newSaleItems); err != nil {
+		return err
+	}
+
+	oldPayments, err := s.saleRepository.GetPaymentsBySaleVersionId(ctx, sale.SalesVersionId)
+	if err != nil {
+		return err
+	}
+	oldPayments = splitPartialReceipts(oldPayments)
+	var newPayments []domain.SalesPayment
+	if exchange.GetDifference() > 0 {
+		newPayments = s.buildExchangePayments(oldPayments, exchange.Payments)
+	} else {
+		newPayments = s.recalculatePayments(oldPayments, newSaleItems, adjustment)
+	}
+	for _, payment := range newPayments {
+		payment.Id, err = s.saleRepository.CreatePayment(ctx, tx, saleToCreate, payment)
+		if err != nil {
+			return err
+		}
+		if _, err = s.saleRepository.CreateManyPaymentDates(ctx, tx, payment, payment.Dates); err != nil {
+			return err
+		}
+	}
+
+	salesReturnId, err := s.saleRepository.CreateSalesReturn(ctx, tx, sale.Id, sale.SalesVersionId, nextSaleVersionId, exchange.Return, user.Id)
+	if err != nil {
+		return err
+	}
+	if _, err = s.saleRepository.CreateSalesReturnItems(ctx, tx, salesReturnId, exchange.Return.Items); err != nil {
+		return err
+	}
+	if _, err = s.saleRepository.CreateSalesExchange(ctx, tx, sale.Id, sale.SalesVersionId, nextSaleVersionId, salesReturnId, exchange, user.Id); err != nil {
+		return err
+	}
+	if err = s.creditRefund(ctx, tx, sale, nextSaleVersionId, refundedValue(oldPayments, newPayments), user.Id); err != nil {
+		return err
+	}
+	planned := domain.Sales{Payments: exchange.Payments}
+	if err = s.debitCredit(ctx, tx, sale.CustomerId, sale, nextSaleVersionId, planned.GetCustomerCreditTotal(), user.Id); err != nil {
+		return err
+	}
+
+	if err = s.saleRepository.CancelPaymentDatesBySaleVersionId(ctx, tx, sale.SalesVersionId); err != nil {
+		return err
+	}
+	if err = s.saleRepository.UpdateSaleDiscountAmount(ctx, tx, sale.Id, saleToCreate.GetSaleDiscountAmount()); err != nil {
+		return err
+	}
+	if err = s.saleRepository.UpdateSaleLastVersion(ctx, tx, sale.Id, nextVersion); err != nil {
+		return err
+	}
+
+	stockIn := make([]inventory_usecase.DoTransactionSkusInput, 0, len(exchange.Return.Items))
+	for _, item := range exchange.Return.Items {
+		stockIn = append(stockIn, inventory_usecase.DoTransactionSkusInput{
+			SkuId:    item.Sku.Id,
+			Quantity: item.Quantity,
+		})
+	}
+	stockOut := make([]inventory_usecase.DoTransactionSkusInput, 0, len(exchange.Items))
+	for _, item := range exchange.Items {
+		stockOut = append(stockOut, inventory_usecase.DoTransactionSkusInput{
+			SkuId:    item.Sku.Id,
+			Quantity: item.Quantity,
+		})
+	}
+
+	transactionSale := domain.Sales{
+		Id:             sale.Id,
+		SalesVersionId: nextSaleVersionId,
+	}
+	justification := fmt.Sprintf("Troca realizada no dia %s pelo cliente %s", exchange.ExchangeDate.Format("02/01/2006"), sale.CustomerName)
+	err = s.inventoryUseCase.DoTransaction(ctx, tx, inventory_usecase.DoTransactionInput{
+		Type:                   domain.InventoryTransactionTypeIn,
+		InventoryDestinationId: inventory.Id,
+		Skus:                   stockIn,
+		Sale:                   transactionSale,
+		Justification:          justification,
+	})
+	if err != nil {
+		return err
+	}
+	err = s.inventoryUseCase.DoTransaction(ctx, tx, inventory_usecase.DoTransactionInput{
+		Type:              domain.InventoryTransactionTypeOut,
+		InventoryOriginId: inventory.Id,
+		Skus:              stockOut,
+		Sale:              transactionSale,
+		Justification:     justification,
+	})
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (s *salesUseCase) buildExchangePayments(old []domain.GetSalesPaymentOutput, planned []domain.SalesPayment) []domain.SalesPayment {
+	carried := make([]domain.SalesPaymentDates, 0, len(old))
+	for _, p := range old {
+		switch p.PaymentStatus {
+		case domain.PaymentStatusCancel:
+			continue
+		case domain.PaymentStatusDelayed:
+			carried = append(carried, toSalesPaymentDates(p, domain.PaymentStatusPending))
+		default:
+			carried = append(carried, toSalesPaymentDates(p, p.PaymentStatus))
+		}
+	}
+	return s.mergePayments(carried, planned)
+}
@@ -0,0 +1,110 @@
+package sales_usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/bncunha/erp-api/src/domain"
+)
+
+func (s *salesUseCase) DoPaymentReceipt(ctx context.Context, input DoPaymentReceiptInput) (err error) {
+	tx, err := s.repository.BeginTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	sale, err := s.saleRepository.GetSaleByIdForUpdate(ctx, tx, input.SaleId)
+	if err != nil {
+		return err
+	}
+
+	paymentDate, err := s.saleRepository.GetPaymentDatesBySaleIdAndPaymentDateId(ctx, sale.Id, input.PaymentDateId)
+	if err != nil {
+		return err
+	}
+	receipts, err := s.saleRepository.GetPaymentReceiptsByPaymentDateId(ctx, paymentDate.Id)
+	if err != nil {
+		return err
+	}
+	receivedValue := 0.0
+	for _, receipt := range receipts {
+		receivedValue += receipt.Value
+	}
+
+	value := input.Value
+	if input.SettleRemaining {
+		value = round2(paymentDate.InstallmentValue - receivedValue)
+	}
+
+	receipt := domain.NewPaymentReceipt(paymentDate.Id, value, input.ReceiptDate, input.PaymentType, input.UserId)
+	if err = receipt.Validate(paymentDate, receivedValue); err != nil {
+		return err
+	}
+
+	// Os encargos por atraso são cobrados uma única vez, sobre o saldo em
+	// aberto, no recebimento que quita a parcela.
+	settles := receipt.GetRemainingValue(paymentDate, receivedValue) <= 0
+	var lateFee domain.LateFee
+	if settles {
+		var rule domain.LateFeeRule
+		rule, err = s.lateFeeRuleRepository.Get(ctx)
+		if err != nil {
+			return err
+		}
+		lateFee = rule.Calculate(paymentDate.PaymentType, round2(paymentDate.InstallmentValue-receivedValue), paymentDate.DueDate, receipt.ReceiptDate)
+	}
+
+	if _, err = s.saleRepository.CreatePaymentReceipt(ctx, tx, receipt); err != nil {
+		return err
+	}
+	if receipt.PaymentType == domain.PaymentTypeCash {
+		var cashRegisterSession *domain.CashRegisterSession
+		cashRegisterSession, err = s.getCashRegisterSession(ctx, input.UserId)
+		if err != nil {
+			return err
+		}
+		entry := domain.NewCashRegisterEntry(domain.CashRegisterEntryTypeReceipt, round2(receipt.Value+lateFee.GetTotal()), fmt.Sprintf("Recebimento da parcela %d da venda %s", paymentDate.InstallmentNumber, sale.Code), input.UserId, receipt.ReceiptDate)
+		entry.SalesId = sale.Id
+		entry.PaymentDateId = paymentDate.Id
+		if err = s.registerCashEntry(ctx, tx, cashRegisterSession, entry); err != nil {
+			return err
+		}
+	}
+	if settles {
+		if err = s.saleRepository.SettlePaymentDate(ctx, tx, paymentDate.Id, receipt.ReceiptDate, lateFee); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// splitPartialReceipts separa o valor já recebido das parcelas em aberto para
+// que seja mantido como pago na próxima versão da venda.
+func splitPartialReceipts(payments []domain.GetSalesPaymentOutput) []domain.GetSalesPaymentOutput {
+	output := make([]domain.GetSalesPaymentOutput, 0, len(payments))
+	for _, payment := range payments {
+		isOpen := payment.PaymentStatus == domain.PaymentStatusPending || payment.PaymentStatus == domain.PaymentStatusDelayed
+		if !isOpen || payment.ReceivedValue <= 0 {
+			output = append(output, payment)
+			continue
+		}
+		received := payment
+		received.InstallmentValue = round2(payment.ReceivedValue)
+		received.PaymentStatus = domain.PaymentStatusPaid
+		received.PaidDate = payment.LastReceiptDate
+		received.ReceivedValue = 0
+
+		remaining := payment
+		remaining.InstallmentValue = round2(payment.InstallmentValue - payment.ReceivedValue)
+		remaining.ReceivedValue = 0
+
+		output = append(output, received, remaining)
+	}
+	return output
+}
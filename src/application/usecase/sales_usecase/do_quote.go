@@ -0,0 +1,87 @@
+package sales_usecase
+
+import (
+	"context"
+
+	"github.com/bncunha/erp-api/src/domain"
+)
+
+// DoQuote registra um orçamento validado como uma venda, mas sem movimentar
+// estoque nem gerar parcelas. Quando a reserva está ativa, as quantidades
+// passam a ser descontadas do estoque disponível para outras vendas.
+func (s *salesUseCase) DoQuote(ctx context.Context, input DoQuoteInput) (err error) {
+	user, err := s.userRepository.GetById(ctx, input.UserId)
+	if err != nil {
+		return err
+	}
+
+	customer, err := s.customerRepository.GetById(ctx, input.CustomerId)
+	if err != nil {
+		return err
+	}
+
+	tx, err := s.repository.BeginTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	customer.CreditBalance, err = s.customerCreditRepository.GetBalance(ctx, customer.Id)
+	if err != nil {
+		return err
+	}
+
+	skusIds := s.detachIds(input.Items)
+	skus, err := s.skuRepository.GetByManyIds(ctx, skusIds)
+	if err != nil {
+		return err
+	}
+	if err = s.validateDuplicatedSkus(skus, skusIds); err != nil {
+		return err
+	}
+
+	inventory, err := s.getSellerInventory(ctx, user)
+	if err != nil {
+		return err
+	}
+	inventoryItems, err := s.inventoryItemRepository.GetByManySkuIdsAndInventoryId(ctx, skusIds, inventory.Id)
+	if err != nil {
+		return err
+	}
+	if err = s.validateExistsInventoryItem(inventoryItems, skusIds); err != nil {
+		return err
+	}
+	if err = s.applyStockReservations(ctx, tx, inventory.Id, skusIds, inventoryItems, 0); err != nil {
+		return err
+	}
+
+	sale := s.createSale(user, customer, inventoryItems, input.Items, input.Payments)
+	sale.Discount = input.Discount
+	sale.ApplyDiscounts()
+	if err = sale.ValidateSale(); err != nil {
+		return err
+	}
+
+	quote := domain.NewQuote(user, customer, sale.Items, input.Plan, input.Discount, input.ExpiresAt, input.ReserveStock)
+	quote.InventoryId = inventory.Id
+	if err = quote.Validate(); err != nil {
+		return err
+	}
+
+	quote.Id, err = s.quoteRepository.Create(ctx, tx, quote)
+	if err != nil {
+		return err
+	}
+	if err = s.quoteRepository.CreateItems(ctx, tx, quote.Id, quote.Items); err != nil {
+		return err
+	}
+	if err = s.quoteRepository.CreatePayments(ctx, tx, quote.Id, quote.Payments); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,139 @@
+package sales_usecase
+
+import (
+	"context"
+	"math"
+
+	"github.com/bncunha/erp-api/src/domain"
+)
+
+func (s *salesUseCase) DoRenegotiation(ctx context.Context, input DoRenegotiationInput) (err error) {
+	user, err := s.userRepository.GetById(ctx, input.UserId)
+	if err != nil {
+		return err
+	}
+
+	tx, err := s.repository.BeginTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	sale, err := s.saleRepository.GetSaleByIdForUpdate(ctx, tx, input.SaleId)
+	if err != nil {
+		return err
+	}
+	if user.Role != string(domain.UserRoleAdmin) && sale.UserId != user.Id {
+		return domain.ErrRenegotiationNotAllowed
+	}
+
+	currentItems, err := s.saleRepository.GetItemsBySaleVersionId(ctx, sale.SalesVersionId)
+	if err != nil {
+		return err
+	}
+	if len(currentItems) == 0 {
+		return domain.ErrSaleAlreadyCancelled
+	}
+
+	oldPayments, err := s.saleRepository.GetPaymentsBySaleVersionId(ctx, sale.SalesVersionId)
+	if err != nil {
+		return err
+	}
+	oldPayments = splitPartialReceipts(oldPayments)
+
+	carried, openValue := s.splitRenegotiatedPayments(oldPayments)
+	renegotiation := domain.NewSalesRenegotiation(openValue, input.Installments, input.FirstDueDate, input.InterestPercentage, input.DiscountPercentage)
+	if err = renegotiation.Validate(); err != nil {
+		return err
+	}
+
+	nextVersion := sale.LastVersion + 1
+	nextSaleVersionId, err := s.saleRepository.CreateSaleVersion(ctx, tx, sale.Id, nextVersion, renegotiation.RenegotiationDate, round2(sale.AdjustmentAmount+renegotiation.GetAdjustmentValue()))
+	if err != nil {
+		return err
+	}
+
+	items := make([]domain.SalesItem, 0, len(currentItems))
+	for _, item := range currentItems {
+		items = append(items, item.ToSalesItem())
+	}
+	saleToCreate := domain.Sales{
+		Id:             sale.Id,
+		SalesVersionId: nextSaleVersionId,
+		Items:          items,
+	}
+	if _, err = s.saleRepository.CreateManySaleItem(ctx, tx, saleToCreate, items); err != nil {
+		return err
+	}
+
+	for _, payment := range s.mergePayments(carried, []domain.SalesPayment{s.buildRenegotiationPayment(renegotiation)}) {
+		payment.Id, err = s.saleRepository.CreatePayment(ctx, tx, saleToCreate, payment)
+		if err != nil {
+			return err
+		}
+		if _, err = s.saleRepository.CreateManyPaymentDates(ctx, tx, payment, payment.Dates); err != nil {
+			return err
+		}
+	}
+
+	if _, err = s.saleRepository.CreateSalesRenegotiation(ctx, tx, sale.Id, sale.SalesVersionId, nextSaleVersionId, renegotiation, user.Id); err != nil {
+		return err
+	}
+	if err = s.saleRepository.CancelPaymentDatesBySaleVersionId(ctx, tx, sale.SalesVersionId); err != nil {
+		return err
+	}
+	if err = s.saleRepository.UpdateSaleLastVersion(ctx, tx, sale.Id, nextVersion); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// carriedAdjustment leva o acréscimo ou abatimento das renegociações anteriores
+// para a nova versão. Um abatimento nunca ultrapassa o total dos itens e, sem
+// itens, não há o que ajustar.
+func carriedAdjustment(adjustment float64, items []domain.SalesItem) float64 {
+	if len(items) == 0 {
+		return 0
+	}
+	itemsTotal := 0.0
+	for _, item := range items {
+		itemsTotal += item.GetTotal()
+	}
+	return round2(math.Max(adjustment, -itemsTotal))
+}
+
+// splitRenegotiatedPayments separa as parcelas de crediário em aberto, que serão
+// renegociadas, das demais parcelas, que seguem para a nova versão sem alteração.
+func (s *salesUseCase) splitRenegotiatedPayments(old []domain.GetSalesPaymentOutput) (carried []domain.SalesPaymentDates, openValue float64) {
+	for _, p := range old {
+		isOpen := p.PaymentStatus == domain.PaymentStatusPending || p.PaymentStatus == domain.PaymentStatusDelayed
+		if isOpen && p.PaymentType == domain.PaymentTypeCreditStore {
+			openValue += p.InstallmentValue
+			continue
+		}
+		if isOpen {
+			carried = append(carried, toSalesPaymentDates(p, domain.PaymentStatusPending))
+			continue
+		}
+		if p.PaymentStatus == domain.PaymentStatusPaid || p.PaymentStatus == domain.PaymentStatusReversal {
+			carried = append(carried, toSalesPaymentDates(p, p.PaymentStatus))
+		}
+	}
+	return carried, round2(openValue)
+}
+
+func (s *salesUseCase) buildRenegotiationPayment(renegotiation domain.SalesRenegotiation) domain.SalesPayment {
+	payment := domain.NewSalesPayment(domain.PaymentTypeCreditStore)
+	values := splitAmount(renegotiation.GetTotal(), renegotiation.Installments)
+	for i, dueDate := range renegotiation.GetDueDates() {
+		d := domain.NewSalesPaymentDates(dueDate, nil, i+1, values[i], domain.PaymentStatusPending)
+		d.PaymentType = domain.PaymentTypeCreditStore
+		payment.Dates = append(payment.Dates, d)
+	}
+	return payment
+}
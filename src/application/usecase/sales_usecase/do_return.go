@@ -2,6 +2,7 @@ package sales_usecase
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
 	"math"
 	"sort"
@@ -44,7 +45,7 @@ func (s *salesUseCase) DoReturn(ctx context.Context, input DoReturnInput) (err e
 				returnItems = append(returnItems, domain.SalesReturnItem{
 					Sku:       saleItem.Sku,
 					Quantity:  item.Quantity,
-					UnitPrice: saleItem.UnitPrice,
+					UnitPrice: saleItem.GetNetUnitPrice(),
 				})
 				break
 			}
@@ -64,13 +65,15 @@ func (s *salesUseCase) DoReturn(ctx context.Context, input DoReturnInput) (err e
 		return err
 	}
 
+	newSaleItems := s.buildRemainingItems(currentItems, salesReturn.Items)
+	adjustment := carriedAdjustment(sale.AdjustmentAmount, newSaleItems)
+
 	nextVersion := sale.LastVersion + 1
-	nextSaleVersionId, err := s.saleRepository.CreateSaleVersion(ctx, tx, sale.Id, nextVersion, time.Now())
+	nextSaleVersionId, err := s.saleRepository.CreateSaleVersion(ctx, tx, sale.Id, nextVersion, time.Now(), adjustment)
 	if err != nil {
 		return err
 	}
 
-	newSaleItems := s.buildRemainingItems(currentItems, salesReturn.Items)
 	saleToCreate := domain.Sales{
 		Id:             sale.Id,
 		SalesVersionId: nextSaleVersionId,
@@ -86,7 +89,8 @@ func (s *salesUseCase) DoReturn(ctx context.Context, input DoReturnInput) (err e
 	if err != nil {
 		return err
 	}
-	newPayments := s.recalculatePayments(oldPayments, newSaleItems)
+	oldPayments = splitPartialReceipts(oldPayments)
+	newPayments := s.recalculatePayments(oldPayments, newSaleItems, adjustment)
 	for _, payment := range newPayments {
 		payment.Id, err = s.saleRepository.CreatePayment(ctx, tx, saleToCreate, payment)
 		if err != nil {
@@ -104,10 +108,16 @@ func (s *salesUseCase) DoReturn(ctx context.Context, input DoReturnInput) (err e
 	if _, err = s.saleRepository.CreateSalesReturnItems(ctx, tx, salesReturnId, salesReturn.Items); err != nil {
 		return err
 	}
+	if err = s.creditRefund(ctx, tx, sale, nextSaleVersionId, refundedValue(oldPayments, newPayments), input.UserId); err != nil {
+		return err
+	}
 
 	if err = s.saleRepository.CancelPaymentDatesBySaleVersionId(ctx, tx, sale.SalesVersionId); err != nil {
 		return err
 	}
+	if err = s.saleRepository.UpdateSaleDiscountAmount(ctx, tx, sale.Id, saleToCreate.GetSaleDiscountAmount()); err != nil {
+		return err
+	}
 	if err = s.saleRepository.UpdateSaleLastVersion(ctx, tx, sale.Id, nextVersion); err != nil {
 		return err
 	}
@@ -149,19 +159,60 @@ func (s *salesUseCase) buildRemainingItems(current []domain.GetItemsOutput, retu
 		if remaining <= 0 {
 			continue
 		}
-		output = append(output, domain.SalesItem{
-			Sku:       item.Sku,
-			Quantity:  remaining,
-			UnitPrice: item.UnitPrice,
-		})
+		output = append(output, item.ToSalesItem().WithQuantity(remaining))
 	}
 	return output
 }
 
-func (s *salesUseCase) recalculatePayments(old []domain.GetSalesPaymentOutput, newItems []domain.SalesItem) []domain.SalesPayment {
-	newTotal := 0.0
+func (s *salesUseCase) creditRefund(ctx context.Context, tx *sql.Tx, sale domain.SaleWithVersionOutput, salesVersionId int64, value float64, userId int64) error {
+	if value <= 0 {
+		return nil
+	}
+	credit := domain.NewCustomerCredit(sale.CustomerId, domain.CustomerCreditTypeCredit, domain.CustomerCreditOriginReturn, value, fmt.Sprintf("Devolução da venda %s", sale.Code))
+	credit.SalesId = sale.Id
+	credit.SalesVersionId = salesVersionId
+	credit.CreatedByUserId = userId
+	_, err := s.customerCreditRepository.CreateWithTx(ctx, tx, credit)
+	return err
+}
+
+// debitCredit debita da carteira do cliente o valor pago com crédito em uma
+// nova versão da venda, validando o saldo com o cliente bloqueado na transação.
+func (s *salesUseCase) debitCredit(ctx context.Context, tx *sql.Tx, customerId int64, sale domain.SaleWithVersionOutput, salesVersionId int64, value float64, userId int64) error {
+	if value <= 0 {
+		return nil
+	}
+	balance, err := s.customerCreditRepository.GetBalanceForUpdate(ctx, tx, customerId)
+	if err != nil {
+		return err
+	}
+	credit := domain.NewCustomerCredit(customerId, domain.CustomerCreditTypeDebit, domain.CustomerCreditOriginSale, value, fmt.Sprintf("Pagamento da venda %s", sale.Code))
+	credit.SalesId = sale.Id
+	credit.SalesVersionId = salesVersionId
+	credit.CreatedByUserId = userId
+	if err = credit.Validate(balance); err != nil {
+		return err
+	}
+	_, err = s.customerCreditRepository.CreateWithTx(ctx, tx, credit)
+	return err
+}
+
+func refundedValue(old []domain.GetSalesPaymentOutput, payments []domain.SalesPayment) float64 {
+	settled := 0.0
+	for _, payment := range payments {
+		for _, date := range payment.Dates {
+			if date.Status == domain.PaymentStatusPaid || date.Status == domain.PaymentStatusReversal {
+				settled += date.InstallmentValue
+			}
+		}
+	}
+	return round2(settledTotal(old) - settled)
+}
+
+func (s *salesUseCase) recalculatePayments(old []domain.GetSalesPaymentOutput, newItems []domain.SalesItem, adjustmentAmount float64) []domain.SalesPayment {
+	newTotal := adjustmentAmount
 	for _, item := range newItems {
-		newTotal += item.Quantity * item.Sku.Price
+		newTotal += item.GetTotal()
 	}
 	newTotal = round2(newTotal)
 
@@ -195,6 +246,12 @@ func (s *salesUseCase) recalculatePayments(old []domain.GetSalesPaymentOutput, n
 		dueDate := p.DueDate
 		d := domain.NewSalesPaymentDates(dueDate, p.PaidDate, int(p.InstallmentNumber), p.InstallmentValue, p.PaymentStatus)
 		d.PaymentType = p.PaymentType
+		d.FineValue = p.FineValue
+		d.InterestValue = p.InterestValue
+		d.CardBrand = p.CardBrand
+		d.CardFeeValue = p.CardFeeValue
+		d.SettlementDate = p.SettlementDate
+		payment.CardBrand = p.CardBrand
 		payment.Dates = append(payment.Dates, d)
 		paymentsMap[p.PaymentType] = payment
 	}
@@ -2,6 +2,7 @@ package sales_usecase
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
 	"time"
 
@@ -14,7 +15,7 @@ var (
 	ErrSkusNotFound = errors.New("SKUs não encontrados")
 )
 
-func (s *salesUseCase) DoSale(ctx context.Context, input DoSaleInput) error {
+func (s *salesUseCase) DoSale(ctx context.Context, input DoSaleInput) (err error) {
 
 	user, err := s.userRepository.GetById(ctx, input.UserId)
 	if err != nil {
@@ -26,26 +27,32 @@ func (s *salesUseCase) DoSale(ctx context.Context, input DoSaleInput) error {
 		return err
 	}
 
-	skusIds := s.detachIds(input.Items)
-	skus, err := s.skuRepository.GetByManyIds(ctx, skusIds)
+	tx, err := s.repository.BeginTx(ctx)
 	if err != nil {
 		return err
 	}
-	err = s.validateDuplicatedSkus(skus, skusIds)
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	customer.CreditBalance, err = s.customerCreditRepository.GetBalanceForUpdate(ctx, tx, customer.Id)
 	if err != nil {
 		return err
 	}
 
-	inventoryOrigin, err := s.inventoryRepository.GetByUserId(ctx, user.Id)
-	if err != nil && !errors.Is(err, domain.ErrInventoryNotFound) {
+	skusIds := s.detachIds(input.Items)
+	skus, err := s.skuRepository.GetByManyIds(ctx, skusIds)
+	if err != nil {
 		return err
 	}
-	if err != nil && errors.Is(err, domain.ErrInventoryNotFound) && user.Role == string(domain.UserRoleAdmin) {
-		inventoryOrigin, err = s.inventoryRepository.GetPrimaryInventory(ctx)
-		if err != nil {
-			return err
-		}
+	err = s.validateDuplicatedSkus(skus, skusIds)
+	if err != nil {
+		return err
 	}
+
+	inventoryOrigin, err := s.getSellerInventory(ctx, user)
 	if err != nil {
 		return err
 	}
@@ -58,23 +65,35 @@ func (s *salesUseCase) DoSale(ctx context.Context, input DoSaleInput) error {
 	if err != nil {
 		return err
 	}
+	err = s.applyStockReservations(ctx, tx, inventoryOrigin.Id, skusIds, inventoryItems, input.QuoteId)
+	if err != nil {
+		return err
+	}
 
 	sale := s.createSale(user, customer, inventoryItems, input.Items, input.Payments)
+	sale.Discount = input.Discount
+	sale.ApplyDiscounts()
+	err = s.applyCardFees(ctx, sale.Payments, sale.Date)
+	if err != nil {
+		return err
+	}
+
+	var cashRegisterSession *domain.CashRegisterSession
+	if sale.HasCashPayment() {
+		cashRegisterSession, err = s.getCashRegisterSession(ctx, user.Id)
+		if err != nil {
+			return err
+		}
+	}
 
 	err = sale.ValidateSale()
 	if err != nil {
 		return err
 	}
-
-	tx, err := s.repository.BeginTx(ctx)
+	err = s.validateDiscountLimit(ctx, user, sale, input.DiscountApproverId)
 	if err != nil {
 		return err
 	}
-	defer func() {
-		if err != nil {
-			tx.Rollback()
-		}
-	}()
 
 	skusInventoryInput := make([]inventory_usecase.DoTransactionSkusInput, len(sale.Items))
 	for i, item := range sale.Items {
@@ -88,7 +107,7 @@ func (s *salesUseCase) DoSale(ctx context.Context, input DoSaleInput) error {
 	if err != nil {
 		return err
 	}
-	sale.SalesVersionId, err = s.saleRepository.CreateSaleVersion(ctx, tx, sale.Id, 1, sale.Date)
+	sale.SalesVersionId, err = s.saleRepository.CreateSaleVersion(ctx, tx, sale.Id, 1, sale.Date, 0)
 	if err != nil {
 		return err
 	}
@@ -98,6 +117,13 @@ func (s *salesUseCase) DoSale(ctx context.Context, input DoSaleInput) error {
 		return err
 	}
 
+	if input.QuoteId != 0 {
+		err = s.quoteRepository.MarkAsConverted(ctx, tx, input.QuoteId, sale.Id)
+		if err != nil {
+			return err
+		}
+	}
+
 	for _, payment := range sale.Payments {
 		payment.Id, err = s.saleRepository.CreatePayment(ctx, tx, sale, payment)
 		if err != nil {
@@ -110,12 +136,31 @@ func (s *salesUseCase) DoSale(ctx context.Context, input DoSaleInput) error {
 		}
 	}
 
+	if cashValue := sale.GetCashReceivedTotal(); cashValue > 0 {
+		entry := domain.NewCashRegisterEntry(domain.CashRegisterEntryTypeSale, cashValue, fmt.Sprintf("Venda %s", sale.Code), user.Id, sale.Date)
+		entry.SalesId = sale.Id
+		if err = s.registerCashEntry(ctx, tx, cashRegisterSession, entry); err != nil {
+			return err
+		}
+	}
+
+	if creditTotal := sale.GetCustomerCreditTotal(); creditTotal > 0 {
+		credit := domain.NewCustomerCredit(customer.Id, domain.CustomerCreditTypeDebit, domain.CustomerCreditOriginSale, creditTotal, fmt.Sprintf("Pagamento da venda %s", sale.Code))
+		credit.SalesId = sale.Id
+		credit.SalesVersionId = sale.SalesVersionId
+		credit.CreatedByUserId = user.Id
+		if _, err = s.customerCreditRepository.CreateWithTx(ctx, tx, credit); err != nil {
+			return err
+		}
+	}
+
 	err = s.inventoryUseCase.DoTransaction(ctx, tx, inventory_usecase.DoTransactionInput{
 		Type:                   domain.InventoryTransactionTypeOut,
 		InventoryOriginId:      inventoryOrigin.Id,
 		InventoryDestinationId: 0,
 		Skus:                   skusInventoryInput,
 		Sale:                   sale,
+		ExceptQuoteId:          input.QuoteId,
 		Justification:          "Vendido em " + time.Now().Format("02/01/2006"),
 	})
 	if err != nil {
@@ -125,25 +170,141 @@ func (s *salesUseCase) DoSale(ctx context.Context, input DoSaleInput) error {
 	return tx.Commit()
 }
 
+// validateDiscountLimit aplica aos revendedores o desconto máximo configurado pela
+// empresa. Acima do limite, a venda só é aceita com a aprovação de um administrador.
+func (s *salesUseCase) validateDiscountLimit(ctx context.Context, user domain.User, sale domain.Sales, approverId int64) error {
+	if user.Role != string(domain.UserRoleReseller) || sale.GetDiscountTotal() <= 0 {
+		return nil
+	}
+	rule, err := s.discountRuleRepository.Get(ctx)
+	if err != nil {
+		return err
+	}
+	limitErr := sale.ValidateDiscountLimit(rule)
+	if limitErr == nil || approverId == 0 {
+		return limitErr
+	}
+	approver, err := s.userRepository.GetById(ctx, approverId)
+	if err != nil {
+		return err
+	}
+	if approver.Role != string(domain.UserRoleAdmin) {
+		return limitErr
+	}
+	return nil
+}
+
+// applyStockReservations desconta do estoque disponível as quantidades
+// reservadas por outros orçamentos em aberto, bloqueando o estoque na transação.
+func (s *salesUseCase) applyStockReservations(ctx context.Context, tx *sql.Tx, inventoryId int64, skusIds []int64, inventoryItems []domain.InventoryItem, exceptQuoteId int64) error {
+	reserved, err := s.quoteRepository.GetReservedQuantitiesForUpdate(ctx, tx, inventoryId, skusIds, exceptQuoteId)
+	if err != nil {
+		return err
+	}
+	for i := range inventoryItems {
+		inventoryItems[i].Sku.Quantity -= reserved[inventoryItems[i].Sku.Id]
+	}
+	return nil
+}
+
+func (s *salesUseCase) getSellerInventory(ctx context.Context, user domain.User) (domain.Inventory, error) {
+	inventory, err := s.inventoryRepository.GetByUserId(ctx, user.Id)
+	if err != nil && !errors.Is(err, domain.ErrInventoryNotFound) {
+		return inventory, err
+	}
+	if err != nil && errors.Is(err, domain.ErrInventoryNotFound) && user.Role == string(domain.UserRoleAdmin) {
+		return s.inventoryRepository.GetPrimaryInventory(ctx)
+	}
+	return inventory, err
+}
+
 func (s *salesUseCase) createSale(user domain.User, customer domain.Customer, inventoryItems []domain.InventoryItem, itemsInput []DoSaleItemsInput, paymentsInput []DoSalePaymentsInput) domain.Sales {
 	items := make([]domain.SalesItem, len(itemsInput))
-	payments := make([]domain.SalesPayment, len(paymentsInput))
 
 	for i, input := range itemsInput {
 		for _, item := range inventoryItems {
 			if item.Sku.Id == input.SkuId {
-				items[i] = domain.NewSalesItem(item.Sku, input.Quantity)
+				sku := item.Sku
+				if input.UnitPrice > 0 {
+					sku.Price = input.UnitPrice
+				}
+				items[i] = domain.NewSalesItem(sku, input.Quantity)
+				items[i].Discount = input.Discount
 				continue
 			}
 		}
 	}
+	return domain.NewSales(time.Now(), user, customer, items, s.createPayments(paymentsInput))
+}
+
+func (s *salesUseCase) createPayments(paymentsInput []DoSalePaymentsInput) []domain.SalesPayment {
+	payments := make([]domain.SalesPayment, len(paymentsInput))
 	for i, payment := range paymentsInput {
 		payments[i] = domain.NewSalesPayment(payment.PaymentType)
 		for _, date := range payment.Dates {
 			payments[i].AppendNewSalesDate(date.DueDate, date.InstallmentNumber, date.InstallmentValue, date.DateInformed)
 		}
+		if payment.ReceivedAmount > 0 {
+			payments[i].SetReceivedAmount(payment.ReceivedAmount)
+		}
+		payments[i].CardBrand = payment.CardBrand
+	}
+	return payments
+}
+
+// applyCardFees calcula a taxa e a data de repasse das parcelas pagas com
+// cartão, conforme a tabela de taxas da empresa.
+func (s *salesUseCase) applyCardFees(ctx context.Context, payments []domain.SalesPayment, saleDate time.Time) error {
+	hasCardPayment := false
+	for _, payment := range payments {
+		hasCardPayment = hasCardPayment || domain.IsCardPayment(payment.PaymentType)
+	}
+	if !hasCardPayment {
+		return nil
+	}
+
+	rules, err := s.cardFeeRuleRepository.GetAll(ctx)
+	if err != nil {
+		return err
+	}
+	for i := range payments {
+		payments[i].ApplyCardFees(rules, saleDate)
+	}
+	return nil
+}
+
+// getCashRegisterSession retorna o caixa aberto do usuário que vai receber
+// dinheiro. Sem caixa aberto retorna nil, a não ser que a empresa exija caixa
+// aberto para movimentar dinheiro.
+func (s *salesUseCase) getCashRegisterSession(ctx context.Context, userId int64) (*domain.CashRegisterSession, error) {
+	session, err := s.cashRegisterRepository.GetOpenByUserId(ctx, userId)
+	if err == nil {
+		return &session, nil
+	}
+	if !errors.Is(err, domain.ErrCashRegisterSessionNotOpen) {
+		return nil, err
+	}
+
+	settings, err := s.cashRegisterRepository.GetSettings(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if settings.RequireOpenSession {
+		return nil, domain.ErrCashRegisterSessionRequired
+	}
+	return nil, nil
+}
+
+func (s *salesUseCase) registerCashEntry(ctx context.Context, tx *sql.Tx, session *domain.CashRegisterSession, entry domain.CashRegisterEntry) error {
+	if session == nil {
+		return nil
+	}
+	entry, err := session.AddEntry(entry)
+	if err != nil {
+		return err
 	}
-	return domain.NewSales(time.Now(), user, customer, items, payments)
+	_, err = s.cashRegisterRepository.CreateEntry(ctx, tx, entry)
+	return err
 }
 
 func (s *salesUseCase) detachIds(items []DoSaleItemsInput) []int64 {
@@ -12,17 +12,26 @@ type DoSaleInput struct {
 	CustomerId int64
 	Payments   []DoSalePaymentsInput
 	Items      []DoSaleItemsInput
+	Discount   domain.SalesDiscount
+	QuoteId    int64
+	// DiscountApproverId é o administrador que aprovou um desconto acima do
+	// limite do revendedor.
+	DiscountApproverId int64
 }
 
 type DoSaleItemsInput struct {
-	SkuId    int64
-	Quantity float64
+	SkuId     int64
+	Quantity  float64
+	Discount  domain.SalesDiscount
+	UnitPrice float64
 }
 
 type DoSalePaymentsInput struct {
-	PaymentType domain.PaymentType
-	Value       float64
-	Dates       []DoSalePaymentDatesInput
+	PaymentType    domain.PaymentType
+	Value          float64
+	Dates          []DoSalePaymentDatesInput
+	ReceivedAmount float64
+	CardBrand      domain.CardBrand
 }
 
 type DoSalePaymentDatesInput struct {
@@ -47,3 +56,57 @@ type DoReturnItemInput struct {
 	SkuId    int64
 	Quantity float64
 }
+
+type DoCancelInput struct {
+	SaleId int64
+	UserId int64
+	Reason string
+}
+
+type DoEditInput struct {
+	SaleId     int64
+	UserId     int64
+	CustomerId int64
+	Items      []DoSaleItemsInput
+	Payments   []DoSalePaymentsInput
+}
+
+type DoExchangeInput struct {
+	SaleId        int64
+	UserId        int64
+	Reason        string
+	ReturnedItems []DoReturnItemInput
+	Items         []DoSaleItemsInput
+	Payments      []DoSalePaymentsInput
+}
+
+type DoPaymentReceiptInput struct {
+	SaleId        int64
+	PaymentDateId int64
+	UserId        int64
+	Value         float64
+	ReceiptDate   time.Time
+	PaymentType   domain.PaymentType
+	// SettleRemaining recebe todo o saldo em aberto da parcela, ignorando Value.
+	SettleRemaining bool
+}
+
+type DoRenegotiationInput struct {
+	SaleId             int64
+	UserId             int64
+	Installments       int
+	FirstDueDate       time.Time
+	InterestPercentage float64
+	DiscountPercentage float64
+}
+
+type DoQuoteInput struct {
+	UserId       int64
+	CustomerId   int64
+	Items        []DoSaleItemsInput
+	Payments     []DoSalePaymentsInput
+	Plan         []domain.QuotePayment
+	Discount     domain.SalesDiscount
+	ExpiresAt    *time.Time
+	ReserveStock bool
+}
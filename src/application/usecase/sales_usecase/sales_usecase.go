@@ -11,35 +11,59 @@ import (
 type SalesUseCase interface {
 	DoSale(ctx context.Context, input DoSaleInput) error
 	DoReturn(ctx context.Context, input DoReturnInput) error
+	DoCancel(ctx context.Context, input DoCancelInput) error
+	DoEdit(ctx context.Context, input DoEditInput) error
+	DoExchange(ctx context.Context, input DoExchangeInput) error
+	DoPaymentReceipt(ctx context.Context, input DoPaymentReceiptInput) error
+	DoRenegotiation(ctx context.Context, input DoRenegotiationInput) error
+	DoQuote(ctx context.Context, input DoQuoteInput) error
 }
 
 type salesUseCase struct {
-	userRepository          domain.UserRepository
-	customerRepository      domain.CustomerRepository
-	skuRepository           domain.SkuRepository
-	saleRepository          domain.SalesRepository
-	inventoryUseCase        inventory_usecase.InventoryUseCase
-	inventoryRepository     domain.InventoryRepository
-	inventoryItemRepository domain.InventoryItemRepository
-	repository              *repository.Repository
+	userRepository           domain.UserRepository
+	customerRepository       domain.CustomerRepository
+	customerCreditRepository domain.CustomerCreditRepository
+	skuRepository            domain.SkuRepository
+	saleRepository           domain.SalesRepository
+	inventoryUseCase         inventory_usecase.InventoryUseCase
+	inventoryRepository      domain.InventoryRepository
+	inventoryItemRepository  domain.InventoryItemRepository
+	discountRuleRepository   domain.DiscountRuleRepository
+	quoteRepository          domain.QuoteRepository
+	cardFeeRuleRepository    domain.CardFeeRuleRepository
+	cashRegisterRepository   domain.CashRegisterRepository
+	lateFeeRuleRepository    domain.LateFeeRuleRepository
+	repository               *repository.Repository
 }
 
 func NewSalesUseCase(userRepository domain.UserRepository,
 	customerRepository domain.CustomerRepository,
+	customerCreditRepository domain.CustomerCreditRepository,
 	skuRepository domain.SkuRepository,
 	saleRepository domain.SalesRepository,
 	inventoryUseCase inventory_usecase.InventoryUseCase,
 	inventoryRepository domain.InventoryRepository,
 	inventoryItemRepository domain.InventoryItemRepository,
+	discountRuleRepository domain.DiscountRuleRepository,
+	quoteRepository domain.QuoteRepository,
+	cardFeeRuleRepository domain.CardFeeRuleRepository,
+	cashRegisterRepository domain.CashRegisterRepository,
+	lateFeeRuleRepository domain.LateFeeRuleRepository,
 	repository *repository.Repository) SalesUseCase {
 	return &salesUseCase{
-		userRepository:          userRepository,
-		customerRepository:      customerRepository,
-		skuRepository:           skuRepository,
-		saleRepository:          saleRepository,
-		inventoryUseCase:        inventoryUseCase,
-		inventoryRepository:     inventoryRepository,
-		repository:              repository,
-		inventoryItemRepository: inventoryItemRepository,
+		userRepository:           userRepository,
+		customerRepository:       customerRepository,
+		customerCreditRepository: customerCreditRepository,
+		skuRepository:            skuRepository,
+		saleRepository:           saleRepository,
+		inventoryUseCase:         inventoryUseCase,
+		inventoryRepository:      inventoryRepository,
+		repository:               repository,
+		inventoryItemRepository:  inventoryItemRepository,
+		discountRuleRepository:   discountRuleRepository,
+		quoteRepository:          quoteRepository,
+		cardFeeRuleRepository:    cardFeeRuleRepository,
+		cashRegisterRepository:   cashRegisterRepository,
+		lateFeeRuleRepository:    lateFeeRuleRepository,
 	}
 }
@@ -71,8 +71,9 @@ func (t *stubTx) Commit() error { return nil }
 func (t *stubTx) Rollback() error { return nil }
 
 type fakeUserRepository struct {
-	user domain.User
-	err  error
+	user  domain.User
+	users map[int64]domain.User
+	err   error
 }
 
 func (f *fakeUserRepository) GetByUsername(context.Context, string) (domain.User, error) {
@@ -87,12 +88,18 @@ func (f *fakeUserRepository) CreateWithTx(context.Context, *sql.Tx, domain.User)
 func (f *fakeUserRepository) Update(context.Context, domain.User) error { return nil }
 
 func (f *fakeUserRepository) Inactivate(context.Context, int64) error { return nil }
+func (f *fakeUserRepository) InactivateWithTx(context.Context, *sql.Tx, int64) error {
+	return nil
+}
 
 func (f *fakeUserRepository) GetAll(context.Context, serviceInput.GetAllUserInput) ([]domain.User, error) {
 	return nil, nil
 }
 
-func (f *fakeUserRepository) GetById(context.Context, int64) (domain.User, error) {
+func (f *fakeUserRepository) GetById(ctx context.Context, id int64) (domain.User, error) {
+	if user, ok := f.users[id]; ok {
+		return user, f.err
+	}
 	return f.user, f.err
 }
 
@@ -125,6 +132,37 @@ func (f *fakeCustomerRepository) Edit(context.Context, domain.Customer, int64) (
 
 func (f *fakeCustomerRepository) Inactivate(context.Context, int64) error { return nil }
 
+type fakeCustomerCreditRepository struct {
+	balance    float64
+	balanceErr error
+	createErr  error
+	created    []domain.CustomerCredit
+}
+
+func (f *fakeCustomerCreditRepository) Create(ctx context.Context, credit domain.CustomerCredit) (int64, error) {
+	return f.CreateWithTx(ctx, nil, credit)
+}
+
+func (f *fakeCustomerCreditRepository) CreateWithTx(_ context.Context, _ *sql.Tx, credit domain.CustomerCredit) (int64, error) {
+	if f.createErr != nil {
+		return 0, f.createErr
+	}
+	f.created = append(f.created, credit)
+	return int64(len(f.created)), nil
+}
+
+func (f *fakeCustomerCreditRepository) GetBalance(context.Context, int64) (float64, error) {
+	return f.balance, f.balanceErr
+}
+
+func (f *fakeCustomerCreditRepository) GetBalanceForUpdate(context.Context, *sql.Tx, int64) (float64, error) {
+	return f.balance, f.balanceErr
+}
+
+func (f *fakeCustomerCreditRepository) GetByCustomerId(context.Context, int64) ([]domain.CustomerCredit, error) {
+	return f.created, nil
+}
+
 type fakeSkuRepository struct {
 	skus []domain.Sku
 	err  error
@@ -142,6 +180,8 @@ func (f *fakeSkuRepository) GetByProductId(context.Context, int64) ([]domain.Sku
 
 func (f *fakeSkuRepository) Update(context.Context, domain.Sku) error { return nil }
 
+func (f *fakeSkuRepository) UpdateCost(context.Context, *sql.Tx, int64, float64) error { return nil }
+
 func (f *fakeSkuRepository) GetById(context.Context, int64) (domain.Sku, error) {
 	return domain.Sku{}, nil
 }
@@ -150,6 +190,10 @@ func (f *fakeSkuRepository) GetByManyIds(context.Context, []int64) ([]domain.Sku
 	return f.skus, f.err
 }
 
+func (f *fakeSkuRepository) GetByCode(context.Context, string) (domain.Sku, error) {
+	return domain.Sku{}, nil
+}
+
 func (f *fakeSkuRepository) GetAll(context.Context, serviceInput.GetSkusInput) ([]domain.Sku, error) {
 	return nil, nil
 }
@@ -159,6 +203,7 @@ func (f *fakeSkuRepository) Inactivate(context.Context, int64) error { return ni
 type fakeInventoryRepository struct {
 	byUser        domain.Inventory
 	byUserErr     error
+	byUserId      int64
 	primary       domain.Inventory
 	primaryErr    error
 	primaryCalled bool
@@ -180,7 +225,8 @@ func (f *fakeInventoryRepository) GetAll(context.Context) ([]domain.Inventory, e
 	return nil, nil
 }
 
-func (f *fakeInventoryRepository) GetByUserId(context.Context, int64) (domain.Inventory, error) {
+func (f *fakeInventoryRepository) GetByUserId(ctx context.Context, userId int64) (domain.Inventory, error) {
+	f.byUserId = userId
 	return f.byUser, f.byUserErr
 }
 
@@ -197,6 +243,149 @@ func (f *fakeInventoryRepository) GetSummaryById(context.Context, int64) (servic
 	return serviceOutput.GetInventorySummaryByIdOutput{}, nil
 }
 
+type fakeDiscountRuleRepository struct {
+	rule   domain.DiscountRule
+	getErr error
+	calls  int
+}
+
+func (f *fakeDiscountRuleRepository) Get(context.Context) (domain.DiscountRule, error) {
+	f.calls++
+	return f.rule, f.getErr
+}
+
+func (f *fakeDiscountRuleRepository) Save(context.Context, domain.DiscountRule) error {
+	return nil
+}
+
+type fakeCardFeeRuleRepository struct {
+	rules  []domain.CardFeeRule
+	getErr error
+	calls  int
+}
+
+func (f *fakeCardFeeRuleRepository) GetAll(context.Context) ([]domain.CardFeeRule, error) {
+	f.calls++
+	return f.rules, f.getErr
+}
+
+func (f *fakeCardFeeRuleRepository) Save(context.Context, domain.CardFeeRule) (int64, error) {
+	return 0, nil
+}
+
+func (f *fakeCardFeeRuleRepository) Delete(context.Context, int64) error {
+	return nil
+}
+
+type fakeLateFeeRuleRepository struct {
+	rule   domain.LateFeeRule
+	getErr error
+}
+
+func (f *fakeLateFeeRuleRepository) Get(context.Context) (domain.LateFeeRule, error) {
+	return f.rule, f.getErr
+}
+
+func (f *fakeLateFeeRuleRepository) Save(context.Context, domain.LateFeeRule) error {
+	return nil
+}
+
+type fakeCashRegisterRepository struct {
+	settings domain.CashRegisterSettings
+	open     *domain.CashRegisterSession
+	entries  []domain.CashRegisterEntry
+}
+
+func (f *fakeCashRegisterRepository) GetSettings(context.Context) (domain.CashRegisterSettings, error) {
+	return f.settings, nil
+}
+
+func (f *fakeCashRegisterRepository) SaveSettings(context.Context, domain.CashRegisterSettings) error {
+	return nil
+}
+
+func (f *fakeCashRegisterRepository) Open(context.Context, domain.CashRegisterSession) (int64, error) {
+	return 0, nil
+}
+
+func (f *fakeCashRegisterRepository) GetOpenByUserId(context.Context, int64) (domain.CashRegisterSession, error) {
+	if f.open == nil {
+		return domain.CashRegisterSession{}, domain.ErrCashRegisterSessionNotOpen
+	}
+	return *f.open, nil
+}
+
+func (f *fakeCashRegisterRepository) GetById(context.Context, int64) (domain.CashRegisterSession, error) {
+	return domain.CashRegisterSession{}, nil
+}
+
+func (f *fakeCashRegisterRepository) GetAll(context.Context, domain.GetCashRegisterSessionsInput) ([]domain.CashRegisterSession, error) {
+	return nil, nil
+}
+
+func (f *fakeCashRegisterRepository) GetEntries(context.Context, int64) ([]domain.CashRegisterEntry, error) {
+	return nil, nil
+}
+
+func (f *fakeCashRegisterRepository) CreateEntry(_ context.Context, _ *sql.Tx, entry domain.CashRegisterEntry) (int64, error) {
+	f.entries = append(f.entries, entry)
+	return int64(len(f.entries)), nil
+}
+
+func (f *fakeCashRegisterRepository) Close(context.Context, domain.CashRegisterSession) error {
+	return nil
+}
+
+type fakeQuoteRepository struct {
+	quote            domain.Quote
+	items            []domain.SalesItem
+	payments         []domain.QuotePayment
+	reserved         map[int64]float64
+	reservedErr      error
+	exceptQuoteId    int64
+	convertedQuoteId int64
+	convertedSalesId int64
+	convertErr       error
+}
+
+func (f *fakeQuoteRepository) Create(_ context.Context, _ *sql.Tx, quote domain.Quote) (int64, error) {
+	f.quote = quote
+	return 301, nil
+}
+
+func (f *fakeQuoteRepository) CreateItems(_ context.Context, _ *sql.Tx, _ int64, items []domain.SalesItem) error {
+	f.items = items
+	return nil
+}
+
+func (f *fakeQuoteRepository) CreatePayments(_ context.Context, _ *sql.Tx, _ int64, payments []domain.QuotePayment) error {
+	f.payments = payments
+	return nil
+}
+
+func (f *fakeQuoteRepository) GetAll(context.Context, domain.GetQuotesInput) ([]domain.GetQuotesOutput, error) {
+	return nil, nil
+}
+
+func (f *fakeQuoteRepository) GetById(context.Context, int64) (domain.Quote, error) {
+	return f.quote, nil
+}
+
+func (f *fakeQuoteRepository) Cancel(context.Context, int64) error {
+	return nil
+}
+
+func (f *fakeQuoteRepository) MarkAsConverted(_ context.Context, _ *sql.Tx, id int64, salesId int64) error {
+	f.convertedQuoteId = id
+	f.convertedSalesId = salesId
+	return f.convertErr
+}
+
+func (f *fakeQuoteRepository) GetReservedQuantitiesForUpdate(_ context.Context, _ *sql.Tx, _ int64, _ []int64, exceptQuoteId int64) (map[int64]float64, error) {
+	f.exceptQuoteId = exceptQuoteId
+	return f.reserved, f.reservedErr
+}
+
 type fakeInventoryItemRepository struct {
 	items    []domain.InventoryItem
 	itemsErr error
@@ -234,8 +423,14 @@ func (f *fakeInventoryItemRepository) GetBySkuId(context.Context, int64) ([]doma
 	return nil, nil
 }
 
+func (f *fakeInventoryItemRepository) GetTotalQuantityBySkuIds(context.Context, *sql.Tx, []int64) (map[int64]float64, error) {
+	return nil, nil
+}
+
 type fakeSalesRepository struct {
 	sale                     domain.Sales
+	versionAdjustment        float64
+	updatedDiscountAmount    *float64
 	saleItems                []domain.SalesItem
 	createManySaleItemCalls  int
 	payments                 []domain.SalesPayment
@@ -247,6 +442,10 @@ type fakeSalesRepository struct {
 	createDatesErr           error
 	createReturnErr          error
 	createReturnItemsErr     error
+	createCancellationErr    error
+	createEditErr            error
+	createExchangeErr        error
+	updateSaleCustomerErr    error
 	cancelPaymentDatesErr    error
 	updateSaleLastVersionErr error
 	saleByIdForUpdate        domain.SaleWithVersionOutput
@@ -257,6 +456,25 @@ type fakeSalesRepository struct {
 	paymentsByVersionErr     error
 	updateLastVersion        int
 	returnCreated            bool
+	cancellation             domain.SalesCancellation
+	cancellationCreated      bool
+	editCreated              bool
+	exchange                 domain.SalesExchange
+	exchangeCreated          bool
+	updatedCustomerId        int64
+	paymentDate              domain.SalesPaymentDates
+	paymentDateErr           error
+	receipts                 []domain.PaymentReceipt
+	receiptsErr              error
+	createdReceipt           *domain.PaymentReceipt
+	createReceiptErr         error
+	settledPaymentDateId     int64
+	settledDate              time.Time
+	settledLateFee           domain.LateFee
+	settleErr                error
+	renegotiation            domain.SalesRenegotiation
+	renegotiationCreated     bool
+	createRenegotiationErr   error
 }
 
 func (f *fakeSalesRepository) CreateSale(ctx context.Context, tx *sql.Tx, sale domain.Sales) (int64, error) {
@@ -267,11 +485,12 @@ func (f *fakeSalesRepository) CreateSale(ctx context.Context, tx *sql.Tx, sale d
 	return 101, nil
 }
 
-func (f *fakeSalesRepository) CreateSaleVersion(ctx context.Context, tx *sql.Tx, saleId int64, version int, date time.Time) (int64, error) {
+func (f *fakeSalesRepository) CreateSaleVersion(ctx context.Context, tx *sql.Tx, saleId int64, version int, date time.Time, adjustmentAmount float64) (int64, error) {
 	if f.createSaleVersionErr != nil {
 		return 0, f.createSaleVersionErr
 	}
-	return 1001, nil
+	f.versionAdjustment = adjustmentAmount
+	return 2001, nil
 }
 
 func (f *fakeSalesRepository) CreateManySaleItem(ctx context.Context, tx *sql.Tx, sale domain.Sales, items []domain.SalesItem) ([]int64, error) {
@@ -315,6 +534,45 @@ func (f *fakeSalesRepository) CreateSalesReturnItems(ctx context.Context, tx *sq
 	return []int64{1}, nil
 }
 
+func (f *fakeSalesRepository) CreateSalesCancellation(ctx context.Context, tx *sql.Tx, saleId int64, fromSalesVersionId int64, toSalesVersionId int64, cancellation domain.SalesCancellation, createdByUserId int64) (int64, error) {
+	if f.createCancellationErr != nil {
+		return 0, f.createCancellationErr
+	}
+	f.cancellation = cancellation
+	f.cancellationCreated = true
+	return 1, nil
+}
+
+func (f *fakeSalesRepository) CreateSalesExchange(ctx context.Context, tx *sql.Tx, saleId int64, fromSalesVersionId int64, toSalesVersionId int64, salesReturnId int64, exchange domain.SalesExchange, createdByUserId int64) (int64, error) {
+	if f.createExchangeErr != nil {
+		return 0, f.createExchangeErr
+	}
+	f.exchange = exchange
+	f.exchangeCreated = true
+	return 1, nil
+}
+
+func (f *fakeSalesRepository) CreateSalesEdit(ctx context.Context, tx *sql.Tx, saleId int64, fromSalesVersionId int64, toSalesVersionId int64, editDate time.Time, createdByUserId int64) (int64, error) {
+	if f.createEditErr != nil {
+		return 0, f.createEditErr
+	}
+	f.editCreated = true
+	return 1, nil
+}
+
+func (f *fakeSalesRepository) UpdateSaleCustomer(ctx context.Context, tx *sql.Tx, saleId int64, customerId int64) error {
+	if f.updateSaleCustomerErr != nil {
+		return f.updateSaleCustomerErr
+	}
+	f.updatedCustomerId = customerId
+	return nil
+}
+
+func (f *fakeSalesRepository) UpdateSaleDiscountAmount(ctx context.Context, tx *sql.Tx, saleId int64, discountAmount float64) error {
+	f.updatedDiscountAmount = &discountAmount
+	return nil
+}
+
 func (f *fakeSalesRepository) UpdateSaleLastVersion(ctx context.Context, tx *sql.Tx, saleId int64, version int) error {
 	if f.updateSaleLastVersionErr != nil {
 		return f.updateSaleLastVersionErr
@@ -342,6 +600,10 @@ func (f *fakeSalesRepository) GetSales(context.Context, serviceInput.GetSalesInp
 	return nil, nil
 }
 
+func (f *fakeSalesRepository) GetSalesTotals(context.Context, serviceInput.GetSalesInput) (domain.GetSalesTotalsOutput, error) {
+	return domain.GetSalesTotalsOutput{}, nil
+}
+
 func (f *fakeSalesRepository) GetSaleById(context.Context, int64) (serviceOutput.GetSaleByIdOutput, error) {
 	return serviceOutput.GetSaleByIdOutput{}, nil
 }
@@ -366,25 +628,70 @@ func (f *fakeSalesRepository) GetReturnsBySaleId(context.Context, int64) ([]serv
 	return nil, nil
 }
 
-func (f *fakeSalesRepository) ChangePaymentStatus(context.Context, int64, domain.PaymentStatus) (int64, error) {
-	return 0, nil
+func (f *fakeSalesRepository) GetVersionsBySaleId(context.Context, int64) ([]domain.GetSalesVersionOutput, error) {
+	return nil, nil
 }
 
-func (f *fakeSalesRepository) ChangePaymentDate(context.Context, int64, *time.Time) (int64, error) {
+func (f *fakeSalesRepository) ChangePaymentStatus(context.Context, int64, domain.PaymentStatus) (int64, error) {
 	return 0, nil
 }
 
 func (f *fakeSalesRepository) GetPaymentDatesBySaleIdAndPaymentDateId(context.Context, int64, int64) (domain.SalesPaymentDates, error) {
-	return domain.SalesPaymentDates{}, nil
+	return f.paymentDate, f.paymentDateErr
+}
+
+func (f *fakeSalesRepository) CreateSalesRenegotiation(ctx context.Context, tx *sql.Tx, saleId int64, fromSalesVersionId int64, toSalesVersionId int64, renegotiation domain.SalesRenegotiation, createdByUserId int64) (int64, error) {
+	if f.createRenegotiationErr != nil {
+		return 0, f.createRenegotiationErr
+	}
+	f.renegotiation = renegotiation
+	f.renegotiationCreated = true
+	return 1, nil
+}
+
+func (f *fakeSalesRepository) GetRenegotiationsBySaleId(context.Context, int64) ([]serviceOutput.GetSalesRenegotiationOutput, error) {
+	return nil, nil
+}
+
+func (f *fakeSalesRepository) CreatePaymentReceipt(ctx context.Context, tx *sql.Tx, receipt domain.PaymentReceipt) (int64, error) {
+	if f.createReceiptErr != nil {
+		return 0, f.createReceiptErr
+	}
+	f.createdReceipt = &receipt
+	return 1, nil
+}
+
+func (f *fakeSalesRepository) SettlePaymentDate(ctx context.Context, tx *sql.Tx, paymentDateId int64, paidDate time.Time, lateFee domain.LateFee) error {
+	if f.settleErr != nil {
+		return f.settleErr
+	}
+	f.settledPaymentDateId = paymentDateId
+	f.settledDate = paidDate
+	f.settledLateFee = lateFee
+	return nil
+}
+
+func (f *fakeSalesRepository) GetPaymentReceiptsByPaymentDateId(ctx context.Context, paymentDateId int64) ([]domain.PaymentReceipt, error) {
+	return f.receipts, f.receiptsErr
 }
 
 type fakeInventoryUseCase struct {
 	received inventory_usecase.DoTransactionInput
+	calls    []inventory_usecase.DoTransactionInput
 	err      error
 }
 
 func (f *fakeInventoryUseCase) DoTransaction(ctx context.Context, tx *sql.Tx, input inventory_usecase.DoTransactionInput) error {
 	f.received = input
+	f.calls = append(f.calls, input)
+	return f.err
+}
+
+func (f *fakeInventoryUseCase) DoAdjustment(ctx context.Context, tx *sql.Tx, input inventory_usecase.DoAdjustmentInput) error {
+	return f.err
+}
+
+func (f *fakeInventoryUseCase) DoTransferAll(ctx context.Context, tx *sql.Tx, input inventory_usecase.DoTransferAllInput) error {
 	return f.err
 }
 
@@ -401,11 +708,17 @@ type saleTestEnv struct {
 	useCase           SalesUseCase
 	userRepo          *fakeUserRepository
 	customerRepo      *fakeCustomerRepository
+	creditRepo        *fakeCustomerCreditRepository
 	skuRepo           *fakeSkuRepository
 	inventoryRepo     *fakeInventoryRepository
 	inventoryItemRepo *fakeInventoryItemRepository
 	salesRepo         *fakeSalesRepository
 	inventoryUseCase  *fakeInventoryUseCase
+	discountRuleRepo  *fakeDiscountRuleRepository
+	quoteRepo         *fakeQuoteRepository
+	cardFeeRuleRepo   *fakeCardFeeRuleRepository
+	cashRegisterRepo  *fakeCashRegisterRepository
+	lateFeeRuleRepo   *fakeLateFeeRuleRepository
 	input             DoSaleInput
 }
 
@@ -416,6 +729,7 @@ func newSaleTestEnv(t *testing.T) saleTestEnv {
 	sku := domain.Sku{Id: 3, Code: "SKU1", Price: 10, Quantity: 5, Product: domain.Product{Name: "Prod"}}
 	userRepo := &fakeUserRepository{user: user}
 	customerRepo := &fakeCustomerRepository{customer: customer}
+	creditRepo := &fakeCustomerCreditRepository{}
 	skuRepo := &fakeSkuRepository{skus: []domain.Sku{sku}}
 	inventory := domain.Inventory{Id: 4}
 	inventoryRepo := &fakeInventoryRepository{byUser: inventory}
@@ -423,6 +737,11 @@ func newSaleTestEnv(t *testing.T) saleTestEnv {
 	inventoryItemRepo := &fakeInventoryItemRepository{items: inventoryItems}
 	salesRepo := &fakeSalesRepository{}
 	inventoryUC := &fakeInventoryUseCase{}
+	discountRuleRepo := &fakeDiscountRuleRepository{}
+	quoteRepo := &fakeQuoteRepository{}
+	cardFeeRuleRepo := &fakeCardFeeRuleRepository{}
+	cashRegisterRepo := &fakeCashRegisterRepository{}
+	lateFeeRuleRepo := &fakeLateFeeRuleRepository{}
 
 	input := DoSaleInput{
 		UserId:     user.Id,
@@ -442,24 +761,30 @@ func newSaleTestEnv(t *testing.T) saleTestEnv {
 		}},
 	}
 
-	useCase := NewSalesUseCase(userRepo, customerRepo, skuRepo, salesRepo, inventoryUC, inventoryRepo, inventoryItemRepo, repo)
+	useCase := NewSalesUseCase(userRepo, customerRepo, creditRepo, skuRepo, salesRepo, inventoryUC, inventoryRepo, inventoryItemRepo, discountRuleRepo, quoteRepo, cardFeeRuleRepo, cashRegisterRepo, lateFeeRuleRepo, repo)
 
 	return saleTestEnv{
 		useCase:           useCase,
 		userRepo:          userRepo,
 		customerRepo:      customerRepo,
+		creditRepo:        creditRepo,
 		skuRepo:           skuRepo,
 		inventoryRepo:     inventoryRepo,
 		inventoryItemRepo: inventoryItemRepo,
 		salesRepo:         salesRepo,
 		inventoryUseCase:  inventoryUC,
+		discountRuleRepo:  discountRuleRepo,
+		quoteRepo:         quoteRepo,
+		cardFeeRuleRepo:   cardFeeRuleRepo,
+		cashRegisterRepo:  cashRegisterRepo,
+		lateFeeRuleRepo:   lateFeeRuleRepo,
 		input:             input,
 	}
 }
 
 func TestNewSalesUseCase(t *testing.T) {
 	repo := newStubRepository(t)
-	uc := NewSalesUseCase(&fakeUserRepository{}, &fakeCustomerRepository{}, &fakeSkuRepository{}, &fakeSalesRepository{}, &fakeInventoryUseCase{}, &fakeInventoryRepository{}, &fakeInventoryItemRepository{}, repo)
+	uc := NewSalesUseCase(&fakeUserRepository{}, &fakeCustomerRepository{}, &fakeCustomerCreditRepository{}, &fakeSkuRepository{}, &fakeSalesRepository{}, &fakeInventoryUseCase{}, &fakeInventoryRepository{}, &fakeInventoryItemRepository{}, &fakeDiscountRuleRepository{}, &fakeQuoteRepository{}, &fakeCardFeeRuleRepository{}, &fakeCashRegisterRepository{}, &fakeLateFeeRuleRepository{}, repo)
 	impl, ok := uc.(*salesUseCase)
 	if !ok {
 		t.Fatalf("expected concrete sales use case type")
@@ -502,6 +827,162 @@ func TestSalesUseCaseDoSaleSuccess(t *testing.T) {
 	}
 }
 
+func TestSalesUseCaseDoSaleWithCardFees(t *testing.T) {
+	env := newSaleTestEnv(t)
+	env.cardFeeRuleRepo.rules = []domain.CardFeeRule{domain.NewCardFeeRule(domain.CardBrandVisa, domain.PaymentTypeDebitCard, 1, 2, 1)}
+	env.input.Payments[0].PaymentType = domain.PaymentTypeDebitCard
+	env.input.Payments[0].CardBrand = domain.CardBrandVisa
+
+	if err := env.useCase.DoSale(context.Background(), env.input); err != nil {
+		t.Fatalf("expected sale to succeed, got %v", err)
+	}
+
+	if env.salesRepo.payments[0].CardBrand != domain.CardBrandVisa {
+		t.Fatalf("expected card brand to be persisted, got %+v", env.salesRepo.payments[0])
+	}
+	date := env.salesRepo.paymentDates[0][0]
+	if date.CardFeeValue != 0.4 || date.SettlementDate == nil {
+		t.Fatalf("expected card fee and settlement date, got %+v", date)
+	}
+
+	cashEnv := newSaleTestEnv(t)
+	if err := cashEnv.useCase.DoSale(context.Background(), cashEnv.input); err != nil {
+		t.Fatalf("expected sale to succeed, got %v", err)
+	}
+	if cashEnv.cardFeeRuleRepo.calls != 0 {
+		t.Fatalf("expected card fees not to be loaded for cash sales")
+	}
+
+	errEnv := newSaleTestEnv(t)
+	errEnv.cardFeeRuleRepo.getErr = stdErrors.New("db")
+	errEnv.input.Payments[0].PaymentType = domain.PaymentTypeCreditCard
+	if err := errEnv.useCase.DoSale(context.Background(), errEnv.input); err == nil {
+		t.Fatalf("expected card fee repository error")
+	}
+}
+
+func TestSalesUseCaseDoSaleRegistersCash(t *testing.T) {
+	env := newSaleTestEnv(t)
+	open := domain.NewCashRegisterSession(domain.User{Id: 1}, 0, time.Now())
+	open.Id = 6
+	env.cashRegisterRepo.open = &open
+	env.input.Payments[0].Dates[0].DueDate = time.Now()
+	env.input.Payments[0].Dates[0].DateInformed = true
+
+	if err := env.useCase.DoSale(context.Background(), env.input); err != nil {
+		t.Fatalf("expected sale to succeed, got %v", err)
+	}
+	if len(env.cashRegisterRepo.entries) != 1 {
+		t.Fatalf("expected cash entry, got %+v", env.cashRegisterRepo.entries)
+	}
+	entry := env.cashRegisterRepo.entries[0]
+	if entry.Type != domain.CashRegisterEntryTypeSale || entry.Value != 20 || entry.SessionId != 6 || entry.SalesId != 101 {
+		t.Fatalf("unexpected cash entry: %+v", entry)
+	}
+
+	pendingEnv := newSaleTestEnv(t)
+	pendingEnv.cashRegisterRepo.open = &open
+	if err := pendingEnv.useCase.DoSale(context.Background(), pendingEnv.input); err != nil {
+		t.Fatalf("expected sale to succeed, got %v", err)
+	}
+	if len(pendingEnv.cashRegisterRepo.entries) != 0 {
+		t.Fatalf("expected pending cash not to enter the register")
+	}
+
+	requiredEnv := newSaleTestEnv(t)
+	requiredEnv.cashRegisterRepo.settings.RequireOpenSession = true
+	if err := requiredEnv.useCase.DoSale(context.Background(), requiredEnv.input); err != domain.ErrCashRegisterSessionRequired {
+		t.Fatalf("expected open cash register to be required, got %v", err)
+	}
+	if requiredEnv.salesRepo.sale.Id != 0 {
+		t.Fatalf("expected sale not to be created")
+	}
+}
+
+func TestSalesUseCaseDoSaleWithDiscounts(t *testing.T) {
+	env := newSaleTestEnv(t)
+	env.discountRuleRepo.rule = domain.NewDiscountRule(15)
+	env.input.Items[0].Discount = domain.NewSalesDiscount(domain.DiscountTypePercentage, 5, "Avaria na embalagem")
+	env.input.Discount = domain.NewSalesDiscount(domain.DiscountTypeFixed, 1, "Cliente fiel")
+	env.input.Payments[0].Dates[0].InstallmentValue = 18
+
+	if err := env.useCase.DoSale(context.Background(), env.input); err != nil {
+		t.Fatalf("expected sale to succeed, got %v", err)
+	}
+	item := env.salesRepo.saleItems[0]
+	if item.DiscountAmount != 1 || item.SaleDiscountAmount != 1 || item.Discount.Reason != "Avaria na embalagem" {
+		t.Fatalf("expected discounts to be persisted with the item, got %+v", item)
+	}
+	if env.salesRepo.sale.Discount.Reason != "Cliente fiel" {
+		t.Fatalf("expected sale discount to be persisted, got %+v", env.salesRepo.sale.Discount)
+	}
+	if env.discountRuleRepo.calls != 1 {
+		t.Fatalf("expected reseller limit to be checked")
+	}
+}
+
+func TestSalesUseCaseDoSaleDiscountOverResellerLimit(t *testing.T) {
+	env := newSaleTestEnv(t)
+	env.discountRuleRepo.rule = domain.NewDiscountRule(5)
+	env.input.Discount = domain.NewSalesDiscount(domain.DiscountTypePercentage, 10, "Cliente fiel")
+	env.input.Payments[0].Dates[0].InstallmentValue = 18
+
+	err := env.useCase.DoSale(context.Background(), env.input)
+	if err == nil || !strings.HasPrefix(err.Error(), domain.ErrDiscountOverResellerLimit.Error()) {
+		t.Fatalf("expected reseller limit error, got %v", err)
+	}
+	if env.salesRepo.createManySaleItemCalls != 0 {
+		t.Fatalf("expected sale not to be created")
+	}
+
+	env = newSaleTestEnv(t)
+	env.discountRuleRepo.getErr = stdErrors.New("rule error")
+	env.input.Discount = domain.NewSalesDiscount(domain.DiscountTypePercentage, 10, "Cliente fiel")
+	env.input.Payments[0].Dates[0].InstallmentValue = 18
+	if err := env.useCase.DoSale(context.Background(), env.input); err == nil || err.Error() != "rule error" {
+		t.Fatalf("expected rule error, got %v", err)
+	}
+}
+
+func TestSalesUseCaseDoSaleDiscountApprovedByAdmin(t *testing.T) {
+	env := newSaleTestEnv(t)
+	env.discountRuleRepo.rule = domain.NewDiscountRule(5)
+	env.userRepo.users = map[int64]domain.User{
+		7: {Id: 7, Role: string(domain.UserRoleAdmin)},
+		8: {Id: 8, Role: string(domain.UserRoleReseller)},
+	}
+	env.input.Discount = domain.NewSalesDiscount(domain.DiscountTypePercentage, 10, "Cliente fiel")
+	env.input.Payments[0].Dates[0].InstallmentValue = 18
+
+	env.input.DiscountApproverId = 8
+	err := env.useCase.DoSale(context.Background(), env.input)
+	if err == nil || !strings.HasPrefix(err.Error(), domain.ErrDiscountOverResellerLimit.Error()) {
+		t.Fatalf("expected reseller approval to be refused, got %v", err)
+	}
+
+	env.input.DiscountApproverId = 7
+	if err := env.useCase.DoSale(context.Background(), env.input); err != nil {
+		t.Fatalf("expected admin approval to accept the discount, got %v", err)
+	}
+	if env.salesRepo.sale.Discount.Reason != "Cliente fiel" {
+		t.Fatalf("expected sale to be created with the approved discount, got %+v", env.salesRepo.sale.Discount)
+	}
+}
+
+func TestSalesUseCaseDoSaleAdminSkipsDiscountLimit(t *testing.T) {
+	env := newSaleTestEnv(t)
+	env.userRepo.user.Role = string(domain.UserRoleAdmin)
+	env.input.Discount = domain.NewSalesDiscount(domain.DiscountTypePercentage, 50, "Queima de estoque")
+	env.input.Payments[0].Dates[0].InstallmentValue = 10
+
+	if err := env.useCase.DoSale(context.Background(), env.input); err != nil {
+		t.Fatalf("expected admin discount to be accepted, got %v", err)
+	}
+	if env.discountRuleRepo.calls != 0 {
+		t.Fatalf("expected limit not to be checked for admins")
+	}
+}
+
 func TestSalesUseCaseDoSaleUserError(t *testing.T) {
 	env := newSaleTestEnv(t)
 	expectedErr := stdErrors.New("user not found")
@@ -660,6 +1141,82 @@ func TestSalesUseCaseDoSaleInventoryTransactionError(t *testing.T) {
 	}
 }
 
+func TestSalesUseCaseDoSaleCustomerCredit(t *testing.T) {
+	newCreditInput := func(env saleTestEnv) DoSaleInput {
+		input := env.input
+		input.Payments = []DoSalePaymentsInput{{
+			PaymentType: domain.PaymentTypeReturn,
+			Dates: []DoSalePaymentDatesInput{{
+				DueDate:           time.Now(),
+				InstallmentNumber: 1,
+				InstallmentValue:  20,
+			}},
+		}}
+		return input
+	}
+
+	t.Run("debits balance", func(t *testing.T) {
+		env := newSaleTestEnv(t)
+		env.creditRepo.balance = 25
+
+		if err := env.useCase.DoSale(context.Background(), newCreditInput(env)); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(env.creditRepo.created) != 1 {
+			t.Fatalf("expected one credit movement, got %+v", env.creditRepo.created)
+		}
+		debit := env.creditRepo.created[0]
+		if debit.Type != domain.CustomerCreditTypeDebit || debit.Origin != domain.CustomerCreditOriginSale || debit.Value != 20 || debit.CustomerId != 2 || debit.CreatedByUserId != 1 {
+			t.Fatalf("unexpected debit: %+v", debit)
+		}
+	})
+
+	t.Run("insufficient balance", func(t *testing.T) {
+		env := newSaleTestEnv(t)
+		env.creditRepo.balance = 10
+
+		err := env.useCase.DoSale(context.Background(), newCreditInput(env))
+		if err == nil || !strings.Contains(err.Error(), domain.ErrCustomerCreditInsufficient.Error()) {
+			t.Fatalf("expected insufficient credit error, got %v", err)
+		}
+		if len(env.creditRepo.created) != 0 {
+			t.Fatalf("expected no credit movement, got %+v", env.creditRepo.created)
+		}
+	})
+
+	t.Run("balance error", func(t *testing.T) {
+		env := newSaleTestEnv(t)
+		expectedErr := stdErrors.New("balance error")
+		env.creditRepo.balanceErr = expectedErr
+
+		if err := env.useCase.DoSale(context.Background(), env.input); err != expectedErr {
+			t.Fatalf("expected error %v, got %v", expectedErr, err)
+		}
+	})
+
+	t.Run("create error", func(t *testing.T) {
+		env := newSaleTestEnv(t)
+		expectedErr := stdErrors.New("create error")
+		env.creditRepo.balance = 20
+		env.creditRepo.createErr = expectedErr
+
+		if err := env.useCase.DoSale(context.Background(), newCreditInput(env)); err != expectedErr {
+			t.Fatalf("expected error %v, got %v", expectedErr, err)
+		}
+	})
+
+	t.Run("no debit without credit payment", func(t *testing.T) {
+		env := newSaleTestEnv(t)
+
+		if err := env.useCase.DoSale(context.Background(), env.input); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(env.creditRepo.created) != 0 {
+			t.Fatalf("expected no credit movement, got %+v", env.creditRepo.created)
+		}
+	})
+}
+
 func TestSalesUseCaseValidateDuplicatedSkus(t *testing.T) {
 	sku := domain.Sku{Id: 1, Code: "SKU1", Product: domain.Product{Name: "Prod"}}
 	useCase := &salesUseCase{}
@@ -768,17 +1325,84 @@ func TestSalesUseCaseDoReturnSuccess(t *testing.T) {
 	if env.inventoryUseCase.received.Type != domain.InventoryTransactionTypeIn {
 		t.Fatalf("expected inventory IN transaction")
 	}
+	if len(env.creditRepo.created) != 1 {
+		t.Fatalf("expected refund to be credited, got %+v", env.creditRepo.created)
+	}
+	credit := env.creditRepo.created[0]
+	if credit.Type != domain.CustomerCreditTypeCredit || credit.Origin != domain.CustomerCreditOriginReturn || credit.Value != 10 || credit.SalesId != 101 {
+		t.Fatalf("unexpected credit: %+v", credit)
+	}
 }
 
-func TestSalesUseCaseDoReturnValidationError(t *testing.T) {
+func TestSalesUseCaseDoReturnRefundsDiscountedPrice(t *testing.T) {
 	env := newSaleTestEnv(t)
-	env.salesRepo.saleByIdForUpdate = domain.SaleWithVersionOutput{
-		Id:             101,
-		LastVersion:    1,
-		SalesVersionId: 1001,
-	}
+	env.salesRepo.saleByIdForUpdate = domain.SaleWithVersionOutput{Id: 101, LastVersion: 1, SalesVersionId: 1001}
 	env.salesRepo.itemsByVersion = []serviceOutput.GetItemsOutput{
-		{Sku: domain.Sku{Id: 3, Price: 10, Product: domain.Product{Name: "Prod"}}, Quantity: 1, UnitPrice: 10},
+		{Sku: domain.Sku{Id: 3, Price: 10, Product: domain.Product{Name: "Prod"}}, Quantity: 2, UnitPrice: 10, DiscountAmount: 2, SaleDiscountAmount: 1},
+	}
+	env.salesRepo.paymentsByVersion = []serviceOutput.GetSalesPaymentOutput{
+		{PaymentType: domain.PaymentTypeCash, InstallmentNumber: 1, InstallmentValue: 17, DueDate: time.Now(), PaymentStatus: domain.PaymentStatusPaid},
+	}
+
+	err := env.useCase.DoReturn(context.Background(), DoReturnInput{
+		SaleId:       101,
+		UserId:       1,
+		ReturnerName: "Cliente",
+		Reason:       "Defeito",
+		Items:        []DoReturnItemInput{{SkuId: 3, Quantity: 1}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	remaining := env.salesRepo.saleItems[0]
+	if remaining.Quantity != 1 || remaining.DiscountAmount != 1 || remaining.SaleDiscountAmount != 0.5 {
+		t.Fatalf("expected discount to follow remaining quantity, got %+v", remaining)
+	}
+	if len(env.creditRepo.created) != 1 || env.creditRepo.created[0].Value != 8.5 {
+		t.Fatalf("expected refund of discounted price, got %+v", env.creditRepo.created)
+	}
+	if env.salesRepo.updatedDiscountAmount == nil || *env.salesRepo.updatedDiscountAmount != 0.5 {
+		t.Fatalf("expected sale discount to follow the remaining items, got %v", env.salesRepo.updatedDiscountAmount)
+	}
+}
+
+func TestSalesUseCaseDoReturnCreditError(t *testing.T) {
+	env := newSaleTestEnv(t)
+	env.salesRepo.saleByIdForUpdate = domain.SaleWithVersionOutput{
+		Id:             101,
+		LastVersion:    1,
+		SalesVersionId: 1001,
+	}
+	env.salesRepo.itemsByVersion = []serviceOutput.GetItemsOutput{
+		{Sku: domain.Sku{Id: 3, Price: 10, Product: domain.Product{Name: "Prod"}}, Quantity: 2, UnitPrice: 10},
+	}
+	env.salesRepo.paymentsByVersion = []serviceOutput.GetSalesPaymentOutput{
+		{PaymentType: domain.PaymentTypeCash, InstallmentNumber: 1, InstallmentValue: 20, DueDate: time.Now(), PaymentStatus: domain.PaymentStatusPaid},
+	}
+	expectedErr := stdErrors.New("credit error")
+	env.creditRepo.createErr = expectedErr
+
+	err := env.useCase.DoReturn(context.Background(), DoReturnInput{
+		SaleId:       101,
+		UserId:       1,
+		ReturnerName: "Cliente",
+		Reason:       "Defeito",
+		Items:        []DoReturnItemInput{{SkuId: 3, Quantity: 1}},
+	})
+	if err != expectedErr {
+		t.Fatalf("expected error %v, got %v", expectedErr, err)
+	}
+}
+
+func TestSalesUseCaseDoReturnValidationError(t *testing.T) {
+	env := newSaleTestEnv(t)
+	env.salesRepo.saleByIdForUpdate = domain.SaleWithVersionOutput{
+		Id:             101,
+		LastVersion:    1,
+		SalesVersionId: 1001,
+	}
+	env.salesRepo.itemsByVersion = []serviceOutput.GetItemsOutput{
+		{Sku: domain.Sku{Id: 3, Price: 10, Product: domain.Product{Name: "Prod"}}, Quantity: 1, UnitPrice: 10},
 	}
 
 	err := env.useCase.DoReturn(context.Background(), DoReturnInput{
@@ -941,7 +1565,7 @@ func TestSalesUseCaseRecalculatePaymentsCreatesCreditStoreWhenNoPending(t *testi
 		{Sku: domain.Sku{Price: 30}, Quantity: 1},
 	}
 
-	payments := uc.recalculatePayments(old, newItems)
+	payments := uc.recalculatePayments(old, newItems, 0)
 	if len(payments) != 2 {
 		t.Fatalf("expected 2 payment groups, got %d", len(payments))
 	}
@@ -976,7 +1600,7 @@ func TestSalesUseCaseRecalculatePaymentsCreatesReturnWhenOverpaid(t *testing.T)
 		{Sku: domain.Sku{Price: 30}, Quantity: 1},
 	}
 
-	payments := uc.recalculatePayments(old, newItems)
+	payments := uc.recalculatePayments(old, newItems, 0)
 	foundReturn := false
 	for _, p := range payments {
 		if p.PaymentType == domain.PaymentTypeReturn {
@@ -1020,7 +1644,7 @@ func TestSalesUseCaseRecalculatePaymentsDistributesPendingAmounts(t *testing.T)
 		{Sku: domain.Sku{Price: 10}, Quantity: 1},
 	}
 
-	payments := uc.recalculatePayments(old, newItems)
+	payments := uc.recalculatePayments(old, newItems, 0)
 	if len(payments) != 1 {
 		t.Fatalf("expected 1 payment group, got %d", len(payments))
 	}
@@ -1057,7 +1681,7 @@ func TestSalesUseCaseRecalculatePaymentsKeepsPreviousReversalsSeparated(t *testi
 		{Sku: domain.Sku{Price: 60}, Quantity: 1},
 	}
 
-	payments := uc.recalculatePayments(old, newItems)
+	payments := uc.recalculatePayments(old, newItems, 0)
 	var returnPayment *domain.SalesPayment
 	for i := range payments {
 		if payments[i].PaymentType == domain.PaymentTypeReturn {
@@ -1078,3 +1702,1218 @@ func TestSalesUseCaseRecalculatePaymentsKeepsPreviousReversalsSeparated(t *testi
 		t.Fatalf("expected reversal status for both entries, got %+v", returnPayment.Dates)
 	}
 }
+
+func newCancelTestEnv(t *testing.T) saleTestEnv {
+	env := newSaleTestEnv(t)
+	env.salesRepo.saleByIdForUpdate = domain.SaleWithVersionOutput{
+		Id:             101,
+		UserId:         1,
+		LastVersion:    1,
+		SalesVersionId: 1001,
+	}
+	env.salesRepo.itemsByVersion = []serviceOutput.GetItemsOutput{
+		{Sku: domain.Sku{Id: 3, Price: 10, Product: domain.Product{Name: "Prod"}}, Quantity: 2, UnitPrice: 10},
+	}
+	now := time.Now()
+	env.salesRepo.paymentsByVersion = []serviceOutput.GetSalesPaymentOutput{
+		{PaymentType: domain.PaymentTypeCreditStore, InstallmentNumber: 1, InstallmentValue: 10, DueDate: now, PaidDate: &now, PaymentStatus: domain.PaymentStatusPaid},
+		{PaymentType: domain.PaymentTypeCreditStore, InstallmentNumber: 2, InstallmentValue: 10, DueDate: now.AddDate(0, 1, 0), PaymentStatus: domain.PaymentStatusPending},
+	}
+	return env
+}
+
+func TestSalesUseCaseDoCancelPermission(t *testing.T) {
+	env := newCancelTestEnv(t)
+	env.salesRepo.saleByIdForUpdate.UserId = 99
+
+	if err := env.useCase.DoCancel(context.Background(), DoCancelInput{SaleId: 101, UserId: 1, Reason: "Venda errada"}); err != domain.ErrSaleEditNotAllowed {
+		t.Fatalf("expected cancel not allowed error, got %v", err)
+	}
+	if env.salesRepo.cancellationCreated || len(env.inventoryUseCase.calls) != 0 {
+		t.Fatalf("expected sale not to be cancelled")
+	}
+
+	env.userRepo.user.Role = string(domain.UserRoleAdmin)
+	env.userRepo.users = map[int64]domain.User{99: {Id: 99, Role: string(domain.UserRoleReseller)}}
+	if err := env.useCase.DoCancel(context.Background(), DoCancelInput{SaleId: 101, UserId: 1, Reason: "Venda errada"}); err != nil {
+		t.Fatalf("expected admin to cancel any sale, got %v", err)
+	}
+	if !env.salesRepo.cancellationCreated {
+		t.Fatalf("expected cancellation to be created")
+	}
+}
+
+func TestSalesUseCaseDoCancelSuccess(t *testing.T) {
+	env := newCancelTestEnv(t)
+
+	err := env.useCase.DoCancel(context.Background(), DoCancelInput{SaleId: 101, UserId: 1, Reason: "  Venda errada  "})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !env.salesRepo.cancellationCreated || env.salesRepo.cancellation.Reason != "Venda errada" {
+		t.Fatalf("expected cancellation to be created with trimmed reason, got %+v", env.salesRepo.cancellation)
+	}
+	if env.salesRepo.createManySaleItemCalls != 0 {
+		t.Fatalf("expected new version without items, got %d CreateManySaleItem calls", env.salesRepo.createManySaleItemCalls)
+	}
+	if env.salesRepo.updateLastVersion != 2 {
+		t.Fatalf("expected last version to be updated to 2, got %d", env.salesRepo.updateLastVersion)
+	}
+
+	var reversal *domain.SalesPaymentDates
+	for i, payment := range env.salesRepo.payments {
+		if payment.PaymentType == domain.PaymentTypeReturn {
+			reversal = &env.salesRepo.paymentDates[i][0]
+		}
+		if payment.PaymentType == domain.PaymentTypeCreditStore {
+			for _, date := range env.salesRepo.paymentDates[i] {
+				if date.Status != domain.PaymentStatusPaid {
+					t.Fatalf("expected only settled installments to be carried over, got %+v", date)
+				}
+			}
+		}
+	}
+	if reversal == nil || reversal.InstallmentValue != -10 || reversal.Status != domain.PaymentStatusReversal {
+		t.Fatalf("expected paid amount to be reversed, got %+v", reversal)
+	}
+	if len(env.creditRepo.created) != 1 || env.creditRepo.created[0].Type != domain.CustomerCreditTypeCredit || env.creditRepo.created[0].Value != 10 || env.creditRepo.created[0].SalesVersionId != 2001 {
+		t.Fatalf("expected paid amount to be credited to the customer, got %+v", env.creditRepo.created)
+	}
+
+	received := env.inventoryUseCase.received
+	if received.Type != domain.InventoryTransactionTypeIn || received.InventoryDestinationId != env.inventoryRepo.byUser.Id {
+		t.Fatalf("expected stock to go back to seller inventory, got %+v", received)
+	}
+	if len(received.Skus) != 1 || received.Skus[0].SkuId != 3 || received.Skus[0].Quantity != 2 {
+		t.Fatalf("expected every remaining sku to be restocked, got %+v", received.Skus)
+	}
+	if received.Sale.SalesVersionId != 2001 || received.Sale.Id != 101 {
+		t.Fatalf("expected transaction to be linked to the new sale version, got %+v", received.Sale)
+	}
+}
+
+func TestSalesUseCaseDoCancelAdminSellerUsesPrimaryInventory(t *testing.T) {
+	env := newCancelTestEnv(t)
+	env.userRepo.user.Role = string(domain.UserRoleAdmin)
+	env.inventoryRepo.byUserErr = domain.ErrInventoryNotFound
+	env.inventoryRepo.primary = domain.Inventory{Id: 9}
+
+	if err := env.useCase.DoCancel(context.Background(), DoCancelInput{SaleId: 101, UserId: 1, Reason: "Erro"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if env.inventoryUseCase.received.InventoryDestinationId != 9 {
+		t.Fatalf("expected primary inventory as destination, got %d", env.inventoryUseCase.received.InventoryDestinationId)
+	}
+}
+
+func TestSalesUseCaseDoCancelAlreadyCancelled(t *testing.T) {
+	env := newCancelTestEnv(t)
+	env.salesRepo.itemsByVersion = nil
+
+	err := env.useCase.DoCancel(context.Background(), DoCancelInput{SaleId: 101, UserId: 1, Reason: "Erro"})
+	if err != domain.ErrSaleAlreadyCancelled {
+		t.Fatalf("expected already cancelled error, got %v", err)
+	}
+	if env.salesRepo.cancellationCreated {
+		t.Fatalf("expected no cancellation to be created")
+	}
+}
+
+func TestSalesUseCaseDoCancelReasonRequired(t *testing.T) {
+	env := newCancelTestEnv(t)
+
+	err := env.useCase.DoCancel(context.Background(), DoCancelInput{SaleId: 101, UserId: 1, Reason: "  "})
+	if err != domain.ErrCancelReasonRequired {
+		t.Fatalf("expected reason required error, got %v", err)
+	}
+}
+
+func TestSalesUseCaseDoCancelErrors(t *testing.T) {
+	expectedErr := stdErrors.New("failure")
+	cases := map[string]func(env saleTestEnv){
+		"user":               func(env saleTestEnv) { env.userRepo.err = expectedErr },
+		"sale for update":    func(env saleTestEnv) { env.salesRepo.saleByIdForUpdateErr = expectedErr },
+		"items by version":   func(env saleTestEnv) { env.salesRepo.itemsByVersionErr = expectedErr },
+		"seller inventory":   func(env saleTestEnv) { env.inventoryRepo.byUserErr = expectedErr },
+		"create version":     func(env saleTestEnv) { env.salesRepo.createSaleVersionErr = expectedErr },
+		"payments":           func(env saleTestEnv) { env.salesRepo.paymentsByVersionErr = expectedErr },
+		"create payment":     func(env saleTestEnv) { env.salesRepo.createPaymentErr = expectedErr },
+		"create dates":       func(env saleTestEnv) { env.salesRepo.createDatesErr = expectedErr },
+		"credit refund":      func(env saleTestEnv) { env.creditRepo.createErr = expectedErr },
+		"create cancel":      func(env saleTestEnv) { env.salesRepo.createCancellationErr = expectedErr },
+		"cancel dates":       func(env saleTestEnv) { env.salesRepo.cancelPaymentDatesErr = expectedErr },
+		"update version":     func(env saleTestEnv) { env.salesRepo.updateSaleLastVersionErr = expectedErr },
+		"inventory movement": func(env saleTestEnv) { env.inventoryUseCase.err = expectedErr },
+	}
+
+	for name, setup := range cases {
+		t.Run(name, func(t *testing.T) {
+			env := newCancelTestEnv(t)
+			setup(env)
+
+			err := env.useCase.DoCancel(context.Background(), DoCancelInput{SaleId: 101, UserId: 1, Reason: "Erro"})
+			if err != expectedErr {
+				t.Fatalf("expected error %v, got %v", expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestSalesUseCaseDoCancelBeginTxError(t *testing.T) {
+	env := newCancelTestEnv(t)
+	expectedErr := stdErrors.New("begin error")
+	stubBeginErr = expectedErr
+	t.Cleanup(func() { stubBeginErr = nil })
+
+	if err := env.useCase.DoCancel(context.Background(), DoCancelInput{SaleId: 101, UserId: 1, Reason: "Erro"}); err != expectedErr {
+		t.Fatalf("expected begin tx error %v, got %v", expectedErr, err)
+	}
+}
+
+func newEditTestEnv(t *testing.T) saleTestEnv {
+	env := newCancelTestEnv(t)
+	env.salesRepo.saleByIdForUpdate.CustomerId = 2
+	newSku := domain.Sku{Id: 7, Code: "SKU7", Price: 15, Quantity: 4, Product: domain.Product{Name: "Novo"}}
+	env.skuRepo.skus = append(env.skuRepo.skus, newSku)
+	env.inventoryItemRepo.items = append(env.inventoryItemRepo.items, domain.InventoryItem{Id: 6, InventoryId: 4, Sku: newSku, Quantity: 4})
+	return env
+}
+
+func newEditInput() DoEditInput {
+	return DoEditInput{
+		SaleId:     101,
+		UserId:     1,
+		CustomerId: 2,
+		Items: []DoSaleItemsInput{
+			{SkuId: 3, Quantity: 1},
+			{SkuId: 7, Quantity: 2},
+		},
+		Payments: []DoSalePaymentsInput{{
+			PaymentType: domain.PaymentTypePix,
+			Dates: []DoSalePaymentDatesInput{{
+				DueDate:           time.Now().Add(24 * time.Hour),
+				InstallmentNumber: 1,
+				InstallmentValue:  30,
+			}},
+		}},
+	}
+}
+
+func TestSalesUseCaseDoEditSuccess(t *testing.T) {
+	env := newEditTestEnv(t)
+	env.customerRepo.customer = domain.Customer{Id: 8}
+	input := newEditInput()
+	input.CustomerId = 8
+
+	if err := env.useCase.DoEdit(context.Background(), input); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if env.salesRepo.updatedCustomerId != 8 {
+		t.Fatalf("expected customer to be updated, got %d", env.salesRepo.updatedCustomerId)
+	}
+	if !env.salesRepo.editCreated || env.salesRepo.updateLastVersion != 2 {
+		t.Fatalf("expected edit to create version 2, got created=%v version=%d", env.salesRepo.editCreated, env.salesRepo.updateLastVersion)
+	}
+	if len(env.salesRepo.saleItems) != 2 || env.salesRepo.saleItems[0].UnitPrice != 10 || env.salesRepo.saleItems[1].UnitPrice != 15 {
+		t.Fatalf("unexpected items for new version: %+v", env.salesRepo.saleItems)
+	}
+
+	if len(env.salesRepo.payments) != 2 {
+		t.Fatalf("expected settled and planned payments, got %+v", env.salesRepo.payments)
+	}
+	for i, payment := range env.salesRepo.payments {
+		dates := env.salesRepo.paymentDates[i]
+		switch payment.PaymentType {
+		case domain.PaymentTypeCreditStore:
+			if len(dates) != 1 || dates[0].Status != domain.PaymentStatusPaid {
+				t.Fatalf("expected only paid installment to be carried over, got %+v", dates)
+			}
+		case domain.PaymentTypePix:
+			if len(dates) != 1 || dates[0].InstallmentValue != 30 {
+				t.Fatalf("expected planned pix installment, got %+v", dates)
+			}
+		default:
+			t.Fatalf("unexpected payment type %s", payment.PaymentType)
+		}
+	}
+
+	calls := env.inventoryUseCase.calls
+	if len(calls) != 2 {
+		t.Fatalf("expected stock in and stock out transactions, got %+v", calls)
+	}
+	if calls[0].Type != domain.InventoryTransactionTypeIn || calls[0].InventoryDestinationId != 4 || len(calls[0].Skus) != 1 || calls[0].Skus[0].SkuId != 3 || calls[0].Skus[0].Quantity != 1 {
+		t.Fatalf("unexpected stock in transaction: %+v", calls[0])
+	}
+	if calls[1].Type != domain.InventoryTransactionTypeOut || calls[1].InventoryOriginId != 4 || len(calls[1].Skus) != 1 || calls[1].Skus[0].SkuId != 7 || calls[1].Skus[0].Quantity != 2 {
+		t.Fatalf("unexpected stock out transaction: %+v", calls[1])
+	}
+	if calls[0].Sale.Id != 101 || calls[0].Sale.SalesVersionId != 2001 {
+		t.Fatalf("expected transactions linked to new version, got %+v", calls[0].Sale)
+	}
+}
+
+func TestSalesUseCaseDoEditKeepsCustomerAndReversesOverpayment(t *testing.T) {
+	env := newEditTestEnv(t)
+	now := time.Now()
+	env.salesRepo.paymentsByVersion[1].PaymentStatus = domain.PaymentStatusPaid
+	env.salesRepo.paymentsByVersion[1].PaidDate = &now
+	input := newEditInput()
+	input.Items = []DoSaleItemsInput{{SkuId: 3, Quantity: 1}}
+	input.Payments = nil
+
+	if err := env.useCase.DoEdit(context.Background(), input); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if env.salesRepo.updatedCustomerId != 0 {
+		t.Fatalf("expected customer not to be updated")
+	}
+
+	var reversal *domain.SalesPaymentDates
+	for i, payment := range env.salesRepo.payments {
+		if payment.PaymentType == domain.PaymentTypeReturn {
+			reversal = &env.salesRepo.paymentDates[i][0]
+		}
+	}
+	if reversal == nil || reversal.InstallmentValue != -10 || reversal.Status != domain.PaymentStatusReversal {
+		t.Fatalf("expected overpaid amount to be reversed, got %+v", reversal)
+	}
+	if len(env.creditRepo.created) != 1 || env.creditRepo.created[0].Type != domain.CustomerCreditTypeCredit || env.creditRepo.created[0].Value != 10 {
+		t.Fatalf("expected overpaid amount to be credited to the customer, got %+v", env.creditRepo.created)
+	}
+	if len(env.inventoryUseCase.calls) != 1 || env.inventoryUseCase.calls[0].Type != domain.InventoryTransactionTypeIn {
+		t.Fatalf("expected only a stock in transaction, got %+v", env.inventoryUseCase.calls)
+	}
+}
+
+func TestSalesUseCaseDoEditDebitsStoreCredit(t *testing.T) {
+	env := newEditTestEnv(t)
+	env.creditRepo.balance = 30
+	input := newEditInput()
+	input.Payments[0].PaymentType = domain.PaymentTypeReturn
+
+	if err := env.useCase.DoEdit(context.Background(), input); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(env.creditRepo.created) != 1 || env.creditRepo.created[0].Type != domain.CustomerCreditTypeDebit || env.creditRepo.created[0].Value != 30 || env.creditRepo.created[0].SalesVersionId != 2001 {
+		t.Fatalf("expected store credit to be debited, got %+v", env.creditRepo.created)
+	}
+
+	env = newEditTestEnv(t)
+	env.creditRepo.balance = 20
+	if err := env.useCase.DoEdit(context.Background(), input); err == nil || !strings.Contains(err.Error(), domain.ErrCustomerCreditInsufficient.Error()) {
+		t.Fatalf("expected insufficient credit error, got %v", err)
+	}
+	if env.salesRepo.updateLastVersion != 0 {
+		t.Fatalf("expected edit to be aborted")
+	}
+}
+
+func TestSalesUseCaseDoEditRenumbersInstallmentsOfSamePaymentType(t *testing.T) {
+	env := newEditTestEnv(t)
+	input := newEditInput()
+	input.Payments = []DoSalePaymentsInput{{
+		PaymentType: domain.PaymentTypeCreditStore,
+		Dates: []DoSalePaymentDatesInput{
+			{DueDate: time.Now().AddDate(0, 1, 0), InstallmentNumber: 1, InstallmentValue: 15},
+			{DueDate: time.Now().AddDate(0, 2, 0), InstallmentNumber: 2, InstallmentValue: 15},
+		},
+	}}
+
+	if err := env.useCase.DoEdit(context.Background(), input); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(env.salesRepo.paymentDates) != 1 {
+		t.Fatalf("expected a single credit store payment, got %+v", env.salesRepo.paymentDates)
+	}
+	dates := env.salesRepo.paymentDates[0]
+	if len(dates) != 3 || dates[0].InstallmentNumber != 1 || dates[1].InstallmentNumber != 2 || dates[2].InstallmentNumber != 3 {
+		t.Fatalf("expected planned installments after the paid one, got %+v", dates)
+	}
+}
+
+func TestSalesUseCaseDoEditPermission(t *testing.T) {
+	env := newEditTestEnv(t)
+	env.salesRepo.saleByIdForUpdate.UserId = 99
+
+	if err := env.useCase.DoEdit(context.Background(), newEditInput()); err != domain.ErrSaleEditNotAllowed {
+		t.Fatalf("expected edit not allowed error, got %v", err)
+	}
+
+	env.userRepo.user.Role = string(domain.UserRoleAdmin)
+	if err := env.useCase.DoEdit(context.Background(), newEditInput()); err != nil {
+		t.Fatalf("expected admin to edit any sale, got %v", err)
+	}
+}
+
+func TestSalesUseCaseDoEditValidationErrors(t *testing.T) {
+	t.Run("already cancelled", func(t *testing.T) {
+		env := newEditTestEnv(t)
+		env.salesRepo.itemsByVersion = nil
+		if err := env.useCase.DoEdit(context.Background(), newEditInput()); err != domain.ErrSaleAlreadyCancelled {
+			t.Fatalf("expected already cancelled error, got %v", err)
+		}
+	})
+
+	t.Run("duplicated skus", func(t *testing.T) {
+		env := newEditTestEnv(t)
+		input := newEditInput()
+		input.Items = append(input.Items, DoSaleItemsInput{SkuId: 3, Quantity: 1})
+		if err := env.useCase.DoEdit(context.Background(), input); err == nil || !strings.Contains(err.Error(), domain.ErrSkusDuplicated.Error()) {
+			t.Fatalf("expected duplicated skus error, got %v", err)
+		}
+	})
+
+	t.Run("sku not found", func(t *testing.T) {
+		env := newEditTestEnv(t)
+		input := newEditInput()
+		input.Items = append(input.Items, DoSaleItemsInput{SkuId: 50, Quantity: 1})
+		if err := env.useCase.DoEdit(context.Background(), input); err == nil || !strings.Contains(err.Error(), ErrSkusNotFound.Error()) {
+			t.Fatalf("expected sku not found error, got %v", err)
+		}
+	})
+
+	t.Run("insufficient stock", func(t *testing.T) {
+		env := newEditTestEnv(t)
+		input := newEditInput()
+		input.Items = []DoSaleItemsInput{{SkuId: 3, Quantity: 8}}
+		input.Payments[0].Dates[0].InstallmentValue = 70
+		if err := env.useCase.DoEdit(context.Background(), input); err == nil || !strings.Contains(err.Error(), domain.ErrQuantityNotValid.Error()) {
+			t.Fatalf("expected quantity error, got %v", err)
+		}
+	})
+
+	t.Run("payment missing", func(t *testing.T) {
+		env := newEditTestEnv(t)
+		input := newEditInput()
+		input.Payments[0].Dates[0].InstallmentValue = 20
+		if err := env.useCase.DoEdit(context.Background(), input); err == nil || !strings.Contains(err.Error(), domain.ErrPaymentValueIsMissing.Error()) {
+			t.Fatalf("expected missing payment error, got %v", err)
+		}
+		if env.salesRepo.editCreated {
+			t.Fatalf("expected no edit to be created")
+		}
+	})
+}
+
+func TestSalesUseCaseDoEditErrors(t *testing.T) {
+	expectedErr := stdErrors.New("failure")
+	cases := map[string]func(env saleTestEnv){
+		"user":               func(env saleTestEnv) { env.userRepo.err = expectedErr },
+		"customer":           func(env saleTestEnv) { env.customerRepo.err = expectedErr },
+		"skus":               func(env saleTestEnv) { env.skuRepo.err = expectedErr },
+		"sale for update":    func(env saleTestEnv) { env.salesRepo.saleByIdForUpdateErr = expectedErr },
+		"items by version":   func(env saleTestEnv) { env.salesRepo.itemsByVersionErr = expectedErr },
+		"seller inventory":   func(env saleTestEnv) { env.inventoryRepo.byUserErr = expectedErr },
+		"inventory items":    func(env saleTestEnv) { env.inventoryItemRepo.itemsErr = expectedErr },
+		"payments":           func(env saleTestEnv) { env.salesRepo.paymentsByVersionErr = expectedErr },
+		"update customer":    func(env saleTestEnv) { env.salesRepo.updateSaleCustomerErr = expectedErr },
+		"create version":     func(env saleTestEnv) { env.salesRepo.createSaleVersionErr = expectedErr },
+		"create items":       func(env saleTestEnv) { env.salesRepo.createItemsErr = expectedErr },
+		"create payment":     func(env saleTestEnv) { env.salesRepo.createPaymentErr = expectedErr },
+		"create dates":       func(env saleTestEnv) { env.salesRepo.createDatesErr = expectedErr },
+		"create edit":        func(env saleTestEnv) { env.salesRepo.createEditErr = expectedErr },
+		"cancel dates":       func(env saleTestEnv) { env.salesRepo.cancelPaymentDatesErr = expectedErr },
+		"update version":     func(env saleTestEnv) { env.salesRepo.updateSaleLastVersionErr = expectedErr },
+		"inventory movement": func(env saleTestEnv) { env.inventoryUseCase.err = expectedErr },
+	}
+
+	for name, setup := range cases {
+		t.Run(name, func(t *testing.T) {
+			env := newEditTestEnv(t)
+			env.customerRepo.customer = domain.Customer{Id: 8}
+			setup(env)
+
+			input := newEditInput()
+			input.CustomerId = 8
+			if err := env.useCase.DoEdit(context.Background(), input); err != expectedErr {
+				t.Fatalf("expected error %v, got %v", expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestSalesUseCaseDoEditStockOutError(t *testing.T) {
+	env := newEditTestEnv(t)
+	input := newEditInput()
+	input.Items = []DoSaleItemsInput{{SkuId: 3, Quantity: 2}, {SkuId: 7, Quantity: 2}}
+	input.Payments[0].Dates[0].InstallmentValue = 40
+	expectedErr := stdErrors.New("stock out")
+	env.inventoryUseCase.err = expectedErr
+
+	if err := env.useCase.DoEdit(context.Background(), input); err != expectedErr {
+		t.Fatalf("expected stock out error, got %v", err)
+	}
+	if len(env.inventoryUseCase.calls) != 1 || env.inventoryUseCase.calls[0].Type != domain.InventoryTransactionTypeOut {
+		t.Fatalf("expected only stock out transaction, got %+v", env.inventoryUseCase.calls)
+	}
+}
+
+func TestSalesUseCaseDoEditBeginTxError(t *testing.T) {
+	env := newEditTestEnv(t)
+	expectedErr := stdErrors.New("begin error")
+	stubBeginErr = expectedErr
+	t.Cleanup(func() { stubBeginErr = nil })
+
+	if err := env.useCase.DoEdit(context.Background(), newEditInput()); err != expectedErr {
+		t.Fatalf("expected begin tx error %v, got %v", expectedErr, err)
+	}
+}
+
+func newExchangeInput() DoExchangeInput {
+	return DoExchangeInput{
+		SaleId:        101,
+		UserId:        1,
+		Reason:        "Tamanho errado",
+		ReturnedItems: []DoReturnItemInput{{SkuId: 3, Quantity: 1}},
+		Items:         []DoSaleItemsInput{{SkuId: 7, Quantity: 1}},
+		Payments: []DoSalePaymentsInput{{
+			PaymentType: domain.PaymentTypePix,
+			Dates: []DoSalePaymentDatesInput{{
+				DueDate:           time.Now().Add(24 * time.Hour),
+				InstallmentNumber: 1,
+				InstallmentValue:  5,
+			}},
+		}},
+	}
+}
+
+func TestSalesUseCaseDoExchangeWithAdditionalPayment(t *testing.T) {
+	env := newEditTestEnv(t)
+	env.salesRepo.saleByIdForUpdate.CustomerName = "Cliente"
+
+	if err := env.useCase.DoExchange(context.Background(), newExchangeInput()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !env.salesRepo.returnCreated || !env.salesRepo.exchangeCreated {
+		t.Fatalf("expected return and exchange records to be created")
+	}
+	if env.salesRepo.exchange.Return.Returner != "Cliente" || env.salesRepo.exchange.GetDifference() != 5 {
+		t.Fatalf("unexpected exchange: %+v", env.salesRepo.exchange)
+	}
+	if len(env.salesRepo.saleItems) != 2 || env.salesRepo.saleItems[0].Quantity != 1 || env.salesRepo.saleItems[1].Sku.Id != 7 {
+		t.Fatalf("unexpected items for new version: %+v", env.salesRepo.saleItems)
+	}
+
+	for i, payment := range env.salesRepo.payments {
+		dates := env.salesRepo.paymentDates[i]
+		switch payment.PaymentType {
+		case domain.PaymentTypeCreditStore:
+			if len(dates) != 2 || dates[0].Status != domain.PaymentStatusPaid || dates[1].Status != domain.PaymentStatusPending || dates[1].InstallmentValue != 10 {
+				t.Fatalf("expected previous installments to be kept, got %+v", dates)
+			}
+		case domain.PaymentTypePix:
+			if len(dates) != 1 || dates[0].InstallmentValue != 5 {
+				t.Fatalf("expected difference to be charged, got %+v", dates)
+			}
+		default:
+			t.Fatalf("unexpected payment type %s", payment.PaymentType)
+		}
+	}
+
+	calls := env.inventoryUseCase.calls
+	if len(calls) != 2 || calls[0].Type != domain.InventoryTransactionTypeIn || calls[1].Type != domain.InventoryTransactionTypeOut {
+		t.Fatalf("expected stock in and stock out, got %+v", calls)
+	}
+	if calls[0].Skus[0].SkuId != 3 || calls[1].Skus[0].SkuId != 7 {
+		t.Fatalf("unexpected skus moved: %+v", calls)
+	}
+	for _, call := range calls {
+		if call.Sale.Id != 101 || call.Sale.SalesVersionId != 2001 {
+			t.Fatalf("expected both movements linked to the new version, got %+v", call.Sale)
+		}
+	}
+}
+
+func TestSalesUseCaseDoExchangeDebitsStoreCredit(t *testing.T) {
+	env := newEditTestEnv(t)
+	env.creditRepo.balance = 5
+	input := newExchangeInput()
+	input.Payments[0].PaymentType = domain.PaymentTypeReturn
+
+	if err := env.useCase.DoExchange(context.Background(), input); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(env.creditRepo.created) != 1 || env.creditRepo.created[0].Type != domain.CustomerCreditTypeDebit || env.creditRepo.created[0].Value != 5 {
+		t.Fatalf("expected store credit to be debited, got %+v", env.creditRepo.created)
+	}
+
+	env = newEditTestEnv(t)
+	env.creditRepo.balance = 4
+	if err := env.useCase.DoExchange(context.Background(), input); err == nil || !strings.Contains(err.Error(), domain.ErrCustomerCreditInsufficient.Error()) {
+		t.Fatalf("expected insufficient credit error, got %v", err)
+	}
+}
+
+func TestSalesUseCaseDoExchangeCheaperReducesPendingAndRefunds(t *testing.T) {
+	t.Run("reduces pending", func(t *testing.T) {
+		env := newEditTestEnv(t)
+		input := newExchangeInput()
+		input.ReturnedItems = []DoReturnItemInput{{SkuId: 3, Quantity: 2}}
+		input.Payments = nil
+
+		if err := env.useCase.DoExchange(context.Background(), input); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(env.salesRepo.payments) != 1 || env.salesRepo.payments[0].PaymentType != domain.PaymentTypeCreditStore {
+			t.Fatalf("expected only credit store payment, got %+v", env.salesRepo.payments)
+		}
+		dates := env.salesRepo.paymentDates[0]
+		if len(dates) != 2 || dates[1].InstallmentValue != 5 {
+			t.Fatalf("expected pending installment to be reduced, got %+v", dates)
+		}
+	})
+
+	t.Run("refunds paid amount", func(t *testing.T) {
+		env := newEditTestEnv(t)
+		now := time.Now()
+		env.salesRepo.paymentsByVersion[1].PaymentStatus = domain.PaymentStatusPaid
+		env.salesRepo.paymentsByVersion[1].PaidDate = &now
+		input := newExchangeInput()
+		input.ReturnedItems = []DoReturnItemInput{{SkuId: 3, Quantity: 2}}
+		input.Payments = nil
+
+		if err := env.useCase.DoExchange(context.Background(), input); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		var reversal *domain.SalesPaymentDates
+		for i, payment := range env.salesRepo.payments {
+			if payment.PaymentType == domain.PaymentTypeReturn {
+				reversal = &env.salesRepo.paymentDates[i][0]
+			}
+		}
+		if reversal == nil || reversal.InstallmentValue != -5 {
+			t.Fatalf("expected refund of the difference, got %+v", reversal)
+		}
+		if len(env.creditRepo.created) != 1 || env.creditRepo.created[0].Value != 5 {
+			t.Fatalf("expected refund to be credited, got %+v", env.creditRepo.created)
+		}
+	})
+}
+
+func TestSalesUseCaseDoExchangeValidationErrors(t *testing.T) {
+	cases := map[string]struct {
+		setup    func(input *DoExchangeInput)
+		expected error
+	}{
+		"payment missing": {
+			setup:    func(input *DoExchangeInput) { input.Payments = nil },
+			expected: domain.ErrPaymentValueIsMissing,
+		},
+		"item already in sale": {
+			setup:    func(input *DoExchangeInput) { input.Items = []DoSaleItemsInput{{SkuId: 3, Quantity: 1}} },
+			expected: domain.ErrExchangeItemAlreadyInSale,
+		},
+		"items required": {
+			setup:    func(input *DoExchangeInput) { input.Items = nil },
+			expected: domain.ErrExchangeItemsRequired,
+		},
+		"sku not in inventory": {
+			setup:    func(input *DoExchangeInput) { input.Items = []DoSaleItemsInput{{SkuId: 50, Quantity: 1}} },
+			expected: ErrSkusNotFound,
+		},
+		"duplicated skus": {
+			setup: func(input *DoExchangeInput) {
+				input.Items = append(input.Items, DoSaleItemsInput{SkuId: 7, Quantity: 1})
+			},
+			expected: domain.ErrSkusDuplicated,
+		},
+		"return reason": {
+			setup:    func(input *DoExchangeInput) { input.Reason = "" },
+			expected: domain.ErrReturnReasonRequired,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			env := newEditTestEnv(t)
+			input := newExchangeInput()
+			tc.setup(&input)
+
+			err := env.useCase.DoExchange(context.Background(), input)
+			if err == nil || !strings.Contains(err.Error(), tc.expected.Error()) {
+				t.Fatalf("expected %v, got %v", tc.expected, err)
+			}
+			if env.salesRepo.exchangeCreated {
+				t.Fatalf("expected no exchange to be created")
+			}
+		})
+	}
+}
+
+func TestSalesUseCaseDoExchangeErrors(t *testing.T) {
+	expectedErr := stdErrors.New("failure")
+	cases := map[string]func(env saleTestEnv){
+		"user":             func(env saleTestEnv) { env.userRepo.err = expectedErr },
+		"skus":             func(env saleTestEnv) { env.skuRepo.err = expectedErr },
+		"inventory":        func(env saleTestEnv) { env.inventoryRepo.byUserErr = expectedErr },
+		"inventory items":  func(env saleTestEnv) { env.inventoryItemRepo.itemsErr = expectedErr },
+		"sale for update":  func(env saleTestEnv) { env.salesRepo.saleByIdForUpdateErr = expectedErr },
+		"items by version": func(env saleTestEnv) { env.salesRepo.itemsByVersionErr = expectedErr },
+		"create version":   func(env saleTestEnv) { env.salesRepo.createSaleVersionErr = expectedErr },
+		"create items":     func(env saleTestEnv) { env.salesRepo.createItemsErr = expectedErr },
+		"payments":         func(env saleTestEnv) { env.salesRepo.paymentsByVersionErr = expectedErr },
+		"create payment":   func(env saleTestEnv) { env.salesRepo.createPaymentErr = expectedErr },
+		"create dates":     func(env saleTestEnv) { env.salesRepo.createDatesErr = expectedErr },
+		"create return":    func(env saleTestEnv) { env.salesRepo.createReturnErr = expectedErr },
+		"create items ret": func(env saleTestEnv) { env.salesRepo.createReturnItemsErr = expectedErr },
+		"create exchange":  func(env saleTestEnv) { env.salesRepo.createExchangeErr = expectedErr },
+		"cancel dates":     func(env saleTestEnv) { env.salesRepo.cancelPaymentDatesErr = expectedErr },
+		"update version":   func(env saleTestEnv) { env.salesRepo.updateSaleLastVersionErr = expectedErr },
+		"inventory in/out": func(env saleTestEnv) { env.inventoryUseCase.err = expectedErr },
+	}
+
+	for name, setup := range cases {
+		t.Run(name, func(t *testing.T) {
+			env := newEditTestEnv(t)
+			setup(env)
+
+			if err := env.useCase.DoExchange(context.Background(), newExchangeInput()); err != expectedErr {
+				t.Fatalf("expected error %v, got %v", expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestSalesUseCaseDoExchangePermission(t *testing.T) {
+	env := newEditTestEnv(t)
+	env.salesRepo.saleByIdForUpdate.UserId = 99
+
+	if err := env.useCase.DoExchange(context.Background(), newExchangeInput()); err != domain.ErrSaleEditNotAllowed {
+		t.Fatalf("expected exchange not allowed error, got %v", err)
+	}
+	if len(env.inventoryUseCase.calls) != 0 {
+		t.Fatalf("expected no stock movement, got %+v", env.inventoryUseCase.calls)
+	}
+
+	env.userRepo.user.Role = string(domain.UserRoleAdmin)
+	env.userRepo.users = map[int64]domain.User{99: {Id: 99, Role: string(domain.UserRoleReseller)}}
+	if err := env.useCase.DoExchange(context.Background(), newExchangeInput()); err != nil {
+		t.Fatalf("expected admin to exchange any sale, got %v", err)
+	}
+	if env.inventoryRepo.byUserId != 99 {
+		t.Fatalf("expected seller inventory to be used, got user %d", env.inventoryRepo.byUserId)
+	}
+}
+
+func TestSalesUseCaseDoExchangeBeginTxError(t *testing.T) {
+	env := newEditTestEnv(t)
+	expectedErr := stdErrors.New("begin error")
+	stubBeginErr = expectedErr
+	t.Cleanup(func() { stubBeginErr = nil })
+
+	if err := env.useCase.DoExchange(context.Background(), newExchangeInput()); err != expectedErr {
+		t.Fatalf("expected begin tx error %v, got %v", expectedErr, err)
+	}
+}
+
+func TestSalesUseCaseBuildExchangePaymentsKeepsDelayedAsPending(t *testing.T) {
+	uc := &salesUseCase{}
+	old := []serviceOutput.GetSalesPaymentOutput{
+		{PaymentType: domain.PaymentTypeCreditStore, InstallmentNumber: 1, InstallmentValue: 10, PaymentStatus: domain.PaymentStatusDelayed},
+		{PaymentType: domain.PaymentTypeCash, InstallmentNumber: 1, InstallmentValue: 10, PaymentStatus: domain.PaymentStatusCancel},
+	}
+
+	payments := uc.buildExchangePayments(old, nil)
+	if len(payments) != 1 || len(payments[0].Dates) != 1 || payments[0].Dates[0].Status != domain.PaymentStatusPending {
+		t.Fatalf("expected delayed installment kept as pending and cancelled dropped, got %+v", payments)
+	}
+}
+
+func newPaymentReceiptTestEnv(t *testing.T) saleTestEnv {
+	env := newCancelTestEnv(t)
+	env.salesRepo.paymentDate = domain.SalesPaymentDates{
+		Id:               55,
+		InstallmentValue: 100,
+		Status:           domain.PaymentStatusPending,
+		PaymentType:      domain.PaymentTypeCreditStore,
+	}
+	return env
+}
+
+func newPaymentReceiptInput(value float64) DoPaymentReceiptInput {
+	return DoPaymentReceiptInput{
+		SaleId:        101,
+		PaymentDateId: 55,
+		UserId:        1,
+		Value:         value,
+		ReceiptDate:   time.Date(2024, 5, 10, 0, 0, 0, 0, time.UTC),
+		PaymentType:   domain.PaymentTypePix,
+	}
+}
+
+func TestSalesUseCaseDoPaymentReceiptPartial(t *testing.T) {
+	env := newPaymentReceiptTestEnv(t)
+	env.salesRepo.receipts = []domain.PaymentReceipt{{Value: 30}}
+
+	if err := env.useCase.DoPaymentReceipt(context.Background(), newPaymentReceiptInput(20)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	receipt := env.salesRepo.createdReceipt
+	if receipt == nil || receipt.PaymentDateId != 55 || receipt.Value != 20 || receipt.ReceivedByUserId != 1 || receipt.PaymentType != domain.PaymentTypePix {
+		t.Fatalf("unexpected receipt created: %+v", receipt)
+	}
+	if env.salesRepo.settledPaymentDateId != 0 {
+		t.Fatalf("expected installment to remain open, got settled %d", env.salesRepo.settledPaymentDateId)
+	}
+}
+
+func TestSalesUseCaseDoPaymentReceiptSettlesInstallment(t *testing.T) {
+	env := newPaymentReceiptTestEnv(t)
+	env.salesRepo.receipts = []domain.PaymentReceipt{{Value: 30}, {Value: 20}}
+
+	input := newPaymentReceiptInput(50)
+	if err := env.useCase.DoPaymentReceipt(context.Background(), input); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if env.salesRepo.settledPaymentDateId != 55 || !env.salesRepo.settledDate.Equal(input.ReceiptDate) {
+		t.Fatalf("expected installment settled on receipt date, got %d %v", env.salesRepo.settledPaymentDateId, env.salesRepo.settledDate)
+	}
+}
+
+func TestSalesUseCaseDoPaymentReceiptSettleRemaining(t *testing.T) {
+	env := newPaymentReceiptTestEnv(t)
+	env.salesRepo.receipts = []domain.PaymentReceipt{{Value: 30}}
+
+	input := newPaymentReceiptInput(0)
+	input.SettleRemaining = true
+	if err := env.useCase.DoPaymentReceipt(context.Background(), input); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if receipt := env.salesRepo.createdReceipt; receipt == nil || receipt.Value != 70 {
+		t.Fatalf("expected remaining balance to be received, got %+v", receipt)
+	}
+	if env.salesRepo.settledPaymentDateId != 55 {
+		t.Fatalf("expected installment to be settled, got %d", env.salesRepo.settledPaymentDateId)
+	}
+}
+
+func TestSalesUseCaseDoPaymentReceiptRecordsLateFeeOnSettlement(t *testing.T) {
+	env := newPaymentReceiptTestEnv(t)
+	input := newPaymentReceiptInput(70)
+	input.PaymentType = domain.PaymentTypeCash
+	env.salesRepo.paymentDate.DueDate = input.ReceiptDate.AddDate(0, 0, -5)
+	env.salesRepo.receipts = []domain.PaymentReceipt{{Value: 30}}
+	env.lateFeeRuleRepo.rule = domain.NewLateFeeRule(2, 0.1)
+	open := domain.NewCashRegisterSession(domain.User{Id: 1}, 0, input.ReceiptDate)
+	open.Id = 4
+	env.cashRegisterRepo.open = &open
+
+	if err := env.useCase.DoPaymentReceipt(context.Background(), input); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	fee := env.salesRepo.settledLateFee
+	if env.salesRepo.settledPaymentDateId != 55 || fee.FineValue != 1.4 || fee.InterestValue != 0.35 {
+		t.Fatalf("expected late fee on the outstanding balance, got %+v", fee)
+	}
+	if len(env.cashRegisterRepo.entries) != 1 || env.cashRegisterRepo.entries[0].Value != 71.75 {
+		t.Fatalf("expected cash entry with the late fee, got %+v", env.cashRegisterRepo.entries)
+	}
+
+	env = newPaymentReceiptTestEnv(t)
+	env.salesRepo.paymentDate.DueDate = input.ReceiptDate.AddDate(0, 0, -5)
+	env.lateFeeRuleRepo.getErr = stdErrors.New("rule")
+	if err := env.useCase.DoPaymentReceipt(context.Background(), newPaymentReceiptInput(20)); err != nil {
+		t.Fatalf("expected partial receipt not to charge late fees, got %v", err)
+	}
+}
+
+func TestSalesUseCaseDoPaymentReceiptCreditStoreInCashRegistersEntry(t *testing.T) {
+	env := newPaymentReceiptTestEnv(t)
+	input := newPaymentReceiptInput(40)
+	input.PaymentType = domain.PaymentTypeCash
+	open := domain.NewCashRegisterSession(domain.User{Id: 1}, 0, input.ReceiptDate)
+	open.Id = 4
+	env.cashRegisterRepo.open = &open
+
+	if err := env.useCase.DoPaymentReceipt(context.Background(), input); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(env.cashRegisterRepo.entries) != 1 {
+		t.Fatalf("expected cash entry for the store credit installment, got %+v", env.cashRegisterRepo.entries)
+	}
+	entry := env.cashRegisterRepo.entries[0]
+	if entry.Type != domain.CashRegisterEntryTypeReceipt || entry.Value != 40 || entry.SessionId != 4 || entry.SalesId != 101 || entry.PaymentDateId != 55 {
+		t.Fatalf("unexpected cash entry: %+v", entry)
+	}
+
+	env = newPaymentReceiptTestEnv(t)
+	env.cashRegisterRepo.settings = domain.CashRegisterSettings{RequireOpenSession: true}
+	if err := env.useCase.DoPaymentReceipt(context.Background(), input); err != domain.ErrCashRegisterSessionRequired {
+		t.Fatalf("expected open session to be required, got %v", err)
+	}
+}
+
+func TestSalesUseCaseDoPaymentReceiptOverRemaining(t *testing.T) {
+	env := newPaymentReceiptTestEnv(t)
+	env.salesRepo.receipts = []domain.PaymentReceipt{{Value: 90}}
+
+	err := env.useCase.DoPaymentReceipt(context.Background(), newPaymentReceiptInput(20))
+	if err == nil || !strings.Contains(err.Error(), domain.ErrPaymentReceiptOverRemaining.Error()) {
+		t.Fatalf("expected over remaining error, got %v", err)
+	}
+	if env.salesRepo.createdReceipt != nil {
+		t.Fatalf("expected no receipt to be created")
+	}
+}
+
+func TestSalesUseCaseDoPaymentReceiptErrors(t *testing.T) {
+	expectedErr := stdErrors.New("failure")
+	cases := map[string]func(env saleTestEnv){
+		"sale for update": func(env saleTestEnv) { env.salesRepo.saleByIdForUpdateErr = expectedErr },
+		"payment date":    func(env saleTestEnv) { env.salesRepo.paymentDateErr = expectedErr },
+		"receipts":        func(env saleTestEnv) { env.salesRepo.receiptsErr = expectedErr },
+		"late fee rule":   func(env saleTestEnv) { env.lateFeeRuleRepo.getErr = expectedErr },
+		"create receipt":  func(env saleTestEnv) { env.salesRepo.createReceiptErr = expectedErr },
+		"settle":          func(env saleTestEnv) { env.salesRepo.settleErr = expectedErr },
+	}
+
+	for name, setup := range cases {
+		t.Run(name, func(t *testing.T) {
+			env := newPaymentReceiptTestEnv(t)
+			setup(env)
+
+			if err := env.useCase.DoPaymentReceipt(context.Background(), newPaymentReceiptInput(100)); err != expectedErr {
+				t.Fatalf("expected error %v, got %v", expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestSplitPartialReceipts(t *testing.T) {
+	receiptDate := time.Date(2024, 5, 10, 0, 0, 0, 0, time.UTC)
+	payments := splitPartialReceipts([]serviceOutput.GetSalesPaymentOutput{
+		{PaymentType: domain.PaymentTypeCreditStore, InstallmentNumber: 1, InstallmentValue: 100, PaymentStatus: domain.PaymentStatusDelayed, ReceivedValue: 40, LastReceiptDate: &receiptDate},
+		{PaymentType: domain.PaymentTypeCreditStore, InstallmentNumber: 2, InstallmentValue: 100, PaymentStatus: domain.PaymentStatusPending},
+	})
+
+	if len(payments) != 3 {
+		t.Fatalf("expected partially received installment to be split, got %+v", payments)
+	}
+	if payments[0].PaymentStatus != domain.PaymentStatusPaid || payments[0].InstallmentValue != 40 || payments[0].PaidDate != &receiptDate {
+		t.Fatalf("unexpected received part: %+v", payments[0])
+	}
+	if payments[1].PaymentStatus != domain.PaymentStatusDelayed || payments[1].InstallmentValue != 60 {
+		t.Fatalf("unexpected remaining part: %+v", payments[1])
+	}
+	if payments[2].InstallmentValue != 100 {
+		t.Fatalf("expected untouched installment, got %+v", payments[2])
+	}
+}
+
+func TestSalesUseCaseDoCancelReversesPartialReceipts(t *testing.T) {
+	env := newCancelTestEnv(t)
+	env.salesRepo.paymentsByVersion[1].ReceivedValue = 4
+
+	if err := env.useCase.DoCancel(context.Background(), DoCancelInput{SaleId: 101, UserId: 1, Reason: "Erro"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for i, payment := range env.salesRepo.payments {
+		if payment.PaymentType == domain.PaymentTypeReturn && env.salesRepo.paymentDates[i][0].InstallmentValue != -14 {
+			t.Fatalf("expected partial receipt to be reversed, got %+v", env.salesRepo.paymentDates[i])
+		}
+	}
+}
+
+func newRenegotiationTestEnv(t *testing.T) saleTestEnv {
+	env := newCancelTestEnv(t)
+	now := time.Now()
+	receiptDate := now.AddDate(0, 0, -5)
+	env.salesRepo.paymentsByVersion = []serviceOutput.GetSalesPaymentOutput{
+		{PaymentType: domain.PaymentTypeCreditStore, InstallmentNumber: 1, InstallmentValue: 10, DueDate: now.AddDate(0, -2, 0), PaidDate: &now, PaymentStatus: domain.PaymentStatusPaid},
+		{PaymentType: domain.PaymentTypeCreditStore, InstallmentNumber: 2, InstallmentValue: 10, DueDate: now.AddDate(0, -1, 0), PaymentStatus: domain.PaymentStatusDelayed, ReceivedValue: 4, LastReceiptDate: &receiptDate},
+		{PaymentType: domain.PaymentTypeCreditStore, InstallmentNumber: 3, InstallmentValue: 10, DueDate: now, PaymentStatus: domain.PaymentStatusPending},
+		{PaymentType: domain.PaymentTypePix, InstallmentNumber: 1, InstallmentValue: 5, DueDate: now, PaymentStatus: domain.PaymentStatusPending},
+		{PaymentType: domain.PaymentTypeCash, InstallmentNumber: 1, InstallmentValue: 5, DueDate: now, PaymentStatus: domain.PaymentStatusCancel},
+	}
+	return env
+}
+
+func newRenegotiationInput() DoRenegotiationInput {
+	return DoRenegotiationInput{
+		SaleId:             101,
+		UserId:             1,
+		Installments:       3,
+		FirstDueDate:       time.Now().AddDate(0, 0, 10),
+		InterestPercentage: 10,
+	}
+}
+
+func TestSalesUseCaseDoRenegotiationSuccess(t *testing.T) {
+	env := newRenegotiationTestEnv(t)
+	input := newRenegotiationInput()
+
+	if err := env.useCase.DoRenegotiation(context.Background(), input); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !env.salesRepo.renegotiationCreated || env.salesRepo.renegotiation.OpenValue != 16 || env.salesRepo.renegotiation.GetTotal() != 17.6 {
+		t.Fatalf("expected renegotiation of the open credit store balance, got %+v", env.salesRepo.renegotiation)
+	}
+	if env.salesRepo.updateLastVersion != 2 || env.salesRepo.createManySaleItemCalls != 1 {
+		t.Fatalf("expected a new version with the current items, got version %d and %d item calls", env.salesRepo.updateLastVersion, env.salesRepo.createManySaleItemCalls)
+	}
+
+	var creditStore, pix []domain.SalesPaymentDates
+	for i, payment := range env.salesRepo.payments {
+		switch payment.PaymentType {
+		case domain.PaymentTypeCreditStore:
+			creditStore = env.salesRepo.paymentDates[i]
+		case domain.PaymentTypePix:
+			pix = env.salesRepo.paymentDates[i]
+		default:
+			t.Fatalf("unexpected payment type carried over: %s", payment.PaymentType)
+		}
+	}
+	if len(pix) != 1 || pix[0].Status != domain.PaymentStatusPending || pix[0].InstallmentValue != 5 {
+		t.Fatalf("expected other open payments to be kept, got %+v", pix)
+	}
+	if len(creditStore) != 5 {
+		t.Fatalf("expected 2 settled and 3 renegotiated installments, got %+v", creditStore)
+	}
+	if creditStore[0].Status != domain.PaymentStatusPaid || creditStore[1].Status != domain.PaymentStatusPaid || creditStore[1].InstallmentValue != 4 {
+		t.Fatalf("expected settled values to be kept, got %+v", creditStore[:2])
+	}
+	expectedValues := []float64{5.87, 5.87, 5.86}
+	for i, date := range creditStore[2:] {
+		if date.Status != domain.PaymentStatusPending || date.InstallmentValue != expectedValues[i] || date.InstallmentNumber != i+3 {
+			t.Fatalf("unexpected renegotiated installment %d: %+v", i, date)
+		}
+		if !date.DueDate.Equal(input.FirstDueDate.AddDate(0, i, 0)) {
+			t.Fatalf("expected monthly due dates, got %v", date.DueDate)
+		}
+	}
+}
+
+func TestSalesUseCaseDoReturnKeepsRenegotiationAdjustment(t *testing.T) {
+	env := newCancelTestEnv(t)
+	input := newRenegotiationInput()
+	input.Installments = 1
+
+	if err := env.useCase.DoRenegotiation(context.Background(), input); err != nil {
+		t.Fatalf("unexpected renegotiation error: %v", err)
+	}
+	if env.salesRepo.versionAdjustment != 1 {
+		t.Fatalf("expected renegotiation interest to be stored on the version, got %v", env.salesRepo.versionAdjustment)
+	}
+
+	env.salesRepo.saleByIdForUpdate.LastVersion = 2
+	env.salesRepo.saleByIdForUpdate.SalesVersionId = 2001
+	env.salesRepo.saleByIdForUpdate.AdjustmentAmount = env.salesRepo.versionAdjustment
+	env.salesRepo.paymentsByVersion = nil
+	for i, payment := range env.salesRepo.payments {
+		for _, date := range env.salesRepo.paymentDates[i] {
+			env.salesRepo.paymentsByVersion = append(env.salesRepo.paymentsByVersion, serviceOutput.GetSalesPaymentOutput{
+				PaymentType:       payment.PaymentType,
+				InstallmentNumber: int64(date.InstallmentNumber),
+				InstallmentValue:  date.InstallmentValue,
+				DueDate:           date.DueDate,
+				PaidDate:          date.PaidDate,
+				PaymentStatus:     date.Status,
+			})
+		}
+	}
+	env.salesRepo.payments = nil
+	env.salesRepo.paymentDates = nil
+
+	err := env.useCase.DoReturn(context.Background(), DoReturnInput{
+		SaleId:       101,
+		UserId:       1,
+		ReturnerName: "Cliente",
+		Reason:       "Defeito",
+		Items:        []DoReturnItemInput{{SkuId: 3, Quantity: 1}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected return error: %v", err)
+	}
+	if env.salesRepo.versionAdjustment != 1 {
+		t.Fatalf("expected adjustment to be carried to the new version, got %v", env.salesRepo.versionAdjustment)
+	}
+	pending := 0.0
+	for _, dates := range env.salesRepo.paymentDates {
+		for _, date := range dates {
+			if date.Status == domain.PaymentStatusPending {
+				pending += date.InstallmentValue
+			}
+		}
+	}
+	if round2(pending) != 1 {
+		t.Fatalf("expected remaining item plus interest minus paid value to stay open, got %v", pending)
+	}
+	if len(env.creditRepo.created) != 0 {
+		t.Fatalf("expected no refund, got %+v", env.creditRepo.created)
+	}
+}
+
+func TestSalesUseCaseDoRenegotiationValidation(t *testing.T) {
+	t.Run("not seller", func(t *testing.T) {
+		env := newRenegotiationTestEnv(t)
+		env.salesRepo.saleByIdForUpdate.UserId = 99
+
+		if err := env.useCase.DoRenegotiation(context.Background(), newRenegotiationInput()); err != domain.ErrRenegotiationNotAllowed {
+			t.Fatalf("expected not allowed error, got %v", err)
+		}
+	})
+
+	t.Run("cancelled sale", func(t *testing.T) {
+		env := newRenegotiationTestEnv(t)
+		env.salesRepo.itemsByVersion = nil
+
+		if err := env.useCase.DoRenegotiation(context.Background(), newRenegotiationInput()); err != domain.ErrSaleAlreadyCancelled {
+			t.Fatalf("expected already cancelled error, got %v", err)
+		}
+	})
+
+	t.Run("nothing open", func(t *testing.T) {
+		env := newRenegotiationTestEnv(t)
+		env.salesRepo.paymentsByVersion = env.salesRepo.paymentsByVersion[:1]
+
+		if err := env.useCase.DoRenegotiation(context.Background(), newRenegotiationInput()); err != domain.ErrRenegotiationNoOpenInstallments {
+			t.Fatalf("expected no open installments error, got %v", err)
+		}
+		if env.salesRepo.renegotiationCreated {
+			t.Fatalf("expected no renegotiation to be created")
+		}
+	})
+}
+
+func TestSalesUseCaseDoRenegotiationErrors(t *testing.T) {
+	expectedErr := stdErrors.New("failure")
+	cases := map[string]func(env saleTestEnv){
+		"user":                 func(env saleTestEnv) { env.userRepo.err = expectedErr },
+		"sale for update":      func(env saleTestEnv) { env.salesRepo.saleByIdForUpdateErr = expectedErr },
+		"items by version":     func(env saleTestEnv) { env.salesRepo.itemsByVersionErr = expectedErr },
+		"payments":             func(env saleTestEnv) { env.salesRepo.paymentsByVersionErr = expectedErr },
+		"create version":       func(env saleTestEnv) { env.salesRepo.createSaleVersionErr = expectedErr },
+		"create items":         func(env saleTestEnv) { env.salesRepo.createItemsErr = expectedErr },
+		"create payment":       func(env saleTestEnv) { env.salesRepo.createPaymentErr = expectedErr },
+		"create dates":         func(env saleTestEnv) { env.salesRepo.createDatesErr = expectedErr },
+		"create renegotiation": func(env saleTestEnv) { env.salesRepo.createRenegotiationErr = expectedErr },
+		"cancel dates":         func(env saleTestEnv) { env.salesRepo.cancelPaymentDatesErr = expectedErr },
+		"update version":       func(env saleTestEnv) { env.salesRepo.updateSaleLastVersionErr = expectedErr },
+	}
+
+	for name, setup := range cases {
+		t.Run(name, func(t *testing.T) {
+			env := newRenegotiationTestEnv(t)
+			setup(env)
+
+			if err := env.useCase.DoRenegotiation(context.Background(), newRenegotiationInput()); err != expectedErr {
+				t.Fatalf("expected error %v, got %v", expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestSalesUseCaseDoSaleRespectsStockReservations(t *testing.T) {
+	env := newSaleTestEnv(t)
+	env.quoteRepo.reserved = map[int64]float64{3: 4}
+
+	err := env.useCase.DoSale(context.Background(), env.input)
+	if err == nil || !strings.HasPrefix(err.Error(), domain.ErrQuantityNotValid.Error()) {
+		t.Fatalf("expected reserved stock to be unavailable, got %v", err)
+	}
+	if env.salesRepo.createManySaleItemCalls != 0 {
+		t.Fatalf("expected sale not to be created")
+	}
+
+	env = newSaleTestEnv(t)
+	env.quoteRepo.reservedErr = stdErrors.New("reserved error")
+	if err := env.useCase.DoSale(context.Background(), env.input); err == nil || err.Error() != "reserved error" {
+		t.Fatalf("expected reserved error, got %v", err)
+	}
+}
+
+func TestSalesUseCaseDoSaleConvertsQuote(t *testing.T) {
+	env := newSaleTestEnv(t)
+	env.quoteRepo.reserved = map[int64]float64{3: 3}
+	env.input.QuoteId = 7
+	env.input.Items[0].UnitPrice = 9
+	env.input.Payments[0].Dates[0].InstallmentValue = 18
+
+	if err := env.useCase.DoSale(context.Background(), env.input); err != nil {
+		t.Fatalf("expected sale to succeed, got %v", err)
+	}
+	if env.quoteRepo.exceptQuoteId != 7 {
+		t.Fatalf("expected reservations of the quote itself to be ignored, got %d", env.quoteRepo.exceptQuoteId)
+	}
+	if env.inventoryUseCase.received.ExceptQuoteId != 7 {
+		t.Fatalf("expected stock movement to ignore the converted quote, got %d", env.inventoryUseCase.received.ExceptQuoteId)
+	}
+	if env.quoteRepo.convertedQuoteId != 7 || env.quoteRepo.convertedSalesId != 101 {
+		t.Fatalf("expected quote to be marked as converted, got %d/%d", env.quoteRepo.convertedQuoteId, env.quoteRepo.convertedSalesId)
+	}
+	if env.salesRepo.saleItems[0].Sku.Price != 9 {
+		t.Fatalf("expected quoted unit price to be kept, got %v", env.salesRepo.saleItems[0].Sku.Price)
+	}
+
+	env = newSaleTestEnv(t)
+	env.input.QuoteId = 7
+	env.quoteRepo.convertErr = domain.ErrQuoteNotOpen
+	if err := env.useCase.DoSale(context.Background(), env.input); err != domain.ErrQuoteNotOpen {
+		t.Fatalf("expected quote not open error, got %v", err)
+	}
+}
+
+func TestSalesUseCaseDoQuote(t *testing.T) {
+	newQuoteInput := func(env saleTestEnv) DoQuoteInput {
+		expiresAt := time.Now().Add(72 * time.Hour)
+		return DoQuoteInput{
+			UserId:       env.input.UserId,
+			CustomerId:   env.input.CustomerId,
+			Items:        env.input.Items,
+			Payments:     env.input.Payments,
+			Plan:         []domain.QuotePayment{{PaymentType: domain.PaymentTypeCash, Value: 20}},
+			ExpiresAt:    &expiresAt,
+			ReserveStock: true,
+		}
+	}
+
+	t.Run("success", func(t *testing.T) {
+		env := newSaleTestEnv(t)
+		if err := env.useCase.DoQuote(context.Background(), newQuoteInput(env)); err != nil {
+			t.Fatalf("expected quote to succeed, got %v", err)
+		}
+		quote := env.quoteRepo.quote
+		if quote.Status != domain.QuoteStatusOpen || !quote.ReserveStock || quote.InventoryId != env.inventoryRepo.byUser.Id {
+			t.Fatalf("unexpected quote: %+v", quote)
+		}
+		if len(env.quoteRepo.items) != 1 || env.quoteRepo.items[0].Quantity != 2 || len(env.quoteRepo.payments) != 1 {
+			t.Fatalf("expected items and payment plan to be persisted: %+v %+v", env.quoteRepo.items, env.quoteRepo.payments)
+		}
+		if env.salesRepo.createManySaleItemCalls != 0 || env.inventoryUseCase.received.Type != "" {
+			t.Fatalf("expected quote not to create sale nor move stock")
+		}
+	})
+
+	t.Run("reserved by other quote", func(t *testing.T) {
+		env := newSaleTestEnv(t)
+		env.quoteRepo.reserved = map[int64]float64{3: 4}
+		err := env.useCase.DoQuote(context.Background(), newQuoteInput(env))
+		if err == nil || !strings.HasPrefix(err.Error(), domain.ErrQuantityNotValid.Error()) {
+			t.Fatalf("expected quantity error, got %v", err)
+		}
+	})
+
+	t.Run("invalid expiration", func(t *testing.T) {
+		env := newSaleTestEnv(t)
+		input := newQuoteInput(env)
+		expiresAt := time.Now().Add(-time.Hour)
+		input.ExpiresAt = &expiresAt
+		if err := env.useCase.DoQuote(context.Background(), input); err != domain.ErrQuoteExpiresAtInvalid {
+			t.Fatalf("expected expiration error, got %v", err)
+		}
+	})
+
+	t.Run("discount over reseller limit waits for approval", func(t *testing.T) {
+		env := newSaleTestEnv(t)
+		env.discountRuleRepo.rule = domain.NewDiscountRule(5)
+		input := newQuoteInput(env)
+		input.Discount = domain.NewSalesDiscount(domain.DiscountTypePercentage, 10, "Cliente fiel")
+		input.Payments[0].Dates[0].InstallmentValue = 18
+		if err := env.useCase.DoQuote(context.Background(), input); err != nil {
+			t.Fatalf("expected quote to be saved for admin approval, got %v", err)
+		}
+		if env.quoteRepo.quote.Discount.Reason != "Cliente fiel" {
+			t.Fatalf("expected quote discount to be kept, got %+v", env.quoteRepo.quote.Discount)
+		}
+	})
+}
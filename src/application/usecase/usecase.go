@@ -23,16 +23,22 @@ func NewApplicationUseCase(repositories *repository.Repository, config *config.C
 }
 
 func (s *ApplicationUseCase) SetupUseCases() {
-	s.InventoryUseCase = inventory_usecase.NewInventoryUseCase(s.repositories, s.repositories.InventoryRepository, s.repositories.InventoryItemRepository, s.repositories.InventoryTransactionRepository, s.repositories.SkuRepository)
+	s.InventoryUseCase = inventory_usecase.NewInventoryUseCase(s.repositories, s.repositories.InventoryRepository, s.repositories.InventoryItemRepository, s.repositories.InventoryTransactionRepository, s.repositories.SkuRepository, s.repositories.QuoteRepository)
 	s.EmailUseCase = emailusecase.NewEmailUseCase(s.config, s.ports.EmailPort)
 	s.SalesUsecase = sales_usecase.NewSalesUseCase(
 		s.repositories.UserRepository,
 		s.repositories.CustomerRepository,
+		s.repositories.CustomerCreditRepository,
 		s.repositories.SkuRepository,
 		s.repositories.SalesRepository,
 		s.InventoryUseCase,
 		s.repositories.InventoryRepository,
 		s.repositories.InventoryItemRepository,
+		s.repositories.DiscountRuleRepository,
+		s.repositories.QuoteRepository,
+		s.repositories.CardFeeRuleRepository,
+		s.repositories.CashRegisterRepository,
+		s.repositories.LateFeeRuleRepository,
 		s.repositories,
 	)
 }
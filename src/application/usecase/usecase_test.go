@@ -22,7 +22,7 @@ func (fakeEmailPort) Send(senderEmail string, senderName string, toEmail string,
 }
 
 func newTestPorts() *ports.Ports {
-	return ports.NewPorts(fakeEncrypto{}, fakeEmailPort{})
+	return ports.NewPorts(fakeEncrypto{}, fakeEmailPort{}, nil, nil)
 }
 
 func TestNewApplicationUseCase(t *testing.T) {
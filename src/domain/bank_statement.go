@@ -0,0 +1,206 @@
+package domain
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	ErrBankStatementFormat  = errors.New("Formato de extrato não suportado. Envie um arquivo OFX ou CSV")
+	ErrBankStatementInvalid = errors.New("Arquivo de extrato inválido")
+	ErrBankStatementEmpty   = errors.New("Extrato não possui lançamentos")
+)
+
+// BankStatementTransaction é um lançamento do extrato bancário. Créditos têm
+// valor positivo.
+type BankStatementTransaction struct {
+	Id          string
+	Date        time.Time
+	Amount      float64
+	Description string
+}
+
+// ParseBankStatement lê um extrato OFX ou CSV, identificado pela extensão do
+// arquivo ou, na falta dela, pelo conteúdo.
+func ParseBankStatement(fileName string, content []byte) ([]BankStatementTransaction, error) {
+	var transactions []BankStatementTransaction
+	var err error
+	switch strings.ToLower(filepath.Ext(fileName)) {
+	case ".ofx":
+		transactions, err = ParseOfx(content)
+	case ".csv":
+		transactions, err = ParseBankStatementCsv(content)
+	default:
+		if bytes.Contains(bytes.ToUpper(content), []byte("<OFX>")) {
+			transactions, err = ParseOfx(content)
+		} else {
+			return nil, ErrBankStatementFormat
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(transactions) == 0 {
+		return nil, ErrBankStatementEmpty
+	}
+	return transactions, nil
+}
+
+var (
+	ofxTransactionRegexp = regexp.MustCompile(`(?is)<STMTTRN>(.*?)</STMTTRN>`)
+	ofxTagRegexp         = regexp.MustCompile(`(?i)<([A-Z0-9.]+)>([^<\r\n]*)`)
+)
+
+// ParseOfx lê os lançamentos (STMTTRN) de um extrato OFX, tanto no formato
+// SGML (tags sem fechamento) quanto no XML.
+func ParseOfx(content []byte) ([]BankStatementTransaction, error) {
+	text := string(content)
+	if !strings.Contains(strings.ToUpper(text), "<OFX>") {
+		return nil, ErrBankStatementInvalid
+	}
+
+	transactions := make([]BankStatementTransaction, 0)
+	for _, block := range ofxTransactionRegexp.FindAllStringSubmatch(text, -1) {
+		tags := make(map[string]string)
+		for _, tag := range ofxTagRegexp.FindAllStringSubmatch(block[1], -1) {
+			tags[strings.ToUpper(tag[1])] = strings.TrimSpace(tag[2])
+		}
+
+		date, err := parseOfxDate(tags["DTPOSTED"])
+		if err != nil {
+			return nil, ErrBankStatementInvalid
+		}
+		amount, err := parseStatementAmount(tags["TRNAMT"])
+		if err != nil {
+			return nil, ErrBankStatementInvalid
+		}
+		description := strings.TrimSpace(tags["NAME"] + " " + tags["MEMO"])
+		transactions = append(transactions, BankStatementTransaction{
+			Id:          tags["FITID"],
+			Date:        date,
+			Amount:      amount,
+			Description: description,
+		})
+	}
+	return transactions, nil
+}
+
+// parseOfxDate aceita datas como 20261015, 20261015120000 e
+// 20261015120000[-3:BRT], considerando apenas o dia.
+func parseOfxDate(value string) (time.Time, error) {
+	if len(value) < 8 {
+		return time.Time{}, ErrBankStatementInvalid
+	}
+	return time.Parse("20060102", value[:8])
+}
+
+var (
+	csvDateColumns        = []string{"data", "date", "data lancamento", "data lançamento", "data do lançamento"}
+	csvAmountColumns      = []string{"valor", "amount", "value", "valor (r$)"}
+	csvDescriptionColumns = []string{"descricao", "descrição", "description", "historico", "histórico", "memo", "lançamento", "lancamento"}
+	csvIdColumns          = []string{"id", "identificador", "documento", "fitid"}
+	csvDateLayouts        = []string{"02/01/2006", "2006-01-02", "02/01/06", "02-01-2006"}
+)
+
+// ParseBankStatementCsv lê um extrato CSV com cabeçalho, separado por vírgula
+// ou ponto e vírgula. As colunas de data e valor são obrigatórias; descrição e
+// identificador são opcionais.
+func ParseBankStatementCsv(content []byte) ([]BankStatementTransaction, error) {
+	content = bytes.TrimPrefix(content, []byte("\xef\xbb\xbf"))
+	firstLine := string(content)
+	if i := strings.IndexAny(firstLine, "\r\n"); i >= 0 {
+		firstLine = firstLine[:i]
+	}
+	reader := csv.NewReader(bytes.NewReader(content))
+	if strings.Count(firstLine, ";") > strings.Count(firstLine, ",") {
+		reader.Comma = ';'
+	}
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	records, err := reader.ReadAll()
+	if err != nil || len(records) == 0 {
+		return nil, ErrBankStatementInvalid
+	}
+
+	header := records[0]
+	dateColumn := findCsvColumn(header, csvDateColumns)
+	amountColumn := findCsvColumn(header, csvAmountColumns)
+	descriptionColumn := findCsvColumn(header, csvDescriptionColumns)
+	idColumn := findCsvColumn(header, csvIdColumns)
+	if dateColumn < 0 || amountColumn < 0 {
+		return nil, ErrBankStatementInvalid
+	}
+
+	transactions := make([]BankStatementTransaction, 0, len(records)-1)
+	for _, record := range records[1:] {
+		if len(record) <= dateColumn || len(record) <= amountColumn || strings.TrimSpace(record[dateColumn]) == "" {
+			continue
+		}
+		date, err := parseCsvDate(record[dateColumn])
+		if err != nil {
+			return nil, ErrBankStatementInvalid
+		}
+		amount, err := parseStatementAmount(record[amountColumn])
+		if err != nil {
+			return nil, ErrBankStatementInvalid
+		}
+		transaction := BankStatementTransaction{Date: date, Amount: amount}
+		if descriptionColumn >= 0 && descriptionColumn < len(record) {
+			transaction.Description = strings.TrimSpace(record[descriptionColumn])
+		}
+		if idColumn >= 0 && idColumn < len(record) {
+			transaction.Id = strings.TrimSpace(record[idColumn])
+		}
+		transactions = append(transactions, transaction)
+	}
+	return transactions, nil
+}
+
+func findCsvColumn(header []string, names []string) int {
+	for i, column := range header {
+		column = strings.ToLower(strings.TrimSpace(column))
+		for _, name := range names {
+			if column == name {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+func parseCsvDate(value string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if len(value) > 10 {
+		value = value[:10]
+	}
+	for _, layout := range csvDateLayouts {
+		if date, err := time.Parse(layout, value); err == nil {
+			return date, nil
+		}
+	}
+	return time.Time{}, ErrBankStatementInvalid
+}
+
+// parseStatementAmount aceita valores como 1234.56, 1.234,56, -50,00 e
+// R$ 10,00. Com vírgula e ponto, o último separador é o decimal.
+func parseStatementAmount(value string) (float64, error) {
+	value = strings.TrimSpace(strings.ReplaceAll(strings.ReplaceAll(value, "R$", ""), " ", ""))
+	comma, dot := strings.LastIndex(value, ","), strings.LastIndex(value, ".")
+	switch {
+	case comma >= 0 && dot >= 0 && comma > dot:
+		value = strings.ReplaceAll(value, ".", "")
+		value = strings.Replace(value, ",", ".", 1)
+	case comma >= 0 && dot >= 0:
+		value = strings.ReplaceAll(value, ",", "")
+	case comma >= 0:
+		value = strings.Replace(value, ",", ".", 1)
+	}
+	return strconv.ParseFloat(value, 64)
+}
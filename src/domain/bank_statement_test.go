@@ -0,0 +1,94 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+const ofxStatement = `OFXHEADER:100
+DATA:OFXSGML
+VERSION:102
+
+<OFX>
+<BANKMSGSRSV1><STMTTRNRS><STMTRS>
+<BANKTRANLIST>
+<DTSTART>20261001
+<STMTTRN>
+<TRNTYPE>CREDIT
+<DTPOSTED>20261015120000[-3:BRT]
+<TRNAMT>150.25
+<FITID>0001
+<NAME>PIX RECEBIDO
+<MEMO>PD0000000042
+</STMTTRN>
+<STMTTRN>
+<TRNTYPE>DEBIT
+<DTPOSTED>20261016
+<TRNAMT>-20.00
+<FITID>0002
+<MEMO>TARIFA
+</STMTTRN>
+</BANKTRANLIST>
+</STMTRS></STMTTRNRS></BANKMSGSRSV1>
+</OFX>`
+
+func TestParseOfx(t *testing.T) {
+	transactions, err := ParseBankStatement("extrato.ofx", []byte(ofxStatement))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(transactions) != 2 {
+		t.Fatalf("expected 2 transactions, got %d", len(transactions))
+	}
+	first := transactions[0]
+	if first.Id != "0001" || first.Amount != 150.25 || first.Description != "PIX RECEBIDO PD0000000042" {
+		t.Fatalf("unexpected transaction: %+v", first)
+	}
+	if !first.Date.Equal(time.Date(2026, 10, 15, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("unexpected date: %v", first.Date)
+	}
+	if transactions[1].Amount != -20 || transactions[1].Description != "TARIFA" {
+		t.Fatalf("unexpected transaction: %+v", transactions[1])
+	}
+
+	if _, err := ParseBankStatement("extrato", []byte(ofxStatement)); err != nil {
+		t.Fatalf("expected ofx to be detected by content, got %v", err)
+	}
+}
+
+func TestParseBankStatementCsv(t *testing.T) {
+	content := "\xef\xbb\xbfData;Descrição;Valor;Documento\n15/10/2026;PIX RECEBIDO PD0000000042;1.234,56;abc\n16/10/2026;TARIFA;-20,00;\n"
+	transactions, err := ParseBankStatement("extrato.csv", []byte(content))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(transactions) != 2 {
+		t.Fatalf("expected 2 transactions, got %d", len(transactions))
+	}
+	if transactions[0].Amount != 1234.56 || transactions[0].Id != "abc" || transactions[0].Description != "PIX RECEBIDO PD0000000042" {
+		t.Fatalf("unexpected transaction: %+v", transactions[0])
+	}
+	if transactions[1].Amount != -20 {
+		t.Fatalf("unexpected transaction: %+v", transactions[1])
+	}
+
+	transactions, err = ParseBankStatementCsv([]byte("date,amount,description\n2026-10-15,\"1,234.56\",PIX\n"))
+	if err != nil || len(transactions) != 1 || transactions[0].Amount != 1234.56 {
+		t.Fatalf("unexpected us formatted csv: %+v %v", transactions, err)
+	}
+}
+
+func TestParseBankStatementErrors(t *testing.T) {
+	if _, err := ParseBankStatement("extrato.pdf", []byte("%PDF")); err != ErrBankStatementFormat {
+		t.Fatalf("expected format error, got %v", err)
+	}
+	if _, err := ParseBankStatement("extrato.csv", []byte("nome;valor\nfulano;10,00\n")); err != ErrBankStatementInvalid {
+		t.Fatalf("expected invalid error, got %v", err)
+	}
+	if _, err := ParseBankStatement("extrato.csv", []byte("data;valor\n15/10/2026;abc\n")); err != ErrBankStatementInvalid {
+		t.Fatalf("expected invalid amount error, got %v", err)
+	}
+	if _, err := ParseBankStatement("extrato.csv", []byte("data;valor\n")); err != ErrBankStatementEmpty {
+		t.Fatalf("expected empty error, got %v", err)
+	}
+}
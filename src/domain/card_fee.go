@@ -0,0 +1,159 @@
+package domain
+
+import (
+	"errors"
+	"math"
+	"time"
+)
+
+type CardBrand string
+
+const (
+	CardBrandVisa       CardBrand = "VISA"
+	CardBrandMastercard CardBrand = "MASTERCARD"
+	CardBrandElo        CardBrand = "ELO"
+	CardBrandAmex       CardBrand = "AMEX"
+	CardBrandHipercard  CardBrand = "HIPERCARD"
+	CardBrandOther      CardBrand = "OTHER"
+)
+
+const (
+	// MaxCardInstallments é o maior número de parcelas aceito nas tabelas de taxas.
+	MaxCardInstallments = 24
+	// DefaultDebitSettlementDays e DefaultCreditSettlementDays são os prazos de
+	// repasse usados quando a empresa não cadastrou taxa para a bandeira.
+	DefaultDebitSettlementDays  = 1
+	DefaultCreditSettlementDays = 30
+	// creditInstallmentInterval é o intervalo, em dias, entre os repasses das
+	// parcelas do crédito parcelado.
+	creditInstallmentInterval = 30
+)
+
+var (
+	ErrCardBrandInvalid             = errors.New("Bandeira do cartão inválida")
+	ErrCardBrandOnlyCard            = errors.New("Bandeira só pode ser informada para pagamentos com cartão")
+	ErrCardFeePaymentTypeInvalid    = errors.New("Taxas de cartão só podem ser cadastradas para crédito ou débito")
+	ErrCardFeeInstallmentsInvalid   = errors.New("Quantidade de parcelas da taxa de cartão inválida")
+	ErrCardFeePercentageInvalid     = errors.New("Percentual da taxa de cartão deve estar entre 0 e 100")
+	ErrCardFeeSettlementDaysInvalid = errors.New("Prazo de recebimento do cartão deve estar entre 0 e 365 dias")
+	ErrCardFeeRuleNotFound          = errors.New("Taxa de cartão não encontrada")
+)
+
+func IsCardBrandValid(brand CardBrand) bool {
+	switch brand {
+	case CardBrandVisa, CardBrandMastercard, CardBrandElo, CardBrandAmex, CardBrandHipercard, CardBrandOther:
+		return true
+	}
+	return false
+}
+
+func IsCardPayment(paymentType PaymentType) bool {
+	return paymentType == PaymentTypeCreditCard || paymentType == PaymentTypeDebitCard
+}
+
+// CardFeeRule é a taxa cobrada pela credenciadora para uma bandeira, tipo de
+// cartão e quantidade de parcelas. SettlementDays é o prazo de repasse da
+// primeira parcela; no crédito parcelado as demais são repassadas a cada 30 dias.
+type CardFeeRule struct {
+	Id             int64
+	Brand          CardBrand
+	PaymentType    PaymentType
+	Installments   int
+	FeePercentage  float64
+	SettlementDays int
+}
+
+func NewCardFeeRule(brand CardBrand, paymentType PaymentType, installments int, feePercentage float64, settlementDays int) CardFeeRule {
+	return CardFeeRule{
+		Brand:          brand,
+		PaymentType:    paymentType,
+		Installments:   installments,
+		FeePercentage:  feePercentage,
+		SettlementDays: settlementDays,
+	}
+}
+
+func (r *CardFeeRule) Validate() error {
+	if !IsCardBrandValid(r.Brand) {
+		return ErrCardBrandInvalid
+	}
+	if !IsCardPayment(r.PaymentType) {
+		return ErrCardFeePaymentTypeInvalid
+	}
+	if r.Installments < 1 || r.Installments > MaxCardInstallments || (r.PaymentType == PaymentTypeDebitCard && r.Installments != 1) {
+		return ErrCardFeeInstallmentsInvalid
+	}
+	if r.FeePercentage < 0 || r.FeePercentage > 100 {
+		return ErrCardFeePercentageInvalid
+	}
+	if r.SettlementDays < 0 || r.SettlementDays > 365 {
+		return ErrCardFeeSettlementDaysInvalid
+	}
+	return nil
+}
+
+// FindCardFeeRule procura a taxa da bandeira para o tipo e a quantidade de
+// parcelas. Sem taxa para a bandeira, usa a cadastrada para OTHER.
+func FindCardFeeRule(rules []CardFeeRule, brand CardBrand, paymentType PaymentType, installments int) (CardFeeRule, bool) {
+	var fallback *CardFeeRule
+	for i, rule := range rules {
+		if rule.PaymentType != paymentType || rule.Installments != installments {
+			continue
+		}
+		if rule.Brand == brand {
+			return rule, true
+		}
+		if rule.Brand == CardBrandOther {
+			fallback = &rules[i]
+		}
+	}
+	if fallback != nil {
+		return *fallback, true
+	}
+	return CardFeeRule{}, false
+}
+
+// ApplyCardFees calcula a taxa, o valor líquido e a data prevista de repasse de
+// cada parcela de um pagamento com cartão. Sem taxa cadastrada, a parcela é
+// repassada integralmente no prazo padrão do tipo de cartão.
+func (s *SalesPayment) ApplyCardFees(rules []CardFeeRule, saleDate time.Time) {
+	if !IsCardPayment(s.PaymentType) {
+		return
+	}
+	settlementDays := DefaultCreditSettlementDays
+	if s.PaymentType == PaymentTypeDebitCard {
+		settlementDays = DefaultDebitSettlementDays
+	}
+	var feePercentage float64
+	if rule, ok := FindCardFeeRule(rules, s.CardBrand, s.PaymentType, len(s.Dates)); ok {
+		feePercentage = rule.FeePercentage
+		settlementDays = rule.SettlementDays
+	}
+
+	day := time.Date(saleDate.Year(), saleDate.Month(), saleDate.Day(), 0, 0, 0, 0, saleDate.Location())
+	for i := range s.Dates {
+		date := &s.Dates[i]
+		date.CardBrand = s.CardBrand
+		date.CardFeeValue = math.Round(date.InstallmentValue*feePercentage) / 100
+		settlementDate := day.AddDate(0, 0, settlementDays+creditInstallmentInterval*(date.InstallmentNumber-1))
+		date.SettlementDate = &settlementDate
+	}
+}
+
+func (s *SalesPayment) validateCardBrand() error {
+	if s.CardBrand == "" {
+		return nil
+	}
+	if !IsCardPayment(s.PaymentType) {
+		return ErrCardBrandOnlyCard
+	}
+	if !IsCardBrandValid(s.CardBrand) {
+		return ErrCardBrandInvalid
+	}
+	return nil
+}
+
+// GetNetValue é o valor da parcela descontada a taxa do cartão.
+func (d *SalesPaymentDates) GetNetValue() float64 {
+	return math.Round((d.InstallmentValue-d.CardFeeValue)*100) / 100
+}
@@ -0,0 +1,9 @@
+package domain
+
+import "context"
+
+type CardFeeRuleRepository interface {
+	GetAll(ctx context.Context) ([]CardFeeRule, error)
+	Save(ctx context.Context, rule CardFeeRule) (int64, error)
+	Delete(ctx context.Context, id int64) error
+}
@@ -0,0 +1,86 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCardFeeRuleValidate(t *testing.T) {
+	rule := NewCardFeeRule(CardBrandVisa, PaymentTypeCreditCard, 3, 4.5, 30)
+	if err := rule.Validate(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	cases := []struct {
+		rule CardFeeRule
+		err  error
+	}{
+		{NewCardFeeRule("DINERS", PaymentTypeCreditCard, 1, 1, 30), ErrCardBrandInvalid},
+		{NewCardFeeRule(CardBrandVisa, PaymentTypePix, 1, 1, 30), ErrCardFeePaymentTypeInvalid},
+		{NewCardFeeRule(CardBrandVisa, PaymentTypeDebitCard, 2, 1, 1), ErrCardFeeInstallmentsInvalid},
+		{NewCardFeeRule(CardBrandVisa, PaymentTypeCreditCard, 25, 1, 30), ErrCardFeeInstallmentsInvalid},
+		{NewCardFeeRule(CardBrandVisa, PaymentTypeCreditCard, 1, 101, 30), ErrCardFeePercentageInvalid},
+		{NewCardFeeRule(CardBrandVisa, PaymentTypeCreditCard, 1, 1, -1), ErrCardFeeSettlementDaysInvalid},
+	}
+	for _, c := range cases {
+		if err := c.rule.Validate(); err != c.err {
+			t.Fatalf("expected %v for %+v, got %v", c.err, c.rule, err)
+		}
+	}
+}
+
+func TestFindCardFeeRule(t *testing.T) {
+	rules := []CardFeeRule{
+		NewCardFeeRule(CardBrandOther, PaymentTypeCreditCard, 1, 3, 30),
+		NewCardFeeRule(CardBrandVisa, PaymentTypeCreditCard, 1, 2.5, 30),
+	}
+
+	if rule, ok := FindCardFeeRule(rules, CardBrandVisa, PaymentTypeCreditCard, 1); !ok || rule.FeePercentage != 2.5 {
+		t.Fatalf("expected brand rule, got %+v", rule)
+	}
+	if rule, ok := FindCardFeeRule(rules, CardBrandElo, PaymentTypeCreditCard, 1); !ok || rule.FeePercentage != 3 {
+		t.Fatalf("expected fallback rule, got %+v", rule)
+	}
+	if _, ok := FindCardFeeRule(rules, CardBrandVisa, PaymentTypeCreditCard, 2); ok {
+		t.Fatalf("expected no rule for other installments")
+	}
+}
+
+func TestSalesPaymentApplyCardFees(t *testing.T) {
+	saleDate := time.Date(2024, 1, 10, 15, 30, 0, 0, time.UTC)
+	rules := []CardFeeRule{
+		NewCardFeeRule(CardBrandMastercard, PaymentTypeCreditCard, 3, 5, 30),
+		NewCardFeeRule(CardBrandMastercard, PaymentTypeDebitCard, 1, 1.5, 1),
+	}
+
+	credit := SalesPayment{PaymentType: PaymentTypeCreditCard, CardBrand: CardBrandMastercard}
+	for i := 1; i <= 3; i++ {
+		credit.Dates = append(credit.Dates, SalesPaymentDates{InstallmentNumber: i, InstallmentValue: 33.33})
+	}
+	credit.ApplyCardFees(rules, saleDate)
+	last := credit.Dates[2]
+	if last.CardFeeValue != 1.67 || last.GetNetValue() != 31.66 || last.CardBrand != CardBrandMastercard {
+		t.Fatalf("unexpected credit fee: %+v", last)
+	}
+	if !last.SettlementDate.Equal(time.Date(2024, 4, 9, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("expected third installment settled 90 days after the sale, got %v", last.SettlementDate)
+	}
+
+	debit := SalesPayment{PaymentType: PaymentTypeDebitCard, CardBrand: CardBrandMastercard, Dates: []SalesPaymentDates{{InstallmentNumber: 1, InstallmentValue: 100}}}
+	debit.ApplyCardFees(rules, saleDate)
+	if debit.Dates[0].CardFeeValue != 1.5 || !debit.Dates[0].SettlementDate.Equal(time.Date(2024, 1, 11, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("unexpected debit fee: %+v", debit.Dates[0])
+	}
+
+	withoutRule := SalesPayment{PaymentType: PaymentTypeCreditCard, Dates: []SalesPaymentDates{{InstallmentNumber: 1, InstallmentValue: 50}}}
+	withoutRule.ApplyCardFees(rules, saleDate)
+	if withoutRule.Dates[0].CardFeeValue != 0 || !withoutRule.Dates[0].SettlementDate.Equal(time.Date(2024, 2, 9, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("expected full value on default settlement, got %+v", withoutRule.Dates[0])
+	}
+
+	pix := SalesPayment{PaymentType: PaymentTypePix, Dates: []SalesPaymentDates{{InstallmentNumber: 1, InstallmentValue: 50}}}
+	pix.ApplyCardFees(rules, saleDate)
+	if pix.Dates[0].SettlementDate != nil {
+		t.Fatalf("expected no settlement date for pix")
+	}
+}
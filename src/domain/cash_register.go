@@ -0,0 +1,203 @@
+package domain
+
+import (
+	"errors"
+	"math"
+	"time"
+)
+
+type CashRegisterSessionStatus string
+
+const (
+	CashRegisterSessionStatusOpen   CashRegisterSessionStatus = "OPEN"
+	CashRegisterSessionStatusClosed CashRegisterSessionStatus = "CLOSED"
+)
+
+type CashRegisterEntryType string
+
+const (
+	CashRegisterEntryTypeSale       CashRegisterEntryType = "SALE"
+	CashRegisterEntryTypeReceipt    CashRegisterEntryType = "RECEIPT"
+	CashRegisterEntryTypeWithdrawal CashRegisterEntryType = "WITHDRAWAL"
+	CashRegisterEntryTypeDeposit    CashRegisterEntryType = "DEPOSIT"
+)
+
+var (
+	ErrCashRegisterSessionNotFound        = errors.New("Caixa não encontrado")
+	ErrCashRegisterSessionNotOpen         = errors.New("Não há caixa aberto para o usuário")
+	ErrCashRegisterSessionAlreadyOpen     = errors.New("Já existe um caixa aberto para o usuário")
+	ErrCashRegisterSessionClosed          = errors.New("O caixa já foi fechado")
+	ErrCashRegisterSessionRequired        = errors.New("É necessário abrir o caixa para movimentar dinheiro")
+	ErrCashRegisterOpeningBalanceInvalid  = errors.New("Saldo de abertura do caixa não pode ser negativo")
+	ErrCashRegisterCountedAmountInvalid   = errors.New("Valor contado no fechamento não pode ser negativo")
+	ErrCashRegisterNotesLength            = errors.New("Observação do caixa deve ter no máximo 2000 caracteres")
+	ErrCashRegisterEntryTypeInvalid       = errors.New("Tipo de lançamento do caixa inválido")
+	ErrCashRegisterEntryValueInvalid      = errors.New("Valor do lançamento do caixa deve ser maior que zero")
+	ErrCashRegisterEntryDescriptionLength = errors.New("Descrição do lançamento deve ter no máximo 255 caracteres")
+	ErrCashRegisterWithdrawalExceeds      = errors.New("Valor da sangria maior que o saldo do caixa")
+)
+
+// CashRegisterSettings indica se a empresa exige caixa aberto para vendas e
+// recebimentos em dinheiro.
+type CashRegisterSettings struct {
+	RequireOpenSession bool
+}
+
+// CashRegisterSession é o caixa de um usuário entre a abertura e o fechamento.
+// O valor esperado é o saldo de abertura somado às vendas, recebimentos e
+// suprimentos em dinheiro, descontadas as sangrias.
+type CashRegisterSession struct {
+	Id             int64
+	User           User
+	Status         CashRegisterSessionStatus
+	OpeningBalance float64
+	ExpectedAmount float64
+	CountedAmount  *float64
+	Difference     *float64
+	Notes          string
+	OpenedAt       time.Time
+	ClosedAt       *time.Time
+	Entries        []CashRegisterEntry
+}
+
+type CashRegisterEntry struct {
+	Id              int64
+	SessionId       int64
+	Type            CashRegisterEntryType
+	Value           float64
+	Description     string
+	SalesId         int64
+	PaymentDateId   int64
+	CreatedByUserId int64
+	CreatedAt       time.Time
+}
+
+func NewCashRegisterSession(user User, openingBalance float64, openedAt time.Time) CashRegisterSession {
+	return CashRegisterSession{
+		User:           user,
+		Status:         CashRegisterSessionStatusOpen,
+		OpeningBalance: openingBalance,
+		OpenedAt:       openedAt,
+	}
+}
+
+func (s *CashRegisterSession) Validate() error {
+	if s.OpeningBalance < 0 {
+		return ErrCashRegisterOpeningBalanceInvalid
+	}
+	if len([]rune(s.Notes)) > 2000 {
+		return ErrCashRegisterNotesLength
+	}
+	return nil
+}
+
+func (s *CashRegisterSession) IsOpen() bool {
+	return s.Status == CashRegisterSessionStatusOpen
+}
+
+// GetBalance é o valor que deveria estar no caixa considerando os lançamentos.
+func (s *CashRegisterSession) GetBalance() float64 {
+	balance := s.OpeningBalance
+	for _, entry := range s.Entries {
+		balance += entry.GetSignedValue()
+	}
+	return math.Round(balance*100) / 100
+}
+
+// Close registra o valor contado, o valor esperado e a diferença entre eles.
+// Diferença negativa indica falta de dinheiro no caixa.
+func (s *CashRegisterSession) Close(countedAmount float64, notes string, closedAt time.Time) error {
+	if !s.IsOpen() {
+		return ErrCashRegisterSessionClosed
+	}
+	if countedAmount < 0 {
+		return ErrCashRegisterCountedAmountInvalid
+	}
+	s.Notes = notes
+	if err := s.Validate(); err != nil {
+		return err
+	}
+
+	difference := math.Round((countedAmount-s.GetBalance())*100) / 100
+	s.Status = CashRegisterSessionStatusClosed
+	s.ExpectedAmount = s.GetBalance()
+	s.CountedAmount = &countedAmount
+	s.Difference = &difference
+	s.ClosedAt = &closedAt
+	return nil
+}
+
+// AddEntry valida o lançamento contra o caixa e o inclui na sessão. Sangrias
+// não podem deixar o caixa com saldo negativo.
+func (s *CashRegisterSession) AddEntry(entry CashRegisterEntry) (CashRegisterEntry, error) {
+	if !s.IsOpen() {
+		return entry, ErrCashRegisterSessionClosed
+	}
+	if err := entry.Validate(); err != nil {
+		return entry, err
+	}
+	if entry.Type == CashRegisterEntryTypeWithdrawal && entry.Value > s.GetBalance() {
+		return entry, ErrCashRegisterWithdrawalExceeds
+	}
+	entry.SessionId = s.Id
+	s.Entries = append(s.Entries, entry)
+	return entry, nil
+}
+
+func NewCashRegisterEntry(entryType CashRegisterEntryType, value float64, description string, createdByUserId int64, createdAt time.Time) CashRegisterEntry {
+	return CashRegisterEntry{
+		Type:            entryType,
+		Value:           math.Round(value*100) / 100,
+		Description:     description,
+		CreatedByUserId: createdByUserId,
+		CreatedAt:       createdAt,
+	}
+}
+
+func (e *CashRegisterEntry) Validate() error {
+	switch e.Type {
+	case CashRegisterEntryTypeSale, CashRegisterEntryTypeReceipt, CashRegisterEntryTypeWithdrawal, CashRegisterEntryTypeDeposit:
+	default:
+		return ErrCashRegisterEntryTypeInvalid
+	}
+	if e.Value <= 0 {
+		return ErrCashRegisterEntryValueInvalid
+	}
+	if len([]rune(e.Description)) > 255 {
+		return ErrCashRegisterEntryDescriptionLength
+	}
+	return nil
+}
+
+func (e CashRegisterEntry) GetSignedValue() float64 {
+	if e.Type == CashRegisterEntryTypeWithdrawal {
+		return -e.Value
+	}
+	return e.Value
+}
+
+// GetCashReceivedTotal soma as parcelas em dinheiro já quitadas na venda, que
+// entram no caixa do vendedor.
+func (s *Sales) GetCashReceivedTotal() float64 {
+	total := 0.0
+	for _, payment := range s.Payments {
+		if payment.PaymentType != PaymentTypeCash {
+			continue
+		}
+		for _, date := range payment.Dates {
+			if date.Status == PaymentStatusPaid {
+				total += date.InstallmentValue
+			}
+		}
+	}
+	return math.Round(total*100) / 100
+}
+
+func (s *Sales) HasCashPayment() bool {
+	for _, payment := range s.Payments {
+		if payment.PaymentType == PaymentTypeCash {
+			return true
+		}
+	}
+	return false
+}
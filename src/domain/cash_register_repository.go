@@ -0,0 +1,22 @@
+package domain
+
+import (
+	"context"
+	"database/sql"
+)
+
+type GetCashRegisterSessionsInput struct {
+	UserId *int64
+}
+
+type CashRegisterRepository interface {
+	GetSettings(ctx context.Context) (CashRegisterSettings, error)
+	SaveSettings(ctx context.Context, settings CashRegisterSettings) error
+	Open(ctx context.Context, session CashRegisterSession) (int64, error)
+	GetOpenByUserId(ctx context.Context, userId int64) (CashRegisterSession, error)
+	GetById(ctx context.Context, id int64) (CashRegisterSession, error)
+	GetAll(ctx context.Context, input GetCashRegisterSessionsInput) ([]CashRegisterSession, error)
+	GetEntries(ctx context.Context, sessionId int64) ([]CashRegisterEntry, error)
+	CreateEntry(ctx context.Context, tx *sql.Tx, entry CashRegisterEntry) (int64, error)
+	Close(ctx context.Context, session CashRegisterSession) error
+}
@@ -0,0 +1,84 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCashRegisterSessionClose(t *testing.T) {
+	now := time.Date(2024, 6, 1, 18, 0, 0, 0, time.UTC)
+	session := NewCashRegisterSession(User{Id: 1}, 100, now.Add(-8*time.Hour))
+	session.Entries = []CashRegisterEntry{
+		NewCashRegisterEntry(CashRegisterEntryTypeSale, 50, "Venda", 1, now),
+		NewCashRegisterEntry(CashRegisterEntryTypeReceipt, 30.5, "Recebimento", 1, now),
+		NewCashRegisterEntry(CashRegisterEntryTypeDeposit, 20, "Troco", 1, now),
+		NewCashRegisterEntry(CashRegisterEntryTypeWithdrawal, 80, "Sangria", 1, now),
+	}
+
+	if err := session.Close(115, "Faltou troco", now); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if session.Status != CashRegisterSessionStatusClosed || session.ExpectedAmount != 120.5 {
+		t.Fatalf("unexpected closed session: %+v", session)
+	}
+	if *session.CountedAmount != 115 || *session.Difference != -5.5 || !session.ClosedAt.Equal(now) {
+		t.Fatalf("expected difference to be recorded, got %+v", session)
+	}
+
+	if err := session.Close(115, "", now); err != ErrCashRegisterSessionClosed {
+		t.Fatalf("expected closed error, got %v", err)
+	}
+
+	open := NewCashRegisterSession(User{Id: 1}, 0, now)
+	if err := open.Close(-1, "", now); err != ErrCashRegisterCountedAmountInvalid {
+		t.Fatalf("expected counted amount error, got %v", err)
+	}
+}
+
+func TestCashRegisterSessionAddEntry(t *testing.T) {
+	now := time.Now()
+	session := NewCashRegisterSession(User{Id: 1}, 50, now)
+	session.Id = 7
+
+	entry, err := session.AddEntry(NewCashRegisterEntry(CashRegisterEntryTypeWithdrawal, 30, "Sangria", 1, now))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if entry.SessionId != 7 || session.GetBalance() != 20 {
+		t.Fatalf("expected withdrawal to reduce balance, got %+v (balance %v)", entry, session.GetBalance())
+	}
+
+	if _, err := session.AddEntry(NewCashRegisterEntry(CashRegisterEntryTypeWithdrawal, 20.01, "", 1, now)); err != ErrCashRegisterWithdrawalExceeds {
+		t.Fatalf("expected withdrawal limit error, got %v", err)
+	}
+	if _, err := session.AddEntry(NewCashRegisterEntry(CashRegisterEntryTypeDeposit, 0, "", 1, now)); err != ErrCashRegisterEntryValueInvalid {
+		t.Fatalf("expected value error, got %v", err)
+	}
+	if _, err := session.AddEntry(NewCashRegisterEntry("OTHER", 10, "", 1, now)); err != ErrCashRegisterEntryTypeInvalid {
+		t.Fatalf("expected type error, got %v", err)
+	}
+
+	invalid := NewCashRegisterSession(User{Id: 1}, -1, now)
+	if err := invalid.Validate(); err != ErrCashRegisterOpeningBalanceInvalid {
+		t.Fatalf("expected opening balance error, got %v", err)
+	}
+}
+
+func TestSalesGetCashReceivedTotal(t *testing.T) {
+	sale := Sales{Payments: []SalesPayment{
+		{PaymentType: PaymentTypeCash, Dates: []SalesPaymentDates{
+			{InstallmentValue: 30, Status: PaymentStatusPaid},
+			{InstallmentValue: 20, Status: PaymentStatusPending},
+		}},
+		{PaymentType: PaymentTypePix, Dates: []SalesPaymentDates{{InstallmentValue: 40, Status: PaymentStatusPaid}}},
+	}}
+
+	if !sale.HasCashPayment() || sale.GetCashReceivedTotal() != 30 {
+		t.Fatalf("expected only paid cash installments, got %v", sale.GetCashReceivedTotal())
+	}
+
+	sale.Payments = sale.Payments[1:]
+	if sale.HasCashPayment() || sale.GetCashReceivedTotal() != 0 {
+		t.Fatalf("expected no cash in pix sale")
+	}
+}
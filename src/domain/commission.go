@@ -0,0 +1,250 @@
+package domain
+
+import (
+	"errors"
+	"math"
+	"time"
+)
+
+type CommissionScope string
+
+const (
+	CommissionScopeProduct  CommissionScope = "PRODUCT"
+	CommissionScopeCategory CommissionScope = "CATEGORY"
+	CommissionScopeReseller CommissionScope = "RESELLER"
+)
+
+type CommissionBase string
+
+const (
+	CommissionBaseSaleValue CommissionBase = "SALE_VALUE"
+	CommissionBaseMargin    CommissionBase = "MARGIN"
+)
+
+type CommissionStatementStatus string
+
+const (
+	CommissionStatementStatusOpen   CommissionStatementStatus = "OPEN"
+	CommissionStatementStatusClosed CommissionStatementStatus = "CLOSED"
+	CommissionStatementStatusPaid   CommissionStatementStatus = "PAID"
+)
+
+var (
+	ErrCommissionRuleNotFound           = errors.New("Regra de comissão não encontrada")
+	ErrCommissionRuleDuplicated         = errors.New("Já existe uma regra de comissão para este escopo")
+	ErrCommissionScopeInvalid           = errors.New("Escopo da comissão inválido")
+	ErrCommissionBaseInvalid            = errors.New("Base de cálculo da comissão inválida")
+	ErrCommissionReferenceRequired      = errors.New("É necessário informar o produto, a categoria ou o revendedor da regra")
+	ErrCommissionPercentageInvalid      = errors.New("Percentual de comissão deve estar entre 0 e 100")
+	ErrCommissionResellerInvalid        = errors.New("A regra por revendedor deve ser de um usuário revendedor")
+	ErrCommissionStatementNotFound      = errors.New("Extrato de comissão não encontrado")
+	ErrCommissionStatementAlreadyClosed = errors.New("Extrato de comissão já fechado para este mês")
+	ErrCommissionStatementNotClosed     = errors.New("O extrato de comissão precisa estar fechado para ser pago")
+	ErrCommissionStatementAlreadyPaid   = errors.New("Extrato de comissão já pago")
+	ErrCommissionMonthInvalid           = errors.New("Mês do extrato inválido")
+	ErrCommissionMonthNotFinished       = errors.New("Apenas meses encerrados podem ser fechados")
+)
+
+// CommissionRule define o percentual de comissão dos revendedores. A regra de
+// produto prevalece sobre a de categoria, que prevalece sobre a do revendedor.
+// Na base MARGIN o percentual incide sobre o valor vendido menos o custo do SKU.
+type CommissionRule struct {
+	Id          int64
+	Scope       CommissionScope
+	ReferenceId int64
+	Percentage  float64
+	Base        CommissionBase
+}
+
+func NewCommissionRule(scope CommissionScope, referenceId int64, percentage float64, base CommissionBase) CommissionRule {
+	return CommissionRule{
+		Scope:       scope,
+		ReferenceId: referenceId,
+		Percentage:  percentage,
+		Base:        base,
+	}
+}
+
+func (r *CommissionRule) Validate() error {
+	if r.Scope != CommissionScopeProduct && r.Scope != CommissionScopeCategory && r.Scope != CommissionScopeReseller {
+		return ErrCommissionScopeInvalid
+	}
+	if r.Base != CommissionBaseSaleValue && r.Base != CommissionBaseMargin {
+		return ErrCommissionBaseInvalid
+	}
+	if r.ReferenceId <= 0 {
+		return ErrCommissionReferenceRequired
+	}
+	if r.Percentage < 0 || r.Percentage > 100 {
+		return ErrCommissionPercentageInvalid
+	}
+	return nil
+}
+
+// CommissionSaleItem é um item da última versão de uma venda, com os dados
+// necessários para encontrar a regra de comissão aplicável.
+type CommissionSaleItem struct {
+	SaleId     int64
+	SkuId      int64
+	ProductId  int64
+	CategoryId *int64
+	Quantity   float64
+	Total      float64
+	Cost       *float64
+}
+
+// CommissionInstallment é uma parcela recebida de uma venda do revendedor.
+type CommissionInstallment struct {
+	PaymentDateId     int64
+	SaleId            int64
+	SaleCode          string
+	CustomerName      string
+	PaymentType       PaymentType
+	InstallmentNumber int
+	PaidDate          time.Time
+	Value             float64
+}
+
+type CommissionEntry struct {
+	PaymentDateId     int64
+	SaleId            int64
+	SaleCode          string
+	CustomerName      string
+	PaymentType       PaymentType
+	InstallmentNumber int
+	PaidDate          time.Time
+	ReceivedValue     float64
+	Percentage        float64
+	Value             float64
+}
+
+type CommissionStatement struct {
+	Id       int64
+	User     User
+	Month    time.Time
+	Status   CommissionStatementStatus
+	Entries  []CommissionEntry
+	ClosedAt *time.Time
+	PaidAt   *time.Time
+}
+
+// NewCommissionStatement calcula o extrato do mês a partir das parcelas
+// recebidas. Cada parcela gera comissão proporcional à taxa efetiva da venda,
+// obtida dos itens da sua última versão.
+func NewCommissionStatement(user User, month time.Time, rules []CommissionRule, installments []CommissionInstallment, items []CommissionSaleItem) CommissionStatement {
+	itemsBySale := make(map[int64][]CommissionSaleItem)
+	for _, item := range items {
+		itemsBySale[item.SaleId] = append(itemsBySale[item.SaleId], item)
+	}
+
+	statement := CommissionStatement{
+		User:    user,
+		Month:   month,
+		Status:  CommissionStatementStatusOpen,
+		Entries: make([]CommissionEntry, 0, len(installments)),
+	}
+	for _, installment := range installments {
+		rate := GetCommissionRate(rules, user.Id, itemsBySale[installment.SaleId])
+		statement.Entries = append(statement.Entries, CommissionEntry{
+			PaymentDateId:     installment.PaymentDateId,
+			SaleId:            installment.SaleId,
+			SaleCode:          installment.SaleCode,
+			CustomerName:      installment.CustomerName,
+			PaymentType:       installment.PaymentType,
+			InstallmentNumber: installment.InstallmentNumber,
+			PaidDate:          installment.PaidDate,
+			ReceivedValue:     installment.Value,
+			Percentage:        math.Round(rate*10000) / 100,
+			Value:             math.Round(installment.Value*rate*100) / 100,
+		})
+	}
+	return statement
+}
+
+// GetCommissionRate retorna a fração do valor da venda devida como comissão ao
+// revendedor, considerando a regra aplicável a cada item.
+func GetCommissionRate(rules []CommissionRule, userId int64, items []CommissionSaleItem) float64 {
+	var total, commission float64
+	for _, item := range items {
+		total += item.Total
+		rule, ok := findCommissionRule(rules, userId, item)
+		if !ok {
+			continue
+		}
+		base := item.Total
+		if rule.Base == CommissionBaseMargin {
+			if item.Cost != nil {
+				base -= *item.Cost * item.Quantity
+			}
+			base = math.Max(base, 0)
+		}
+		commission += base * rule.Percentage / 100
+	}
+	if total <= 0 {
+		return 0
+	}
+	return commission / total
+}
+
+func findCommissionRule(rules []CommissionRule, userId int64, item CommissionSaleItem) (CommissionRule, bool) {
+	var category, reseller *CommissionRule
+	for i, rule := range rules {
+		switch rule.Scope {
+		case CommissionScopeProduct:
+			if rule.ReferenceId == item.ProductId {
+				return rule, true
+			}
+		case CommissionScopeCategory:
+			if item.CategoryId != nil && rule.ReferenceId == *item.CategoryId {
+				category = &rules[i]
+			}
+		case CommissionScopeReseller:
+			if rule.ReferenceId == userId {
+				reseller = &rules[i]
+			}
+		}
+	}
+	if category != nil {
+		return *category, true
+	}
+	if reseller != nil {
+		return *reseller, true
+	}
+	return CommissionRule{}, false
+}
+
+func (s *CommissionStatement) GetTotal() float64 {
+	var total float64
+	for _, entry := range s.Entries {
+		total += entry.Value
+	}
+	return math.Round(total*100) / 100
+}
+
+func (s *CommissionStatement) GetReceivedTotal() float64 {
+	var total float64
+	for _, entry := range s.Entries {
+		total += entry.ReceivedValue
+	}
+	return math.Round(total*100) / 100
+}
+
+func (s *CommissionStatement) ValidatePayment() error {
+	switch s.Status {
+	case CommissionStatementStatusClosed:
+		return nil
+	case CommissionStatementStatusPaid:
+		return ErrCommissionStatementAlreadyPaid
+	default:
+		return ErrCommissionStatementNotClosed
+	}
+}
+
+// ParseCommissionMonth interpreta o mês no formato AAAA-MM.
+func ParseCommissionMonth(value string) (time.Time, error) {
+	month, err := time.Parse("2006-01", value)
+	if err != nil {
+		return month, ErrCommissionMonthInvalid
+	}
+	return month, nil
+}
@@ -0,0 +1,19 @@
+package domain
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+type CommissionRepository interface {
+	GetRules(ctx context.Context) ([]CommissionRule, error)
+	CreateRule(ctx context.Context, rule CommissionRule) (int64, error)
+	DeleteRule(ctx context.Context, id int64) error
+	GetReceivedInstallments(ctx context.Context, userId int64, initialDate time.Time, finalDate time.Time) ([]CommissionInstallment, error)
+	GetSalesItems(ctx context.Context, saleIds []int64) ([]CommissionSaleItem, error)
+	GetStatement(ctx context.Context, userId int64, month time.Time) (CommissionStatement, error)
+	CreateStatement(ctx context.Context, tx *sql.Tx, statement CommissionStatement) (int64, error)
+	CreateStatementEntries(ctx context.Context, tx *sql.Tx, statementId int64, entries []CommissionEntry) error
+	MarkStatementAsPaid(ctx context.Context, id int64) error
+}
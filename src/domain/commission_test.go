@@ -0,0 +1,102 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCommissionRuleValidate(t *testing.T) {
+	cases := map[string]struct {
+		rule CommissionRule
+		err  error
+	}{
+		"valid":      {NewCommissionRule(CommissionScopeProduct, 1, 10, CommissionBaseSaleValue), nil},
+		"scope":      {NewCommissionRule("OTHER", 1, 10, CommissionBaseSaleValue), ErrCommissionScopeInvalid},
+		"base":       {NewCommissionRule(CommissionScopeCategory, 1, 10, "OTHER"), ErrCommissionBaseInvalid},
+		"reference":  {NewCommissionRule(CommissionScopeReseller, 0, 10, CommissionBaseMargin), ErrCommissionReferenceRequired},
+		"percentage": {NewCommissionRule(CommissionScopeReseller, 1, 101, CommissionBaseMargin), ErrCommissionPercentageInvalid},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if err := tc.rule.Validate(); err != tc.err {
+				t.Fatalf("expected %v, got %v", tc.err, err)
+			}
+		})
+	}
+}
+
+func TestGetCommissionRatePrecedence(t *testing.T) {
+	categoryId := int64(20)
+	cost := 30.0
+	rules := []CommissionRule{
+		NewCommissionRule(CommissionScopeReseller, 5, 5, CommissionBaseSaleValue),
+		NewCommissionRule(CommissionScopeCategory, categoryId, 10, CommissionBaseSaleValue),
+		NewCommissionRule(CommissionScopeProduct, 1, 50, CommissionBaseMargin),
+	}
+	items := []CommissionSaleItem{
+		{ProductId: 1, CategoryId: &categoryId, Quantity: 1, Total: 50, Cost: &cost},
+		{ProductId: 2, CategoryId: &categoryId, Quantity: 1, Total: 30},
+		{ProductId: 3, Quantity: 1, Total: 20},
+	}
+
+	// produto: 50% de (50 - 30) = 10; categoria: 10% de 30 = 3; revendedor: 5% de 20 = 1
+	rate := GetCommissionRate(rules, 5, items)
+	if rate != 14.0/100 {
+		t.Fatalf("expected rate 0.14, got %v", rate)
+	}
+	if rate := GetCommissionRate(rules, 6, items[2:]); rate != 0 {
+		t.Fatalf("expected no commission without rule, got %v", rate)
+	}
+	if rate := GetCommissionRate(rules, 5, nil); rate != 0 {
+		t.Fatalf("expected zero rate without items, got %v", rate)
+	}
+}
+
+func TestNewCommissionStatement(t *testing.T) {
+	month := time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC)
+	rules := []CommissionRule{NewCommissionRule(CommissionScopeReseller, 5, 10, CommissionBaseSaleValue)}
+	installments := []CommissionInstallment{
+		{PaymentDateId: 1, SaleId: 7, Value: 33.33},
+		{PaymentDateId: 2, SaleId: 8, Value: 10},
+	}
+	items := []CommissionSaleItem{{SaleId: 7, ProductId: 1, Quantity: 1, Total: 100}}
+
+	statement := NewCommissionStatement(User{Id: 5}, month, rules, installments, items)
+	if statement.Status != CommissionStatementStatusOpen || len(statement.Entries) != 2 {
+		t.Fatalf("unexpected statement: %+v", statement)
+	}
+	if statement.Entries[0].Value != 3.33 || statement.Entries[0].Percentage != 10 {
+		t.Fatalf("unexpected entry: %+v", statement.Entries[0])
+	}
+	if statement.Entries[1].Value != 0 {
+		t.Fatalf("expected no commission for sale without items, got %+v", statement.Entries[1])
+	}
+	if statement.GetTotal() != 3.33 || statement.GetReceivedTotal() != 43.33 {
+		t.Fatalf("unexpected totals: %v %v", statement.GetTotal(), statement.GetReceivedTotal())
+	}
+}
+
+func TestCommissionStatementValidatePayment(t *testing.T) {
+	statement := CommissionStatement{Status: CommissionStatementStatusClosed}
+	if err := statement.ValidatePayment(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	statement.Status = CommissionStatementStatusPaid
+	if err := statement.ValidatePayment(); err != ErrCommissionStatementAlreadyPaid {
+		t.Fatalf("expected already paid error, got %v", err)
+	}
+	statement.Status = CommissionStatementStatusOpen
+	if err := statement.ValidatePayment(); err != ErrCommissionStatementNotClosed {
+		t.Fatalf("expected not closed error, got %v", err)
+	}
+}
+
+func TestParseCommissionMonth(t *testing.T) {
+	month, err := ParseCommissionMonth("2026-09")
+	if err != nil || month.Month() != time.September || month.Day() != 1 {
+		t.Fatalf("unexpected month: %v %v", month, err)
+	}
+	if _, err := ParseCommissionMonth("09/2026"); err != ErrCommissionMonthInvalid {
+		t.Fatalf("expected invalid month error, got %v", err)
+	}
+}
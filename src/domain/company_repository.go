@@ -7,4 +7,5 @@ import (
 
 type CompanyRepository interface {
     CreateWithTx(ctx context.Context, tx *sql.Tx, company Company) (int64, error)
+    Get(ctx context.Context) (Company, error)
 }
@@ -0,0 +1,101 @@
+package domain
+
+import (
+	"errors"
+	"math"
+	"time"
+
+	"github.com/bncunha/erp-api/src/infrastructure/ksuid"
+)
+
+var (
+	ErrConsignmentSettlementNotFound    = errors.New("Acerto de consignação não encontrado")
+	ErrConsignmentInventoryNotReseller  = errors.New("O acerto só pode ser feito para o estoque de um revendedor")
+	ErrConsignmentSettlementNotesLength = errors.New("Observação do acerto deve ter no máximo 2000 caracteres")
+)
+
+// ConsignmentSettlement é o acerto periódico com um revendedor: registra o que
+// foi enviado ao seu estoque, o que foi vendido, o que foi devolvido ao estoque
+// principal e o que ainda está com ele. O período começa no fechamento do
+// acerto anterior.
+type ConsignmentSettlement struct {
+	Id             int64
+	Code           string
+	User           User
+	InventoryId    int64
+	PeriodStart    *time.Time
+	PeriodEnd      time.Time
+	ReturnedUnsold bool
+	Notes          string
+	Items          []ConsignmentSettlementItem
+}
+
+type ConsignmentSettlementItem struct {
+	Sku               Sku
+	SentQuantity      float64
+	SoldQuantity      float64
+	SoldValue         float64
+	ReturnedQuantity  float64
+	OnHandQuantity    float64
+	ReturnedOnClosing float64
+}
+
+func NewConsignmentSettlement(user User, inventory Inventory, periodStart *time.Time, periodEnd time.Time, items []ConsignmentSettlementItem) ConsignmentSettlement {
+	return ConsignmentSettlement{
+		Code:        "A-" + ksuid.New().String(),
+		User:        user,
+		InventoryId: inventory.Id,
+		PeriodStart: periodStart,
+		PeriodEnd:   periodEnd,
+		Items:       items,
+	}
+}
+
+func ValidateConsignmentInventory(inventory Inventory) error {
+	if inventory.Type != InventoryTypeReseller {
+		return ErrConsignmentInventoryNotReseller
+	}
+	return nil
+}
+
+func (s *ConsignmentSettlement) Validate() error {
+	if len([]rune(s.Notes)) > 2000 {
+		return ErrConsignmentSettlementNotesLength
+	}
+	return nil
+}
+
+// ReturnUnsold marca o saldo em mãos de cada item como devolvido no
+// fechamento e retorna os itens que precisam voltar ao estoque principal.
+func (s *ConsignmentSettlement) ReturnUnsold() []ConsignmentSettlementItem {
+	s.ReturnedUnsold = true
+	unsold := make([]ConsignmentSettlementItem, 0)
+	for i := range s.Items {
+		if s.Items[i].OnHandQuantity <= 0 {
+			continue
+		}
+		s.Items[i].ReturnedOnClosing = s.Items[i].OnHandQuantity
+		unsold = append(unsold, s.Items[i])
+	}
+	return unsold
+}
+
+// GetAmountDue retorna o valor que o revendedor deve repassar: o total líquido
+// das vendas feitas no período.
+func (s *ConsignmentSettlement) GetAmountDue() float64 {
+	var total float64
+	for _, item := range s.Items {
+		total += item.SoldValue
+	}
+	return math.Round(total*100) / 100
+}
+
+// GetOnHandValue retorna o valor de venda do saldo que permanece com o
+// revendedor após o acerto.
+func (s *ConsignmentSettlement) GetOnHandValue() float64 {
+	var total float64
+	for _, item := range s.Items {
+		total += (item.OnHandQuantity - item.ReturnedOnClosing) * item.Sku.Price
+	}
+	return math.Round(total*100) / 100
+}
@@ -0,0 +1,27 @@
+package domain
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+type GetConsignmentSettlementsOutput struct {
+	Id             int64
+	Code           string
+	UserId         int64
+	UserName       string
+	PeriodStart    *time.Time
+	PeriodEnd      time.Time
+	ReturnedUnsold bool
+	AmountDue      float64
+}
+
+type ConsignmentSettlementRepository interface {
+	GetItems(ctx context.Context, inventoryId int64, userId int64, periodStart *time.Time, periodEnd time.Time) ([]ConsignmentSettlementItem, error)
+	GetLastPeriodEnd(ctx context.Context, userId int64) (*time.Time, error)
+	Create(ctx context.Context, tx *sql.Tx, settlement ConsignmentSettlement, createdByUserId int64) (int64, error)
+	CreateItems(ctx context.Context, tx *sql.Tx, settlementId int64, items []ConsignmentSettlementItem) error
+	GetAll(ctx context.Context, userIds []int64) ([]GetConsignmentSettlementsOutput, error)
+	GetById(ctx context.Context, id int64) (ConsignmentSettlement, error)
+}
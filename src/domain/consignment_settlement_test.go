@@ -0,0 +1,38 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestConsignmentSettlementReturnUnsold(t *testing.T) {
+	settlement := NewConsignmentSettlement(User{Id: 1}, Inventory{Id: 2}, nil, time.Now(), []ConsignmentSettlementItem{
+		{Sku: Sku{Id: 1, Price: 10}, SoldValue: 20.005, OnHandQuantity: 3},
+		{Sku: Sku{Id: 2, Price: 5}, SoldValue: 10, OnHandQuantity: 0},
+	})
+	if !strings.HasPrefix(settlement.Code, "A-") {
+		t.Fatalf("unexpected code: %s", settlement.Code)
+	}
+	if settlement.GetAmountDue() != 30.01 || settlement.GetOnHandValue() != 30 {
+		t.Fatalf("unexpected totals: %v %v", settlement.GetAmountDue(), settlement.GetOnHandValue())
+	}
+
+	unsold := settlement.ReturnUnsold()
+	if len(unsold) != 1 || unsold[0].ReturnedOnClosing != 3 || !settlement.ReturnedUnsold {
+		t.Fatalf("unexpected unsold items: %+v", unsold)
+	}
+	if settlement.GetOnHandValue() != 0 {
+		t.Fatalf("expected nothing left on hand, got %v", settlement.GetOnHandValue())
+	}
+}
+
+func TestConsignmentSettlementValidate(t *testing.T) {
+	if err := ValidateConsignmentInventory(Inventory{Type: InventoryTypePrimary}); err != ErrConsignmentInventoryNotReseller {
+		t.Fatalf("expected reseller inventory error, got %v", err)
+	}
+	settlement := ConsignmentSettlement{Notes: strings.Repeat("a", 2001)}
+	if err := settlement.Validate(); err != ErrConsignmentSettlementNotesLength {
+		t.Fatalf("expected notes length error, got %v", err)
+	}
+}
@@ -1,7 +1,8 @@
 package domain
 
 type Customer struct {
-	Id          int64
-	Name        string
-	PhoneNumber string
+	Id            int64
+	Name          string
+	PhoneNumber   string
+	CreditBalance float64
 }
@@ -0,0 +1,79 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+type CustomerCreditType string
+
+type CustomerCreditOrigin string
+
+var (
+	ErrCustomerCreditInsufficient       = errors.New("Saldo de crédito do cliente insuficiente")
+	ErrCustomerCreditValueInvalid       = errors.New("Valor do crédito deve ser maior que zero")
+	ErrCustomerCreditTypeInvalid        = errors.New("Tipo de movimentação de crédito inválido")
+	ErrCustomerCreditDescriptionInvalid = errors.New("Descrição do crédito deve ter no máximo 500 caracteres")
+)
+
+const (
+	CustomerCreditTypeCredit CustomerCreditType = "CREDIT"
+	CustomerCreditTypeDebit  CustomerCreditType = "DEBIT"
+)
+
+const (
+	CustomerCreditOriginReturn     CustomerCreditOrigin = "RETURN"
+	CustomerCreditOriginSale       CustomerCreditOrigin = "SALE"
+	CustomerCreditOriginAdjustment CustomerCreditOrigin = "ADJUSTMENT"
+)
+
+type CustomerCredit struct {
+	Id              int64
+	CustomerId      int64
+	Type            CustomerCreditType
+	Origin          CustomerCreditOrigin
+	Value           float64
+	Description     string
+	SalesId         int64
+	SalesVersionId  int64
+	CreatedByUserId int64
+	CreatedBy       string
+	CreatedAt       time.Time
+}
+
+func NewCustomerCredit(customerId int64, creditType CustomerCreditType, origin CustomerCreditOrigin, value float64, description string) CustomerCredit {
+	return CustomerCredit{
+		CustomerId:  customerId,
+		Type:        creditType,
+		Origin:      origin,
+		Value:       math.Round(value*100) / 100,
+		Description: strings.TrimSpace(description),
+		CreatedAt:   time.Now(),
+	}
+}
+
+func (c *CustomerCredit) Validate(balance float64) error {
+	if c.Type != CustomerCreditTypeCredit && c.Type != CustomerCreditTypeDebit {
+		return ErrCustomerCreditTypeInvalid
+	}
+	if c.Value <= 0 {
+		return ErrCustomerCreditValueInvalid
+	}
+	if len([]rune(c.Description)) > 500 {
+		return ErrCustomerCreditDescriptionInvalid
+	}
+	if c.Type == CustomerCreditTypeDebit && c.Value > math.Round(balance*100)/100 {
+		return errors.New(ErrCustomerCreditInsufficient.Error() + fmt.Sprintf(": R$ %.2f", balance))
+	}
+	return nil
+}
+
+func (c *CustomerCredit) GetSignedValue() float64 {
+	if c.Type == CustomerCreditTypeDebit {
+		return -c.Value
+	}
+	return c.Value
+}
@@ -0,0 +1,14 @@
+package domain
+
+import (
+	"context"
+	"database/sql"
+)
+
+type CustomerCreditRepository interface {
+	Create(ctx context.Context, credit CustomerCredit) (int64, error)
+	CreateWithTx(ctx context.Context, tx *sql.Tx, credit CustomerCredit) (int64, error)
+	GetBalance(ctx context.Context, customerId int64) (float64, error)
+	GetBalanceForUpdate(ctx context.Context, tx *sql.Tx, customerId int64) (float64, error)
+	GetByCustomerId(ctx context.Context, customerId int64) ([]CustomerCredit, error)
+}
@@ -0,0 +1,49 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNewCustomerCreditRoundsAndTrims(t *testing.T) {
+	credit := NewCustomerCredit(1, CustomerCreditTypeCredit, CustomerCreditOriginAdjustment, 10.005, "  ajuste  ")
+	if credit.CustomerId != 1 || credit.Value != 10.01 || credit.Description != "ajuste" || credit.CreatedAt.IsZero() {
+		t.Fatalf("unexpected credit: %+v", credit)
+	}
+}
+
+func TestCustomerCreditValidate(t *testing.T) {
+	credit := NewCustomerCredit(1, "OTHER", CustomerCreditOriginAdjustment, 10, "")
+	if err := credit.Validate(0); err != ErrCustomerCreditTypeInvalid {
+		t.Fatalf("expected type error, got %v", err)
+	}
+
+	credit = NewCustomerCredit(1, CustomerCreditTypeCredit, CustomerCreditOriginAdjustment, 0, "")
+	if err := credit.Validate(0); err != ErrCustomerCreditValueInvalid {
+		t.Fatalf("expected value error, got %v", err)
+	}
+
+	credit = NewCustomerCredit(1, CustomerCreditTypeCredit, CustomerCreditOriginAdjustment, 10, strings.Repeat("a", 501))
+	if err := credit.Validate(0); err != ErrCustomerCreditDescriptionInvalid {
+		t.Fatalf("expected description error, got %v", err)
+	}
+
+	credit = NewCustomerCredit(1, CustomerCreditTypeDebit, CustomerCreditOriginSale, 10, "")
+	if err := credit.Validate(5); err == nil || !strings.Contains(err.Error(), ErrCustomerCreditInsufficient.Error()) {
+		t.Fatalf("expected insufficient credit error, got %v", err)
+	}
+	if err := credit.Validate(10); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestCustomerCreditGetSignedValue(t *testing.T) {
+	credit := NewCustomerCredit(1, CustomerCreditTypeCredit, CustomerCreditOriginReturn, 10, "")
+	if credit.GetSignedValue() != 10 {
+		t.Fatalf("expected positive value, got %v", credit.GetSignedValue())
+	}
+	debit := NewCustomerCredit(1, CustomerCreditTypeDebit, CustomerCreditOriginSale, 10, "")
+	if debit.GetSignedValue() != -10 {
+		t.Fatalf("expected negative value, got %v", debit.GetSignedValue())
+	}
+}
@@ -14,6 +14,10 @@ const (
 	DashboardWidgetMinhasVendas             DashboardWidgetEnum = "MINHAS_VENDAS"
 	DashboardWidgetMinhasVendasNoTempo      DashboardWidgetEnum = "MINHAS_VENDAS_NO_TEMPO"
 	DashboardWidgetMeusProdutosMaisVendidos DashboardWidgetEnum = "MEUS_PRODUTOS_MAIS_VENDIDOS"
+	DashboardWidgetMultasEJuros             DashboardWidgetEnum = "MULTAS_E_JUROS"
+	DashboardWidgetMinhasMultasEJuros       DashboardWidgetEnum = "MINHAS_MULTAS_E_JUROS"
+	DashboardWidgetRecebimentosDeCartao     DashboardWidgetEnum = "RECEBIMENTOS_DE_CARTAO"
+	DashboardWidgetTaxasDeCartao            DashboardWidgetEnum = "TAXAS_DE_CARTAO"
 )
 
 type DashboardWidgetType string
@@ -35,13 +35,21 @@ type DashboardProductSalesItem struct {
 }
 
 type DashboardLowStockItem struct {
-	ProductId   int64
-	ProductName string
-	Quantity    float64
+	ProductId     int64
+	ProductName   string
+	Sku           Sku
+	InventoryId   int64
+	InventoryType InventoryType
+	UserName      *string
+	Quantity      float64
+	MinQuantity   float64
 }
 
 type DashboardRepository interface {
 	GetRevenue(ctx context.Context, input DashboardQueryInput) (float64, error)
+	GetLateFeeRevenue(ctx context.Context, input DashboardQueryInput) (float64, error)
+	GetCardFees(ctx context.Context, input DashboardQueryInput) (float64, error)
+	GetCardSettlementsByDay(ctx context.Context, input DashboardQueryInput) ([]DashboardTimeSeriesItem, error)
 	GetSalesCount(ctx context.Context, input DashboardQueryInput) (int64, error)
 	GetRevenueByDay(ctx context.Context, input DashboardQueryInput) ([]DashboardTimeSeriesItem, error)
 	GetSalesCountByDay(ctx context.Context, input DashboardQueryInput) ([]DashboardTimeSeriesItem, error)
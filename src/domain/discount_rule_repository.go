@@ -0,0 +1,8 @@
+package domain
+
+import "context"
+
+type DiscountRuleRepository interface {
+	Get(ctx context.Context) (DiscountRule, error)
+	Save(ctx context.Context, rule DiscountRule) error
+}
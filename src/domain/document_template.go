@@ -0,0 +1,305 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"text/template"
+	"time"
+)
+
+type DocumentTemplateType string
+
+const (
+	DocumentTemplateTypeSaleReceipt DocumentTemplateType = "SALE_RECEIPT"
+	DocumentTemplateTypeCarne       DocumentTemplateType = "CARNE"
+)
+
+var (
+	ErrDocumentTemplateNotFound        = errors.New("Modelo de documento não encontrado")
+	ErrDocumentTemplateTypeInvalid     = errors.New("Tipo de modelo de documento inválido")
+	ErrDocumentTemplateContentRequired = errors.New("Conteúdo do modelo de documento é obrigatório")
+	ErrDocumentTemplateContentLength   = errors.New("Conteúdo do modelo de documento deve ter no máximo 20000 caracteres")
+	ErrDocumentTemplateInvalid         = errors.New("Modelo de documento inválido")
+	ErrSaleWithoutCreditStore          = errors.New("Venda não possui parcelas de notinha para gerar o carnê")
+)
+
+// DocumentTemplate é o modelo (text/template) usado para gerar os documentos
+// impressos da empresa. O resultado do modelo é o texto de marcação aceito
+// pelo gerador de PDF.
+type DocumentTemplate struct {
+	Id        int64
+	Type      DocumentTemplateType
+	Content   string
+	IsDefault bool
+	UpdatedAt *time.Time
+}
+
+func NewDocumentTemplate(templateType DocumentTemplateType, content string) DocumentTemplate {
+	return DocumentTemplate{
+		Type:    templateType,
+		Content: content,
+	}
+}
+
+// DefaultDocumentTemplate retorna o modelo padrão usado enquanto a empresa não
+// personaliza o seu.
+func DefaultDocumentTemplate(templateType DocumentTemplateType) DocumentTemplate {
+	content := defaultSaleReceiptTemplate
+	if templateType == DocumentTemplateTypeCarne {
+		content = defaultCarneTemplate
+	}
+	return DocumentTemplate{Type: templateType, Content: content, IsDefault: true}
+}
+
+func IsDocumentTemplateTypeValid(templateType DocumentTemplateType) bool {
+	return templateType == DocumentTemplateTypeSaleReceipt || templateType == DocumentTemplateTypeCarne
+}
+
+func (t *DocumentTemplate) Validate() error {
+	if !IsDocumentTemplateTypeValid(t.Type) {
+		return ErrDocumentTemplateTypeInvalid
+	}
+	if strings.TrimSpace(t.Content) == "" {
+		return ErrDocumentTemplateContentRequired
+	}
+	if len([]rune(t.Content)) > 20000 {
+		return ErrDocumentTemplateContentLength
+	}
+	if _, err := t.Parse(); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (t *DocumentTemplate) Parse() (*template.Template, error) {
+	parsed, err := template.New(string(t.Type)).Funcs(documentTemplateFuncs).Parse(t.Content)
+	if err != nil {
+		return nil, errors.New(ErrDocumentTemplateInvalid.Error() + ": " + err.Error())
+	}
+	return parsed, nil
+}
+
+// Execute aplica os dados ao modelo e retorna o texto de marcação do documento.
+func (t *DocumentTemplate) Execute(data DocumentData) (string, error) {
+	parsed, err := t.Parse()
+	if err != nil {
+		return "", err
+	}
+	var out strings.Builder
+	if err = parsed.Execute(&out, data); err != nil {
+		return "", errors.New(ErrDocumentTemplateInvalid.Error() + ": " + err.Error())
+	}
+	return out.String(), nil
+}
+
+// DocumentData são os dados disponíveis para os modelos de documento.
+type DocumentData struct {
+	Company      DocumentCompany
+	Sale         DocumentSale
+	Items        []DocumentItem
+	Payments     []DocumentPayment
+	Installments []DocumentInstallment
+	GeneratedAt  time.Time
+}
+
+type DocumentCompany struct {
+	Name      string
+	LegalName string
+	Document  string
+	Cellphone string
+	Address   string
+}
+
+type DocumentSale struct {
+	Code          string
+	Date          time.Time
+	SellerName    string
+	CustomerName  string
+	Subtotal      float64
+	Discount      float64
+	Total         float64
+	ReceivedValue float64
+	FutureRevenue float64
+	CashReceived  float64
+	ChangeAmount  float64
+	PaymentStatus PaymentStatus
+}
+
+type DocumentItem struct {
+	Code        string
+	Description string
+	Quantity    float64
+	UnitPrice   float64
+	Discount    float64
+	Total       float64
+}
+
+type DocumentPayment struct {
+	Type         PaymentType
+	Installments []DocumentInstallment
+}
+
+type DocumentInstallment struct {
+	Number   int64
+	Of       int
+	DueDate  time.Time
+	PaidDate *time.Time
+	Value    float64
+	Status   PaymentStatus
+}
+
+func NewDocumentCompany(company Company) DocumentCompany {
+	document := company.Cnpj
+	if document == "" {
+		document = company.Cpf
+	}
+	var address string
+	if company.Address != nil {
+		address = fmt.Sprintf("%s, %s - %s, %s/%s - CEP %s", company.Address.Street, company.Address.Number, company.Address.Neighborhood, company.Address.City, company.Address.UF, company.Address.Cep)
+	}
+	return DocumentCompany{
+		Name:      company.Name,
+		LegalName: company.LegalName,
+		Document:  document,
+		Cellphone: company.Cellphone,
+		Address:   address,
+	}
+}
+
+var documentTemplateFuncs = template.FuncMap{
+	"money":         FormatMoney,
+	"quantity":      formatQuantity,
+	"date":          func(t time.Time) string { return t.Format("02/01/2006") },
+	"datetime":      func(t time.Time) string { return t.Format("02/01/2006 15:04") },
+	"paymentType":   PaymentTypeLabel,
+	"paymentStatus": PaymentStatusLabel,
+}
+
+// FormatMoney formata o valor em reais, como R$ 1.234,56.
+func FormatMoney(value float64) string {
+	cents := int64(math.Round(math.Abs(value) * 100))
+	integer := fmt.Sprintf("%d", cents/100)
+	var grouped strings.Builder
+	for i, digit := range integer {
+		if i > 0 && (len(integer)-i)%3 == 0 {
+			grouped.WriteByte('.')
+		}
+		grouped.WriteRune(digit)
+	}
+	sign := ""
+	if value < 0 && cents > 0 {
+		sign = "-"
+	}
+	return fmt.Sprintf("%sR$ %s,%02d", sign, grouped.String(), cents%100)
+}
+
+func formatQuantity(quantity float64) string {
+	if quantity == math.Trunc(quantity) {
+		return fmt.Sprintf("%.0f", quantity)
+	}
+	return strings.Replace(strings.TrimRight(fmt.Sprintf("%.3f", quantity), "0"), ".", ",", 1)
+}
+
+func PaymentTypeLabel(paymentType PaymentType) string {
+	switch paymentType {
+	case PaymentTypeCash:
+		return "Dinheiro"
+	case PaymentTypeCreditCard:
+		return "Cartão de crédito"
+	case PaymentTypeDebitCard:
+		return "Cartão de débito"
+	case PaymentTypePix:
+		return "PIX"
+	case PaymentTypeCreditStore:
+		return "Notinha"
+	case PaymentTypeReturn:
+		return "Crédito do cliente"
+	default:
+		return string(paymentType)
+	}
+}
+
+func PaymentStatusLabel(status PaymentStatus) string {
+	switch status {
+	case PaymentStatusPaid:
+		return "Pago"
+	case PaymentStatusPending:
+		return "Pendente"
+	case PaymentStatusDelayed:
+		return "Atrasado"
+	case PaymentStatusCancel:
+		return "Cancelado"
+	case PaymentStatusReversal:
+		return "Estornado"
+	default:
+		return string(status)
+	}
+}
+
+const defaultSaleReceiptTemplate = `# {{.Company.Name}}
+{{- if .Company.LegalName}}
+> {{.Company.LegalName}}
+{{- end}}
+{{- if .Company.Document}}
+> CNPJ/CPF: {{.Company.Document}}
+{{- end}}
+{{- if .Company.Address}}
+> {{.Company.Address}}
+{{- end}}
+{{- if .Company.Cellphone}}
+> Telefone: {{.Company.Cellphone}}
+{{- end}}
+---
+## Recibo de venda {{.Sale.Code}}
+Data: {{datetime .Sale.Date}}
+Cliente: {{.Sale.CustomerName}}
+Vendedor: {{.Sale.SellerName}}
+
+## Item | Qtd | Unitário | Total
+---
+{{- range .Items}}
+{{.Description}} | {{quantity .Quantity}} | {{money .UnitPrice}} | {{money .Total}}
+{{- end}}
+---
+{{- if .Sale.Discount}}
+Subtotal | {{money .Sale.Subtotal}}
+Desconto | -{{money .Sale.Discount}}
+{{- end}}
+## Total | {{money .Sale.Total}}
+
+## Pagamentos
+{{- range .Payments}}
+{{paymentType .Type}}
+{{- range .Installments}}
+> Parcela {{.Number}}/{{.Of}} - vencimento {{date .DueDate}} - {{paymentStatus .Status}} | {{money .Value}}
+{{- end}}
+{{- end}}
+{{- if .Sale.ChangeAmount}}
+
+Valor recebido em dinheiro | {{money .Sale.CashReceived}}
+Troco | {{money .Sale.ChangeAmount}}
+{{- end}}
+
+> Documento sem valor fiscal. Emitido em {{datetime .GeneratedAt}}.
+`
+
+const defaultCarneTemplate = `# {{.Company.Name}}
+> Carnê de pagamento da venda {{.Sale.Code}} - {{.Sale.CustomerName}}
+{{- range .Installments}}
+[bloco]
+- - -
+## {{$.Company.Name}} | Parcela {{.Number}}/{{.Of}}
+{{- if $.Company.Document}}
+> CNPJ/CPF: {{$.Company.Document}}
+{{- end}}
+Cliente: {{$.Sale.CustomerName}}
+Venda: {{$.Sale.Code}} | {{date $.Sale.Date}}
+Vencimento | {{date .DueDate}}
+## Valor | {{money .Value}}
+> Situação: {{paymentStatus .Status}}
+[/bloco]
+{{- end}}
+- - -
+`
@@ -0,0 +1,9 @@
+package domain
+
+import "context"
+
+type DocumentTemplateRepository interface {
+	GetByType(ctx context.Context, templateType DocumentTemplateType) (DocumentTemplate, error)
+	Save(ctx context.Context, template DocumentTemplate) error
+	Delete(ctx context.Context, templateType DocumentTemplateType) error
+}
@@ -0,0 +1,79 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFormatMoney(t *testing.T) {
+	cases := map[float64]string{
+		0:          "R$ 0,00",
+		1234.5:     "R$ 1.234,50",
+		1234567.89: "R$ 1.234.567,89",
+		-10.005:    "-R$ 10,01",
+	}
+	for value, expected := range cases {
+		if got := FormatMoney(value); got != expected {
+			t.Fatalf("FormatMoney(%v) = %s, expected %s", value, got, expected)
+		}
+	}
+}
+
+func TestDocumentTemplateValidate(t *testing.T) {
+	template := NewDocumentTemplate("NOTA", "# {{.Company.Name}}")
+	if err := template.Validate(); err != ErrDocumentTemplateTypeInvalid {
+		t.Fatalf("expected type error, got %v", err)
+	}
+	template = NewDocumentTemplate(DocumentTemplateTypeSaleReceipt, "  ")
+	if err := template.Validate(); err != ErrDocumentTemplateContentRequired {
+		t.Fatalf("expected content error, got %v", err)
+	}
+	template = NewDocumentTemplate(DocumentTemplateTypeSaleReceipt, strings.Repeat("a", 20001))
+	if err := template.Validate(); err != ErrDocumentTemplateContentLength {
+		t.Fatalf("expected length error, got %v", err)
+	}
+	template = NewDocumentTemplate(DocumentTemplateTypeSaleReceipt, "# {{.Company.Name")
+	if err := template.Validate(); err == nil || !strings.HasPrefix(err.Error(), ErrDocumentTemplateInvalid.Error()) {
+		t.Fatalf("expected invalid template error, got %v", err)
+	}
+	template = NewDocumentTemplate(DocumentTemplateTypeCarne, "# {{money .Sale.Total}}")
+	if err := template.Validate(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestDocumentTemplateExecuteDefaults(t *testing.T) {
+	date := time.Date(2026, 10, 1, 14, 30, 0, 0, time.UTC)
+	data := DocumentData{
+		Company: NewDocumentCompany(Company{Name: "Loja", Cnpj: "12345678000199", Address: &Address{Street: "Rua A", Number: "10", Neighborhood: "Centro", City: "Natal", UF: "RN", Cep: "59000000"}}),
+		Sale:    DocumentSale{Code: "V-1", Date: date, CustomerName: "Maria", Subtotal: 100, Discount: 10, Total: 90, CashReceived: 50, ChangeAmount: 10},
+		Items:   []DocumentItem{{Description: "Blusa P", Quantity: 2, UnitPrice: 50, Total: 90}},
+		Payments: []DocumentPayment{
+			{Type: PaymentTypeCash, Installments: []DocumentInstallment{{Number: 1, Of: 1, DueDate: date, Value: 40, Status: PaymentStatusPaid}}},
+			{Type: PaymentTypeCreditStore, Installments: []DocumentInstallment{{Number: 1, Of: 2, DueDate: date, Value: 25, Status: PaymentStatusPending}, {Number: 2, Of: 2, DueDate: date.AddDate(0, 1, 0), Value: 25, Status: PaymentStatusPending}}},
+		},
+		GeneratedAt: date,
+	}
+
+	receiptTemplate := DefaultDocumentTemplate(DocumentTemplateTypeSaleReceipt)
+	receipt, err := receiptTemplate.Execute(data)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, expected := range []string{"# Loja", "Rua A, 10 - Centro, Natal/RN", "Blusa P | 2 | R$ 50,00 | R$ 90,00", "Desconto | -R$ 10,00", "Notinha", "Troco | R$ 10,00"} {
+		if !strings.Contains(receipt, expected) {
+			t.Fatalf("expected receipt to contain %q:\n%s", expected, receipt)
+		}
+	}
+
+	data.Installments = data.Payments[1].Installments
+	carneTemplate := DefaultDocumentTemplate(DocumentTemplateTypeCarne)
+	carne, err := carneTemplate.Execute(data)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.Count(carne, "[bloco]") != 2 || !strings.Contains(carne, "Parcela 2/2") || !strings.Contains(carne, "Vencimento | 01/11/2026") {
+		t.Fatalf("unexpected carne:\n%s", carne)
+	}
+}
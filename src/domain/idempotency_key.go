@@ -0,0 +1,79 @@
+package domain
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"time"
+)
+
+const (
+	IdempotencyKeyHeader    = "Idempotency-Key"
+	IdempotencyKeyMaxLength = 255
+	// IdempotencyKeyLockTimeout é o tempo após o qual uma requisição ainda em
+	// processamento é considerada abandonada e a chave pode ser usada de novo.
+	IdempotencyKeyLockTimeout = 5 * time.Minute
+)
+
+type IdempotencyKeyStatus string
+
+const (
+	IdempotencyKeyStatusProcessing IdempotencyKeyStatus = "PROCESSING"
+	IdempotencyKeyStatusCompleted  IdempotencyKeyStatus = "COMPLETED"
+)
+
+var (
+	ErrIdempotencyKeyInvalid    = errors.New("Idempotency-Key deve ter no máximo 255 caracteres")
+	ErrIdempotencyKeyInProgress = errors.New("Já existe uma requisição em processamento com esta Idempotency-Key")
+	ErrIdempotencyKeyReused     = errors.New("Idempotency-Key já utilizada em uma requisição diferente")
+)
+
+// IdempotencyKey guarda o resultado de uma criação feita com o cabeçalho
+// Idempotency-Key para que novas tentativas recebam a resposta original em vez
+// de repetir a operação.
+type IdempotencyKey struct {
+	Id             int64
+	Key            string
+	Scope          string
+	RequestHash    string
+	Status         IdempotencyKeyStatus
+	ResponseStatus int
+	ResponseBody   []byte
+	UpdatedAt      time.Time
+}
+
+func NewIdempotencyKey(key string, scope string, payload []byte) IdempotencyKey {
+	hash := sha256.Sum256(payload)
+	return IdempotencyKey{
+		Key:         strings.TrimSpace(key),
+		Scope:       scope,
+		RequestHash: hex.EncodeToString(hash[:]),
+		Status:      IdempotencyKeyStatusProcessing,
+	}
+}
+
+func (k *IdempotencyKey) Validate() error {
+	if k.Key == "" || len(k.Key) > IdempotencyKeyMaxLength {
+		return ErrIdempotencyKeyInvalid
+	}
+	return nil
+}
+
+// CheckReplay verifica se a chave já registrada pode ser usada para repetir a
+// resposta da requisição atual.
+func (k *IdempotencyKey) CheckReplay(requestHash string) error {
+	if k.RequestHash != requestHash {
+		return ErrIdempotencyKeyReused
+	}
+	if k.Status != IdempotencyKeyStatusCompleted {
+		return ErrIdempotencyKeyInProgress
+	}
+	return nil
+}
+
+func (k *IdempotencyKey) Complete(responseStatus int, responseBody []byte) {
+	k.Status = IdempotencyKeyStatusCompleted
+	k.ResponseStatus = responseStatus
+	k.ResponseBody = responseBody
+}
@@ -0,0 +1,9 @@
+package domain
+
+import "context"
+
+type IdempotencyKeyRepository interface {
+	Reserve(ctx context.Context, key IdempotencyKey) (IdempotencyKey, bool, error)
+	Complete(ctx context.Context, key IdempotencyKey) error
+	Release(ctx context.Context, key IdempotencyKey) error
+}
@@ -0,0 +1,48 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNewIdempotencyKey(t *testing.T) {
+	key := NewIdempotencyKey("  abc  ", "POST /sales", []byte(`{"a":1}`))
+	if key.Key != "abc" || key.Status != IdempotencyKeyStatusProcessing {
+		t.Fatalf("unexpected key: %+v", key)
+	}
+	same := NewIdempotencyKey("abc", "POST /sales", []byte(`{"a":1}`))
+	other := NewIdempotencyKey("abc", "POST /sales", []byte(`{"a":2}`))
+	if key.RequestHash != same.RequestHash || key.RequestHash == other.RequestHash {
+		t.Fatalf("unexpected request hashes")
+	}
+}
+
+func TestIdempotencyKeyValidate(t *testing.T) {
+	for _, value := range []string{" ", strings.Repeat("a", IdempotencyKeyMaxLength+1)} {
+		key := NewIdempotencyKey(value, "POST /sales", nil)
+		if err := key.Validate(); err != ErrIdempotencyKeyInvalid {
+			t.Fatalf("expected invalid key, got %v", err)
+		}
+	}
+	key := NewIdempotencyKey("abc", "POST /sales", nil)
+	if err := key.Validate(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestIdempotencyKeyCheckReplay(t *testing.T) {
+	key := NewIdempotencyKey("abc", "POST /sales", []byte(`{}`))
+	if err := key.CheckReplay(key.RequestHash); err != ErrIdempotencyKeyInProgress {
+		t.Fatalf("expected in progress, got %v", err)
+	}
+	key.Complete(201, []byte("null"))
+	if err := key.CheckReplay("other"); err != ErrIdempotencyKeyReused {
+		t.Fatalf("expected reused, got %v", err)
+	}
+	if err := key.CheckReplay(key.RequestHash); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if key.ResponseStatus != 201 || string(key.ResponseBody) != "null" {
+		t.Fatalf("unexpected response: %+v", key)
+	}
+}
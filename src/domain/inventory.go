@@ -17,6 +17,10 @@ const (
 	InventoryTransactionTypeTransfer InventoryTransactionType = "TRANSFER"
 	InventoryTransactionTypeIn       InventoryTransactionType = "IN"
 	InventoryTransactionTypeOut      InventoryTransactionType = "OUT"
+
+	// InventoryTransactionTypeAdjustment é o ajuste lançado ao postar uma
+	// contagem de estoque, separado das entradas e saídas manuais.
+	InventoryTransactionTypeAdjustment InventoryTransactionType = "ADJUSTMENT"
 )
 
 type Inventory struct {
@@ -53,6 +57,7 @@ type InventoryTransaction struct {
 	InventoryItem InventoryItem
 	Sale          Sales
 	SalesVersionId int64
+	UnitCost      *float64
 	TenantId      int64
 	Justification string
 }
@@ -36,4 +36,5 @@ type InventoryItemRepository interface {
 	GetAll(ctx context.Context) ([]GetInventoryItemsOutput, error)
 	GetByInventoryId(ctx context.Context, id int64) ([]GetInventoryItemsOutput, error)
 	GetBySkuId(ctx context.Context, skuId int64) ([]GetSkuInventoryOutput, error)
+	GetTotalQuantityBySkuIds(ctx context.Context, tx *sql.Tx, skuIds []int64) (map[int64]float64, error)
 }
@@ -21,6 +21,7 @@ type GetInventoryTransactionsOutput struct {
 	UserOriginName           *string
 	UserDestinationName      *string
 	Justification            *string
+	UnitCost                 *float64
 }
 
 type InventoryTransactionRepository interface {
@@ -0,0 +1,65 @@
+package domain
+
+import (
+	"errors"
+	"math"
+	"time"
+)
+
+var (
+	ErrLateFeeFinePercentageInvalid     = errors.New("Percentual de multa deve estar entre 0 e 100")
+	ErrLateFeeInterestPercentageInvalid = errors.New("Percentual de juros ao dia deve estar entre 0 e 100")
+)
+
+// LateFeeRule é a regra de encargos por atraso configurada pela empresa para
+// parcelas de crediário: multa fixa sobre o valor da parcela mais juros ao dia.
+type LateFeeRule struct {
+	FinePercentage          float64
+	DailyInterestPercentage float64
+}
+
+type LateFee struct {
+	DaysLate      int
+	FineValue     float64
+	InterestValue float64
+}
+
+func NewLateFeeRule(finePercentage float64, dailyInterestPercentage float64) LateFeeRule {
+	return LateFeeRule{
+		FinePercentage:          finePercentage,
+		DailyInterestPercentage: dailyInterestPercentage,
+	}
+}
+
+func (r *LateFeeRule) Validate() error {
+	if r.FinePercentage < 0 || r.FinePercentage > 100 {
+		return ErrLateFeeFinePercentageInvalid
+	}
+	if r.DailyInterestPercentage < 0 || r.DailyInterestPercentage > 100 {
+		return ErrLateFeeInterestPercentageInvalid
+	}
+	return nil
+}
+
+// Calculate retorna os encargos de uma parcela paga (ou consultada) em paymentDate.
+// Apenas parcelas de crediário vencidas geram encargos.
+func (r *LateFeeRule) Calculate(paymentType PaymentType, value float64, dueDate time.Time, paymentDate time.Time) LateFee {
+	if paymentType != PaymentTypeCreditStore || value <= 0 {
+		return LateFee{}
+	}
+	due := time.Date(dueDate.Year(), dueDate.Month(), dueDate.Day(), 0, 0, 0, 0, time.UTC)
+	paid := time.Date(paymentDate.Year(), paymentDate.Month(), paymentDate.Day(), 0, 0, 0, 0, time.UTC)
+	days := int(paid.Sub(due).Hours() / 24)
+	if days <= 0 {
+		return LateFee{}
+	}
+	return LateFee{
+		DaysLate:      days,
+		FineValue:     math.Round(value*r.FinePercentage) / 100,
+		InterestValue: math.Round(value*r.DailyInterestPercentage*float64(days)) / 100,
+	}
+}
+
+func (f LateFee) GetTotal() float64 {
+	return math.Round((f.FineValue+f.InterestValue)*100) / 100
+}
@@ -0,0 +1,8 @@
+package domain
+
+import "context"
+
+type LateFeeRuleRepository interface {
+	Get(ctx context.Context) (LateFeeRule, error)
+	Save(ctx context.Context, rule LateFeeRule) error
+}
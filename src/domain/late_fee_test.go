@@ -0,0 +1,46 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLateFeeRuleValidate(t *testing.T) {
+	rule := NewLateFeeRule(2, 0.033)
+	if err := rule.Validate(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	rule = NewLateFeeRule(-1, 0)
+	if err := rule.Validate(); err != ErrLateFeeFinePercentageInvalid {
+		t.Fatalf("expected fine error, got %v", err)
+	}
+
+	rule = NewLateFeeRule(2, 101)
+	if err := rule.Validate(); err != ErrLateFeeInterestPercentageInvalid {
+		t.Fatalf("expected interest error, got %v", err)
+	}
+}
+
+func TestLateFeeRuleCalculate(t *testing.T) {
+	rule := NewLateFeeRule(2, 0.5)
+	dueDate := time.Date(2024, 3, 10, 23, 0, 0, 0, time.UTC)
+
+	fee := rule.Calculate(PaymentTypeCreditStore, 100, dueDate, time.Date(2024, 3, 20, 8, 0, 0, 0, time.UTC))
+	if fee.DaysLate != 10 || fee.FineValue != 2 || fee.InterestValue != 5 {
+		t.Fatalf("unexpected fee: %+v", fee)
+	}
+	if fee.GetTotal() != 7 {
+		t.Fatalf("expected total 7, got %v", fee.GetTotal())
+	}
+
+	if fee := rule.Calculate(PaymentTypeCreditStore, 100, dueDate, dueDate.Add(30*time.Minute)); fee != (LateFee{}) {
+		t.Fatalf("expected no fee on due date, got %+v", fee)
+	}
+	if fee := rule.Calculate(PaymentTypePix, 100, dueDate, dueDate.AddDate(0, 1, 0)); fee != (LateFee{}) {
+		t.Fatalf("expected no fee for non credit store, got %+v", fee)
+	}
+	if fee := rule.Calculate(PaymentTypeCreditStore, 0, dueDate, dueDate.AddDate(0, 1, 0)); fee != (LateFee{}) {
+		t.Fatalf("expected no fee without open value, got %+v", fee)
+	}
+}
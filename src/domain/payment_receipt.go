@@ -0,0 +1,76 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+)
+
+var (
+	ErrPaymentReceiptValueInvalid       = errors.New("Valor do recebimento deve ser maior que zero")
+	ErrPaymentReceiptOverRemaining      = errors.New("Valor do recebimento excede o saldo da parcela")
+	ErrPaymentReceiptDateInvalid        = errors.New("Data do recebimento é obrigatória")
+	ErrPaymentReceiptTypeInvalid        = errors.New("Forma de recebimento inválida")
+	ErrPaymentReceiptInstallmentNotOpen = errors.New("Parcela não está em aberto")
+	ErrPaymentDateHasReceipts           = errors.New("Parcela com recebimentos registrados não pode ser reaberta ou cancelada")
+)
+
+type PaymentReceipt struct {
+	Id               int64
+	PaymentDateId    int64
+	Value            float64
+	ReceiptDate      time.Time
+	PaymentType      PaymentType
+	ReceivedByUserId int64
+	ReceivedBy       string
+}
+
+func NewPaymentReceipt(paymentDateId int64, value float64, receiptDate time.Time, paymentType PaymentType, receivedByUserId int64) PaymentReceipt {
+	return PaymentReceipt{
+		PaymentDateId:    paymentDateId,
+		Value:            math.Round(value*100) / 100,
+		ReceiptDate:      receiptDate,
+		PaymentType:      paymentType,
+		ReceivedByUserId: receivedByUserId,
+	}
+}
+
+// IsPaymentReceiptType indica se a forma de pagamento pode ser usada para
+// receber uma parcela.
+func IsPaymentReceiptType(paymentType PaymentType) bool {
+	return paymentType == PaymentTypeCash || paymentType == PaymentTypePix || paymentType == PaymentTypeDebitCard || paymentType == PaymentTypeCreditCard
+}
+
+func (r *PaymentReceipt) Validate(paymentDate SalesPaymentDates, receivedValue float64) error {
+	if paymentDate.Status != PaymentStatusPending && paymentDate.Status != PaymentStatusDelayed {
+		return ErrPaymentReceiptInstallmentNotOpen
+	}
+	if !IsPaymentReceiptType(r.PaymentType) {
+		return ErrPaymentReceiptTypeInvalid
+	}
+	if r.ReceiptDate.IsZero() {
+		return ErrPaymentReceiptDateInvalid
+	}
+	if r.Value <= 0 {
+		return ErrPaymentReceiptValueInvalid
+	}
+	remaining := math.Round((paymentDate.InstallmentValue-receivedValue)*100) / 100
+	if r.Value > remaining {
+		return errors.New(ErrPaymentReceiptOverRemaining.Error() + fmt.Sprintf(": R$ %.2f", remaining))
+	}
+	return nil
+}
+
+// GetRemainingValue retorna o saldo da parcela depois deste recebimento.
+func (r *PaymentReceipt) GetRemainingValue(paymentDate SalesPaymentDates, receivedValue float64) float64 {
+	return math.Round((paymentDate.InstallmentValue-receivedValue-r.Value)*100) / 100
+}
+
+// GetRemainingValue retorna o saldo em aberto da parcela considerando os recebimentos parciais.
+func (p *GetSalesPaymentOutput) GetRemainingValue() float64 {
+	if p.PaymentStatus != PaymentStatusPending && p.PaymentStatus != PaymentStatusDelayed {
+		return 0
+	}
+	return math.Round((p.InstallmentValue-p.ReceivedValue)*100) / 100
+}
@@ -0,0 +1,65 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewPaymentReceipt(t *testing.T) {
+	now := time.Now()
+	receipt := NewPaymentReceipt(1, 10.005, now, PaymentTypePix, 2)
+	if receipt.PaymentDateId != 1 || receipt.Value != 10.01 || !receipt.ReceiptDate.Equal(now) || receipt.PaymentType != PaymentTypePix || receipt.ReceivedByUserId != 2 {
+		t.Fatalf("unexpected receipt: %+v", receipt)
+	}
+}
+
+func TestPaymentReceiptValidate(t *testing.T) {
+	open := SalesPaymentDates{InstallmentValue: 30, Status: PaymentStatusDelayed}
+	now := time.Now()
+
+	receipt := NewPaymentReceipt(1, 10, now, PaymentTypeCash, 2)
+	if err := receipt.Validate(SalesPaymentDates{InstallmentValue: 30, Status: PaymentStatusPaid}, 0); err != ErrPaymentReceiptInstallmentNotOpen {
+		t.Fatalf("expected not open error, got %v", err)
+	}
+
+	receipt = NewPaymentReceipt(1, 10, now, PaymentTypeCreditStore, 2)
+	if err := receipt.Validate(open, 0); err != ErrPaymentReceiptTypeInvalid {
+		t.Fatalf("expected type error, got %v", err)
+	}
+
+	receipt = NewPaymentReceipt(1, 10, time.Time{}, PaymentTypeCash, 2)
+	if err := receipt.Validate(open, 0); err != ErrPaymentReceiptDateInvalid {
+		t.Fatalf("expected date error, got %v", err)
+	}
+
+	receipt = NewPaymentReceipt(1, 0, now, PaymentTypeCash, 2)
+	if err := receipt.Validate(open, 0); err != ErrPaymentReceiptValueInvalid {
+		t.Fatalf("expected value error, got %v", err)
+	}
+
+	receipt = NewPaymentReceipt(1, 20.01, now, PaymentTypeCash, 2)
+	if err := receipt.Validate(open, 10); err == nil || !strings.Contains(err.Error(), ErrPaymentReceiptOverRemaining.Error()) {
+		t.Fatalf("expected over remaining error, got %v", err)
+	}
+
+	receipt = NewPaymentReceipt(1, 20, now, PaymentTypeCash, 2)
+	if err := receipt.Validate(open, 10); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if remaining := receipt.GetRemainingValue(open, 10); remaining != 0 {
+		t.Fatalf("expected installment to be settled, got %v", remaining)
+	}
+}
+
+func TestGetSalesPaymentOutputGetRemainingValue(t *testing.T) {
+	payment := GetSalesPaymentOutput{InstallmentValue: 50, ReceivedValue: 20.5, PaymentStatus: PaymentStatusPending}
+	if remaining := payment.GetRemainingValue(); remaining != 29.5 {
+		t.Fatalf("expected remaining 29.5, got %v", remaining)
+	}
+
+	payment.PaymentStatus = PaymentStatusPaid
+	if remaining := payment.GetRemainingValue(); remaining != 0 {
+		t.Fatalf("expected settled installment without balance, got %v", remaining)
+	}
+}
@@ -0,0 +1,216 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+	"time"
+)
+
+var (
+	ErrPixSettingsNotConfigured = errors.New("PIX não configurado para a empresa")
+	ErrPixKeyInvalid            = errors.New("Chave PIX inválida")
+	ErrPixMerchantNameRequired  = errors.New("Nome do recebedor do PIX é obrigatório")
+	ErrPixMerchantCityRequired  = errors.New("Cidade do recebedor do PIX é obrigatória")
+	ErrPaymentNotPix            = errors.New("Parcela não é de pagamento PIX")
+	ErrPaymentNotPending        = errors.New("Parcela não está pendente")
+)
+
+var (
+	pixEmailRegexp = regexp.MustCompile(`^[^@\s]+@[^@\s]+\.[^@\s]+$`)
+	pixPhoneRegexp = regexp.MustCompile(`^\+[1-9][0-9]{10,13}$`)
+	pixEvpRegexp   = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+	pixDigits      = regexp.MustCompile(`^[0-9]{11}$|^[0-9]{14}$`)
+)
+
+// PixSettings é a chave PIX da empresa e os dados do recebedor impressos no
+// BR Code.
+type PixSettings struct {
+	Key          string
+	MerchantName string
+	MerchantCity string
+	UpdatedAt    *time.Time
+}
+
+func NewPixSettings(key string, merchantName string, merchantCity string) PixSettings {
+	return PixSettings{
+		Key:          strings.TrimSpace(key),
+		MerchantName: strings.TrimSpace(merchantName),
+		MerchantCity: strings.TrimSpace(merchantCity),
+	}
+}
+
+func (s *PixSettings) Validate() error {
+	if !IsPixKeyValid(s.Key) {
+		return ErrPixKeyInvalid
+	}
+	if s.MerchantName == "" {
+		return ErrPixMerchantNameRequired
+	}
+	if s.MerchantCity == "" {
+		return ErrPixMerchantCityRequired
+	}
+	return nil
+}
+
+func (s *PixSettings) IsConfigured() bool {
+	return s.Key != ""
+}
+
+// IsPixKeyValid aceita CPF ou CNPJ (apenas dígitos), e-mail, telefone no
+// formato +5584999999999 ou chave aleatória.
+func IsPixKeyValid(key string) bool {
+	if key == "" || len(key) > 77 {
+		return false
+	}
+	return pixDigits.MatchString(key) || pixEmailRegexp.MatchString(key) || pixPhoneRegexp.MatchString(key) || pixEvpRegexp.MatchString(key)
+}
+
+// PixTxId é o identificador da cobrança de uma parcela. Ele vai no BR Code e
+// costuma aparecer na descrição do crédito no extrato do banco.
+func PixTxId(paymentDateId int64) string {
+	return fmt.Sprintf("PD%010d", paymentDateId)
+}
+
+// NewPixPayload monta o BR Code estático ("copia e cola") no padrão EMV do
+// Banco Central, com o CRC16 no final.
+func NewPixPayload(settings PixSettings, amount float64, txId string) string {
+	var payload strings.Builder
+	payload.WriteString(emvField("00", "01"))
+	payload.WriteString(emvField("26", emvField("00", "br.gov.bcb.pix")+emvField("01", settings.Key)))
+	payload.WriteString(emvField("52", "0000"))
+	payload.WriteString(emvField("53", "986"))
+	if amount > 0 {
+		payload.WriteString(emvField("54", fmt.Sprintf("%.2f", math.Round(amount*100)/100)))
+	}
+	payload.WriteString(emvField("58", "BR"))
+	payload.WriteString(emvField("59", emvText(settings.MerchantName, 25)))
+	payload.WriteString(emvField("60", emvText(settings.MerchantCity, 15)))
+	if txId == "" {
+		txId = "***"
+	}
+	payload.WriteString(emvField("62", emvField("05", txId)))
+	payload.WriteString("6304")
+	return payload.String() + fmt.Sprintf("%04X", CRC16(payload.String()))
+}
+
+// CRC16 calcula o CRC-16/CCITT-FALSE (polinômio 0x1021, valor inicial 0xFFFF)
+// exigido pelo BR Code.
+func CRC16(data string) uint16 {
+	crc := uint16(0xFFFF)
+	for i := 0; i < len(data); i++ {
+		crc ^= uint16(data[i]) << 8
+		for j := 0; j < 8; j++ {
+			if crc&0x8000 != 0 {
+				crc = crc<<1 ^ 0x1021
+			} else {
+				crc <<= 1
+			}
+		}
+	}
+	return crc
+}
+
+func emvField(id string, value string) string {
+	return fmt.Sprintf("%s%02d%s", id, len(value), value)
+}
+
+var emvReplacer = strings.NewReplacer(
+	"á", "a", "à", "a", "â", "a", "ã", "a", "ä", "a", "é", "e", "ê", "e", "è", "e", "í", "i", "ì", "i",
+	"ó", "o", "ô", "o", "õ", "o", "ò", "o", "ú", "u", "ü", "u", "ù", "u", "ç", "c", "ñ", "n",
+	"Á", "A", "À", "A", "Â", "A", "Ã", "A", "Ä", "A", "É", "E", "Ê", "E", "È", "E", "Í", "I", "Ì", "I",
+	"Ó", "O", "Ô", "O", "Õ", "O", "Ò", "O", "Ú", "U", "Ü", "U", "Ù", "U", "Ç", "C", "Ñ", "N",
+)
+
+// emvText remove acentos e caracteres fora do ASCII e limita o tamanho do
+// texto, como pedem os campos de nome e cidade do BR Code.
+func emvText(text string, limit int) string {
+	var out strings.Builder
+	for _, r := range emvReplacer.Replace(text) {
+		if r >= 32 && r < 127 {
+			out.WriteRune(r)
+		}
+	}
+	result := strings.TrimSpace(out.String())
+	if len(result) > limit {
+		result = strings.TrimSpace(result[:limit])
+	}
+	return result
+}
+
+// PixCharge é a cobrança PIX de uma parcela pendente.
+type PixCharge struct {
+	PaymentDateId int64
+	TxId          string
+	Amount        float64
+	Payload       string
+}
+
+// PixPendingPayment é uma parcela PIX em aberto que pode ser baixada pela
+// conciliação do extrato. Value já desconta os recebimentos parciais.
+type PixPendingPayment struct {
+	PaymentDateId     int64
+	SaleId            int64
+	SaleCode          string
+	CustomerName      string
+	InstallmentNumber int
+	DueDate           time.Time
+	Value             float64
+}
+
+type PixReconciliationMatch struct {
+	Transaction BankStatementTransaction
+	Payment     PixPendingPayment
+}
+
+// ReconcilePix relaciona os créditos do extrato às parcelas PIX pendentes.
+// Primeiro procura o identificador da cobrança na descrição do crédito; sem
+// ele, aceita apenas quando uma única parcela pendente tem o mesmo valor.
+// Créditos sem correspondência segura ficam para conferência manual.
+func ReconcilePix(transactions []BankStatementTransaction, pending []PixPendingPayment) (matches []PixReconciliationMatch, unmatched []BankStatementTransaction) {
+	used := make(map[int64]bool)
+	matches = make([]PixReconciliationMatch, 0)
+	unmatched = make([]BankStatementTransaction, 0)
+
+	for _, transaction := range transactions {
+		if transaction.Amount <= 0 {
+			continue
+		}
+		amount := toCents(transaction.Amount)
+		text := strings.ToUpper(transaction.Id + " " + transaction.Description)
+
+		found := -1
+		for i, payment := range pending {
+			if !used[payment.PaymentDateId] && toCents(payment.Value) == amount && strings.Contains(text, PixTxId(payment.PaymentDateId)) {
+				found = i
+				break
+			}
+		}
+		if found < 0 {
+			for i, payment := range pending {
+				if used[payment.PaymentDateId] || toCents(payment.Value) != amount {
+					continue
+				}
+				if found >= 0 {
+					found = -1
+					break
+				}
+				found = i
+			}
+		}
+
+		if found < 0 {
+			unmatched = append(unmatched, transaction)
+			continue
+		}
+		used[pending[found].PaymentDateId] = true
+		matches = append(matches, PixReconciliationMatch{Transaction: transaction, Payment: pending[found]})
+	}
+	return matches, unmatched
+}
+
+func toCents(value float64) int64 {
+	return int64(math.Round(value * 100))
+}
@@ -0,0 +1,9 @@
+package domain
+
+import "context"
+
+type PixRepository interface {
+	GetSettings(ctx context.Context) (PixSettings, error)
+	SaveSettings(ctx context.Context, settings PixSettings) error
+	GetPendingPayments(ctx context.Context) ([]PixPendingPayment, error)
+}
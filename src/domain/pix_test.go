@@ -0,0 +1,117 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCRC16(t *testing.T) {
+	if crc := CRC16("123456789"); crc != 0x29B1 {
+		t.Fatalf("unexpected crc: %04X", crc)
+	}
+}
+
+func TestIsPixKeyValid(t *testing.T) {
+	valid := []string{"12345678901", "12345678000199", "loja@example.com", "+5584999999999", "123e4567-e89b-12d3-a456-426614174000"}
+	for _, key := range valid {
+		if !IsPixKeyValid(key) {
+			t.Fatalf("expected %q to be valid", key)
+		}
+	}
+	invalid := []string{"", "123", "84999999999x", "loja@", "5584999999999"}
+	for _, key := range invalid {
+		if IsPixKeyValid(key) {
+			t.Fatalf("expected %q to be invalid", key)
+		}
+	}
+}
+
+func TestPixSettingsValidate(t *testing.T) {
+	settings := NewPixSettings(" loja@example.com ", "Loja", "Natal")
+	if err := settings.Validate(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if settings.Key != "loja@example.com" {
+		t.Fatalf("expected key to be trimmed, got %q", settings.Key)
+	}
+
+	settings = NewPixSettings("invalida", "Loja", "Natal")
+	if err := settings.Validate(); err != ErrPixKeyInvalid {
+		t.Fatalf("expected invalid key error, got %v", err)
+	}
+	settings = NewPixSettings("loja@example.com", "", "Natal")
+	if err := settings.Validate(); err != ErrPixMerchantNameRequired {
+		t.Fatalf("expected merchant name error, got %v", err)
+	}
+	settings = NewPixSettings("loja@example.com", "Loja", " ")
+	if err := settings.Validate(); err != ErrPixMerchantCityRequired {
+		t.Fatalf("expected merchant city error, got %v", err)
+	}
+}
+
+func TestNewPixPayload(t *testing.T) {
+	settings := NewPixSettings("+5584999999999", "Confecções São João da Silva Ltda", "São Gonçalo do Amarante")
+	payload := NewPixPayload(settings, 100, PixTxId(42))
+
+	expected := "000201" +
+		"26360014br.gov.bcb.pix0114+5584999999999" +
+		"52040000" +
+		"5303986" +
+		"5406100.00" +
+		"5802BR" +
+		"5925Confeccoes Sao Joao da Si" +
+		"6014Sao Goncalo do" +
+		"62160512PD0000000042" +
+		"6304"
+	if !strings.HasPrefix(payload, expected) {
+		t.Fatalf("unexpected payload: %s", payload)
+	}
+	if crc := fmt.Sprintf("%04X", CRC16(expected)); payload != expected+crc {
+		t.Fatalf("unexpected crc in payload: %s", payload)
+	}
+
+	withoutAmount := NewPixPayload(settings, 0, "")
+	if strings.Contains(withoutAmount, "5406") || !strings.Contains(withoutAmount, "62070503***") {
+		t.Fatalf("unexpected payload without amount: %s", withoutAmount)
+	}
+}
+
+func TestReconcilePix(t *testing.T) {
+	date := time.Date(2026, 10, 15, 0, 0, 0, 0, time.UTC)
+	pending := []PixPendingPayment{
+		{PaymentDateId: 1, SaleId: 10, Value: 50},
+		{PaymentDateId: 2, SaleId: 20, Value: 50},
+		{PaymentDateId: 3, SaleId: 30, Value: 75.5},
+		{PaymentDateId: 4, SaleId: 40, Value: 120},
+	}
+	transactions := []BankStatementTransaction{
+		{Id: "a", Date: date, Amount: 50, Description: "PIX RECEBIDO " + PixTxId(2)},
+		{Id: "b", Date: date, Amount: 75.50, Description: "PIX RECEBIDO FULANO"},
+		{Id: "c", Date: date, Amount: 120, Description: "PIX RECEBIDO " + PixTxId(1)},
+		{Id: "d", Date: date, Amount: -75.50, Description: "PIX ENVIADO"},
+		{Id: "e", Date: date, Amount: 10, Description: "PIX RECEBIDO"},
+	}
+
+	matches, unmatched := ReconcilePix(transactions, pending)
+	if len(matches) != 3 {
+		t.Fatalf("expected 3 matches, got %+v", matches)
+	}
+	if matches[0].Payment.PaymentDateId != 2 || matches[1].Payment.PaymentDateId != 3 || matches[2].Payment.PaymentDateId != 4 {
+		t.Fatalf("unexpected matches: %+v", matches)
+	}
+	if len(unmatched) != 1 || unmatched[0].Id != "e" {
+		t.Fatalf("unexpected unmatched: %+v", unmatched)
+	}
+}
+
+func TestReconcilePixAmbiguousAmount(t *testing.T) {
+	pending := []PixPendingPayment{{PaymentDateId: 1, Value: 50}, {PaymentDateId: 2, Value: 50}}
+	transactions := []BankStatementTransaction{{Id: "a", Amount: 50, Description: "PIX RECEBIDO"}}
+
+	matches, unmatched := ReconcilePix(transactions, pending)
+	if len(matches) != 0 || len(unmatched) != 1 {
+		t.Fatalf("expected ambiguous credit to stay unmatched, got %+v %+v", matches, unmatched)
+	}
+}
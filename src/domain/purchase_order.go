@@ -0,0 +1,205 @@
+package domain
+
+import (
+	"errors"
+	"math"
+	"time"
+
+	"github.com/bncunha/erp-api/src/infrastructure/ksuid"
+)
+
+type PurchaseOrderStatus string
+
+const (
+	PurchaseOrderStatusOpen              PurchaseOrderStatus = "OPEN"
+	PurchaseOrderStatusPartiallyReceived PurchaseOrderStatus = "PARTIALLY_RECEIVED"
+	PurchaseOrderStatusReceived          PurchaseOrderStatus = "RECEIVED"
+	PurchaseOrderStatusCanceled          PurchaseOrderStatus = "CANCELED"
+)
+
+var (
+	ErrPurchaseOrderNotFound          = errors.New("Pedido de compra não encontrado")
+	ErrPurchaseOrderItemsRequired     = errors.New("O pedido de compra deve ter ao menos um item")
+	ErrPurchaseOrderQuantityInvalid   = errors.New("Quantidade do item deve ser maior que zero")
+	ErrPurchaseOrderUnitCostInvalid   = errors.New("Custo unitário do item não pode ser negativo")
+	ErrPurchaseOrderDuplicatedSku     = errors.New("O mesmo SKU foi informado mais de uma vez")
+	ErrPurchaseOrderNotesLength       = errors.New("Observação do pedido de compra deve ter no máximo 2000 caracteres")
+	ErrPurchaseOrderClosed            = errors.New("O pedido de compra já foi recebido ou cancelado")
+	ErrPurchaseOrderSkuNotInOrder     = errors.New("SKU recebido não faz parte do pedido de compra")
+	ErrPurchaseReceiptExceedsPending  = errors.New("Quantidade recebida maior que a pendente no pedido de compra")
+	ErrPurchaseReceiptItemsRequired   = errors.New("O recebimento deve ter ao menos um item")
+	ErrPurchaseReceiptQuantityInvalid = errors.New("Quantidade recebida deve ser maior que zero")
+)
+
+// PurchaseOrder é o pedido de compra feito a um fornecedor. A mercadoria entra
+// no estoque principal a cada recebimento, que pode ser parcial.
+type PurchaseOrder struct {
+	Id           int64
+	Code         string
+	Supplier     Supplier
+	Status       PurchaseOrderStatus
+	ExpectedDate *time.Time
+	Notes        string
+	CreatedAt    time.Time
+	Items        []PurchaseOrderItem
+	Receipts     []PurchaseReceipt
+}
+
+type PurchaseOrderItem struct {
+	Id               int64
+	Sku              Sku
+	Quantity         float64
+	ReceivedQuantity float64
+	UnitCost         float64
+}
+
+type PurchaseReceipt struct {
+	Id         int64
+	Notes      string
+	ReceivedAt time.Time
+	Items      []PurchaseReceiptItem
+}
+
+type PurchaseReceiptItem struct {
+	PurchaseOrderItemId int64
+	Sku                 Sku
+	Quantity            float64
+	UnitCost            float64
+}
+
+// ReceivePurchaseOrderItemInput é o que chegou de um SKU. Sem custo unitário,
+// vale o custo combinado no pedido.
+type ReceivePurchaseOrderItemInput struct {
+	SkuId    int64
+	Quantity float64
+	UnitCost *float64
+}
+
+func NewPurchaseOrder(supplier Supplier, items []PurchaseOrderItem, createdAt time.Time) PurchaseOrder {
+	return PurchaseOrder{
+		Code:      "C-" + ksuid.New().String(),
+		Supplier:  supplier,
+		Status:    PurchaseOrderStatusOpen,
+		CreatedAt: createdAt,
+		Items:     items,
+	}
+}
+
+func (p *PurchaseOrder) Validate() error {
+	if len(p.Items) == 0 {
+		return ErrPurchaseOrderItemsRequired
+	}
+	skus := make(map[int64]bool, len(p.Items))
+	for _, item := range p.Items {
+		if item.Quantity <= 0 {
+			return ErrPurchaseOrderQuantityInvalid
+		}
+		if item.UnitCost < 0 {
+			return ErrPurchaseOrderUnitCostInvalid
+		}
+		if skus[item.Sku.Id] {
+			return ErrPurchaseOrderDuplicatedSku
+		}
+		skus[item.Sku.Id] = true
+	}
+	if len([]rune(p.Notes)) > 2000 {
+		return ErrPurchaseOrderNotesLength
+	}
+	return nil
+}
+
+func (p *PurchaseOrder) IsClosed() bool {
+	return p.Status == PurchaseOrderStatusReceived || p.Status == PurchaseOrderStatusCanceled
+}
+
+func (p *PurchaseOrder) GetTotal() float64 {
+	var total float64
+	for _, item := range p.Items {
+		total += item.Quantity * item.UnitCost
+	}
+	return math.Round(total*100) / 100
+}
+
+// Receive registra o que chegou do pedido, sem permitir receber além do
+// pendente de cada item, e atualiza a situação do pedido.
+func (p *PurchaseOrder) Receive(items []ReceivePurchaseOrderItemInput, receivedAt time.Time) (PurchaseReceipt, error) {
+	if p.IsClosed() {
+		return PurchaseReceipt{}, ErrPurchaseOrderClosed
+	}
+	if len(items) == 0 {
+		return PurchaseReceipt{}, ErrPurchaseReceiptItemsRequired
+	}
+
+	receipt := PurchaseReceipt{ReceivedAt: receivedAt, Items: make([]PurchaseReceiptItem, 0, len(items))}
+	received := make(map[int64]bool, len(items))
+	for _, input := range items {
+		if input.Quantity <= 0 {
+			return PurchaseReceipt{}, ErrPurchaseReceiptQuantityInvalid
+		}
+		if input.UnitCost != nil && *input.UnitCost < 0 {
+			return PurchaseReceipt{}, ErrPurchaseOrderUnitCostInvalid
+		}
+		if received[input.SkuId] {
+			return PurchaseReceipt{}, ErrPurchaseOrderDuplicatedSku
+		}
+		received[input.SkuId] = true
+
+		index := p.findItem(input.SkuId)
+		if index < 0 {
+			return PurchaseReceipt{}, ErrPurchaseOrderSkuNotInOrder
+		}
+		item := &p.Items[index]
+		if input.Quantity > item.GetPendingQuantity() {
+			return PurchaseReceipt{}, ErrPurchaseReceiptExceedsPending
+		}
+
+		unitCost := item.UnitCost
+		if input.UnitCost != nil {
+			unitCost = *input.UnitCost
+		}
+		item.ReceivedQuantity = math.Round((item.ReceivedQuantity+input.Quantity)*1000) / 1000
+		receipt.Items = append(receipt.Items, PurchaseReceiptItem{
+			PurchaseOrderItemId: item.Id,
+			Sku:                 item.Sku,
+			Quantity:            input.Quantity,
+			UnitCost:            unitCost,
+		})
+	}
+
+	p.Status = PurchaseOrderStatusReceived
+	for _, item := range p.Items {
+		if item.GetPendingQuantity() > 0 {
+			p.Status = PurchaseOrderStatusPartiallyReceived
+			break
+		}
+	}
+	p.Receipts = append(p.Receipts, receipt)
+	return receipt, nil
+}
+
+// Cancel encerra o pedido. O que já foi recebido permanece no estoque; o
+// pendente deixa de ser esperado.
+func (p *PurchaseOrder) Cancel() error {
+	if p.IsClosed() {
+		return ErrPurchaseOrderClosed
+	}
+	p.Status = PurchaseOrderStatusCanceled
+	return nil
+}
+
+func (p *PurchaseOrder) findItem(skuId int64) int {
+	for i := range p.Items {
+		if p.Items[i].Sku.Id == skuId {
+			return i
+		}
+	}
+	return -1
+}
+
+func (i *PurchaseOrderItem) GetPendingQuantity() float64 {
+	pending := math.Round((i.Quantity-i.ReceivedQuantity)*1000) / 1000
+	if pending < 0 {
+		return 0
+	}
+	return pending
+}
@@ -0,0 +1,45 @@
+package domain
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+type GetPurchaseOrdersInput struct {
+	SupplierId *int64
+	Status     *PurchaseOrderStatus
+}
+
+type GetPurchaseOrdersOutput struct {
+	Id               int64
+	Code             string
+	SupplierId       int64
+	SupplierName     string
+	Status           PurchaseOrderStatus
+	ExpectedDate     *time.Time
+	CreatedAt        time.Time
+	TotalQuantity    float64
+	ReceivedQuantity float64
+	TotalValue       float64
+}
+
+type GetSkuPurchasesOutput struct {
+	ReceiptId         int64
+	ReceivedAt        time.Time
+	PurchaseOrderId   int64
+	PurchaseOrderCode string
+	SupplierId        int64
+	SupplierName      string
+	Quantity          float64
+	UnitCost          float64
+}
+
+type PurchaseOrderRepository interface {
+	Create(ctx context.Context, tx *sql.Tx, order PurchaseOrder, createdByUserId int64) (int64, error)
+	GetById(ctx context.Context, id int64) (PurchaseOrder, error)
+	GetAll(ctx context.Context, input GetPurchaseOrdersInput) ([]GetPurchaseOrdersOutput, error)
+	CreateReceipt(ctx context.Context, tx *sql.Tx, orderId int64, receipt PurchaseReceipt, receivedByUserId int64) (int64, error)
+	UpdateStatus(ctx context.Context, tx *sql.Tx, order PurchaseOrder) error
+	GetPurchasesBySkuId(ctx context.Context, skuId int64) ([]GetSkuPurchasesOutput, error)
+}
@@ -0,0 +1,91 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func newTestPurchaseOrder() PurchaseOrder {
+	return NewPurchaseOrder(Supplier{Id: 1}, []PurchaseOrderItem{
+		{Id: 10, Sku: Sku{Id: 1}, Quantity: 10, UnitCost: 5},
+		{Id: 11, Sku: Sku{Id: 2}, Quantity: 4, UnitCost: 12.5},
+	}, time.Now())
+}
+
+func TestPurchaseOrderValidate(t *testing.T) {
+	order := newTestPurchaseOrder()
+	if !strings.HasPrefix(order.Code, "C-") || order.Status != PurchaseOrderStatusOpen {
+		t.Fatalf("unexpected order: %+v", order)
+	}
+	if err := order.Validate(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if order.GetTotal() != 100 {
+		t.Fatalf("expected total 100, got %v", order.GetTotal())
+	}
+
+	order.Items = append(order.Items, PurchaseOrderItem{Sku: Sku{Id: 1}, Quantity: 1})
+	if err := order.Validate(); err != ErrPurchaseOrderDuplicatedSku {
+		t.Fatalf("expected duplicated sku, got %v", err)
+	}
+	order.Items = []PurchaseOrderItem{{Sku: Sku{Id: 1}, Quantity: 0}}
+	if err := order.Validate(); err != ErrPurchaseOrderQuantityInvalid {
+		t.Fatalf("expected invalid quantity, got %v", err)
+	}
+	order.Items = nil
+	if err := order.Validate(); err != ErrPurchaseOrderItemsRequired {
+		t.Fatalf("expected items required, got %v", err)
+	}
+}
+
+func TestPurchaseOrderReceivePartially(t *testing.T) {
+	order := newTestPurchaseOrder()
+	cost := 4.5
+
+	receipt, err := order.Receive([]ReceivePurchaseOrderItemInput{{SkuId: 1, Quantity: 6, UnitCost: &cost}}, time.Now())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if order.Status != PurchaseOrderStatusPartiallyReceived || order.Items[0].GetPendingQuantity() != 4 {
+		t.Fatalf("unexpected order after partial receipt: %+v", order)
+	}
+	if len(receipt.Items) != 1 || receipt.Items[0].PurchaseOrderItemId != 10 || receipt.Items[0].UnitCost != 4.5 {
+		t.Fatalf("unexpected receipt: %+v", receipt)
+	}
+
+	receipt, err = order.Receive([]ReceivePurchaseOrderItemInput{{SkuId: 1, Quantity: 4}, {SkuId: 2, Quantity: 4}}, time.Now())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if order.Status != PurchaseOrderStatusReceived || receipt.Items[1].UnitCost != 12.5 || len(order.Receipts) != 2 {
+		t.Fatalf("expected order fully received with order cost, got %+v", order)
+	}
+	if _, err := order.Receive([]ReceivePurchaseOrderItemInput{{SkuId: 1, Quantity: 1}}, time.Now()); err != ErrPurchaseOrderClosed {
+		t.Fatalf("expected closed order, got %v", err)
+	}
+}
+
+func TestPurchaseOrderReceiveValidation(t *testing.T) {
+	order := newTestPurchaseOrder()
+
+	if _, err := order.Receive([]ReceivePurchaseOrderItemInput{{SkuId: 1, Quantity: 11}}, time.Now()); err != ErrPurchaseReceiptExceedsPending {
+		t.Fatalf("expected exceeds pending, got %v", err)
+	}
+	if _, err := order.Receive([]ReceivePurchaseOrderItemInput{{SkuId: 3, Quantity: 1}}, time.Now()); err != ErrPurchaseOrderSkuNotInOrder {
+		t.Fatalf("expected sku not in order, got %v", err)
+	}
+	if _, err := order.Receive([]ReceivePurchaseOrderItemInput{{SkuId: 1, Quantity: 1}, {SkuId: 1, Quantity: 1}}, time.Now()); err != ErrPurchaseOrderDuplicatedSku {
+		t.Fatalf("expected duplicated sku, got %v", err)
+	}
+}
+
+func TestPurchaseOrderCancel(t *testing.T) {
+	order := newTestPurchaseOrder()
+	if err := order.Cancel(); err != nil || order.Status != PurchaseOrderStatusCanceled {
+		t.Fatalf("expected order canceled, got %v", err)
+	}
+	if err := order.Cancel(); err != ErrPurchaseOrderClosed {
+		t.Fatalf("expected closed order, got %v", err)
+	}
+}
@@ -0,0 +1,103 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/bncunha/erp-api/src/infrastructure/ksuid"
+)
+
+type QuoteStatus string
+
+const (
+	QuoteStatusOpen      QuoteStatus = "OPEN"
+	QuoteStatusConverted QuoteStatus = "CONVERTED"
+	QuoteStatusCancelled QuoteStatus = "CANCELLED"
+	QuoteStatusExpired   QuoteStatus = "EXPIRED"
+)
+
+var (
+	ErrQuoteNotFound         = errors.New("Orçamento não encontrado")
+	ErrQuoteNotOpen          = errors.New("Orçamento não está em aberto")
+	ErrQuoteExpired          = errors.New("Orçamento expirado")
+	ErrQuoteExpiresAtInvalid = errors.New("A validade do orçamento deve ser maior que a data atual")
+	ErrQuoteItemsRequired    = errors.New("É necessário informar ao menos um item no orçamento")
+	ErrQuotePaymentsRequired = errors.New("É necessário informar o plano de pagamento do orçamento")
+	ErrQuoteNotAllowed       = errors.New("Apenas administradores ou o vendedor do orçamento podem alterá-lo")
+)
+
+// Quote é um orçamento: itens e plano de pagamento propostos ao cliente, que
+// não movimentam estoque nem geram recebíveis até serem convertidos em venda.
+// Quando ReserveStock está ativo, as quantidades ficam reservadas no estoque
+// do vendedor enquanto o orçamento estiver em aberto e dentro da validade.
+type Quote struct {
+	Id           int64
+	Code         string
+	Date         time.Time
+	ExpiresAt    *time.Time
+	Status       QuoteStatus
+	ReserveStock bool
+	InventoryId  int64
+	User         User
+	Customer     Customer
+	Items        []SalesItem
+	Payments     []QuotePayment
+	Discount     SalesDiscount
+	SalesId      *int64
+}
+
+// QuotePayment é o plano de pagamento proposto. As datas das parcelas só são
+// geradas na conversão em venda.
+type QuotePayment struct {
+	PaymentType          PaymentType
+	Value                float64
+	InstallmentsQuantity *int
+	FirstInstallmentDate *time.Time
+}
+
+func NewQuote(user User, customer Customer, items []SalesItem, payments []QuotePayment, discount SalesDiscount, expiresAt *time.Time, reserveStock bool) Quote {
+	return Quote{
+		Code:         "O-" + ksuid.New().String(),
+		Date:         time.Now(),
+		ExpiresAt:    expiresAt,
+		Status:       QuoteStatusOpen,
+		ReserveStock: reserveStock,
+		User:         user,
+		Customer:     customer,
+		Items:        items,
+		Payments:     payments,
+		Discount:     discount,
+	}
+}
+
+func (q *Quote) Validate() error {
+	if len(q.Items) == 0 {
+		return ErrQuoteItemsRequired
+	}
+	if len(q.Payments) == 0 {
+		return ErrQuotePaymentsRequired
+	}
+	if q.ExpiresAt != nil && !q.ExpiresAt.After(q.Date) {
+		return ErrQuoteExpiresAtInvalid
+	}
+	return nil
+}
+
+// GetStatus retorna o status considerando a validade do orçamento em now.
+func (q *Quote) GetStatus(now time.Time) QuoteStatus {
+	if q.Status == QuoteStatusOpen && q.ExpiresAt != nil && !q.ExpiresAt.After(now) {
+		return QuoteStatusExpired
+	}
+	return q.Status
+}
+
+func (q *Quote) ValidateConversion(now time.Time) error {
+	switch q.GetStatus(now) {
+	case QuoteStatusOpen:
+		return nil
+	case QuoteStatusExpired:
+		return ErrQuoteExpired
+	default:
+		return ErrQuoteNotOpen
+	}
+}
@@ -0,0 +1,37 @@
+package domain
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+type GetQuotesInput struct {
+	UserId     []int64
+	CustomerId []int64
+	Status     *QuoteStatus
+}
+
+type GetQuotesOutput struct {
+	Id           int64
+	Code         string
+	Date         time.Time
+	ExpiresAt    *time.Time
+	Status       QuoteStatus
+	ReserveStock bool
+	SellerName   string
+	CustomerName string
+	TotalValue   float64
+	SalesId      *int64
+}
+
+type QuoteRepository interface {
+	Create(ctx context.Context, tx *sql.Tx, quote Quote) (int64, error)
+	CreateItems(ctx context.Context, tx *sql.Tx, quoteId int64, items []SalesItem) error
+	CreatePayments(ctx context.Context, tx *sql.Tx, quoteId int64, payments []QuotePayment) error
+	GetAll(ctx context.Context, input GetQuotesInput) ([]GetQuotesOutput, error)
+	GetById(ctx context.Context, id int64) (Quote, error)
+	Cancel(ctx context.Context, id int64) error
+	MarkAsConverted(ctx context.Context, tx *sql.Tx, id int64, salesId int64) error
+	GetReservedQuantitiesForUpdate(ctx context.Context, tx *sql.Tx, inventoryId int64, skuIds []int64, exceptQuoteId int64) (map[int64]float64, error)
+}
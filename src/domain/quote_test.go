@@ -0,0 +1,60 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewQuote(t *testing.T) {
+	expiresAt := time.Now().Add(24 * time.Hour)
+	quote := NewQuote(User{Id: 1}, Customer{Id: 2}, []SalesItem{{Quantity: 1}}, []QuotePayment{{PaymentType: PaymentTypeCash, Value: 10}}, SalesDiscount{}, &expiresAt, true)
+
+	if !strings.HasPrefix(quote.Code, "O-") || quote.Status != QuoteStatusOpen || !quote.ReserveStock {
+		t.Fatalf("unexpected quote: %+v", quote)
+	}
+	if err := quote.Validate(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestQuoteValidate(t *testing.T) {
+	items := []SalesItem{{Quantity: 1}}
+	payments := []QuotePayment{{PaymentType: PaymentTypeCash, Value: 10}}
+	past := time.Now().Add(-time.Hour)
+
+	cases := map[string]struct {
+		quote Quote
+		err   error
+	}{
+		"no items":        {NewQuote(User{}, Customer{}, nil, payments, SalesDiscount{}, nil, false), ErrQuoteItemsRequired},
+		"no payments":     {NewQuote(User{}, Customer{}, items, nil, SalesDiscount{}, nil, false), ErrQuotePaymentsRequired},
+		"past expiration": {NewQuote(User{}, Customer{}, items, payments, SalesDiscount{}, &past, false), ErrQuoteExpiresAtInvalid},
+		"no expiration":   {NewQuote(User{}, Customer{}, items, payments, SalesDiscount{}, nil, false), nil},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if err := tc.quote.Validate(); err != tc.err {
+				t.Fatalf("expected %v, got %v", tc.err, err)
+			}
+		})
+	}
+}
+
+func TestQuoteStatusAndConversion(t *testing.T) {
+	now := time.Now()
+	expiresAt := now.Add(time.Hour)
+	quote := Quote{Status: QuoteStatusOpen, ExpiresAt: &expiresAt}
+
+	if quote.GetStatus(now) != QuoteStatusOpen || quote.ValidateConversion(now) != nil {
+		t.Fatalf("expected open quote to be convertible")
+	}
+	later := now.Add(2 * time.Hour)
+	if quote.GetStatus(later) != QuoteStatusExpired || quote.ValidateConversion(later) != ErrQuoteExpired {
+		t.Fatalf("expected quote to be expired")
+	}
+	quote.Status = QuoteStatusConverted
+	if quote.GetStatus(later) != QuoteStatusConverted || quote.ValidateConversion(now) != ErrQuoteNotOpen {
+		t.Fatalf("expected converted quote not to be convertible")
+	}
+}
@@ -0,0 +1,79 @@
+package domain
+
+import (
+	"math"
+	"time"
+)
+
+type ReceivableAgingBucket string
+
+const (
+	ReceivableAgingNotDue ReceivableAgingBucket = "NOT_DUE"
+	ReceivableAging1To30  ReceivableAgingBucket = "1-30"
+	ReceivableAging31To60 ReceivableAgingBucket = "31-60"
+	ReceivableAging61To90 ReceivableAgingBucket = "61-90"
+	ReceivableAgingOver90 ReceivableAgingBucket = "90+"
+)
+
+var ReceivableAgingBuckets = []ReceivableAgingBucket{
+	ReceivableAgingNotDue,
+	ReceivableAging1To30,
+	ReceivableAging31To60,
+	ReceivableAging61To90,
+	ReceivableAgingOver90,
+}
+
+type Receivable struct {
+	PaymentDateId     int64
+	SaleId            int64
+	SaleCode          string
+	SaleDate          time.Time
+	SellerId          int64
+	SellerName        string
+	CustomerId        int64
+	CustomerName      string
+	PaymentType       PaymentType
+	InstallmentNumber int
+	InstallmentValue  float64
+	ReceivedValue     float64
+	DueDate           time.Time
+	Status            PaymentStatus
+	LateFee           LateFee
+}
+
+func (r *Receivable) GetOpenValue() float64 {
+	return math.Round((r.InstallmentValue-r.ReceivedValue)*100) / 100
+}
+
+// GetAmountDue retorna o saldo em aberto acrescido de multa e juros por atraso.
+func (r *Receivable) GetAmountDue() float64 {
+	return math.Round((r.GetOpenValue()+r.LateFee.GetTotal())*100) / 100
+}
+
+func (r *Receivable) GetDaysOverdue(today time.Time) int {
+	dueDate := time.Date(r.DueDate.Year(), r.DueDate.Month(), r.DueDate.Day(), 0, 0, 0, 0, time.UTC)
+	todayDate := time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, time.UTC)
+	days := int(todayDate.Sub(dueDate).Hours() / 24)
+	if days < 0 {
+		return 0
+	}
+	return days
+}
+
+// GetAgingBucket classifica a parcela pelos dias de atraso. A parcela que vence
+// hoje ainda não está atrasada e fica em NOT_DUE; o atraso começa em 1-30.
+func (r *Receivable) GetAgingBucket(today time.Time) ReceivableAgingBucket {
+	days := r.GetDaysOverdue(today)
+	switch {
+	case days == 0:
+		return ReceivableAgingNotDue
+	case days <= 30:
+		return ReceivableAging1To30
+	case days <= 60:
+		return ReceivableAging31To60
+	case days <= 90:
+		return ReceivableAging61To90
+	default:
+		return ReceivableAgingOver90
+	}
+}
@@ -0,0 +1,20 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+type GetReceivablesInput struct {
+	InitialDueDate *time.Time
+	FinalDueDate   *time.Time
+	UserId         []int64
+	CustomerId     []int64
+	PaymentStatus  *PaymentStatus
+}
+
+type ReceivableRepository interface {
+	GetAll(ctx context.Context, input GetReceivablesInput) ([]Receivable, error)
+	UpdateDelayedPaymentDates(ctx context.Context) (int64, error)
+	HasOpenInstallmentsByUserId(ctx context.Context, userId int64) (bool, error)
+}
@@ -0,0 +1,64 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestReceivableGetDaysOverdue(t *testing.T) {
+	today := time.Date(2025, 3, 10, 15, 0, 0, 0, time.Local)
+	receivable := Receivable{DueDate: time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)}
+	if days := receivable.GetDaysOverdue(today); days != 9 {
+		t.Fatalf("expected 9 days overdue, got %d", days)
+	}
+
+	receivable.DueDate = time.Date(2025, 3, 20, 0, 0, 0, 0, time.UTC)
+	if days := receivable.GetDaysOverdue(today); days != 0 {
+		t.Fatalf("expected not overdue, got %d", days)
+	}
+}
+
+func TestReceivableGetAgingBucket(t *testing.T) {
+	today := time.Date(2025, 6, 30, 0, 0, 0, 0, time.UTC)
+	cases := map[int]ReceivableAgingBucket{
+		-5:  ReceivableAgingNotDue,
+		0:   ReceivableAgingNotDue,
+		1:   ReceivableAging1To30,
+		30:  ReceivableAging1To30,
+		31:  ReceivableAging31To60,
+		60:  ReceivableAging31To60,
+		61:  ReceivableAging61To90,
+		90:  ReceivableAging61To90,
+		91:  ReceivableAgingOver90,
+		365: ReceivableAgingOver90,
+	}
+	for days, expected := range cases {
+		receivable := Receivable{DueDate: today.AddDate(0, 0, -days)}
+		if bucket := receivable.GetAgingBucket(today); bucket != expected {
+			t.Fatalf("expected %s for %d days, got %s", expected, days, bucket)
+		}
+	}
+}
+
+func TestReceivableGetAgingBucketBoundaries(t *testing.T) {
+	today := time.Date(2025, 6, 30, 18, 30, 0, 0, time.UTC)
+	dueToday := Receivable{DueDate: time.Date(2025, 6, 30, 0, 0, 0, 0, time.UTC)}
+	if bucket := dueToday.GetAgingBucket(today); bucket != ReceivableAgingNotDue {
+		t.Fatalf("expected installment due today to be %s, got %s", ReceivableAgingNotDue, bucket)
+	}
+	thirtyDays := Receivable{DueDate: time.Date(2025, 5, 31, 0, 0, 0, 0, time.UTC)}
+	if bucket := thirtyDays.GetAgingBucket(today); bucket != ReceivableAging1To30 {
+		t.Fatalf("expected 30 days overdue to be %s, got %s", ReceivableAging1To30, bucket)
+	}
+	thirtyOneDays := Receivable{DueDate: time.Date(2025, 5, 30, 0, 0, 0, 0, time.UTC)}
+	if bucket := thirtyOneDays.GetAgingBucket(today); bucket != ReceivableAging31To60 {
+		t.Fatalf("expected 31 days overdue to be %s, got %s", ReceivableAging31To60, bucket)
+	}
+}
+
+func TestReceivableGetOpenValue(t *testing.T) {
+	receivable := Receivable{InstallmentValue: 100, ReceivedValue: 33.33}
+	if open := receivable.GetOpenValue(); open != 66.67 {
+		t.Fatalf("expected open value 66.67, got %v", open)
+	}
+}
@@ -32,6 +32,15 @@ var (
 	ErrReturnItemsDuplicated       = errors.New("Há itens duplicados na devolução")
 	ErrReturnItemQuantityInvalid   = errors.New("Quantidade de devolução inválida")
 	ErrReturnItemNotFound          = errors.New("Item de devolução não encontrado na venda")
+	ErrCancelReasonRequired        = errors.New("Motivo do cancelamento é obrigatório")
+	ErrCancelReasonLengthInvalid   = errors.New("Motivo do cancelamento deve ter no máximo 2000 caracteres")
+	ErrSaleAlreadyCancelled        = errors.New("Venda já está cancelada")
+	ErrSaleEditNotAllowed          = errors.New("Apenas administradores ou o vendedor da venda podem editá-la")
+	ErrEditItemsRequired           = errors.New("É necessário informar ao menos um item na venda")
+	ErrExchangeItemsRequired       = errors.New("É necessário informar ao menos um item novo para a troca")
+	ErrExchangeItemAlreadyInSale   = errors.New("Item da troca já faz parte da venda")
+	ErrReceivedAmountOnlyCash      = errors.New("Valor recebido só pode ser informado para pagamentos em dinheiro")
+	ErrReceivedAmountInsufficient  = errors.New("Valor recebido é menor que o valor do pagamento em dinheiro")
 )
 
 const (
@@ -51,6 +60,17 @@ const (
 	PaymentStatusReversal PaymentStatus = "REVERSAL"
 )
 
+type SalesVersionType string
+
+const (
+	SalesVersionTypeSale          SalesVersionType = "SALE"
+	SalesVersionTypeEdit          SalesVersionType = "EDIT"
+	SalesVersionTypeReturn        SalesVersionType = "RETURN"
+	SalesVersionTypeExchange      SalesVersionType = "EXCHANGE"
+	SalesVersionTypeCancel        SalesVersionType = "CANCEL"
+	SalesVersionTypeRenegotiation SalesVersionType = "RENEGOTIATION"
+)
+
 type Sales struct {
 	Id             int64
 	Code           string
@@ -62,6 +82,10 @@ type Sales struct {
 	Items          []SalesItem
 	Payments       []SalesPayment
 	Returns        []SalesReturn
+	Discount       SalesDiscount
+	// AdjustmentAmount é o acréscimo (positivo) ou abatimento (negativo)
+	// concedido em renegociações, somado ao total dos itens.
+	AdjustmentAmount float64
 }
 
 func NewSales(date time.Time, user User, customer Customer, items []SalesItem, payments []SalesPayment) Sales {
@@ -77,12 +101,42 @@ func NewSales(date time.Time, user User, customer Customer, items []SalesItem, p
 }
 
 func (s *Sales) ValidateSale() error {
+	if err := s.validateDiscounts(); err != nil {
+		return err
+	}
 	missingValue := s.getMissingValue()
 	if missingValue > 0 {
 		return errors.New(ErrPaymentValueIsMissing.Error() + fmt.Sprintf(": R$ %.2f", missingValue))
 	} else if missingValue < 0 {
 		return errors.New(ErrPaymentValueIsOverTotal.Error() + fmt.Sprintf(": R$ %.2f", missingValue))
 	}
+	if s.GetCustomerCreditTotal() > math.Round(s.Customer.CreditBalance*100)/100 {
+		return errors.New(ErrCustomerCreditInsufficient.Error() + fmt.Sprintf(": R$ %.2f", s.Customer.CreditBalance))
+	}
+	return s.validateItemsAndPayments()
+}
+
+func (s *Sales) ValidateEdit(paidValue float64) error {
+	if len(s.Items) == 0 {
+		return ErrEditItemsRequired
+	}
+	remaining := math.Max(s.GetTotal()-paidValue, 0)
+	missingValue := remaining
+	for _, payment := range s.Payments {
+		for _, date := range payment.Dates {
+			missingValue -= date.InstallmentValue
+		}
+	}
+	missingValue = math.Round(missingValue*100) / 100
+	if missingValue > 0 {
+		return errors.New(ErrPaymentValueIsMissing.Error() + fmt.Sprintf(": R$ %.2f", missingValue))
+	} else if missingValue < 0 {
+		return errors.New(ErrPaymentValueIsOverTotal.Error() + fmt.Sprintf(": R$ %.2f", missingValue))
+	}
+	return s.validateItemsAndPayments()
+}
+
+func (s *Sales) validateItemsAndPayments() error {
 	if s.isPaymentTypesDuplicated() {
 		return ErrPaymentTypesDuplicated
 	}
@@ -104,6 +158,12 @@ func (s *Sales) ValidateSale() error {
 		if !payment.isPaymentDatesQuantityValid() {
 			return ErrPaymentDatesQuantityInvalid
 		}
+		if err := payment.validateReceivedAmount(); err != nil {
+			return err
+		}
+		if err := payment.validateCardBrand(); err != nil {
+			return err
+		}
 	}
 	return nil
 }
@@ -118,12 +178,35 @@ func (s *Sales) getMissingValue() float64 {
 	return math.Round(missingValue*100) / 100
 }
 
+func (s *Sales) GetCustomerCreditTotal() float64 {
+	var total float64
+	for _, payment := range s.Payments {
+		if payment.PaymentType != PaymentTypeReturn {
+			continue
+		}
+		for _, date := range payment.Dates {
+			total += date.InstallmentValue
+		}
+	}
+	return math.Round(total*100) / 100
+}
+
+// GetChangeAmount retorna o troco devolvido ao cliente nos pagamentos em
+// dinheiro da venda.
+func (s *Sales) GetChangeAmount() float64 {
+	var total float64
+	for _, payment := range s.Payments {
+		total += payment.ChangeAmount
+	}
+	return math.Round(total*100) / 100
+}
+
 func (s *Sales) GetTotal() float64 {
 	var total float64
 	for _, item := range s.Items {
-		total += item.Sku.Price * item.Quantity
+		total += item.GetTotal()
 	}
-	return total
+	return total + s.AdjustmentAmount
 }
 
 func (s *Sales) isPaymentTypesDuplicated() bool {
@@ -194,16 +277,103 @@ func (r *SalesReturn) Validate(saleItems []SalesItem) error {
 	return nil
 }
 
+func (r *SalesReturn) GetTotal() float64 {
+	var total float64
+	for _, item := range r.Items {
+		total += item.UnitPrice * item.Quantity
+	}
+	return math.Round(total*100) / 100
+}
+
+type SalesExchange struct {
+	Id           int64
+	ExchangeDate time.Time
+	Return       SalesReturn
+	Items        []SalesItem
+	Payments     []SalesPayment
+}
+
+func NewSalesExchange(salesReturn SalesReturn, items []SalesItem, payments []SalesPayment) SalesExchange {
+	return SalesExchange{
+		ExchangeDate: salesReturn.ReturnDate,
+		Return:       salesReturn,
+		Items:        items,
+		Payments:     payments,
+	}
+}
+
+func (e *SalesExchange) GetDifference() float64 {
+	sale := Sales{Items: e.Items}
+	return math.Round((sale.GetTotal()-e.Return.GetTotal())*100) / 100
+}
+
+func (e *SalesExchange) Validate(saleItems []SalesItem) error {
+	if err := e.Return.Validate(saleItems); err != nil {
+		return err
+	}
+	if len(e.Items) == 0 {
+		return ErrExchangeItemsRequired
+	}
+
+	returned := make(map[int64]float64)
+	for _, item := range e.Return.Items {
+		returned[item.Sku.Id] += item.Quantity
+	}
+	for _, item := range e.Items {
+		for _, saleItem := range saleItems {
+			if saleItem.Sku.Id == item.Sku.Id && saleItem.Quantity-returned[item.Sku.Id] > 0 {
+				return errors.New(ErrExchangeItemAlreadyInSale.Error() + fmt.Sprintf(": (%d) %s", item.Sku.Id, item.Sku.GetName()))
+			}
+		}
+	}
+
+	sale := Sales{Items: e.Items, Payments: e.Payments}
+	return sale.ValidateEdit(e.Return.GetTotal())
+}
+
+type SalesCancellation struct {
+	Id         int64
+	CancelDate time.Time
+	Reason     string
+}
+
+func NewSalesCancellation(reason string) SalesCancellation {
+	return SalesCancellation{
+		CancelDate: time.Now(),
+		Reason:     strings.TrimSpace(reason),
+	}
+}
+
+func (c *SalesCancellation) Validate(saleItems []SalesItem) error {
+	if c.Reason == "" {
+		return ErrCancelReasonRequired
+	}
+	if len([]rune(c.Reason)) > 2000 {
+		return ErrCancelReasonLengthInvalid
+	}
+	if len(saleItems) == 0 {
+		return ErrSaleAlreadyCancelled
+	}
+	return nil
+}
+
 type SalesItem struct {
-	Sku       Sku
-	Quantity  float64
-	UnitPrice float64
+	Sku                Sku
+	Quantity           float64
+	UnitPrice          float64
+	Discount           SalesDiscount
+	DiscountAmount     float64
+	SaleDiscountAmount float64
+	UnitCost           *float64
 }
 
+// NewSalesItem cria o item guardando o custo médio do SKU no momento da venda,
+// base para a margem bruta do item.
 func NewSalesItem(sku Sku, quantity float64) SalesItem {
 	return SalesItem{
 		Sku:      sku,
 		Quantity: quantity,
+		UnitCost: sku.Cost,
 	}
 }
 
@@ -212,9 +382,12 @@ func (s *SalesItem) isQuantityValid() bool {
 }
 
 type SalesPayment struct {
-	Id          int64
-	PaymentType PaymentType
-	Dates       []SalesPaymentDates
+	Id             int64
+	PaymentType    PaymentType
+	Dates          []SalesPaymentDates
+	ReceivedAmount float64
+	ChangeAmount   float64
+	CardBrand      CardBrand
 }
 
 func NewSalesPayment(paymentType PaymentType) SalesPayment {
@@ -224,6 +397,34 @@ func NewSalesPayment(paymentType PaymentType) SalesPayment {
 	}
 }
 
+func (s *SalesPayment) GetTotal() float64 {
+	var total float64
+	for _, date := range s.Dates {
+		total += date.InstallmentValue
+	}
+	return math.Round(total*100) / 100
+}
+
+// SetReceivedAmount registra o valor entregue pelo cliente em um pagamento em
+// dinheiro. A parcela continua com o valor devido e a diferença é o troco.
+func (s *SalesPayment) SetReceivedAmount(receivedAmount float64) {
+	s.ReceivedAmount = math.Round(receivedAmount*100) / 100
+	s.ChangeAmount = math.Max(math.Round((s.ReceivedAmount-s.GetTotal())*100)/100, 0)
+}
+
+func (s *SalesPayment) validateReceivedAmount() error {
+	if s.ReceivedAmount == 0 {
+		return nil
+	}
+	if s.PaymentType != PaymentTypeCash {
+		return ErrReceivedAmountOnlyCash
+	}
+	if s.ReceivedAmount < s.GetTotal() {
+		return errors.New(ErrReceivedAmountInsufficient.Error() + fmt.Sprintf(": R$ %.2f", s.GetTotal()))
+	}
+	return nil
+}
+
 func (s *SalesPayment) shouldConfirmPayment() bool {
 	return s.PaymentType == PaymentTypeCreditStore
 }
@@ -325,6 +526,11 @@ type SalesPaymentDates struct {
 	InstallmentValue  float64
 	Status            PaymentStatus
 	PaymentType       PaymentType
+	FineValue         float64
+	InterestValue     float64
+	CardBrand         CardBrand
+	CardFeeValue      float64
+	SettlementDate    *time.Time
 }
 
 func NewSalesPaymentDates(dueDate time.Time, paidDate *time.Time, installmentNumber int, installmentValue float64, status PaymentStatus) SalesPaymentDates {
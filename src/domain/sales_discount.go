@@ -0,0 +1,218 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+)
+
+type DiscountType string
+
+const (
+	DiscountTypePercentage DiscountType = "PERCENTAGE"
+	DiscountTypeFixed      DiscountType = "FIXED"
+)
+
+var (
+	ErrDiscountTypeInvalid              = errors.New("Tipo de desconto inválido")
+	ErrDiscountValueInvalid             = errors.New("Valor do desconto deve ser maior que zero")
+	ErrDiscountPercentageInvalid        = errors.New("Percentual de desconto deve ser no máximo 100")
+	ErrDiscountReasonRequired           = errors.New("Motivo do desconto é obrigatório")
+	ErrDiscountReasonLengthInvalid      = errors.New("Motivo do desconto deve ter no máximo 2000 caracteres")
+	ErrDiscountOverTotal                = errors.New("Desconto excede o valor")
+	ErrDiscountOverResellerLimit        = errors.New("Desconto acima do limite permitido para revendedores")
+	ErrDiscountRuleMaxPercentageInvalid = errors.New("Percentual máximo de desconto deve estar entre 0 e 100")
+	ErrDiscountOnlyOnSale               = errors.New("Descontos só podem ser informados no registro da venda")
+)
+
+// SalesDiscount é o desconto informado na venda, aplicado sobre um item ou
+// sobre o total. Value é o percentual ou o valor fixo, conforme Type.
+type SalesDiscount struct {
+	Type   DiscountType
+	Value  float64
+	Reason string
+}
+
+func NewSalesDiscount(discountType DiscountType, value float64, reason string) SalesDiscount {
+	return SalesDiscount{
+		Type:   discountType,
+		Value:  value,
+		Reason: strings.TrimSpace(reason),
+	}
+}
+
+func (d *SalesDiscount) IsEmpty() bool {
+	return d.Type == ""
+}
+
+func (d *SalesDiscount) Validate() error {
+	if d.Type != DiscountTypePercentage && d.Type != DiscountTypeFixed {
+		return ErrDiscountTypeInvalid
+	}
+	if d.Value <= 0 {
+		return ErrDiscountValueInvalid
+	}
+	if d.Type == DiscountTypePercentage && d.Value > 100 {
+		return ErrDiscountPercentageInvalid
+	}
+	if d.Reason == "" {
+		return ErrDiscountReasonRequired
+	}
+	if len([]rune(d.Reason)) > 2000 {
+		return ErrDiscountReasonLengthInvalid
+	}
+	return nil
+}
+
+// GetAmount retorna o valor do desconto sobre base, arredondado em centavos.
+func (d *SalesDiscount) GetAmount(base float64) float64 {
+	if d.IsEmpty() {
+		return 0
+	}
+	if d.Type == DiscountTypePercentage {
+		return math.Round(base*d.Value) / 100
+	}
+	return math.Round(d.Value*100) / 100
+}
+
+// DiscountRule é o limite de desconto que um revendedor pode conceder sem
+// aprovação de um administrador, em percentual sobre o valor de tabela da venda.
+type DiscountRule struct {
+	ResellerMaxPercentage float64
+}
+
+func NewDiscountRule(resellerMaxPercentage float64) DiscountRule {
+	return DiscountRule{ResellerMaxPercentage: resellerMaxPercentage}
+}
+
+func (r *DiscountRule) Validate() error {
+	if r.ResellerMaxPercentage < 0 || r.ResellerMaxPercentage > 100 {
+		return ErrDiscountRuleMaxPercentageInvalid
+	}
+	return nil
+}
+
+// ApplyDiscounts calcula o desconto de cada item e rateia o desconto da venda
+// entre os itens, proporcionalmente ao valor líquido de cada um.
+func (s *Sales) ApplyDiscounts() {
+	subtotal := 0.0
+	for i := range s.Items {
+		s.Items[i].DiscountAmount = s.Items[i].Discount.GetAmount(s.Items[i].GetGrossTotal())
+		s.Items[i].SaleDiscountAmount = 0
+		subtotal += s.Items[i].GetTotal()
+	}
+	subtotal = math.Round(subtotal*100) / 100
+
+	saleDiscount := s.Discount.GetAmount(subtotal)
+	if saleDiscount <= 0 || subtotal <= 0 {
+		return
+	}
+
+	remainingCents := int64(math.Round(saleDiscount * 100))
+	last := -1
+	for i := range s.Items {
+		if s.Items[i].GetTotal() > 0 {
+			last = i
+		}
+	}
+	for i := range s.Items {
+		if i == last {
+			s.Items[i].SaleDiscountAmount = float64(remainingCents) / 100
+			break
+		}
+		share := int64(math.Round(saleDiscount * s.Items[i].GetTotal() / subtotal * 100))
+		if share > remainingCents {
+			share = remainingCents
+		}
+		s.Items[i].SaleDiscountAmount = float64(share) / 100
+		remainingCents -= share
+	}
+}
+
+func (s *Sales) GetGrossTotal() float64 {
+	var total float64
+	for _, item := range s.Items {
+		total += item.GetGrossTotal()
+	}
+	return math.Round(total*100) / 100
+}
+
+// GetSaleDiscountAmount retorna apenas o desconto concedido sobre o total da venda.
+func (s *Sales) GetSaleDiscountAmount() float64 {
+	var total float64
+	for _, item := range s.Items {
+		total += item.SaleDiscountAmount
+	}
+	return math.Round(total*100) / 100
+}
+
+func (s *Sales) GetDiscountTotal() float64 {
+	var total float64
+	for _, item := range s.Items {
+		total += item.DiscountAmount + item.SaleDiscountAmount
+	}
+	return math.Round(total*100) / 100
+}
+
+// ValidateDiscountLimit verifica se o desconto total da venda respeita o
+// percentual máximo permitido.
+func (s *Sales) ValidateDiscountLimit(rule DiscountRule) error {
+	gross := s.GetGrossTotal()
+	if gross <= 0 {
+		return nil
+	}
+	percentage := math.Round(s.GetDiscountTotal()/gross*10000) / 100
+	if percentage > rule.ResellerMaxPercentage {
+		return errors.New(ErrDiscountOverResellerLimit.Error() + fmt.Sprintf(": máximo de %.2f%%", rule.ResellerMaxPercentage))
+	}
+	return nil
+}
+
+func (s *Sales) validateDiscounts() error {
+	subtotal := 0.0
+	for _, item := range s.Items {
+		if item.Discount.IsEmpty() {
+			subtotal += item.GetGrossTotal()
+			continue
+		}
+		if err := item.Discount.Validate(); err != nil {
+			return err
+		}
+		if item.Discount.GetAmount(item.GetGrossTotal()) > math.Round(item.GetGrossTotal()*100)/100 {
+			return errors.New(ErrDiscountOverTotal.Error() + fmt.Sprintf(" do item: (%d) %s", item.Sku.Id, item.Sku.GetName()))
+		}
+		subtotal += item.GetGrossTotal() - item.Discount.GetAmount(item.GetGrossTotal())
+	}
+	if s.Discount.IsEmpty() {
+		return nil
+	}
+	if err := s.Discount.Validate(); err != nil {
+		return err
+	}
+	subtotal = math.Round(subtotal*100) / 100
+	if s.Discount.GetAmount(subtotal) > subtotal {
+		return errors.New(ErrDiscountOverTotal.Error() + fmt.Sprintf(" da venda: R$ %.2f", subtotal))
+	}
+	return nil
+}
+
+func (s *SalesItem) GetGrossTotal() float64 {
+	return s.Sku.Price * s.Quantity
+}
+
+// GetTotal retorna o valor do item já descontado.
+func (s *SalesItem) GetTotal() float64 {
+	return math.Round((s.GetGrossTotal()-s.DiscountAmount-s.SaleDiscountAmount)*100) / 100
+}
+
+// WithQuantity retorna o item com a nova quantidade, mantendo o desconto
+// proporcional por unidade.
+func (s SalesItem) WithQuantity(quantity float64) SalesItem {
+	if s.Quantity > 0 {
+		s.DiscountAmount = math.Round(s.DiscountAmount*quantity/s.Quantity*100) / 100
+		s.SaleDiscountAmount = math.Round(s.SaleDiscountAmount*quantity/s.Quantity*100) / 100
+	}
+	s.Quantity = quantity
+	return s
+}
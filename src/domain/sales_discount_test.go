@@ -0,0 +1,150 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSalesDiscountValidate(t *testing.T) {
+	cases := []struct {
+		discount SalesDiscount
+		err      error
+	}{
+		{NewSalesDiscount(DiscountTypePercentage, 10, "Cliente fiel"), nil},
+		{NewSalesDiscount("OTHER", 10, "Motivo"), ErrDiscountTypeInvalid},
+		{NewSalesDiscount(DiscountTypeFixed, 0, "Motivo"), ErrDiscountValueInvalid},
+		{NewSalesDiscount(DiscountTypePercentage, 101, "Motivo"), ErrDiscountPercentageInvalid},
+		{NewSalesDiscount(DiscountTypeFixed, 5, "   "), ErrDiscountReasonRequired},
+		{NewSalesDiscount(DiscountTypeFixed, 5, strings.Repeat("a", 2001)), ErrDiscountReasonLengthInvalid},
+	}
+	for _, c := range cases {
+		if err := c.discount.Validate(); err != c.err {
+			t.Fatalf("expected %v for %+v, got %v", c.err, c.discount, err)
+		}
+	}
+}
+
+func TestSalesDiscountGetAmount(t *testing.T) {
+	percentage := NewSalesDiscount(DiscountTypePercentage, 12.5, "Motivo")
+	if amount := percentage.GetAmount(33.33); amount != 4.17 {
+		t.Fatalf("expected 4.17, got %v", amount)
+	}
+	fixed := NewSalesDiscount(DiscountTypeFixed, 7.5, "Motivo")
+	if amount := fixed.GetAmount(100); amount != 7.5 {
+		t.Fatalf("expected 7.5, got %v", amount)
+	}
+	empty := SalesDiscount{}
+	if amount := empty.GetAmount(100); amount != 0 {
+		t.Fatalf("expected no discount, got %v", amount)
+	}
+}
+
+func TestSalesApplyDiscounts(t *testing.T) {
+	sale := Sales{
+		Items: []SalesItem{
+			{Sku: Sku{Id: 1, Price: 50}, Quantity: 2, Discount: NewSalesDiscount(DiscountTypePercentage, 10, "Avaria")},
+			{Sku: Sku{Id: 2, Price: 10}, Quantity: 1},
+			{Sku: Sku{Id: 3, Price: 20}, Quantity: 1},
+		},
+		Discount: NewSalesDiscount(DiscountTypeFixed, 10, "Compra grande"),
+	}
+	sale.ApplyDiscounts()
+
+	if sale.Items[0].DiscountAmount != 10 {
+		t.Fatalf("expected item discount 10, got %v", sale.Items[0].DiscountAmount)
+	}
+	if sale.Items[0].SaleDiscountAmount != 7.5 || sale.Items[1].SaleDiscountAmount != 0.83 || sale.Items[2].SaleDiscountAmount != 1.67 {
+		t.Fatalf("unexpected sale discount split: %+v", sale.Items)
+	}
+	if sale.GetGrossTotal() != 130 || sale.GetDiscountTotal() != 20 || sale.GetSaleDiscountAmount() != 10 || sale.GetTotal() != 110 {
+		t.Fatalf("unexpected totals: gross %v discount %v total %v", sale.GetGrossTotal(), sale.GetDiscountTotal(), sale.GetTotal())
+	}
+}
+
+func TestSalesValidateSaleUsesDiscountedTotal(t *testing.T) {
+	due := time.Now().Add(48 * time.Hour)
+	newSale := func(value float64) Sales {
+		return Sales{
+			Items:    []SalesItem{{Sku: Sku{Id: 1, Price: 10, Quantity: 5}, Quantity: 2}},
+			Discount: NewSalesDiscount(DiscountTypePercentage, 10, "Cliente fiel"),
+			Payments: []SalesPayment{{PaymentType: PaymentTypeCash, Dates: []SalesPaymentDates{{DueDate: due, InstallmentNumber: 1, InstallmentValue: value}}}},
+		}
+	}
+
+	sale := newSale(18)
+	sale.ApplyDiscounts()
+	if err := sale.ValidateSale(); err != nil {
+		t.Fatalf("expected discounted payment to be valid, got %v", err)
+	}
+
+	sale = newSale(20)
+	sale.ApplyDiscounts()
+	if err := sale.ValidateSale(); err == nil || !strings.Contains(err.Error(), ErrPaymentValueIsOverTotal.Error()) {
+		t.Fatalf("expected over payment error, got %v", err)
+	}
+}
+
+func TestSalesValidateSaleDiscountErrors(t *testing.T) {
+	sale := Sales{Items: []SalesItem{{Sku: Sku{Id: 1, Price: 10, Quantity: 5}, Quantity: 1, Discount: NewSalesDiscount(DiscountTypeFixed, 11, "Motivo")}}}
+	if err := sale.ValidateSale(); err == nil || !strings.HasPrefix(err.Error(), ErrDiscountOverTotal.Error()) {
+		t.Fatalf("expected item over total error, got %v", err)
+	}
+
+	sale = Sales{
+		Items:    []SalesItem{{Sku: Sku{Id: 1, Price: 10, Quantity: 5}, Quantity: 1}},
+		Discount: NewSalesDiscount(DiscountTypeFixed, 11, "Motivo"),
+	}
+	if err := sale.ValidateSale(); err == nil || !strings.HasPrefix(err.Error(), ErrDiscountOverTotal.Error()) {
+		t.Fatalf("expected sale over total error, got %v", err)
+	}
+
+	sale.Discount = NewSalesDiscount(DiscountTypeFixed, 5, "")
+	if err := sale.ValidateSale(); err != ErrDiscountReasonRequired {
+		t.Fatalf("expected reason error, got %v", err)
+	}
+}
+
+func TestSalesValidateDiscountLimit(t *testing.T) {
+	sale := Sales{Items: []SalesItem{{Sku: Sku{Price: 100}, Quantity: 1, DiscountAmount: 10}}}
+
+	if err := sale.ValidateDiscountLimit(NewDiscountRule(10)); err != nil {
+		t.Fatalf("expected discount within limit, got %v", err)
+	}
+	if err := sale.ValidateDiscountLimit(NewDiscountRule(5)); err == nil || !strings.HasPrefix(err.Error(), ErrDiscountOverResellerLimit.Error()) {
+		t.Fatalf("expected limit error, got %v", err)
+	}
+}
+
+func TestDiscountRuleValidate(t *testing.T) {
+	rule := NewDiscountRule(15)
+	if err := rule.Validate(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	rule = NewDiscountRule(120)
+	if err := rule.Validate(); err != ErrDiscountRuleMaxPercentageInvalid {
+		t.Fatalf("expected percentage error, got %v", err)
+	}
+}
+
+func TestSalesItemWithQuantity(t *testing.T) {
+	item := SalesItem{Sku: Sku{Price: 10}, Quantity: 4, DiscountAmount: 4, SaleDiscountAmount: 2}
+	half := item.WithQuantity(2)
+	if half.Quantity != 2 || half.DiscountAmount != 2 || half.SaleDiscountAmount != 1 || half.GetTotal() != 17 {
+		t.Fatalf("unexpected item: %+v", half)
+	}
+	if item.Quantity != 4 {
+		t.Fatalf("expected original item to be kept")
+	}
+}
+
+func TestGetItemsOutputDiscountedValues(t *testing.T) {
+	item := GetItemsOutput{Quantity: 4, UnitPrice: 10, DiscountAmount: 4, SaleDiscountAmount: 2}
+	if item.GetTotal() != 34 || item.GetNetUnitPrice() != 8.5 {
+		t.Fatalf("unexpected values: total %v unit %v", item.GetTotal(), item.GetNetUnitPrice())
+	}
+	copied := item.ToSalesItem()
+	if copied.DiscountAmount != 4 || copied.SaleDiscountAmount != 2 || copied.UnitPrice != 10 {
+		t.Fatalf("unexpected copy: %+v", copied)
+	}
+}
@@ -0,0 +1,87 @@
+package domain
+
+import (
+	"errors"
+	"math"
+	"time"
+)
+
+const SalesRenegotiationMaxInstallments = 48
+
+var (
+	ErrRenegotiationNotAllowed          = errors.New("Apenas administradores ou o vendedor da venda podem renegociá-la")
+	ErrRenegotiationNoOpenInstallments  = errors.New("Não há parcelas de crediário em aberto para renegociar")
+	ErrRenegotiationInstallmentsInvalid = errors.New("Quantidade de parcelas da renegociação deve ser entre 1 e 48")
+	ErrRenegotiationFirstDueDateInvalid = errors.New("A data do primeiro vencimento deve ser a partir de hoje")
+	ErrRenegotiationPercentageInvalid   = errors.New("Percentual de juros ou desconto inválido")
+	ErrRenegotiationInterestAndDiscount = errors.New("Informe apenas juros ou desconto na renegociação")
+)
+
+type SalesRenegotiation struct {
+	Id                 int64
+	RenegotiationDate  time.Time
+	Installments       int
+	FirstDueDate       time.Time
+	InterestPercentage float64
+	DiscountPercentage float64
+	OpenValue          float64
+}
+
+func NewSalesRenegotiation(openValue float64, installments int, firstDueDate time.Time, interestPercentage float64, discountPercentage float64) SalesRenegotiation {
+	return SalesRenegotiation{
+		RenegotiationDate:  time.Now(),
+		Installments:       installments,
+		FirstDueDate:       firstDueDate,
+		InterestPercentage: interestPercentage,
+		DiscountPercentage: discountPercentage,
+		OpenValue:          math.Round(openValue*100) / 100,
+	}
+}
+
+func (r *SalesRenegotiation) Validate() error {
+	if r.OpenValue <= 0 {
+		return ErrRenegotiationNoOpenInstallments
+	}
+	if r.Installments < 1 || r.Installments > SalesRenegotiationMaxInstallments {
+		return ErrRenegotiationInstallmentsInvalid
+	}
+	today := time.Now()
+	if r.FirstDueDate.IsZero() || r.FirstDueDate.Before(time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, today.Location())) {
+		return ErrRenegotiationFirstDueDateInvalid
+	}
+	if r.InterestPercentage < 0 || r.DiscountPercentage < 0 || r.DiscountPercentage >= 100 {
+		return ErrRenegotiationPercentageInvalid
+	}
+	if r.InterestPercentage > 0 && r.DiscountPercentage > 0 {
+		return ErrRenegotiationInterestAndDiscount
+	}
+	return nil
+}
+
+func (r *SalesRenegotiation) GetInterestValue() float64 {
+	return math.Round(r.OpenValue*r.InterestPercentage) / 100
+}
+
+func (r *SalesRenegotiation) GetDiscountValue() float64 {
+	return math.Round(r.OpenValue*r.DiscountPercentage) / 100
+}
+
+// GetAdjustmentValue retorna quanto a renegociação acrescenta (juros) ou abate
+// (desconto) do valor da venda.
+func (r *SalesRenegotiation) GetAdjustmentValue() float64 {
+	return math.Round((r.GetInterestValue()-r.GetDiscountValue())*100) / 100
+}
+
+// GetTotal retorna o valor do novo plano de pagamento, já com juros ou desconto.
+func (r *SalesRenegotiation) GetTotal() float64 {
+	return math.Round((r.OpenValue+r.GetInterestValue()-r.GetDiscountValue())*100) / 100
+}
+
+// GetDueDates retorna os vencimentos mensais do novo plano a partir do primeiro vencimento.
+func (r *SalesRenegotiation) GetDueDates() []time.Time {
+	dates := make([]time.Time, r.Installments)
+	for i := range dates {
+		dates[i] = r.FirstDueDate.AddDate(0, i, 0)
+	}
+	return dates
+}
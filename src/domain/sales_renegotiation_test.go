@@ -0,0 +1,56 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSalesRenegotiationValidate(t *testing.T) {
+	tomorrow := time.Now().AddDate(0, 0, 1)
+
+	cases := map[string]struct {
+		renegotiation SalesRenegotiation
+		expected      error
+	}{
+		"no open value":         {NewSalesRenegotiation(0, 2, tomorrow, 0, 0), ErrRenegotiationNoOpenInstallments},
+		"no installments":       {NewSalesRenegotiation(100, 0, tomorrow, 0, 0), ErrRenegotiationInstallmentsInvalid},
+		"too many installments": {NewSalesRenegotiation(100, 49, tomorrow, 0, 0), ErrRenegotiationInstallmentsInvalid},
+		"past due date":         {NewSalesRenegotiation(100, 2, time.Now().AddDate(0, 0, -1), 0, 0), ErrRenegotiationFirstDueDateInvalid},
+		"missing due date":      {NewSalesRenegotiation(100, 2, time.Time{}, 0, 0), ErrRenegotiationFirstDueDateInvalid},
+		"negative interest":     {NewSalesRenegotiation(100, 2, tomorrow, -1, 0), ErrRenegotiationPercentageInvalid},
+		"full discount":         {NewSalesRenegotiation(100, 2, tomorrow, 0, 100), ErrRenegotiationPercentageInvalid},
+		"interest and discount": {NewSalesRenegotiation(100, 2, tomorrow, 2, 5), ErrRenegotiationInterestAndDiscount},
+		"valid today":           {NewSalesRenegotiation(100, 2, time.Now(), 0, 0), nil},
+		"valid with interest":   {NewSalesRenegotiation(100, 2, tomorrow, 2, 0), nil},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if err := tc.renegotiation.Validate(); err != tc.expected {
+				t.Fatalf("expected %v, got %v", tc.expected, err)
+			}
+		})
+	}
+}
+
+func TestSalesRenegotiationGetTotal(t *testing.T) {
+	withInterest := NewSalesRenegotiation(150.5, 3, time.Now(), 2.5, 0)
+	if withInterest.GetInterestValue() != 3.76 || withInterest.GetTotal() != 154.26 {
+		t.Fatalf("unexpected interest total: %v %v", withInterest.GetInterestValue(), withInterest.GetTotal())
+	}
+
+	withDiscount := NewSalesRenegotiation(200, 3, time.Now(), 0, 10)
+	if withDiscount.GetDiscountValue() != 20 || withDiscount.GetTotal() != 180 {
+		t.Fatalf("unexpected discount total: %v %v", withDiscount.GetDiscountValue(), withDiscount.GetTotal())
+	}
+}
+
+func TestSalesRenegotiationGetDueDates(t *testing.T) {
+	first := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+	renegotiation := NewSalesRenegotiation(100, 3, first, 0, 0)
+
+	dates := renegotiation.GetDueDates()
+	if len(dates) != 3 || !dates[0].Equal(first) || !dates[2].Equal(time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("unexpected due dates: %v", dates)
+	}
+}
@@ -3,19 +3,52 @@ package domain
 import (
 	"context"
 	"database/sql"
+	"math"
 	"time"
 )
 
+type SalesSort string
+
+const (
+	SalesSortDate     SalesSort = "date"
+	SalesSortTotal    SalesSort = "total"
+	SalesSortCustomer SalesSort = "customer"
+)
+
 type GetSalesInput struct {
 	InitialDate   *time.Time
 	FinalDate     *time.Time
 	UserId        []int64
 	CustomerId    []int64
 	PaymentStatus *PaymentStatus
+	ProductId     []int64
+	SkuId         []int64
+	CategoryId    []int64
+	PaymentType   []PaymentType
+	HasReturns    *bool
+	MinValue      *float64
+	MaxValue      *float64
+	Search        *string
+	Sort          SalesSort
+	Descending    bool
+	// Limit zero retorna todas as vendas filtradas.
+	Limit  int
+	Offset int
+}
+
+// GetSalesTotalsOutput agrega todas as vendas filtradas, independente da
+// página retornada.
+type GetSalesTotalsOutput struct {
+	TotalSales    int64
+	TotalItems    float64
+	TotalValue    float64
+	ReceivedValue float64
+	FutureRevenue float64
 }
 
 type GetSalesItemOutput struct {
 	Id            int
+	Code          string
 	Date          string
 	SellerName    string
 	CustomerName  string
@@ -36,17 +69,29 @@ type GetSaleByIdOutput struct {
 	ReceivedValue float64
 	FutureRevenue float64
 	PaymentStatus PaymentStatus
+	Cancellation  *GetSalesCancellationOutput
+	Discount      SalesDiscount
+	DiscountValue float64
+	CashReceived  float64
+	ChangeAmount  float64
+}
+
+type GetSalesCancellationOutput struct {
+	CancelDate  time.Time
+	Reason      string
+	CancelledBy string
 }
 
 type SaleWithVersionOutput struct {
-	Id            int64
-	Code          string
-	Date          time.Time
-	UserId        int64
-	CustomerId    int64
-	CustomerName  string
-	LastVersion   int
-	SalesVersionId int64
+	Id               int64
+	Code             string
+	Date             time.Time
+	UserId           int64
+	CustomerId       int64
+	CustomerName     string
+	LastVersion      int
+	SalesVersionId   int64
+	AdjustmentAmount float64
 }
 
 type GetSalesPaymentOutput struct {
@@ -57,13 +102,66 @@ type GetSalesPaymentOutput struct {
 	PaidDate          *time.Time
 	PaymentStatus     PaymentStatus
 	PaymentType       PaymentType
+	ReceivedValue     float64
+	LastReceiptDate   *time.Time
+	FineValue         float64
+	InterestValue     float64
+	CardBrand         CardBrand
+	CardFeeValue      float64
+	SettlementDate    *time.Time
+}
+
+// GetNetValue é o valor da parcela descontada a taxa do cartão.
+func (p *GetSalesPaymentOutput) GetNetValue() float64 {
+	return math.Round((p.InstallmentValue-p.CardFeeValue)*100) / 100
 }
 
 type GetItemsOutput struct {
-	Sku        Sku
-	Quantity   float64
-	UnitPrice  float64
-	TotalValue float64
+	Sku                Sku
+	Quantity           float64
+	UnitPrice          float64
+	TotalValue         float64
+	Discount           SalesDiscount
+	DiscountAmount     float64
+	SaleDiscountAmount float64
+	UnitCost           *float64
+}
+
+// ToSalesItem copia o item de uma versão da venda, com preço e descontos
+// originais, para ser gravado em uma nova versão.
+func (o GetItemsOutput) ToSalesItem() SalesItem {
+	return SalesItem{
+		Sku:                o.Sku,
+		Quantity:           o.Quantity,
+		UnitPrice:          o.UnitPrice,
+		Discount:           o.Discount,
+		DiscountAmount:     o.DiscountAmount,
+		SaleDiscountAmount: o.SaleDiscountAmount,
+		UnitCost:           o.UnitCost,
+	}
+}
+
+// GetNetUnitPrice retorna o preço por unidade já descontado, usado como
+// valor de devolução do item.
+func (o GetItemsOutput) GetNetUnitPrice() float64 {
+	if o.Quantity == 0 {
+		return o.UnitPrice
+	}
+	return (o.UnitPrice*o.Quantity - o.DiscountAmount - o.SaleDiscountAmount) / o.Quantity
+}
+
+func (o GetItemsOutput) GetTotal() float64 {
+	return math.Round((o.UnitPrice*o.Quantity-o.DiscountAmount-o.SaleDiscountAmount)*100) / 100
+}
+
+// GetGrossMargin retorna o valor líquido do item menos o seu custo no momento
+// da venda. Sem custo registrado não há margem a calcular.
+func (o GetItemsOutput) GetGrossMargin() *float64 {
+	if o.UnitCost == nil {
+		return nil
+	}
+	margin := math.Round((o.GetTotal()-*o.UnitCost*o.Quantity)*100) / 100
+	return &margin
 }
 
 type GetSalesReturnOutput struct {
@@ -80,26 +178,59 @@ type GetSalesReturnItemOutput struct {
 	UnitPrice float64
 }
 
+type GetSalesVersionOutput struct {
+	Id           int64
+	Version      int
+	Date         time.Time
+	Type         SalesVersionType
+	CustomerId   int64
+	CustomerName string
+	CreatedBy    string
+}
+
+type GetSalesRenegotiationOutput struct {
+	Id                 int64
+	RenegotiationDate  time.Time
+	Installments       int
+	FirstDueDate       time.Time
+	OpenValue          float64
+	InterestPercentage float64
+	DiscountPercentage float64
+	TotalValue         float64
+	CreatedBy          string
+}
+
 type SalesRepository interface {
 	CreateSale(ctx context.Context, tx *sql.Tx, sale Sales) (int64, error)
-	CreateSaleVersion(ctx context.Context, tx *sql.Tx, saleId int64, version int, date time.Time) (int64, error)
+	CreateSaleVersion(ctx context.Context, tx *sql.Tx, saleId int64, version int, date time.Time, adjustmentAmount float64) (int64, error)
 	CreateManySaleItem(ctx context.Context, tx *sql.Tx, sale Sales, saleItems []SalesItem) ([]int64, error)
 	CreatePayment(ctx context.Context, tx *sql.Tx, sale Sales, payment SalesPayment) (int64, error)
 	CreateManyPaymentDates(ctx context.Context, tx *sql.Tx, payment SalesPayment, paymentDates []SalesPaymentDates) ([]int64, error)
 	CreateSalesReturn(ctx context.Context, tx *sql.Tx, saleId int64, fromSalesVersionId int64, toSalesVersionId int64, salesReturn SalesReturn, createdByUserId int64) (int64, error)
 	CreateSalesReturnItems(ctx context.Context, tx *sql.Tx, salesReturnId int64, items []SalesReturnItem) ([]int64, error)
+	CreateSalesCancellation(ctx context.Context, tx *sql.Tx, saleId int64, fromSalesVersionId int64, toSalesVersionId int64, cancellation SalesCancellation, createdByUserId int64) (int64, error)
+	CreateSalesExchange(ctx context.Context, tx *sql.Tx, saleId int64, fromSalesVersionId int64, toSalesVersionId int64, salesReturnId int64, exchange SalesExchange, createdByUserId int64) (int64, error)
+	CreateSalesEdit(ctx context.Context, tx *sql.Tx, saleId int64, fromSalesVersionId int64, toSalesVersionId int64, editDate time.Time, createdByUserId int64) (int64, error)
+	CreateSalesRenegotiation(ctx context.Context, tx *sql.Tx, saleId int64, fromSalesVersionId int64, toSalesVersionId int64, renegotiation SalesRenegotiation, createdByUserId int64) (int64, error)
 	UpdateSaleLastVersion(ctx context.Context, tx *sql.Tx, saleId int64, version int) error
+	UpdateSaleCustomer(ctx context.Context, tx *sql.Tx, saleId int64, customerId int64) error
+	UpdateSaleDiscountAmount(ctx context.Context, tx *sql.Tx, saleId int64, discountAmount float64) error
 	CancelPaymentDatesBySaleVersionId(ctx context.Context, tx *sql.Tx, saleVersionId int64) error
 	GetSaleByIdForUpdate(ctx context.Context, tx *sql.Tx, id int64) (SaleWithVersionOutput, error)
 	GetSaleVersionIdBySaleIdAndVersion(ctx context.Context, tx *sql.Tx, saleId int64, version int) (int64, error)
 	GetPaymentsBySaleVersionId(ctx context.Context, saleVersionId int64) ([]GetSalesPaymentOutput, error)
 	GetItemsBySaleVersionId(ctx context.Context, saleVersionId int64) ([]GetItemsOutput, error)
 	GetReturnsBySaleId(ctx context.Context, id int64) ([]GetSalesReturnOutput, error)
+	GetVersionsBySaleId(ctx context.Context, id int64) ([]GetSalesVersionOutput, error)
+	GetRenegotiationsBySaleId(ctx context.Context, id int64) ([]GetSalesRenegotiationOutput, error)
 	GetSales(ctx context.Context, input GetSalesInput) ([]GetSalesItemOutput, error)
+	GetSalesTotals(ctx context.Context, input GetSalesInput) (GetSalesTotalsOutput, error)
 	GetSaleById(ctx context.Context, id int64) (GetSaleByIdOutput, error)
 	GetPaymentsBySaleId(ctx context.Context, id int64) ([]GetSalesPaymentOutput, error)
 	GetItemsBySaleId(ctx context.Context, id int64) ([]GetItemsOutput, error)
 	ChangePaymentStatus(ctx context.Context, id int64, status PaymentStatus) (int64, error)
-	ChangePaymentDate(ctx context.Context, id int64, date *time.Time) (int64, error)
 	GetPaymentDatesBySaleIdAndPaymentDateId(ctx context.Context, id int64, paymentDateId int64) (SalesPaymentDates, error)
+	CreatePaymentReceipt(ctx context.Context, tx *sql.Tx, receipt PaymentReceipt) (int64, error)
+	SettlePaymentDate(ctx context.Context, tx *sql.Tx, paymentDateId int64, paidDate time.Time, lateFee LateFee) error
+	GetPaymentReceiptsByPaymentDateId(ctx context.Context, paymentDateId int64) ([]PaymentReceipt, error)
 }
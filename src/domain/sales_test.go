@@ -71,6 +71,57 @@ func TestSalesValidateSaleOverPayment(t *testing.T) {
 	}
 }
 
+func TestSalesValidateSaleCashReceivedAmount(t *testing.T) {
+	sku := Sku{Id: 1, Price: 43.5, Quantity: 5}
+	item := SalesItem{Sku: sku, Quantity: 2}
+	payment := NewSalesPayment(PaymentTypeCash)
+	payment.AppendNewSalesDate(time.Now(), 1, 87, true)
+	payment.SetReceivedAmount(100)
+	sale := Sales{Items: []SalesItem{item}, Payments: []SalesPayment{payment}}
+
+	if err := sale.ValidateSale(); err != nil {
+		t.Fatalf("expected sale to be valid, got %v", err)
+	}
+	if sale.Payments[0].Dates[0].InstallmentValue != 87 || sale.Payments[0].ChangeAmount != 13 || sale.GetChangeAmount() != 13 {
+		t.Fatalf("expected installment to keep the amount due and record change: %+v", sale.Payments[0])
+	}
+
+	sale.Payments[0].SetReceivedAmount(50)
+	if err := sale.ValidateSale(); err == nil || !strings.Contains(err.Error(), ErrReceivedAmountInsufficient.Error()) {
+		t.Fatalf("expected insufficient received amount error, got %v", err)
+	}
+
+	sale.Payments[0].PaymentType = PaymentTypePix
+	sale.Payments[0].SetReceivedAmount(100)
+	if err := sale.ValidateSale(); err != ErrReceivedAmountOnlyCash {
+		t.Fatalf("expected cash only error, got %v", err)
+	}
+}
+
+func TestSalesValidateSaleCustomerCreditInsufficient(t *testing.T) {
+	sku := Sku{Id: 1, Price: 10, Quantity: 5}
+	item := SalesItem{Sku: sku, Quantity: 2}
+	payment := SalesPayment{PaymentType: PaymentTypeReturn, Dates: []SalesPaymentDates{{
+		DueDate:           time.Now(),
+		InstallmentNumber: 1,
+		InstallmentValue:  20,
+		Status:            PaymentStatusPaid,
+	}}}
+	sale := Sales{Customer: Customer{CreditBalance: 15}, Items: []SalesItem{item}, Payments: []SalesPayment{payment}}
+
+	if err := sale.ValidateSale(); err == nil || !strings.Contains(err.Error(), ErrCustomerCreditInsufficient.Error()) {
+		t.Fatalf("expected insufficient credit error, got %v", err)
+	}
+
+	sale.Customer.CreditBalance = 20
+	if err := sale.ValidateSale(); err != nil {
+		t.Fatalf("expected sale paid with credit to be valid, got %v", err)
+	}
+	if total := sale.GetCustomerCreditTotal(); total != 20 {
+		t.Fatalf("expected credit total 20, got %v", total)
+	}
+}
+
 func TestSalesValidateSaleDuplicatePaymentTypes(t *testing.T) {
 	due := time.Now().Add(48 * time.Hour)
 	payment := SalesPayment{PaymentType: PaymentTypeCash, Dates: []SalesPaymentDates{{
@@ -366,3 +417,108 @@ func TestSalesReturnValidate(t *testing.T) {
 		t.Fatalf("expected not found item error")
 	}
 }
+
+func TestSalesCancellationValidate(t *testing.T) {
+	saleItems := []SalesItem{
+		{Sku: Sku{Id: 1, Product: Product{Name: "Produto"}}, Quantity: 3},
+	}
+
+	valid := NewSalesCancellation("  Venda lançada errada ")
+	if valid.Reason != "Venda lançada errada" || valid.CancelDate.IsZero() {
+		t.Fatalf("unexpected cancellation: %+v", valid)
+	}
+	if err := valid.Validate(saleItems); err != nil {
+		t.Fatalf("expected valid cancellation, got %v", err)
+	}
+
+	missingReason := NewSalesCancellation("   ")
+	if err := missingReason.Validate(saleItems); err != ErrCancelReasonRequired {
+		t.Fatalf("expected reason required error, got %v", err)
+	}
+
+	longReason := NewSalesCancellation(strings.Repeat("a", 2001))
+	if err := longReason.Validate(saleItems); err != ErrCancelReasonLengthInvalid {
+		t.Fatalf("expected reason length error, got %v", err)
+	}
+
+	if err := valid.Validate(nil); err != ErrSaleAlreadyCancelled {
+		t.Fatalf("expected already cancelled error, got %v", err)
+	}
+}
+
+func TestSalesValidateEdit(t *testing.T) {
+	sku := Sku{Id: 1, Price: 10, Quantity: 5, Product: Product{Name: "Produto"}}
+	payment := NewSalesPayment(PaymentTypePix)
+	payment.AppendNewSalesDate(time.Now(), 1, 15, false)
+	sale := Sales{
+		Items:    []SalesItem{NewSalesItem(sku, 3)},
+		Payments: []SalesPayment{payment},
+	}
+
+	if err := sale.ValidateEdit(15); err != nil {
+		t.Fatalf("expected valid edit, got %v", err)
+	}
+	if err := sale.ValidateEdit(10); err == nil || !strings.Contains(err.Error(), ErrPaymentValueIsMissing.Error()) {
+		t.Fatalf("expected missing value error, got %v", err)
+	}
+	if err := sale.ValidateEdit(20); err == nil || !strings.Contains(err.Error(), ErrPaymentValueIsOverTotal.Error()) {
+		t.Fatalf("expected over total error, got %v", err)
+	}
+
+	overpaid := Sales{Items: []SalesItem{NewSalesItem(sku, 1)}}
+	if err := overpaid.ValidateEdit(30); err != nil {
+		t.Fatalf("expected edit below paid value to be valid, got %v", err)
+	}
+
+	empty := Sales{}
+	if err := empty.ValidateEdit(0); err != ErrEditItemsRequired {
+		t.Fatalf("expected items required error, got %v", err)
+	}
+
+	withoutStock := Sales{Items: []SalesItem{NewSalesItem(sku, 6)}}
+	if err := withoutStock.ValidateEdit(60); err == nil || !strings.Contains(err.Error(), ErrQuantityNotValid.Error()) {
+		t.Fatalf("expected quantity error, got %v", err)
+	}
+}
+
+func TestSalesExchangeValidate(t *testing.T) {
+	oldSku := Sku{Id: 1, Price: 10, Product: Product{Name: "Camisa M"}}
+	newSku := Sku{Id: 2, Price: 15, Quantity: 3, Product: Product{Name: "Camisa G"}}
+	saleItems := []SalesItem{{Sku: oldSku, Quantity: 2, UnitPrice: 10}}
+	salesReturn := NewSalesReturn("Cliente", "Troca de tamanho", []SalesReturnItem{{Sku: oldSku, Quantity: 1, UnitPrice: 10}})
+
+	payment := NewSalesPayment(PaymentTypePix)
+	payment.AppendNewSalesDate(time.Now(), 1, 5, false)
+	exchange := NewSalesExchange(salesReturn, []SalesItem{NewSalesItem(newSku, 1)}, []SalesPayment{payment})
+	if exchange.ExchangeDate != salesReturn.ReturnDate || exchange.GetDifference() != 5 || salesReturn.GetTotal() != 10 {
+		t.Fatalf("unexpected exchange: %+v", exchange)
+	}
+	if err := exchange.Validate(saleItems); err != nil {
+		t.Fatalf("expected valid exchange, got %v", err)
+	}
+
+	withoutPayment := NewSalesExchange(salesReturn, []SalesItem{NewSalesItem(newSku, 1)}, nil)
+	if err := withoutPayment.Validate(saleItems); err == nil || !strings.Contains(err.Error(), ErrPaymentValueIsMissing.Error()) {
+		t.Fatalf("expected missing payment error, got %v", err)
+	}
+
+	cheaper := NewSalesExchange(NewSalesReturn("Cliente", "Troca", []SalesReturnItem{{Sku: oldSku, Quantity: 2, UnitPrice: 10}}), []SalesItem{NewSalesItem(newSku, 1)}, nil)
+	if err := cheaper.Validate(saleItems); err != nil || cheaper.GetDifference() != -5 {
+		t.Fatalf("expected cheaper exchange without payment, got %v (%v)", err, cheaper.GetDifference())
+	}
+
+	noItems := NewSalesExchange(salesReturn, nil, nil)
+	if err := noItems.Validate(saleItems); err != ErrExchangeItemsRequired {
+		t.Fatalf("expected items required error, got %v", err)
+	}
+
+	sameSku := NewSalesExchange(salesReturn, []SalesItem{NewSalesItem(Sku{Id: 1, Price: 10, Quantity: 5}, 1)}, nil)
+	if err := sameSku.Validate(saleItems); err == nil || !strings.Contains(err.Error(), ErrExchangeItemAlreadyInSale.Error()) {
+		t.Fatalf("expected item already in sale error, got %v", err)
+	}
+
+	invalidReturn := NewSalesExchange(NewSalesReturn("Cliente", "", salesReturn.Items), []SalesItem{NewSalesItem(newSku, 1)}, nil)
+	if err := invalidReturn.Validate(saleItems); err != ErrReturnReasonRequired {
+		t.Fatalf("expected return validation error, got %v", err)
+	}
+}
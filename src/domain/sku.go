@@ -1,5 +1,7 @@
 package domain
 
+import "math"
+
 type Sku struct {
 	Id       int64
 	Code     string
@@ -24,3 +26,16 @@ func (s *Sku) GetName() string {
 	}
 	return skuName
 }
+
+// ApplyEntryCost recalcula o custo médio ponderado do SKU com a entrada de
+// quantity unidades a unitCost, considerando stockQuantity em estoque antes da
+// entrada. Sem custo anterior ou sem saldo, vale o custo da entrada.
+func (s *Sku) ApplyEntryCost(stockQuantity float64, quantity float64, unitCost float64) float64 {
+	cost := unitCost
+	if s.Cost != nil && stockQuantity > 0 && stockQuantity+quantity > 0 {
+		cost = (stockQuantity*(*s.Cost) + quantity*unitCost) / (stockQuantity + quantity)
+	}
+	cost = math.Round(cost*10000) / 10000
+	s.Cost = &cost
+	return cost
+}
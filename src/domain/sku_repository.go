@@ -1,6 +1,9 @@
 package domain
 
-import "context"
+import (
+	"context"
+	"database/sql"
+)
 
 type GetSkusInput struct {
 	SellerId *float64
@@ -11,8 +14,10 @@ type SkuRepository interface {
 	CreateMany(ctx context.Context, skus []Sku, productId int64) ([]int64, error)
 	GetByProductId(ctx context.Context, productId int64) ([]Sku, error)
 	Update(ctx context.Context, sku Sku) error
+	UpdateCost(ctx context.Context, tx *sql.Tx, skuId int64, cost float64) error
 	GetById(ctx context.Context, id int64) (Sku, error)
 	GetByManyIds(ctx context.Context, ids []int64) ([]Sku, error)
+	GetByCode(ctx context.Context, code string) (Sku, error)
 	GetAll(ctx context.Context, input GetSkusInput) ([]Sku, error)
 	Inactivate(ctx context.Context, id int64) error
 }
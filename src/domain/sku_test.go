@@ -12,3 +12,20 @@ func TestSkuGetName(t *testing.T) {
 		t.Fatalf("expected empty name, got %s", name)
 	}
 }
+
+func TestSkuApplyEntryCost(t *testing.T) {
+	cost := 10.0
+	sku := &Sku{Cost: &cost}
+	if got := sku.ApplyEntryCost(10, 5, 16); got != 12 || *sku.Cost != 12 {
+		t.Fatalf("expected weighted average 12, got %v", got)
+	}
+
+	if got := (&Sku{}).ApplyEntryCost(3, 2, 7.5); got != 7.5 {
+		t.Fatalf("expected entry cost without previous cost, got %v", got)
+	}
+
+	previous := 20.0
+	if got := (&Sku{Cost: &previous}).ApplyEntryCost(0, 4, 8); got != 8 {
+		t.Fatalf("expected entry cost without stock, got %v", got)
+	}
+}
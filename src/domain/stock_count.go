@@ -0,0 +1,157 @@
+package domain
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/bncunha/erp-api/src/infrastructure/ksuid"
+)
+
+type StockCountStatus string
+
+const (
+	StockCountStatusOpen     StockCountStatus = "OPEN"
+	StockCountStatusPosted   StockCountStatus = "POSTED"
+	StockCountStatusCanceled StockCountStatus = "CANCELED"
+)
+
+var (
+	ErrStockCountNotFound        = errors.New("Contagem de estoque não encontrada")
+	ErrStockCountAlreadyOpen     = errors.New("Já existe uma contagem em aberto para este estoque")
+	ErrStockCountNotOpen         = errors.New("A contagem de estoque não está em aberto")
+	ErrStockCountQuantityInvalid = errors.New("Quantidade contada não pode ser negativa")
+	ErrStockCountNotesLength     = errors.New("Observação da contagem deve ter no máximo 2000 caracteres")
+)
+
+// StockCount é a contagem física (inventário/balanço) de um estoque. Ao abrir,
+// guarda o saldo de cada item naquele momento; as quantidades contadas são
+// comparadas com esse saldo e as diferenças são lançadas como ajuste ao postar.
+type StockCount struct {
+	Id            int64
+	Code          string
+	InventoryId   int64
+	InventoryType InventoryType
+	UserName      *string
+	Status        StockCountStatus
+	Notes         string
+	OpenedAt      time.Time
+	PostedAt      *time.Time
+	Items         []StockCountItem
+}
+
+type StockCountItem struct {
+	Sku              Sku
+	ExpectedQuantity float64
+	CountedQuantity  *float64
+}
+
+func NewStockCount(inventory Inventory, items []InventoryItem, openedAt time.Time) StockCount {
+	countItems := make([]StockCountItem, 0, len(items))
+	for _, item := range items {
+		countItems = append(countItems, StockCountItem{Sku: item.Sku, ExpectedQuantity: item.Quantity})
+	}
+	return StockCount{
+		Code:          "I-" + ksuid.New().String(),
+		InventoryId:   inventory.Id,
+		InventoryType: inventory.Type,
+		Status:        StockCountStatusOpen,
+		OpenedAt:      openedAt,
+		Items:         countItems,
+	}
+}
+
+func (s *StockCount) Validate() error {
+	if len([]rune(s.Notes)) > 2000 {
+		return ErrStockCountNotesLength
+	}
+	return nil
+}
+
+func (s *StockCount) IsOpen() bool {
+	return s.Status == StockCountStatusOpen
+}
+
+// FindSkuByCode procura entre os itens da contagem o SKU com o código lido,
+// permitindo contar pelo código de barras sem consultar o cadastro.
+func (s *StockCount) FindSkuByCode(code string) (Sku, bool) {
+	code = strings.TrimSpace(code)
+	for _, item := range s.Items {
+		if item.Sku.Code == code {
+			return item.Sku, true
+		}
+	}
+	return Sku{}, false
+}
+
+// Count registra a quantidade contada de um SKU. Com accumulate, a quantidade
+// é somada à já contada, como na leitura item a item do código de barras. Um
+// SKU que não estava no estoque ao abrir a contagem entra com saldo esperado 0.
+func (s *StockCount) Count(sku Sku, quantity float64, accumulate bool) (StockCountItem, error) {
+	if !s.IsOpen() {
+		return StockCountItem{}, ErrStockCountNotOpen
+	}
+	if quantity < 0 {
+		return StockCountItem{}, ErrStockCountQuantityInvalid
+	}
+	index := -1
+	for i := range s.Items {
+		if s.Items[i].Sku.Id == sku.Id {
+			index = i
+			break
+		}
+	}
+	if index < 0 {
+		s.Items = append(s.Items, StockCountItem{Sku: sku})
+		index = len(s.Items) - 1
+	}
+	item := &s.Items[index]
+	if accumulate && item.CountedQuantity != nil {
+		quantity += *item.CountedQuantity
+	}
+	item.CountedQuantity = &quantity
+	return *item, nil
+}
+
+// GetVariances retorna os itens contados cuja quantidade difere do saldo
+// esperado. Itens não contados não geram ajuste.
+func (s *StockCount) GetVariances() []StockCountItem {
+	variances := make([]StockCountItem, 0)
+	for _, item := range s.Items {
+		if item.GetVariance() != 0 {
+			variances = append(variances, item)
+		}
+	}
+	return variances
+}
+
+func (s *StockCount) Post(postedAt time.Time) error {
+	if !s.IsOpen() {
+		return ErrStockCountNotOpen
+	}
+	s.Status = StockCountStatusPosted
+	s.PostedAt = &postedAt
+	return nil
+}
+
+func (s *StockCount) Cancel() error {
+	if !s.IsOpen() {
+		return ErrStockCountNotOpen
+	}
+	s.Status = StockCountStatusCanceled
+	return nil
+}
+
+func (i *StockCountItem) IsCounted() bool {
+	return i.CountedQuantity != nil
+}
+
+// GetVariance retorna a diferença entre o contado e o esperado: positiva para
+// sobra e negativa para falta.
+func (i *StockCountItem) GetVariance() float64 {
+	if i.CountedQuantity == nil {
+		return 0
+	}
+	return math.Round((*i.CountedQuantity-i.ExpectedQuantity)*1000) / 1000
+}
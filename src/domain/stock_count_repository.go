@@ -0,0 +1,34 @@
+package domain
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+type GetStockCountsInput struct {
+	InventoryId *int64
+	Status      *StockCountStatus
+}
+
+type GetStockCountsOutput struct {
+	Id            int64
+	Code          string
+	InventoryId   int64
+	InventoryType InventoryType
+	UserName      *string
+	Status        StockCountStatus
+	OpenedAt      time.Time
+	PostedAt      *time.Time
+	TotalItems    int64
+	CountedItems  int64
+	VarianceItems int64
+}
+
+type StockCountRepository interface {
+	Create(ctx context.Context, tx *sql.Tx, count StockCount, openedByUserId int64) (int64, error)
+	GetById(ctx context.Context, id int64) (StockCount, error)
+	GetAll(ctx context.Context, input GetStockCountsInput) ([]GetStockCountsOutput, error)
+	SaveItems(ctx context.Context, countId int64, items []StockCountItem) error
+	UpdateStatus(ctx context.Context, tx *sql.Tx, count StockCount) error
+}
@@ -0,0 +1,58 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewStockCountSnapshotsItems(t *testing.T) {
+	count := NewStockCount(Inventory{Id: 2, Type: InventoryTypePrimary}, []InventoryItem{{Sku: Sku{Id: 1}, Quantity: 4}}, time.Now())
+	if count.Code == "" || count.Status != StockCountStatusOpen || count.InventoryId != 2 {
+		t.Fatalf("unexpected count: %+v", count)
+	}
+	if len(count.Items) != 1 || count.Items[0].ExpectedQuantity != 4 || count.Items[0].IsCounted() {
+		t.Fatalf("unexpected items: %+v", count.Items)
+	}
+}
+
+func TestStockCountCount(t *testing.T) {
+	count := NewStockCount(Inventory{Id: 2}, []InventoryItem{{Sku: Sku{Id: 1, Code: "A"}, Quantity: 4}}, time.Now())
+
+	if _, err := count.Count(Sku{Id: 1}, -1, false); err != ErrStockCountQuantityInvalid {
+		t.Fatalf("expected invalid quantity, got %v", err)
+	}
+	sku, ok := count.FindSkuByCode(" A ")
+	if !ok {
+		t.Fatalf("expected sku found by code")
+	}
+	count.Count(sku, 1, true)
+	item, _ := count.Count(sku, 2, true)
+	if *item.CountedQuantity != 3 || item.GetVariance() != -1 {
+		t.Fatalf("expected accumulated count, got %+v", item)
+	}
+	item, _ = count.Count(sku, 6, false)
+	if *item.CountedQuantity != 6 || item.GetVariance() != 2 {
+		t.Fatalf("expected replaced count, got %+v", item)
+	}
+
+	item, _ = count.Count(Sku{Id: 5}, 1, false)
+	if item.ExpectedQuantity != 0 || len(count.Items) != 2 {
+		t.Fatalf("expected sku outside snapshot to be added")
+	}
+	if len(count.GetVariances()) != 2 {
+		t.Fatalf("expected two variances")
+	}
+}
+
+func TestStockCountPostAndCancel(t *testing.T) {
+	count := NewStockCount(Inventory{Id: 2}, nil, time.Now())
+	if err := count.Post(time.Now()); err != nil || count.Status != StockCountStatusPosted || count.PostedAt == nil {
+		t.Fatalf("expected count posted")
+	}
+	if err := count.Cancel(); err != ErrStockCountNotOpen {
+		t.Fatalf("expected not open, got %v", err)
+	}
+	if _, err := count.Count(Sku{Id: 1}, 1, false); err != ErrStockCountNotOpen {
+		t.Fatalf("expected not open, got %v", err)
+	}
+}
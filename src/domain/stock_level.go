@@ -0,0 +1,181 @@
+package domain
+
+import (
+	"errors"
+	"math"
+)
+
+type StockReorderSource string
+
+const (
+	StockReorderSourcePurchase StockReorderSource = "PURCHASE"
+	StockReorderSourceTransfer StockReorderSource = "TRANSFER"
+)
+
+const (
+	DefaultStockReorderDays = 30
+	MaxStockReorderDays     = 365
+)
+
+var (
+	ErrStockLevelNotFound      = errors.New("Nível de estoque não encontrado")
+	ErrStockLevelMinInvalid    = errors.New("Estoque mínimo não pode ser negativo")
+	ErrStockLevelMaxInvalid    = errors.New("Estoque máximo deve ser maior ou igual ao mínimo")
+	ErrStockReorderDaysInvalid = errors.New("Período de vendas deve ser entre 1 e 365 dias")
+)
+
+// StockLevel define o estoque mínimo e máximo de um SKU em um estoque. Abaixo
+// do mínimo o item aparece como estoque baixo; o máximo é a quantidade até a
+// qual a reposição é sugerida.
+type StockLevel struct {
+	Id            int64
+	InventoryId   int64
+	InventoryType InventoryType
+	UserName      *string
+	Sku           Sku
+	MinQuantity   float64
+	MaxQuantity   float64
+}
+
+func NewStockLevel(inventoryId int64, skuId int64, minQuantity float64, maxQuantity float64) StockLevel {
+	return StockLevel{
+		InventoryId: inventoryId,
+		Sku:         Sku{Id: skuId},
+		MinQuantity: minQuantity,
+		MaxQuantity: maxQuantity,
+	}
+}
+
+func (l *StockLevel) Validate() error {
+	if l.MinQuantity < 0 {
+		return ErrStockLevelMinInvalid
+	}
+	if l.MaxQuantity < l.MinQuantity {
+		return ErrStockLevelMaxInvalid
+	}
+	return nil
+}
+
+// StockReorderCandidate é um item com nível configurado, com o saldo atual, o
+// saldo do mesmo SKU no estoque principal e o quanto foi vendido no período.
+type StockReorderCandidate struct {
+	Level              StockLevel
+	Quantity           float64
+	PrimaryInventoryId int64
+	PrimaryQuantity    float64
+	SoldQuantity       float64
+}
+
+type StockReorderSuggestion struct {
+	StockReorderCandidate
+	Source            StockReorderSource
+	DailySales        float64
+	NeededQuantity    float64
+	SuggestedQuantity float64
+}
+
+// getNeed calcula a reposição de um item: quando o saldo projetado para o fim
+// do período, descontadas as vendas no ritmo atual e a demanda extra, fica no
+// mínimo ou abaixo dele, sugere repor o suficiente para cobrir as vendas do
+// período acima do mínimo, e ao menos até o máximo.
+func (c *StockReorderCandidate) getNeed(dailySales float64, days int, extraDemand float64) float64 {
+	expectedSales := dailySales * float64(days)
+	if c.Quantity-extraDemand-expectedSales > c.Level.MinQuantity {
+		return 0
+	}
+	target := math.Max(c.Level.MaxQuantity, c.Level.MinQuantity+expectedSales)
+	return math.Max(0, math.Ceil(target+extraDemand-c.Quantity))
+}
+
+// SuggestStockReorder propõe transferências do estoque principal para cada
+// revendedor e compras para o estoque principal. As transferências são
+// limitadas ao saldo do principal e a demanda dos revendedores entra na
+// sugestão de compra do SKU.
+func SuggestStockReorder(candidates []StockReorderCandidate, days int) []StockReorderSuggestion {
+	suggestions := make([]StockReorderSuggestion, 0)
+	transferDemand := make(map[int64]float64)
+	primaryAvailable := make(map[int64]float64)
+	primaryCandidates := make(map[int64]StockReorderCandidate)
+
+	for _, candidate := range candidates {
+		if candidate.Level.InventoryType == InventoryTypePrimary {
+			primaryCandidates[candidate.Level.Sku.Id] = candidate
+			continue
+		}
+		dailySales := candidate.SoldQuantity / float64(days)
+		need := candidate.getNeed(dailySales, days, 0)
+		if need == 0 {
+			continue
+		}
+		skuId := candidate.Level.Sku.Id
+		if _, ok := primaryAvailable[skuId]; !ok {
+			primaryAvailable[skuId] = math.Max(0, candidate.PrimaryQuantity)
+		}
+		suggested := math.Min(need, primaryAvailable[skuId])
+		primaryAvailable[skuId] -= suggested
+		transferDemand[skuId] += need
+		suggestions = append(suggestions, StockReorderSuggestion{
+			StockReorderCandidate: candidate,
+			Source:                StockReorderSourceTransfer,
+			DailySales:            roundDailySales(dailySales),
+			NeededQuantity:        need,
+			SuggestedQuantity:     suggested,
+		})
+	}
+
+	purchases := make([]StockReorderSuggestion, 0)
+	for _, candidate := range candidates {
+		if candidate.Level.InventoryType != InventoryTypePrimary {
+			continue
+		}
+		dailySales := candidate.SoldQuantity / float64(days)
+		need := candidate.getNeed(dailySales, days, transferDemand[candidate.Level.Sku.Id])
+		if need == 0 {
+			continue
+		}
+		purchases = append(purchases, StockReorderSuggestion{
+			StockReorderCandidate: candidate,
+			Source:                StockReorderSourcePurchase,
+			DailySales:            roundDailySales(dailySales),
+			NeededQuantity:        need,
+			SuggestedQuantity:     need,
+		})
+	}
+
+	// SKUs sem nível no estoque principal ainda precisam ser comprados quando
+	// a demanda dos revendedores supera o saldo disponível.
+	for _, suggestion := range suggestions {
+		skuId := suggestion.Level.Sku.Id
+		if _, ok := primaryCandidates[skuId]; ok {
+			continue
+		}
+		shortage := math.Ceil(transferDemand[skuId] - math.Max(0, suggestion.PrimaryQuantity))
+		if shortage <= 0 {
+			continue
+		}
+		primaryCandidates[skuId] = StockReorderCandidate{}
+		purchases = append(purchases, StockReorderSuggestion{
+			StockReorderCandidate: StockReorderCandidate{
+				Level:              StockLevel{InventoryId: suggestion.PrimaryInventoryId, InventoryType: InventoryTypePrimary, Sku: suggestion.Level.Sku},
+				Quantity:           suggestion.PrimaryQuantity,
+				PrimaryInventoryId: suggestion.PrimaryInventoryId,
+				PrimaryQuantity:    suggestion.PrimaryQuantity,
+			},
+			Source:            StockReorderSourcePurchase,
+			NeededQuantity:    shortage,
+			SuggestedQuantity: shortage,
+		})
+	}
+	return append(purchases, suggestions...)
+}
+
+func ValidateStockReorderDays(days int) error {
+	if days < 1 || days > MaxStockReorderDays {
+		return ErrStockReorderDaysInvalid
+	}
+	return nil
+}
+
+func roundDailySales(dailySales float64) float64 {
+	return math.Round(dailySales*100) / 100
+}
@@ -0,0 +1,18 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+type GetStockLevelsInput struct {
+	InventoryId *int64
+	SkuId       *int64
+}
+
+type StockLevelRepository interface {
+	GetAll(ctx context.Context, input GetStockLevelsInput) ([]StockLevel, error)
+	Save(ctx context.Context, level StockLevel) (int64, error)
+	Delete(ctx context.Context, id int64) error
+	GetReorderCandidates(ctx context.Context, salesSince time.Time) ([]StockReorderCandidate, error)
+}
@@ -0,0 +1,87 @@
+package domain
+
+import "testing"
+
+func TestStockLevelValidate(t *testing.T) {
+	cases := []struct {
+		level StockLevel
+		err   error
+	}{
+		{NewStockLevel(1, 1, 2, 5), nil},
+		{NewStockLevel(1, 1, 0, 0), nil},
+		{NewStockLevel(1, 1, -1, 5), ErrStockLevelMinInvalid},
+		{NewStockLevel(1, 1, 5, 2), ErrStockLevelMaxInvalid},
+	}
+	for _, c := range cases {
+		if err := c.level.Validate(); err != c.err {
+			t.Fatalf("expected %v for %+v, got %v", c.err, c.level, err)
+		}
+	}
+}
+
+func TestValidateStockReorderDays(t *testing.T) {
+	if err := ValidateStockReorderDays(0); err != ErrStockReorderDaysInvalid {
+		t.Fatalf("expected invalid days, got %v", err)
+	}
+	if err := ValidateStockReorderDays(MaxStockReorderDays + 1); err != ErrStockReorderDaysInvalid {
+		t.Fatalf("expected invalid days, got %v", err)
+	}
+	if err := ValidateStockReorderDays(DefaultStockReorderDays); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func newReorderCandidate(inventoryId int64, inventoryType InventoryType, skuId int64, quantity, min, max, primaryQuantity, sold float64) StockReorderCandidate {
+	level := NewStockLevel(inventoryId, skuId, min, max)
+	level.InventoryType = inventoryType
+	return StockReorderCandidate{Level: level, Quantity: quantity, PrimaryInventoryId: 1, PrimaryQuantity: primaryQuantity, SoldQuantity: sold}
+}
+
+func TestSuggestStockReorder(t *testing.T) {
+	candidates := []StockReorderCandidate{
+		newReorderCandidate(1, InventoryTypePrimary, 1, 3, 2, 10, 3, 15),
+		newReorderCandidate(2, InventoryTypeReseller, 1, 1, 2, 6, 3, 0),
+		newReorderCandidate(3, InventoryTypeReseller, 1, 0, 1, 4, 3, 0),
+		newReorderCandidate(2, InventoryTypeReseller, 2, 0, 1, 2, 1, 0),
+		newReorderCandidate(3, InventoryTypeReseller, 2, 5, 1, 2, 1, 0),
+	}
+
+	suggestions := SuggestStockReorder(candidates, 30)
+	if len(suggestions) != 5 {
+		t.Fatalf("expected 5 suggestions, got %+v", suggestions)
+	}
+
+	expected := []struct {
+		source      StockReorderSource
+		inventoryId int64
+		skuId       int64
+		needed      float64
+		suggested   float64
+	}{
+		// 0,5 por dia: cobre 15 vendas acima do mínimo e os 9 pedidos pelos revendedores.
+		{StockReorderSourcePurchase, 1, 1, 23, 23},
+		{StockReorderSourcePurchase, 1, 2, 1, 1},
+		{StockReorderSourceTransfer, 2, 1, 5, 3},
+		{StockReorderSourceTransfer, 3, 1, 4, 0},
+		{StockReorderSourceTransfer, 2, 2, 2, 1},
+	}
+	for i, e := range expected {
+		s := suggestions[i]
+		if s.Source != e.source || s.Level.InventoryId != e.inventoryId || s.Level.Sku.Id != e.skuId || s.NeededQuantity != e.needed || s.SuggestedQuantity != e.suggested {
+			t.Fatalf("unexpected suggestion %d: %+v", i, s)
+		}
+	}
+	if suggestions[0].DailySales != 0.5 {
+		t.Fatalf("expected daily sales 0.5, got %v", suggestions[0].DailySales)
+	}
+}
+
+func TestSuggestStockReorderAboveMinimum(t *testing.T) {
+	candidates := []StockReorderCandidate{
+		newReorderCandidate(1, InventoryTypePrimary, 1, 50, 5, 60, 50, 30),
+		newReorderCandidate(2, InventoryTypeReseller, 1, 4, 1, 5, 50, 0),
+	}
+	if suggestions := SuggestStockReorder(candidates, 30); len(suggestions) != 0 {
+		t.Fatalf("expected no suggestions, got %+v", suggestions)
+	}
+}
@@ -0,0 +1,17 @@
+package domain
+
+import "errors"
+
+var (
+	ErrSupplierNotFound      = errors.New("Fornecedor não encontrado")
+	ErrSupplierCnpjDuplicate = errors.New("Já existe um fornecedor cadastrado com este CNPJ")
+)
+
+type Supplier struct {
+	Id          int64
+	Name        string
+	Cnpj        string
+	ContactName string
+	PhoneNumber string
+	Email       string
+}
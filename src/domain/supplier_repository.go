@@ -0,0 +1,11 @@
+package domain
+
+import "context"
+
+type SupplierRepository interface {
+	Create(ctx context.Context, supplier Supplier) (int64, error)
+	Edit(ctx context.Context, supplier Supplier) error
+	GetById(ctx context.Context, id int64) (Supplier, error)
+	GetAll(ctx context.Context) ([]Supplier, error)
+	Inactivate(ctx context.Context, id int64) error
+}
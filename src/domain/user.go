@@ -1,6 +1,14 @@
 package domain
 
-import "strings"
+import (
+	"errors"
+	"strings"
+)
+
+var (
+	ErrUserHasStock           = errors.New("O revendedor ainda possui estoque. Transfira o estoque antes de inativá-lo")
+	ErrUserHasOpenReceivables = errors.New("O revendedor possui valores a receber em aberto")
+)
 
 type Role string
 
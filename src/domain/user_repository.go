@@ -15,6 +15,7 @@ type UserRepository interface {
 	CreateWithTx(ctx context.Context, tx *sql.Tx, user User) (int64, error)
 	Update(ctx context.Context, user User) error
 	Inactivate(ctx context.Context, id int64) error
+	InactivateWithTx(ctx context.Context, tx *sql.Tx, id int64) error
 	GetAll(ctx context.Context, input GetAllUserInput) ([]User, error)
 	GetById(ctx context.Context, id int64) (User, error)
 	UpdatePassword(ctx context.Context, user User, newPassword string) error
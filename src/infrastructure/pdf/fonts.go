@@ -0,0 +1,75 @@
+package pdf
+
+// Larguras (em milésimos do tamanho da fonte) dos caracteres ASCII imprimíveis,
+// de ' ' (32) a '~' (126), conforme as métricas padrão das fontes Helvetica.
+var helveticaWidths = [95]int{
+	278, 278, 355, 556, 556, 889, 667, 191, 333, 333, 389, 584, 278, 333, 278, 278,
+	556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 278, 278, 584, 584, 584, 556,
+	1015, 667, 667, 722, 722, 667, 611, 778, 722, 278, 500, 667, 556, 833, 722, 778,
+	667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 278, 278, 278, 469, 556,
+	333, 556, 556, 500, 556, 556, 278, 556, 556, 222, 222, 500, 222, 833, 556, 556,
+	556, 556, 333, 500, 278, 556, 500, 722, 500, 500, 500, 334, 260, 334, 584,
+}
+
+var helveticaBoldWidths = [95]int{
+	278, 333, 474, 556, 556, 889, 722, 238, 333, 333, 389, 584, 278, 333, 278, 278,
+	556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 333, 333, 584, 584, 584, 611,
+	975, 722, 722, 722, 722, 667, 611, 778, 722, 278, 556, 722, 611, 833, 722, 778,
+	667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 333, 278, 333, 584, 556,
+	333, 556, 611, 556, 611, 556, 333, 611, 611, 278, 278, 556, 278, 889, 611, 611,
+	611, 611, 389, 556, 333, 611, 556, 778, 556, 556, 500, 389, 280, 389, 584,
+}
+
+// winAnsiSpecial mapeia os caracteres fora do Latin-1 que existem na
+// codificação WinAnsi usada pelas fontes padrão do PDF.
+var winAnsiSpecial = map[rune]byte{
+	'€': 0x80, '…': 0x85, '‘': 0x91, '’': 0x92, '“': 0x93, '”': 0x94,
+	'•': 0x95, '–': 0x96, '—': 0x97,
+}
+
+// encode converte o texto para WinAnsi. Caracteres sem representação viram '?'.
+func encode(text string) []byte {
+	out := make([]byte, 0, len(text))
+	for _, r := range text {
+		switch {
+		case r < 0x80 || (r >= 0xA0 && r <= 0xFF):
+			out = append(out, byte(r))
+		default:
+			if b, ok := winAnsiSpecial[r]; ok {
+				out = append(out, b)
+			} else {
+				out = append(out, '?')
+			}
+		}
+	}
+	return out
+}
+
+func charWidth(b byte, bold bool) int {
+	table := helveticaWidths
+	if bold {
+		table = helveticaBoldWidths
+	}
+	switch {
+	case b >= 32 && b <= 126:
+		return table[b-32]
+	case b >= 0xC0 && b <= 0xDE:
+		return 722
+	case b >= 0xDF:
+		if bold {
+			return 611
+		}
+		return 556
+	default:
+		return 556
+	}
+}
+
+// textWidth retorna a largura do texto em pontos.
+func textWidth(text string, size float64, bold bool) float64 {
+	var total int
+	for _, b := range encode(text) {
+		total += charWidth(b, bold)
+	}
+	return float64(total) * size / 1000
+}
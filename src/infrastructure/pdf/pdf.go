@@ -0,0 +1,247 @@
+package pdf
+
+import (
+	"errors"
+	"math"
+	"strings"
+
+	"github.com/bncunha/erp-api/src/application/ports"
+)
+
+const (
+	margin      = 40.0
+	contentSize = pageWidth - margin*2
+	lineHeight  = 1.4
+	titleSize   = 16.0
+	headingSize = 12.0
+	textSize    = 10.0
+	smallSize   = 8.0
+	ruleHeight  = 8.0
+	blankHeight = 6.0
+	maxColumnW  = 110.0
+)
+
+var ErrUnclosedBlock = errors.New("Bloco do modelo de documento não foi fechado")
+
+type elementKind int
+
+const (
+	elementText elementKind = iota
+	elementRule
+	elementBlank
+	elementPageBreak
+)
+
+type element struct {
+	kind    elementKind
+	size    float64
+	bold    bool
+	columns []string
+	dashed  bool
+}
+
+func (e element) height() float64 {
+	switch e.kind {
+	case elementText:
+		return e.size * lineHeight
+	case elementRule:
+		return ruleHeight
+	case elementBlank:
+		return blankHeight
+	default:
+		return 0
+	}
+}
+
+type pdf struct{}
+
+func NewPdf() ports.PdfPort {
+	return &pdf{}
+}
+
+func (p *pdf) Render(markup string) ([]byte, error) {
+	blocks, err := parse(markup)
+	if err != nil {
+		return nil, err
+	}
+
+	w := newWriter()
+	y := pageHeight - margin
+	for _, block := range blocks {
+		if len(block) == 1 && block[0].kind == elementPageBreak {
+			w.addPage()
+			y = pageHeight - margin
+			continue
+		}
+		// Blocos que não cabem no restante da página começam na próxima,
+		// a menos que sejam maiores que uma página inteira.
+		if blockHeight(block) > y-margin && y < pageHeight-margin {
+			w.addPage()
+			y = pageHeight - margin
+		}
+		for _, e := range block {
+			if e.height() > y-margin {
+				w.addPage()
+				y = pageHeight - margin
+			}
+			y -= e.height()
+			draw(w, e, y)
+		}
+	}
+	return w.bytes()
+}
+
+func blockHeight(block []element) float64 {
+	var total float64
+	for _, e := range block {
+		total += e.height()
+	}
+	return total
+}
+
+func draw(w *writer, e element, y float64) {
+	switch e.kind {
+	case elementRule:
+		w.line(margin, y+ruleHeight/2, pageWidth-margin, y+ruleHeight/2, e.dashed)
+	case elementText:
+		baseline := y + e.size*(lineHeight-1)
+		if len(e.columns) == 1 {
+			w.text(margin, baseline, e.size, e.bold, e.columns[0])
+			return
+		}
+		widths := columnWidths(len(e.columns))
+		x := margin
+		for i, column := range e.columns {
+			column = truncate(column, widths[i]-4, e.size, e.bold)
+			if i == 0 {
+				w.text(x, baseline, e.size, e.bold, column)
+			} else {
+				w.text(x+widths[i]-textWidth(column, e.size, e.bold), baseline, e.size, e.bold, column)
+			}
+			x += widths[i]
+		}
+	}
+}
+
+// columnWidths reserva larguras iguais às colunas alinhadas à direita e deixa
+// o restante da linha para a primeira coluna.
+func columnWidths(n int) []float64 {
+	widths := make([]float64, n)
+	other := math.Min(contentSize/2/float64(n-1), maxColumnW)
+	widths[0] = contentSize - other*float64(n-1)
+	for i := 1; i < n; i++ {
+		widths[i] = other
+	}
+	return widths
+}
+
+func truncate(text string, width float64, size float64, bold bool) string {
+	if textWidth(text, size, bold) <= width {
+		return text
+	}
+	runes := []rune(text)
+	for len(runes) > 0 && textWidth(string(runes)+"…", size, bold) > width {
+		runes = runes[:len(runes)-1]
+	}
+	return string(runes) + "…"
+}
+
+func wrap(text string, width float64, size float64, bold bool) []string {
+	words := strings.Fields(text)
+	if len(words) == 0 {
+		return []string{""}
+	}
+	lines := make([]string, 0)
+	current := words[0]
+	for _, word := range words[1:] {
+		if textWidth(current+" "+word, size, bold) > width {
+			lines = append(lines, current)
+			current = word
+			continue
+		}
+		current += " " + word
+	}
+	return append(lines, truncate(current, width, size, bold))
+}
+
+// parse converte a marcação em blocos de elementos. Linhas fora de um
+// [bloco] formam um bloco cada uma.
+func parse(markup string) ([][]element, error) {
+	blocks := make([][]element, 0)
+	var open []element
+	inBlock := false
+
+	add := func(elements ...element) {
+		if inBlock {
+			open = append(open, elements...)
+			return
+		}
+		for _, e := range elements {
+			blocks = append(blocks, []element{e})
+		}
+	}
+
+	for _, raw := range strings.Split(strings.ReplaceAll(markup, "\r\n", "\n"), "\n") {
+		line := strings.TrimSpace(raw)
+		switch {
+		case line == "[bloco]":
+			if inBlock {
+				blocks = append(blocks, open)
+			}
+			inBlock = true
+			open = make([]element, 0)
+		case line == "[/bloco]":
+			if inBlock {
+				blocks = append(blocks, open)
+			}
+			inBlock = false
+			open = nil
+		case line == "[pagina]":
+			if inBlock {
+				return nil, ErrUnclosedBlock
+			}
+			blocks = append(blocks, []element{{kind: elementPageBreak}})
+		case line == "":
+			add(element{kind: elementBlank})
+		case line == "---":
+			add(element{kind: elementRule})
+		case line == "- - -":
+			add(element{kind: elementRule, dashed: true})
+		default:
+			add(parseText(line)...)
+		}
+	}
+	if inBlock {
+		return nil, ErrUnclosedBlock
+	}
+	return blocks, nil
+}
+
+func parseText(line string) []element {
+	e := element{kind: elementText, size: textSize}
+	switch {
+	case strings.HasPrefix(line, "## "):
+		e.size, e.bold, line = headingSize, true, line[3:]
+	case strings.HasPrefix(line, "# "):
+		e.size, e.bold, line = titleSize, true, line[2:]
+	case strings.HasPrefix(line, "> "):
+		e.size, line = smallSize, line[2:]
+	}
+
+	if strings.Contains(line, "|") {
+		columns := strings.Split(line, "|")
+		for i := range columns {
+			columns[i] = strings.TrimSpace(columns[i])
+		}
+		e.columns = columns
+		return []element{e}
+	}
+
+	elements := make([]element, 0)
+	for _, wrapped := range wrap(line, contentSize, e.size, e.bold) {
+		text := e
+		text.columns = []string{wrapped}
+		elements = append(elements, text)
+	}
+	return elements
+}
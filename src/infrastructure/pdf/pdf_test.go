@@ -0,0 +1,106 @@
+package pdf
+
+import (
+	"bytes"
+	"compress/zlib"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func pageContents(t *testing.T, document []byte) []string {
+	t.Helper()
+	streams := regexp.MustCompile(`(?s)stream\n(.*?)\nendstream`).FindAllSubmatch(document, -1)
+	contents := make([]string, 0, len(streams))
+	for _, stream := range streams {
+		reader, err := zlib.NewReader(bytes.NewReader(stream[1]))
+		if err != nil {
+			t.Fatalf("invalid stream: %v", err)
+		}
+		content, err := io.ReadAll(reader)
+		if err != nil {
+			t.Fatalf("invalid stream: %v", err)
+		}
+		contents = append(contents, string(content))
+	}
+	return contents
+}
+
+func TestRenderBuildsValidDocument(t *testing.T) {
+	document, err := NewPdf().Render("# Loja (Centro)\n## Item | Qtd | Total\nCamisa | 2 | R$ 10,00\n---\n> Emitido às 10:00")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !bytes.HasPrefix(document, []byte("%PDF-1.4")) || !bytes.HasSuffix(document, []byte("%%EOF\n")) {
+		t.Fatalf("unexpected document envelope")
+	}
+
+	startxref := regexp.MustCompile(`startxref\n(\d+)\n`).FindSubmatch(document)
+	offset, _ := strconv.Atoi(string(startxref[1]))
+	if !bytes.HasPrefix(document[offset:], []byte("xref\n0 7\n")) {
+		t.Fatalf("expected xref table at offset %d", offset)
+	}
+	objectOffset, _ := strconv.Atoi(string(document[offset+len("xref\n0 7\n0000000000 65535 f \n") : offset+len("xref\n0 7\n0000000000 65535 f \n")+10]))
+	if !bytes.HasPrefix(document[objectOffset:], []byte("1 0 obj")) {
+		t.Fatalf("expected first object at offset %d", objectOffset)
+	}
+
+	contents := pageContents(t, document)
+	if len(contents) != 1 {
+		t.Fatalf("expected one page, got %d", len(contents))
+	}
+	for _, expected := range []string{"(Loja \\(Centro\\)) Tj", "/F2 12.00 Tf", "(Camisa) Tj", "(Emitido \xe0s 10:00) Tj", " l S"} {
+		if !strings.Contains(contents[0], expected) {
+			t.Fatalf("expected %q in page content:\n%s", expected, contents[0])
+		}
+	}
+}
+
+func TestRenderPageBreaksAndBlocks(t *testing.T) {
+	var markup strings.Builder
+	for i := 0; i < 60; i++ {
+		markup.WriteString("linha\n")
+	}
+	markup.WriteString("[bloco]\n## Parcela 1\nValor | R$ 10,00\n[/bloco]\n[pagina]\núltima")
+
+	document, err := NewPdf().Render(markup.String())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	contents := pageContents(t, document)
+	if len(contents) != 3 {
+		t.Fatalf("expected three pages, got %d", len(contents))
+	}
+	if !strings.Contains(contents[1], "(Parcela 1) Tj") || !strings.Contains(contents[1], "(R$ 10,00) Tj") {
+		t.Fatalf("expected block to be kept on the second page:\n%s", contents[1])
+	}
+	if !strings.Contains(contents[2], "(\xfaltima) Tj") {
+		t.Fatalf("expected page break before last line")
+	}
+}
+
+func TestRenderUnclosedBlock(t *testing.T) {
+	if _, err := NewPdf().Render("[bloco]\ntexto"); err != ErrUnclosedBlock {
+		t.Fatalf("expected unclosed block error, got %v", err)
+	}
+}
+
+func TestWrapAndTruncate(t *testing.T) {
+	lines := wrap(strings.Repeat("palavra ", 40), 200, textSize, false)
+	if len(lines) < 2 {
+		t.Fatalf("expected long text to wrap, got %v", lines)
+	}
+	for _, line := range lines {
+		if textWidth(line, textSize, false) > 200 {
+			t.Fatalf("line wider than limit: %q", line)
+		}
+	}
+	if truncated := truncate(strings.Repeat("a", 100), 50, textSize, false); !strings.HasSuffix(truncated, "…") || textWidth(truncated, textSize, false) > 50 {
+		t.Fatalf("unexpected truncated text: %q", truncated)
+	}
+	if string(encode("ação € ✓")) != "a\xe7\xe3o \x80 ?" {
+		t.Fatalf("unexpected encoding: %q", encode("ação € ✓"))
+	}
+}
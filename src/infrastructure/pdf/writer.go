@@ -0,0 +1,102 @@
+package pdf
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"strings"
+)
+
+const (
+	pageWidth  = 595.28
+	pageHeight = 841.89
+)
+
+// writer monta um PDF com páginas A4 e as fontes Helvetica padrão, que não
+// precisam ser embutidas no arquivo.
+type writer struct {
+	pages   []*bytes.Buffer
+	current *bytes.Buffer
+}
+
+func newWriter() *writer {
+	w := &writer{}
+	w.addPage()
+	return w
+}
+
+func (w *writer) addPage() {
+	w.current = &bytes.Buffer{}
+	w.pages = append(w.pages, w.current)
+}
+
+func (w *writer) text(x float64, y float64, size float64, bold bool, text string) {
+	font := "F1"
+	if bold {
+		font = "F2"
+	}
+	fmt.Fprintf(w.current, "BT /%s %.2f Tf %.2f %.2f Td (%s) Tj ET\n", font, size, x, y, escape(encode(text)))
+}
+
+func (w *writer) line(x1 float64, y1 float64, x2 float64, y2 float64, dashed bool) {
+	if dashed {
+		fmt.Fprintf(w.current, "[3 3] 0 d %.2f %.2f m %.2f %.2f l S [] 0 d\n", x1, y1, x2, y2)
+		return
+	}
+	fmt.Fprintf(w.current, "%.2f %.2f m %.2f %.2f l S\n", x1, y1, x2, y2)
+}
+
+func (w *writer) bytes() ([]byte, error) {
+	var out bytes.Buffer
+	offsets := make([]int, 0)
+	object := func(body string) {
+		offsets = append(offsets, out.Len())
+		fmt.Fprintf(&out, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	out.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+
+	// Objetos fixos: 1 catálogo, 2 árvore de páginas, 3 e 4 fontes. Cada página
+	// ocupa dois objetos em seguida: a página e o seu conteúdo.
+	kids := make([]string, len(w.pages))
+	for i := range w.pages {
+		kids[i] = fmt.Sprintf("%d 0 R", 5+i*2)
+	}
+	object("<< /Type /Catalog /Pages 2 0 R >>")
+	object(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(w.pages)))
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>")
+
+	for i, page := range w.pages {
+		object(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %.2f %.2f] /Resources << /Font << /F1 3 0 R /F2 4 0 R >> >> /Contents %d 0 R >>", pageWidth, pageHeight, 6+i*2))
+
+		var compressed bytes.Buffer
+		zw := zlib.NewWriter(&compressed)
+		if _, err := zw.Write(page.Bytes()); err != nil {
+			return nil, err
+		}
+		if err := zw.Close(); err != nil {
+			return nil, err
+		}
+		object(fmt.Sprintf("<< /Length %d /Filter /FlateDecode >>\nstream\n%s\nendstream", compressed.Len(), compressed.String()))
+	}
+
+	xref := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&out, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+	return out.Bytes(), nil
+}
+
+func escape(text []byte) string {
+	var out strings.Builder
+	for _, b := range text {
+		if b == '(' || b == ')' || b == '\\' {
+			out.WriteByte('\\')
+		}
+		out.WriteByte(b)
+	}
+	return out.String()
+}
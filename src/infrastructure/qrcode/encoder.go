@@ -0,0 +1,415 @@
+package qrcode
+
+import "errors"
+
+var ErrContentTooLong = errors.New("Conteúdo muito longo para gerar o QR Code")
+
+// blockLayout descreve os blocos de uma versão no nível de correção M: os
+// blocos do primeiro grupo têm shortData codewords de dados e os demais um a
+// mais.
+type blockLayout struct {
+	ecPerBlock  int
+	shortBlocks int
+	shortData   int
+	longBlocks  int
+}
+
+// Nível de correção M (~15%), versões 1 a 20. Um BR Code do PIX cabe com folga
+// nessas versões.
+var layoutsM = []blockLayout{
+	{},
+	{10, 1, 16, 0}, {16, 1, 28, 0}, {26, 1, 44, 0}, {18, 2, 32, 0}, {24, 2, 43, 0},
+	{16, 4, 27, 0}, {18, 4, 31, 0}, {22, 2, 38, 2}, {22, 3, 36, 2}, {26, 4, 43, 1},
+	{30, 1, 50, 4}, {22, 6, 36, 2}, {22, 8, 37, 1}, {24, 4, 40, 5}, {24, 5, 41, 5},
+	{28, 7, 45, 3}, {28, 10, 46, 1}, {26, 9, 43, 4}, {26, 3, 44, 11}, {26, 3, 41, 13},
+}
+
+var alignmentPositions = [][]int{
+	{}, {},
+	{6, 18}, {6, 22}, {6, 26}, {6, 30}, {6, 34},
+	{6, 22, 38}, {6, 24, 42}, {6, 26, 46}, {6, 28, 50}, {6, 30, 54}, {6, 32, 58}, {6, 34, 62},
+	{6, 26, 46, 66}, {6, 26, 48, 70}, {6, 26, 50, 74}, {6, 30, 54, 78}, {6, 30, 56, 82}, {6, 30, 58, 86}, {6, 34, 62, 90},
+}
+
+func (l blockLayout) dataCodewords() int {
+	return l.shortBlocks*l.shortData + l.longBlocks*(l.shortData+1)
+}
+
+// symbol é a matriz de módulos do QR Code; dark[y][x] indica um módulo escuro.
+type symbol struct {
+	version    int
+	size       int
+	dark       [][]bool
+	isFunction [][]bool
+}
+
+// encode gera o símbolo em modo byte com correção de erro M.
+func encode(content []byte) (*symbol, error) {
+	version := 0
+	for v := 1; v < len(layoutsM); v++ {
+		if 4+countBits(v)+len(content)*8 <= layoutsM[v].dataCodewords()*8 {
+			version = v
+			break
+		}
+	}
+	if version == 0 {
+		return nil, ErrContentTooLong
+	}
+
+	codewords := addErrorCorrection(encodeData(content, version), layoutsM[version])
+
+	s := newSymbol(version)
+	s.drawFunctionPatterns()
+	s.drawCodewords(codewords)
+
+	bestMask, bestPenalty := 0, -1
+	for mask := 0; mask < 8; mask++ {
+		s.applyMask(mask)
+		s.drawFormatBits(mask)
+		if penalty := s.penalty(); bestPenalty < 0 || penalty < bestPenalty {
+			bestMask, bestPenalty = mask, penalty
+		}
+		s.applyMask(mask)
+	}
+	s.applyMask(bestMask)
+	s.drawFormatBits(bestMask)
+	return s, nil
+}
+
+func countBits(version int) int {
+	if version <= 9 {
+		return 8
+	}
+	return 16
+}
+
+// encodeData monta os codewords de dados: modo byte, tamanho, conteúdo,
+// terminador e bytes de preenchimento.
+func encodeData(content []byte, version int) []byte {
+	capacity := layoutsM[version].dataCodewords() * 8
+	bits := make([]bool, 0, capacity)
+	appendBits := func(value int, length int) {
+		for i := length - 1; i >= 0; i-- {
+			bits = append(bits, (value>>i)&1 == 1)
+		}
+	}
+
+	appendBits(0x4, 4)
+	appendBits(len(content), countBits(version))
+	for _, b := range content {
+		appendBits(int(b), 8)
+	}
+	terminator := capacity - len(bits)
+	if terminator > 4 {
+		terminator = 4
+	}
+	appendBits(0, terminator)
+	if len(bits)%8 != 0 {
+		appendBits(0, 8-len(bits)%8)
+	}
+	for pad := 0xEC; len(bits) < capacity; pad ^= 0xEC ^ 0x11 {
+		appendBits(pad, 8)
+	}
+
+	data := make([]byte, len(bits)/8)
+	for i, bit := range bits {
+		if bit {
+			data[i/8] |= 1 << (7 - i%8)
+		}
+	}
+	return data
+}
+
+// addErrorCorrection divide os dados em blocos, calcula a correção de erro de
+// cada um e intercala os codewords na ordem em que são gravados no símbolo.
+func addErrorCorrection(data []byte, layout blockLayout) []byte {
+	blocks := make([][]byte, 0, layout.shortBlocks+layout.longBlocks)
+	ecBlocks := make([][]byte, 0, cap(blocks))
+	offset := 0
+	for i := 0; i < layout.shortBlocks+layout.longBlocks; i++ {
+		length := layout.shortData
+		if i >= layout.shortBlocks {
+			length++
+		}
+		block := data[offset : offset+length]
+		offset += length
+		blocks = append(blocks, block)
+		ecBlocks = append(ecBlocks, errorCorrection(block, layout.ecPerBlock))
+	}
+
+	result := make([]byte, 0, len(data)+len(ecBlocks)*layout.ecPerBlock)
+	for i := 0; i <= layout.shortData; i++ {
+		for _, block := range blocks {
+			if i < len(block) {
+				result = append(result, block[i])
+			}
+		}
+	}
+	for i := 0; i < layout.ecPerBlock; i++ {
+		for _, block := range ecBlocks {
+			result = append(result, block[i])
+		}
+	}
+	return result
+}
+
+func newSymbol(version int) *symbol {
+	size := version*4 + 17
+	s := &symbol{version: version, size: size, dark: make([][]bool, size), isFunction: make([][]bool, size)}
+	for i := 0; i < size; i++ {
+		s.dark[i] = make([]bool, size)
+		s.isFunction[i] = make([]bool, size)
+	}
+	return s
+}
+
+func (s *symbol) setFunction(x int, y int, dark bool) {
+	s.dark[y][x] = dark
+	s.isFunction[y][x] = true
+}
+
+func (s *symbol) drawFunctionPatterns() {
+	for i := 0; i < s.size; i++ {
+		s.setFunction(6, i, i%2 == 0)
+		s.setFunction(i, 6, i%2 == 0)
+	}
+
+	s.drawFinderPattern(3, 3)
+	s.drawFinderPattern(s.size-4, 3)
+	s.drawFinderPattern(3, s.size-4)
+
+	positions := alignmentPositions[s.version]
+	last := len(positions) - 1
+	for i, x := range positions {
+		for j, y := range positions {
+			// Os cantos coincidem com os padrões localizadores.
+			if (i == 0 && j == 0) || (i == 0 && j == last) || (i == last && j == 0) {
+				continue
+			}
+			s.drawAlignmentPattern(x, y)
+		}
+	}
+
+	// Reserva a área do formato; os bits corretos são gravados após escolher
+	// a máscara.
+	s.drawFormatBits(0)
+	s.drawVersion()
+}
+
+func (s *symbol) drawFinderPattern(cx int, cy int) {
+	for dy := -4; dy <= 4; dy++ {
+		for dx := -4; dx <= 4; dx++ {
+			x, y := cx+dx, cy+dy
+			if x < 0 || x >= s.size || y < 0 || y >= s.size {
+				continue
+			}
+			distance := max(abs(dx), abs(dy))
+			s.setFunction(x, y, distance != 2 && distance != 4)
+		}
+	}
+}
+
+func (s *symbol) drawAlignmentPattern(cx int, cy int) {
+	for dy := -2; dy <= 2; dy++ {
+		for dx := -2; dx <= 2; dx++ {
+			s.setFunction(cx+dx, cy+dy, max(abs(dx), abs(dy)) != 1)
+		}
+	}
+}
+
+// drawFormatBits grava o nível de correção (M = 00) e a máscara, protegidos
+// por BCH(15,5), nas duas cópias da área de formato.
+func (s *symbol) drawFormatBits(mask int) {
+	data := mask
+	remainder := data
+	for i := 0; i < 10; i++ {
+		remainder = (remainder << 1) ^ ((remainder >> 9) * 0x537)
+	}
+	bits := (data<<10 | remainder) ^ 0x5412
+
+	for i := 0; i <= 5; i++ {
+		s.setFunction(8, i, bit(bits, i))
+	}
+	s.setFunction(8, 7, bit(bits, 6))
+	s.setFunction(8, 8, bit(bits, 7))
+	s.setFunction(7, 8, bit(bits, 8))
+	for i := 9; i < 15; i++ {
+		s.setFunction(14-i, 8, bit(bits, i))
+	}
+
+	for i := 0; i < 8; i++ {
+		s.setFunction(s.size-1-i, 8, bit(bits, i))
+	}
+	for i := 8; i < 15; i++ {
+		s.setFunction(8, s.size-15+i, bit(bits, i))
+	}
+	s.setFunction(8, s.size-8, true)
+}
+
+// drawVersion grava a versão, protegida por BCH(18,6), a partir da versão 7.
+func (s *symbol) drawVersion() {
+	if s.version < 7 {
+		return
+	}
+	remainder := s.version
+	for i := 0; i < 12; i++ {
+		remainder = (remainder << 1) ^ ((remainder >> 11) * 0x1F25)
+	}
+	bits := s.version<<12 | remainder
+	for i := 0; i < 18; i++ {
+		a, b := s.size-11+i%3, i/3
+		s.setFunction(a, b, bit(bits, i))
+		s.setFunction(b, a, bit(bits, i))
+	}
+}
+
+// drawCodewords percorre o símbolo em colunas duplas, da direita para a
+// esquerda e alternando para cima e para baixo, ignorando os módulos de
+// função. Os módulos que sobram ficam claros (bits de resto).
+func (s *symbol) drawCodewords(codewords []byte) {
+	i := 0
+	for right := s.size - 1; right >= 1; right -= 2 {
+		if right == 6 {
+			right = 5
+		}
+		for vertical := 0; vertical < s.size; vertical++ {
+			for j := 0; j < 2; j++ {
+				x := right - j
+				y := vertical
+				if (right+1)&2 == 0 {
+					y = s.size - 1 - vertical
+				}
+				if !s.isFunction[y][x] && i < len(codewords)*8 {
+					s.dark[y][x] = bit(int(codewords[i>>3]), 7-(i&7))
+					i++
+				}
+			}
+		}
+	}
+}
+
+func (s *symbol) applyMask(mask int) {
+	for y := 0; y < s.size; y++ {
+		for x := 0; x < s.size; x++ {
+			if s.isFunction[y][x] {
+				continue
+			}
+			var invert bool
+			switch mask {
+			case 0:
+				invert = (x+y)%2 == 0
+			case 1:
+				invert = y%2 == 0
+			case 2:
+				invert = x%3 == 0
+			case 3:
+				invert = (x+y)%3 == 0
+			case 4:
+				invert = (x/3+y/2)%2 == 0
+			case 5:
+				invert = x*y%2+x*y%3 == 0
+			case 6:
+				invert = (x*y%2+x*y%3)%2 == 0
+			case 7:
+				invert = ((x+y)%2+x*y%3)%2 == 0
+			}
+			if invert {
+				s.dark[y][x] = !s.dark[y][x]
+			}
+		}
+	}
+}
+
+// penalty aplica as quatro regras de penalidade da especificação; a máscara
+// com menor penalidade gera o símbolo mais fácil de ler.
+func (s *symbol) penalty() int {
+	result := 0
+	line := make([]bool, s.size)
+	for _, vertical := range []bool{false, true} {
+		for i := 0; i < s.size; i++ {
+			for j := 0; j < s.size; j++ {
+				if vertical {
+					line[j] = s.dark[j][i]
+				} else {
+					line[j] = s.dark[i][j]
+				}
+			}
+			result += linePenalty(line)
+		}
+	}
+
+	dark := 0
+	for y := 0; y < s.size; y++ {
+		for x := 0; x < s.size; x++ {
+			if s.dark[y][x] {
+				dark++
+			}
+			if x+1 < s.size && y+1 < s.size {
+				color := s.dark[y][x]
+				if color == s.dark[y][x+1] && color == s.dark[y+1][x] && color == s.dark[y+1][x+1] {
+					result += 3
+				}
+			}
+		}
+	}
+	total := s.size * s.size
+	result += abs(dark*100/total-50) / 5 * 10
+	return result
+}
+
+// linePenalty calcula as regras de sequências de mesma cor e de padrões
+// parecidos com os localizadores (1:1:3:1:1 com quatro módulos claros).
+func linePenalty(line []bool) int {
+	result := 0
+	run := 1
+	for i := 1; i <= len(line); i++ {
+		if i < len(line) && line[i] == line[i-1] {
+			run++
+			continue
+		}
+		if run >= 5 {
+			result += 3 + run - 5
+		}
+		run = 1
+	}
+
+	finder := []bool{true, false, true, true, true, false, true}
+	for i := 0; i+len(finder) <= len(line); i++ {
+		matches := true
+		for j, dark := range finder {
+			if line[i+j] != dark {
+				matches = false
+				break
+			}
+		}
+		if !matches {
+			continue
+		}
+		if lightRun(line, i-4, i) || lightRun(line, i+len(finder), i+len(finder)+4) {
+			result += 40
+		}
+	}
+	return result
+}
+
+// lightRun indica se os módulos de from até to (exclusivo) são claros. Módulos
+// fora do símbolo contam como claros, pois fazem parte da margem.
+func lightRun(line []bool, from int, to int) bool {
+	for i := from; i < to; i++ {
+		if i >= 0 && i < len(line) && line[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func bit(value int, i int) bool {
+	return (value>>i)&1 == 1
+}
+
+func abs(value int) int {
+	if value < 0 {
+		return -value
+	}
+	return value
+}
@@ -0,0 +1,53 @@
+package qrcode
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+
+	"github.com/bncunha/erp-api/src/application/ports"
+)
+
+// quietZone é a margem clara, em módulos, exigida ao redor do símbolo.
+const quietZone = 4
+
+type qrCode struct{}
+
+func NewQrCode() ports.QrCodePort {
+	return &qrCode{}
+}
+
+func (q *qrCode) Encode(content string, size int) ([]byte, error) {
+	s, err := encode([]byte(content))
+	if err != nil {
+		return nil, err
+	}
+
+	modules := s.size + quietZone*2
+	scale := size / modules
+	if scale < 1 {
+		scale = 1
+	}
+
+	palette := color.Palette{color.White, color.Black}
+	img := image.NewPaletted(image.Rect(0, 0, modules*scale, modules*scale), palette)
+	for y := 0; y < s.size; y++ {
+		for x := 0; x < s.size; x++ {
+			if !s.dark[y][x] {
+				continue
+			}
+			for dy := 0; dy < scale; dy++ {
+				for dx := 0; dx < scale; dx++ {
+					img.SetColorIndex((x+quietZone)*scale+dx, (y+quietZone)*scale+dy, 1)
+				}
+			}
+		}
+	}
+
+	var out bytes.Buffer
+	if err := png.Encode(&out, img); err != nil {
+		return nil, err
+	}
+	return out.Bytes(), nil
+}
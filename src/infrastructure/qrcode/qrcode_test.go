@@ -0,0 +1,182 @@
+package qrcode
+
+import (
+	"bytes"
+	"image/png"
+	"strings"
+	"testing"
+)
+
+func TestErrorCorrection(t *testing.T) {
+	// Exemplo "HELLO WORLD" 1-M da especificação.
+	data := []byte{32, 91, 11, 120, 209, 114, 220, 77, 67, 64, 236, 17, 236, 17, 236, 17}
+	expected := []byte{196, 35, 39, 119, 235, 215, 231, 226, 93, 23}
+	if got := errorCorrection(data, 10); !bytes.Equal(got, expected) {
+		t.Fatalf("unexpected error correction: %v", got)
+	}
+}
+
+func TestFormatAndVersionBits(t *testing.T) {
+	s := newSymbol(7)
+	s.drawFormatBits(1)
+	// M com máscara 1: 101000100100101, lido do bit 14 ao 0 na coluna 8.
+	expected := "101000100100101"
+	var got strings.Builder
+	for i := 14; i >= 8; i-- {
+		got.WriteString(map[bool]string{true: "1", false: "0"}[s.dark[s.size-15+i][8]])
+	}
+	for i := 7; i >= 0; i-- {
+		got.WriteString(map[bool]string{true: "1", false: "0"}[s.dark[8][s.size-1-i]])
+	}
+	if got.String() != expected {
+		t.Fatalf("unexpected format bits: %s", got.String())
+	}
+
+	s.drawVersion()
+	// Versão 7: 000111110010010100, bit 0 no canto superior direito do bloco.
+	var version int
+	for i := 0; i < 18; i++ {
+		if s.dark[i/3][s.size-11+i%3] {
+			version |= 1 << i
+		}
+	}
+	if version != 0x07C94 {
+		t.Fatalf("unexpected version bits: %018b", version)
+	}
+}
+
+func TestEncodeRoundTrip(t *testing.T) {
+	content := []byte(strings.Repeat("00020101021126580014br.gov.bcb.pix", 5))
+	s, err := encode(content)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if s.version != 9 || s.size != 53 {
+		t.Fatalf("unexpected version %d", s.version)
+	}
+
+	mask := readMask(t, s)
+	s.applyMask(mask)
+	codewords := readCodewords(s)
+	layout := layoutsM[s.version]
+	blocks := layout.shortBlocks + layout.longBlocks
+	dataLength := layout.dataCodewords()
+
+	// Desfaz a intercalação e confere a correção de erro de cada bloco.
+	data := make([]byte, 0, dataLength)
+	blockData := make([][]byte, blocks)
+	index := 0
+	for i := 0; i <= layout.shortData; i++ {
+		for b := 0; b < blocks; b++ {
+			if i < layout.shortData || b >= layout.shortBlocks {
+				blockData[b] = append(blockData[b], codewords[index])
+				index++
+			}
+		}
+	}
+	for b := 0; b < blocks; b++ {
+		ec := make([]byte, layout.ecPerBlock)
+		for i := range ec {
+			ec[i] = codewords[dataLength+i*blocks+b]
+		}
+		if !bytes.Equal(errorCorrection(blockData[b], layout.ecPerBlock), ec) {
+			t.Fatalf("error correction mismatch on block %d", b)
+		}
+		data = append(data, blockData[b]...)
+	}
+
+	if data[0]>>4 != 0x4 {
+		t.Fatalf("expected byte mode, got %x", data[0]>>4)
+	}
+	length := int(data[0]&0x0F)<<4 | int(data[1]>>4)
+	decoded := make([]byte, length)
+	for i := range decoded {
+		decoded[i] = data[1+i]<<4 | data[2+i]>>4
+	}
+	if !bytes.Equal(decoded, content) {
+		t.Fatalf("unexpected content: %s", decoded)
+	}
+}
+
+func TestEncodePng(t *testing.T) {
+	image, err := NewQrCode().Encode("00020126360014br.gov.bcb.pix0114+5584999999999", 300)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	decoded, err := png.Decode(bytes.NewReader(image))
+	if err != nil {
+		t.Fatalf("invalid png: %v", err)
+	}
+	// Versão 4 (33 módulos) mais a margem de 4 módulos de cada lado.
+	if decoded.Bounds().Dx() != 41*7 {
+		t.Fatalf("unexpected image size: %v", decoded.Bounds())
+	}
+	if r, _, _, _ := decoded.At(4*7, 4*7).RGBA(); r != 0 {
+		t.Fatalf("expected finder pattern at the top left corner")
+	}
+
+	if _, err := NewQrCode().Encode(strings.Repeat("a", 700), 300); err != ErrContentTooLong {
+		t.Fatalf("expected content too long error, got %v", err)
+	}
+}
+
+func readMask(t *testing.T, s *symbol) int {
+	var bits int
+	for i := 0; i <= 5; i++ {
+		if s.dark[i][8] {
+			bits |= 1 << i
+		}
+	}
+	if s.dark[7][8] {
+		bits |= 1 << 6
+	}
+	if s.dark[8][8] {
+		bits |= 1 << 7
+	}
+	if s.dark[8][7] {
+		bits |= 1 << 8
+	}
+	for i := 9; i < 15; i++ {
+		if s.dark[8][14-i] {
+			bits |= 1 << i
+		}
+	}
+	format := (bits ^ 0x5412) >> 10
+	if format>>3 != 0 {
+		t.Fatalf("expected error correction level M, got %b", format>>3)
+	}
+	return format & 0x7
+}
+
+func readCodewords(s *symbol) []byte {
+	codewords := make([]byte, 0)
+	var current byte
+	count := 0
+	for right := s.size - 1; right >= 1; right -= 2 {
+		if right == 6 {
+			right = 5
+		}
+		for vertical := 0; vertical < s.size; vertical++ {
+			for j := 0; j < 2; j++ {
+				x := right - j
+				y := vertical
+				if (right+1)&2 == 0 {
+					y = s.size - 1 - vertical
+				}
+				if s.isFunction[y][x] {
+					continue
+				}
+				current <<= 1
+				if s.dark[y][x] {
+					current |= 1
+				}
+				count++
+				if count == 8 {
+					codewords = append(codewords, current)
+					current, count = 0, 0
+				}
+			}
+		}
+	}
+	return codewords
+}
@@ -0,0 +1,63 @@
+package qrcode
+
+// Tabelas de logaritmo do corpo finito GF(256) usado pelo QR Code, com o
+// polinômio primitivo x^8 + x^4 + x^3 + x^2 + 1.
+var (
+	gfExp [512]byte
+	gfLog [256]byte
+)
+
+func init() {
+	x := 1
+	for i := 0; i < 255; i++ {
+		gfExp[i] = byte(x)
+		gfLog[x] = byte(i)
+		x <<= 1
+		if x&0x100 != 0 {
+			x ^= 0x11D
+		}
+	}
+	for i := 255; i < len(gfExp); i++ {
+		gfExp[i] = gfExp[i-255]
+	}
+}
+
+func gfMultiply(a byte, b byte) byte {
+	if a == 0 || b == 0 {
+		return 0
+	}
+	return gfExp[int(gfLog[a])+int(gfLog[b])]
+}
+
+// generatorPolynomial retorna os coeficientes (do maior grau ao menor, sem o
+// coeficiente líder) do polinômio gerador com as raízes α^0 a α^(degree-1).
+func generatorPolynomial(degree int) []byte {
+	result := make([]byte, degree)
+	result[degree-1] = 1
+	root := byte(1)
+	for i := 0; i < degree; i++ {
+		for j := 0; j < degree; j++ {
+			result[j] = gfMultiply(result[j], root)
+			if j+1 < degree {
+				result[j] ^= result[j+1]
+			}
+		}
+		root = gfMultiply(root, 0x02)
+	}
+	return result
+}
+
+// errorCorrection calcula os codewords de correção de erro de um bloco.
+func errorCorrection(data []byte, degree int) []byte {
+	generator := generatorPolynomial(degree)
+	result := make([]byte, degree)
+	for _, b := range data {
+		factor := b ^ result[0]
+		copy(result, result[1:])
+		result[degree-1] = 0
+		for i := range result {
+			result[i] ^= gfMultiply(generator[i], factor)
+		}
+	}
+	return result
+}
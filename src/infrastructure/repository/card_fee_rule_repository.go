@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/bncunha/erp-api/src/application/constants"
+	"github.com/bncunha/erp-api/src/domain"
+)
+
+type cardFeeRuleRepository struct {
+	db *sql.DB
+}
+
+func NewCardFeeRuleRepository(db *sql.DB) domain.CardFeeRuleRepository {
+	return &cardFeeRuleRepository{db}
+}
+
+func (r *cardFeeRuleRepository) GetAll(ctx context.Context) ([]domain.CardFeeRule, error) {
+	tenantId := ctx.Value(constants.TENANT_KEY)
+	rules := make([]domain.CardFeeRule, 0)
+
+	query := `
+	SELECT id, brand, payment_type, installments, fee_percentage, settlement_days
+	FROM card_fee_rules
+	WHERE tenant_id = $1
+	ORDER BY brand ASC, payment_type ASC, installments ASC`
+	rows, err := r.db.QueryContext(ctx, query, tenantId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var rule domain.CardFeeRule
+		if err := rows.Scan(&rule.Id, &rule.Brand, &rule.PaymentType, &rule.Installments, &rule.FeePercentage, &rule.SettlementDays); err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+// Save cadastra a taxa ou atualiza a já existente para a mesma bandeira, tipo
+// e quantidade de parcelas.
+func (r *cardFeeRuleRepository) Save(ctx context.Context, rule domain.CardFeeRule) (int64, error) {
+	tenantId := ctx.Value(constants.TENANT_KEY)
+	var id int64
+	query := `
+	INSERT INTO card_fee_rules (brand, payment_type, installments, fee_percentage, settlement_days, tenant_id)
+	VALUES ($1, $2, $3, $4, $5, $6)
+	ON CONFLICT (tenant_id, brand, payment_type, installments) DO UPDATE SET
+		fee_percentage = EXCLUDED.fee_percentage,
+		settlement_days = EXCLUDED.settlement_days,
+		updated_at = NOW()
+	RETURNING id`
+	err := r.db.QueryRowContext(ctx, query, rule.Brand, rule.PaymentType, rule.Installments, rule.FeePercentage, rule.SettlementDays, tenantId).Scan(&id)
+	return id, err
+}
+
+func (r *cardFeeRuleRepository) Delete(ctx context.Context, id int64) error {
+	tenantId := ctx.Value(constants.TENANT_KEY)
+	query := `DELETE FROM card_fee_rules WHERE id = $1 AND tenant_id = $2`
+	result, err := r.db.ExecContext(ctx, query, id, tenantId)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return domain.ErrCardFeeRuleNotFound
+	}
+	return nil
+}
@@ -0,0 +1,205 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/bncunha/erp-api/src/application/constants"
+	"github.com/bncunha/erp-api/src/application/errors"
+	"github.com/bncunha/erp-api/src/domain"
+)
+
+type cashRegisterRepository struct {
+	db *sql.DB
+}
+
+func NewCashRegisterRepository(db *sql.DB) domain.CashRegisterRepository {
+	return &cashRegisterRepository{db}
+}
+
+// GetSettings retorna a configuração do caixa da empresa ou a configuração
+// padrão, sem exigência de caixa aberto, quando ainda não foi cadastrada.
+func (r *cashRegisterRepository) GetSettings(ctx context.Context) (domain.CashRegisterSettings, error) {
+	tenantId := ctx.Value(constants.TENANT_KEY)
+	var settings domain.CashRegisterSettings
+	query := `SELECT require_open_session FROM cash_register_settings WHERE tenant_id = $1`
+	err := r.db.QueryRowContext(ctx, query, tenantId).Scan(&settings.RequireOpenSession)
+	if err != nil {
+		if errors.IsNoRowsFinded(err) {
+			return domain.CashRegisterSettings{}, nil
+		}
+		return settings, err
+	}
+	return settings, nil
+}
+
+func (r *cashRegisterRepository) SaveSettings(ctx context.Context, settings domain.CashRegisterSettings) error {
+	tenantId := ctx.Value(constants.TENANT_KEY)
+	query := `
+	INSERT INTO cash_register_settings (require_open_session, tenant_id)
+	VALUES ($1, $2)
+	ON CONFLICT (tenant_id) DO UPDATE SET
+		require_open_session = EXCLUDED.require_open_session,
+		updated_at = NOW()`
+	_, err := r.db.ExecContext(ctx, query, settings.RequireOpenSession, tenantId)
+	return err
+}
+
+func (r *cashRegisterRepository) Open(ctx context.Context, session domain.CashRegisterSession) (int64, error) {
+	tenantId := ctx.Value(constants.TENANT_KEY)
+	var id int64
+	query := `
+	INSERT INTO cash_register_sessions (user_id, status, opening_balance, opened_at, tenant_id)
+	VALUES ($1, $2, $3, $4, $5)
+	RETURNING id`
+	err := r.db.QueryRowContext(ctx, query, session.User.Id, session.Status, session.OpeningBalance, session.OpenedAt, tenantId).Scan(&id)
+	if err != nil {
+		if errors.IsUniqueViolation(err) {
+			return 0, domain.ErrCashRegisterSessionAlreadyOpen
+		}
+		return 0, err
+	}
+	return id, nil
+}
+
+const selectCashRegisterSessionQuery = `
+	SELECT crs.id, u.id, u.name, crs.status, crs.opening_balance, COALESCE(crs.expected_amount, 0), crs.counted_amount, crs.difference, COALESCE(crs.notes, ''), crs.opened_at, crs.closed_at
+	FROM cash_register_sessions crs
+	JOIN users u ON u.id = crs.user_id AND u.tenant_id = crs.tenant_id`
+
+func (r *cashRegisterRepository) GetOpenByUserId(ctx context.Context, userId int64) (domain.CashRegisterSession, error) {
+	tenantId := ctx.Value(constants.TENANT_KEY)
+	query := selectCashRegisterSessionQuery + `
+	WHERE crs.user_id = $1 AND crs.status = 'OPEN' AND crs.tenant_id = $2`
+	session, err := scanCashRegisterSession(r.db.QueryRowContext(ctx, query, userId, tenantId))
+	if err != nil {
+		if errors.IsNoRowsFinded(err) {
+			return session, domain.ErrCashRegisterSessionNotOpen
+		}
+		return session, err
+	}
+	return session, nil
+}
+
+func (r *cashRegisterRepository) GetById(ctx context.Context, id int64) (domain.CashRegisterSession, error) {
+	tenantId := ctx.Value(constants.TENANT_KEY)
+	query := selectCashRegisterSessionQuery + `
+	WHERE crs.id = $1 AND crs.tenant_id = $2`
+	session, err := scanCashRegisterSession(r.db.QueryRowContext(ctx, query, id, tenantId))
+	if err != nil {
+		if errors.IsNoRowsFinded(err) {
+			return session, domain.ErrCashRegisterSessionNotFound
+		}
+		return session, err
+	}
+	return session, nil
+}
+
+func (r *cashRegisterRepository) GetAll(ctx context.Context, input domain.GetCashRegisterSessionsInput) ([]domain.CashRegisterSession, error) {
+	tenantId := ctx.Value(constants.TENANT_KEY)
+	sessions := make([]domain.CashRegisterSession, 0)
+	query := selectCashRegisterSessionQuery + `
+	WHERE crs.tenant_id = $1 AND ($2::bigint IS NULL OR crs.user_id = $2)
+	ORDER BY crs.opened_at DESC, crs.id DESC`
+	rows, err := r.db.QueryContext(ctx, query, tenantId, input.UserId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		session, err := scanCashRegisterSession(rows)
+		if err != nil {
+			return nil, err
+		}
+		sessions = append(sessions, session)
+	}
+	return sessions, nil
+}
+
+func scanCashRegisterSession(row interface{ Scan(dest ...any) error }) (domain.CashRegisterSession, error) {
+	var session domain.CashRegisterSession
+	var countedAmount, difference sql.NullFloat64
+	var closedAt sql.NullTime
+	err := row.Scan(&session.Id, &session.User.Id, &session.User.Name, &session.Status, &session.OpeningBalance, &session.ExpectedAmount, &countedAmount, &difference, &session.Notes, &session.OpenedAt, &closedAt)
+	if err != nil {
+		return session, err
+	}
+	if countedAmount.Valid {
+		session.CountedAmount = &countedAmount.Float64
+	}
+	if difference.Valid {
+		session.Difference = &difference.Float64
+	}
+	if closedAt.Valid {
+		session.ClosedAt = &closedAt.Time
+	}
+	return session, nil
+}
+
+func (r *cashRegisterRepository) GetEntries(ctx context.Context, sessionId int64) ([]domain.CashRegisterEntry, error) {
+	tenantId := ctx.Value(constants.TENANT_KEY)
+	entries := make([]domain.CashRegisterEntry, 0)
+	query := `
+	SELECT id, cash_register_session_id, type, value, COALESCE(description, ''), COALESCE(sales_id, 0), COALESCE(payment_date_id, 0), created_by_user_id, created_at
+	FROM cash_register_entries
+	WHERE cash_register_session_id = $1 AND tenant_id = $2
+	ORDER BY created_at ASC, id ASC`
+	rows, err := r.db.QueryContext(ctx, query, sessionId, tenantId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var entry domain.CashRegisterEntry
+		if err := rows.Scan(&entry.Id, &entry.SessionId, &entry.Type, &entry.Value, &entry.Description, &entry.SalesId, &entry.PaymentDateId, &entry.CreatedByUserId, &entry.CreatedAt); err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+const insertCashRegisterEntryQuery = `
+	INSERT INTO cash_register_entries (cash_register_session_id, type, value, description, sales_id, payment_date_id, created_by_user_id, tenant_id, created_at)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	RETURNING id`
+
+// CreateEntry grava o lançamento na transação informada ou, sem transação,
+// diretamente no banco.
+func (r *cashRegisterRepository) CreateEntry(ctx context.Context, tx *sql.Tx, entry domain.CashRegisterEntry) (int64, error) {
+	tenantId := ctx.Value(constants.TENANT_KEY)
+	description := sql.NullString{String: entry.Description, Valid: entry.Description != ""}
+	salesId := sql.NullInt64{Int64: entry.SalesId, Valid: entry.SalesId != 0}
+	paymentDateId := sql.NullInt64{Int64: entry.PaymentDateId, Valid: entry.PaymentDateId != 0}
+	args := []interface{}{entry.SessionId, entry.Type, entry.Value, description, salesId, paymentDateId, entry.CreatedByUserId, tenantId, entry.CreatedAt}
+
+	var id int64
+	var err error
+	if tx != nil {
+		err = tx.QueryRowContext(ctx, insertCashRegisterEntryQuery, args...).Scan(&id)
+	} else {
+		err = r.db.QueryRowContext(ctx, insertCashRegisterEntryQuery, args...).Scan(&id)
+	}
+	return id, err
+}
+
+func (r *cashRegisterRepository) Close(ctx context.Context, session domain.CashRegisterSession) error {
+	tenantId := ctx.Value(constants.TENANT_KEY)
+	query := `
+	UPDATE cash_register_sessions
+	SET status = $1, expected_amount = $2, counted_amount = $3, difference = $4, notes = $5, closed_at = $6
+	WHERE id = $7 AND tenant_id = $8 AND status = 'OPEN'`
+	notes := sql.NullString{String: session.Notes, Valid: session.Notes != ""}
+	result, err := r.db.ExecContext(ctx, query, session.Status, session.ExpectedAmount, session.CountedAmount, session.Difference, notes, session.ClosedAt, session.Id, tenantId)
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return domain.ErrCashRegisterSessionClosed
+	}
+	return nil
+}
@@ -0,0 +1,246 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/bncunha/erp-api/src/application/constants"
+	"github.com/bncunha/erp-api/src/application/errors"
+	"github.com/bncunha/erp-api/src/domain"
+	"github.com/lib/pq"
+)
+
+type commissionRepository struct {
+	db *sql.DB
+}
+
+func NewCommissionRepository(db *sql.DB) domain.CommissionRepository {
+	return &commissionRepository{db}
+}
+
+func (r *commissionRepository) GetRules(ctx context.Context) ([]domain.CommissionRule, error) {
+	tenantId := ctx.Value(constants.TENANT_KEY)
+	rules := make([]domain.CommissionRule, 0)
+
+	query := `SELECT id, scope, reference_id, percentage, base FROM commission_rules WHERE tenant_id = $1 ORDER BY scope ASC, id ASC`
+	rows, err := r.db.QueryContext(ctx, query, tenantId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var rule domain.CommissionRule
+		if err := rows.Scan(&rule.Id, &rule.Scope, &rule.ReferenceId, &rule.Percentage, &rule.Base); err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+func (r *commissionRepository) CreateRule(ctx context.Context, rule domain.CommissionRule) (int64, error) {
+	tenantId := ctx.Value(constants.TENANT_KEY)
+	var insertedId int64
+
+	query := `INSERT INTO commission_rules (scope, reference_id, percentage, base, tenant_id) VALUES ($1, $2, $3, $4, $5) RETURNING id`
+	err := r.db.QueryRowContext(ctx, query, rule.Scope, rule.ReferenceId, rule.Percentage, rule.Base, tenantId).Scan(&insertedId)
+	if err != nil {
+		if errors.IsUniqueViolation(err) {
+			return insertedId, domain.ErrCommissionRuleDuplicated
+		}
+		return insertedId, err
+	}
+	return insertedId, nil
+}
+
+func (r *commissionRepository) DeleteRule(ctx context.Context, id int64) error {
+	tenantId := ctx.Value(constants.TENANT_KEY)
+	query := `DELETE FROM commission_rules WHERE id = $1 AND tenant_id = $2`
+	result, err := r.db.ExecContext(ctx, query, id, tenantId)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return domain.ErrCommissionRuleNotFound
+	}
+	return nil
+}
+
+// GetReceivedInstallments retorna, por parcela, a soma dos recebimentos do
+// período nas vendas do revendedor, considerando apenas a última versão de cada
+// venda. A data é a do último recebimento da parcela no período. Pagamentos com
+// crédito de devolução não geram comissão, pois não representam um novo recebimento.
+func (r *commissionRepository) GetReceivedInstallments(ctx context.Context, userId int64, initialDate time.Time, finalDate time.Time) ([]domain.CommissionInstallment, error) {
+	tenantId := ctx.Value(constants.TENANT_KEY)
+	installments := make([]domain.CommissionInstallment, 0)
+
+	query := `
+	SELECT pd.id, s.id, s.code, c.name, p.payment_type, pd.installment_number, MAX(pr.receipt_date), SUM(pr.value)
+	FROM sales s
+	JOIN sales_versions sv ON sv.sales_id = s.id AND sv.version = s.last_version AND sv.tenant_id = s.tenant_id
+	JOIN customers c ON c.id = s.customer_id AND c.tenant_id = s.tenant_id
+	JOIN payments p ON p.sales_version_id = sv.id AND p.tenant_id = s.tenant_id
+	JOIN payment_dates pd ON pd.payment_id = p.id AND pd.tenant_id = s.tenant_id
+	JOIN payment_receipts pr ON pr.payment_date_id = pd.id AND pr.tenant_id = s.tenant_id
+	WHERE s.tenant_id = $1
+	  AND s.user_id = $2
+	  AND pd.status <> 'CANCEL'
+	  AND p.payment_type <> 'PAYMENT_RETURN'
+	  AND pr.receipt_date >= $3
+	  AND pr.receipt_date < $4
+	GROUP BY pd.id, s.id, s.code, c.name, p.payment_type, pd.installment_number
+	ORDER BY MAX(pr.receipt_date) ASC, pd.id ASC`
+	rows, err := r.db.QueryContext(ctx, query, tenantId, userId, initialDate, finalDate)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var installment domain.CommissionInstallment
+		if err := rows.Scan(&installment.PaymentDateId, &installment.SaleId, &installment.SaleCode, &installment.CustomerName, &installment.PaymentType, &installment.InstallmentNumber, &installment.PaidDate, &installment.Value); err != nil {
+			return nil, err
+		}
+		installments = append(installments, installment)
+	}
+	return installments, nil
+}
+
+func (r *commissionRepository) GetSalesItems(ctx context.Context, saleIds []int64) ([]domain.CommissionSaleItem, error) {
+	tenantId := ctx.Value(constants.TENANT_KEY)
+	items := make([]domain.CommissionSaleItem, 0)
+	if len(saleIds) == 0 {
+		return items, nil
+	}
+
+	query := `
+	SELECT s.id, sk.id, p.id, p.category_id, si.quantity,
+		si.quantity * si.unit_price - si.discount_amount - si.sale_discount_amount, sk.cost
+	FROM sales s
+	JOIN sales_versions sv ON sv.sales_id = s.id AND sv.version = s.last_version AND sv.tenant_id = s.tenant_id
+	JOIN sales_items si ON si.sales_version_id = sv.id AND si.tenant_id = s.tenant_id
+	JOIN skus sk ON sk.id = si.sku_id
+	JOIN products p ON p.id = sk.product_id
+	WHERE s.tenant_id = $1 AND s.id = ANY($2)
+	ORDER BY s.id ASC, si.id ASC`
+	rows, err := r.db.QueryContext(ctx, query, tenantId, pq.Array(saleIds))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var item domain.CommissionSaleItem
+		if err := rows.Scan(&item.SaleId, &item.SkuId, &item.ProductId, &item.CategoryId, &item.Quantity, &item.Total, &item.Cost); err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+func (r *commissionRepository) GetStatement(ctx context.Context, userId int64, month time.Time) (domain.CommissionStatement, error) {
+	tenantId := ctx.Value(constants.TENANT_KEY)
+	var statement domain.CommissionStatement
+
+	query := `
+	SELECT cs.id, u.id, u.name, cs.month, cs.status, cs.closed_at, cs.paid_at
+	FROM commission_statements cs
+	JOIN users u ON u.id = cs.user_id AND u.tenant_id = cs.tenant_id
+	WHERE cs.tenant_id = $1 AND cs.user_id = $2 AND cs.month = $3`
+	err := r.db.QueryRowContext(ctx, query, tenantId, userId, month).Scan(&statement.Id, &statement.User.Id, &statement.User.Name, &statement.Month, &statement.Status, &statement.ClosedAt, &statement.PaidAt)
+	if err != nil {
+		if errors.IsNoRowsFinded(err) {
+			return statement, domain.ErrCommissionStatementNotFound
+		}
+		return statement, err
+	}
+
+	statement.Entries = make([]domain.CommissionEntry, 0)
+	query = `
+	SELECT e.payment_date_id, s.id, s.code, c.name, e.payment_type, e.installment_number, e.paid_date, e.received_value, e.percentage, e.value
+	FROM commission_statement_entries e
+	JOIN sales s ON s.id = e.sales_id AND s.tenant_id = e.tenant_id
+	JOIN customers c ON c.id = s.customer_id AND c.tenant_id = s.tenant_id
+	WHERE e.commission_statement_id = $1 AND e.tenant_id = $2
+	ORDER BY e.paid_date ASC, e.id ASC`
+	rows, err := r.db.QueryContext(ctx, query, statement.Id, tenantId)
+	if err != nil {
+		return statement, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var entry domain.CommissionEntry
+		if err := rows.Scan(&entry.PaymentDateId, &entry.SaleId, &entry.SaleCode, &entry.CustomerName, &entry.PaymentType, &entry.InstallmentNumber, &entry.PaidDate, &entry.ReceivedValue, &entry.Percentage, &entry.Value); err != nil {
+			return statement, err
+		}
+		statement.Entries = append(statement.Entries, entry)
+	}
+	return statement, nil
+}
+
+func (r *commissionRepository) CreateStatement(ctx context.Context, tx *sql.Tx, statement domain.CommissionStatement) (int64, error) {
+	tenantId := ctx.Value(constants.TENANT_KEY)
+	var insertedId int64
+
+	query := `INSERT INTO commission_statements (user_id, month, status, total_value, closed_at, tenant_id) VALUES ($1, $2, $3, $4, $5, $6) RETURNING id`
+	err := tx.QueryRowContext(ctx, query, statement.User.Id, statement.Month, statement.Status, statement.GetTotal(), statement.ClosedAt, tenantId).Scan(&insertedId)
+	if err != nil {
+		if errors.IsUniqueViolation(err) {
+			return insertedId, domain.ErrCommissionStatementAlreadyClosed
+		}
+		return insertedId, err
+	}
+	return insertedId, nil
+}
+
+func (r *commissionRepository) CreateStatementEntries(ctx context.Context, tx *sql.Tx, statementId int64, entries []domain.CommissionEntry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+	tenantId := ctx.Value(constants.TENANT_KEY)
+
+	query := `INSERT INTO commission_statement_entries (commission_statement_id, payment_date_id, sales_id, payment_type, installment_number, paid_date, received_value, percentage, value, tenant_id) VALUES %s`
+	valueStrings := make([]string, 0, len(entries))
+	valueArgs := make([]interface{}, 0, len(entries)*10)
+	for i, entry := range entries {
+		n := i * 10
+		valueStrings = append(valueStrings, fmt.Sprintf("($%d,$%d,$%d,$%d,$%d,$%d,$%d,$%d,$%d,$%d)", n+1, n+2, n+3, n+4, n+5, n+6, n+7, n+8, n+9, n+10))
+		valueArgs = append(valueArgs,
+			statementId,
+			entry.PaymentDateId,
+			entry.SaleId,
+			entry.PaymentType,
+			entry.InstallmentNumber,
+			entry.PaidDate,
+			entry.ReceivedValue,
+			entry.Percentage,
+			entry.Value,
+			tenantId,
+		)
+	}
+	_, err := tx.ExecContext(ctx, fmt.Sprintf(query, strings.Join(valueStrings, ",")), valueArgs...)
+	return err
+}
+
+func (r *commissionRepository) MarkStatementAsPaid(ctx context.Context, id int64) error {
+	tenantId := ctx.Value(constants.TENANT_KEY)
+	query := `UPDATE commission_statements SET status = 'PAID', paid_at = NOW() WHERE id = $1 AND tenant_id = $2 AND status = 'CLOSED'`
+	result, err := r.db.ExecContext(ctx, query, id, tenantId)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return domain.ErrCommissionStatementAlreadyPaid
+	}
+	return nil
+}
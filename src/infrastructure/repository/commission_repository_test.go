@@ -0,0 +1,40 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/bncunha/erp-api/src/application/constants"
+	"github.com/bncunha/erp-api/src/domain"
+)
+
+func TestCommissionRepositoryGetReceivedInstallmentsUsesReceipts(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock: %v", err)
+	}
+	defer db.Close()
+
+	repo := NewCommissionRepository(db)
+	ctx := context.WithValue(context.Background(), constants.TENANT_KEY, int64(1))
+	initialDate := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	finalDate := initialDate.AddDate(0, 1, 0)
+	receiptDate := time.Date(2026, 10, 15, 0, 0, 0, 0, time.UTC)
+	mock.ExpectQuery(`MAX\(pr\.receipt_date\), SUM\(pr\.value\)[\s\S]*JOIN payment_receipts pr[\s\S]*pr\.receipt_date >= \$3\s+AND pr\.receipt_date < \$4`).
+		WithArgs(int64(1), int64(5), initialDate, finalDate).
+		WillReturnRows(sqlmock.NewRows([]string{"pd_id", "s_id", "code", "name", "payment_type", "installment_number", "receipt_date", "value"}).
+			AddRow(int64(55), int64(101), "V-1", "Maria", "CREDIT_STORE", 1, receiptDate, 40.0))
+
+	installments, err := repo.GetReceivedInstallments(ctx, 5, initialDate, finalDate)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(installments) != 1 || installments[0].PaymentDateId != 55 || installments[0].PaymentType != domain.PaymentTypeCreditStore || installments[0].Value != 40 || !installments[0].PaidDate.Equal(receiptDate) {
+		t.Fatalf("unexpected installments: %+v", installments)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet expectations: %v", err)
+	}
+}
@@ -4,6 +4,7 @@ import (
     "context"
     "database/sql"
 
+    "github.com/bncunha/erp-api/src/application/constants"
     "github.com/bncunha/erp-api/src/domain"
 )
 
@@ -28,3 +29,33 @@ func (r *companyRepository) CreateWithTx(ctx context.Context, tx *sql.Tx, compan
     }
     return id, nil
 }
+
+// Get retorna a empresa do tenant atual com o seu endereço, quando cadastrado.
+func (r *companyRepository) Get(ctx context.Context) (domain.Company, error) {
+    tenantId := ctx.Value(constants.TENANT_KEY)
+    var company domain.Company
+    var street, neighborhood, number, city, uf, cep sql.NullString
+
+    query := `
+    SELECT c.id, c.name, COALESCE(c.legal_name, ''), COALESCE(c.cnpj, ''), COALESCE(c.cpf, ''), COALESCE(c.cellphone, ''),
+        a.street, a.neighborhood, a.number, a.city, a.uf, a.cep
+    FROM companies c
+    LEFT JOIN addresses a ON a.tenant_id = c.id
+    WHERE c.id = $1`
+    err := r.db.QueryRowContext(ctx, query, tenantId).Scan(&company.Id, &company.Name, &company.LegalName, &company.Cnpj, &company.Cpf, &company.Cellphone, &street, &neighborhood, &number, &city, &uf, &cep)
+    if err != nil {
+        return company, err
+    }
+    if street.Valid {
+        company.Address = &domain.Address{
+            Street:       street.String,
+            Neighborhood: neighborhood.String,
+            Number:       number.String,
+            City:         city.String,
+            UF:           uf.String,
+            Cep:          cep.String,
+            TenantId:     company.Id,
+        }
+    }
+    return company, nil
+}
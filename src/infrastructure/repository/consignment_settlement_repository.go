@@ -0,0 +1,202 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/bncunha/erp-api/src/application/constants"
+	"github.com/bncunha/erp-api/src/application/errors"
+	"github.com/bncunha/erp-api/src/domain"
+	"github.com/lib/pq"
+)
+
+type consignmentSettlementRepository struct {
+	db *sql.DB
+}
+
+func NewConsignmentSettlementRepository(db *sql.DB) domain.ConsignmentSettlementRepository {
+	return &consignmentSettlementRepository{db}
+}
+
+// GetItems consolida, por SKU, as transferências de entrada e saída do estoque
+// do revendedor, as vendas feitas por ele no período (considerando a última
+// versão de cada venda) e o saldo atual do estoque.
+func (r *consignmentSettlementRepository) GetItems(ctx context.Context, inventoryId int64, userId int64, periodStart *time.Time, periodEnd time.Time) ([]domain.ConsignmentSettlementItem, error) {
+	tenantId := ctx.Value(constants.TENANT_KEY)
+	items := make([]domain.ConsignmentSettlementItem, 0)
+
+	query := `
+	WITH transfers AS (
+		SELECT ii.sku_id,
+			SUM(CASE WHEN it.inventory_in_id = $1 THEN it.quantity ELSE 0 END) AS sent,
+			SUM(CASE WHEN it.inventory_out_id = $1 THEN it.quantity ELSE 0 END) AS returned
+		FROM inventory_transactions it
+		JOIN inventory_items ii ON ii.id = it.inventory_item_id
+		WHERE it.tenant_id = $2
+		  AND it.type = 'TRANSFER'
+		  AND it.deleted_at IS NULL
+		  AND (it.inventory_in_id = $1 OR it.inventory_out_id = $1)
+		  AND ($3::timestamp IS NULL OR it.date >= $3)
+		  AND it.date < $4
+		GROUP BY ii.sku_id
+	),
+	sold AS (
+		SELECT si.sku_id,
+			SUM(si.quantity) AS quantity,
+			SUM(si.quantity * si.unit_price - si.discount_amount - si.sale_discount_amount) AS value
+		FROM sales s
+		JOIN sales_versions sv ON sv.sales_id = s.id AND sv.version = s.last_version AND sv.tenant_id = s.tenant_id
+		JOIN sales_items si ON si.sales_version_id = sv.id AND si.tenant_id = s.tenant_id
+		WHERE s.tenant_id = $2
+		  AND s.user_id = $5
+		  AND ($3::timestamp IS NULL OR s.date >= $3)
+		  AND s.date < $4
+		GROUP BY si.sku_id
+	)
+	SELECT sku.id, sku.code, COALESCE(sku.color, ''), COALESCE(sku.size, ''), sku.price, p.name,
+		COALESCE(t.sent, 0), COALESCE(sd.quantity, 0), COALESCE(sd.value, 0), COALESCE(t.returned, 0), COALESCE(ii.quantity, 0)
+	FROM skus sku
+	JOIN products p ON p.id = sku.product_id
+	LEFT JOIN inventory_items ii ON ii.sku_id = sku.id AND ii.inventory_id = $1 AND ii.deleted_at IS NULL
+	LEFT JOIN transfers t ON t.sku_id = sku.id
+	LEFT JOIN sold sd ON sd.sku_id = sku.id
+	WHERE sku.tenant_id = $2
+	  AND (t.sku_id IS NOT NULL OR sd.sku_id IS NOT NULL OR ii.quantity > 0)
+	ORDER BY p.name ASC, sku.code ASC`
+	rows, err := r.db.QueryContext(ctx, query, inventoryId, tenantId, periodStart, periodEnd, userId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var item domain.ConsignmentSettlementItem
+		if err := rows.Scan(&item.Sku.Id, &item.Sku.Code, &item.Sku.Color, &item.Sku.Size, &item.Sku.Price, &item.Sku.Product.Name,
+			&item.SentQuantity, &item.SoldQuantity, &item.SoldValue, &item.ReturnedQuantity, &item.OnHandQuantity); err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+func (r *consignmentSettlementRepository) GetLastPeriodEnd(ctx context.Context, userId int64) (*time.Time, error) {
+	tenantId := ctx.Value(constants.TENANT_KEY)
+	var periodEnd sql.NullTime
+
+	query := `SELECT MAX(period_end) FROM consignment_settlements WHERE tenant_id = $1 AND user_id = $2`
+	if err := r.db.QueryRowContext(ctx, query, tenantId, userId).Scan(&periodEnd); err != nil {
+		return nil, err
+	}
+	if !periodEnd.Valid {
+		return nil, nil
+	}
+	return &periodEnd.Time, nil
+}
+
+func (r *consignmentSettlementRepository) Create(ctx context.Context, tx *sql.Tx, settlement domain.ConsignmentSettlement, createdByUserId int64) (int64, error) {
+	tenantId := ctx.Value(constants.TENANT_KEY)
+	var insertedId int64
+
+	query := `INSERT INTO consignment_settlements (code, user_id, inventory_id, period_start, period_end, returned_unsold, amount_due, notes, created_by_user_id, tenant_id) VALUES ($1, $2, $3, $4, $5, $6, $7, NULLIF($8, ''), $9, $10) RETURNING id`
+	err := tx.QueryRowContext(ctx, query, settlement.Code, settlement.User.Id, settlement.InventoryId, settlement.PeriodStart, settlement.PeriodEnd, settlement.ReturnedUnsold, settlement.GetAmountDue(), settlement.Notes, createdByUserId, tenantId).Scan(&insertedId)
+	return insertedId, err
+}
+
+func (r *consignmentSettlementRepository) CreateItems(ctx context.Context, tx *sql.Tx, settlementId int64, items []domain.ConsignmentSettlementItem) error {
+	if len(items) == 0 {
+		return nil
+	}
+	tenantId := ctx.Value(constants.TENANT_KEY)
+
+	query := `INSERT INTO consignment_settlement_items (consignment_settlement_id, sku_id, unit_price, sent_quantity, sold_quantity, sold_value, returned_quantity, on_hand_quantity, returned_on_closing, tenant_id) VALUES %s`
+	valueStrings := make([]string, 0, len(items))
+	valueArgs := make([]interface{}, 0, len(items)*10)
+	for i, item := range items {
+		n := i * 10
+		valueStrings = append(valueStrings, fmt.Sprintf("($%d,$%d,$%d,$%d,$%d,$%d,$%d,$%d,$%d,$%d)", n+1, n+2, n+3, n+4, n+5, n+6, n+7, n+8, n+9, n+10))
+		valueArgs = append(valueArgs,
+			settlementId,
+			item.Sku.Id,
+			item.Sku.Price,
+			item.SentQuantity,
+			item.SoldQuantity,
+			item.SoldValue,
+			item.ReturnedQuantity,
+			item.OnHandQuantity,
+			item.ReturnedOnClosing,
+			tenantId,
+		)
+	}
+	_, err := tx.ExecContext(ctx, fmt.Sprintf(query, strings.Join(valueStrings, ",")), valueArgs...)
+	return err
+}
+
+func (r *consignmentSettlementRepository) GetAll(ctx context.Context, userIds []int64) ([]domain.GetConsignmentSettlementsOutput, error) {
+	tenantId := ctx.Value(constants.TENANT_KEY)
+	settlements := make([]domain.GetConsignmentSettlementsOutput, 0)
+
+	query := `
+	SELECT cs.id, cs.code, u.id, u.name, cs.period_start, cs.period_end, cs.returned_unsold, cs.amount_due
+	FROM consignment_settlements cs
+	JOIN users u ON u.id = cs.user_id AND u.tenant_id = cs.tenant_id
+	WHERE cs.tenant_id = $1 AND ($2::bigint[] IS NULL OR cs.user_id = ANY($2))
+	ORDER BY cs.period_end DESC, cs.id DESC`
+	rows, err := r.db.QueryContext(ctx, query, tenantId, pq.Array(userIds))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var settlement domain.GetConsignmentSettlementsOutput
+		if err := rows.Scan(&settlement.Id, &settlement.Code, &settlement.UserId, &settlement.UserName, &settlement.PeriodStart, &settlement.PeriodEnd, &settlement.ReturnedUnsold, &settlement.AmountDue); err != nil {
+			return nil, err
+		}
+		settlements = append(settlements, settlement)
+	}
+	return settlements, nil
+}
+
+func (r *consignmentSettlementRepository) GetById(ctx context.Context, id int64) (domain.ConsignmentSettlement, error) {
+	tenantId := ctx.Value(constants.TENANT_KEY)
+	var settlement domain.ConsignmentSettlement
+
+	query := `
+	SELECT cs.id, cs.code, u.id, u.name, cs.inventory_id, cs.period_start, cs.period_end, cs.returned_unsold, COALESCE(cs.notes, '')
+	FROM consignment_settlements cs
+	JOIN users u ON u.id = cs.user_id AND u.tenant_id = cs.tenant_id
+	WHERE cs.id = $1 AND cs.tenant_id = $2`
+	err := r.db.QueryRowContext(ctx, query, id, tenantId).Scan(&settlement.Id, &settlement.Code, &settlement.User.Id, &settlement.User.Name, &settlement.InventoryId, &settlement.PeriodStart, &settlement.PeriodEnd, &settlement.ReturnedUnsold, &settlement.Notes)
+	if err != nil {
+		if errors.IsNoRowsFinded(err) {
+			return settlement, domain.ErrConsignmentSettlementNotFound
+		}
+		return settlement, err
+	}
+
+	settlement.Items = make([]domain.ConsignmentSettlementItem, 0)
+	query = `
+	SELECT sku.id, sku.code, COALESCE(sku.color, ''), COALESCE(sku.size, ''), csi.unit_price, p.name,
+		csi.sent_quantity, csi.sold_quantity, csi.sold_value, csi.returned_quantity, csi.on_hand_quantity, csi.returned_on_closing
+	FROM consignment_settlement_items csi
+	JOIN skus sku ON sku.id = csi.sku_id
+	JOIN products p ON p.id = sku.product_id
+	WHERE csi.consignment_settlement_id = $1 AND csi.tenant_id = $2
+	ORDER BY csi.id ASC`
+	rows, err := r.db.QueryContext(ctx, query, id, tenantId)
+	if err != nil {
+		return settlement, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var item domain.ConsignmentSettlementItem
+		if err := rows.Scan(&item.Sku.Id, &item.Sku.Code, &item.Sku.Color, &item.Sku.Size, &item.Sku.Price, &item.Sku.Product.Name,
+			&item.SentQuantity, &item.SoldQuantity, &item.SoldValue, &item.ReturnedQuantity, &item.OnHandQuantity, &item.ReturnedOnClosing); err != nil {
+			return settlement, err
+		}
+		settlement.Items = append(settlement.Items, item)
+	}
+	return settlement, nil
+}
@@ -0,0 +1,116 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/bncunha/erp-api/src/application/constants"
+	"github.com/bncunha/erp-api/src/application/errors"
+	"github.com/bncunha/erp-api/src/domain"
+)
+
+type customerCreditRepository struct {
+	db *sql.DB
+}
+
+func NewCustomerCreditRepository(db *sql.DB) domain.CustomerCreditRepository {
+	return &customerCreditRepository{db}
+}
+
+const insertCustomerCreditQuery = `INSERT INTO customer_credits (customer_id, type, origin, value, description, sales_id, sales_version_id, created_by_user_id, tenant_id, created_at)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10) RETURNING id`
+
+func (r *customerCreditRepository) Create(ctx context.Context, credit domain.CustomerCredit) (int64, error) {
+	var insertedId int64
+	err := r.db.QueryRowContext(ctx, insertCustomerCreditQuery, r.insertArgs(ctx, credit)...).Scan(&insertedId)
+	if err != nil {
+		return insertedId, err
+	}
+	return insertedId, nil
+}
+
+func (r *customerCreditRepository) CreateWithTx(ctx context.Context, tx *sql.Tx, credit domain.CustomerCredit) (int64, error) {
+	if tx == nil {
+		return 0, errors.New("transaction not provided")
+	}
+	var insertedId int64
+	err := tx.QueryRowContext(ctx, insertCustomerCreditQuery, r.insertArgs(ctx, credit)...).Scan(&insertedId)
+	if err != nil {
+		return insertedId, err
+	}
+	return insertedId, nil
+}
+
+func (r *customerCreditRepository) insertArgs(ctx context.Context, credit domain.CustomerCredit) []interface{} {
+	tenantId := ctx.Value(constants.TENANT_KEY)
+	salesId := sql.NullInt64{Int64: credit.SalesId, Valid: credit.SalesId != 0}
+	salesVersionId := sql.NullInt64{Int64: credit.SalesVersionId, Valid: credit.SalesVersionId != 0}
+	description := sql.NullString{String: credit.Description, Valid: credit.Description != ""}
+	return []interface{}{credit.CustomerId, credit.Type, credit.Origin, credit.Value, description, salesId, salesVersionId, credit.CreatedByUserId, tenantId, credit.CreatedAt}
+}
+
+func (r *customerCreditRepository) GetBalance(ctx context.Context, customerId int64) (float64, error) {
+	var balance float64
+	tenantId := ctx.Value(constants.TENANT_KEY)
+	query := `SELECT COALESCE(SUM(CASE WHEN type = 'DEBIT' THEN -value ELSE value END), 0)
+	FROM customer_credits
+	WHERE customer_id = $1 AND tenant_id = $2`
+	err := r.db.QueryRowContext(ctx, query, customerId, tenantId).Scan(&balance)
+	if err != nil {
+		return balance, err
+	}
+	return balance, nil
+}
+
+// GetBalanceForUpdate bloqueia o cliente na transação antes de somar o saldo,
+// para que movimentações concorrentes não usem o mesmo crédito.
+func (r *customerCreditRepository) GetBalanceForUpdate(ctx context.Context, tx *sql.Tx, customerId int64) (float64, error) {
+	if tx == nil {
+		return 0, errors.New("transaction not provided")
+	}
+	var balance float64
+	tenantId := ctx.Value(constants.TENANT_KEY)
+	lockQuery := `SELECT id FROM customers WHERE id = $1 AND tenant_id = $2 FOR UPDATE`
+	var lockedId int64
+	if err := tx.QueryRowContext(ctx, lockQuery, customerId, tenantId).Scan(&lockedId); err != nil {
+		return balance, err
+	}
+	query := `SELECT COALESCE(SUM(CASE WHEN type = 'DEBIT' THEN -value ELSE value END), 0)
+	FROM customer_credits
+	WHERE customer_id = $1 AND tenant_id = $2`
+	err := tx.QueryRowContext(ctx, query, customerId, tenantId).Scan(&balance)
+	if err != nil {
+		return balance, err
+	}
+	return balance, nil
+}
+
+func (r *customerCreditRepository) GetByCustomerId(ctx context.Context, customerId int64) ([]domain.CustomerCredit, error) {
+	credits := make([]domain.CustomerCredit, 0)
+	tenantId := ctx.Value(constants.TENANT_KEY)
+	query := `SELECT cc.id, cc.customer_id, cc.type, cc.origin, cc.value, cc.description, cc.sales_id, cc.sales_version_id, cc.created_by_user_id, u.name, cc.created_at
+	FROM customer_credits cc
+	JOIN users u ON u.id = cc.created_by_user_id
+	WHERE cc.customer_id = $1 AND cc.tenant_id = $2
+	ORDER BY cc.created_at DESC, cc.id DESC`
+	rows, err := r.db.QueryContext(ctx, query, customerId, tenantId)
+	if err != nil {
+		return credits, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var credit domain.CustomerCredit
+		var description sql.NullString
+		var salesId, salesVersionId sql.NullInt64
+		err = rows.Scan(&credit.Id, &credit.CustomerId, &credit.Type, &credit.Origin, &credit.Value, &description, &salesId, &salesVersionId, &credit.CreatedByUserId, &credit.CreatedBy, &credit.CreatedAt)
+		if err != nil {
+			return credits, err
+		}
+		credit.Description = description.String
+		credit.SalesId = salesId.Int64
+		credit.SalesVersionId = salesVersionId.Int64
+		credits = append(credits, credit)
+	}
+	return credits, nil
+}
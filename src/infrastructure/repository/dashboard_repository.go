@@ -21,7 +21,7 @@ func (r *dashboardRepository) GetRevenue(ctx context.Context, input domain.Dashb
 	var total float64
 
 	query := `
-	SELECT COALESCE(SUM(si.quantity * si.unit_price), 0)
+	SELECT COALESCE(SUM(si.quantity * si.unit_price - si.discount_amount - si.sale_discount_amount), 0)
 	FROM sales s
 	JOIN sales_versions sv ON sv.sales_id = s.id AND sv.version = s.last_version AND sv.tenant_id = s.tenant_id
 	JOIN sales_items si ON si.sales_version_id = sv.id AND si.tenant_id = s.tenant_id
@@ -36,6 +36,84 @@ func (r *dashboardRepository) GetRevenue(ctx context.Context, input domain.Dashb
 	return total, err
 }
 
+// GetLateFeeRevenue soma multas e juros recebidos no período, separados do valor das vendas.
+func (r *dashboardRepository) GetLateFeeRevenue(ctx context.Context, input domain.DashboardQueryInput) (float64, error) {
+	tenantId := ctx.Value(constants.TENANT_KEY)
+	var total float64
+
+	query := `
+	SELECT COALESCE(SUM(pd.fine_value + pd.interest_value), 0)
+	FROM sales s
+	JOIN sales_versions sv ON sv.sales_id = s.id AND sv.version = s.last_version AND sv.tenant_id = s.tenant_id
+	JOIN payments p ON p.sales_version_id = sv.id AND p.tenant_id = s.tenant_id
+	JOIN payment_dates pd ON pd.payment_id = p.id AND pd.tenant_id = s.tenant_id
+	WHERE s.tenant_id = $1
+	  AND ($2::bigint IS NULL OR s.user_id = $2)
+	  AND pd.status = 'PAID'
+	  AND pd.paid_date >= $3
+	  AND pd.paid_date <= $4`
+
+	err := r.db.QueryRowContext(ctx, query, tenantId, input.ResellerId, input.From, input.To).Scan(&total)
+	return total, err
+}
+
+// GetCardFees soma as taxas de cartão das parcelas com recebimento previsto no período.
+func (r *dashboardRepository) GetCardFees(ctx context.Context, input domain.DashboardQueryInput) (float64, error) {
+	tenantId := ctx.Value(constants.TENANT_KEY)
+	var total float64
+
+	query := `
+	SELECT COALESCE(SUM(pd.card_fee_value), 0)
+	FROM sales s
+	JOIN sales_versions sv ON sv.sales_id = s.id AND sv.version = s.last_version AND sv.tenant_id = s.tenant_id
+	JOIN payments p ON p.sales_version_id = sv.id AND p.tenant_id = s.tenant_id
+	JOIN payment_dates pd ON pd.payment_id = p.id AND pd.tenant_id = s.tenant_id
+	WHERE s.tenant_id = $1
+	  AND ($2::bigint IS NULL OR s.user_id = $2)
+	  AND pd.status NOT IN ('CANCEL', 'REVERSAL')
+	  AND pd.settlement_date >= $3::date
+	  AND pd.settlement_date <= $4::date`
+
+	err := r.db.QueryRowContext(ctx, query, tenantId, input.ResellerId, input.From, input.To).Scan(&total)
+	return total, err
+}
+
+// GetCardSettlementsByDay agrupa o valor líquido das parcelas de cartão pela data prevista de depósito.
+func (r *dashboardRepository) GetCardSettlementsByDay(ctx context.Context, input domain.DashboardQueryInput) ([]domain.DashboardTimeSeriesItem, error) {
+	tenantId := ctx.Value(constants.TENANT_KEY)
+	items := make([]domain.DashboardTimeSeriesItem, 0)
+
+	query := `
+	SELECT pd.settlement_date AS day, COALESCE(SUM(pd.installment_value - pd.card_fee_value), 0)
+	FROM sales s
+	JOIN sales_versions sv ON sv.sales_id = s.id AND sv.version = s.last_version AND sv.tenant_id = s.tenant_id
+	JOIN payments p ON p.sales_version_id = sv.id AND p.tenant_id = s.tenant_id
+	JOIN payment_dates pd ON pd.payment_id = p.id AND pd.tenant_id = s.tenant_id
+	WHERE s.tenant_id = $1
+	  AND ($2::bigint IS NULL OR s.user_id = $2)
+	  AND pd.status NOT IN ('CANCEL', 'REVERSAL')
+	  AND pd.settlement_date >= $3::date
+	  AND pd.settlement_date <= $4::date
+	GROUP BY day
+	ORDER BY day ASC`
+
+	rows, err := r.db.QueryContext(ctx, query, tenantId, input.ResellerId, input.From, input.To)
+	if err != nil {
+		return items, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var item domain.DashboardTimeSeriesItem
+		if err := rows.Scan(&item.Date, &item.Value); err != nil {
+			return items, err
+		}
+		items = append(items, item)
+	}
+
+	return items, nil
+}
+
 func (r *dashboardRepository) GetSalesCount(ctx context.Context, input domain.DashboardQueryInput) (int64, error) {
 	tenantId := ctx.Value(constants.TENANT_KEY)
 	var total int64
@@ -61,7 +139,7 @@ func (r *dashboardRepository) GetRevenueByDay(ctx context.Context, input domain.
 	items := make([]domain.DashboardTimeSeriesItem, 0)
 
 	query := `
-	SELECT date_trunc('day', s.date) AS day, COALESCE(SUM(si.quantity * si.unit_price), 0)
+	SELECT date_trunc('day', s.date) AS day, COALESCE(SUM(si.quantity * si.unit_price - si.discount_amount - si.sale_discount_amount), 0)
 	FROM sales s
 	JOIN sales_versions sv ON sv.sales_id = s.id AND sv.version = s.last_version AND sv.tenant_id = s.tenant_id
 	JOIN sales_items si ON si.sales_version_id = sv.id AND si.tenant_id = s.tenant_id
@@ -146,17 +224,34 @@ func (r *dashboardRepository) GetLowStockProducts(ctx context.Context, input dom
 	tenantId := ctx.Value(constants.TENANT_KEY)
 	items := make([]domain.DashboardLowStockItem, 0)
 
+	// Itens com nível configurado são comparados com o próprio mínimo, mesmo
+	// sem saldo cadastrado no estoque. Sem nível, só os itens do estoque
+	// principal são comparados com o limite padrão.
 	query := `
-	SELECT p.id, p.name, COALESCE(SUM(ii.quantity), 0) AS qty
-	FROM products p
-	JOIN skus s ON s.product_id = p.id AND s.tenant_id = p.tenant_id AND s.deleted_at IS NULL
-	LEFT JOIN inventory_items ii ON ii.sku_id = s.id AND ii.tenant_id = p.tenant_id AND ii.deleted_at IS NULL
-	LEFT JOIN inventories inv ON inv.id = ii.inventory_id AND inv.tenant_id = p.tenant_id
-	WHERE p.tenant_id = $1 AND p.deleted_at IS NULL
-	  AND ($2::bigint IS NULL OR inv.user_id = $2)
-	GROUP BY p.id, p.name
-	HAVING COALESCE(SUM(ii.quantity), 0) <= $3
-	ORDER BY qty ASC, p.name ASC`
+	WITH stock AS (
+		SELECT ii.inventory_id, ii.sku_id, ii.quantity
+		FROM inventory_items ii
+		WHERE ii.tenant_id = $1 AND ii.deleted_at IS NULL
+		UNION ALL
+		SELECT sl.inventory_id, sl.sku_id, 0
+		FROM stock_levels sl
+		WHERE sl.tenant_id = $1
+		  AND NOT EXISTS (
+			SELECT 1 FROM inventory_items ii
+			WHERE ii.inventory_id = sl.inventory_id AND ii.sku_id = sl.sku_id AND ii.deleted_at IS NULL
+		  )
+	)
+	SELECT p.id, p.name, s.id, s.code, COALESCE(s.color, ''), COALESCE(s.size, ''), inv.id, inv.type, u.name, st.quantity, COALESCE(sl.min_quantity, $3) AS min_qty
+	FROM stock st
+	JOIN inventories inv ON inv.id = st.inventory_id AND inv.tenant_id = $1
+	LEFT JOIN users u ON u.id = inv.user_id
+	JOIN skus s ON s.id = st.sku_id AND s.tenant_id = $1 AND s.deleted_at IS NULL
+	JOIN products p ON p.id = s.product_id AND p.tenant_id = $1 AND p.deleted_at IS NULL
+	LEFT JOIN stock_levels sl ON sl.inventory_id = st.inventory_id AND sl.sku_id = st.sku_id AND sl.tenant_id = $1
+	WHERE ($2::bigint IS NULL OR inv.user_id = $2)
+	  AND (sl.id IS NOT NULL OR inv.type = 'PRIMARY')
+	  AND st.quantity <= COALESCE(sl.min_quantity, $3)
+	ORDER BY st.quantity - COALESCE(sl.min_quantity, $3) ASC, p.name ASC`
 
 	rows, err := r.db.QueryContext(ctx, query, tenantId, input.ResellerId, input.Threshold)
 	if err != nil {
@@ -166,9 +261,10 @@ func (r *dashboardRepository) GetLowStockProducts(ctx context.Context, input dom
 
 	for rows.Next() {
 		var item domain.DashboardLowStockItem
-		if err := rows.Scan(&item.ProductId, &item.ProductName, &item.Quantity); err != nil {
+		if err := rows.Scan(&item.ProductId, &item.ProductName, &item.Sku.Id, &item.Sku.Code, &item.Sku.Color, &item.Sku.Size, &item.InventoryId, &item.InventoryType, &item.UserName, &item.Quantity, &item.MinQuantity); err != nil {
 			return items, err
 		}
+		item.Sku.Product.Name = item.ProductName
 		items = append(items, item)
 	}
 
@@ -180,7 +276,7 @@ func (r *dashboardRepository) GetRevenueByReseller(ctx context.Context, input do
 	items := make([]domain.DashboardResellerSalesItem, 0)
 
 	query := `
-	SELECT s.user_id, u.name, COALESCE(SUM(si.quantity * si.unit_price), 0) AS revenue
+	SELECT s.user_id, u.name, COALESCE(SUM(si.quantity * si.unit_price - si.discount_amount - si.sale_discount_amount), 0) AS revenue
 	FROM sales s
 	JOIN users u ON u.id = s.user_id AND u.tenant_id = s.tenant_id
 	JOIN sales_versions sv ON sv.sales_id = s.id AND sv.version = s.last_version AND sv.tenant_id = s.tenant_id
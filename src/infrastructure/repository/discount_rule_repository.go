@@ -0,0 +1,46 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/bncunha/erp-api/src/application/constants"
+	"github.com/bncunha/erp-api/src/application/errors"
+	"github.com/bncunha/erp-api/src/domain"
+)
+
+type discountRuleRepository struct {
+	db *sql.DB
+}
+
+func NewDiscountRuleRepository(db *sql.DB) domain.DiscountRuleRepository {
+	return &discountRuleRepository{db}
+}
+
+// Get retorna a regra da empresa ou uma regra sem desconto liberado para
+// revendedores quando ainda não foi configurada.
+func (r *discountRuleRepository) Get(ctx context.Context) (domain.DiscountRule, error) {
+	tenantId := ctx.Value(constants.TENANT_KEY)
+	var rule domain.DiscountRule
+	query := `SELECT reseller_max_percentage FROM discount_rules WHERE tenant_id = $1`
+	err := r.db.QueryRowContext(ctx, query, tenantId).Scan(&rule.ResellerMaxPercentage)
+	if err != nil {
+		if errors.IsNoRowsFinded(err) {
+			return domain.DiscountRule{}, nil
+		}
+		return rule, err
+	}
+	return rule, nil
+}
+
+func (r *discountRuleRepository) Save(ctx context.Context, rule domain.DiscountRule) error {
+	tenantId := ctx.Value(constants.TENANT_KEY)
+	query := `
+	INSERT INTO discount_rules (reseller_max_percentage, tenant_id)
+	VALUES ($1, $2)
+	ON CONFLICT (tenant_id) DO UPDATE SET
+		reseller_max_percentage = EXCLUDED.reseller_max_percentage,
+		updated_at = NOW()`
+	_, err := r.db.ExecContext(ctx, query, rule.ResellerMaxPercentage, tenantId)
+	return err
+}
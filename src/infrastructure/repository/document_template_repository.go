@@ -0,0 +1,53 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/bncunha/erp-api/src/application/constants"
+	"github.com/bncunha/erp-api/src/application/errors"
+	"github.com/bncunha/erp-api/src/domain"
+)
+
+type documentTemplateRepository struct {
+	db *sql.DB
+}
+
+func NewDocumentTemplateRepository(db *sql.DB) domain.DocumentTemplateRepository {
+	return &documentTemplateRepository{db}
+}
+
+// GetByType retorna o modelo personalizado da empresa ou o modelo padrão
+// quando ainda não foi personalizado.
+func (r *documentTemplateRepository) GetByType(ctx context.Context, templateType domain.DocumentTemplateType) (domain.DocumentTemplate, error) {
+	tenantId := ctx.Value(constants.TENANT_KEY)
+	var template domain.DocumentTemplate
+	query := `SELECT id, type, content, updated_at FROM document_templates WHERE type = $1 AND tenant_id = $2`
+	err := r.db.QueryRowContext(ctx, query, templateType, tenantId).Scan(&template.Id, &template.Type, &template.Content, &template.UpdatedAt)
+	if err != nil {
+		if errors.IsNoRowsFinded(err) {
+			return domain.DefaultDocumentTemplate(templateType), nil
+		}
+		return template, err
+	}
+	return template, nil
+}
+
+func (r *documentTemplateRepository) Save(ctx context.Context, template domain.DocumentTemplate) error {
+	tenantId := ctx.Value(constants.TENANT_KEY)
+	query := `
+	INSERT INTO document_templates (type, content, tenant_id)
+	VALUES ($1, $2, $3)
+	ON CONFLICT (tenant_id, type) DO UPDATE SET
+		content = EXCLUDED.content,
+		updated_at = NOW()`
+	_, err := r.db.ExecContext(ctx, query, template.Type, template.Content, tenantId)
+	return err
+}
+
+func (r *documentTemplateRepository) Delete(ctx context.Context, templateType domain.DocumentTemplateType) error {
+	tenantId := ctx.Value(constants.TENANT_KEY)
+	query := `DELETE FROM document_templates WHERE type = $1 AND tenant_id = $2`
+	_, err := r.db.ExecContext(ctx, query, templateType, tenantId)
+	return err
+}
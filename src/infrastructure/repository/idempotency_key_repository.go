@@ -0,0 +1,77 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/bncunha/erp-api/src/application/constants"
+	"github.com/bncunha/erp-api/src/application/errors"
+	"github.com/bncunha/erp-api/src/domain"
+)
+
+type idempotencyKeyRepository struct {
+	db *sql.DB
+}
+
+func NewIdempotencyKeyRepository(db *sql.DB) domain.IdempotencyKeyRepository {
+	return &idempotencyKeyRepository{db}
+}
+
+// Reserve registra a chave como em processamento. Quando a chave já existe,
+// retorna o registro gravado e false, a não ser que a requisição anterior
+// tenha sido abandonada em processamento, caso em que a chave é reservada de novo.
+func (r *idempotencyKeyRepository) Reserve(ctx context.Context, key domain.IdempotencyKey) (domain.IdempotencyKey, bool, error) {
+	tenantId := ctx.Value(constants.TENANT_KEY)
+	query := fmt.Sprintf(`
+	INSERT INTO idempotency_keys (key, scope, request_hash, status, tenant_id)
+	VALUES ($1, $2, $3, $4, $5)
+	ON CONFLICT (tenant_id, scope, key) DO UPDATE SET
+		request_hash = EXCLUDED.request_hash,
+		updated_at = NOW()
+	WHERE idempotency_keys.status = 'PROCESSING'
+	  AND idempotency_keys.updated_at < NOW() - INTERVAL '%d seconds'
+	RETURNING id`, int(domain.IdempotencyKeyLockTimeout.Seconds()))
+	err := r.db.QueryRowContext(ctx, query, key.Key, key.Scope, key.RequestHash, key.Status, tenantId).Scan(&key.Id)
+	if err == nil {
+		return key, true, nil
+	}
+	if !errors.IsNoRowsFinded(err) {
+		return key, false, err
+	}
+
+	var stored domain.IdempotencyKey
+	var responseStatus sql.NullInt64
+	var responseBody sql.NullString
+	query = `
+	SELECT id, key, scope, request_hash, status, response_status, response_body, updated_at
+	FROM idempotency_keys
+	WHERE tenant_id = $1 AND scope = $2 AND key = $3`
+	err = r.db.QueryRowContext(ctx, query, tenantId, key.Scope, key.Key).Scan(&stored.Id, &stored.Key, &stored.Scope, &stored.RequestHash, &stored.Status, &responseStatus, &responseBody, &stored.UpdatedAt)
+	if err != nil {
+		return stored, false, err
+	}
+	stored.ResponseStatus = int(responseStatus.Int64)
+	if responseBody.Valid {
+		stored.ResponseBody = []byte(responseBody.String)
+	}
+	return stored, false, nil
+}
+
+func (r *idempotencyKeyRepository) Complete(ctx context.Context, key domain.IdempotencyKey) error {
+	tenantId := ctx.Value(constants.TENANT_KEY)
+	query := `
+	UPDATE idempotency_keys
+	SET status = $1, response_status = $2, response_body = $3, updated_at = NOW()
+	WHERE id = $4 AND tenant_id = $5`
+	_, err := r.db.ExecContext(ctx, query, key.Status, key.ResponseStatus, string(key.ResponseBody), key.Id, tenantId)
+	return err
+}
+
+// Release apaga a chave de uma requisição que falhou para que possa ser repetida.
+func (r *idempotencyKeyRepository) Release(ctx context.Context, key domain.IdempotencyKey) error {
+	tenantId := ctx.Value(constants.TENANT_KEY)
+	query := `DELETE FROM idempotency_keys WHERE id = $1 AND tenant_id = $2 AND status = 'PROCESSING'`
+	_, err := r.db.ExecContext(ctx, query, key.Id, tenantId)
+	return err
+}
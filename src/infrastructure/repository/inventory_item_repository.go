@@ -184,3 +184,26 @@ func (r *inventoryItemRepository) GetBySkuId(ctx context.Context, skuId int64) (
 
 	return inventories, err
 }
+
+// GetTotalQuantityBySkuIds soma o saldo de cada SKU em todos os estoques,
+// dentro da transação para enxergar as movimentações ainda não confirmadas.
+func (r *inventoryItemRepository) GetTotalQuantityBySkuIds(ctx context.Context, tx *sql.Tx, skuIds []int64) (map[int64]float64, error) {
+	tenantId := ctx.Value(constants.TENANT_KEY)
+	quantities := make(map[int64]float64, len(skuIds))
+
+	query := `SELECT sku_id, COALESCE(SUM(quantity), 0) FROM inventory_items WHERE sku_id = ANY($1) AND tenant_id = $2 AND deleted_at IS NULL GROUP BY sku_id`
+	rows, err := tx.QueryContext(ctx, query, pq.Array(skuIds), tenantId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var skuId int64
+		var quantity float64
+		if err := rows.Scan(&skuId, &quantity); err != nil {
+			return nil, err
+		}
+		quantities[skuId] = quantity
+	}
+	return quantities, rows.Err()
+}
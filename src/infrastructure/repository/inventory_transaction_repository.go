@@ -40,8 +40,8 @@ func (r *inventoryTransactionRepository) Create(ctx context.Context, tx *sql.Tx,
 		nullableSaleVersionId = &transaction.Sale.SalesVersionId
 	}
 
-	query := `INSERT INTO inventory_transactions (quantity, type, date, inventory_out_id, inventory_in_id, inventory_item_id, tenant_id, justification, sales_id, sales_version_id) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10) RETURNING id`
-	err := tx.QueryRowContext(ctx, query, transaction.Quantity, transaction.Type, transaction.Date, nullableInventoryOutId, nullableInventoryInId, transaction.InventoryItem.Id, tenantId, transaction.Justification, nullableSaleId, nullableSaleVersionId).Scan(&insertedID)
+	query := `INSERT INTO inventory_transactions (quantity, type, date, inventory_out_id, inventory_in_id, inventory_item_id, tenant_id, justification, sales_id, sales_version_id, unit_cost) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11) RETURNING id`
+	err := tx.QueryRowContext(ctx, query, transaction.Quantity, transaction.Type, transaction.Date, nullableInventoryOutId, nullableInventoryInId, transaction.InventoryItem.Id, tenantId, transaction.Justification, nullableSaleId, nullableSaleVersionId, transaction.UnitCost).Scan(&insertedID)
 	return insertedID, err
 }
 
@@ -63,6 +63,7 @@ func (r *inventoryTransactionRepository) GetAll(ctx context.Context) ([]domain.G
 	user_origin.name, 
 	user_destination.name, 
 	inv_transactions.justification,
+	inv_transactions.unit_cost,
 	s.id,
 	s.date
 	FROM inventory_transactions inv_transactions
@@ -86,7 +87,7 @@ func (r *inventoryTransactionRepository) GetAll(ctx context.Context) ([]domain.G
 		saleId := sql.NullInt64{}
 		saleDate := sql.NullTime{}
 
-		err = rows.Scan(&inventoryTransaction.Id, &inventoryTransaction.Date, &inventoryTransaction.Type, &inventoryTransaction.Quantity, &inventoryTransaction.SkuCode, &inventoryTransaction.SkuColor, &inventoryTransaction.SkuSize, &inventoryTransaction.ProductName, &inventoryTransaction.InventoryOriginType, &inventoryTransaction.InventoryDestinationType, &inventoryTransaction.UserOriginName, &inventoryTransaction.UserDestinationName, &inventoryTransaction.Justification, &saleId, &saleDate)
+		err = rows.Scan(&inventoryTransaction.Id, &inventoryTransaction.Date, &inventoryTransaction.Type, &inventoryTransaction.Quantity, &inventoryTransaction.SkuCode, &inventoryTransaction.SkuColor, &inventoryTransaction.SkuSize, &inventoryTransaction.ProductName, &inventoryTransaction.InventoryOriginType, &inventoryTransaction.InventoryDestinationType, &inventoryTransaction.UserOriginName, &inventoryTransaction.UserDestinationName, &inventoryTransaction.Justification, &inventoryTransaction.UnitCost, &saleId, &saleDate)
 		if err != nil {
 			return inventoryTransactions, err
 		}
@@ -116,6 +117,7 @@ func (r *inventoryTransactionRepository) GetByInventoryId(ctx context.Context, i
         user_origin.name,
         user_destination.name,
         inv_transactions.justification,
+        inv_transactions.unit_cost,
         s.id,
         s.date
         FROM inventory_transactions inv_transactions
@@ -146,7 +148,7 @@ func (r *inventoryTransactionRepository) GetByInventoryId(ctx context.Context, i
 		saleId := sql.NullInt64{}
 		saleDate := sql.NullTime{}
 
-		err = rows.Scan(&inventoryTransaction.Id, &inventoryTransaction.Date, &inventoryTransaction.Type, &inventoryTransaction.Quantity, &inventoryTransaction.SkuCode, &inventoryTransaction.SkuColor, &inventoryTransaction.SkuSize, &inventoryTransaction.ProductName, &inventoryTransaction.InventoryOriginType, &inventoryTransaction.InventoryDestinationType, &inventoryTransaction.UserOriginName, &inventoryTransaction.UserDestinationName, &inventoryTransaction.Justification, &saleId, &saleDate)
+		err = rows.Scan(&inventoryTransaction.Id, &inventoryTransaction.Date, &inventoryTransaction.Type, &inventoryTransaction.Quantity, &inventoryTransaction.SkuCode, &inventoryTransaction.SkuColor, &inventoryTransaction.SkuSize, &inventoryTransaction.ProductName, &inventoryTransaction.InventoryOriginType, &inventoryTransaction.InventoryDestinationType, &inventoryTransaction.UserOriginName, &inventoryTransaction.UserDestinationName, &inventoryTransaction.Justification, &inventoryTransaction.UnitCost, &saleId, &saleDate)
 		if err != nil {
 			return inventoryTransactions, err
 		}
@@ -176,6 +178,7 @@ func (r *inventoryTransactionRepository) GetBySkuId(ctx context.Context, skuId i
         user_origin.name,
         user_destination.name,
         inv_transactions.justification,
+        inv_transactions.unit_cost,
         s.id,
         s.date
         FROM inventory_transactions inv_transactions
@@ -202,7 +205,7 @@ func (r *inventoryTransactionRepository) GetBySkuId(ctx context.Context, skuId i
 		saleId := sql.NullInt64{}
 		saleDate := sql.NullTime{}
 
-		err = rows.Scan(&inventoryTransaction.Id, &inventoryTransaction.Date, &inventoryTransaction.Type, &inventoryTransaction.Quantity, &inventoryTransaction.SkuCode, &inventoryTransaction.SkuColor, &inventoryTransaction.SkuSize, &inventoryTransaction.ProductName, &inventoryTransaction.InventoryOriginType, &inventoryTransaction.InventoryDestinationType, &inventoryTransaction.UserOriginName, &inventoryTransaction.UserDestinationName, &inventoryTransaction.Justification, &saleId, &saleDate)
+		err = rows.Scan(&inventoryTransaction.Id, &inventoryTransaction.Date, &inventoryTransaction.Type, &inventoryTransaction.Quantity, &inventoryTransaction.SkuCode, &inventoryTransaction.SkuColor, &inventoryTransaction.SkuSize, &inventoryTransaction.ProductName, &inventoryTransaction.InventoryOriginType, &inventoryTransaction.InventoryDestinationType, &inventoryTransaction.UserOriginName, &inventoryTransaction.UserDestinationName, &inventoryTransaction.Justification, &inventoryTransaction.UnitCost, &saleId, &saleDate)
 		if err != nil {
 			return inventoryTransactions, err
 		}
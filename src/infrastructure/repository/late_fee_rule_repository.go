@@ -0,0 +1,46 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/bncunha/erp-api/src/application/constants"
+	"github.com/bncunha/erp-api/src/application/errors"
+	"github.com/bncunha/erp-api/src/domain"
+)
+
+type lateFeeRuleRepository struct {
+	db *sql.DB
+}
+
+func NewLateFeeRuleRepository(db *sql.DB) domain.LateFeeRuleRepository {
+	return &lateFeeRuleRepository{db}
+}
+
+// Get retorna a regra da empresa ou uma regra sem encargos quando ainda não foi configurada.
+func (r *lateFeeRuleRepository) Get(ctx context.Context) (domain.LateFeeRule, error) {
+	tenantId := ctx.Value(constants.TENANT_KEY)
+	var rule domain.LateFeeRule
+	query := `SELECT fine_percentage, daily_interest_percentage FROM late_fee_rules WHERE tenant_id = $1`
+	err := r.db.QueryRowContext(ctx, query, tenantId).Scan(&rule.FinePercentage, &rule.DailyInterestPercentage)
+	if err != nil {
+		if errors.IsNoRowsFinded(err) {
+			return domain.LateFeeRule{}, nil
+		}
+		return rule, err
+	}
+	return rule, nil
+}
+
+func (r *lateFeeRuleRepository) Save(ctx context.Context, rule domain.LateFeeRule) error {
+	tenantId := ctx.Value(constants.TENANT_KEY)
+	query := `
+	INSERT INTO late_fee_rules (fine_percentage, daily_interest_percentage, tenant_id)
+	VALUES ($1, $2, $3)
+	ON CONFLICT (tenant_id) DO UPDATE SET
+		fine_percentage = EXCLUDED.fine_percentage,
+		daily_interest_percentage = EXCLUDED.daily_interest_percentage,
+		updated_at = NOW()`
+	_, err := r.db.ExecContext(ctx, query, rule.FinePercentage, rule.DailyInterestPercentage, tenantId)
+	return err
+}
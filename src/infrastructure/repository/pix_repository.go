@@ -0,0 +1,98 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/bncunha/erp-api/src/application/constants"
+	"github.com/bncunha/erp-api/src/application/errors"
+	"github.com/bncunha/erp-api/src/domain"
+)
+
+type pixRepository struct {
+	db *sql.DB
+}
+
+func NewPixRepository(db *sql.DB) domain.PixRepository {
+	return &pixRepository{db}
+}
+
+// GetSettings retorna a configuração PIX da empresa ou uma configuração vazia quando ainda não foi cadastrada.
+func (r *pixRepository) GetSettings(ctx context.Context) (domain.PixSettings, error) {
+	tenantId := ctx.Value(constants.TENANT_KEY)
+	var settings domain.PixSettings
+	query := `SELECT pix_key, merchant_name, merchant_city, updated_at FROM pix_settings WHERE tenant_id = $1`
+	err := r.db.QueryRowContext(ctx, query, tenantId).Scan(&settings.Key, &settings.MerchantName, &settings.MerchantCity, &settings.UpdatedAt)
+	if err != nil {
+		if errors.IsNoRowsFinded(err) {
+			return domain.PixSettings{}, nil
+		}
+		return settings, err
+	}
+	return settings, nil
+}
+
+func (r *pixRepository) SaveSettings(ctx context.Context, settings domain.PixSettings) error {
+	tenantId := ctx.Value(constants.TENANT_KEY)
+	query := `
+	INSERT INTO pix_settings (pix_key, merchant_name, merchant_city, tenant_id)
+	VALUES ($1, $2, $3, $4)
+	ON CONFLICT (tenant_id) DO UPDATE SET
+		pix_key = EXCLUDED.pix_key,
+		merchant_name = EXCLUDED.merchant_name,
+		merchant_city = EXCLUDED.merchant_city,
+		updated_at = NOW()`
+	_, err := r.db.ExecContext(ctx, query, settings.Key, settings.MerchantName, settings.MerchantCity, tenantId)
+	return err
+}
+
+func (r *pixRepository) GetPendingPayments(ctx context.Context) ([]domain.PixPendingPayment, error) {
+	tenantId := ctx.Value(constants.TENANT_KEY)
+	payments := make([]domain.PixPendingPayment, 0)
+
+	query := `
+	SELECT
+		pd.id,
+		s.id,
+		s.code,
+		c.name,
+		pd.installment_number,
+		pd.due_date,
+		pd.installment_value - COALESCE(pr.received_value, 0) AS value
+	FROM sales s
+	JOIN sales_versions sv ON sv.sales_id = s.id AND sv.version = s.last_version AND sv.tenant_id = s.tenant_id
+	JOIN customers c ON c.id = s.customer_id AND c.tenant_id = s.tenant_id
+	JOIN payments p ON p.sales_version_id = sv.id AND p.tenant_id = s.tenant_id
+	JOIN payment_dates pd ON pd.payment_id = p.id AND pd.tenant_id = s.tenant_id
+	LEFT JOIN (
+		SELECT payment_date_id, SUM(value) AS received_value
+		FROM payment_receipts
+		GROUP BY payment_date_id
+	) pr ON pr.payment_date_id = pd.id
+	WHERE s.tenant_id = $1
+	  AND p.payment_type = 'PIX'
+	  AND pd.status IN ('PENDING','DELAYED')
+	ORDER BY pd.due_date ASC, pd.id ASC`
+
+	rows, err := r.db.QueryContext(ctx, query, tenantId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var payment domain.PixPendingPayment
+		if err := rows.Scan(
+			&payment.PaymentDateId,
+			&payment.SaleId,
+			&payment.SaleCode,
+			&payment.CustomerName,
+			&payment.InstallmentNumber,
+			&payment.DueDate,
+			&payment.Value,
+		); err != nil {
+			return nil, err
+		}
+		payments = append(payments, payment)
+	}
+	return payments, nil
+}
@@ -0,0 +1,246 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/bncunha/erp-api/src/application/constants"
+	"github.com/bncunha/erp-api/src/application/errors"
+	"github.com/bncunha/erp-api/src/domain"
+)
+
+type purchaseOrderRepository struct {
+	db *sql.DB
+}
+
+func NewPurchaseOrderRepository(db *sql.DB) domain.PurchaseOrderRepository {
+	return &purchaseOrderRepository{db}
+}
+
+func (r *purchaseOrderRepository) Create(ctx context.Context, tx *sql.Tx, order domain.PurchaseOrder, createdByUserId int64) (int64, error) {
+	tenantId := ctx.Value(constants.TENANT_KEY)
+	var id int64
+
+	query := `
+	INSERT INTO purchase_orders (code, supplier_id, status, expected_date, notes, created_by_user_id, created_at, tenant_id)
+	VALUES ($1, $2, $3, $4, NULLIF($5, ''), $6, $7, $8)
+	RETURNING id`
+	err := tx.QueryRowContext(ctx, query, order.Code, order.Supplier.Id, order.Status, order.ExpectedDate, order.Notes, createdByUserId, order.CreatedAt, tenantId).Scan(&id)
+	if err != nil {
+		return 0, err
+	}
+
+	query = `INSERT INTO purchase_order_items (purchase_order_id, sku_id, quantity, unit_cost, tenant_id) VALUES %s`
+	valueStrings := make([]string, 0, len(order.Items))
+	valueArgs := make([]interface{}, 0, len(order.Items)*5)
+	for i, item := range order.Items {
+		n := i * 5
+		valueStrings = append(valueStrings, fmt.Sprintf("($%d,$%d,$%d,$%d,$%d)", n+1, n+2, n+3, n+4, n+5))
+		valueArgs = append(valueArgs, id, item.Sku.Id, item.Quantity, item.UnitCost, tenantId)
+	}
+	_, err = tx.ExecContext(ctx, fmt.Sprintf(query, strings.Join(valueStrings, ",")), valueArgs...)
+	return id, err
+}
+
+func (r *purchaseOrderRepository) GetById(ctx context.Context, id int64) (domain.PurchaseOrder, error) {
+	tenantId := ctx.Value(constants.TENANT_KEY)
+	var order domain.PurchaseOrder
+
+	query := `
+	SELECT po.id, po.code, s.id, s.name, COALESCE(s.cnpj, ''), po.status, po.expected_date, COALESCE(po.notes, ''), po.created_at
+	FROM purchase_orders po
+	JOIN suppliers s ON s.id = po.supplier_id
+	WHERE po.id = $1 AND po.tenant_id = $2`
+	err := r.db.QueryRowContext(ctx, query, id, tenantId).Scan(&order.Id, &order.Code, &order.Supplier.Id, &order.Supplier.Name, &order.Supplier.Cnpj, &order.Status, &order.ExpectedDate, &order.Notes, &order.CreatedAt)
+	if err != nil {
+		if errors.IsNoRowsFinded(err) {
+			return order, domain.ErrPurchaseOrderNotFound
+		}
+		return order, err
+	}
+
+	order.Items = make([]domain.PurchaseOrderItem, 0)
+	query = `
+	SELECT poi.id, sku.id, sku.code, COALESCE(sku.color, ''), COALESCE(sku.size, ''), sku.cost, sku.price, p.name, poi.quantity, poi.received_quantity, poi.unit_cost
+	FROM purchase_order_items poi
+	JOIN skus sku ON sku.id = poi.sku_id
+	JOIN products p ON p.id = sku.product_id
+	WHERE poi.purchase_order_id = $1 AND poi.tenant_id = $2
+	ORDER BY poi.id ASC`
+	rows, err := r.db.QueryContext(ctx, query, id, tenantId)
+	if err != nil {
+		return order, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var item domain.PurchaseOrderItem
+		if err := rows.Scan(&item.Id, &item.Sku.Id, &item.Sku.Code, &item.Sku.Color, &item.Sku.Size, &item.Sku.Cost, &item.Sku.Price, &item.Sku.Product.Name, &item.Quantity, &item.ReceivedQuantity, &item.UnitCost); err != nil {
+			return order, err
+		}
+		order.Items = append(order.Items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return order, err
+	}
+
+	order.Receipts, err = r.getReceipts(ctx, id)
+	return order, err
+}
+
+func (r *purchaseOrderRepository) getReceipts(ctx context.Context, orderId int64) ([]domain.PurchaseReceipt, error) {
+	tenantId := ctx.Value(constants.TENANT_KEY)
+	receipts := make([]domain.PurchaseReceipt, 0)
+
+	query := `
+	SELECT pr.id, COALESCE(pr.notes, ''), pr.received_at, pri.purchase_order_item_id, sku.id, sku.code, COALESCE(sku.color, ''), COALESCE(sku.size, ''), p.name, pri.quantity, pri.unit_cost
+	FROM purchase_receipts pr
+	JOIN purchase_receipt_items pri ON pri.purchase_receipt_id = pr.id
+	JOIN skus sku ON sku.id = pri.sku_id
+	JOIN products p ON p.id = sku.product_id
+	WHERE pr.purchase_order_id = $1 AND pr.tenant_id = $2
+	ORDER BY pr.received_at ASC, pr.id ASC, pri.id ASC`
+	rows, err := r.db.QueryContext(ctx, query, orderId, tenantId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var receipt domain.PurchaseReceipt
+		var item domain.PurchaseReceiptItem
+		if err := rows.Scan(&receipt.Id, &receipt.Notes, &receipt.ReceivedAt, &item.PurchaseOrderItemId, &item.Sku.Id, &item.Sku.Code, &item.Sku.Color, &item.Sku.Size, &item.Sku.Product.Name, &item.Quantity, &item.UnitCost); err != nil {
+			return nil, err
+		}
+		if len(receipts) == 0 || receipts[len(receipts)-1].Id != receipt.Id {
+			receipts = append(receipts, receipt)
+		}
+		last := &receipts[len(receipts)-1]
+		last.Items = append(last.Items, item)
+	}
+	return receipts, rows.Err()
+}
+
+func (r *purchaseOrderRepository) GetAll(ctx context.Context, input domain.GetPurchaseOrdersInput) ([]domain.GetPurchaseOrdersOutput, error) {
+	tenantId := ctx.Value(constants.TENANT_KEY)
+	orders := make([]domain.GetPurchaseOrdersOutput, 0)
+
+	query := `
+	SELECT po.id, po.code, s.id, s.name, po.status, po.expected_date, po.created_at,
+		COALESCE(SUM(poi.quantity), 0),
+		COALESCE(SUM(poi.received_quantity), 0),
+		COALESCE(SUM(poi.quantity * poi.unit_cost), 0)
+	FROM purchase_orders po
+	JOIN suppliers s ON s.id = po.supplier_id
+	LEFT JOIN purchase_order_items poi ON poi.purchase_order_id = po.id
+	WHERE po.tenant_id = $1
+		AND ($2::bigint IS NULL OR po.supplier_id = $2)
+		AND ($3::varchar IS NULL OR po.status = $3)
+	GROUP BY po.id, s.id
+	ORDER BY po.created_at DESC, po.id DESC`
+	rows, err := r.db.QueryContext(ctx, query, tenantId, input.SupplierId, input.Status)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var order domain.GetPurchaseOrdersOutput
+		if err := rows.Scan(&order.Id, &order.Code, &order.SupplierId, &order.SupplierName, &order.Status, &order.ExpectedDate, &order.CreatedAt,
+			&order.TotalQuantity, &order.ReceivedQuantity, &order.TotalValue); err != nil {
+			return nil, err
+		}
+		orders = append(orders, order)
+	}
+	return orders, nil
+}
+
+// CreateReceipt grava o recebimento e soma as quantidades recebidas aos itens
+// do pedido. A soma só é aplicada enquanto houver saldo pendente, evitando que
+// dois recebimentos simultâneos ultrapassem o pedido.
+func (r *purchaseOrderRepository) CreateReceipt(ctx context.Context, tx *sql.Tx, orderId int64, receipt domain.PurchaseReceipt, receivedByUserId int64) (int64, error) {
+	tenantId := ctx.Value(constants.TENANT_KEY)
+	var id int64
+
+	query := `
+	INSERT INTO purchase_receipts (purchase_order_id, notes, received_by_user_id, received_at, tenant_id)
+	VALUES ($1, NULLIF($2, ''), $3, $4, $5)
+	RETURNING id`
+	err := tx.QueryRowContext(ctx, query, orderId, receipt.Notes, receivedByUserId, receipt.ReceivedAt, tenantId).Scan(&id)
+	if err != nil {
+		return 0, err
+	}
+
+	query = `INSERT INTO purchase_receipt_items (purchase_receipt_id, purchase_order_item_id, sku_id, quantity, unit_cost, tenant_id) VALUES %s`
+	valueStrings := make([]string, 0, len(receipt.Items))
+	valueArgs := make([]interface{}, 0, len(receipt.Items)*6)
+	for i, item := range receipt.Items {
+		n := i * 6
+		valueStrings = append(valueStrings, fmt.Sprintf("($%d,$%d,$%d,$%d,$%d,$%d)", n+1, n+2, n+3, n+4, n+5, n+6))
+		valueArgs = append(valueArgs, id, item.PurchaseOrderItemId, item.Sku.Id, item.Quantity, item.UnitCost, tenantId)
+	}
+	if _, err = tx.ExecContext(ctx, fmt.Sprintf(query, strings.Join(valueStrings, ",")), valueArgs...); err != nil {
+		return 0, err
+	}
+
+	query = `
+	UPDATE purchase_order_items SET received_quantity = received_quantity + $1
+	WHERE id = $2 AND purchase_order_id = $3 AND tenant_id = $4 AND received_quantity + $1 <= quantity + 0.0005`
+	for _, item := range receipt.Items {
+		result, err := tx.ExecContext(ctx, query, item.Quantity, item.PurchaseOrderItemId, orderId, tenantId)
+		if err != nil {
+			return 0, err
+		}
+		affected, err := result.RowsAffected()
+		if err != nil {
+			return 0, err
+		}
+		if affected == 0 {
+			return 0, domain.ErrPurchaseReceiptExceedsPending
+		}
+	}
+	return id, nil
+}
+
+func (r *purchaseOrderRepository) UpdateStatus(ctx context.Context, tx *sql.Tx, order domain.PurchaseOrder) error {
+	tenantId := ctx.Value(constants.TENANT_KEY)
+	query := `UPDATE purchase_orders SET status = $1 WHERE id = $2 AND tenant_id = $3 AND status NOT IN ('RECEIVED', 'CANCELED')`
+	result, err := tx.ExecContext(ctx, query, order.Status, order.Id, tenantId)
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return domain.ErrPurchaseOrderClosed
+	}
+	return nil
+}
+
+func (r *purchaseOrderRepository) GetPurchasesBySkuId(ctx context.Context, skuId int64) ([]domain.GetSkuPurchasesOutput, error) {
+	tenantId := ctx.Value(constants.TENANT_KEY)
+	purchases := make([]domain.GetSkuPurchasesOutput, 0)
+
+	query := `
+	SELECT pr.id, pr.received_at, po.id, po.code, s.id, s.name, pri.quantity, pri.unit_cost
+	FROM purchase_receipt_items pri
+	JOIN purchase_receipts pr ON pr.id = pri.purchase_receipt_id
+	JOIN purchase_orders po ON po.id = pr.purchase_order_id
+	JOIN suppliers s ON s.id = po.supplier_id
+	WHERE pri.sku_id = $1 AND pri.tenant_id = $2
+	ORDER BY pr.received_at DESC, pr.id DESC`
+	rows, err := r.db.QueryContext(ctx, query, skuId, tenantId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var purchase domain.GetSkuPurchasesOutput
+		if err := rows.Scan(&purchase.ReceiptId, &purchase.ReceivedAt, &purchase.PurchaseOrderId, &purchase.PurchaseOrderCode, &purchase.SupplierId, &purchase.SupplierName, &purchase.Quantity, &purchase.UnitCost); err != nil {
+			return nil, err
+		}
+		purchases = append(purchases, purchase)
+	}
+	return purchases, nil
+}
@@ -0,0 +1,292 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/bncunha/erp-api/src/application/constants"
+	"github.com/bncunha/erp-api/src/application/errors"
+	"github.com/bncunha/erp-api/src/domain"
+	"github.com/lib/pq"
+)
+
+type quoteRepository struct {
+	db *sql.DB
+}
+
+func NewQuoteRepository(db *sql.DB) domain.QuoteRepository {
+	return &quoteRepository{db}
+}
+
+func (r *quoteRepository) Create(ctx context.Context, tx *sql.Tx, quote domain.Quote) (int64, error) {
+	tenantId := ctx.Value(constants.TENANT_KEY)
+	var insertedId int64
+	query := `INSERT INTO quotes (code, date, expires_at, status, reserve_stock, inventory_id, user_id, customer_id, discount_type, discount_value, discount_reason, tenant_id) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, NULLIF($9, ''), $10, NULLIF($11, ''), $12) RETURNING id`
+	err := tx.QueryRowContext(ctx, query, quote.Code, quote.Date, quote.ExpiresAt, quote.Status, quote.ReserveStock, quote.InventoryId, quote.User.Id, quote.Customer.Id, string(quote.Discount.Type), quote.Discount.Value, quote.Discount.Reason, tenantId).Scan(&insertedId)
+	return insertedId, err
+}
+
+func (r *quoteRepository) CreateItems(ctx context.Context, tx *sql.Tx, quoteId int64, items []domain.SalesItem) error {
+	if len(items) == 0 {
+		return nil
+	}
+	tenantId := ctx.Value(constants.TENANT_KEY)
+
+	query := `INSERT INTO quote_items (quote_id, sku_id, quantity, unit_price, discount_type, discount_value, discount_reason, discount_amount, sale_discount_amount, tenant_id) VALUES %s`
+	valueStrings := make([]string, 0, len(items))
+	valueArgs := make([]interface{}, 0, len(items)*10)
+	for i, item := range items {
+		n := i * 10
+		valueStrings = append(valueStrings, fmt.Sprintf("($%d,$%d,$%d,$%d,NULLIF($%d, ''),$%d,NULLIF($%d, ''),$%d,$%d,$%d)", n+1, n+2, n+3, n+4, n+5, n+6, n+7, n+8, n+9, n+10))
+		valueArgs = append(valueArgs,
+			quoteId,
+			item.Sku.Id,
+			item.Quantity,
+			item.Sku.Price,
+			string(item.Discount.Type),
+			item.Discount.Value,
+			item.Discount.Reason,
+			item.DiscountAmount,
+			item.SaleDiscountAmount,
+			tenantId,
+		)
+	}
+	_, err := tx.ExecContext(ctx, fmt.Sprintf(query, strings.Join(valueStrings, ",")), valueArgs...)
+	return err
+}
+
+func (r *quoteRepository) CreatePayments(ctx context.Context, tx *sql.Tx, quoteId int64, payments []domain.QuotePayment) error {
+	if len(payments) == 0 {
+		return nil
+	}
+	tenantId := ctx.Value(constants.TENANT_KEY)
+
+	query := `INSERT INTO quote_payments (quote_id, payment_type, value, installments_quantity, first_installment_date, tenant_id) VALUES %s`
+	valueStrings := make([]string, 0, len(payments))
+	valueArgs := make([]interface{}, 0, len(payments)*6)
+	for i, payment := range payments {
+		n := i * 6
+		valueStrings = append(valueStrings, fmt.Sprintf("($%d,$%d,$%d,$%d,$%d,$%d)", n+1, n+2, n+3, n+4, n+5, n+6))
+		valueArgs = append(valueArgs,
+			quoteId,
+			payment.PaymentType,
+			payment.Value,
+			payment.InstallmentsQuantity,
+			payment.FirstInstallmentDate,
+			tenantId,
+		)
+	}
+	_, err := tx.ExecContext(ctx, fmt.Sprintf(query, strings.Join(valueStrings, ",")), valueArgs...)
+	return err
+}
+
+func (r *quoteRepository) GetAll(ctx context.Context, input domain.GetQuotesInput) ([]domain.GetQuotesOutput, error) {
+	tenantId := ctx.Value(constants.TENANT_KEY)
+	quotes := make([]domain.GetQuotesOutput, 0)
+
+	query := `
+	SELECT
+		q.id,
+		q.code,
+		q.date,
+		q.expires_at,
+		CASE
+			WHEN q.status = 'OPEN' AND q.expires_at IS NOT NULL AND q.expires_at <= NOW() THEN 'EXPIRED'
+			ELSE q.status
+		END AS status,
+		q.reserve_stock,
+		u.name,
+		c.name,
+		COALESCE(SUM(qi.quantity * qi.unit_price - qi.discount_amount - qi.sale_discount_amount), 0) AS total_value,
+		q.sales_id
+	FROM quotes q
+	JOIN users u ON u.id = q.user_id AND u.tenant_id = q.tenant_id
+	JOIN customers c ON c.id = q.customer_id AND c.tenant_id = q.tenant_id
+	LEFT JOIN quote_items qi ON qi.quote_id = q.id AND qi.tenant_id = q.tenant_id
+	WHERE q.tenant_id = $1
+	  AND ($2::bigint[] IS NULL OR q.user_id = ANY($2))
+	  AND ($3::bigint[] IS NULL OR q.customer_id = ANY($3))
+	  AND ($4::text IS NULL OR $4 = CASE
+			WHEN q.status = 'OPEN' AND q.expires_at IS NOT NULL AND q.expires_at <= NOW() THEN 'EXPIRED'
+			ELSE q.status
+		END)
+	GROUP BY q.id, u.name, c.name
+	ORDER BY q.date DESC, q.id DESC`
+	rows, err := r.db.QueryContext(ctx, query, tenantId, pq.Array(input.UserId), pq.Array(input.CustomerId), input.Status)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var quote domain.GetQuotesOutput
+		if err := rows.Scan(&quote.Id, &quote.Code, &quote.Date, &quote.ExpiresAt, &quote.Status, &quote.ReserveStock, &quote.SellerName, &quote.CustomerName, &quote.TotalValue, &quote.SalesId); err != nil {
+			return nil, err
+		}
+		quotes = append(quotes, quote)
+	}
+	return quotes, nil
+}
+
+func (r *quoteRepository) GetById(ctx context.Context, id int64) (domain.Quote, error) {
+	tenantId := ctx.Value(constants.TENANT_KEY)
+	var quote domain.Quote
+
+	query := `
+	SELECT q.id, q.code, q.date, q.expires_at, q.status, q.reserve_stock, q.inventory_id, u.id, u.name, c.id, c.name,
+		COALESCE(q.discount_type, ''), q.discount_value, COALESCE(q.discount_reason, ''), q.sales_id
+	FROM quotes q
+	JOIN users u ON u.id = q.user_id AND u.tenant_id = q.tenant_id
+	JOIN customers c ON c.id = q.customer_id AND c.tenant_id = q.tenant_id
+	WHERE q.id = $1 AND q.tenant_id = $2`
+	err := r.db.QueryRowContext(ctx, query, id, tenantId).Scan(&quote.Id, &quote.Code, &quote.Date, &quote.ExpiresAt, &quote.Status, &quote.ReserveStock, &quote.InventoryId, &quote.User.Id, &quote.User.Name, &quote.Customer.Id, &quote.Customer.Name, &quote.Discount.Type, &quote.Discount.Value, &quote.Discount.Reason, &quote.SalesId)
+	if err != nil {
+		if errors.IsNoRowsFinded(err) {
+			return quote, domain.ErrQuoteNotFound
+		}
+		return quote, err
+	}
+
+	quote.Items, err = r.getItems(ctx, id)
+	if err != nil {
+		return quote, err
+	}
+	quote.Payments, err = r.getPayments(ctx, id)
+	return quote, err
+}
+
+func (r *quoteRepository) getItems(ctx context.Context, quoteId int64) ([]domain.SalesItem, error) {
+	tenantId := ctx.Value(constants.TENANT_KEY)
+	items := make([]domain.SalesItem, 0)
+
+	query := `
+	SELECT qi.quantity, qi.unit_price, COALESCE(qi.discount_type, ''), qi.discount_value, COALESCE(qi.discount_reason, ''), qi.discount_amount, qi.sale_discount_amount,
+		s.id, s.code, s.color, s.size, p.name, p.description
+	FROM quote_items qi
+	JOIN skus s ON s.id = qi.sku_id
+	JOIN products p ON p.id = s.product_id
+	WHERE qi.quote_id = $1 AND qi.tenant_id = $2
+	ORDER BY qi.id ASC`
+	rows, err := r.db.QueryContext(ctx, query, quoteId, tenantId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var item domain.SalesItem
+		if err := rows.Scan(&item.Quantity, &item.Sku.Price, &item.Discount.Type, &item.Discount.Value, &item.Discount.Reason, &item.DiscountAmount, &item.SaleDiscountAmount, &item.Sku.Id, &item.Sku.Code, &item.Sku.Color, &item.Sku.Size, &item.Sku.Product.Name, &item.Sku.Product.Description); err != nil {
+			return nil, err
+		}
+		item.UnitPrice = item.Sku.Price
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+func (r *quoteRepository) getPayments(ctx context.Context, quoteId int64) ([]domain.QuotePayment, error) {
+	tenantId := ctx.Value(constants.TENANT_KEY)
+	payments := make([]domain.QuotePayment, 0)
+
+	query := `
+	SELECT payment_type, value, installments_quantity, first_installment_date
+	FROM quote_payments
+	WHERE quote_id = $1 AND tenant_id = $2
+	ORDER BY id ASC`
+	rows, err := r.db.QueryContext(ctx, query, quoteId, tenantId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var payment domain.QuotePayment
+		if err := rows.Scan(&payment.PaymentType, &payment.Value, &payment.InstallmentsQuantity, &payment.FirstInstallmentDate); err != nil {
+			return nil, err
+		}
+		payments = append(payments, payment)
+	}
+	return payments, nil
+}
+
+func (r *quoteRepository) Cancel(ctx context.Context, id int64) error {
+	tenantId := ctx.Value(constants.TENANT_KEY)
+	query := `UPDATE quotes SET status = 'CANCELLED', updated_at = NOW() WHERE id = $1 AND tenant_id = $2 AND status = 'OPEN'`
+	result, err := r.db.ExecContext(ctx, query, id, tenantId)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return domain.ErrQuoteNotOpen
+	}
+	return nil
+}
+
+// MarkAsConverted vincula o orçamento à venda gerada. Só altera orçamentos em
+// aberto e dentro da validade, o que impede converter o mesmo orçamento duas vezes.
+func (r *quoteRepository) MarkAsConverted(ctx context.Context, tx *sql.Tx, id int64, salesId int64) error {
+	tenantId := ctx.Value(constants.TENANT_KEY)
+	query := `
+	UPDATE quotes SET status = 'CONVERTED', sales_id = $1, updated_at = NOW()
+	WHERE id = $2 AND tenant_id = $3 AND status = 'OPEN' AND (expires_at IS NULL OR expires_at > NOW())`
+	result, err := tx.ExecContext(ctx, query, salesId, id, tenantId)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return domain.ErrQuoteNotOpen
+	}
+	return nil
+}
+
+// GetReservedQuantitiesForUpdate soma, por SKU, as quantidades reservadas no
+// estoque por orçamentos em aberto e dentro da validade, ignorando
+// exceptQuoteId. O estoque fica bloqueado na transação, para que reservas e
+// saídas concorrentes não usem o mesmo saldo.
+func (r *quoteRepository) GetReservedQuantitiesForUpdate(ctx context.Context, tx *sql.Tx, inventoryId int64, skuIds []int64, exceptQuoteId int64) (map[int64]float64, error) {
+	if tx == nil {
+		return nil, errors.New("transaction not provided")
+	}
+	tenantId := ctx.Value(constants.TENANT_KEY)
+	reserved := make(map[int64]float64)
+
+	lockQuery := `SELECT id FROM inventories WHERE id = $1 AND tenant_id = $2 FOR UPDATE`
+	var lockedId int64
+	if err := tx.QueryRowContext(ctx, lockQuery, inventoryId, tenantId).Scan(&lockedId); err != nil {
+		return nil, err
+	}
+
+	query := `
+	SELECT qi.sku_id, SUM(qi.quantity)
+	FROM quotes q
+	JOIN quote_items qi ON qi.quote_id = q.id AND qi.tenant_id = q.tenant_id
+	WHERE q.tenant_id = $1
+	  AND q.inventory_id = $2
+	  AND qi.sku_id = ANY($3)
+	  AND q.id <> $4
+	  AND q.reserve_stock
+	  AND q.status = 'OPEN'
+	  AND (q.expires_at IS NULL OR q.expires_at > NOW())
+	GROUP BY qi.sku_id`
+	rows, err := tx.QueryContext(ctx, query, tenantId, inventoryId, pq.Array(skuIds), exceptQuoteId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var skuId int64
+		var quantity float64
+		if err := rows.Scan(&skuId, &quantity); err != nil {
+			return nil, err
+		}
+		reserved[skuId] = quantity
+	}
+	return reserved, rows.Err()
+}
@@ -0,0 +1,133 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/bncunha/erp-api/src/application/constants"
+	"github.com/bncunha/erp-api/src/domain"
+	"github.com/lib/pq"
+)
+
+type receivableRepository struct {
+	db *sql.DB
+}
+
+func NewReceivableRepository(db *sql.DB) domain.ReceivableRepository {
+	return &receivableRepository{db}
+}
+
+func (r *receivableRepository) GetAll(ctx context.Context, input domain.GetReceivablesInput) ([]domain.Receivable, error) {
+	tenantId := ctx.Value(constants.TENANT_KEY)
+	receivables := make([]domain.Receivable, 0)
+
+	query := `
+	SELECT
+		pd.id,
+		s.id,
+		s.code,
+		s.date,
+		u.id,
+		u.name,
+		c.id,
+		c.name,
+		p.payment_type,
+		pd.installment_number,
+		pd.installment_value,
+		COALESCE(pr.received_value, 0) AS received_value,
+		pd.due_date,
+		CASE
+			WHEN pd.status = 'DELAYED' OR pd.due_date < CURRENT_DATE
+				THEN 'DELAYED'
+				ELSE 'PENDING'
+		END AS status
+	FROM sales s
+	JOIN sales_versions sv ON sv.sales_id = s.id AND sv.version = s.last_version AND sv.tenant_id = s.tenant_id
+	JOIN users u ON u.id = s.user_id AND u.tenant_id = s.tenant_id
+	JOIN customers c ON c.id = s.customer_id AND c.tenant_id = s.tenant_id
+	JOIN payments p ON p.sales_version_id = sv.id AND p.tenant_id = s.tenant_id
+	JOIN payment_dates pd ON pd.payment_id = p.id AND pd.tenant_id = s.tenant_id
+	LEFT JOIN (
+		SELECT payment_date_id, SUM(value) AS received_value
+		FROM payment_receipts
+		GROUP BY payment_date_id
+	) pr ON pr.payment_date_id = pd.id
+	WHERE s.tenant_id = $1
+	  AND p.payment_type = 'CREDIT_STORE'
+	  AND pd.status IN ('PENDING','DELAYED')
+	  AND ($2::bigint[] IS NULL OR s.user_id = ANY($2))
+	  AND ($3::bigint[] IS NULL OR s.customer_id = ANY($3))
+	  AND ($4::date IS NULL OR pd.due_date >= $4)
+	  AND ($5::date IS NULL OR pd.due_date <= $5)
+	  AND ($6::text IS NULL OR ($6 = 'DELAYED') = (pd.status = 'DELAYED' OR pd.due_date < CURRENT_DATE))
+	ORDER BY pd.due_date ASC, pd.id ASC`
+	valueArgs := []interface{}{tenantId, pq.Array(input.UserId), pq.Array(input.CustomerId), input.InitialDueDate, input.FinalDueDate, input.PaymentStatus}
+
+	rows, err := r.db.QueryContext(ctx, query, valueArgs...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var receivable domain.Receivable
+		if err := rows.Scan(
+			&receivable.PaymentDateId,
+			&receivable.SaleId,
+			&receivable.SaleCode,
+			&receivable.SaleDate,
+			&receivable.SellerId,
+			&receivable.SellerName,
+			&receivable.CustomerId,
+			&receivable.CustomerName,
+			&receivable.PaymentType,
+			&receivable.InstallmentNumber,
+			&receivable.InstallmentValue,
+			&receivable.ReceivedValue,
+			&receivable.DueDate,
+			&receivable.Status,
+		); err != nil {
+			return nil, err
+		}
+		receivables = append(receivables, receivable)
+	}
+	return receivables, nil
+}
+
+func (r *receivableRepository) UpdateDelayedPaymentDates(ctx context.Context) (int64, error) {
+	query := `
+	UPDATE payment_dates pd
+	SET status = 'DELAYED'
+	FROM payments p
+	WHERE p.id = pd.payment_id
+	  AND p.tenant_id = pd.tenant_id
+	  AND p.payment_type = 'CREDIT_STORE'
+	  AND pd.status = 'PENDING'
+	  AND pd.due_date < CURRENT_DATE`
+	result, err := r.db.ExecContext(ctx, query)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+// HasOpenInstallmentsByUserId indica se o vendedor possui parcelas pendentes ou
+// em atraso em suas vendas, em qualquer forma de pagamento.
+func (r *receivableRepository) HasOpenInstallmentsByUserId(ctx context.Context, userId int64) (bool, error) {
+	tenantId := ctx.Value(constants.TENANT_KEY)
+	query := `
+	SELECT EXISTS (
+		SELECT 1
+		FROM sales s
+		JOIN sales_versions sv ON sv.sales_id = s.id AND sv.version = s.last_version AND sv.tenant_id = s.tenant_id
+		JOIN payments p ON p.sales_version_id = sv.id AND p.tenant_id = s.tenant_id
+		JOIN payment_dates pd ON pd.payment_id = p.id AND pd.tenant_id = s.tenant_id
+		WHERE s.tenant_id = $1
+		  AND s.user_id = $2
+		  AND pd.status IN ('PENDING','DELAYED')
+	)`
+	var exists bool
+	if err := r.db.QueryRowContext(ctx, query, tenantId, userId).Scan(&exists); err != nil {
+		return false, err
+	}
+	return exists, nil
+}
@@ -0,0 +1,57 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/bncunha/erp-api/src/application/constants"
+)
+
+func TestReceivableRepositoryUpdateDelayedPaymentDates(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock: %v", err)
+	}
+	defer db.Close()
+
+	repo := NewReceivableRepository(db)
+	mock.ExpectExec(`UPDATE payment_dates pd\s+SET status = 'DELAYED'\s+FROM payments p[\s\S]*p\.payment_type = 'CREDIT_STORE'[\s\S]*pd\.status = 'PENDING'`).
+		WillReturnResult(sqlmock.NewResult(0, 3))
+
+	updated, err := repo.UpdateDelayedPaymentDates(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if updated != 3 {
+		t.Fatalf("expected 3 rows updated, got %d", updated)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet expectations: %v", err)
+	}
+}
+
+func TestReceivableRepositoryHasOpenInstallmentsByUserId(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock: %v", err)
+	}
+	defer db.Close()
+
+	repo := NewReceivableRepository(db)
+	ctx := context.WithValue(context.Background(), constants.TENANT_KEY, int64(1))
+	mock.ExpectQuery(`SELECT EXISTS[\s\S]*s\.user_id = \$2\s+AND pd\.status IN \('PENDING','DELAYED'\)\s+\)`).
+		WithArgs(int64(1), int64(7)).
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+
+	hasOpen, err := repo.HasOpenInstallmentsByUserId(ctx, 7)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !hasOpen {
+		t.Fatalf("expected open installments")
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet expectations: %v", err)
+	}
+}
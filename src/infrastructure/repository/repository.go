@@ -8,26 +8,42 @@ import (
 )
 
 type Repository struct {
-	db                             *sql.DB
-	ProductRepository              domain.ProductRepository
-	CategoryRepository             domain.CategoryRepository
-	SkuRepository                  domain.SkuRepository
-	UserRepository                 domain.UserRepository
-	UserTokenRepository            domain.UserTokenRepository
-	InventoryRepository            domain.InventoryRepository
-	InventoryItemRepository        domain.InventoryItemRepository
-	InventoryTransactionRepository domain.InventoryTransactionRepository
-	SalesRepository                domain.SalesRepository
-	CustomerRepository             domain.CustomerRepository
-	CompanyRepository              domain.CompanyRepository
-	AddressRepository              domain.AddressRepository
-	LegalDocumentRepository        domain.LegalDocumentRepository
-	LegalAcceptanceRepository      domain.LegalAcceptanceRepository
-	DashboardRepository            domain.DashboardRepository
-	PlanRepository                 domain.PlanRepository
-	SubscriptionRepository         domain.SubscriptionRepository
-	BillingPaymentRepository       domain.BillingPaymentRepository
-	NewsRepository                 domain.NewsRepository
+	db                              *sql.DB
+	ProductRepository               domain.ProductRepository
+	CategoryRepository              domain.CategoryRepository
+	SkuRepository                   domain.SkuRepository
+	UserRepository                  domain.UserRepository
+	UserTokenRepository             domain.UserTokenRepository
+	InventoryRepository             domain.InventoryRepository
+	InventoryItemRepository         domain.InventoryItemRepository
+	InventoryTransactionRepository  domain.InventoryTransactionRepository
+	SalesRepository                 domain.SalesRepository
+	CustomerRepository              domain.CustomerRepository
+	CustomerCreditRepository        domain.CustomerCreditRepository
+	CompanyRepository               domain.CompanyRepository
+	AddressRepository               domain.AddressRepository
+	LegalDocumentRepository         domain.LegalDocumentRepository
+	LegalAcceptanceRepository       domain.LegalAcceptanceRepository
+	DashboardRepository             domain.DashboardRepository
+	PlanRepository                  domain.PlanRepository
+	SubscriptionRepository          domain.SubscriptionRepository
+	BillingPaymentRepository        domain.BillingPaymentRepository
+	NewsRepository                  domain.NewsRepository
+	ReceivableRepository            domain.ReceivableRepository
+	LateFeeRuleRepository           domain.LateFeeRuleRepository
+	DiscountRuleRepository          domain.DiscountRuleRepository
+	QuoteRepository                 domain.QuoteRepository
+	CommissionRepository            domain.CommissionRepository
+	ConsignmentSettlementRepository domain.ConsignmentSettlementRepository
+	DocumentTemplateRepository      domain.DocumentTemplateRepository
+	PixRepository                   domain.PixRepository
+	CardFeeRuleRepository           domain.CardFeeRuleRepository
+	CashRegisterRepository          domain.CashRegisterRepository
+	IdempotencyKeyRepository        domain.IdempotencyKeyRepository
+	StockCountRepository            domain.StockCountRepository
+	StockLevelRepository            domain.StockLevelRepository
+	SupplierRepository              domain.SupplierRepository
+	PurchaseOrderRepository         domain.PurchaseOrderRepository
 }
 
 func NewRepository(db *sql.DB) *Repository {
@@ -45,6 +61,7 @@ func (r *Repository) SetupRepositories() {
 	r.InventoryTransactionRepository = NewInventoryTransactionRepository(r.db, r.InventoryItemRepository)
 	r.SalesRepository = NewSalesRepository(r.db)
 	r.CustomerRepository = NewCustomerRepository(r.db)
+	r.CustomerCreditRepository = NewCustomerCreditRepository(r.db)
 	r.CompanyRepository = NewCompanyRepository(r.db)
 	r.AddressRepository = NewAddressRepository(r.db)
 	r.LegalDocumentRepository = NewLegalDocumentRepository(r.db)
@@ -54,6 +71,21 @@ func (r *Repository) SetupRepositories() {
 	r.SubscriptionRepository = NewSubscriptionRepository(r.db)
 	r.BillingPaymentRepository = NewBillingPaymentRepository(r.db)
 	r.NewsRepository = NewNewsRepository(r.db)
+	r.ReceivableRepository = NewReceivableRepository(r.db)
+	r.LateFeeRuleRepository = NewLateFeeRuleRepository(r.db)
+	r.DiscountRuleRepository = NewDiscountRuleRepository(r.db)
+	r.QuoteRepository = NewQuoteRepository(r.db)
+	r.CommissionRepository = NewCommissionRepository(r.db)
+	r.ConsignmentSettlementRepository = NewConsignmentSettlementRepository(r.db)
+	r.DocumentTemplateRepository = NewDocumentTemplateRepository(r.db)
+	r.PixRepository = NewPixRepository(r.db)
+	r.CardFeeRuleRepository = NewCardFeeRuleRepository(r.db)
+	r.CashRegisterRepository = NewCashRegisterRepository(r.db)
+	r.IdempotencyKeyRepository = NewIdempotencyKeyRepository(r.db)
+	r.StockCountRepository = NewStockCountRepository(r.db)
+	r.StockLevelRepository = NewStockLevelRepository(r.db)
+	r.SupplierRepository = NewSupplierRepository(r.db)
+	r.PurchaseOrderRepository = NewPurchaseOrderRepository(r.db)
 }
 
 func (r *Repository) BeginTx(ctx context.Context) (*sql.Tx, error) {
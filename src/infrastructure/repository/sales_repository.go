@@ -24,19 +24,24 @@ func NewSalesRepository(db *sql.DB) domain.SalesRepository {
 func (r *salesRepository) CreateSale(ctx context.Context, tx *sql.Tx, sale domain.Sales) (int64, error) {
 	tenantId := ctx.Value(constants.TENANT_KEY)
 	var insertedId int64
-	query := `INSERT INTO sales (date, user_id, customer_id, tenant_id, code, last_version) VALUES ($1, $2, $3, $4, $5, 1) RETURNING id`
-	err := tx.QueryRowContext(ctx, query, sale.Date, sale.User.Id, sale.Customer.Id, tenantId, sale.Code).Scan(&insertedId)
+	query := `INSERT INTO sales (date, user_id, customer_id, tenant_id, code, last_version, discount_type, discount_value, discount_reason, discount_amount) VALUES ($1, $2, $3, $4, $5, 1, NULLIF($6, ''), $7, NULLIF($8, ''), $9) RETURNING id`
+	err := tx.QueryRowContext(ctx, query, sale.Date, sale.User.Id, sale.Customer.Id, tenantId, sale.Code, string(sale.Discount.Type), sale.Discount.Value, sale.Discount.Reason, sale.GetSaleDiscountAmount()).Scan(&insertedId)
 	if err != nil {
 		return insertedId, err
 	}
 	return insertedId, nil
 }
 
-func (r *salesRepository) CreateSaleVersion(ctx context.Context, tx *sql.Tx, saleId int64, version int, date time.Time) (int64, error) {
+func (r *salesRepository) CreateSaleVersion(ctx context.Context, tx *sql.Tx, saleId int64, version int, date time.Time, adjustmentAmount float64) (int64, error) {
 	tenantId := ctx.Value(constants.TENANT_KEY)
 	var insertedId int64
-	query := `INSERT INTO sales_versions (sales_id, version, date, tenant_id) VALUES ($1, $2, $3, $4) RETURNING id`
-	err := tx.QueryRowContext(ctx, query, saleId, version, date, tenantId).Scan(&insertedId)
+	query := `
+	INSERT INTO sales_versions (sales_id, version, date, customer_id, adjustment_amount, tenant_id)
+	SELECT s.id, $2, $3, s.customer_id, $5, s.tenant_id
+	FROM sales s
+	WHERE s.id = $1 AND s.tenant_id = $4
+	RETURNING id`
+	err := tx.QueryRowContext(ctx, query, saleId, version, date, tenantId, adjustmentAmount).Scan(&insertedId)
 	return insertedId, err
 }
 
@@ -48,17 +53,21 @@ func (r *salesRepository) CreateManySaleItem(ctx context.Context, tx *sql.Tx, sa
 	tenantId := ctx.Value(constants.TENANT_KEY)
 	var ids []int64
 
-	query := `INSERT INTO sales_items (quantity, unit_price, sku_id, sales_id, sales_version_id, tenant_id) VALUES %s RETURNING id`
+	query := `INSERT INTO sales_items (quantity, unit_price, sku_id, sales_id, sales_version_id, tenant_id, discount_type, discount_value, discount_reason, discount_amount, sale_discount_amount, unit_cost) VALUES %s RETURNING id`
 	valueStrings := make([]string, 0, len(saleItems))
-	valueArgs := make([]interface{}, 0, len(saleItems)*6)
+	valueArgs := make([]interface{}, 0, len(saleItems)*12)
 
 	for i, item := range saleItems {
 		unitPrice := item.UnitPrice
 		if unitPrice == 0 {
 			unitPrice = item.Sku.Price
 		}
-		n := i * 6
-		valueStrings = append(valueStrings, fmt.Sprintf("($%d,$%d,$%d,$%d,$%d,$%d)", n+1, n+2, n+3, n+4, n+5, n+6))
+		unitCost := item.UnitCost
+		if unitCost == nil {
+			unitCost = item.Sku.Cost
+		}
+		n := i * 12
+		valueStrings = append(valueStrings, fmt.Sprintf("($%d,$%d,$%d,$%d,$%d,$%d,NULLIF($%d, ''),$%d,NULLIF($%d, ''),$%d,$%d,$%d)", n+1, n+2, n+3, n+4, n+5, n+6, n+7, n+8, n+9, n+10, n+11, n+12))
 		valueArgs = append(valueArgs,
 			item.Quantity,
 			unitPrice,
@@ -66,6 +75,12 @@ func (r *salesRepository) CreateManySaleItem(ctx context.Context, tx *sql.Tx, sa
 			sale.Id,
 			sale.SalesVersionId,
 			tenantId,
+			string(item.Discount.Type),
+			item.Discount.Value,
+			item.Discount.Reason,
+			item.DiscountAmount,
+			item.SaleDiscountAmount,
+			unitCost,
 		)
 	}
 	query = fmt.Sprintf(query, strings.Join(valueStrings, ","))
@@ -88,8 +103,12 @@ func (r *salesRepository) CreateManySaleItem(ctx context.Context, tx *sql.Tx, sa
 func (r *salesRepository) CreatePayment(ctx context.Context, tx *sql.Tx, sale domain.Sales, payment domain.SalesPayment) (int64, error) {
 	tenantId := ctx.Value(constants.TENANT_KEY)
 	var insertedId int64
-	query := `INSERT INTO payments (payment_type, sales_id, sales_version_id, tenant_id) VALUES ($1, $2, $3, $4) RETURNING id`
-	err := tx.QueryRowContext(ctx, query, payment.PaymentType, sale.Id, sale.SalesVersionId, tenantId).Scan(&insertedId)
+	var cardBrand *domain.CardBrand
+	if payment.CardBrand != "" {
+		cardBrand = &payment.CardBrand
+	}
+	query := `INSERT INTO payments (payment_type, sales_id, sales_version_id, received_amount, change_amount, card_brand, tenant_id) VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING id`
+	err := tx.QueryRowContext(ctx, query, payment.PaymentType, sale.Id, sale.SalesVersionId, payment.ReceivedAmount, payment.ChangeAmount, cardBrand, tenantId).Scan(&insertedId)
 	if err != nil {
 		return insertedId, err
 	}
@@ -100,19 +119,23 @@ func (r *salesRepository) CreateManyPaymentDates(ctx context.Context, tx *sql.Tx
 	tenantId := ctx.Value(constants.TENANT_KEY)
 	var ids []int64
 	valueStrings := make([]string, 0, len(paymentDates))
-	valueArgs := make([]interface{}, 0, len(paymentDates)*7)
+	valueArgs := make([]interface{}, 0, len(paymentDates)*11)
 
-	query := `INSERT INTO payment_dates (due_date, paid_date, installment_number, installment_value, status, payment_id, tenant_id) VALUES %s RETURNING id`
+	query := `INSERT INTO payment_dates (due_date, paid_date, installment_number, installment_value, status, fine_value, interest_value, card_fee_value, settlement_date, payment_id, tenant_id) VALUES %s RETURNING id`
 
 	for i, date := range paymentDates {
-		n := i * 7
-		valueStrings = append(valueStrings, fmt.Sprintf("($%d,$%d,$%d,$%d,$%d,$%d,$%d)", n+1, n+2, n+3, n+4, n+5, n+6, n+7))
+		n := i * 11
+		valueStrings = append(valueStrings, fmt.Sprintf("($%d,$%d,$%d,$%d,$%d,$%d,$%d,$%d,$%d,$%d,$%d)", n+1, n+2, n+3, n+4, n+5, n+6, n+7, n+8, n+9, n+10, n+11))
 		valueArgs = append(valueArgs,
 			date.DueDate,
 			date.PaidDate,
 			date.InstallmentNumber,
 			date.InstallmentValue,
 			date.Status,
+			date.FineValue,
+			date.InterestValue,
+			date.CardFeeValue,
+			date.SettlementDate,
 			payment.Id,
 			tenantId,
 		)
@@ -169,12 +192,56 @@ func (r *salesRepository) CreateSalesReturnItems(ctx context.Context, tx *sql.Tx
 	return ids, nil
 }
 
+func (r *salesRepository) CreateSalesCancellation(ctx context.Context, tx *sql.Tx, saleId int64, fromSalesVersionId int64, toSalesVersionId int64, cancellation domain.SalesCancellation, createdByUserId int64) (int64, error) {
+	tenantId := ctx.Value(constants.TENANT_KEY)
+	var insertedId int64
+	query := `INSERT INTO sales_cancellations (sales_id, from_sales_version_id, to_sales_version_id, cancel_date, reason, created_by_user_id, tenant_id) VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING id`
+	err := tx.QueryRowContext(ctx, query, saleId, fromSalesVersionId, toSalesVersionId, cancellation.CancelDate, cancellation.Reason, createdByUserId, tenantId).Scan(&insertedId)
+	return insertedId, err
+}
+
+func (r *salesRepository) CreateSalesExchange(ctx context.Context, tx *sql.Tx, saleId int64, fromSalesVersionId int64, toSalesVersionId int64, salesReturnId int64, exchange domain.SalesExchange, createdByUserId int64) (int64, error) {
+	tenantId := ctx.Value(constants.TENANT_KEY)
+	var insertedId int64
+	query := `INSERT INTO sales_exchanges (sales_id, from_sales_version_id, to_sales_version_id, sales_return_id, exchange_date, created_by_user_id, tenant_id) VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING id`
+	err := tx.QueryRowContext(ctx, query, saleId, fromSalesVersionId, toSalesVersionId, salesReturnId, exchange.ExchangeDate, createdByUserId, tenantId).Scan(&insertedId)
+	return insertedId, err
+}
+
+func (r *salesRepository) CreateSalesRenegotiation(ctx context.Context, tx *sql.Tx, saleId int64, fromSalesVersionId int64, toSalesVersionId int64, renegotiation domain.SalesRenegotiation, createdByUserId int64) (int64, error) {
+	tenantId := ctx.Value(constants.TENANT_KEY)
+	var insertedId int64
+	query := `INSERT INTO sales_renegotiations (sales_id, from_sales_version_id, to_sales_version_id, renegotiation_date, installments, first_due_date, open_value, interest_percentage, discount_percentage, total_value, created_by_user_id, tenant_id) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12) RETURNING id`
+	err := tx.QueryRowContext(ctx, query, saleId, fromSalesVersionId, toSalesVersionId, renegotiation.RenegotiationDate, renegotiation.Installments, renegotiation.FirstDueDate, renegotiation.OpenValue, renegotiation.InterestPercentage, renegotiation.DiscountPercentage, renegotiation.GetTotal(), createdByUserId, tenantId).Scan(&insertedId)
+	return insertedId, err
+}
+
+func (r *salesRepository) CreateSalesEdit(ctx context.Context, tx *sql.Tx, saleId int64, fromSalesVersionId int64, toSalesVersionId int64, editDate time.Time, createdByUserId int64) (int64, error) {
+	tenantId := ctx.Value(constants.TENANT_KEY)
+	var insertedId int64
+	query := `INSERT INTO sales_edits (sales_id, from_sales_version_id, to_sales_version_id, edit_date, created_by_user_id, tenant_id) VALUES ($1, $2, $3, $4, $5, $6) RETURNING id`
+	err := tx.QueryRowContext(ctx, query, saleId, fromSalesVersionId, toSalesVersionId, editDate, createdByUserId, tenantId).Scan(&insertedId)
+	return insertedId, err
+}
+
 func (r *salesRepository) UpdateSaleLastVersion(ctx context.Context, tx *sql.Tx, saleId int64, version int) error {
 	tenantId := ctx.Value(constants.TENANT_KEY)
 	_, err := tx.ExecContext(ctx, `UPDATE sales SET last_version = $1 WHERE id = $2 AND tenant_id = $3`, version, saleId, tenantId)
 	return err
 }
 
+func (r *salesRepository) UpdateSaleDiscountAmount(ctx context.Context, tx *sql.Tx, saleId int64, discountAmount float64) error {
+	tenantId := ctx.Value(constants.TENANT_KEY)
+	_, err := tx.ExecContext(ctx, `UPDATE sales SET discount_amount = $1 WHERE id = $2 AND tenant_id = $3`, discountAmount, saleId, tenantId)
+	return err
+}
+
+func (r *salesRepository) UpdateSaleCustomer(ctx context.Context, tx *sql.Tx, saleId int64, customerId int64) error {
+	tenantId := ctx.Value(constants.TENANT_KEY)
+	_, err := tx.ExecContext(ctx, `UPDATE sales SET customer_id = $1 WHERE id = $2 AND tenant_id = $3`, customerId, saleId, tenantId)
+	return err
+}
+
 func (r *salesRepository) CancelPaymentDatesBySaleVersionId(ctx context.Context, tx *sql.Tx, saleVersionId int64) error {
 	tenantId := ctx.Value(constants.TENANT_KEY)
 	query := `
@@ -192,7 +259,7 @@ func (r *salesRepository) GetSaleByIdForUpdate(ctx context.Context, tx *sql.Tx,
 	tenantId := ctx.Value(constants.TENANT_KEY)
 	var output domain.SaleWithVersionOutput
 	query := `
-	SELECT s.id, s.code, s.date, s.user_id, s.customer_id, c.name, s.last_version, sv.id
+	SELECT s.id, s.code, s.date, s.user_id, s.customer_id, c.name, s.last_version, sv.id, sv.adjustment_amount
 	FROM sales s
 	JOIN customers c ON c.id = s.customer_id AND c.tenant_id = s.tenant_id
 	JOIN sales_versions sv ON sv.sales_id = s.id AND sv.version = s.last_version AND sv.tenant_id = s.tenant_id
@@ -207,6 +274,7 @@ func (r *salesRepository) GetSaleByIdForUpdate(ctx context.Context, tx *sql.Tx,
 		&output.CustomerName,
 		&output.LastVersion,
 		&output.SalesVersionId,
+		&output.AdjustmentAmount,
 	)
 	return output, err
 }
@@ -218,19 +286,19 @@ func (r *salesRepository) GetSaleVersionIdBySaleIdAndVersion(ctx context.Context
 	return id, err
 }
 
-func (r *salesRepository) GetSales(ctx context.Context, input domain.GetSalesInput) ([]domain.GetSalesItemOutput, error) {
-	tenantId := ctx.Value(constants.TENANT_KEY)
-	var sales []domain.GetSalesItemOutput
-
-	query := `
+// salesListQuery seleciona as vendas filtradas pelos parâmetros $1 a $14 de
+// salesListArgs. Os filtros de itens e pagamentos olham a última versão. É usada tanto pela listagem paginada quanto pelos totais.
+const salesListQuery = `
 SELECT
   s.id,
+  s.code,
   s.date,
   c.name AS customer,
   u.name AS seller,
   COALESCE(SUM(pd.installment_value), 0) AS total_value,
-  COALESCE(SUM(pd.installment_value) FILTER (WHERE pd.status IN ('PAID','REVERSAL')), 0) AS received_value,
-  COALESCE(SUM(pd.installment_value) FILTER (WHERE pd.status IN ('PENDING','DELAYED')), 0) AS future_revenue,
+  COALESCE(SUM(pd.installment_value) FILTER (WHERE pd.status IN ('PAID','REVERSAL')), 0)
+    + COALESCE(SUM(pr.received_value) FILTER (WHERE pd.status IN ('PENDING','DELAYED')), 0) AS received_value,
+  COALESCE(SUM(pd.installment_value - COALESCE(pr.received_value, 0)) FILTER (WHERE pd.status IN ('PENDING','DELAYED')), 0) AS future_revenue,
   (
     SELECT COALESCE(SUM(si.quantity), 0)
     FROM sales_items si
@@ -250,7 +318,7 @@ SELECT
     WHEN COUNT(pd.id) FILTER (WHERE pd.status IN ('PAID','PENDING','DELAYED')) > 0
       AND COUNT(pd.id) FILTER (WHERE pd.status IN ('PAID','PENDING','DELAYED')) = COUNT(pd.id) FILTER (WHERE pd.status = 'PAID')
       THEN 'PAID'
-    WHEN COALESCE(BOOL_OR(pd.status = 'PENDING' AND pd.due_date < CURRENT_DATE), FALSE)
+    WHEN COALESCE(BOOL_OR(pd.status = 'DELAYED' OR (pd.status = 'PENDING' AND pd.due_date < CURRENT_DATE)), FALSE)
       THEN 'DELAYED'
     ELSE 'PENDING'
   END AS summary_status
@@ -260,59 +328,140 @@ JOIN users u ON u.id = s.user_id AND u.tenant_id = s.tenant_id
 JOIN customers c ON c.id = s.customer_id AND c.tenant_id = s.tenant_id
 LEFT JOIN payments p ON p.sales_version_id = sv.id AND p.tenant_id = s.tenant_id
 LEFT JOIN payment_dates pd ON pd.payment_id = p.id AND pd.tenant_id = s.tenant_id
+LEFT JOIN (
+  SELECT payment_date_id, SUM(value) AS received_value
+  FROM payment_receipts
+  GROUP BY payment_date_id
+) pr ON pr.payment_date_id = pd.id
 WHERE s.tenant_id = $1
   AND ($2::bigint[] IS NULL OR s.user_id = ANY($2))
   AND ($3::bigint[] IS NULL OR s.customer_id = ANY($3))
   AND ($4::timestamptz IS NULL OR s.date >= $4)
   AND ($5::timestamptz IS NULL OR s.date <= $5)
-GROUP BY s.id, s.date, c.name, u.name, sv.id
+  AND ($7::text IS NULL OR s.code ILIKE $7 OR c.name ILIKE $7)
+  AND (
+    ($8::bigint[] IS NULL AND $9::bigint[] IS NULL AND $10::bigint[] IS NULL)
+    OR EXISTS (
+      SELECT 1
+      FROM sales_items si
+      JOIN skus sk ON sk.id = si.sku_id AND sk.tenant_id = si.tenant_id
+      JOIN products prd ON prd.id = sk.product_id AND prd.tenant_id = si.tenant_id
+      WHERE si.sales_version_id = sv.id
+        AND si.tenant_id = s.tenant_id
+        AND ($8::bigint[] IS NULL OR prd.id = ANY($8))
+        AND ($9::bigint[] IS NULL OR sk.id = ANY($9))
+        AND ($10::bigint[] IS NULL OR prd.category_id = ANY($10))
+    )
+  )
+  AND ($11::text[] IS NULL OR EXISTS (
+    SELECT 1
+    FROM payments pt
+    WHERE pt.sales_version_id = sv.id
+      AND pt.tenant_id = s.tenant_id
+      AND pt.payment_type = ANY($11)
+  ))
+  AND ($12::boolean IS NULL OR $12 = EXISTS (
+    SELECT 1
+    FROM sales_returns sr
+    WHERE sr.sales_id = s.id
+      AND sr.tenant_id = s.tenant_id
+  ))
+GROUP BY s.id, s.code, s.date, c.name, u.name, sv.id
 HAVING
-  $6::text IS NULL
-  OR (
-      ($6 = 'CANCEL' AND (
-        (
-          SELECT COALESCE(SUM(si.quantity), 0)
-          FROM sales_items si
-          WHERE si.sales_version_id = sv.id
-            AND si.tenant_id = s.tenant_id
-        ) = 0
-        OR COUNT(pd.id) FILTER (WHERE pd.status IN ('PAID','PENDING','DELAYED')) = 0
-      ))
-      OR ($6 = 'PAID'
-        AND (
-          SELECT COALESCE(SUM(si.quantity), 0)
-          FROM sales_items si
-          WHERE si.sales_version_id = sv.id
-            AND si.tenant_id = s.tenant_id
-        ) > 0
-        AND COUNT(pd.id) FILTER (WHERE pd.status IN ('PAID','PENDING','DELAYED')) > 0
-        AND COUNT(pd.id) FILTER (WHERE pd.status IN ('PAID','PENDING','DELAYED')) = COUNT(pd.id) FILTER (WHERE pd.status = 'PAID')
-      )
-      OR ($6 = 'DELAYED'
-        AND (
-          SELECT COALESCE(SUM(si.quantity), 0)
-          FROM sales_items si
-          WHERE si.sales_version_id = sv.id
-            AND si.tenant_id = s.tenant_id
-        ) > 0
-        AND COALESCE(BOOL_OR(pd.status = 'PENDING' AND pd.due_date < CURRENT_DATE), FALSE)
-      )
-      OR ($6 = 'PENDING'
-        AND (
-          SELECT COALESCE(SUM(si.quantity), 0)
-          FROM sales_items si
-          WHERE si.sales_version_id = sv.id
-            AND si.tenant_id = s.tenant_id
-        ) > 0
-        AND COUNT(pd.id) FILTER (WHERE pd.status IN ('PAID','PENDING','DELAYED')) > 0
-        AND NOT (
-          (COUNT(pd.id) FILTER (WHERE pd.status IN ('PAID','PENDING','DELAYED')) = COUNT(pd.id) FILTER (WHERE pd.status = 'PAID'))
-          OR COALESCE(BOOL_OR(pd.status = 'PENDING' AND pd.due_date < CURRENT_DATE), FALSE)
+  ($13::float8 IS NULL OR COALESCE(SUM(pd.installment_value), 0) >= $13)
+  AND ($14::float8 IS NULL OR COALESCE(SUM(pd.installment_value), 0) <= $14)
+  AND (
+    $6::text IS NULL
+    OR (
+        ($6 = 'CANCEL' AND (
+          (
+            SELECT COALESCE(SUM(si.quantity), 0)
+            FROM sales_items si
+            WHERE si.sales_version_id = sv.id
+              AND si.tenant_id = s.tenant_id
+          ) = 0
+          OR COUNT(pd.id) FILTER (WHERE pd.status IN ('PAID','PENDING','DELAYED')) = 0
+        ))
+        OR ($6 = 'PAID'
+          AND (
+            SELECT COALESCE(SUM(si.quantity), 0)
+            FROM sales_items si
+            WHERE si.sales_version_id = sv.id
+              AND si.tenant_id = s.tenant_id
+          ) > 0
+          AND COUNT(pd.id) FILTER (WHERE pd.status IN ('PAID','PENDING','DELAYED')) > 0
+          AND COUNT(pd.id) FILTER (WHERE pd.status IN ('PAID','PENDING','DELAYED')) = COUNT(pd.id) FILTER (WHERE pd.status = 'PAID')
         )
-      )
-  )
-ORDER BY s.date DESC, s.id DESC`
-	valueArgs := []interface{}{tenantId, pq.Array(input.UserId), pq.Array(input.CustomerId), input.InitialDate, input.FinalDate, input.PaymentStatus}
+        OR ($6 = 'DELAYED'
+          AND (
+            SELECT COALESCE(SUM(si.quantity), 0)
+            FROM sales_items si
+            WHERE si.sales_version_id = sv.id
+              AND si.tenant_id = s.tenant_id
+          ) > 0
+          AND COALESCE(BOOL_OR(pd.status = 'DELAYED' OR (pd.status = 'PENDING' AND pd.due_date < CURRENT_DATE)), FALSE)
+        )
+        OR ($6 = 'PENDING'
+          AND (
+            SELECT COALESCE(SUM(si.quantity), 0)
+            FROM sales_items si
+            WHERE si.sales_version_id = sv.id
+              AND si.tenant_id = s.tenant_id
+          ) > 0
+          AND COUNT(pd.id) FILTER (WHERE pd.status IN ('PAID','PENDING','DELAYED')) > 0
+          AND NOT (
+            (COUNT(pd.id) FILTER (WHERE pd.status IN ('PAID','PENDING','DELAYED')) = COUNT(pd.id) FILTER (WHERE pd.status = 'PAID'))
+            OR COALESCE(BOOL_OR(pd.status = 'DELAYED' OR (pd.status = 'PENDING' AND pd.due_date < CURRENT_DATE)), FALSE)
+          )
+        )
+    )
+  )`
+
+var salesSortColumns = map[domain.SalesSort]string{
+	domain.SalesSortDate:     "date",
+	domain.SalesSortTotal:    "total_value",
+	domain.SalesSortCustomer: "customer",
+}
+
+func salesListArgs(ctx context.Context, input domain.GetSalesInput) []interface{} {
+	var search *string
+	if input.Search != nil && strings.TrimSpace(*input.Search) != "" {
+		pattern := "%" + strings.TrimSpace(*input.Search) + "%"
+		search = &pattern
+	}
+	var paymentTypes []string
+	for _, paymentType := range input.PaymentType {
+		paymentTypes = append(paymentTypes, string(paymentType))
+	}
+	return []interface{}{
+		ctx.Value(constants.TENANT_KEY), pq.Array(input.UserId), pq.Array(input.CustomerId), input.InitialDate, input.FinalDate, input.PaymentStatus, search,
+		pq.Array(input.ProductId), pq.Array(input.SkuId), pq.Array(input.CategoryId), pq.Array(paymentTypes), input.HasReturns, input.MinValue, input.MaxValue,
+	}
+}
+
+func (r *salesRepository) GetSales(ctx context.Context, input domain.GetSalesInput) ([]domain.GetSalesItemOutput, error) {
+	var sales []domain.GetSalesItemOutput
+
+	column, ok := salesSortColumns[input.Sort]
+	if !ok {
+		column = salesSortColumns[domain.SalesSortDate]
+	}
+	direction := "ASC"
+	if input.Descending {
+		direction = "DESC"
+	}
+	var limit *int
+	if input.Limit > 0 {
+		limit = &input.Limit
+	}
+
+	query := fmt.Sprintf(`
+WITH sales_list AS (%s)
+SELECT id, code, date, customer, seller, total_value, received_value, future_revenue, total_items, summary_status
+FROM sales_list
+ORDER BY %s %s, id %s
+LIMIT $15 OFFSET $16`, salesListQuery, column, direction, direction)
+	valueArgs := append(salesListArgs(ctx, input), limit, input.Offset)
 
 	rows, err := r.db.QueryContext(ctx, query, valueArgs...)
 	if err != nil {
@@ -321,7 +470,7 @@ ORDER BY s.date DESC, s.id DESC`
 	defer rows.Close()
 	for rows.Next() {
 		var sale domain.GetSalesItemOutput
-		if err := rows.Scan(&sale.Id, &sale.Date, &sale.CustomerName, &sale.SellerName, &sale.TotalValue, &sale.ReceivedValue, &sale.FutureRevenue, &sale.TotalItems, &sale.Status); err != nil {
+		if err := rows.Scan(&sale.Id, &sale.Code, &sale.Date, &sale.CustomerName, &sale.SellerName, &sale.TotalValue, &sale.ReceivedValue, &sale.FutureRevenue, &sale.TotalItems, &sale.Status); err != nil {
 			return nil, err
 		}
 		sales = append(sales, sale)
@@ -329,6 +478,21 @@ ORDER BY s.date DESC, s.id DESC`
 	return sales, nil
 }
 
+func (r *salesRepository) GetSalesTotals(ctx context.Context, input domain.GetSalesInput) (domain.GetSalesTotalsOutput, error) {
+	var totals domain.GetSalesTotalsOutput
+	query := fmt.Sprintf(`
+WITH sales_list AS (%s)
+SELECT
+  COUNT(*),
+  COALESCE(SUM(total_items), 0),
+  COALESCE(SUM(total_value), 0),
+  COALESCE(SUM(received_value), 0),
+  COALESCE(SUM(future_revenue), 0)
+FROM sales_list`, salesListQuery)
+	err := r.db.QueryRowContext(ctx, query, salesListArgs(ctx, input)...).Scan(&totals.TotalSales, &totals.TotalItems, &totals.TotalValue, &totals.ReceivedValue, &totals.FutureRevenue)
+	return totals, err
+}
+
 func (r *salesRepository) GetSaleById(ctx context.Context, id int64) (domain.GetSaleByIdOutput, error) {
 	tenantId := ctx.Value(constants.TENANT_KEY)
 	var output domain.GetSaleByIdOutput
@@ -341,8 +505,9 @@ func (r *salesRepository) GetSaleById(ctx context.Context, id int64) (domain.Get
 		u.name AS seller_name,
 		c.name AS customer_name,
 		COALESCE(SUM(pd.installment_value), 0) AS total_value,
-		COALESCE(SUM(pd.installment_value) FILTER (WHERE pd.status IN ('PAID','REVERSAL')), 0) AS received_value,
-		COALESCE(SUM(pd.installment_value) FILTER (WHERE pd.status IN ('PENDING','DELAYED')), 0) AS future_revenue,
+		COALESCE(SUM(pd.installment_value) FILTER (WHERE pd.status IN ('PAID','REVERSAL')), 0)
+		  + COALESCE(SUM(pr.received_value) FILTER (WHERE pd.status IN ('PENDING','DELAYED')), 0) AS received_value,
+		COALESCE(SUM(pd.installment_value - COALESCE(pr.received_value, 0)) FILTER (WHERE pd.status IN ('PENDING','DELAYED')), 0) AS future_revenue,
 		CASE
 			WHEN (
 				SELECT COALESCE(SUM(si.quantity), 0)
@@ -353,22 +518,57 @@ func (r *salesRepository) GetSaleById(ctx context.Context, id int64) (domain.Get
 			WHEN COUNT(pd.id) FILTER (WHERE pd.status IN ('PAID','PENDING','DELAYED')) = 0 THEN 'CANCEL'
 			WHEN COUNT(pd.id) FILTER (WHERE pd.status IN ('PAID','PENDING','DELAYED')) > 0
 			  AND COUNT(pd.id) FILTER (WHERE pd.status IN ('PAID','PENDING','DELAYED')) = COUNT(pd.id) FILTER (WHERE pd.status = 'PAID') THEN 'PAID'
-			WHEN COALESCE(BOOL_OR(pd.status = 'PENDING' AND pd.due_date < CURRENT_DATE), FALSE) THEN 'DELAYED'
+			WHEN COALESCE(BOOL_OR(pd.status = 'DELAYED' OR (pd.status = 'PENDING' AND pd.due_date < CURRENT_DATE)), FALSE) THEN 'DELAYED'
 			ELSE 'PENDING'
-		END AS payment_status
+		END AS payment_status,
+		sc.cancel_date,
+		sc.reason,
+		cu.name AS cancelled_by,
+		COALESCE(s.discount_type, ''),
+		s.discount_value,
+		COALESCE(s.discount_reason, ''),
+		s.discount_amount,
+		(
+			SELECT COALESCE(SUM(cp.received_amount), 0)
+			FROM payments cp
+			WHERE cp.sales_id = s.id AND cp.tenant_id = s.tenant_id
+		) AS cash_received,
+		(
+			SELECT COALESCE(SUM(cp.change_amount), 0)
+			FROM payments cp
+			WHERE cp.sales_id = s.id AND cp.tenant_id = s.tenant_id
+		) AS change_amount
 	FROM sales s
 	JOIN sales_versions sv ON sv.sales_id = s.id AND sv.version = s.last_version AND sv.tenant_id = s.tenant_id
 	JOIN users u ON u.id = s.user_id AND u.tenant_id = s.tenant_id
 	JOIN customers c ON c.id = s.customer_id AND c.tenant_id = s.tenant_id
 	LEFT JOIN payments p ON p.sales_version_id = sv.id AND p.tenant_id = s.tenant_id
 	LEFT JOIN payment_dates pd ON pd.payment_id = p.id AND pd.tenant_id = s.tenant_id
+	LEFT JOIN (
+		SELECT payment_date_id, SUM(value) AS received_value
+		FROM payment_receipts
+		GROUP BY payment_date_id
+	) pr ON pr.payment_date_id = pd.id
+	LEFT JOIN sales_cancellations sc ON sc.sales_id = s.id AND sc.tenant_id = s.tenant_id
+	LEFT JOIN users cu ON cu.id = sc.created_by_user_id
+	LEFT JOIN sales_renegotiations sn ON sn.to_sales_version_id = sv.id AND sn.tenant_id = sv.tenant_id
+	LEFT JOIN users nu ON nu.id = sn.created_by_user_id
 	WHERE s.id = $1
 	  AND s.tenant_id = $2
-	GROUP BY s.id, s.code, s.date, u.name, c.name, sv.id`
-	err := r.db.QueryRowContext(ctx, query, id, tenantId).Scan(&output.Id, &output.Code, &output.Date, &output.SellerName, &output.CustomerName, &output.TotalValue, &output.ReceivedValue, &output.FutureRevenue, &output.PaymentStatus)
+	GROUP BY s.id, s.code, s.date, u.name, c.name, sv.id, sc.cancel_date, sc.reason, cu.name`
+	var cancelDate sql.NullTime
+	var cancelReason, cancelledBy sql.NullString
+	err := r.db.QueryRowContext(ctx, query, id, tenantId).Scan(&output.Id, &output.Code, &output.Date, &output.SellerName, &output.CustomerName, &output.TotalValue, &output.ReceivedValue, &output.FutureRevenue, &output.PaymentStatus, &cancelDate, &cancelReason, &cancelledBy, &output.Discount.Type, &output.Discount.Value, &output.Discount.Reason, &output.DiscountValue, &output.CashReceived, &output.ChangeAmount)
 	if err != nil {
 		return output, err
 	}
+	if cancelDate.Valid {
+		output.Cancellation = &domain.GetSalesCancellationOutput{
+			CancelDate:  cancelDate.Time,
+			Reason:      cancelReason.String,
+			CancelledBy: cancelledBy.String,
+		}
+	}
 	return output, nil
 }
 
@@ -388,11 +588,23 @@ func (r *salesRepository) GetPaymentsBySaleId(ctx context.Context, id int64) ([]
 			WHEN pd.status = 'PENDING' AND pd.due_date < CURRENT_DATE
 				THEN 'DELAYED'
 				ELSE pd.status
-		END AS status
+		END AS status,
+		COALESCE(pr.received_value, 0) AS received_value,
+		pr.last_receipt_date,
+		pd.fine_value,
+		pd.interest_value,
+		COALESCE(p.card_brand, ''),
+		pd.card_fee_value,
+		pd.settlement_date
 	FROM sales s
 	JOIN sales_versions sv ON sv.sales_id = s.id AND sv.version = s.last_version AND sv.tenant_id = s.tenant_id
 	JOIN payments p ON p.sales_version_id = sv.id AND p.tenant_id = s.tenant_id
 	JOIN payment_dates pd ON p.id = pd.payment_id AND pd.tenant_id = s.tenant_id
+	LEFT JOIN (
+		SELECT payment_date_id, SUM(value) AS received_value, MAX(receipt_date) AS last_receipt_date
+		FROM payment_receipts
+		GROUP BY payment_date_id
+	) pr ON pr.payment_date_id = pd.id
 	WHERE s.id = $1 AND s.tenant_id = $2
 	ORDER BY p.payment_type, pd.installment_number ASC`
 	rows, err := r.db.QueryContext(ctx, query, id, tenantId)
@@ -402,7 +614,7 @@ func (r *salesRepository) GetPaymentsBySaleId(ctx context.Context, id int64) ([]
 	defer rows.Close()
 	for rows.Next() {
 		var payment domain.GetSalesPaymentOutput
-		if err := rows.Scan(&payment.Id, &payment.InstallmentNumber, &payment.InstallmentValue, &payment.DueDate, &payment.PaidDate, &payment.PaymentType, &payment.PaymentStatus); err != nil {
+		if err := rows.Scan(&payment.Id, &payment.InstallmentNumber, &payment.InstallmentValue, &payment.DueDate, &payment.PaidDate, &payment.PaymentType, &payment.PaymentStatus, &payment.ReceivedValue, &payment.LastReceiptDate, &payment.FineValue, &payment.InterestValue, &payment.CardBrand, &payment.CardFeeValue, &payment.SettlementDate); err != nil {
 			return nil, err
 		}
 		o = append(o, payment)
@@ -425,9 +637,21 @@ func (r *salesRepository) GetPaymentsBySaleVersionId(ctx context.Context, saleVe
 			WHEN pd.status = 'PENDING' AND pd.due_date < CURRENT_DATE
 				THEN 'DELAYED'
 				ELSE pd.status
-		END AS status
+		END AS status,
+		COALESCE(pr.received_value, 0) AS received_value,
+		pr.last_receipt_date,
+		pd.fine_value,
+		pd.interest_value,
+		COALESCE(p.card_brand, ''),
+		pd.card_fee_value,
+		pd.settlement_date
 	FROM payments p
 	JOIN payment_dates pd ON p.id = pd.payment_id
+	LEFT JOIN (
+		SELECT payment_date_id, SUM(value) AS received_value, MAX(receipt_date) AS last_receipt_date
+		FROM payment_receipts
+		GROUP BY payment_date_id
+	) pr ON pr.payment_date_id = pd.id
 	WHERE p.sales_version_id = $1 AND p.tenant_id = $2
 	ORDER BY p.payment_type, pd.installment_number ASC`
 	rows, err := r.db.QueryContext(ctx, query, saleVersionId, tenantId)
@@ -437,7 +661,7 @@ func (r *salesRepository) GetPaymentsBySaleVersionId(ctx context.Context, saleVe
 	defer rows.Close()
 	for rows.Next() {
 		var payment domain.GetSalesPaymentOutput
-		if err := rows.Scan(&payment.Id, &payment.InstallmentNumber, &payment.InstallmentValue, &payment.DueDate, &payment.PaidDate, &payment.PaymentType, &payment.PaymentStatus); err != nil {
+		if err := rows.Scan(&payment.Id, &payment.InstallmentNumber, &payment.InstallmentValue, &payment.DueDate, &payment.PaidDate, &payment.PaymentType, &payment.PaymentStatus, &payment.ReceivedValue, &payment.LastReceiptDate, &payment.FineValue, &payment.InterestValue, &payment.CardBrand, &payment.CardFeeValue, &payment.SettlementDate); err != nil {
 			return nil, err
 		}
 		o = append(o, payment)
@@ -453,7 +677,13 @@ func (r *salesRepository) GetItemsBySaleId(ctx context.Context, id int64) ([]dom
 	SELECT
 		si.quantity,
 		si.sku_id,
-		sku.price,
+		si.unit_price,
+		COALESCE(si.discount_type, ''),
+		si.discount_value,
+		COALESCE(si.discount_reason, ''),
+		si.discount_amount,
+		si.sale_discount_amount,
+		si.unit_cost,
 		sku.id,
 		sku.code,
 		sku.color,
@@ -474,10 +704,11 @@ func (r *salesRepository) GetItemsBySaleId(ctx context.Context, id int64) ([]dom
 	defer rows.Close()
 	for rows.Next() {
 		var item domain.GetItemsOutput
-		if err := rows.Scan(&item.Quantity, &item.Sku.Id, &item.Sku.Price, &item.Sku.Id, &item.Sku.Code, &item.Sku.Color, &item.Sku.Size, &item.Sku.Product.Name, &item.Sku.Product.Description); err != nil {
+		if err := rows.Scan(&item.Quantity, &item.Sku.Id, &item.Sku.Price, &item.Discount.Type, &item.Discount.Value, &item.Discount.Reason, &item.DiscountAmount, &item.SaleDiscountAmount, &item.UnitCost, &item.Sku.Id, &item.Sku.Code, &item.Sku.Color, &item.Sku.Size, &item.Sku.Product.Name, &item.Sku.Product.Description); err != nil {
 			return nil, err
 		}
 		item.UnitPrice = item.Sku.Price
+		item.TotalValue = item.GetTotal()
 		items = append(items, item)
 	}
 	return items, nil
@@ -490,6 +721,12 @@ func (r *salesRepository) GetItemsBySaleVersionId(ctx context.Context, saleVersi
 	SELECT
 		si.quantity,
 		si.unit_price,
+		COALESCE(si.discount_type, ''),
+		si.discount_value,
+		COALESCE(si.discount_reason, ''),
+		si.discount_amount,
+		si.sale_discount_amount,
+		si.unit_cost,
 		si.sku_id,
 		s.id,
 		s.code,
@@ -509,10 +746,11 @@ func (r *salesRepository) GetItemsBySaleVersionId(ctx context.Context, saleVersi
 	defer rows.Close()
 	for rows.Next() {
 		var item domain.GetItemsOutput
-		if err := rows.Scan(&item.Quantity, &item.UnitPrice, &item.Sku.Id, &item.Sku.Id, &item.Sku.Code, &item.Sku.Color, &item.Sku.Size, &item.Sku.Product.Name, &item.Sku.Product.Description); err != nil {
+		if err := rows.Scan(&item.Quantity, &item.UnitPrice, &item.Discount.Type, &item.Discount.Value, &item.Discount.Reason, &item.DiscountAmount, &item.SaleDiscountAmount, &item.UnitCost, &item.Sku.Id, &item.Sku.Id, &item.Sku.Code, &item.Sku.Color, &item.Sku.Size, &item.Sku.Product.Name, &item.Sku.Product.Description); err != nil {
 			return nil, err
 		}
 		item.Sku.Price = item.UnitPrice
+		item.TotalValue = item.GetTotal()
 		items = append(items, item)
 	}
 	return items, nil
@@ -546,6 +784,80 @@ func (r *salesRepository) GetReturnsBySaleId(ctx context.Context, id int64) ([]d
 	return output, nil
 }
 
+func (r *salesRepository) GetRenegotiationsBySaleId(ctx context.Context, id int64) ([]domain.GetSalesRenegotiationOutput, error) {
+	tenantId := ctx.Value(constants.TENANT_KEY)
+	output := make([]domain.GetSalesRenegotiationOutput, 0)
+	query := `
+	SELECT sr.id, sr.renegotiation_date, sr.installments, sr.first_due_date, sr.open_value, sr.interest_percentage, sr.discount_percentage, sr.total_value, u.name
+	FROM sales_renegotiations sr
+	JOIN users u ON u.id = sr.created_by_user_id
+	WHERE sr.sales_id = $1 AND sr.tenant_id = $2
+	ORDER BY sr.renegotiation_date ASC, sr.id ASC`
+	rows, err := r.db.QueryContext(ctx, query, id, tenantId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var renegotiation domain.GetSalesRenegotiationOutput
+		if err := rows.Scan(&renegotiation.Id, &renegotiation.RenegotiationDate, &renegotiation.Installments, &renegotiation.FirstDueDate, &renegotiation.OpenValue, &renegotiation.InterestPercentage, &renegotiation.DiscountPercentage, &renegotiation.TotalValue, &renegotiation.CreatedBy); err != nil {
+			return nil, err
+		}
+		output = append(output, renegotiation)
+	}
+	return output, nil
+}
+
+func (r *salesRepository) GetVersionsBySaleId(ctx context.Context, id int64) ([]domain.GetSalesVersionOutput, error) {
+	tenantId := ctx.Value(constants.TENANT_KEY)
+	output := make([]domain.GetSalesVersionOutput, 0)
+	query := `
+	SELECT
+		sv.id,
+		sv.version,
+		sv.date,
+		CASE
+			WHEN sc.id IS NOT NULL THEN 'CANCEL'
+			WHEN sn.id IS NOT NULL THEN 'RENEGOTIATION'
+			WHEN sx.id IS NOT NULL THEN 'EXCHANGE'
+			WHEN sr.id IS NOT NULL THEN 'RETURN'
+			WHEN se.id IS NOT NULL THEN 'EDIT'
+			ELSE 'SALE'
+		END AS version_type,
+		c.id,
+		c.name,
+		COALESCE(cu.name, nu.name, xu.name, ru.name, eu.name, u.name) AS created_by
+	FROM sales_versions sv
+	JOIN sales s ON s.id = sv.sales_id AND s.tenant_id = sv.tenant_id
+	JOIN users u ON u.id = s.user_id
+	JOIN customers c ON c.id = sv.customer_id
+	LEFT JOIN sales_cancellations sc ON sc.to_sales_version_id = sv.id AND sc.tenant_id = sv.tenant_id
+	LEFT JOIN users cu ON cu.id = sc.created_by_user_id
+	LEFT JOIN sales_renegotiations sn ON sn.to_sales_version_id = sv.id AND sn.tenant_id = sv.tenant_id
+	LEFT JOIN users nu ON nu.id = sn.created_by_user_id
+	LEFT JOIN sales_exchanges sx ON sx.to_sales_version_id = sv.id AND sx.tenant_id = sv.tenant_id
+	LEFT JOIN users xu ON xu.id = sx.created_by_user_id
+	LEFT JOIN sales_returns sr ON sr.to_sales_version_id = sv.id AND sr.tenant_id = sv.tenant_id
+	LEFT JOIN users ru ON ru.id = sr.created_by_user_id
+	LEFT JOIN sales_edits se ON se.to_sales_version_id = sv.id AND se.tenant_id = sv.tenant_id
+	LEFT JOIN users eu ON eu.id = se.created_by_user_id
+	WHERE sv.sales_id = $1 AND sv.tenant_id = $2
+	ORDER BY sv.version ASC`
+	rows, err := r.db.QueryContext(ctx, query, id, tenantId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var version domain.GetSalesVersionOutput
+		if err := rows.Scan(&version.Id, &version.Version, &version.Date, &version.Type, &version.CustomerId, &version.CustomerName, &version.CreatedBy); err != nil {
+			return nil, err
+		}
+		output = append(output, version)
+	}
+	return output, nil
+}
+
 func (r *salesRepository) getSalesReturnItems(ctx context.Context, salesReturnId int64) ([]domain.GetSalesReturnItemOutput, error) {
 	tenantId := ctx.Value(constants.TENANT_KEY)
 	output := make([]domain.GetSalesReturnItemOutput, 0)
@@ -572,12 +884,25 @@ func (r *salesRepository) getSalesReturnItems(ctx context.Context, salesReturnId
 	return output, nil
 }
 
+// ChangePaymentStatus altera o status de uma parcela que não foi quitada,
+// limpando a data de pagamento e os encargos por atraso. Parcelas com
+// recebimentos não são alteradas, para que o caixa e as comissões continuem
+// refletindo o dinheiro recebido.
 func (r *salesRepository) ChangePaymentStatus(ctx context.Context, id int64, status domain.PaymentStatus) (int64, error) {
 	tenantId := ctx.Value(constants.TENANT_KEY)
 	var insertedId int64
-	query := `UPDATE payment_dates SET status = $1 WHERE id = $2 AND tenant_id = $3 RETURNING id`
+	query := `UPDATE payment_dates SET status = $1, paid_date = NULL, fine_value = 0, interest_value = 0
+	WHERE id = $2 AND tenant_id = $3
+	  AND NOT EXISTS (
+		SELECT 1 FROM payment_receipts pr
+		WHERE pr.payment_date_id = payment_dates.id AND pr.tenant_id = payment_dates.tenant_id
+	  )
+	RETURNING id`
 	err := r.db.QueryRowContext(ctx, query, status, id, tenantId).Scan(&insertedId)
 	if err != nil {
+		if errors.IsNoRowsFinded(err) {
+			return insertedId, domain.ErrPaymentDateHasReceipts
+		}
 		return insertedId, err
 	}
 	return insertedId, nil
@@ -611,13 +936,44 @@ func (r *salesRepository) GetPaymentDatesBySaleIdAndPaymentDateId(ctx context.Co
 	return output, nil
 }
 
-func (r *salesRepository) ChangePaymentDate(ctx context.Context, id int64, date *time.Time) (int64, error) {
+func (r *salesRepository) CreatePaymentReceipt(ctx context.Context, tx *sql.Tx, receipt domain.PaymentReceipt) (int64, error) {
 	tenantId := ctx.Value(constants.TENANT_KEY)
 	var insertedId int64
-	query := `UPDATE payment_dates SET paid_date = $1 WHERE id = $2 AND tenant_id = $3 RETURNING id`
-	err := r.db.QueryRowContext(ctx, query, date, id, tenantId).Scan(&insertedId)
+	query := `INSERT INTO payment_receipts (payment_date_id, value, receipt_date, payment_type, received_by_user_id, tenant_id) VALUES ($1, $2, $3, $4, $5, $6) RETURNING id`
+	err := tx.QueryRowContext(ctx, query, receipt.PaymentDateId, receipt.Value, receipt.ReceiptDate, receipt.PaymentType, receipt.ReceivedByUserId, tenantId).Scan(&insertedId)
 	if err != nil {
 		return insertedId, err
 	}
 	return insertedId, nil
 }
+
+func (r *salesRepository) SettlePaymentDate(ctx context.Context, tx *sql.Tx, paymentDateId int64, paidDate time.Time, lateFee domain.LateFee) error {
+	tenantId := ctx.Value(constants.TENANT_KEY)
+	query := `UPDATE payment_dates SET status = 'PAID', paid_date = $1, fine_value = $2, interest_value = $3 WHERE id = $4 AND tenant_id = $5`
+	_, err := tx.ExecContext(ctx, query, paidDate, lateFee.FineValue, lateFee.InterestValue, paymentDateId, tenantId)
+	return err
+}
+
+func (r *salesRepository) GetPaymentReceiptsByPaymentDateId(ctx context.Context, paymentDateId int64) ([]domain.PaymentReceipt, error) {
+	tenantId := ctx.Value(constants.TENANT_KEY)
+	receipts := make([]domain.PaymentReceipt, 0)
+	query := `
+	SELECT pr.id, pr.payment_date_id, pr.value, pr.receipt_date, pr.payment_type, pr.received_by_user_id, u.name
+	FROM payment_receipts pr
+	JOIN users u ON u.id = pr.received_by_user_id
+	WHERE pr.payment_date_id = $1 AND pr.tenant_id = $2
+	ORDER BY pr.receipt_date ASC, pr.id ASC`
+	rows, err := r.db.QueryContext(ctx, query, paymentDateId, tenantId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var receipt domain.PaymentReceipt
+		if err := rows.Scan(&receipt.Id, &receipt.PaymentDateId, &receipt.Value, &receipt.ReceiptDate, &receipt.PaymentType, &receipt.ReceivedByUserId, &receipt.ReceivedBy); err != nil {
+			return nil, err
+		}
+		receipts = append(receipts, receipt)
+	}
+	return receipts, nil
+}
@@ -2,8 +2,10 @@ package repository
 
 import (
 	"context"
+	"database/sql/driver"
 	"testing"
 
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
 	"github.com/bncunha/erp-api/src/application/constants"
 	"github.com/bncunha/erp-api/src/domain"
 )
@@ -20,3 +22,114 @@ func TestCreateManySaleItemWithEmptySlice(t *testing.T) {
 		t.Fatalf("expected no ids, got %v", ids)
 	}
 }
+
+func TestGetSalesOrdersAndPaginates(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock: %v", err)
+	}
+	defer db.Close()
+
+	repo := NewSalesRepository(db)
+	ctx := context.WithValue(context.Background(), constants.TENANT_KEY, int64(1))
+	search := " V-10 "
+	args := []driver.Value{int64(1), nil, nil, nil, nil, nil, "%V-10%", nil, nil, nil, nil, nil, nil, nil, int64(20), int64(40)}
+	mock.ExpectQuery(`ORDER BY customer ASC, id ASC\s+LIMIT \$15 OFFSET \$16`).
+		WithArgs(args...).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "code", "date", "customer", "seller", "total_value", "received_value", "future_revenue", "total_items", "summary_status"}).
+			AddRow(1, "V-10", "2026-10-01", "Maria", "Ana", 100.0, 50.0, 50.0, 2.0, "PENDING"))
+
+	sales, err := repo.GetSales(ctx, domain.GetSalesInput{Search: &search, Sort: domain.SalesSortCustomer, Limit: 20, Offset: 40})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(sales) != 1 || sales[0].Code != "V-10" || sales[0].CustomerName != "Maria" {
+		t.Fatalf("unexpected sales: %+v", sales)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet expectations: %v", err)
+	}
+}
+
+func TestGetSalesTotalsFilters(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock: %v", err)
+	}
+	defer db.Close()
+
+	repo := NewSalesRepository(db)
+	ctx := context.WithValue(context.Background(), constants.TENANT_KEY, int64(1))
+	hasReturns := true
+	minValue, maxValue := 10.0, 100.0
+	args := []driver.Value{int64(1), nil, nil, nil, nil, nil, nil, "{2}", "{3}", "{4}", `{"PIX"}`, true, 10.0, 100.0}
+	mock.ExpectQuery(`FROM sales_list`).
+		WithArgs(args...).
+		WillReturnRows(sqlmock.NewRows([]string{"count", "total_items", "total_value", "received_value", "future_revenue"}).AddRow(2, 3.0, 150.0, 100.0, 50.0))
+
+	totals, err := repo.GetSalesTotals(ctx, domain.GetSalesInput{
+		ProductId:   []int64{2},
+		SkuId:       []int64{3},
+		CategoryId:  []int64{4},
+		PaymentType: []domain.PaymentType{domain.PaymentTypePix},
+		HasReturns:  &hasReturns,
+		MinValue:    &minValue,
+		MaxValue:    &maxValue,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if totals.TotalSales != 2 || totals.TotalValue != 150 {
+		t.Fatalf("unexpected totals: %+v", totals)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet expectations: %v", err)
+	}
+}
+
+func TestUpdateSaleDiscountAmount(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock: %v", err)
+	}
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`UPDATE sales SET discount_amount = \$1 WHERE id = \$2 AND tenant_id = \$3`).
+		WithArgs(2.5, int64(10), int64(1)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	tx, err := db.Begin()
+	if err != nil {
+		t.Fatalf("failed to begin tx: %v", err)
+	}
+	repo := NewSalesRepository(db)
+	ctx := context.WithValue(context.Background(), constants.TENANT_KEY, int64(1))
+	if err := repo.UpdateSaleDiscountAmount(ctx, tx, 10, 2.5); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet expectations: %v", err)
+	}
+}
+
+func TestChangePaymentStatusRejectsInstallmentsWithReceipts(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock: %v", err)
+	}
+	defer db.Close()
+
+	repo := NewSalesRepository(db)
+	ctx := context.WithValue(context.Background(), constants.TENANT_KEY, int64(1))
+	mock.ExpectQuery(`UPDATE payment_dates SET status = \$1[\s\S]*NOT EXISTS \([\s\S]*FROM payment_receipts pr`).
+		WithArgs(domain.PaymentStatusPending, int64(5), int64(1)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	if _, err := repo.ChangePaymentStatus(ctx, 5, domain.PaymentStatusPending); err != domain.ErrPaymentDateHasReceipts {
+		t.Fatalf("expected receipts error, got %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet expectations: %v", err)
+	}
+}
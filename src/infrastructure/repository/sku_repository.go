@@ -104,6 +104,13 @@ func (r *skuRepository) Update(ctx context.Context, sku domain.Sku) error {
 	return err
 }
 
+func (r *skuRepository) UpdateCost(ctx context.Context, tx *sql.Tx, skuId int64, cost float64) error {
+	tenantId := ctx.Value(constants.TENANT_KEY)
+	query := `UPDATE skus SET cost = $1 WHERE id = $2 AND tenant_id = $3`
+	_, err := tx.ExecContext(ctx, query, cost, skuId, tenantId)
+	return err
+}
+
 func (r *skuRepository) GetById(ctx context.Context, id int64) (domain.Sku, error) {
 	tenantId := ctx.Value(constants.TENANT_KEY)
 	var sku domain.Sku
@@ -122,6 +129,26 @@ func (r *skuRepository) GetById(ctx context.Context, id int64) (domain.Sku, erro
 	return sku, nil
 }
 
+func (r *skuRepository) GetByCode(ctx context.Context, code string) (domain.Sku, error) {
+	tenantId := ctx.Value(constants.TENANT_KEY)
+	var sku domain.Sku
+
+	query := `SELECT s.id, s.code, s.color, s.size, s.cost, s.price, p.name
+	FROM skus s
+	INNER JOIN products p ON p.id = s.product_id
+	WHERE s.code = $1 AND s.tenant_id = $2 AND s.deleted_at IS NULL
+	ORDER BY s.id
+	LIMIT 1`
+	err := r.db.QueryRowContext(ctx, query, code, tenantId).Scan(&sku.Id, &sku.Code, &sku.Color, &sku.Size, &sku.Cost, &sku.Price, &sku.Product.Name)
+	if err != nil {
+		if errors.IsNoRowsFinded(err) {
+			return sku, errors.New("SKU não encontrada para o código " + code)
+		}
+		return sku, err
+	}
+	return sku, nil
+}
+
 func (r *skuRepository) GetByManyIds(ctx context.Context, ids []int64) ([]domain.Sku, error) {
 	tenantId := ctx.Value(constants.TENANT_KEY)
 	var skus []domain.Sku
@@ -0,0 +1,171 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/bncunha/erp-api/src/application/constants"
+	"github.com/bncunha/erp-api/src/application/errors"
+	"github.com/bncunha/erp-api/src/domain"
+)
+
+type stockCountRepository struct {
+	db *sql.DB
+}
+
+func NewStockCountRepository(db *sql.DB) domain.StockCountRepository {
+	return &stockCountRepository{db}
+}
+
+func (r *stockCountRepository) Create(ctx context.Context, tx *sql.Tx, count domain.StockCount, openedByUserId int64) (int64, error) {
+	tenantId := ctx.Value(constants.TENANT_KEY)
+	var id int64
+
+	query := `
+	INSERT INTO stock_counts (code, inventory_id, status, notes, opened_by_user_id, opened_at, tenant_id)
+	VALUES ($1, $2, $3, NULLIF($4, ''), $5, $6, $7)
+	RETURNING id`
+	err := tx.QueryRowContext(ctx, query, count.Code, count.InventoryId, count.Status, count.Notes, openedByUserId, count.OpenedAt, tenantId).Scan(&id)
+	if err != nil {
+		if errors.IsUniqueViolation(err) {
+			return 0, domain.ErrStockCountAlreadyOpen
+		}
+		return 0, err
+	}
+	if len(count.Items) == 0 {
+		return id, nil
+	}
+
+	query = `INSERT INTO stock_count_items (stock_count_id, sku_id, expected_quantity, tenant_id) VALUES %s`
+	valueStrings := make([]string, 0, len(count.Items))
+	valueArgs := make([]interface{}, 0, len(count.Items)*4)
+	for i, item := range count.Items {
+		n := i * 4
+		valueStrings = append(valueStrings, fmt.Sprintf("($%d,$%d,$%d,$%d)", n+1, n+2, n+3, n+4))
+		valueArgs = append(valueArgs, id, item.Sku.Id, item.ExpectedQuantity, tenantId)
+	}
+	_, err = tx.ExecContext(ctx, fmt.Sprintf(query, strings.Join(valueStrings, ",")), valueArgs...)
+	return id, err
+}
+
+func (r *stockCountRepository) GetById(ctx context.Context, id int64) (domain.StockCount, error) {
+	tenantId := ctx.Value(constants.TENANT_KEY)
+	var count domain.StockCount
+
+	query := `
+	SELECT sc.id, sc.code, sc.inventory_id, i.type, u.name, sc.status, COALESCE(sc.notes, ''), sc.opened_at, sc.posted_at
+	FROM stock_counts sc
+	JOIN inventories i ON i.id = sc.inventory_id
+	LEFT JOIN users u ON u.id = i.user_id
+	WHERE sc.id = $1 AND sc.tenant_id = $2`
+	err := r.db.QueryRowContext(ctx, query, id, tenantId).Scan(&count.Id, &count.Code, &count.InventoryId, &count.InventoryType, &count.UserName, &count.Status, &count.Notes, &count.OpenedAt, &count.PostedAt)
+	if err != nil {
+		if errors.IsNoRowsFinded(err) {
+			return count, domain.ErrStockCountNotFound
+		}
+		return count, err
+	}
+
+	count.Items = make([]domain.StockCountItem, 0)
+	query = `
+	SELECT sku.id, sku.code, COALESCE(sku.color, ''), COALESCE(sku.size, ''), sku.price, p.name, sci.expected_quantity, sci.counted_quantity
+	FROM stock_count_items sci
+	JOIN skus sku ON sku.id = sci.sku_id
+	JOIN products p ON p.id = sku.product_id
+	WHERE sci.stock_count_id = $1 AND sci.tenant_id = $2
+	ORDER BY p.name ASC, sku.code ASC`
+	rows, err := r.db.QueryContext(ctx, query, id, tenantId)
+	if err != nil {
+		return count, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var item domain.StockCountItem
+		var countedQuantity sql.NullFloat64
+		if err := rows.Scan(&item.Sku.Id, &item.Sku.Code, &item.Sku.Color, &item.Sku.Size, &item.Sku.Price, &item.Sku.Product.Name, &item.ExpectedQuantity, &countedQuantity); err != nil {
+			return count, err
+		}
+		if countedQuantity.Valid {
+			item.CountedQuantity = &countedQuantity.Float64
+		}
+		count.Items = append(count.Items, item)
+	}
+	return count, nil
+}
+
+func (r *stockCountRepository) GetAll(ctx context.Context, input domain.GetStockCountsInput) ([]domain.GetStockCountsOutput, error) {
+	tenantId := ctx.Value(constants.TENANT_KEY)
+	counts := make([]domain.GetStockCountsOutput, 0)
+
+	query := `
+	SELECT sc.id, sc.code, sc.inventory_id, i.type, u.name, sc.status, sc.opened_at, sc.posted_at,
+		COUNT(sci.id),
+		COUNT(sci.counted_quantity),
+		COUNT(sci.id) FILTER (WHERE sci.counted_quantity IS NOT NULL AND sci.counted_quantity <> sci.expected_quantity)
+	FROM stock_counts sc
+	JOIN inventories i ON i.id = sc.inventory_id
+	LEFT JOIN users u ON u.id = i.user_id
+	LEFT JOIN stock_count_items sci ON sci.stock_count_id = sc.id
+	WHERE sc.tenant_id = $1
+		AND ($2::bigint IS NULL OR sc.inventory_id = $2)
+		AND ($3::varchar IS NULL OR sc.status = $3)
+	GROUP BY sc.id, i.type, u.name
+	ORDER BY sc.opened_at DESC, sc.id DESC`
+	rows, err := r.db.QueryContext(ctx, query, tenantId, input.InventoryId, input.Status)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var count domain.GetStockCountsOutput
+		if err := rows.Scan(&count.Id, &count.Code, &count.InventoryId, &count.InventoryType, &count.UserName, &count.Status, &count.OpenedAt, &count.PostedAt,
+			&count.TotalItems, &count.CountedItems, &count.VarianceItems); err != nil {
+			return nil, err
+		}
+		counts = append(counts, count)
+	}
+	return counts, nil
+}
+
+// SaveItems grava as quantidades contadas. SKUs que não estavam no estoque ao
+// abrir a contagem são incluídos com saldo esperado 0.
+func (r *stockCountRepository) SaveItems(ctx context.Context, countId int64, items []domain.StockCountItem) error {
+	if len(items) == 0 {
+		return nil
+	}
+	tenantId := ctx.Value(constants.TENANT_KEY)
+
+	query := `
+	INSERT INTO stock_count_items (stock_count_id, sku_id, expected_quantity, counted_quantity, tenant_id) VALUES %s
+	ON CONFLICT (stock_count_id, sku_id) DO UPDATE SET
+		counted_quantity = EXCLUDED.counted_quantity,
+		updated_at = NOW()`
+	valueStrings := make([]string, 0, len(items))
+	valueArgs := make([]interface{}, 0, len(items)*5)
+	for i, item := range items {
+		n := i * 5
+		valueStrings = append(valueStrings, fmt.Sprintf("($%d,$%d,$%d,$%d,$%d)", n+1, n+2, n+3, n+4, n+5))
+		valueArgs = append(valueArgs, countId, item.Sku.Id, item.ExpectedQuantity, item.CountedQuantity, tenantId)
+	}
+	_, err := r.db.ExecContext(ctx, fmt.Sprintf(query, strings.Join(valueStrings, ",")), valueArgs...)
+	return err
+}
+
+func (r *stockCountRepository) UpdateStatus(ctx context.Context, tx *sql.Tx, count domain.StockCount) error {
+	tenantId := ctx.Value(constants.TENANT_KEY)
+	query := `UPDATE stock_counts SET status = $1, posted_at = $2 WHERE id = $3 AND tenant_id = $4 AND status = 'OPEN'`
+	result, err := tx.ExecContext(ctx, query, count.Status, count.PostedAt, count.Id, tenantId)
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return domain.ErrStockCountNotOpen
+	}
+	return nil
+}
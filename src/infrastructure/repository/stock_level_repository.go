@@ -0,0 +1,134 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/bncunha/erp-api/src/application/constants"
+	"github.com/bncunha/erp-api/src/domain"
+)
+
+type stockLevelRepository struct {
+	db *sql.DB
+}
+
+func NewStockLevelRepository(db *sql.DB) domain.StockLevelRepository {
+	return &stockLevelRepository{db}
+}
+
+func (r *stockLevelRepository) GetAll(ctx context.Context, input domain.GetStockLevelsInput) ([]domain.StockLevel, error) {
+	tenantId := ctx.Value(constants.TENANT_KEY)
+	levels := make([]domain.StockLevel, 0)
+
+	query := `
+	SELECT sl.id, sl.inventory_id, inv.type, u.name, sku.id, sku.code, COALESCE(sku.color, ''), COALESCE(sku.size, ''), p.name, sl.min_quantity, sl.max_quantity
+	FROM stock_levels sl
+	JOIN inventories inv ON inv.id = sl.inventory_id
+	LEFT JOIN users u ON u.id = inv.user_id
+	JOIN skus sku ON sku.id = sl.sku_id
+	JOIN products p ON p.id = sku.product_id
+	WHERE sl.tenant_id = $1
+	  AND ($2::bigint IS NULL OR sl.inventory_id = $2)
+	  AND ($3::bigint IS NULL OR sl.sku_id = $3)
+	ORDER BY inv.type ASC, u.name ASC NULLS FIRST, p.name ASC, sku.code ASC`
+	rows, err := r.db.QueryContext(ctx, query, tenantId, input.InventoryId, input.SkuId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var level domain.StockLevel
+		if err := rows.Scan(&level.Id, &level.InventoryId, &level.InventoryType, &level.UserName, &level.Sku.Id, &level.Sku.Code, &level.Sku.Color, &level.Sku.Size, &level.Sku.Product.Name, &level.MinQuantity, &level.MaxQuantity); err != nil {
+			return nil, err
+		}
+		levels = append(levels, level)
+	}
+	return levels, nil
+}
+
+// Save cadastra o nível ou atualiza o já existente para o mesmo estoque e SKU.
+func (r *stockLevelRepository) Save(ctx context.Context, level domain.StockLevel) (int64, error) {
+	tenantId := ctx.Value(constants.TENANT_KEY)
+	var id int64
+	query := `
+	INSERT INTO stock_levels (inventory_id, sku_id, min_quantity, max_quantity, tenant_id)
+	VALUES ($1, $2, $3, $4, $5)
+	ON CONFLICT (tenant_id, inventory_id, sku_id) DO UPDATE SET
+		min_quantity = EXCLUDED.min_quantity,
+		max_quantity = EXCLUDED.max_quantity,
+		updated_at = NOW()
+	RETURNING id`
+	err := r.db.QueryRowContext(ctx, query, level.InventoryId, level.Sku.Id, level.MinQuantity, level.MaxQuantity, tenantId).Scan(&id)
+	return id, err
+}
+
+func (r *stockLevelRepository) Delete(ctx context.Context, id int64) error {
+	tenantId := ctx.Value(constants.TENANT_KEY)
+	query := `DELETE FROM stock_levels WHERE id = $1 AND tenant_id = $2`
+	result, err := r.db.ExecContext(ctx, query, id, tenantId)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return domain.ErrStockLevelNotFound
+	}
+	return nil
+}
+
+// GetReorderCandidates retorna os itens com nível configurado e a quantidade
+// vendida desde salesSince. As vendas contam para o estoque do vendedor ou,
+// quando ele não tem estoque próprio, para o estoque principal.
+func (r *stockLevelRepository) GetReorderCandidates(ctx context.Context, salesSince time.Time) ([]domain.StockReorderCandidate, error) {
+	tenantId := ctx.Value(constants.TENANT_KEY)
+	candidates := make([]domain.StockReorderCandidate, 0)
+
+	query := `
+	WITH primary_inventory AS (
+		SELECT id FROM inventories WHERE tenant_id = $1 AND type = 'PRIMARY' LIMIT 1
+	),
+	sold AS (
+		SELECT COALESCE(seller_inv.id, (SELECT id FROM primary_inventory)) AS inventory_id, si.sku_id, SUM(si.quantity) AS quantity
+		FROM sales s
+		JOIN sales_versions sv ON sv.sales_id = s.id AND sv.version = s.last_version AND sv.tenant_id = s.tenant_id
+		JOIN sales_items si ON si.sales_version_id = sv.id AND si.tenant_id = s.tenant_id
+		LEFT JOIN inventories seller_inv ON seller_inv.user_id = s.user_id AND seller_inv.tenant_id = s.tenant_id
+		WHERE s.tenant_id = $1 AND s.date >= $2
+		GROUP BY 1, si.sku_id
+	)
+	SELECT sl.id, sl.inventory_id, inv.type, u.name, sku.id, sku.code, COALESCE(sku.color, ''), COALESCE(sku.size, ''), p.name,
+		sl.min_quantity, sl.max_quantity,
+		COALESCE(ii.quantity, 0),
+		COALESCE((SELECT id FROM primary_inventory), 0),
+		COALESCE(primary_item.quantity, 0),
+		COALESCE(sold.quantity, 0)
+	FROM stock_levels sl
+	JOIN inventories inv ON inv.id = sl.inventory_id
+	LEFT JOIN users u ON u.id = inv.user_id
+	JOIN skus sku ON sku.id = sl.sku_id AND sku.deleted_at IS NULL
+	JOIN products p ON p.id = sku.product_id AND p.deleted_at IS NULL
+	LEFT JOIN inventory_items ii ON ii.inventory_id = sl.inventory_id AND ii.sku_id = sl.sku_id AND ii.deleted_at IS NULL
+	LEFT JOIN inventory_items primary_item ON primary_item.inventory_id = (SELECT id FROM primary_inventory) AND primary_item.sku_id = sl.sku_id AND primary_item.deleted_at IS NULL
+	LEFT JOIN sold ON sold.inventory_id = sl.inventory_id AND sold.sku_id = sl.sku_id
+	WHERE sl.tenant_id = $1
+	ORDER BY inv.type ASC, u.name ASC NULLS FIRST, p.name ASC, sku.code ASC`
+	rows, err := r.db.QueryContext(ctx, query, tenantId, salesSince)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var candidate domain.StockReorderCandidate
+		level := &candidate.Level
+		if err := rows.Scan(&level.Id, &level.InventoryId, &level.InventoryType, &level.UserName, &level.Sku.Id, &level.Sku.Code, &level.Sku.Color, &level.Sku.Size, &level.Sku.Product.Name,
+			&level.MinQuantity, &level.MaxQuantity, &candidate.Quantity, &candidate.PrimaryInventoryId, &candidate.PrimaryQuantity, &candidate.SoldQuantity); err != nil {
+			return nil, err
+		}
+		candidates = append(candidates, candidate)
+	}
+	return candidates, nil
+}
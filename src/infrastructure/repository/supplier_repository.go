@@ -0,0 +1,119 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/bncunha/erp-api/src/application/constants"
+	"github.com/bncunha/erp-api/src/application/errors"
+	"github.com/bncunha/erp-api/src/domain"
+)
+
+type supplierRepository struct {
+	db *sql.DB
+}
+
+func NewSupplierRepository(db *sql.DB) domain.SupplierRepository {
+	return &supplierRepository{db}
+}
+
+func (r *supplierRepository) Create(ctx context.Context, supplier domain.Supplier) (int64, error) {
+	tenantId := ctx.Value(constants.TENANT_KEY)
+	var id int64
+
+	query := `
+	INSERT INTO suppliers (name, cnpj, contact_name, phone_number, email, tenant_id)
+	VALUES ($1, NULLIF($2, ''), NULLIF($3, ''), NULLIF($4, ''), NULLIF($5, ''), $6)
+	RETURNING id`
+	err := r.db.QueryRowContext(ctx, query, supplier.Name, supplier.Cnpj, supplier.ContactName, supplier.PhoneNumber, supplier.Email, tenantId).Scan(&id)
+	if err != nil {
+		if errors.IsUniqueViolation(err) {
+			return 0, domain.ErrSupplierCnpjDuplicate
+		}
+		return 0, err
+	}
+	return id, nil
+}
+
+func (r *supplierRepository) Edit(ctx context.Context, supplier domain.Supplier) error {
+	tenantId := ctx.Value(constants.TENANT_KEY)
+
+	query := `
+	UPDATE suppliers SET name = $1, cnpj = NULLIF($2, ''), contact_name = NULLIF($3, ''), phone_number = NULLIF($4, ''), email = NULLIF($5, '')
+	WHERE id = $6 AND tenant_id = $7 AND deleted_at IS NULL`
+	result, err := r.db.ExecContext(ctx, query, supplier.Name, supplier.Cnpj, supplier.ContactName, supplier.PhoneNumber, supplier.Email, supplier.Id, tenantId)
+	if err != nil {
+		if errors.IsUniqueViolation(err) {
+			return domain.ErrSupplierCnpjDuplicate
+		}
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return domain.ErrSupplierNotFound
+	}
+	return nil
+}
+
+func (r *supplierRepository) GetById(ctx context.Context, id int64) (domain.Supplier, error) {
+	tenantId := ctx.Value(constants.TENANT_KEY)
+	var supplier domain.Supplier
+
+	query := `
+	SELECT id, name, COALESCE(cnpj, ''), COALESCE(contact_name, ''), COALESCE(phone_number, ''), COALESCE(email, '')
+	FROM suppliers
+	WHERE id = $1 AND tenant_id = $2 AND deleted_at IS NULL`
+	err := r.db.QueryRowContext(ctx, query, id, tenantId).Scan(&supplier.Id, &supplier.Name, &supplier.Cnpj, &supplier.ContactName, &supplier.PhoneNumber, &supplier.Email)
+	if err != nil {
+		if errors.IsNoRowsFinded(err) {
+			return supplier, domain.ErrSupplierNotFound
+		}
+		return supplier, err
+	}
+	return supplier, nil
+}
+
+func (r *supplierRepository) GetAll(ctx context.Context) ([]domain.Supplier, error) {
+	tenantId := ctx.Value(constants.TENANT_KEY)
+	suppliers := make([]domain.Supplier, 0)
+
+	query := `
+	SELECT id, name, COALESCE(cnpj, ''), COALESCE(contact_name, ''), COALESCE(phone_number, ''), COALESCE(email, '')
+	FROM suppliers
+	WHERE tenant_id = $1 AND deleted_at IS NULL
+	ORDER BY name ASC`
+	rows, err := r.db.QueryContext(ctx, query, tenantId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var supplier domain.Supplier
+		if err := rows.Scan(&supplier.Id, &supplier.Name, &supplier.Cnpj, &supplier.ContactName, &supplier.PhoneNumber, &supplier.Email); err != nil {
+			return nil, err
+		}
+		suppliers = append(suppliers, supplier)
+	}
+	return suppliers, nil
+}
+
+func (r *supplierRepository) Inactivate(ctx context.Context, id int64) error {
+	tenantId := ctx.Value(constants.TENANT_KEY)
+
+	query := `UPDATE suppliers SET deleted_at = NOW() WHERE id = $1 AND tenant_id = $2 AND deleted_at IS NULL`
+	result, err := r.db.ExecContext(ctx, query, id, tenantId)
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return domain.ErrSupplierNotFound
+	}
+	return nil
+}
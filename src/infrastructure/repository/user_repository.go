@@ -82,13 +82,26 @@ func (r *userRepository) Update(ctx context.Context, user domain.User) error {
 }
 
 func (r *userRepository) Inactivate(ctx context.Context, id int64) error {
+	return r.inactivate(ctx, r.db, nil, id)
+}
+
+func (r *userRepository) InactivateWithTx(ctx context.Context, tx *sql.Tx, id int64) error {
+	return r.inactivate(ctx, nil, tx, id)
+}
+
+func (r *userRepository) inactivate(ctx context.Context, db *sql.DB, tx *sql.Tx, id int64) error {
 	tenantId := ctx.Value(constants.TENANT_KEY)
-	query := `DELETE FROM users WHERE id = $1 AND tenant_id = $2`
-	result, err := r.db.ExecContext(ctx, query, id, tenantId)
+	query := `UPDATE users SET deleted_at = NOW() WHERE id = $1 AND tenant_id = $2 AND deleted_at IS NULL`
+	var result sql.Result
+	var err error
+	if tx != nil {
+		result, err = tx.ExecContext(ctx, query, id, tenantId)
+	} else if db != nil {
+		result, err = db.ExecContext(ctx, query, id, tenantId)
+	} else {
+		return errors.New("executor not provided")
+	}
 	if err != nil {
-		if errors.IsForeignKeyViolation(err) {
-			return errors.New("Não é possível deletar o usuário pois existem registros associados.")
-		}
 		return err
 	}
 
@@ -0,0 +1,49 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/bncunha/erp-api/src/application/constants"
+)
+
+func TestUserRepositoryInactivateKeepsResellerWithInventoryAndSales(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock: %v", err)
+	}
+	defer db.Close()
+
+	// O revendedor 3 é dono de um estoque e de vendas; o registro precisa
+	// continuar existindo para as chaves estrangeiras de inventories e sales.
+	mock.ExpectBegin()
+	mock.ExpectExec(`UPDATE users SET deleted_at = NOW\(\) WHERE id = \$1 AND tenant_id = \$2 AND deleted_at IS NULL`).
+		WithArgs(int64(3), int64(1)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`UPDATE users SET deleted_at = NOW\(\)`).
+		WithArgs(int64(3), int64(1)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(`SELECT id, username, name, phone_number, role, tenant_id, email FROM users WHERE id = \$1 AND tenant_id = \$2 AND deleted_at IS NULL`).
+		WithArgs(int64(3), int64(1)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "username", "name", "phone_number", "role", "tenant_id", "email"}))
+
+	tx, err := db.Begin()
+	if err != nil {
+		t.Fatalf("failed to begin tx: %v", err)
+	}
+	repo := NewUserRepository(db)
+	ctx := context.WithValue(context.Background(), constants.TENANT_KEY, int64(1))
+	if err := repo.InactivateWithTx(ctx, tx, 3); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := repo.InactivateWithTx(ctx, tx, 3); err == nil || err.Error() != "Usuário não encontrado" {
+		t.Fatalf("expected already inactive user not to be found, got %v", err)
+	}
+	if _, err := repo.GetById(ctx, 3); err == nil || err.Error() != "Usuário não encontrado" {
+		t.Fatalf("expected inactive user to be hidden, got %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet expectations: %v", err)
+	}
+}
@@ -52,6 +52,7 @@ func (r *userTokenRepository) GetLastActiveByUuid(ctx context.Context, uuid stri
 		FROM user_tokens ut
 		INNER JOIN users u ON u.id = ut.user_id
 		WHERE ut.uuid = $1
+			AND u.deleted_at IS NULL
 			AND ut.used_at IS NULL
 			AND ut.expires_at >= NOW()
 		ORDER BY ut.created_at DESC
@@ -0,0 +1,62 @@
+package scheduler
+
+import (
+	"context"
+	"time"
+
+	"github.com/bncunha/erp-api/src/infrastructure/logs"
+)
+
+type Job func(ctx context.Context) error
+
+type Scheduler struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+func NewScheduler() *Scheduler {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Scheduler{ctx: ctx, cancel: cancel}
+}
+
+// Daily executa o job ao iniciar e depois todos os dias no horário informado.
+func (s *Scheduler) Daily(name string, hour int, minute int, job Job) {
+	go func() {
+		s.run(name, job)
+		for {
+			timer := time.NewTimer(time.Until(nextRun(time.Now(), hour, minute)))
+			select {
+			case <-s.ctx.Done():
+				timer.Stop()
+				return
+			case <-timer.C:
+				s.run(name, job)
+			}
+		}
+	}()
+}
+
+func (s *Scheduler) Stop() {
+	s.cancel()
+}
+
+func (s *Scheduler) run(name string, job Job) {
+	defer func() {
+		if r := recover(); r != nil {
+			logs.Logger.Errorf("Job %s falhou: %v", name, r)
+		}
+	}()
+	if err := job(s.ctx); err != nil {
+		logs.Logger.Errorf("Job %s falhou: %v", name, err)
+		return
+	}
+	logs.Logger.Infof("Job %s executado", name)
+}
+
+func nextRun(now time.Time, hour int, minute int) time.Time {
+	next := time.Date(now.Year(), now.Month(), now.Day(), hour, minute, 0, 0, now.Location())
+	if !next.After(now) {
+		next = next.AddDate(0, 0, 1)
+	}
+	return next
+}
@@ -0,0 +1,16 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNextRun(t *testing.T) {
+	now := time.Date(2025, 3, 10, 0, 30, 0, 0, time.UTC)
+	if next := nextRun(now, 1, 0); !next.Equal(time.Date(2025, 3, 10, 1, 0, 0, 0, time.UTC)) {
+		t.Fatalf("expected run later today, got %v", next)
+	}
+	if next := nextRun(now, 0, 30); !next.Equal(time.Date(2025, 3, 11, 0, 30, 0, 0, time.UTC)) {
+		t.Fatalf("expected run tomorrow, got %v", next)
+	}
+}